@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -13,6 +14,7 @@ import (
 	appmiddleware "github.com/rakaarfi/digital-parenting-app-be/internal/middleware"
 	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
 	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/worker"
 	zlog "github.com/rs/zerolog/log"
 
 	// Import untuk Swagger/OpenAPI documentation
@@ -109,6 +111,26 @@ func main() {
 	userRewardRepo := repository.NewUserRewardRepository(dbPool, userRelRepo) // UserRewardRepo butuh UserRelRepo
 	pointRepo := repository.NewPointTransactionRepository(dbPool)
 	invitationCodeRepo := repository.NewInvitationCodeRepository(dbPool)
+	rotationRepo := repository.NewTaskRotationRepository(dbPool)
+	bountyRepo := repository.NewTaskBountyRepository(dbPool)
+	templateRepo := repository.NewTemplateRepository(dbPool)
+	autoApprovalRepo := repository.NewAutoApprovalPolicyRepository(dbPool)
+	auditRepo := repository.NewAuditLogRepository(dbPool)
+	savingsGoalRepo := repository.NewSavingsGoalRepository(dbPool)
+	notificationRepo := repository.NewNotificationRepository(dbPool)
+	rewardApprovalRepo := repository.NewRewardApprovalRepository(dbPool)
+	pointExpirationRepo := repository.NewPointExpirationRepository(dbPool)
+	interestRepo := repository.NewInterestRepository(dbPool)
+	allowanceRepo := repository.NewAllowanceRepository(dbPool)
+	pointTransferRepo := repository.NewPointTransferRepository(dbPool)
+	currencyRepo := repository.NewCurrencyRepository(dbPool)
+	cashOutRepo := repository.NewCashOutRepository(dbPool)
+	statementRepo := repository.NewStatementRepository(dbPool)
+	ledgerRepo := repository.NewLedgerRepository(dbPool)
+	penaltyRepo := repository.NewPenaltyRepository(dbPool)
+	badgeRepo := repository.NewBadgeRepository(dbPool)
+	streakRepo := repository.NewStreakRepository(dbPool)
+	levelRepo := repository.NewLevelRepository(dbPool)
 	zlog.Info().Msg("Repositories initialized successfully.")
 
 	// ====================================================================================
@@ -117,10 +139,27 @@ func main() {
 	// Membuat instance konkret dari setiap interface service.
 	// Setiap service di-inject dengan dependensi repository yang relevan.
 	authService := service.NewAuthService(userRepo, roleRepo)
-	taskService := service.NewTaskService(dbPool, userTaskRepo, pointRepo, userRelRepo)
-	rewardService := service.NewRewardService(dbPool, rewardRepo, userRewardRepo, pointRepo, userRelRepo)
+	badgeService := service.NewBadgeService(badgeRepo, streakRepo, pointRepo, taskRepo, userRepo, userRelRepo, notificationRepo) // Dipakai service yang menulis ledger
+	taskService := service.NewTaskService(dbPool, userTaskRepo, pointRepo, userRelRepo, autoApprovalRepo, auditRepo, penaltyRepo, notificationRepo, streakRepo, userRepo, levelRepo, badgeService)
+	rewardService := service.NewRewardService(dbPool, rewardRepo, userRewardRepo, pointRepo, userRelRepo, savingsGoalRepo, rewardApprovalRepo, badgeService)
 	userService := service.NewUserService(dbPool, userRepo, roleRepo, userRelRepo)
 	invitationService := service.NewInvitationService(dbPool, invitationCodeRepo, userRelRepo, userRepo)
+	rotationService := service.NewRotationService(dbPool, rotationRepo, taskRepo, userTaskRepo, userRelRepo)
+	bountyService := service.NewBountyService(dbPool, bountyRepo, taskRepo, userTaskRepo, userRelRepo)
+	templateService := service.NewTemplateService(dbPool, templateRepo, taskRepo, rewardRepo)
+	autoApprovalService := service.NewAutoApprovalService(autoApprovalRepo, taskRepo, userRelRepo, auditRepo)
+	savingsGoalService := service.NewSavingsGoalService(dbPool, savingsGoalRepo, rewardRepo, pointRepo, userRelRepo, notificationRepo, rewardService, badgeService)
+	pointExpirationService := service.NewPointExpirationService(dbPool, pointExpirationRepo, pointRepo, savingsGoalRepo, userRelRepo, notificationRepo)
+	interestService := service.NewInterestService(dbPool, interestRepo, pointRepo, userRelRepo, notificationRepo, badgeService)
+	allowanceService := service.NewAllowanceService(dbPool, allowanceRepo, pointRepo, userRepo, userRelRepo, notificationRepo, badgeService)
+	transferService := service.NewTransferService(dbPool, pointTransferRepo, pointRepo, savingsGoalRepo, userRelRepo, notificationRepo, badgeService)
+	currencyService := service.NewCurrencyService(dbPool, currencyRepo, pointRepo, savingsGoalRepo, userRelRepo, badgeService)
+	cashOutService := service.NewCashOutService(dbPool, cashOutRepo, pointRepo, savingsGoalRepo, userRepo, userRelRepo, notificationRepo, badgeService)
+	statementService := service.NewStatementService(statementRepo, currencyRepo, userRepo, userRelRepo)
+	ledgerService := service.NewLedgerService(dbPool, ledgerRepo, pointRepo, auditRepo, badgeService)
+	penaltyService := service.NewPenaltyService(dbPool, penaltyRepo, pointRepo, taskRepo, userTaskRepo, userRepo, userRelRepo, notificationRepo, auditRepo)
+	streakService := service.NewStreakService(dbPool, streakRepo, pointRepo, savingsGoalRepo, taskRepo, userRepo, userRelRepo, notificationRepo)
+	levelService := service.NewLevelService(levelRepo, userRelRepo) // Dipakai parent & child handler
 	zlog.Info().Msg("Services initialized successfully.")
 
 	// ====================================================================================
@@ -134,13 +173,52 @@ func main() {
 	parentHandler := handlers.NewParentHandler(
 		userRelRepo, taskRepo, userTaskRepo, rewardRepo, userRewardRepo,
 		pointRepo, userRepo, taskService, rewardService,
-		userService, invitationService, // Inject services
+		userService, invitationService, levelService, badgeService, // Inject services
 	)
 	childHandler := handlers.NewChildHandler(
-		userTaskRepo, rewardRepo, userRewardRepo, pointRepo, rewardService, // Inject services/repos
+		userTaskRepo, rewardRepo, userRewardRepo, pointRepo, rewardService, taskService, levelService, // Inject services/repos
 	)
+	rotationHandler := handlers.NewRotationHandler(rotationService)
+	bountyHandler := handlers.NewBountyHandler(bountyService)
+	templateHandler := handlers.NewTemplateHandler(templateService)
+	autoApprovalHandler := handlers.NewAutoApprovalHandler(autoApprovalService)
+	auditHandler := handlers.NewAuditHandler(auditRepo) // Audit log hanya baca, langsung pakai repo
+	savingsGoalHandler := handlers.NewSavingsGoalHandler(savingsGoalService)
+	notificationHandler := handlers.NewNotificationHandler(notificationRepo) // Notifikasi sederhana, langsung pakai repo
+	pointExpirationHandler := handlers.NewPointExpirationHandler(pointExpirationService)
+	interestHandler := handlers.NewInterestHandler(interestService)
+	allowanceHandler := handlers.NewAllowanceHandler(allowanceService)
+	transferHandler := handlers.NewTransferHandler(transferService)
+	currencyHandler := handlers.NewCurrencyHandler(currencyService)
+	cashOutHandler := handlers.NewCashOutHandler(cashOutService)
+	statementHandler := handlers.NewStatementHandler(statementService)
+	ledgerHandler := handlers.NewLedgerHandler(ledgerService)
+	penaltyHandler := handlers.NewPenaltyHandler(penaltyService)
+	badgeHandler := handlers.NewBadgeHandler(badgeService)
+	streakHandler := handlers.NewStreakHandler(streakService)
+	levelHandler := handlers.NewLevelHandler(levelService)
 	zlog.Info().Msg("Handlers initialized successfully.")
 
+	// ====================================================================================
+	// Langkah 5b: Start Background Worker (Scheduler)
+	// ====================================================================================
+	// Menjalankan job periodik (misal: rotasi tugas terjadwal) di goroutine terpisah.
+	// Context dibatalkan saat main selesai sehingga semua job ikut berhenti.
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	scheduler := worker.NewScheduler()
+	scheduler.Register(worker.NewRotationJob(rotationService))
+	scheduler.Register(worker.NewAutoApprovalJob(taskService))
+	scheduler.Register(worker.NewTaskExpiryJob(taskService))
+	scheduler.Register(worker.NewSavingsGoalJob(savingsGoalService))
+	scheduler.Register(worker.NewPointExpirationJob(pointExpirationService))
+	scheduler.Register(worker.NewInterestJob(interestService))
+	scheduler.Register(worker.NewAllowanceJob(allowanceService))
+	scheduler.Register(worker.NewBadgeJob(badgeService))
+	scheduler.Register(worker.NewStreakFreezeJob(streakService))
+	scheduler.Start(workerCtx)
+	zlog.Info().Msg("Background workers started.")
+
 	// ====================================================================================
 	// Langkah 6: Setup Aplikasi Web (Fiber)
 	// ====================================================================================
@@ -173,6 +251,25 @@ func main() {
 		userHandler,
 		parentHandler,
 		childHandler,
+		rotationHandler,
+		bountyHandler,
+		templateHandler,
+		autoApprovalHandler,
+		auditHandler,
+		savingsGoalHandler,
+		notificationHandler,
+		pointExpirationHandler,
+		interestHandler,
+		allowanceHandler,
+		transferHandler,
+		currencyHandler,
+		cashOutHandler,
+		statementHandler,
+		ledgerHandler,
+		penaltyHandler,
+		badgeHandler,
+		streakHandler,
+		levelHandler,
 	)
 	zlog.Info().Msg("API v1 routes registered successfully.")
 
@@ -0,0 +1,91 @@
+// cmd/reconcile/main.go
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rakaarfi/digital-parenting-app-be/configs"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/database"
+	applogger "github.com/rakaarfi/digital-parenting-app-be/internal/logger"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// main menghitung ulang saldo poin dari ledger (point_transactions) dan melaporkan
+// setiap anak yang saldo tersimpannya (point_balances) berbeda.
+//
+// Penggunaan:
+//
+//	go run cmd/reconcile/main.go         # hanya laporan
+//	go run cmd/reconcile/main.go -fix    # samakan saldo tersimpan dengan ledger
+//
+// Exit code 1 jika ada selisih yang belum diperbaiki, sehingga bisa dipakai di cron/CI.
+func main() {
+	os.Exit(run())
+}
+
+// run menjalankan rekonsiliasi dan mengembalikan exit code. Dipisah dari main agar semua defer
+// (penutupan pool & file log) tetap dijalankan sebelum os.Exit.
+func run() int {
+	fix := flag.Bool("fix", false, "Overwrite stored balances with the ledger totals")
+	timeout := flag.Duration("timeout", 5*time.Minute, "Maximum duration of the reconciliation")
+	flag.Parse()
+
+	configs.LoadConfig()
+	logCloser := applogger.SetupLogger()
+	if logCloser != nil {
+		defer logCloser.Close()
+	}
+
+	dbPool, err := database.NewPgxPool()
+	if err != nil {
+		zlog.Error().Err(err).Msg("Could not establish database connection pool")
+		return 1
+	}
+	defer dbPool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	pointRepo := repository.NewPointTransactionRepository(dbPool)
+	drifts, err := pointRepo.ReconcileBalances(ctx, *fix)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Point balance reconciliation failed")
+		return 1
+	}
+
+	if len(drifts) == 0 {
+		fmt.Println("Point balances match the ledger. No drift found.")
+		return 0
+	}
+
+	unresolved := 0
+	fmt.Printf("%-10s %-10s %15s %15s %10s %s\n", "USER_ID", "CURRENCY", "STORED", "LEDGER", "DRIFT", "STATUS")
+	for _, d := range drifts {
+		status := "drift"
+		switch {
+		case d.Fixed:
+			status = "fixed"
+		case d.LedgerBalance < 0:
+			status = "negative ledger (manual review needed)"
+			unresolved++
+		default:
+			unresolved++
+		}
+		currency := "points"
+		if d.CurrencyID != 0 {
+			currency = fmt.Sprintf("#%d", d.CurrencyID)
+		}
+		fmt.Printf("%-10d %-10s %15d %15d %10d %s\n", d.UserID, currency, d.StoredBalance, d.LedgerBalance, d.Drift, status)
+	}
+	fmt.Printf("%d account(s) with drift, %d unresolved.\n", len(drifts), unresolved)
+
+	if unresolved > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,276 @@
+// internal/api/v1/handlers/allowance_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// AllowanceHandler menangani endpoint rencana uang saku otomatis (Parent) dan riwayat pembayarannya (Parent & Child).
+type AllowanceHandler struct {
+	AllowanceService service.AllowanceService
+	Validate         *validator.Validate
+}
+
+// NewAllowanceHandler membuat instance baru dari AllowanceHandler.
+func NewAllowanceHandler(allowanceService service.AllowanceService) *AllowanceHandler {
+	return &AllowanceHandler{
+		AllowanceService: allowanceService,
+		Validate:         validator.New(),
+	}
+}
+
+// ==========================================================
+// --- Parent: Allowance Plan ---
+// ==========================================================
+
+// SetAllowancePlan godoc
+// @Summary Set Allowance Plan
+// @Description Creates or updates the child's automatic allowance. Every completed period (weekly, biweekly or monthly from start_date, in the child's timezone) pays `amount` points exactly once, provided at least min_approved_tasks tasks were approved during that period. A new start date (or cadence) must not be in the past.
+// @Tags Parent - Points
+// @Accept json
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Param plan_input body models.SetAllowancePlanInput true "Plan details"
+// @Success 200 {object} models.Response{data=models.AllowancePlan} "Plan saved"
+// @Failure 400 {object} models.Response "Invalid Child ID, validation failed or invalid start date"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/allowance-plan [put]
+func (h *AllowanceHandler) SetAllowancePlan(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	input := new(models.SetAllowancePlanInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	plan, err := h.AllowanceService.SetPlan(c.Context(), parentID, childID, input)
+	if err != nil {
+		return handleParentError(c, err, "SetAllowancePlan")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Allowance plan saved successfully", Data: plan})
+}
+
+// GetAllowancePlan godoc
+// @Summary Get Allowance Plan
+// @Description Retrieves the child's allowance plan, including the next period to be paid.
+// @Tags Parent - Points
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response{data=models.AllowancePlan} "Plan retrieved"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 404 {object} models.Response "No plan set for this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/allowance-plan [get]
+func (h *AllowanceHandler) GetAllowancePlan(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	plan, err := h.AllowanceService.GetPlan(c.Context(), parentID, childID)
+	if err != nil {
+		return handleParentError(c, err, "GetAllowancePlan")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Allowance plan retrieved successfully", Data: plan})
+}
+
+// DeleteAllowancePlan godoc
+// @Summary Delete Allowance Plan
+// @Description Removes the child's allowance plan. Payout history is kept.
+// @Tags Parent - Points
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response "Plan deleted"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 404 {object} models.Response "No plan set for this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/allowance-plan [delete]
+func (h *AllowanceHandler) DeleteAllowancePlan(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	if err := h.AllowanceService.DeletePlan(c.Context(), parentID, childID); err != nil {
+		return handleParentError(c, err, "DeleteAllowancePlan")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Allowance plan deleted successfully"})
+}
+
+// PauseAllowancePlan godoc
+// @Summary Pause Allowance Plan
+// @Description Pauses the child's allowance. Periods that pass while the plan is paused are not paid.
+// @Tags Parent - Points
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response{data=models.AllowancePlan} "Plan paused"
+// @Failure 400 {object} models.Response "Invalid Child ID or plan already paused"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 404 {object} models.Response "No plan set for this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/allowance-plan/pause [post]
+func (h *AllowanceHandler) PauseAllowancePlan(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	plan, err := h.AllowanceService.PausePlan(c.Context(), parentID, childID)
+	if err != nil {
+		return handleParentError(c, err, "PauseAllowancePlan")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Allowance plan paused successfully", Data: plan})
+}
+
+// ResumeAllowancePlan godoc
+// @Summary Resume Allowance Plan
+// @Description Resumes a paused allowance starting with the current period.
+// @Tags Parent - Points
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response{data=models.AllowancePlan} "Plan resumed"
+// @Failure 400 {object} models.Response "Invalid Child ID or plan not paused"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 404 {object} models.Response "No plan set for this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/allowance-plan/resume [post]
+func (h *AllowanceHandler) ResumeAllowancePlan(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	plan, err := h.AllowanceService.ResumePlan(c.Context(), parentID, childID, time.Now())
+	if err != nil {
+		return handleParentError(c, err, "ResumeAllowancePlan")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Allowance plan resumed successfully", Data: plan})
+}
+
+// GetChildAllowancePayouts godoc
+// @Summary Get Child's Allowance Payouts
+// @Description Retrieves the child's allowance payout history (newest period first), including skipped periods and why they were skipped.
+// @Tags Parent - Points
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponseGeneric "Payouts retrieved"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/allowance-payouts [get]
+func (h *AllowanceHandler) GetChildAllowancePayouts(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	pagination := utils.ParsePaginationParams(c)
+	payouts, totalCount, err := h.AllowanceService.GetPayoutsForParent(c.Context(), parentID, childID, pagination.Page, pagination.Limit)
+	if err != nil {
+		return handleParentError(c, err, "GetChildAllowancePayouts")
+	}
+
+	meta := utils.BuildPaginationMeta(totalCount, pagination.Limit, pagination.Page)
+	return c.Status(http.StatusOK).JSON(utils.NewPaginatedResponse("Allowance payouts retrieved successfully", payouts, meta))
+}
+
+// ==========================================================
+// --- Child: Allowance Payouts ---
+// ==========================================================
+
+// GetMyAllowancePayouts godoc
+// @Summary Get My Allowance Payouts
+// @Description Retrieves the child's own allowance payout history (newest period first).
+// @Tags Child - Points & Rewards
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponseGeneric "Payouts retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/allowance/payouts [get]
+func (h *AllowanceHandler) GetMyAllowancePayouts(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	pagination := utils.ParsePaginationParams(c)
+	payouts, totalCount, err := h.AllowanceService.GetMyPayouts(c.Context(), childID, pagination.Page, pagination.Limit)
+	if err != nil {
+		return handleChildError(c, err, "GetMyAllowancePayouts")
+	}
+
+	meta := utils.BuildPaginationMeta(totalCount, pagination.Limit, pagination.Page)
+	return c.Status(http.StatusOK).JSON(utils.NewPaginatedResponse("Allowance payouts retrieved successfully", payouts, meta))
+}
@@ -0,0 +1,67 @@
+// internal/api/v1/handlers/audit_handler.go
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// AuditHandler menangani endpoint jejak audit (Admin). Hanya baca, sehingga langsung memakai repository.
+type AuditHandler struct {
+	AuditRepo repository.AuditLogRepository
+	Validate  *validator.Validate
+}
+
+// NewAuditHandler membuat instance baru dari AuditHandler.
+func NewAuditHandler(auditRepo repository.AuditLogRepository) *AuditHandler {
+	return &AuditHandler{
+		AuditRepo: auditRepo,
+		Validate:  validator.New(),
+	}
+}
+
+// GetAuditLogs godoc
+// @Summary Get Audit Logs (Admin)
+// @Description Retrieves a paginated audit trail (newest first). System actions such as auto-approvals have actor_type "system" and no actor_user_id.
+// @Tags Admin - Audit
+// @Produce json
+// @Param entity_type query string false "Filter by entity type (e.g. user_task)"
+// @Param entity_id query int false "Filter by entity ID"
+// @Param actor_user_id query int false "Filter by acting user"
+// @Param actor_type query string false "Filter by actor type" Enums(user, system)
+// @Param action query string false "Filter by action (e.g. user_task.approved)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponseGeneric "Audit logs retrieved"
+// @Failure 400 {object} models.Response "Invalid query parameters"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (User is not an Admin)"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/audit-logs [get]
+func (h *AuditHandler) GetAuditLogs(c *fiber.Ctx) error {
+	filter := new(models.AuditLogFilter)
+	if err := c.QueryParser(filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid query parameters"})
+	}
+	if err := h.Validate.Struct(filter); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	pagination := utils.ParsePaginationParams(c)
+	logs, totalCount, err := h.AuditRepo.GetLogs(c.Context(), filter, pagination.Page, pagination.Limit)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to get audit logs")
+		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{Success: false, Message: "Failed to retrieve audit logs"})
+	}
+
+	meta := utils.BuildPaginationMeta(totalCount, pagination.Limit, pagination.Page)
+	return c.Status(http.StatusOK).JSON(utils.NewPaginatedResponse("Audit logs retrieved successfully", logs, meta))
+}
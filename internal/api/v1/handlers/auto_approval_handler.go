@@ -0,0 +1,168 @@
+// internal/api/v1/handlers/auto_approval_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// AutoApprovalHandler menangani endpoint kebijakan auto-approval submission tugas (Parent).
+type AutoApprovalHandler struct {
+	AutoApprovalService service.AutoApprovalService
+	Validate            *validator.Validate
+}
+
+// NewAutoApprovalHandler membuat instance baru dari AutoApprovalHandler.
+func NewAutoApprovalHandler(autoApprovalService service.AutoApprovalService) *AutoApprovalHandler {
+	return &AutoApprovalHandler{
+		AutoApprovalService: autoApprovalService,
+		Validate:            validator.New(),
+	}
+}
+
+// CreatePolicy godoc
+// @Summary Create Auto-Approval Policy
+// @Description Creates a policy that auto-approves submissions for a task, a child, or both — immediately (grace_period_minutes = 0) or after a grace period if no parent has rejected them first.
+// @Tags Parent - Auto-Approval
+// @Accept json
+// @Produce json
+// @Param policy_input body models.CreateAutoApprovalPolicyInput true "Policy scope and grace period"
+// @Success 201 {object} models.Response{data=map[string]int} "Policy created, returns policy_id"
+// @Failure 400 {object} models.Response "Invalid request body or validation failed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (No access to the task or child)"
+// @Failure 404 {object} models.Response "Task definition not found"
+// @Failure 409 {object} models.Response "A policy with the same scope already exists"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/auto-approval-policies [post]
+func (h *AutoApprovalHandler) CreatePolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	input := new(models.CreateAutoApprovalPolicyInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	policyID, err := h.AutoApprovalService.CreatePolicy(c.Context(), parentID, input)
+	if err != nil {
+		return handleParentError(c, err, "CreateAutoApprovalPolicy")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.Response{Success: true, Message: "Auto-approval policy created successfully", Data: fiber.Map{"policy_id": policyID}})
+}
+
+// GetMyPolicies godoc
+// @Summary Get My Auto-Approval Policies
+// @Description Retrieves all auto-approval policies created by the logged-in parent.
+// @Tags Parent - Auto-Approval
+// @Produce json
+// @Success 200 {object} models.Response{data=[]models.AutoApprovalPolicy} "Policies retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/auto-approval-policies [get]
+func (h *AutoApprovalHandler) GetMyPolicies(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	policies, err := h.AutoApprovalService.GetPoliciesForParent(c.Context(), parentID)
+	if err != nil {
+		return handleParentError(c, err, "GetMyAutoApprovalPolicies")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Auto-approval policies retrieved successfully", Data: policies})
+}
+
+// UpdatePolicy godoc
+// @Summary Update Auto-Approval Policy
+// @Description Changes the grace period and/or pauses or resumes an auto-approval policy.
+// @Tags Parent - Auto-Approval
+// @Accept json
+// @Produce json
+// @Param policyId path int true "Policy ID"
+// @Param policy_input body models.UpdateAutoApprovalPolicyInput true "Fields to update"
+// @Success 200 {object} models.Response "Policy updated"
+// @Failure 400 {object} models.Response "Invalid Policy ID, request body, or validation failed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden"
+// @Failure 404 {object} models.Response "Policy not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/auto-approval-policies/{policyId} [patch]
+func (h *AutoApprovalHandler) UpdatePolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	policyID, err := strconv.Atoi(c.Params("policyId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Policy ID parameter"})
+	}
+
+	input := new(models.UpdateAutoApprovalPolicyInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	if err := h.AutoApprovalService.UpdatePolicy(c.Context(), policyID, parentID, input); err != nil {
+		return handleParentError(c, err, "UpdateAutoApprovalPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Auto-approval policy updated successfully"})
+}
+
+// DeletePolicy godoc
+// @Summary Delete Auto-Approval Policy
+// @Description Deletes an auto-approval policy. Submissions already auto-approved are kept.
+// @Tags Parent - Auto-Approval
+// @Produce json
+// @Param policyId path int true "Policy ID"
+// @Success 200 {object} models.Response "Policy deleted"
+// @Failure 400 {object} models.Response "Invalid Policy ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden"
+// @Failure 404 {object} models.Response "Policy not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/auto-approval-policies/{policyId} [delete]
+func (h *AutoApprovalHandler) DeletePolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	policyID, err := strconv.Atoi(c.Params("policyId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Policy ID parameter"})
+	}
+
+	if err := h.AutoApprovalService.DeletePolicy(c.Context(), policyID, parentID); err != nil {
+		return handleParentError(c, err, "DeleteAutoApprovalPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Auto-approval policy deleted successfully"})
+}
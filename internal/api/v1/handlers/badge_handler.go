@@ -0,0 +1,277 @@
+// internal/api/v1/handlers/badge_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// BadgeHandler menangani endpoint badge custom & pencapaian anak (Parent) serta badge milik anak (Child).
+type BadgeHandler struct {
+	BadgeService service.BadgeService
+	Validate     *validator.Validate
+}
+
+// NewBadgeHandler membuat instance baru dari BadgeHandler.
+func NewBadgeHandler(badgeService service.BadgeService) *BadgeHandler {
+	return &BadgeHandler{
+		BadgeService: badgeService,
+		Validate:     validator.New(),
+	}
+}
+
+// ==========================================================
+// --- Parent: Badges ---
+// ==========================================================
+
+// GetBadges godoc
+// @Summary Get Badges
+// @Description Retrieves the built-in system badges (first task, 7-day streak, 100 points saved, 10 rewards claimed) and the parent's custom badges.
+// @Tags Parent - Badges
+// @Produce json
+// @Success 200 {object} models.Response{data=[]models.Badge} "Badges retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/badges [get]
+func (h *BadgeHandler) GetBadges(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	badges, err := h.BadgeService.GetBadges(c.Context(), parentID)
+	if err != nil {
+		return handleParentError(c, err, "GetBadges")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Badges retrieved successfully", Data: badges})
+}
+
+// CreateBadge godoc
+// @Summary Create Custom Badge
+// @Description Creates a custom badge for the parent's children. Rule-based badges (tasks_completed, task_streak, points_balance, rewards_claimed) are awarded automatically once the threshold is reached; task rules can target one of the parent's tasks via task_id. Manual badges have no threshold and are awarded by the parent.
+// @Tags Parent - Badges
+// @Accept json
+// @Produce json
+// @Param badge_input body models.BadgeInput true "Badge details"
+// @Success 201 {object} models.Response{data=models.Badge} "Badge created"
+// @Failure 400 {object} models.Response "Validation failed or invalid rule"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Task was not created by the parent"
+// @Failure 409 {object} models.Response "Badge with this name already exists"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/badges [post]
+func (h *BadgeHandler) CreateBadge(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	input := new(models.BadgeInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	badge, err := h.BadgeService.CreateBadge(c.Context(), parentID, input)
+	if err != nil {
+		return handleParentError(c, err, "CreateBadge")
+	}
+
+	return c.Status(http.StatusCreated).JSON(models.Response{Success: true, Message: "Badge created successfully", Data: badge})
+}
+
+// UpdateBadge godoc
+// @Summary Update Custom Badge
+// @Description Updates a custom badge. Children who already earned it keep it.
+// @Tags Parent - Badges
+// @Accept json
+// @Produce json
+// @Param badgeId path int true "Badge ID"
+// @Param badge_input body models.BadgeInput true "Badge details"
+// @Success 200 {object} models.Response{data=models.Badge} "Badge updated"
+// @Failure 400 {object} models.Response "Invalid Badge ID, validation failed or invalid rule"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "System badge or badge of another parent"
+// @Failure 404 {object} models.Response "Badge not found"
+// @Failure 409 {object} models.Response "Badge with this name already exists"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/badges/{badgeId} [put]
+func (h *BadgeHandler) UpdateBadge(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	badgeID, err := strconv.Atoi(c.Params("badgeId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Badge ID parameter"})
+	}
+
+	input := new(models.BadgeInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	badge, err := h.BadgeService.UpdateBadge(c.Context(), parentID, badgeID, input)
+	if err != nil {
+		return handleParentError(c, err, "UpdateBadge")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Badge updated successfully", Data: badge})
+}
+
+// DeleteBadge godoc
+// @Summary Delete Custom Badge
+// @Description Deletes a custom badge; it is removed from children who earned it.
+// @Tags Parent - Badges
+// @Produce json
+// @Param badgeId path int true "Badge ID"
+// @Success 200 {object} models.Response "Badge deleted"
+// @Failure 400 {object} models.Response "Invalid Badge ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "System badge or badge of another parent"
+// @Failure 404 {object} models.Response "Badge not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/badges/{badgeId} [delete]
+func (h *BadgeHandler) DeleteBadge(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	badgeID, err := strconv.Atoi(c.Params("badgeId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Badge ID parameter"})
+	}
+
+	if err := h.BadgeService.DeleteBadge(c.Context(), parentID, badgeID); err != nil {
+		return handleParentError(c, err, "DeleteBadge")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Badge deleted successfully"})
+}
+
+// ==========================================================
+// --- Parent: Child Badges ---
+// ==========================================================
+
+// GetChildBadges godoc
+// @Summary Get Child Badges
+// @Description Retrieves the badges a child has earned (newest first) with the time each was awarded.
+// @Tags Parent - Badges
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response{data=[]models.ChildBadge} "Badges retrieved"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Not the parent of this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/badges [get]
+func (h *BadgeHandler) GetChildBadges(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	badges, err := h.BadgeService.GetChildBadges(c.Context(), parentID, childID)
+	if err != nil {
+		return handleParentError(c, err, "GetChildBadges")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Child badges retrieved successfully", Data: badges})
+}
+
+// AwardBadge godoc
+// @Summary Award Manual Badge
+// @Description Awards one of the parent's manual badges to a child. Rule-based badges cannot be awarded by hand.
+// @Tags Parent - Badges
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Param badgeId path int true "Badge ID"
+// @Success 201 {object} models.Response{data=models.ChildBadge} "Badge awarded"
+// @Failure 400 {object} models.Response "Invalid ID or badge is not manual"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Not the parent of this child or not the owner of the badge"
+// @Failure 404 {object} models.Response "Badge not found"
+// @Failure 409 {object} models.Response "Child already has this badge"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/badges/{badgeId} [post]
+func (h *BadgeHandler) AwardBadge(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+	badgeID, err := strconv.Atoi(c.Params("badgeId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Badge ID parameter"})
+	}
+
+	award, err := h.BadgeService.AwardBadge(c.Context(), parentID, childID, badgeID)
+	if err != nil {
+		return handleParentError(c, err, "AwardBadge")
+	}
+
+	return c.Status(http.StatusCreated).JSON(models.Response{Success: true, Message: "Badge awarded successfully", Data: award})
+}
+
+// ==========================================================
+// --- Child: Badges ---
+// ==========================================================
+
+// GetMyBadges godoc
+// @Summary Get My Badges
+// @Description Retrieves the badges the child has earned (newest first) with the time each was awarded.
+// @Tags Child - Points & Rewards
+// @Produce json
+// @Success 200 {object} models.Response{data=[]models.ChildBadge} "Badges retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/badges [get]
+func (h *BadgeHandler) GetMyBadges(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	badges, err := h.BadgeService.GetMyBadges(c.Context(), childID)
+	if err != nil {
+		return handleChildError(c, err, "GetMyBadges")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Badges retrieved successfully", Data: badges})
+}
@@ -0,0 +1,199 @@
+// internal/api/v1/handlers/bounty_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// BountyHandler menangani endpoint bounty (tugas terbuka) untuk Parent dan Child.
+type BountyHandler struct {
+	BountyService service.BountyService
+	Validate      *validator.Validate
+}
+
+// NewBountyHandler membuat instance baru dari BountyHandler.
+func NewBountyHandler(bountyService service.BountyService) *BountyHandler {
+	return &BountyHandler{
+		BountyService: bountyService,
+		Validate:      validator.New(),
+	}
+}
+
+// ==========================================================
+// --- Parent: Bounty Management ---
+// ==========================================================
+
+// CreateBounty godoc
+// @Summary Post Task Bounty
+// @Description Posts a task definition as an open bounty that any of the parent's children can claim. Supports an optional claim limit and expiry.
+// @Tags Parent - Bounties
+// @Accept json
+// @Produce json
+// @Param bounty_input body models.CreateBountyInput true "Bounty details"
+// @Success 201 {object} models.Response{data=map[string]int} "Bounty created, returns bounty_id"
+// @Failure 400 {object} models.Response "Invalid request body, validation failed, or expiry in the past"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (No access to the task definition)"
+// @Failure 404 {object} models.Response "Task definition not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/bounties [post]
+func (h *BountyHandler) CreateBounty(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	input := new(models.CreateBountyInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	bountyID, err := h.BountyService.CreateBounty(c.Context(), parentID, input)
+	if err != nil {
+		return handleParentError(c, err, "CreateBounty")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.Response{Success: true, Message: "Bounty created successfully", Data: fiber.Map{"bounty_id": bountyID}})
+}
+
+// GetMyBounties godoc
+// @Summary Get My Task Bounties
+// @Description Retrieves a paginated list of bounties posted by the logged-in parent, including who claimed them.
+// @Tags Parent - Bounties
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponseGeneric "Bounties retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/bounties [get]
+func (h *BountyHandler) GetMyBounties(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	pagination := utils.ParsePaginationParams(c)
+	bounties, totalCount, err := h.BountyService.GetBountiesForParent(c.Context(), parentID, pagination.Page, pagination.Limit)
+	if err != nil {
+		return handleParentError(c, err, "GetMyBounties")
+	}
+
+	meta := utils.BuildPaginationMeta(totalCount, pagination.Limit, pagination.Page)
+	return c.Status(http.StatusOK).JSON(utils.NewPaginatedResponse("Bounties retrieved successfully", bounties, meta))
+}
+
+// CancelBounty godoc
+// @Summary Cancel Task Bounty
+// @Description Cancels an open bounty so no further children can claim it. Assignments from earlier claims are kept.
+// @Tags Parent - Bounties
+// @Produce json
+// @Param bountyId path int true "Bounty ID"
+// @Success 200 {object} models.Response "Bounty cancelled"
+// @Failure 400 {object} models.Response "Invalid Bounty ID or bounty is not open"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden"
+// @Failure 404 {object} models.Response "Bounty not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/bounties/{bountyId} [delete]
+func (h *BountyHandler) CancelBounty(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	bountyID, err := strconv.Atoi(c.Params("bountyId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Bounty ID parameter"})
+	}
+
+	if err := h.BountyService.CancelBounty(c.Context(), bountyID, parentID); err != nil {
+		return handleParentError(c, err, "CancelBounty")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Bounty cancelled successfully"})
+}
+
+// ==========================================================
+// --- Child: Open Bounties ---
+// ==========================================================
+
+// GetOpenBounties godoc
+// @Summary Get Open Bounties
+// @Description Retrieves bounties posted by the child's parents that are still open, not expired, and not yet claimed by this child.
+// @Tags Child - Bounties
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponseGeneric "Open bounties retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/bounties [get]
+func (h *BountyHandler) GetOpenBounties(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	pagination := utils.ParsePaginationParams(c)
+	bounties, totalCount, err := h.BountyService.GetOpenBountiesForChild(c.Context(), childID, pagination.Page, pagination.Limit)
+	if err != nil {
+		return handleChildError(c, err, "GetOpenBounties")
+	}
+
+	meta := utils.BuildPaginationMeta(totalCount, pagination.Limit, pagination.Page)
+	return c.Status(http.StatusOK).JSON(utils.NewPaginatedResponse("Open bounties retrieved successfully", bounties, meta))
+}
+
+// ClaimBounty godoc
+// @Summary Claim Bounty
+// @Description Claims an open bounty. The task is assigned to the child and follows the normal submit/verify flow.
+// @Tags Child - Bounties
+// @Produce json
+// @Param bountyId path int true "Bounty ID"
+// @Success 201 {object} models.Response{data=map[string]int} "Bounty claimed, returns user_task_id"
+// @Failure 400 {object} models.Response "Invalid Bounty ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Bounty not posted by your parent)"
+// @Failure 404 {object} models.Response "Bounty not found"
+// @Failure 409 {object} models.Response "Bounty no longer available or already claimed"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/bounties/{bountyId}/claim [post]
+func (h *BountyHandler) ClaimBounty(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	bountyID, err := strconv.Atoi(c.Params("bountyId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Bounty ID parameter"})
+	}
+
+	userTaskID, err := h.BountyService.ClaimBounty(c.Context(), childID, bountyID)
+	if err != nil {
+		return handleChildError(c, err, "ClaimBounty")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.Response{Success: true, Message: "Bounty claimed successfully", Data: fiber.Map{"user_task_id": userTaskID}})
+}
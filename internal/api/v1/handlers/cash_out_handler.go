@@ -0,0 +1,476 @@
+// internal/api/v1/handlers/cash_out_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// CashOutHandler menangani endpoint pencairan poin menjadi uang: kurs dan review (Parent),
+// permintaan cash-out (Child), serta laporan uang bulanan (keduanya).
+type CashOutHandler struct {
+	CashOutService service.CashOutService
+	Validate       *validator.Validate
+}
+
+// NewCashOutHandler membuat instance baru dari CashOutHandler.
+func NewCashOutHandler(cashOutService service.CashOutService) *CashOutHandler {
+	return &CashOutHandler{
+		CashOutService: cashOutService,
+		Validate:       validator.New(),
+	}
+}
+
+// isValidCashOutStatus memeriksa apakah string status valid untuk filter cash-out.
+func isValidCashOutStatus(status string) bool {
+	switch models.CashOutStatus(status) {
+	case models.CashOutStatusPending,
+		models.CashOutStatusApproved,
+		models.CashOutStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// isValidStatementMonth memeriksa apakah query month kosong (bulan berjalan) atau berformat YYYY-MM.
+func isValidStatementMonth(month string) bool {
+	if month == "" {
+		return true
+	}
+	_, err := time.Parse(models.StatementMonthLayout, month)
+	return err == nil
+}
+
+// ==========================================================
+// --- Parent: Cash-Out Policy ---
+// ==========================================================
+
+// SetFamilyCashOutPolicy godoc
+// @Summary Set Family Cash-Out Policy
+// @Description Sets the logged-in parent's points-to-money conversion rate for all of their children: every `points` points are worth `minor_units` of the currency's smallest unit (e.g. 10 points = 100 cents USD). Cash-out requests must be a multiple of `points` and at least `min_points`. Without any applicable policy a child cannot cash out. A child-specific policy from any of the child's parents takes precedence.
+// @Tags Parent - Points
+// @Accept json
+// @Produce json
+// @Param policy_input body models.SetCashOutPolicyInput true "Policy details"
+// @Success 200 {object} models.Response{data=models.CashOutPolicy} "Policy saved"
+// @Failure 400 {object} models.Response "Validation failed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/cash-out-policy [put]
+func (h *CashOutHandler) SetFamilyCashOutPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	return h.setCashOutPolicy(c, parentID, 0)
+}
+
+// GetFamilyCashOutPolicy godoc
+// @Summary Get Family Cash-Out Policy
+// @Description Retrieves the logged-in parent's cash-out conversion rate for all of their children.
+// @Tags Parent - Points
+// @Produce json
+// @Success 200 {object} models.Response{data=models.CashOutPolicy} "Policy retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 404 {object} models.Response "No family policy set"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/cash-out-policy [get]
+func (h *CashOutHandler) GetFamilyCashOutPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	policy, err := h.CashOutService.GetPolicy(c.Context(), parentID, 0)
+	if err != nil {
+		return handleParentError(c, err, "GetFamilyCashOutPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Cash-out policy retrieved successfully", Data: policy})
+}
+
+// DeleteFamilyCashOutPolicy godoc
+// @Summary Delete Family Cash-Out Policy
+// @Description Removes the logged-in parent's cash-out conversion rate for all of their children. Child-specific policies and policies of other parents are unaffected.
+// @Tags Parent - Points
+// @Produce json
+// @Success 200 {object} models.Response "Policy deleted"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 404 {object} models.Response "No family policy set"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/cash-out-policy [delete]
+func (h *CashOutHandler) DeleteFamilyCashOutPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	if err := h.CashOutService.DeletePolicy(c.Context(), parentID, 0); err != nil {
+		return handleParentError(c, err, "DeleteFamilyCashOutPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Cash-out policy deleted successfully"})
+}
+
+// SetCashOutPolicy godoc
+// @Summary Set Child Cash-Out Policy
+// @Description Creates or updates the logged-in parent's cash-out conversion rate for one child. It takes precedence over family policies (those set for all children) of any of the child's parents.
+// @Tags Parent - Points
+// @Accept json
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Param policy_input body models.SetCashOutPolicyInput true "Policy details"
+// @Success 200 {object} models.Response{data=models.CashOutPolicy} "Policy saved"
+// @Failure 400 {object} models.Response "Invalid Child ID or validation failed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/cash-out-policy [put]
+func (h *CashOutHandler) SetCashOutPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+	return h.setCashOutPolicy(c, parentID, childID)
+}
+
+// setCashOutPolicy memvalidasi input lalu menyimpan kurs pencairan parent untuk childID (0 = semua anak).
+func (h *CashOutHandler) setCashOutPolicy(c *fiber.Ctx, parentID int, childID int) error {
+	input := new(models.SetCashOutPolicyInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	policy, err := h.CashOutService.SetPolicy(c.Context(), parentID, childID, input)
+	if err != nil {
+		return handleParentError(c, err, "SetCashOutPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Cash-out policy saved successfully", Data: policy})
+}
+
+// GetCashOutPolicy godoc
+// @Summary Get Child Cash-Out Policy
+// @Description Retrieves the cash-out conversion rate that applies to the child: a child-specific policy from any of the child's parents, otherwise the oldest family policy of one of them. created_by_user_id and child_id show which policy applies.
+// @Tags Parent - Points
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response{data=models.CashOutPolicy} "Policy retrieved"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 404 {object} models.Response "No policy applies to this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/cash-out-policy [get]
+func (h *CashOutHandler) GetCashOutPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	policy, err := h.CashOutService.GetPolicy(c.Context(), parentID, childID)
+	if err != nil {
+		return handleParentError(c, err, "GetCashOutPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Cash-out policy retrieved successfully", Data: policy})
+}
+
+// DeleteCashOutPolicy godoc
+// @Summary Delete Child Cash-Out Policy
+// @Description Removes the logged-in parent's cash-out conversion rate for this child; family policies then apply again (without any, the child can no longer request cash-outs; pending requests keep the amount recorded when they were made). Policies set by other parents are unaffected.
+// @Tags Parent - Points
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response "Policy deleted"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 404 {object} models.Response "No policy of yours set for this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/cash-out-policy [delete]
+func (h *CashOutHandler) DeleteCashOutPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	if err := h.CashOutService.DeletePolicy(c.Context(), parentID, childID); err != nil {
+		return handleParentError(c, err, "DeleteCashOutPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Cash-out policy deleted successfully"})
+}
+
+// ==========================================================
+// --- Parent: Cash-Out Review & Statements ---
+// ==========================================================
+
+// GetCashOuts godoc
+// @Summary Get Children's Cash-Out Requests
+// @Description Retrieves cash-out requests of the parent's children (newest first), optionally filtered by status. Approved requests include their payout.
+// @Tags Parent - Points
+// @Produce json
+// @Param status query string false "Filter by status (pending, approved, rejected)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponseGeneric "Cash-out requests retrieved"
+// @Failure 400 {object} models.Response "Invalid status filter"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/cash-outs [get]
+func (h *CashOutHandler) GetCashOuts(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	statusFilter := c.Query("status")
+	if statusFilter != "" && !isValidCashOutStatus(statusFilter) {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
+			Success: false,
+			Message: fmt.Sprintf("Invalid status filter value: '%s'. Valid statuses are pending, approved, rejected.", statusFilter),
+		})
+	}
+
+	pagination := utils.ParsePaginationParams(c)
+	requests, totalCount, err := h.CashOutService.GetCashOutsForParent(c.Context(), parentID, models.CashOutStatus(statusFilter), pagination.Page, pagination.Limit)
+	if err != nil {
+		return handleParentError(c, err, "GetCashOuts")
+	}
+
+	meta := utils.BuildPaginationMeta(totalCount, pagination.Limit, pagination.Page)
+	return c.Status(http.StatusOK).JSON(utils.NewPaginatedResponse("Cash-out requests retrieved successfully", requests, meta))
+}
+
+// ReviewCashOut godoc
+// @Summary Review Cash-Out Request
+// @Description Approves or rejects a pending cash-out request. Approving records the payout in the money ledger as paid in cash or transferred (payout_method is required). Rejecting returns the points to the child.
+// @Tags Parent - Points
+// @Accept json
+// @Produce json
+// @Param cashOutId path int true "Cash-Out Request ID"
+// @Param review_input body models.ReviewCashOutInput true "Review details"
+// @Success 200 {object} models.Response{data=models.CashOutRequest} "Cash-out reviewed"
+// @Failure 400 {object} models.Response "Invalid ID, validation failed or request is not pending"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 404 {object} models.Response "Cash-out request not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/cash-outs/{cashOutId}/review [patch]
+func (h *CashOutHandler) ReviewCashOut(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	requestID, err := strconv.Atoi(c.Params("cashOutId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Cash-Out ID parameter"})
+	}
+
+	input := new(models.ReviewCashOutInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	request, err := h.CashOutService.ReviewCashOut(c.Context(), parentID, requestID, input)
+	if err != nil {
+		return handleParentError(c, err, "ReviewCashOut")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: fmt.Sprintf("Cash-out %s successfully", input.Status), Data: request})
+}
+
+// GetChildMoneyStatement godoc
+// @Summary Get Child's Monthly Money Statement
+// @Description Retrieves the money paid out to the child in a calendar month (in the child's timezone), with totals per currency in minor units.
+// @Tags Parent - Points
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Param month query string false "Month in YYYY-MM format (default: current month)"
+// @Success 200 {object} models.Response{data=models.MoneyStatement} "Statement retrieved"
+// @Failure 400 {object} models.Response "Invalid Child ID or month"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/money-statements [get]
+func (h *CashOutHandler) GetChildMoneyStatement(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+	month := c.Query("month")
+	if !isValidStatementMonth(month) {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid month parameter, expected YYYY-MM"})
+	}
+
+	statement, err := h.CashOutService.GetChildStatement(c.Context(), parentID, childID, month)
+	if err != nil {
+		return handleParentError(c, err, "GetChildMoneyStatement")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Money statement retrieved successfully", Data: statement})
+}
+
+// ==========================================================
+// --- Child: Cash-Outs ---
+// ==========================================================
+
+// RequestCashOut godoc
+// @Summary Request Cash-Out
+// @Description Requests converting points into pocket money at the current rate. The points are deducted immediately and returned if a parent rejects the request. Points earmarked for savings goals cannot be cashed out.
+// @Tags Child - Points & Rewards
+// @Accept json
+// @Produce json
+// @Param cash_out_input body models.CreateCashOutInput true "Cash-out details"
+// @Success 201 {object} models.Response{data=models.CashOutRequest} "Cash-out requested"
+// @Failure 400 {object} models.Response "Validation failed, below the minimum or not a multiple of the rate"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 402 {object} models.Response "Not enough available points"
+// @Failure 403 {object} models.Response "Cash-out is not enabled for this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/cash-outs [post]
+func (h *CashOutHandler) RequestCashOut(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	input := new(models.CreateCashOutInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	request, err := h.CashOutService.RequestCashOut(c.Context(), childID, input)
+	if err != nil {
+		return handleChildError(c, err, "RequestCashOut")
+	}
+
+	return c.Status(http.StatusCreated).JSON(models.Response{Success: true, Message: "Cash-out submitted and waiting for parent approval", Data: request})
+}
+
+// GetMyCashOuts godoc
+// @Summary Get My Cash-Out Requests
+// @Description Retrieves the child's cash-out requests (newest first), optionally filtered by status.
+// @Tags Child - Points & Rewards
+// @Produce json
+// @Param status query string false "Filter by status (pending, approved, rejected)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponseGeneric "Cash-out requests retrieved"
+// @Failure 400 {object} models.Response "Invalid status filter"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/cash-outs [get]
+func (h *CashOutHandler) GetMyCashOuts(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	statusFilter := c.Query("status")
+	if statusFilter != "" && !isValidCashOutStatus(statusFilter) {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
+			Success: false,
+			Message: fmt.Sprintf("Invalid status filter value: '%s'. Valid statuses are pending, approved, rejected.", statusFilter),
+		})
+	}
+
+	pagination := utils.ParsePaginationParams(c)
+	requests, totalCount, err := h.CashOutService.GetMyCashOuts(c.Context(), childID, models.CashOutStatus(statusFilter), pagination.Page, pagination.Limit)
+	if err != nil {
+		return handleChildError(c, err, "GetMyCashOuts")
+	}
+
+	meta := utils.BuildPaginationMeta(totalCount, pagination.Limit, pagination.Page)
+	return c.Status(http.StatusOK).JSON(utils.NewPaginatedResponse("Cash-out requests retrieved successfully", requests, meta))
+}
+
+// GetMyMoneyStatement godoc
+// @Summary Get My Monthly Money Statement
+// @Description Retrieves the money paid out to the child in a calendar month (in the child's timezone), with totals per currency in minor units.
+// @Tags Child - Points & Rewards
+// @Produce json
+// @Param month query string false "Month in YYYY-MM format (default: current month)"
+// @Success 200 {object} models.Response{data=models.MoneyStatement} "Statement retrieved"
+// @Failure 400 {object} models.Response "Invalid month"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/money-statements [get]
+func (h *CashOutHandler) GetMyMoneyStatement(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	month := c.Query("month")
+	if !isValidStatementMonth(month) {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid month parameter, expected YYYY-MM"})
+	}
+
+	statement, err := h.CashOutService.GetStatement(c.Context(), childID, month)
+	if err != nil {
+		return handleChildError(c, err, "GetMyMoneyStatement")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Money statement retrieved successfully", Data: statement})
+}
@@ -27,7 +27,8 @@ type ChildHandler struct {
 
 	// --- Services (untuk operasi dengan logika/transaksi) ---
 	RewardService service.RewardService // Untuk ClaimReward
-	// TaskService service.TaskService // Belum perlu untuk SubmitTask? Tergantung repo
+	TaskService   service.TaskService   // Untuk auto-approval setelah SubmitTask
+	LevelService  service.LevelService  // Level & progres XP pada GetMyPoints
 
 	// --- Lainnya ---
 	// UserRepo repository.UserRepository // Mungkin tidak perlu jika info user dari JWT cukup
@@ -41,7 +42,8 @@ func NewChildHandler(
 	userRewardRepo repository.UserRewardRepository,
 	pointRepo repository.PointTransactionRepository,
 	rewardService service.RewardService, // Inject RewardService
-	// taskService service.TaskService,
+	taskService service.TaskService, // Inject TaskService (auto-approval)
+	levelService service.LevelService, // Inject LevelService (level & XP)
 ) *ChildHandler {
 	return &ChildHandler{
 		UserTaskRepo:   userTaskRepo,
@@ -49,8 +51,9 @@ func NewChildHandler(
 		UserRewardRepo: userRewardRepo,
 		PointRepo:      pointRepo,
 		RewardService:  rewardService, // Simpan RewardService
-		// TaskService:  taskService,
-		Validate: validator.New(),
+		TaskService:    taskService,
+		LevelService:   levelService,
+		Validate:       validator.New(),
 	}
 }
 
@@ -60,7 +63,9 @@ func isValidTaskStatus(status string) bool {
 	case models.UserTaskStatusAssigned,
 		models.UserTaskStatusSubmitted,
 		models.UserTaskStatusApproved,
-		models.UserTaskStatusRejected:
+		models.UserTaskStatusRejected,
+		models.UserTaskStatusCancelled,
+		models.UserTaskStatusExpired:
 		return true
 	default:
 		return false
@@ -72,7 +77,9 @@ func isValidClaimStatus(status string) bool {
 	switch models.UserRewardStatus(status) {
 	case models.UserRewardStatusPending,
 		models.UserRewardStatusApproved,
-		models.UserRewardStatusRejected:
+		models.UserRewardStatusRejected,
+		models.UserRewardStatusScheduled,
+		models.UserRewardStatusFulfilled:
 		return true
 	default:
 		return false
@@ -91,6 +98,22 @@ func handleChildError(c *fiber.Ctx, err error, operation string) error {
 			message = "Task assignment not found or not yours"
 		} else if operation == "ClaimReward" {
 			message = "Reward not found"
+		} else if operation == "ClaimBounty" {
+			message = "Bounty not found"
+		} else if operation == "ConfirmClaimReceived" || operation == "GetMyClaimHistory" {
+			message = "Claim not found"
+		} else if operation == "CreateSavingsGoal" {
+			message = "Reward not found"
+		} else if operation == "EarmarkPoints" || operation == "CancelSavingsGoal" {
+			message = "Savings goal not found"
+		} else if operation == "GetMyInterestPreview" {
+			message = "No interest policy set"
+		} else if operation == "CancelTransfer" {
+			message = "Transfer not found"
+		} else if operation == "ExchangeCurrency" {
+			message = "Exchange rule not found"
+		} else if operation == "GetMyStatement" {
+			message = "Currency not found"
 		}
 		return c.Status(fiber.StatusNotFound).JSON(models.Response{Success: false, Message: message})
 	}
@@ -98,11 +121,25 @@ func handleChildError(c *fiber.Ctx, err error, operation string) error {
 		log.Warn().Err(err).Msg("Insufficient points")
 		return c.Status(fiber.StatusPaymentRequired).JSON(models.Response{Success: false, Message: err.Error()}) // 402
 	}
+	var unavailable *service.RewardUnavailableError
+	if errors.As(err, &unavailable) {
+		log.Warn().Err(err).Str("reason", string(unavailable.Availability.Reason)).Msg("Reward not available")
+		return c.Status(fiber.StatusConflict).JSON(models.Response{Success: false, Message: err.Error(), Data: unavailable.Availability}) // 409
+	}
+	if errors.Is(err, service.ErrBountyUnavailable) || errors.Is(err, service.ErrBountyAlreadyClaimed) {
+		log.Warn().Err(err).Msg("Bounty cannot be claimed")
+		return c.Status(fiber.StatusConflict).JSON(models.Response{Success: false, Message: err.Error()}) // 409
+	}
 	// Cek error forbidden/status salah dari repo/service
 	if strings.Contains(err.Error(), "forbidden") || strings.Contains(err.Error(), "not assigned to you") || strings.Contains(err.Error(), "already submitted/completed") || strings.Contains(err.Error(), "task status is already") {
 		log.Warn().Err(err).Msg("Forbidden or invalid state")
 		return c.Status(fiber.StatusForbidden).JSON(models.Response{Success: false, Message: err.Error()}) // 403
 	}
+	// Transisi status yang tidak sah (misal: konfirmasi klaim yang belum disetujui)
+	if strings.HasPrefix(err.Error(), "cannot ") {
+		log.Warn().Err(err).Msg("Invalid state transition")
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: err.Error()}) // 400
+	}
 
 	// Error Internal Server
 	log.Error().Err(err).Msg("Internal server error")
@@ -118,7 +155,7 @@ func handleChildError(c *fiber.Ctx, err error, operation string) error {
 // @Description Retrieves tasks assigned to the logged-in child, optionally filtered by status.
 // @Tags Child - Tasks
 // @Produce json
-// @Param status query string false "Filter by status (assigned, submitted, approved, rejected)" Enums(assigned, submitted, approved, rejected)
+// @Param status query string false "Filter by status (assigned, submitted, approved, rejected, cancelled, expired)" Enums(assigned, submitted, approved, rejected, cancelled, expired)
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Success 200 {object} utils.PaginatedResponseGeneric "Tasks retrieved"
@@ -160,11 +197,11 @@ func (h *ChildHandler) GetMyTasks(c *fiber.Ctx) error {
 
 // SubmitMyTask godoc
 // @Summary Submit My Task
-// @Description Marks a specific assigned task as 'submitted' by the logged-in child.
+// @Description Marks a specific assigned task as 'submitted' by the logged-in child. If an auto-approval policy without grace period applies, the task is approved immediately.
 // @Tags Child - Tasks
 // @Produce json
 // @Param userTaskId path int true "UserTask ID (the specific assignment)"
-// @Success 200 {object} models.Response "Task submitted successfully"
+// @Success 200 {object} models.Response{data=map[string]bool} "Task submitted successfully (data.auto_approved is set when approved immediately)"
 // @Failure 400 {object} models.Response "Invalid UserTask ID"
 // @Failure 401 {object} models.Response "Unauthorized"
 // @Failure 403 {object} models.Response "Forbidden (Not your task or task not assignable)"
@@ -194,19 +231,62 @@ func (h *ChildHandler) SubmitMyTask(c *fiber.Ctx) error {
 	}
 
 	log.Info().Int("child_id", childID).Int("user_task_id", userTaskID).Msg("Handler: Task submitted successfully by child")
+
+	// Submission sudah tersimpan; kegagalan auto-approval hanya di-log (worker akan mencoba lagi)
+	autoApproved, err := h.TaskService.AutoApproveTask(ctx, userTaskID)
+	if err != nil {
+		log.Error().Err(err).Int("user_task_id", userTaskID).Msg("Handler: Auto-approval after submission failed")
+	}
+	if autoApproved {
+		return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Task submitted and auto-approved", Data: fiber.Map{"auto_approved": true}})
+	}
 	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Task submitted successfully"})
 }
 
+// GetMyTaskTimeline godoc
+// @Summary Get My Task Timeline
+// @Description Retrieves the status transition history of one of the logged-in child's task assignments (oldest first).
+// @Tags Child - Tasks
+// @Produce json
+// @Param userTaskId path int true "UserTask ID (the specific assignment)"
+// @Success 200 {object} models.Response{data=[]models.UserTaskEvent} "Task timeline retrieved"
+// @Failure 400 {object} models.Response "Invalid UserTask ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not your task)"
+// @Failure 404 {object} models.Response "Task assignment not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/tasks/{userTaskId}/timeline [get]
+func (h *ChildHandler) GetMyTaskTimeline(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	userTaskID, err := strconv.Atoi(c.Params("userTaskId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid UserTask ID parameter"})
+	}
+
+	events, err := h.TaskService.GetTaskTimeline(c.Context(), userTaskID, childID)
+	if err != nil {
+		return handleChildError(c, err, "GetMyTaskTimeline")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Task timeline retrieved successfully", Data: events})
+}
+
 // ==========================================================
 // --- Points & Rewards ---
 // ==========================================================
 
 // GetMyPoints godoc
 // @Summary Get My Points Balance
-// @Description Retrieves the current points balance for the logged-in child.
+// @Description Retrieves the current points balance for the logged-in child, plus the child's level and progress (`level`). XP is separate from points and does not drop when points are spent.
 // @Tags Child - Points & Rewards
 // @Produce json
-// @Success 200 {object} models.Response{data=map[string]int} "Points balance retrieved"
+// @Success 200 {object} models.Response{data=map[string]interface{}} "Points balance retrieved"
 // @Failure 401 {object} models.Response "Unauthorized"
 // @Failure 500 {object} models.Response "Internal server error"
 // @Security ApiKeyAuth
@@ -225,13 +305,18 @@ func (h *ChildHandler) GetMyPoints(c *fiber.Ctx) error {
 		return handleChildError(c, err, "GetMyPoints")
 	}
 
-	log.Info().Int("child_id", childID).Int("total_points", totalPoints).Msg("Handler: Retrieved points balance for child")
-	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Points balance retrieved", Data: fiber.Map{"total_points": totalPoints}})
+	level, err := h.LevelService.GetLevelProgress(ctx, childID)
+	if err != nil {
+		return handleChildError(c, err, "GetMyPoints")
+	}
+
+	log.Info().Int("child_id", childID).Int("total_points", totalPoints).Int("level", level.Level).Msg("Handler: Retrieved points balance for child")
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Points balance retrieved", Data: fiber.Map{"total_points": totalPoints, "level": level}})
 }
 
 // GetAvailableRewards godoc
 // @Summary Get Available Rewards
-// @Description Retrieves rewards available for the logged-in child to claim.
+// @Description Retrieves rewards available for the logged-in child to claim. Each reward includes its availability for this child (remaining stock, remaining claims in the current period, cooldown).
 // @Tags Child - Points & Rewards
 // @Produce json
 // @Param page query int false "Page number" default(1)
@@ -275,6 +360,7 @@ func (h *ChildHandler) GetAvailableRewards(c *fiber.Ctx) error {
 // @Failure 401 {object} models.Response "Unauthorized"
 // @Failure 402 {object} models.Response "Insufficient points" // Payment Required (402) bisa dipakai di sini
 // @Failure 404 {object} models.Response "Reward not found"
+// @Failure 409 {object} models.Response{data=models.RewardAvailability} "Reward unavailable (data.reason: out_of_stock, claim_limit_reached, cooldown; data.available_at when it can be claimed again)"
 // @Failure 500 {object} models.Response "Internal server error"
 // @Security ApiKeyAuth
 // @Router /child/rewards/{rewardId}/claim [post]
@@ -309,7 +395,7 @@ func (h *ChildHandler) ClaimReward(c *fiber.Ctx) error {
 // @Description Retrieves the history of reward claims made by the logged-in child (paginated).
 // @Tags Child - Points & Rewards
 // @Produce json
-// @Param status query string false "Filter by status (pending, approved, rejected)" Enums(pending, approved, rejected)
+// @Param status query string false "Filter by status (pending, approved, rejected, scheduled, fulfilled)" Enums(pending, approved, rejected, scheduled, fulfilled)
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Success 200 {object} utils.PaginatedResponseGeneric "Claims history retrieved"
@@ -330,7 +416,7 @@ func (h *ChildHandler) GetMyClaims(c *fiber.Ctx) error {
 		log.Warn().Str("status_filter", statusFilter).Int("child_id", childID).Msg("Handler: Invalid status filter value provided for GetMyClaims")
 		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
 			Success: false,
-			Message: fmt.Sprintf("Invalid status filter value: '%s'. Valid statuses are pending, approved, rejected.", statusFilter),
+			Message: fmt.Sprintf("Invalid status filter value: '%s'. Valid statuses are pending, approved, rejected, scheduled, fulfilled.", statusFilter),
 		})
 	}
 
@@ -348,6 +434,73 @@ func (h *ChildHandler) GetMyClaims(c *fiber.Ctx) error {
 	return c.Status(http.StatusOK).JSON(response)
 }
 
+// ConfirmClaimReceived godoc
+// @Summary Confirm Reward Received
+// @Description Lets the logged-in child confirm that an approved reward was actually handed over. The claim moves to 'fulfilled'.
+// @Tags Child - Points & Rewards
+// @Produce json
+// @Param claimId path int true "UserReward Claim ID"
+// @Success 200 {object} models.Response "Reward receipt confirmed"
+// @Failure 400 {object} models.Response "Invalid Claim ID, claim not approved yet, or already confirmed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not your claim)"
+// @Failure 404 {object} models.Response "Claim not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/claims/{claimId}/received [patch]
+func (h *ChildHandler) ConfirmClaimReceived(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	claimID, err := strconv.Atoi(c.Params("claimId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Claim ID parameter"})
+	}
+
+	if err := h.RewardService.ConfirmClaimReceipt(c.Context(), claimID, childID); err != nil {
+		return handleChildError(c, err, "ConfirmClaimReceived")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Reward receipt confirmed"})
+}
+
+// GetMyClaimHistory godoc
+// @Summary Get My Reward Claim History
+// @Description Retrieves the status transition history of one of the logged-in child's reward claims (oldest first).
+// @Tags Child - Points & Rewards
+// @Produce json
+// @Param claimId path int true "UserReward Claim ID"
+// @Success 200 {object} models.Response{data=[]models.UserRewardEvent} "Claim history retrieved"
+// @Failure 400 {object} models.Response "Invalid Claim ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not your claim)"
+// @Failure 404 {object} models.Response "Claim not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/claims/{claimId}/history [get]
+func (h *ChildHandler) GetMyClaimHistory(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	claimID, err := strconv.Atoi(c.Params("claimId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Claim ID parameter"})
+	}
+
+	events, err := h.RewardService.GetClaimHistory(c.Context(), claimID, childID)
+	if err != nil {
+		return handleChildError(c, err, "GetMyClaimHistory")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Claim history retrieved successfully", Data: events})
+}
+
 // GetMyPointHistory godoc
 // @Summary Get My Points History
 // @Description Retrieves the points transaction history for the logged-in child (paginated).
@@ -0,0 +1,393 @@
+// internal/api/v1/handlers/currency_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// CurrencyHandler menangani endpoint mata uang tambahan dan aturan tukar (Parent) serta saldo & penukaran (Child).
+type CurrencyHandler struct {
+	CurrencyService service.CurrencyService
+	Validate        *validator.Validate
+}
+
+// NewCurrencyHandler membuat instance baru dari CurrencyHandler.
+func NewCurrencyHandler(currencyService service.CurrencyService) *CurrencyHandler {
+	return &CurrencyHandler{
+		CurrencyService: currencyService,
+		Validate:        validator.New(),
+	}
+}
+
+// ==========================================================
+// --- Parent: Currencies ---
+// ==========================================================
+
+// CreateCurrency godoc
+// @Summary Create Currency
+// @Description Creates an additional currency (e.g. stars, coins, screen-time minutes) owned by the parent. Tasks and rewards created by the parent can then pay out or be priced in it. Points remain the default currency (currency_id 0).
+// @Tags Parent - Points
+// @Accept json
+// @Produce json
+// @Param currency_input body models.CurrencyInput true "Currency details"
+// @Success 201 {object} models.Response{data=models.Currency} "Currency created"
+// @Failure 400 {object} models.Response "Validation failed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 409 {object} models.Response "Currency with this name already exists"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/currencies [post]
+func (h *CurrencyHandler) CreateCurrency(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	input := new(models.CurrencyInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	currency, err := h.CurrencyService.CreateCurrency(c.Context(), parentID, input)
+	if err != nil {
+		return handleParentError(c, err, "CreateCurrency")
+	}
+
+	return c.Status(http.StatusCreated).JSON(models.Response{Success: true, Message: "Currency created successfully", Data: currency})
+}
+
+// GetCurrencies godoc
+// @Summary Get My Currencies
+// @Description Retrieves the currencies created by the parent.
+// @Tags Parent - Points
+// @Produce json
+// @Success 200 {object} models.Response{data=[]models.Currency} "Currencies retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/currencies [get]
+func (h *CurrencyHandler) GetCurrencies(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	currencies, err := h.CurrencyService.GetCurrencies(c.Context(), parentID)
+	if err != nil {
+		return handleParentError(c, err, "GetCurrencies")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Currencies retrieved successfully", Data: currencies})
+}
+
+// UpdateCurrency godoc
+// @Summary Update Currency
+// @Description Renames a currency or changes its symbol.
+// @Tags Parent - Points
+// @Accept json
+// @Produce json
+// @Param currencyId path int true "Currency ID"
+// @Param currency_input body models.CurrencyInput true "Currency details"
+// @Success 200 {object} models.Response{data=models.Currency} "Currency updated"
+// @Failure 400 {object} models.Response "Invalid Currency ID or validation failed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 404 {object} models.Response "Currency not found"
+// @Failure 409 {object} models.Response "Currency with this name already exists"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/currencies/{currencyId} [put]
+func (h *CurrencyHandler) UpdateCurrency(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	currencyID, err := strconv.Atoi(c.Params("currencyId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Currency ID parameter"})
+	}
+
+	input := new(models.CurrencyInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	currency, err := h.CurrencyService.UpdateCurrency(c.Context(), parentID, currencyID, input)
+	if err != nil {
+		return handleParentError(c, err, "UpdateCurrency")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Currency updated successfully", Data: currency})
+}
+
+// DeleteCurrency godoc
+// @Summary Delete Currency
+// @Description Deletes a currency that is not used by any task, reward or ledger entry. Exchange rules using it are removed too.
+// @Tags Parent - Points
+// @Produce json
+// @Param currencyId path int true "Currency ID"
+// @Success 200 {object} models.Response "Currency deleted"
+// @Failure 400 {object} models.Response "Invalid Currency ID or currency still in use"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 404 {object} models.Response "Currency not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/currencies/{currencyId} [delete]
+func (h *CurrencyHandler) DeleteCurrency(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	currencyID, err := strconv.Atoi(c.Params("currencyId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Currency ID parameter"})
+	}
+
+	if err := h.CurrencyService.DeleteCurrency(c.Context(), parentID, currencyID); err != nil {
+		return handleParentError(c, err, "DeleteCurrency")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Currency deleted successfully"})
+}
+
+// ==========================================================
+// --- Parent: Exchange Rules & Balances ---
+// ==========================================================
+
+// CreateExchangeRule godoc
+// @Summary Create Exchange Rule
+// @Description Creates a rule letting the parent's children exchange from_amount units of one currency for to_amount units of another (currency_id 0 = points). Only one rule per currency pair is allowed.
+// @Tags Parent - Points
+// @Accept json
+// @Produce json
+// @Param rule_input body models.CreateExchangeRuleInput true "Exchange rule details"
+// @Success 201 {object} models.Response{data=models.CurrencyExchangeRule} "Exchange rule created"
+// @Failure 400 {object} models.Response "Validation failed, same currency on both sides or unknown currency"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 409 {object} models.Response "Exchange rule for this currency pair already exists"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/currency-exchange-rules [post]
+func (h *CurrencyHandler) CreateExchangeRule(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	input := new(models.CreateExchangeRuleInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	rule, err := h.CurrencyService.CreateExchangeRule(c.Context(), parentID, input)
+	if err != nil {
+		return handleParentError(c, err, "CreateExchangeRule")
+	}
+
+	return c.Status(http.StatusCreated).JSON(models.Response{Success: true, Message: "Exchange rule created successfully", Data: rule})
+}
+
+// GetExchangeRules godoc
+// @Summary Get My Exchange Rules
+// @Description Retrieves the exchange rules created by the parent.
+// @Tags Parent - Points
+// @Produce json
+// @Success 200 {object} models.Response{data=[]models.CurrencyExchangeRule} "Exchange rules retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/currency-exchange-rules [get]
+func (h *CurrencyHandler) GetExchangeRules(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	rules, err := h.CurrencyService.GetExchangeRules(c.Context(), parentID)
+	if err != nil {
+		return handleParentError(c, err, "GetExchangeRules")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Exchange rules retrieved successfully", Data: rules})
+}
+
+// DeleteExchangeRule godoc
+// @Summary Delete Exchange Rule
+// @Description Deletes one of the parent's exchange rules. Past exchanges stay in the ledger.
+// @Tags Parent - Points
+// @Produce json
+// @Param ruleId path int true "Exchange Rule ID"
+// @Success 200 {object} models.Response "Exchange rule deleted"
+// @Failure 400 {object} models.Response "Invalid Rule ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 404 {object} models.Response "Exchange rule not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/currency-exchange-rules/{ruleId} [delete]
+func (h *CurrencyHandler) DeleteExchangeRule(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	ruleID, err := strconv.Atoi(c.Params("ruleId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Rule ID parameter"})
+	}
+
+	if err := h.CurrencyService.DeleteExchangeRule(c.Context(), parentID, ruleID); err != nil {
+		return handleParentError(c, err, "DeleteExchangeRule")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Exchange rule deleted successfully"})
+}
+
+// GetChildBalances godoc
+// @Summary Get Child Balances
+// @Description Retrieves the child's balance in points and in every currency available to them.
+// @Tags Parent - Points
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response{data=[]models.CurrencyBalance} "Balances retrieved"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/balances [get]
+func (h *CurrencyHandler) GetChildBalances(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	balances, err := h.CurrencyService.GetChildBalances(c.Context(), parentID, childID)
+	if err != nil {
+		return handleParentError(c, err, "GetChildBalances")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Balances retrieved successfully", Data: balances})
+}
+
+// ==========================================================
+// --- Child: Balances & Exchange ---
+// ==========================================================
+
+// GetMyBalances godoc
+// @Summary Get My Balances
+// @Description Retrieves the child's balance in points and in every currency available to them.
+// @Tags Child - Points & Rewards
+// @Produce json
+// @Success 200 {object} models.Response{data=[]models.CurrencyBalance} "Balances retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/balances [get]
+func (h *CurrencyHandler) GetMyBalances(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	balances, err := h.CurrencyService.GetBalances(c.Context(), childID)
+	if err != nil {
+		return handleChildError(c, err, "GetMyBalances")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Balances retrieved successfully", Data: balances})
+}
+
+// GetMyExchangeRules godoc
+// @Summary Get Available Exchange Rules
+// @Description Retrieves the exchange rules set by the child's parents.
+// @Tags Child - Points & Rewards
+// @Produce json
+// @Success 200 {object} models.Response{data=[]models.CurrencyExchangeRule} "Exchange rules retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/currency-exchange-rules [get]
+func (h *CurrencyHandler) GetMyExchangeRules(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	rules, err := h.CurrencyService.GetExchangeRulesForChild(c.Context(), childID)
+	if err != nil {
+		return handleChildError(c, err, "GetMyExchangeRules")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Exchange rules retrieved successfully", Data: rules})
+}
+
+// ExchangeCurrency godoc
+// @Summary Exchange Currency
+// @Description Exchanges an amount of one currency for another using one of the parents' exchange rules. The amount must be a multiple of the rule's from_amount. Points earmarked for savings goals cannot be exchanged.
+// @Tags Child - Points & Rewards
+// @Accept json
+// @Produce json
+// @Param exchange_input body models.ExchangeCurrencyInput true "Exchange details"
+// @Success 201 {object} models.Response{data=models.CurrencyExchangeResult} "Currency exchanged"
+// @Failure 400 {object} models.Response "Validation failed or amount not a multiple of the rule"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 402 {object} models.Response "Not enough balance in the source currency"
+// @Failure 404 {object} models.Response "Exchange rule not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/currency-exchanges [post]
+func (h *CurrencyHandler) ExchangeCurrency(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	input := new(models.ExchangeCurrencyInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	result, err := h.CurrencyService.Exchange(c.Context(), childID, input)
+	if err != nil {
+		return handleChildError(c, err, "ExchangeCurrency")
+	}
+
+	return c.Status(http.StatusCreated).JSON(models.Response{Success: true, Message: "Currency exchanged successfully", Data: result})
+}
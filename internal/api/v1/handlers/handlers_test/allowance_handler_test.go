@@ -0,0 +1,156 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/api/v1/handlers"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAllowanceHandler_SetAllowancePlan(t *testing.T) {
+	parentID := 1
+	childID := 10
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockAllowanceService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "Success",
+			body: models.SetAllowancePlanInput{Amount: 50, Cadence: models.AllowanceCadenceWeekly, StartDate: "2030-01-07", MinApprovedTasks: 3},
+			setupMock: func(mockService *serviceMocks.MockAllowanceService) {
+				mockService.On("SetPlan", mock.Anything, parentID, childID, mock.AnythingOfType("*models.SetAllowancePlanInput")).
+					Return(&models.AllowancePlan{ID: 1, ChildID: childID, Amount: 50, Cadence: models.AllowanceCadenceWeekly, MinApprovedTasks: 3}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Allowance plan saved successfully",
+		},
+		{
+			name:           "Validation Error - Invalid Start Date Format",
+			body:           models.SetAllowancePlanInput{Amount: 50, Cadence: models.AllowanceCadenceWeekly, StartDate: "07/01/2030"},
+			setupMock:      func(mockService *serviceMocks.MockAllowanceService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name:           "Validation Error - Invalid Cadence",
+			body:           map[string]interface{}{"amount": 50, "cadence": "daily", "start_date": "2030-01-07"},
+			setupMock:      func(mockService *serviceMocks.MockAllowanceService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name: "Start Date In The Past",
+			body: models.SetAllowancePlanInput{Amount: 50, Cadence: models.AllowanceCadenceMonthly, StartDate: "2020-01-01"},
+			setupMock: func(mockService *serviceMocks.MockAllowanceService) {
+				mockService.On("SetPlan", mock.Anything, parentID, childID, mock.AnythingOfType("*models.SetAllowancePlanInput")).
+					Return(nil, errors.New("invalid start date: start_date cannot be in the past"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "invalid start date: start_date cannot be in the past",
+		},
+		{
+			name: "Forbidden - Not Parent",
+			body: models.SetAllowancePlanInput{Amount: 50, Cadence: models.AllowanceCadenceWeekly, StartDate: "2030-01-07"},
+			setupMock: func(mockService *serviceMocks.MockAllowanceService) {
+				mockService.On("SetPlan", mock.Anything, parentID, childID, mock.AnythingOfType("*models.SetAllowancePlanInput")).
+					Return(nil, errors.New("forbidden: you are not authorized to manage the allowance for this child"))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedMsg:    "Forbidden: You are not authorized for this action",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockAllowanceService)
+			tc.setupMock(mockService)
+			handler := handlers.NewAllowanceHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Put("/api/v1/parent/children/:childId/allowance-plan", handler.SetAllowancePlan)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/parent/children/10/allowance-plan", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAllowanceHandler_PauseAllowancePlan(t *testing.T) {
+	parentID := 1
+	childID := 10
+
+	tests := []struct {
+		name           string
+		setupMock      func(mockService *serviceMocks.MockAllowanceService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "Success",
+			setupMock: func(mockService *serviceMocks.MockAllowanceService) {
+				mockService.On("PausePlan", mock.Anything, parentID, childID).
+					Return(&models.AllowancePlan{ID: 1, ChildID: childID, IsPaused: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Allowance plan paused successfully",
+		},
+		{
+			name: "Already Paused",
+			setupMock: func(mockService *serviceMocks.MockAllowanceService) {
+				mockService.On("PausePlan", mock.Anything, parentID, childID).
+					Return(nil, errors.New("cannot pause: allowance plan is already paused"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "cannot pause: allowance plan is already paused",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockAllowanceService)
+			tc.setupMock(mockService)
+			handler := handlers.NewAllowanceHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Post("/api/v1/parent/children/:childId/allowance-plan/pause", handler.PauseAllowancePlan)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/parent/children/10/allowance-plan/pause", nil)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
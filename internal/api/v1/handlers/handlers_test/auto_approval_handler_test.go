@@ -0,0 +1,95 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/api/v1/handlers"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAutoApprovalHandler_CreatePolicy(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockAutoApprovalService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "Success - Task And Child With Grace Period",
+			body: models.CreateAutoApprovalPolicyInput{TaskID: 3, ChildID: 2, GracePeriodMinutes: 60},
+			setupMock: func(mockService *serviceMocks.MockAutoApprovalService) {
+				mockService.On("CreatePolicy", mock.Anything, parentID, mock.MatchedBy(func(input *models.CreateAutoApprovalPolicyInput) bool {
+					return input.TaskID == 3 && input.ChildID == 2 && input.GracePeriodMinutes == 60
+				})).Return(5, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedMsg:    "Auto-approval policy created successfully",
+		},
+		{
+			name:           "Validation Error - No Scope",
+			body:           models.CreateAutoApprovalPolicyInput{GracePeriodMinutes: 10},
+			setupMock:      func(mockService *serviceMocks.MockAutoApprovalService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name: "Forbidden - Not Parent Of Child",
+			body: models.CreateAutoApprovalPolicyInput{ChildID: 9},
+			setupMock: func(mockService *serviceMocks.MockAutoApprovalService) {
+				mockService.On("CreatePolicy", mock.Anything, parentID, mock.AnythingOfType("*models.CreateAutoApprovalPolicyInput")).
+					Return(0, errors.New("forbidden: you are not authorized to manage this child"))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedMsg:    "Forbidden: You are not authorized for this action",
+		},
+		{
+			name: "Conflict - Duplicate Scope",
+			body: models.CreateAutoApprovalPolicyInput{TaskID: 3},
+			setupMock: func(mockService *serviceMocks.MockAutoApprovalService) {
+				mockService.On("CreatePolicy", mock.Anything, parentID, mock.AnythingOfType("*models.CreateAutoApprovalPolicyInput")).
+					Return(0, errors.New("auto-approval policy for this task/child already exists"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedMsg:    "auto-approval policy for this task/child already exists",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockAutoApprovalService)
+			tc.setupMock(mockService)
+			handler := handlers.NewAutoApprovalHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Post("/api/v1/parent/auto-approval-policies", handler.CreatePolicy)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/parent/auto-approval-policies", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
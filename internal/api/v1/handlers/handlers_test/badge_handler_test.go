@@ -0,0 +1,169 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/api/v1/handlers"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBadgeHandler_CreateBadge(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockBadgeService)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			body: models.BadgeInput{Name: "Bookworm", RuleType: models.BadgeRuleTasksCompleted, Threshold: 20, TaskID: 4},
+			setupMock: func(mockService *serviceMocks.MockBadgeService) {
+				mockService.On("CreateBadge", mock.Anything, parentID, &models.BadgeInput{Name: "Bookworm", RuleType: models.BadgeRuleTasksCompleted, Threshold: 20, TaskID: 4}).
+					Return(&models.Badge{ID: 9, Name: "Bookworm", RuleType: models.BadgeRuleTasksCompleted}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Validation Failed - Unknown Rule",
+			body:           models.BadgeInput{Name: "Bookworm", RuleType: "books_read", Threshold: 20},
+			setupMock:      func(mockService *serviceMocks.MockBadgeService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid Threshold",
+			body: models.BadgeInput{Name: "Bookworm", RuleType: models.BadgeRuleTasksCompleted},
+			setupMock: func(mockService *serviceMocks.MockBadgeService) {
+				mockService.On("CreateBadge", mock.Anything, parentID, mock.Anything).
+					Return(nil, errors.New("invalid threshold: must be greater than 0 for rule 'tasks_completed'"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Duplicate Name",
+			body: models.BadgeInput{Name: "Bookworm", RuleType: models.BadgeRuleManual},
+			setupMock: func(mockService *serviceMocks.MockBadgeService) {
+				mockService.On("CreateBadge", mock.Anything, parentID, mock.Anything).
+					Return(nil, errors.New("badge with this name already exists"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockBadgeService)
+			tc.setupMock(mockService)
+			handler := handlers.NewBadgeHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Post("/api/v1/parent/badges", handler.CreateBadge)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/parent/badges", bytes.NewReader(bodyBytes))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBadgeHandler_AwardBadge(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(mockService *serviceMocks.MockBadgeService)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			path: "/api/v1/parent/children/2/badges/9",
+			setupMock: func(mockService *serviceMocks.MockBadgeService) {
+				mockService.On("AwardBadge", mock.Anything, parentID, 2, 9).
+					Return(&models.ChildBadge{ID: 5, ChildID: 2, Badge: models.Badge{ID: 9}}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Invalid Badge ID",
+			path:           "/api/v1/parent/children/2/badges/abc",
+			setupMock:      func(mockService *serviceMocks.MockBadgeService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Rule Badge Cannot Be Awarded",
+			path: "/api/v1/parent/children/2/badges/1",
+			setupMock: func(mockService *serviceMocks.MockBadgeService) {
+				mockService.On("AwardBadge", mock.Anything, parentID, 2, 1).
+					Return(nil, errors.New("cannot award badge: 'points_balance' badges are awarded automatically"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Not Parent Of Child",
+			path: "/api/v1/parent/children/3/badges/9",
+			setupMock: func(mockService *serviceMocks.MockBadgeService) {
+				mockService.On("AwardBadge", mock.Anything, parentID, 3, 9).
+					Return(nil, errors.New("forbidden: you are not authorized to manage badges for this child"))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "Badge Not Found",
+			path: "/api/v1/parent/children/2/badges/99",
+			setupMock: func(mockService *serviceMocks.MockBadgeService) {
+				mockService.On("AwardBadge", mock.Anything, parentID, 2, 99).
+					Return(nil, pgx.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Already Awarded",
+			path: "/api/v1/parent/children/2/badges/9",
+			setupMock: func(mockService *serviceMocks.MockBadgeService) {
+				mockService.On("AwardBadge", mock.Anything, parentID, 2, 9).
+					Return(nil, errors.New("badge already exists for this child"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockBadgeService)
+			tc.setupMock(mockService)
+			handler := handlers.NewBadgeHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Post("/api/v1/parent/children/:childId/badges/:badgeId", handler.AwardBadge)
+
+			req := httptest.NewRequest(http.MethodPost, tc.path, nil)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/api/v1/handlers"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBountyHandler_CreateBounty(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockBountyService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "Success",
+			body: models.CreateBountyInput{TaskID: 3, MaxClaims: 2},
+			setupMock: func(mockService *serviceMocks.MockBountyService) {
+				mockService.On("CreateBounty", mock.Anything, parentID, mock.AnythingOfType("*models.CreateBountyInput")).Return(5, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedMsg:    "Bounty created successfully",
+		},
+		{
+			name:           "Validation Error - Claim Limit Too High",
+			body:           models.CreateBountyInput{TaskID: 3, MaxClaims: 50},
+			setupMock:      func(mockService *serviceMocks.MockBountyService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name: "Expiry In The Past",
+			body: models.CreateBountyInput{TaskID: 3},
+			setupMock: func(mockService *serviceMocks.MockBountyService) {
+				mockService.On("CreateBounty", mock.Anything, parentID, mock.AnythingOfType("*models.CreateBountyInput")).
+					Return(0, errors.New("invalid expires_at: must be in the future"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "invalid expires_at: must be in the future",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockBountyService)
+			tc.setupMock(mockService)
+			handler := handlers.NewBountyHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Post("/api/v1/parent/bounties", handler.CreateBounty)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/parent/bounties", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBountyHandler_ClaimBounty(t *testing.T) {
+	childID := 10
+
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(mockService *serviceMocks.MockBountyService)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			path: "/api/v1/child/bounties/5/claim",
+			setupMock: func(mockService *serviceMocks.MockBountyService) {
+				mockService.On("ClaimBounty", mock.Anything, childID, 5).Return(42, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Bounty Already Filled",
+			path: "/api/v1/child/bounties/5/claim",
+			setupMock: func(mockService *serviceMocks.MockBountyService) {
+				mockService.On("ClaimBounty", mock.Anything, childID, 5).Return(0, service.ErrBountyUnavailable)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "Already Claimed By Child",
+			path: "/api/v1/child/bounties/5/claim",
+			setupMock: func(mockService *serviceMocks.MockBountyService) {
+				mockService.On("ClaimBounty", mock.Anything, childID, 5).Return(0, service.ErrBountyAlreadyClaimed)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "Not Found",
+			path: "/api/v1/child/bounties/6/claim",
+			setupMock: func(mockService *serviceMocks.MockBountyService) {
+				mockService.On("ClaimBounty", mock.Anything, childID, 6).Return(0, pgx.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Invalid Bounty ID",
+			path:           "/api/v1/child/bounties/abc/claim",
+			setupMock:      func(mockService *serviceMocks.MockBountyService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockBountyService)
+			tc.setupMock(mockService)
+			handler := handlers.NewBountyHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
+			app.Post("/api/v1/child/bounties/:bountyId/claim", handler.ClaimBounty)
+
+			req := httptest.NewRequest(http.MethodPost, tc.path, nil)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			if tc.expectedStatus == http.StatusCreated {
+				var responseBody map[string]interface{}
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+				data, ok := responseBody["data"].(map[string]interface{})
+				assert.True(t, ok)
+				assert.Equal(t, float64(42), data["user_task_id"])
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
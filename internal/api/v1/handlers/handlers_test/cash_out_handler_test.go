@@ -0,0 +1,324 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/api/v1/handlers"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCashOutHandler_SetFamilyCashOutPolicy(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockCashOutService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "Success - Applies To All Children",
+			body: models.SetCashOutPolicyInput{Points: 10, MinorUnits: 100, CurrencyCode: "USD"},
+			setupMock: func(mockService *serviceMocks.MockCashOutService) {
+				// childID 0 = kurs untuk semua anak parent
+				mockService.On("SetPolicy", mock.Anything, parentID, 0, mock.AnythingOfType("*models.SetCashOutPolicyInput")).
+					Return(&models.CashOutPolicy{ID: 1, CreatedByUserID: parentID, Points: 10, MinorUnits: 100, CurrencyCode: "USD"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Cash-out policy saved successfully",
+		},
+		{
+			name:           "Validation Error - Lowercase Currency",
+			body:           models.SetCashOutPolicyInput{Points: 10, MinorUnits: 100, CurrencyCode: "usd"},
+			setupMock:      func(mockService *serviceMocks.MockCashOutService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockCashOutService)
+			tc.setupMock(mockService)
+			handler := handlers.NewCashOutHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Put("/api/v1/parent/cash-out-policy", handler.SetFamilyCashOutPolicy)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/parent/cash-out-policy", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCashOutHandler_RequestCashOut(t *testing.T) {
+	childID := 10
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockCashOutService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "Success",
+			body: models.CreateCashOutInput{Points: 50},
+			setupMock: func(mockService *serviceMocks.MockCashOutService) {
+				mockService.On("RequestCashOut", mock.Anything, childID, mock.AnythingOfType("*models.CreateCashOutInput")).
+					Return(&models.CashOutRequest{ID: 1, ChildID: childID, Points: 50, AmountMinor: 500, CurrencyCode: "USD", Status: models.CashOutStatusPending}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedMsg:    "Cash-out submitted and waiting for parent approval",
+		},
+		{
+			name:           "Validation Error - Zero Points",
+			body:           models.CreateCashOutInput{Points: 0},
+			setupMock:      func(mockService *serviceMocks.MockCashOutService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name: "Not Enabled",
+			body: models.CreateCashOutInput{Points: 50},
+			setupMock: func(mockService *serviceMocks.MockCashOutService) {
+				mockService.On("RequestCashOut", mock.Anything, childID, mock.AnythingOfType("*models.CreateCashOutInput")).
+					Return(nil, errors.New("forbidden: cash-out is not enabled for this child"))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedMsg:    "forbidden: cash-out is not enabled for this child",
+		},
+		{
+			name: "Not A Multiple",
+			body: models.CreateCashOutInput{Points: 55},
+			setupMock: func(mockService *serviceMocks.MockCashOutService) {
+				mockService.On("RequestCashOut", mock.Anything, childID, mock.AnythingOfType("*models.CreateCashOutInput")).
+					Return(nil, errors.New("cannot request cash-out: points must be a multiple of 10"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "cannot request cash-out: points must be a multiple of 10",
+		},
+		{
+			name: "Insufficient Points",
+			body: models.CreateCashOutInput{Points: 50},
+			setupMock: func(mockService *serviceMocks.MockCashOutService) {
+				mockService.On("RequestCashOut", mock.Anything, childID, mock.AnythingOfType("*models.CreateCashOutInput")).
+					Return(nil, fmt.Errorf("%w: only 30 points are available to cash out", service.ErrInsufficientPoints))
+			},
+			expectedStatus: http.StatusPaymentRequired,
+			expectedMsg:    "insufficient points to claim reward: only 30 points are available to cash out",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockCashOutService)
+			tc.setupMock(mockService)
+			handler := handlers.NewCashOutHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
+			app.Post("/api/v1/child/cash-outs", handler.RequestCashOut)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/child/cash-outs", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCashOutHandler_ReviewCashOut(t *testing.T) {
+	parentID := 1
+	requestID := 7
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockCashOutService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "Approve Paid In Cash",
+			body: models.ReviewCashOutInput{Status: "approved", PayoutMethod: "cash"},
+			setupMock: func(mockService *serviceMocks.MockCashOutService) {
+				mockService.On("ReviewCashOut", mock.Anything, parentID, requestID, mock.AnythingOfType("*models.ReviewCashOutInput")).
+					Return(&models.CashOutRequest{ID: requestID, Status: models.CashOutStatusApproved,
+						Payout: &models.MoneyTransaction{ID: 3, CashOutRequestID: requestID, AmountMinor: 500, CurrencyCode: "USD", PayoutMethod: models.PayoutMethodCash}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Cash-out approved successfully",
+		},
+		{
+			name: "Reject",
+			body: models.ReviewCashOutInput{Status: "rejected", Note: "Save it for later"},
+			setupMock: func(mockService *serviceMocks.MockCashOutService) {
+				mockService.On("ReviewCashOut", mock.Anything, parentID, requestID, mock.AnythingOfType("*models.ReviewCashOutInput")).
+					Return(&models.CashOutRequest{ID: requestID, Status: models.CashOutStatusRejected, RefundTransactionID: 12}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Cash-out rejected successfully",
+		},
+		{
+			name:           "Validation Error - Approve Without Payout Method",
+			body:           models.ReviewCashOutInput{Status: "approved"},
+			setupMock:      func(mockService *serviceMocks.MockCashOutService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name:           "Validation Error - Unknown Payout Method",
+			body:           models.ReviewCashOutInput{Status: "approved", PayoutMethod: "cheque"},
+			setupMock:      func(mockService *serviceMocks.MockCashOutService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name: "Already Reviewed",
+			body: models.ReviewCashOutInput{Status: "rejected"},
+			setupMock: func(mockService *serviceMocks.MockCashOutService) {
+				mockService.On("ReviewCashOut", mock.Anything, parentID, requestID, mock.AnythingOfType("*models.ReviewCashOutInput")).
+					Return(nil, errors.New("cannot review cash-out: request is already approved"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "cannot review cash-out: request is already approved",
+		},
+		{
+			name: "Not Found",
+			body: models.ReviewCashOutInput{Status: "rejected"},
+			setupMock: func(mockService *serviceMocks.MockCashOutService) {
+				mockService.On("ReviewCashOut", mock.Anything, parentID, requestID, mock.AnythingOfType("*models.ReviewCashOutInput")).
+					Return(nil, pgx.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedMsg:    "Resource not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockCashOutService)
+			tc.setupMock(mockService)
+			handler := handlers.NewCashOutHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Patch("/api/v1/parent/cash-outs/:cashOutId/review", handler.ReviewCashOut)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/parent/cash-outs/%d/review", requestID), bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCashOutHandler_GetMyMoneyStatement(t *testing.T) {
+	childID := 10
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(mockService *serviceMocks.MockCashOutService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name:  "Success",
+			query: "?month=2026-09",
+			setupMock: func(mockService *serviceMocks.MockCashOutService) {
+				mockService.On("GetStatement", mock.Anything, childID, "2026-09").
+					Return(&models.MoneyStatement{ChildID: childID, Month: "2026-09",
+						Totals: []models.MoneyStatementTotal{{CurrencyCode: "USD", AmountMinor: 1500, PayoutCount: 2}}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Money statement retrieved successfully",
+		},
+		{
+			name:  "Default Current Month",
+			query: "",
+			setupMock: func(mockService *serviceMocks.MockCashOutService) {
+				mockService.On("GetStatement", mock.Anything, childID, "").
+					Return(&models.MoneyStatement{ChildID: childID, Month: "2026-10", Totals: []models.MoneyStatementTotal{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Money statement retrieved successfully",
+		},
+		{
+			name:           "Invalid Month",
+			query:          "?month=2026-13",
+			setupMock:      func(mockService *serviceMocks.MockCashOutService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Invalid month parameter, expected YYYY-MM",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockCashOutService)
+			tc.setupMock(mockService)
+			handler := handlers.NewCashOutHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
+			app.Get("/api/v1/child/money-statements", handler.GetMyMoneyStatement)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/child/money-statements"+tc.query, nil)
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
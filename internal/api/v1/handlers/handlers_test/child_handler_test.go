@@ -15,17 +15,20 @@ import (
 	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
 	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
 	"github.com/rakaarfi/digital-parenting-app-be/internal/repository/mocks"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
 	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-func setupChildHandler() (*fiber.App, *handlers.ChildHandler, *mocks.MockUserTaskRepository, *mocks.MockRewardRepository, *mocks.MockUserRewardRepository, *mocks.MockPointTransactionRepository, *serviceMocks.MockRewardService) {
+func setupChildHandler() (*fiber.App, *handlers.ChildHandler, *mocks.MockUserTaskRepository, *mocks.MockRewardRepository, *mocks.MockUserRewardRepository, *mocks.MockPointTransactionRepository, *serviceMocks.MockRewardService, *serviceMocks.MockTaskService) {
 	mockUserTaskRepo := new(mocks.MockUserTaskRepository)
 	mockRewardRepo := new(mocks.MockRewardRepository)
 	mockUserRewardRepo := new(mocks.MockUserRewardRepository)
 	mockPointRepo := new(mocks.MockPointTransactionRepository)
 	mockRewardService := new(serviceMocks.MockRewardService)
+	mockTaskService := new(serviceMocks.MockTaskService)
+	mockLevelService := new(serviceMocks.MockLevelService)
 
 	childHandler := handlers.NewChildHandler(
 		mockUserTaskRepo,
@@ -33,10 +36,12 @@ func setupChildHandler() (*fiber.App, *handlers.ChildHandler, *mocks.MockUserTas
 		mockUserRewardRepo,
 		mockPointRepo,
 		mockRewardService,
+		mockTaskService,
+		mockLevelService,
 	)
 
 	app := fiber.New()
-	return app, childHandler, mockUserTaskRepo, mockRewardRepo, mockUserRewardRepo, mockPointRepo, mockRewardService
+	return app, childHandler, mockUserTaskRepo, mockRewardRepo, mockUserRewardRepo, mockPointRepo, mockRewardService, mockTaskService
 }
 
 func TestChildHandler_GetMyTasks(t *testing.T) {
@@ -154,7 +159,7 @@ func TestChildHandler_GetMyTasks(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			app, handler, mockUserTaskRepo, _, _, _, _ := setupChildHandler()
+			app, handler, mockUserTaskRepo, _, _, _, _, _ := setupChildHandler()
 
 			// Add JWT middleware to simulate a logged-in child user
 			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
@@ -289,7 +294,7 @@ func TestChildHandler_SubmitMyTask(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			app, handler, mockUserTaskRepo, _, _, _, _ := setupChildHandler()
+			app, handler, mockUserTaskRepo, _, _, _, _, mockTaskService := setupChildHandler()
 
 			// Add JWT middleware to simulate a logged-in child user
 			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
@@ -302,6 +307,8 @@ func TestChildHandler_SubmitMyTask(t *testing.T) {
 			if tc.name != "Invalid UserTask ID" {
 				tc.setupMock(mockUserTaskRepo, userTaskID, childID)
 			}
+			// Tanpa kebijakan auto-approval, submission tetap menunggu verifikasi parent
+			mockTaskService.On("AutoApproveTask", mock.Anything, userTaskID).Return(false, nil).Maybe()
 
 			// Prepare request
 			req := httptest.NewRequest(http.MethodPatch, "/api/v1/child/tasks/"+tc.userTaskID+"/submit", nil)
@@ -323,19 +330,44 @@ func TestChildHandler_SubmitMyTask(t *testing.T) {
 	}
 }
 
+func TestChildHandler_SubmitMyTask_AutoApproved(t *testing.T) {
+	childID := 1
+	app, handler, mockUserTaskRepo, _, _, _, _, mockTaskService := setupChildHandler()
+	app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
+	app.Patch("/api/v1/child/tasks/:userTaskId/submit", handler.SubmitMyTask)
+
+	mockUserTaskRepo.On("SubmitTask", mock.Anything, 7, childID).Return(nil)
+	mockTaskService.On("AutoApproveTask", mock.Anything, 7).Return(true, nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/child/tasks/7/submit", nil)
+	resp, err := app.Test(req, -1)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var result map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.Equal(t, "Task submitted and auto-approved", result["message"])
+	assert.Equal(t, map[string]interface{}{"auto_approved": true}, result["data"])
+	mockUserTaskRepo.AssertExpectations(t)
+	mockTaskService.AssertExpectations(t)
+}
+
 func TestChildHandler_GetMyPoints(t *testing.T) {
 	childID := 1
+	level := &models.LevelProgress{Level: 2, Title: "Helper", TotalXP: 175, LevelXP: 100, NextLevel: 3, NextLevelXP: 250, XPToNextLevel: 75, ProgressPercent: 50}
 
 	tests := []struct {
 		name           string
-		setupMock      func(mockRepo *mocks.MockPointTransactionRepository, childID int)
+		setupMock      func(mockRepo *mocks.MockPointTransactionRepository, mockLevelService *serviceMocks.MockLevelService, childID int)
 		expectedStatus int
 		expectedBody   map[string]interface{}
 	}{
 		{
 			name: "Success",
-			setupMock: func(mockRepo *mocks.MockPointTransactionRepository, childID int) {
+			setupMock: func(mockRepo *mocks.MockPointTransactionRepository, mockLevelService *serviceMocks.MockLevelService, childID int) {
 				mockRepo.On("CalculateTotalPointsByUserID", mock.Anything, childID).Return(500, nil)
+				mockLevelService.On("GetLevelProgress", mock.Anything, childID).Return(level, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
@@ -343,12 +375,22 @@ func TestChildHandler_GetMyPoints(t *testing.T) {
 				"message": "Points balance retrieved",
 				"data": map[string]interface{}{
 					"total_points": float64(500),
+					"level": map[string]interface{}{
+						"level":            float64(2),
+						"title":            "Helper",
+						"total_xp":         float64(175),
+						"level_xp":         float64(100),
+						"next_level":       float64(3),
+						"next_level_xp":    float64(250),
+						"xp_to_next_level": float64(75),
+						"progress_percent": float64(50),
+					},
 				},
 			},
 		},
 		{
 			name: "Database Error",
-			setupMock: func(mockRepo *mocks.MockPointTransactionRepository, childID int) {
+			setupMock: func(mockRepo *mocks.MockPointTransactionRepository, mockLevelService *serviceMocks.MockLevelService, childID int) {
 				mockRepo.On("CalculateTotalPointsByUserID", mock.Anything, childID).Return(0, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
@@ -357,11 +399,24 @@ func TestChildHandler_GetMyPoints(t *testing.T) {
 				"message": "An internal error occurred",
 			},
 		},
+		{
+			name: "Level Error",
+			setupMock: func(mockRepo *mocks.MockPointTransactionRepository, mockLevelService *serviceMocks.MockLevelService, childID int) {
+				mockRepo.On("CalculateTotalPointsByUserID", mock.Anything, childID).Return(500, nil)
+				mockLevelService.On("GetLevelProgress", mock.Anything, childID).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]interface{}{
+				"success": false,
+				"message": "An internal error occurred",
+			},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			app, handler, _, _, _, mockPointRepo, _ := setupChildHandler()
+			app, handler, _, _, _, mockPointRepo, _, _ := setupChildHandler()
+			mockLevelService := handler.LevelService.(*serviceMocks.MockLevelService)
 
 			// Add JWT middleware to simulate a logged-in child user
 			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
@@ -370,7 +425,7 @@ func TestChildHandler_GetMyPoints(t *testing.T) {
 			app.Get("/api/v1/child/points", handler.GetMyPoints)
 
 			// Setup mock expectations
-			tc.setupMock(mockPointRepo, childID)
+			tc.setupMock(mockPointRepo, mockLevelService, childID)
 
 			// Prepare request
 			req := httptest.NewRequest(http.MethodGet, "/api/v1/child/points", nil)
@@ -476,7 +531,7 @@ func TestChildHandler_GetMyPointHistory(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			app, handler, _, _, _, mockPointRepo, _ := setupChildHandler()
+			app, handler, _, _, _, mockPointRepo, _, _ := setupChildHandler()
 
 			// Add JWT middleware to simulate a logged-in child user
 			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
@@ -595,7 +650,7 @@ func TestChildHandler_GetMyClaims(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			app, handler, _, _, mockUserRewardRepo, _, _ := setupChildHandler()
+			app, handler, _, _, mockUserRewardRepo, _, _, _ := setupChildHandler()
 
 			// Add JWT middleware to simulate a logged-in child user
 			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
@@ -698,6 +753,26 @@ func TestChildHandler_ClaimReward(t *testing.T) {
 				"message": "An internal error occurred",
 			},
 		},
+		{
+			name:     "Out Of Stock",
+			rewardID: "4",
+			setupMock: func(mockService *serviceMocks.MockRewardService, rewardID, childID int) {
+				noStock := 0
+				mockService.On("ClaimReward", mock.Anything, childID, rewardID).Return(0, &service.RewardUnavailableError{
+					Availability: models.RewardAvailability{Reason: models.RewardUnavailableOutOfStock, RemainingStock: &noStock},
+				})
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: map[string]interface{}{
+				"success": false,
+				"message": "reward is out of stock",
+				"data": map[string]interface{}{
+					"claimable":       false,
+					"reason":          "out_of_stock",
+					"remaining_stock": float64(0),
+				},
+			},
+		},
 		{
 			name:     "Database Error",
 			rewardID: "3",
@@ -714,7 +789,7 @@ func TestChildHandler_ClaimReward(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			app, handler, _, _, _, _, mockRewardService := setupChildHandler()
+			app, handler, _, _, _, _, mockRewardService, _ := setupChildHandler()
 
 			// Add JWT middleware to simulate a logged-in child user
 			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
@@ -747,3 +822,70 @@ func TestChildHandler_ClaimReward(t *testing.T) {
 		})
 	}
 }
+
+func TestChildHandler_ConfirmClaimReceived(t *testing.T) {
+	childID := 1
+	claimID := 12
+
+	tests := []struct {
+		name           string
+		setupMock      func(mockRewardService *serviceMocks.MockRewardService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "Success",
+			setupMock: func(mockRewardService *serviceMocks.MockRewardService) {
+				mockRewardService.On("ConfirmClaimReceipt", mock.Anything, claimID, childID).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Reward receipt confirmed",
+		},
+		{
+			name: "Claim Still Pending",
+			setupMock: func(mockRewardService *serviceMocks.MockRewardService) {
+				mockRewardService.On("ConfirmClaimReceipt", mock.Anything, claimID, childID).
+					Return(errors.New("cannot confirm_receipt claim: current status is 'pending', expected 'approved' or 'scheduled' or 'fulfilled'"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "cannot confirm_receipt claim: current status is 'pending', expected 'approved' or 'scheduled' or 'fulfilled'",
+		},
+		{
+			name: "Not Your Claim",
+			setupMock: func(mockRewardService *serviceMocks.MockRewardService) {
+				mockRewardService.On("ConfirmClaimReceipt", mock.Anything, claimID, childID).
+					Return(errors.New("forbidden: this claim does not belong to you"))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedMsg:    "forbidden: this claim does not belong to you",
+		},
+		{
+			name: "Claim Not Found",
+			setupMock: func(mockRewardService *serviceMocks.MockRewardService) {
+				mockRewardService.On("ConfirmClaimReceipt", mock.Anything, claimID, childID).Return(pgx.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedMsg:    "Claim not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			app, handler, _, _, _, _, mockRewardService, _ := setupChildHandler()
+			tc.setupMock(mockRewardService)
+			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
+			app.Patch("/api/v1/child/claims/:claimId/received", handler.ConfirmClaimReceived)
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/child/claims/"+strconv.Itoa(claimID)+"/received", nil)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var result map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			assert.Equal(t, tc.expectedMsg, result["message"])
+			mockRewardService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/api/v1/handlers"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCurrencyHandler_CreateExchangeRule(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockCurrencyService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "Success",
+			body: models.CreateExchangeRuleInput{FromCurrencyID: 0, ToCurrencyID: 3, FromAmount: 10, ToAmount: 1},
+			setupMock: func(mockService *serviceMocks.MockCurrencyService) {
+				mockService.On("CreateExchangeRule", mock.Anything, parentID, mock.AnythingOfType("*models.CreateExchangeRuleInput")).
+					Return(&models.CurrencyExchangeRule{ID: 1, CreatedByUserID: parentID, ToCurrencyID: 3, FromAmount: 10, ToAmount: 1}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedMsg:    "Exchange rule created successfully",
+		},
+		{
+			name:           "Validation Error - Zero Amount",
+			body:           models.CreateExchangeRuleInput{FromCurrencyID: 0, ToCurrencyID: 3, FromAmount: 0, ToAmount: 1},
+			setupMock:      func(mockService *serviceMocks.MockCurrencyService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name: "Unknown Currency",
+			body: models.CreateExchangeRuleInput{FromCurrencyID: 0, ToCurrencyID: 99, FromAmount: 10, ToAmount: 1},
+			setupMock: func(mockService *serviceMocks.MockCurrencyService) {
+				mockService.On("CreateExchangeRule", mock.Anything, parentID, mock.AnythingOfType("*models.CreateExchangeRuleInput")).
+					Return(nil, errors.New("invalid currency: currency not found"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "invalid currency: currency not found",
+		},
+		{
+			name: "Duplicate Pair",
+			body: models.CreateExchangeRuleInput{FromCurrencyID: 0, ToCurrencyID: 3, FromAmount: 5, ToAmount: 1},
+			setupMock: func(mockService *serviceMocks.MockCurrencyService) {
+				mockService.On("CreateExchangeRule", mock.Anything, parentID, mock.AnythingOfType("*models.CreateExchangeRuleInput")).
+					Return(nil, errors.New("exchange rule for this currency pair already exists"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedMsg:    "exchange rule for this currency pair already exists",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockCurrencyService)
+			tc.setupMock(mockService)
+			handler := handlers.NewCurrencyHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Post("/api/v1/parent/currency-exchange-rules", handler.CreateExchangeRule)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/parent/currency-exchange-rules", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCurrencyHandler_ExchangeCurrency(t *testing.T) {
+	childID := 10
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockCurrencyService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "Success",
+			body: models.ExchangeCurrencyInput{RuleID: 1, Amount: 30},
+			setupMock: func(mockService *serviceMocks.MockCurrencyService) {
+				mockService.On("Exchange", mock.Anything, childID, mock.AnythingOfType("*models.ExchangeCurrencyInput")).
+					Return(&models.CurrencyExchangeResult{RuleID: 1, ToCurrencyID: 3, Debited: 30, Credited: 3, DebitTransactionID: 7, CreditTransactionID: 8}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedMsg:    "Currency exchanged successfully",
+		},
+		{
+			name:           "Validation Error - Missing Rule",
+			body:           models.ExchangeCurrencyInput{Amount: 30},
+			setupMock:      func(mockService *serviceMocks.MockCurrencyService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name: "Rule Not Found",
+			body: models.ExchangeCurrencyInput{RuleID: 99, Amount: 30},
+			setupMock: func(mockService *serviceMocks.MockCurrencyService) {
+				mockService.On("Exchange", mock.Anything, childID, mock.AnythingOfType("*models.ExchangeCurrencyInput")).
+					Return(nil, pgx.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedMsg:    "Exchange rule not found",
+		},
+		{
+			name: "Amount Not A Multiple",
+			body: models.ExchangeCurrencyInput{RuleID: 1, Amount: 25},
+			setupMock: func(mockService *serviceMocks.MockCurrencyService) {
+				mockService.On("Exchange", mock.Anything, childID, mock.AnythingOfType("*models.ExchangeCurrencyInput")).
+					Return(nil, errors.New("cannot exchange: amount must be a multiple of 10"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "cannot exchange: amount must be a multiple of 10",
+		},
+		{
+			name: "Insufficient Balance",
+			body: models.ExchangeCurrencyInput{RuleID: 1, Amount: 30},
+			setupMock: func(mockService *serviceMocks.MockCurrencyService) {
+				mockService.On("Exchange", mock.Anything, childID, mock.AnythingOfType("*models.ExchangeCurrencyInput")).
+					Return(nil, fmt.Errorf("%w: only 20 are available to exchange", service.ErrInsufficientPoints))
+			},
+			expectedStatus: http.StatusPaymentRequired,
+			expectedMsg:    "insufficient points to claim reward: only 20 are available to exchange",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockCurrencyService)
+			tc.setupMock(mockService)
+			handler := handlers.NewCurrencyHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
+			app.Post("/api/v1/child/currency-exchanges", handler.ExchangeCurrency)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/child/currency-exchanges", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/api/v1/handlers"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestInterestHandler_SetInterestPolicy(t *testing.T) {
+	parentID := 1
+	childID := 10
+
+	tests := []struct {
+		name           string
+		childIDParam   string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockInterestService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name:         "Success",
+			childIDParam: "10",
+			body:         models.SetInterestPolicyInput{RateBasisPoints: 200, Schedule: models.InterestScheduleWeekly, MaxPointsPerPeriod: 50},
+			setupMock: func(mockService *serviceMocks.MockInterestService) {
+				mockService.On("SetPolicy", mock.Anything, parentID, childID, mock.AnythingOfType("*models.SetInterestPolicyInput")).
+					Return(&models.InterestPolicy{ChildID: childID, RateBasisPoints: 200, Schedule: models.InterestScheduleWeekly, Rounding: models.InterestRoundingFloor, MaxPointsPerPeriod: 50}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Interest policy saved successfully",
+		},
+		{
+			name:           "Validation Error - Invalid Schedule",
+			childIDParam:   "10",
+			body:           map[string]interface{}{"rate_basis_points": 200, "schedule": "daily"},
+			setupMock:      func(mockService *serviceMocks.MockInterestService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name:           "Validation Error - Rate Too High",
+			childIDParam:   "10",
+			body:           models.SetInterestPolicyInput{RateBasisPoints: 10001, Schedule: models.InterestScheduleMonthly},
+			setupMock:      func(mockService *serviceMocks.MockInterestService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name:         "Forbidden - Not Parent",
+			childIDParam: "10",
+			body:         models.SetInterestPolicyInput{RateBasisPoints: 100, Schedule: models.InterestScheduleMonthly},
+			setupMock: func(mockService *serviceMocks.MockInterestService) {
+				mockService.On("SetPolicy", mock.Anything, parentID, childID, mock.AnythingOfType("*models.SetInterestPolicyInput")).
+					Return(nil, errors.New("forbidden: you are not authorized to manage interest for this child"))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedMsg:    "Forbidden: You are not authorized for this action",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockInterestService)
+			tc.setupMock(mockService)
+			handler := handlers.NewInterestHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Put("/api/v1/parent/children/:childId/interest-policy", handler.SetInterestPolicy)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/parent/children/"+tc.childIDParam+"/interest-policy", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestInterestHandler_SetFamilyInterestPolicy(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockInterestService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "Success - Applies To All Children",
+			body: models.SetInterestPolicyInput{RateBasisPoints: 200, Schedule: models.InterestScheduleMonthly},
+			setupMock: func(mockService *serviceMocks.MockInterestService) {
+				// childID 0 = kebijakan untuk semua anak parent
+				mockService.On("SetPolicy", mock.Anything, parentID, 0, mock.AnythingOfType("*models.SetInterestPolicyInput")).
+					Return(&models.InterestPolicy{ID: 1, CreatedByUserID: parentID, RateBasisPoints: 200, Schedule: models.InterestScheduleMonthly, Rounding: models.InterestRoundingFloor}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Interest policy saved successfully",
+		},
+		{
+			name:           "Validation Error - Invalid Schedule",
+			body:           map[string]interface{}{"rate_basis_points": 200, "schedule": "daily"},
+			setupMock:      func(mockService *serviceMocks.MockInterestService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockInterestService)
+			tc.setupMock(mockService)
+			handler := handlers.NewInterestHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Put("/api/v1/parent/interest-policy", handler.SetFamilyInterestPolicy)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/parent/interest-policy", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestInterestHandler_GetMyInterestPreview(t *testing.T) {
+	childID := 10
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(mockService *serviceMocks.MockInterestService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name:  "Success - Default Periods",
+			query: "",
+			setupMock: func(mockService *serviceMocks.MockInterestService) {
+				mockService.On("PreviewForChild", mock.Anything, childID, 12, mock.AnythingOfType("time.Time")).
+					Return(&models.InterestProjection{StartingBalance: 100, TotalInterest: 12, EndingBalance: 112, Periods: []models.InterestProjectionPeriod{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Interest projection calculated successfully",
+		},
+		{
+			name:  "Success - Custom Periods",
+			query: "?periods=3",
+			setupMock: func(mockService *serviceMocks.MockInterestService) {
+				mockService.On("PreviewForChild", mock.Anything, childID, 3, mock.AnythingOfType("time.Time")).
+					Return(&models.InterestProjection{StartingBalance: 100, TotalInterest: 3, EndingBalance: 103, Periods: []models.InterestProjectionPeriod{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Interest projection calculated successfully",
+		},
+		{
+			name:           "Invalid Periods",
+			query:          "?periods=61",
+			setupMock:      func(mockService *serviceMocks.MockInterestService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Invalid periods parameter: must be between 1 and 60",
+		},
+		{
+			name:  "No Policy",
+			query: "",
+			setupMock: func(mockService *serviceMocks.MockInterestService) {
+				mockService.On("PreviewForChild", mock.Anything, childID, 12, mock.AnythingOfType("time.Time")).
+					Return(nil, pgx.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedMsg:    "No interest policy set",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockInterestService)
+			tc.setupMock(mockService)
+			handler := handlers.NewInterestHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
+			app.Get("/api/v1/child/points/interest-preview", handler.GetMyInterestPreview)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/child/points/interest-preview"+tc.query, nil)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
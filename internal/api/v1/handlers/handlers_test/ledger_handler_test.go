@@ -0,0 +1,163 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/api/v1/handlers"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLedgerHandler_VerifyLedger(t *testing.T) {
+	adminID := 99
+
+	tests := []struct {
+		name            string
+		query           string
+		setupMock       func(mockService *serviceMocks.MockLedgerService)
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{
+			name:  "Intact Ledger",
+			query: "",
+			setupMock: func(mockService *serviceMocks.MockLedgerService) {
+				mockService.On("VerifyLedger", mock.Anything, 0).
+					Return(&models.LedgerVerificationReport{CheckedUsers: 2, CheckedEntries: 7, Valid: true, Issues: []models.LedgerIntegrityIssue{}}, nil)
+			},
+			expectedStatus:  http.StatusOK,
+			expectedMessage: "Point ledger is intact",
+		},
+		{
+			name:  "Tampered Entry",
+			query: "?user_id=10",
+			setupMock: func(mockService *serviceMocks.MockLedgerService) {
+				mockService.On("VerifyLedger", mock.Anything, 10).Return(&models.LedgerVerificationReport{
+					CheckedUsers:   1,
+					CheckedEntries: 3,
+					Issues: []models.LedgerIntegrityIssue{
+						{UserID: 10, TransactionID: 21, Seq: 2, Kind: models.LedgerIssueHashMismatch, Detail: "entry contents do not match entry_hash"},
+					},
+				}, nil)
+			},
+			expectedStatus:  http.StatusOK,
+			expectedMessage: "Point ledger integrity issues found",
+		},
+		{
+			name:            "Invalid User ID",
+			query:           "?user_id=abc",
+			setupMock:       func(mockService *serviceMocks.MockLedgerService) {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "Invalid user_id query parameter",
+		},
+		{
+			name:  "Service Error",
+			query: "",
+			setupMock: func(mockService *serviceMocks.MockLedgerService) {
+				mockService.On("VerifyLedger", mock.Anything, 0).Return(nil, errors.New("database error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedMessage: "Failed to verify point ledger",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockLedgerService)
+			tc.setupMock(mockService)
+			handler := handlers.NewLedgerHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(adminID, "admin_user", "Admin"))
+			app.Get("/api/v1/admin/ledger/verify", handler.VerifyLedger)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/ledger/verify"+tc.query, nil)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMessage, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerHandler_ReverseTransaction(t *testing.T) {
+	adminID := 99
+	transactionID := 21
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockLedgerService)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			body: models.ReversePointTransactionInput{Reason: "Points awarded twice"},
+			setupMock: func(mockService *serviceMocks.MockLedgerService) {
+				mockService.On("ReverseTransaction", mock.Anything, adminID, transactionID, &models.ReversePointTransactionInput{Reason: "Points awarded twice"}).
+					Return(&models.PointTransaction{ID: 30, UserID: 10, ChangeAmount: -20, TransactionType: models.TransactionTypeCorrection, ReversesTransactionID: transactionID}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Validation Failed",
+			body:           models.ReversePointTransactionInput{Reason: ""},
+			setupMock:      func(mockService *serviceMocks.MockLedgerService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Already Reversed",
+			body: models.ReversePointTransactionInput{Reason: "Points awarded twice"},
+			setupMock: func(mockService *serviceMocks.MockLedgerService) {
+				mockService.On("ReverseTransaction", mock.Anything, adminID, transactionID, mock.Anything).
+					Return(nil, errors.New("cannot reverse transaction: it has already been reversed"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Not Found",
+			body: models.ReversePointTransactionInput{Reason: "Points awarded twice"},
+			setupMock: func(mockService *serviceMocks.MockLedgerService) {
+				mockService.On("ReverseTransaction", mock.Anything, adminID, transactionID, mock.Anything).Return(nil, pgx.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockLedgerService)
+			tc.setupMock(mockService)
+			handler := handlers.NewLedgerHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(adminID, "admin_user", "Admin"))
+			app.Post("/api/v1/admin/point-transactions/:transactionId/reverse", handler.ReverseTransaction)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/point-transactions/21/reverse", bytes.NewReader(bodyBytes))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/api/v1/handlers"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLevelHandler_GetChildLevel(t *testing.T) {
+	parentID := 1
+	childID := 2
+
+	tests := []struct {
+		name           string
+		childIDParam   string
+		setupMock      func(mockService *serviceMocks.MockLevelService)
+		expectedStatus int
+		expectedLevel  float64
+	}{
+		{
+			name:         "Success",
+			childIDParam: "2",
+			setupMock: func(mockService *serviceMocks.MockLevelService) {
+				mockService.On("GetChildLevel", mock.Anything, parentID, childID).Return(&models.LevelOverview{
+					ChildID:  childID,
+					Progress: models.LevelProgress{Level: 2, Title: "Helper", TotalXP: 120, LevelXP: 100, NextLevel: 3, NextLevelXP: 250, XPToNextLevel: 130, ProgressPercent: 13},
+					Levels: []models.Level{
+						{Level: 1, XPRequired: 0, Title: "Beginner"},
+						{Level: 2, XPRequired: 100, Title: "Helper", UnlockedRewards: []models.LevelReward{{RewardID: 5, RewardName: "Movie night", MinLevel: 2}}},
+					},
+					LevelUps: []models.LevelUp{{ID: 1, UserID: childID, Level: 2, Title: "Helper", TotalXP: 120}},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedLevel:  2,
+		},
+		{
+			name:           "Invalid Child ID",
+			childIDParam:   "abc",
+			setupMock:      func(mockService *serviceMocks.MockLevelService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "Not Parent",
+			childIDParam: "2",
+			setupMock: func(mockService *serviceMocks.MockLevelService) {
+				mockService.On("GetChildLevel", mock.Anything, parentID, childID).
+					Return(nil, errors.New("forbidden: you are not authorized to view this child's level"))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockLevelService)
+			tc.setupMock(mockService)
+			handler := handlers.NewLevelHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Get("/api/v1/parent/children/:childId/level", handler.GetChildLevel)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/parent/children/"+tc.childIDParam+"/level", nil)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			if tc.expectedStatus == http.StatusOK {
+				var result map[string]interface{}
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+				progress := result["data"].(map[string]interface{})["progress"].(map[string]interface{})
+				assert.Equal(t, tc.expectedLevel, progress["level"])
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLevelHandler_GetMyLevel(t *testing.T) {
+	childID := 2
+
+	tests := []struct {
+		name           string
+		setupMock      func(mockService *serviceMocks.MockLevelService)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			setupMock: func(mockService *serviceMocks.MockLevelService) {
+				mockService.On("GetMyLevel", mock.Anything, childID).Return(&models.LevelOverview{
+					ChildID:  childID,
+					Progress: models.LevelProgress{Level: 1, Title: "Beginner", NextLevel: 2, NextLevelXP: 100, XPToNextLevel: 100},
+					Levels:   []models.Level{{Level: 1, XPRequired: 0, Title: "Beginner"}, {Level: 2, XPRequired: 100, Title: "Helper"}},
+					LevelUps: []models.LevelUp{},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Service Error",
+			setupMock: func(mockService *serviceMocks.MockLevelService) {
+				mockService.On("GetMyLevel", mock.Anything, childID).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockLevelService)
+			tc.setupMock(mockService)
+			handler := handlers.NewLevelHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
+			app.Get("/api/v1/child/level", handler.GetMyLevel)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/child/level", nil)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/rakaarfi/digital-parenting-app-be/internal/api/v1/handlers"
 	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
 	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
 	"github.com/rakaarfi/digital-parenting-app-be/internal/repository/mocks"
 	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
 	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
@@ -29,33 +30,38 @@ func TestGetMyChildren(t *testing.T) {
 
 	tests := []struct {
 		name           string
-		setupMock      func(mockRepo *mocks.MockUserRelationshipRepository, parentID int)
+		setupMock      func(mockRepo *mocks.MockUserRelationshipRepository, mockLevelService *serviceMocks.MockLevelService, parentID int)
 		expectedStatus int
 		expectedBody   map[string]interface{}
 	}{
 		{
 			name: "Success",
-			setupMock: func(mockRepo *mocks.MockUserRelationshipRepository, parentID int) {
+			setupMock: func(mockRepo *mocks.MockUserRelationshipRepository, mockLevelService *serviceMocks.MockLevelService, parentID int) {
 				mockChildren := []models.User{
 					{ID: 2, Username: "child1", Email: "child1@example.com", RoleID: 2},
 					{ID: 3, Username: "child2", Email: "child2@example.com", RoleID: 2},
 				}
 				mockRepo.On("GetChildrenByParentID", mock.Anything, parentID).Return(mockChildren, nil)
+				mockLevelService.On("GetLevelProgressForUsers", mock.Anything, []int{2, 3}).Return(map[int]models.LevelProgress{
+					2: {Level: 3, Title: "Go-Getter", TotalXP: 300},
+					3: {Level: 1, Title: "Beginner", TotalXP: 0},
+				}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
 				"success": true,
 				"message": "Children retrieved successfully",
 				"data": []interface{}{
-					map[string]interface{}{"id": float64(2), "username": "child1", "email": "child1@example.com", "role_id": float64(2)},
-					map[string]interface{}{"id": float64(3), "username": "child2", "email": "child2@example.com", "role_id": float64(2)},
+					map[string]interface{}{"id": float64(2), "username": "child1", "email": "child1@example.com", "role_id": float64(2), "level": float64(3)},
+					map[string]interface{}{"id": float64(3), "username": "child2", "email": "child2@example.com", "role_id": float64(2), "level": float64(1)},
 				},
 			},
 		},
 		{
 			name: "No Children Found",
-			setupMock: func(mockRepo *mocks.MockUserRelationshipRepository, parentID int) {
+			setupMock: func(mockRepo *mocks.MockUserRelationshipRepository, mockLevelService *serviceMocks.MockLevelService, parentID int) {
 				mockRepo.On("GetChildrenByParentID", mock.Anything, parentID).Return([]models.User{}, nil)
+				mockLevelService.On("GetLevelProgressForUsers", mock.Anything, []int{}).Return(map[int]models.LevelProgress{}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
@@ -66,7 +72,7 @@ func TestGetMyChildren(t *testing.T) {
 		},
 		{
 			name: "Database Error",
-			setupMock: func(mockRepo *mocks.MockUserRelationshipRepository, parentID int) {
+			setupMock: func(mockRepo *mocks.MockUserRelationshipRepository, mockLevelService *serviceMocks.MockLevelService, parentID int) {
 				mockRepo.On("GetChildrenByParentID", mock.Anything, parentID).Return(nil, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
@@ -75,6 +81,19 @@ func TestGetMyChildren(t *testing.T) {
 				"message": "An internal error occurred",
 			},
 		},
+		{
+			name: "Level Error",
+			setupMock: func(mockRepo *mocks.MockUserRelationshipRepository, mockLevelService *serviceMocks.MockLevelService, parentID int) {
+				mockChildren := []models.User{{ID: 2, Username: "child1", Email: "child1@example.com", RoleID: 2}}
+				mockRepo.On("GetChildrenByParentID", mock.Anything, parentID).Return(mockChildren, nil)
+				mockLevelService.On("GetLevelProgressForUsers", mock.Anything, []int{2}).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]interface{}{
+				"success": false,
+				"message": "An internal error occurred",
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -92,6 +111,8 @@ func TestGetMyChildren(t *testing.T) {
 			mockRewardService := new(serviceMocks.MockRewardService)
 			mockUserService := new(serviceMocks.MockUserService)
 			mockInvitationService := new(serviceMocks.MockInvitationService)
+			mockLevelService := new(serviceMocks.MockLevelService)
+			mockBadgeService := new(serviceMocks.MockBadgeService)
 
 			parentHandler := handlers.NewParentHandler(
 				mockUserRelRepo,
@@ -105,6 +126,8 @@ func TestGetMyChildren(t *testing.T) {
 				mockRewardService,
 				mockUserService,
 				mockInvitationService,
+				mockLevelService,
+				mockBadgeService,
 			)
 
 			// Add JWT middleware to simulate a logged-in parent user
@@ -114,7 +137,7 @@ func TestGetMyChildren(t *testing.T) {
 			app.Get("/api/v1/parent/children", parentHandler.GetMyChildren)
 
 			// Setup mock expectations
-			tc.setupMock(mockUserRelRepo, parentID)
+			tc.setupMock(mockUserRelRepo, mockLevelService, parentID)
 
 			// Prepare request
 			req := httptest.NewRequest(http.MethodGet, "/api/v1/parent/children", nil)
@@ -151,12 +174,15 @@ func TestGetMyChildren(t *testing.T) {
 						assert.Equal(t, expectedChild["username"], actualChild["username"])
 						assert.Equal(t, expectedChild["email"], actualChild["email"])
 						assert.Equal(t, expectedChild["role_id"], actualChild["role_id"])
+						actualLevel := actualChild["level"].(map[string]interface{})
+						assert.Equal(t, expectedChild["level"], actualLevel["level"])
 					}
 				}
 			}
 
 			// Verify mock expectations
 			mockUserRelRepo.AssertExpectations(t)
+			mockLevelService.AssertExpectations(t)
 		})
 	}
 }
@@ -288,6 +314,8 @@ func TestAddChild(t *testing.T) {
 			mockRewardService := new(serviceMocks.MockRewardService)
 			mockUserService := new(serviceMocks.MockUserService)
 			mockInvitationService := new(serviceMocks.MockInvitationService)
+			mockLevelService := new(serviceMocks.MockLevelService)
+			mockBadgeService := new(serviceMocks.MockBadgeService)
 
 			parentHandler := handlers.NewParentHandler(
 				mockUserRelRepo,
@@ -301,6 +329,8 @@ func TestAddChild(t *testing.T) {
 				mockRewardService,
 				mockUserService,
 				mockInvitationService,
+				mockLevelService,
+				mockBadgeService,
 			)
 
 			// Add JWT middleware to simulate a logged-in parent user
@@ -425,6 +455,8 @@ func TestRemoveChild(t *testing.T) {
 			mockRewardService := new(serviceMocks.MockRewardService)
 			mockUserService := new(serviceMocks.MockUserService)
 			mockInvitationService := new(serviceMocks.MockInvitationService)
+			mockLevelService := new(serviceMocks.MockLevelService)
+			mockBadgeService := new(serviceMocks.MockBadgeService)
 
 			parentHandler := handlers.NewParentHandler(
 				mockUserRelRepo,
@@ -438,6 +470,8 @@ func TestRemoveChild(t *testing.T) {
 				mockRewardService,
 				mockUserService,
 				mockInvitationService,
+				mockLevelService,
+				mockBadgeService,
 			)
 
 			// Add JWT middleware to simulate a logged-in parent user
@@ -573,6 +607,8 @@ func TestCreateChildAccount(t *testing.T) {
 			mockRewardService := new(serviceMocks.MockRewardService)
 			mockUserService := new(serviceMocks.MockUserService)
 			mockInvitationService := new(serviceMocks.MockInvitationService)
+			mockLevelService := new(serviceMocks.MockLevelService)
+			mockBadgeService := new(serviceMocks.MockBadgeService)
 
 			parentHandler := handlers.NewParentHandler(
 				mockUserRelRepo,
@@ -586,6 +622,8 @@ func TestCreateChildAccount(t *testing.T) {
 				mockRewardService,
 				mockUserService,
 				mockInvitationService,
+				mockLevelService,
+				mockBadgeService,
 			)
 
 			// Add JWT middleware to simulate a logged-in parent user
@@ -968,7 +1006,7 @@ func TestParentHandler_AssignTaskToChild(t *testing.T) {
 				mockUserTaskRepo.On("CheckExistingActiveTask", mock.Anything, childID, taskID).Return(false, nil)
 
 				// Mock AssignTask
-				mockUserTaskRepo.On("AssignTask", mock.Anything, childID, taskID, parentID).Return(5, nil)
+				mockUserTaskRepo.On("AssignTask", mock.Anything, childID, taskID, parentID, (*time.Time)(nil)).Return(5, nil)
 			},
 			expectedStatus: http.StatusCreated,
 			expectedBody: map[string]interface{}{
@@ -1097,7 +1135,7 @@ func TestParentHandler_AssignTaskToChild(t *testing.T) {
 				mockUserTaskRepo.On("CheckExistingActiveTask", mock.Anything, childID, taskID).Return(false, nil)
 
 				// Mock AssignTask with error
-				mockUserTaskRepo.On("AssignTask", mock.Anything, childID, taskID, parentID).Return(0, errors.New("database error"))
+				mockUserTaskRepo.On("AssignTask", mock.Anything, childID, taskID, parentID, (*time.Time)(nil)).Return(0, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: map[string]interface{}{
@@ -1105,6 +1143,30 @@ func TestParentHandler_AssignTaskToChild(t *testing.T) {
 				"message": "An internal error occurred",
 			},
 		},
+		{
+			name: "Conflict - Concurrent Assignment",
+			input: models.AssignTaskInput{
+				TaskID: taskID,
+			},
+			setupMock: func(mockUserRelRepo *mocks.MockUserRelationshipRepository, mockTaskRepo *mocks.MockTaskRepository, mockUserTaskRepo *mocks.MockUserTaskRepository, parentID, childID, taskID int) {
+				mockUserRelRepo.On("IsParentOf", mock.Anything, parentID, childID).Return(true, nil)
+				mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&models.Task{
+					ID:              taskID,
+					TaskName:        "Test Task",
+					TaskPoint:       100,
+					CreatedByUserID: parentID,
+				}, nil)
+
+				// Cek awal lolos, tetapi penugasan lain menang saat repo mengecek ulang di bawah kunci
+				mockUserTaskRepo.On("CheckExistingActiveTask", mock.Anything, childID, taskID).Return(false, nil)
+				mockUserTaskRepo.On("AssignTask", mock.Anything, childID, taskID, parentID, (*time.Time)(nil)).Return(0, repository.ErrTaskAlreadyActive)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: map[string]interface{}{
+				"success": false,
+				"message": "Task 'Test Task' is already assigned or submitted for this child and needs to be completed or verified first.",
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -1364,6 +1426,147 @@ func TestParentHandler_VerifySubmittedTask(t *testing.T) {
 	}
 }
 
+func TestParentHandler_RevertTaskVerification(t *testing.T) {
+	parentID := 1
+	userTaskID := 5
+
+	tests := []struct {
+		name           string
+		input          models.RevertVerificationInput
+		setupMock      func(mockTaskService *serviceMocks.MockTaskService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name:  "Success",
+			input: models.RevertVerificationInput{Reason: "Approved by mistake"},
+			setupMock: func(mockTaskService *serviceMocks.MockTaskService) {
+				mockTaskService.On("RevertVerification", mock.Anything, userTaskID, parentID, "Approved by mistake").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Task verification reverted successfully",
+		},
+		{
+			name:           "Validation Error - Missing Reason",
+			input:          models.RevertVerificationInput{},
+			setupMock:      func(mockTaskService *serviceMocks.MockTaskService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name:  "Revert Window Passed",
+			input: models.RevertVerificationInput{Reason: "Too late"},
+			setupMock: func(mockTaskService *serviceMocks.MockTaskService) {
+				mockTaskService.On("RevertVerification", mock.Anything, userTaskID, parentID, "Too late").
+					Return(errors.New("cannot revert verification: verifications can only be reverted within 24 hours"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "cannot revert verification: verifications can only be reverted within 24 hours",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			mockTaskService := new(serviceMocks.MockTaskService)
+			tc.setupMock(mockTaskService)
+			parentHandler := &handlers.ParentHandler{
+				TaskService: mockTaskService,
+				Validate:    validator.New(),
+			}
+
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Patch("/api/v1/parent/tasks/:userTaskId/revert", parentHandler.RevertTaskVerification)
+
+			bodyBytes, _ := json.Marshal(tc.input)
+			req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/parent/tasks/%d/revert", userTaskID), bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var result map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			assert.Equal(t, tc.expectedMsg, result["message"])
+			mockTaskService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestParentHandler_ReassignTask(t *testing.T) {
+	parentID := 1
+	userTaskID := 5
+
+	tests := []struct {
+		name           string
+		input          models.ReassignTaskInput
+		setupMock      func(mockTaskService *serviceMocks.MockTaskService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name:  "Success",
+			input: models.ReassignTaskInput{ChildID: 3},
+			setupMock: func(mockTaskService *serviceMocks.MockTaskService) {
+				mockTaskService.On("ReassignTask", mock.Anything, userTaskID, parentID, 3).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Task reassigned successfully",
+		},
+		{
+			name:  "Not Parent Of Target Child",
+			input: models.ReassignTaskInput{ChildID: 9},
+			setupMock: func(mockTaskService *serviceMocks.MockTaskService) {
+				mockTaskService.On("ReassignTask", mock.Anything, userTaskID, parentID, 9).
+					Return(errors.New("forbidden: you are not authorized to assign tasks to this child"))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedMsg:    "Forbidden: You are not authorized for this action",
+		},
+		{
+			name:  "Task Already Submitted",
+			input: models.ReassignTaskInput{ChildID: 3},
+			setupMock: func(mockTaskService *serviceMocks.MockTaskService) {
+				mockTaskService.On("ReassignTask", mock.Anything, userTaskID, parentID, 3).
+					Return(errors.New("cannot reassign task: current status is 'submitted', expected 'assigned'"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "cannot reassign task: current status is 'submitted', expected 'assigned'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			mockTaskService := new(serviceMocks.MockTaskService)
+			tc.setupMock(mockTaskService)
+			parentHandler := &handlers.ParentHandler{
+				TaskService: mockTaskService,
+				Validate:    validator.New(),
+			}
+
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Patch("/api/v1/parent/tasks/:userTaskId/reassign", parentHandler.ReassignTask)
+
+			bodyBytes, _ := json.Marshal(tc.input)
+			req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/parent/tasks/%d/reassign", userTaskID), bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var result map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			assert.Equal(t, tc.expectedMsg, result["message"])
+			mockTaskService.AssertExpectations(t)
+		})
+	}
+}
+
 func TestParentHandler_GetPendingClaims(t *testing.T) {
 	parentID := 1
 
@@ -1533,12 +1736,31 @@ func TestParentHandler_ReviewRewardClaim(t *testing.T) {
 			},
 			setupMock: func(mockRewardService *serviceMocks.MockRewardService, claimID, parentID int) {
 				// Mock RewardService.ReviewClaim with approved status
-				mockRewardService.On("ReviewClaim", mock.Anything, claimID, parentID, models.UserRewardStatusApproved).Return(nil)
+				mockRewardService.On("ReviewClaim", mock.Anything, claimID, parentID, models.UserRewardStatusApproved).Return(&models.ClaimReviewResult{ClaimID: claimID, Status: models.UserRewardStatusApproved}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
 				"success": true,
 				"message": "Reward claim reviewed successfully",
+				"data":    map[string]interface{}{"claim_id": float64(5), "status": "approved"},
+			},
+		},
+		{
+			name: "Approval Recorded - Waiting For Quorum",
+			input: models.ReviewClaimInput{
+				Status: "approved",
+			},
+			setupMock: func(mockRewardService *serviceMocks.MockRewardService, claimID, parentID int) {
+				// Kebijakan persetujuan bersama: baru 1 dari 2 orang tua yang menyetujui
+				mockRewardService.On("ReviewClaim", mock.Anything, claimID, parentID, models.UserRewardStatusApproved).Return(&models.ClaimReviewResult{
+					ClaimID: claimID, Status: models.UserRewardStatusPending, Approvals: 1, RequiredApprovals: 2,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"success": true,
+				"message": "Approval recorded, waiting for other parents",
+				"data":    map[string]interface{}{"claim_id": float64(5), "status": "pending", "approvals": float64(1), "required_approvals": float64(2)},
 			},
 		},
 		{
@@ -1548,12 +1770,13 @@ func TestParentHandler_ReviewRewardClaim(t *testing.T) {
 			},
 			setupMock: func(mockRewardService *serviceMocks.MockRewardService, claimID, parentID int) {
 				// Mock RewardService.ReviewClaim with rejected status
-				mockRewardService.On("ReviewClaim", mock.Anything, claimID, parentID, models.UserRewardStatusRejected).Return(nil)
+				mockRewardService.On("ReviewClaim", mock.Anything, claimID, parentID, models.UserRewardStatusRejected).Return(&models.ClaimReviewResult{ClaimID: claimID, Status: models.UserRewardStatusRejected}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
 				"success": true,
 				"message": "Reward claim reviewed successfully",
+				"data":    map[string]interface{}{"claim_id": float64(5), "status": "rejected"},
 			},
 		},
 		{
@@ -1592,7 +1815,7 @@ func TestParentHandler_ReviewRewardClaim(t *testing.T) {
 			},
 			setupMock: func(mockRewardService *serviceMocks.MockRewardService, claimID, parentID int) {
 				// Mock RewardService.ReviewClaim returning not found error
-				mockRewardService.On("ReviewClaim", mock.Anything, claimID, parentID, models.UserRewardStatusApproved).Return(pgx.ErrNoRows)
+				mockRewardService.On("ReviewClaim", mock.Anything, claimID, parentID, models.UserRewardStatusApproved).Return(nil, pgx.ErrNoRows)
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedBody: map[string]interface{}{
@@ -1607,7 +1830,7 @@ func TestParentHandler_ReviewRewardClaim(t *testing.T) {
 			},
 			setupMock: func(mockRewardService *serviceMocks.MockRewardService, claimID, parentID int) {
 				// Mock RewardService.ReviewClaim returning forbidden error
-				mockRewardService.On("ReviewClaim", mock.Anything, claimID, parentID, models.UserRewardStatusApproved).Return(errors.New("forbidden: you are not authorized to review this claim"))
+				mockRewardService.On("ReviewClaim", mock.Anything, claimID, parentID, models.UserRewardStatusApproved).Return(nil, errors.New("forbidden: you are not authorized to review this claim"))
 			},
 			expectedStatus: http.StatusForbidden,
 			expectedBody: map[string]interface{}{
@@ -1622,7 +1845,7 @@ func TestParentHandler_ReviewRewardClaim(t *testing.T) {
 			},
 			setupMock: func(mockRewardService *serviceMocks.MockRewardService, claimID, parentID int) {
 				// Mock RewardService.ReviewClaim returning state error
-				mockRewardService.On("ReviewClaim", mock.Anything, claimID, parentID, models.UserRewardStatusApproved).Return(errors.New("cannot review claim: current status is 'approved', expected 'pending'"))
+				mockRewardService.On("ReviewClaim", mock.Anything, claimID, parentID, models.UserRewardStatusApproved).Return(nil, errors.New("cannot review claim: current status is 'approved', expected 'pending'"))
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody: map[string]interface{}{
@@ -1637,7 +1860,7 @@ func TestParentHandler_ReviewRewardClaim(t *testing.T) {
 			},
 			setupMock: func(mockRewardService *serviceMocks.MockRewardService, claimID, parentID int) {
 				// Mock RewardService.ReviewClaim returning insufficient points error
-				mockRewardService.On("ReviewClaim", mock.Anything, claimID, parentID, models.UserRewardStatusApproved).Return(errors.New("insufficient points: child does not have enough points for this reward"))
+				mockRewardService.On("ReviewClaim", mock.Anything, claimID, parentID, models.UserRewardStatusApproved).Return(nil, errors.New("insufficient points: child does not have enough points for this reward"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: map[string]interface{}{
@@ -1652,7 +1875,7 @@ func TestParentHandler_ReviewRewardClaim(t *testing.T) {
 			},
 			setupMock: func(mockRewardService *serviceMocks.MockRewardService, claimID, parentID int) {
 				// Mock RewardService.ReviewClaim returning database error
-				mockRewardService.On("ReviewClaim", mock.Anything, claimID, parentID, models.UserRewardStatusApproved).Return(errors.New("internal server error: could not update claim status"))
+				mockRewardService.On("ReviewClaim", mock.Anything, claimID, parentID, models.UserRewardStatusApproved).Return(nil, errors.New("internal server error: could not update claim status"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: map[string]interface{}{
@@ -1735,6 +1958,7 @@ func TestParentHandler_AdjustChildPoints(t *testing.T) {
 		name           string
 		input          models.AdjustPointsInput
 		setupMock      func(mockUserRelRepo *mocks.MockUserRelationshipRepository, mockPointRepo *mocks.MockPointTransactionRepository, parentID, childID int)
+		setupBadgeMock func(mockBadgeService *serviceMocks.MockBadgeService) // nil = evaluasi badge tidak dipanggil
 		expectedStatus int
 		expectedBody   map[string]interface{}
 	}{
@@ -1757,6 +1981,28 @@ func TestParentHandler_AdjustChildPoints(t *testing.T) {
 						tx.CreatedByUserID == parentID
 				})).Return(nil)
 			},
+			setupBadgeMock: func(mockBadgeService *serviceMocks.MockBadgeService) {
+				mockBadgeService.On("EvaluateChild", mock.Anything, childID).Return([]models.ChildBadge{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"success": true,
+				"message": "Points adjusted successfully for child 2 by 100",
+			},
+		},
+		{
+			name: "Success - Badge Evaluation Error Ignored",
+			input: models.AdjustPointsInput{
+				ChangeAmount: 100,
+				Notes:        "Bonus points for good behavior",
+			},
+			setupMock: func(mockUserRelRepo *mocks.MockUserRelationshipRepository, mockPointRepo *mocks.MockPointTransactionRepository, parentID, childID int) {
+				mockUserRelRepo.On("IsParentOf", mock.Anything, parentID, childID).Return(true, nil)
+				mockPointRepo.On("CreateTransaction", mock.Anything, mock.AnythingOfType("*models.PointTransaction")).Return(nil)
+			},
+			setupBadgeMock: func(mockBadgeService *serviceMocks.MockBadgeService) {
+				mockBadgeService.On("EvaluateChild", mock.Anything, childID).Return(nil, errors.New("database error"))
+			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
 				"success": true,
@@ -1901,12 +2147,14 @@ func TestParentHandler_AdjustChildPoints(t *testing.T) {
 			app := fiber.New()
 			mockUserRelRepo := new(mocks.MockUserRelationshipRepository)
 			mockPointRepo := new(mocks.MockPointTransactionRepository)
+			mockBadgeService := new(serviceMocks.MockBadgeService)
 
 			// Create a minimal ParentHandler with just what we need for this test
 			parentHandler := &handlers.ParentHandler{
-				UserRelRepo: mockUserRelRepo,
-				PointRepo:   mockPointRepo,
-				Validate:    validator.New(),
+				UserRelRepo:  mockUserRelRepo,
+				PointRepo:    mockPointRepo,
+				BadgeService: mockBadgeService,
+				Validate:     validator.New(),
 			}
 
 			// Add JWT middleware to simulate a logged-in parent user
@@ -1919,6 +2167,9 @@ func TestParentHandler_AdjustChildPoints(t *testing.T) {
 			if tc.name != "Invalid Child ID" {
 				tc.setupMock(mockUserRelRepo, mockPointRepo, parentID, childID)
 			}
+			if tc.setupBadgeMock != nil {
+				tc.setupBadgeMock(mockBadgeService)
+			}
 
 			// Prepare request body
 			bodyBytes, _ := json.Marshal(tc.input)
@@ -1959,6 +2210,7 @@ func TestParentHandler_AdjustChildPoints(t *testing.T) {
 			// Verify mock expectations
 			mockUserRelRepo.AssertExpectations(t)
 			mockPointRepo.AssertExpectations(t)
+			mockBadgeService.AssertExpectations(t)
 		})
 	}
 }
@@ -2390,6 +2642,8 @@ func TestParentHandler_CreateChildAccount(t *testing.T) {
 			mockRewardService := new(serviceMocks.MockRewardService)
 			mockUserService := new(serviceMocks.MockUserService)
 			mockInvitationService := new(serviceMocks.MockInvitationService)
+			mockLevelService := new(serviceMocks.MockLevelService)
+			mockBadgeService := new(serviceMocks.MockBadgeService)
 
 			parentHandler := handlers.NewParentHandler(
 				mockUserRelRepo,
@@ -2403,6 +2657,8 @@ func TestParentHandler_CreateChildAccount(t *testing.T) {
 				mockRewardService,
 				mockUserService,
 				mockInvitationService,
+				mockLevelService,
+				mockBadgeService,
 			)
 
 			// Add JWT middleware to simulate a logged-in parent user
@@ -2526,6 +2782,8 @@ func TestParentHandler_GenerateInvitationCode(t *testing.T) {
 			mockRewardService := new(serviceMocks.MockRewardService)
 			mockUserService := new(serviceMocks.MockUserService)
 			mockInvitationService := new(serviceMocks.MockInvitationService)
+			mockLevelService := new(serviceMocks.MockLevelService)
+			mockBadgeService := new(serviceMocks.MockBadgeService)
 
 			parentHandler := handlers.NewParentHandler(
 				mockUserRelRepo,
@@ -2539,6 +2797,8 @@ func TestParentHandler_GenerateInvitationCode(t *testing.T) {
 				mockRewardService,
 				mockUserService,
 				mockInvitationService,
+				mockLevelService,
+				mockBadgeService,
 			)
 
 			// Add JWT middleware to simulate a logged-in parent user
@@ -2589,3 +2849,287 @@ func TestParentHandler_GenerateInvitationCode(t *testing.T) {
 		})
 	}
 }
+
+func TestParentHandler_GetTaskTimeline(t *testing.T) {
+	parentID := 1
+	userTaskID := 5
+
+	tests := []struct {
+		name           string
+		setupMock      func(mockTaskService *serviceMocks.MockTaskService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "Success",
+			setupMock: func(mockTaskService *serviceMocks.MockTaskService) {
+				events := []models.UserTaskEvent{
+					{ID: 1, UserTaskID: userTaskID, Action: models.UserTaskActionAssign, ToStatus: models.UserTaskStatusAssigned, ActorUserID: parentID, ActorType: models.AuditActorUser},
+					{ID: 2, UserTaskID: userTaskID, Action: models.UserTaskActionExpire, FromStatus: models.UserTaskStatusAssigned, ToStatus: models.UserTaskStatusExpired, ActorType: models.AuditActorSystem, Reason: "Due date passed"},
+				}
+				mockTaskService.On("GetTaskTimeline", mock.Anything, userTaskID, parentID).Return(events, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Task timeline retrieved successfully",
+		},
+		{
+			name: "Forbidden - Not Parent Of Child",
+			setupMock: func(mockTaskService *serviceMocks.MockTaskService) {
+				mockTaskService.On("GetTaskTimeline", mock.Anything, userTaskID, parentID).
+					Return(nil, errors.New("forbidden: you are not authorized to view this task"))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedMsg:    "Forbidden: You are not authorized for this action",
+		},
+		{
+			name: "Not Found",
+			setupMock: func(mockTaskService *serviceMocks.MockTaskService) {
+				mockTaskService.On("GetTaskTimeline", mock.Anything, userTaskID, parentID).Return(nil, pgx.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedMsg:    "Resource not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			mockTaskService := new(serviceMocks.MockTaskService)
+			tc.setupMock(mockTaskService)
+			parentHandler := &handlers.ParentHandler{
+				TaskService: mockTaskService,
+				Validate:    validator.New(),
+			}
+
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Get("/api/v1/parent/tasks/:userTaskId/timeline", parentHandler.GetTaskTimeline)
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/parent/tasks/%d/timeline", userTaskID), nil)
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var result map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			assert.Equal(t, tc.expectedMsg, result["message"])
+			if tc.expectedStatus == http.StatusOK {
+				data, ok := result["data"].([]interface{})
+				assert.True(t, ok)
+				assert.Len(t, data, 2)
+			}
+			mockTaskService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestParentHandler_ScheduleClaimFulfillment(t *testing.T) {
+	parentID := 1
+	claimID := 12
+	deliveryDate := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+
+	tests := []struct {
+		name           string
+		deliveryDate   time.Time
+		setupMock      func(mockRewardService *serviceMocks.MockRewardService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name:         "Success",
+			deliveryDate: deliveryDate,
+			setupMock: func(mockRewardService *serviceMocks.MockRewardService) {
+				mockRewardService.On("ScheduleClaimFulfillment", mock.Anything, claimID, parentID, deliveryDate, "Saturday morning").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Reward delivery scheduled successfully",
+		},
+		{
+			name:           "Delivery Date In The Past",
+			deliveryDate:   time.Now().Add(-time.Hour).UTC().Truncate(time.Second),
+			setupMock:      func(mockRewardService *serviceMocks.MockRewardService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "delivery_date must be in the future",
+		},
+		{
+			name:         "Claim Not Approved",
+			deliveryDate: deliveryDate,
+			setupMock: func(mockRewardService *serviceMocks.MockRewardService) {
+				mockRewardService.On("ScheduleClaimFulfillment", mock.Anything, claimID, parentID, deliveryDate, "Saturday morning").
+					Return(errors.New("cannot schedule claim: current status is 'pending', expected 'approved' or 'scheduled'"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "cannot schedule claim: current status is 'pending', expected 'approved' or 'scheduled'",
+		},
+		{
+			name:         "Forbidden - Not Parent Of Child",
+			deliveryDate: deliveryDate,
+			setupMock: func(mockRewardService *serviceMocks.MockRewardService) {
+				mockRewardService.On("ScheduleClaimFulfillment", mock.Anything, claimID, parentID, deliveryDate, "Saturday morning").
+					Return(errors.New("forbidden: you are not authorized to manage claims for this child"))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedMsg:    "Forbidden: You are not authorized for this action",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			mockRewardService := new(serviceMocks.MockRewardService)
+			tc.setupMock(mockRewardService)
+			parentHandler := &handlers.ParentHandler{
+				RewardService: mockRewardService,
+				Validate:      validator.New(),
+			}
+
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Patch("/api/v1/parent/claims/:claimId/schedule", parentHandler.ScheduleClaimFulfillment)
+
+			body, _ := json.Marshal(models.ScheduleClaimInput{DeliveryDate: tc.deliveryDate, Note: "Saturday morning"})
+			req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/parent/claims/%d/schedule", claimID), bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var result map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			assert.Equal(t, tc.expectedMsg, result["message"])
+			mockRewardService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestParentHandler_SetRewardApprovalPolicy(t *testing.T) {
+	parentID := 1
+	childID := 10
+
+	tests := []struct {
+		name           string
+		input          models.SetRewardApprovalPolicyInput
+		setupMock      func(mockRewardService *serviceMocks.MockRewardService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name:  "Success",
+			input: models.SetRewardApprovalPolicyInput{PointsThreshold: 500, RequiredApprovals: 2},
+			setupMock: func(mockRewardService *serviceMocks.MockRewardService) {
+				mockRewardService.On("SetApprovalPolicy", mock.Anything, parentID, childID, mock.AnythingOfType("*models.SetRewardApprovalPolicyInput")).
+					Return(&models.RewardApprovalPolicy{ID: 1, CreatedByUserID: parentID, ChildID: childID, PointsThreshold: 500, RequiredApprovals: 2}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Reward approval policy saved successfully",
+		},
+		{
+			name:           "Validation Error - Single Approval",
+			input:          models.SetRewardApprovalPolicyInput{PointsThreshold: 500, RequiredApprovals: 1},
+			setupMock:      func(mockRewardService *serviceMocks.MockRewardService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name:  "Forbidden - Not Parent Of Child",
+			input: models.SetRewardApprovalPolicyInput{PointsThreshold: 500, RequiredApprovals: 2},
+			setupMock: func(mockRewardService *serviceMocks.MockRewardService) {
+				mockRewardService.On("SetApprovalPolicy", mock.Anything, parentID, childID, mock.AnythingOfType("*models.SetRewardApprovalPolicyInput")).
+					Return(nil, errors.New("forbidden: you are not authorized to manage approval policies for this child"))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedMsg:    "Forbidden: You are not authorized for this action",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			mockRewardService := new(serviceMocks.MockRewardService)
+			tc.setupMock(mockRewardService)
+			parentHandler := &handlers.ParentHandler{
+				RewardService: mockRewardService,
+				Validate:      validator.New(),
+			}
+
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Put("/api/v1/parent/children/:childId/reward-approval-policy", parentHandler.SetRewardApprovalPolicy)
+
+			body, _ := json.Marshal(tc.input)
+			req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/parent/children/%d/reward-approval-policy", childID), bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var result map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			assert.Equal(t, tc.expectedMsg, result["message"])
+			mockRewardService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestParentHandler_SetFamilyRewardApprovalPolicy(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name           string
+		input          models.SetRewardApprovalPolicyInput
+		setupMock      func(mockRewardService *serviceMocks.MockRewardService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name:  "Success - Applies To All Children",
+			input: models.SetRewardApprovalPolicyInput{PointsThreshold: 500, RequiredApprovals: 2},
+			setupMock: func(mockRewardService *serviceMocks.MockRewardService) {
+				// childID 0 = kebijakan untuk semua anak parent
+				mockRewardService.On("SetApprovalPolicy", mock.Anything, parentID, 0, mock.AnythingOfType("*models.SetRewardApprovalPolicyInput")).
+					Return(&models.RewardApprovalPolicy{ID: 1, CreatedByUserID: parentID, PointsThreshold: 500, RequiredApprovals: 2}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Reward approval policy saved successfully",
+		},
+		{
+			name:           "Validation Error - Too Many Approvals",
+			input:          models.SetRewardApprovalPolicyInput{PointsThreshold: 500, RequiredApprovals: 11},
+			setupMock:      func(mockRewardService *serviceMocks.MockRewardService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			mockRewardService := new(serviceMocks.MockRewardService)
+			tc.setupMock(mockRewardService)
+			parentHandler := &handlers.ParentHandler{
+				RewardService: mockRewardService,
+				Validate:      validator.New(),
+			}
+
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Put("/api/v1/parent/reward-approval-policy", parentHandler.SetFamilyRewardApprovalPolicy)
+
+			body, _ := json.Marshal(tc.input)
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/parent/reward-approval-policy", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var result map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			assert.Equal(t, tc.expectedMsg, result["message"])
+			mockRewardService.AssertExpectations(t)
+		})
+	}
+}
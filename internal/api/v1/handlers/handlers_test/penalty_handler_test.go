@@ -0,0 +1,216 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/api/v1/handlers"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPenaltyHandler_CreateInfractionType(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockPenaltyService)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			body: models.InfractionTypeInput{Name: "Late to bed", Points: 5, MaxPerDay: 1},
+			setupMock: func(mockService *serviceMocks.MockPenaltyService) {
+				mockService.On("CreateInfractionType", mock.Anything, parentID, &models.InfractionTypeInput{Name: "Late to bed", Points: 5, MaxPerDay: 1}).
+					Return(&models.InfractionType{ID: 3, CreatedByUserID: parentID, Name: "Late to bed", Points: 5, MaxPerDay: 1}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Validation Failed",
+			body:           models.InfractionTypeInput{Name: "Late to bed", Points: 0},
+			setupMock:      func(mockService *serviceMocks.MockPenaltyService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Earn-Back Task Not Owned",
+			body: models.InfractionTypeInput{Name: "Hitting sibling", Points: 10, EarnBackTaskID: 7},
+			setupMock: func(mockService *serviceMocks.MockPenaltyService) {
+				mockService.On("CreateInfractionType", mock.Anything, parentID, mock.Anything).
+					Return(nil, errors.New("forbidden: earn-back task must be created by you"))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "Duplicate Name",
+			body: models.InfractionTypeInput{Name: "Late to bed", Points: 5},
+			setupMock: func(mockService *serviceMocks.MockPenaltyService) {
+				mockService.On("CreateInfractionType", mock.Anything, parentID, mock.Anything).
+					Return(nil, errors.New("infraction type with this name already exists"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockPenaltyService)
+			tc.setupMock(mockService)
+			handler := handlers.NewPenaltyHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Post("/api/v1/parent/infraction-types", handler.CreateInfractionType)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/parent/infraction-types", bytes.NewReader(bodyBytes))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPenaltyHandler_ApplyPenalty(t *testing.T) {
+	parentID := 1
+	childID := 10
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockPenaltyService)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			body: models.ApplyPenaltyInput{InfractionTypeID: 3, Notes: "Again after warning"},
+			setupMock: func(mockService *serviceMocks.MockPenaltyService) {
+				mockService.On("ApplyPenalty", mock.Anything, parentID, childID, &models.ApplyPenaltyInput{InfractionTypeID: 3, Notes: "Again after warning"}).
+					Return(&models.Penalty{ID: 4, ChildID: childID, InfractionTypeID: 3, InfractionName: "Late to bed", Points: 5, PointsDeducted: 5, TransactionID: 40}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Validation Failed",
+			body:           models.ApplyPenaltyInput{},
+			setupMock:      func(mockService *serviceMocks.MockPenaltyService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Daily Limit Reached",
+			body: models.ApplyPenaltyInput{InfractionTypeID: 3},
+			setupMock: func(mockService *serviceMocks.MockPenaltyService) {
+				mockService.On("ApplyPenalty", mock.Anything, parentID, childID, mock.Anything).
+					Return(nil, errors.New("cannot apply penalty: daily limit of 1 reached for 'Late to bed'"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Not Parent Of Child",
+			body: models.ApplyPenaltyInput{InfractionTypeID: 3},
+			setupMock: func(mockService *serviceMocks.MockPenaltyService) {
+				mockService.On("ApplyPenalty", mock.Anything, parentID, childID, mock.Anything).
+					Return(nil, errors.New("forbidden: you are not authorized to manage penalties for this child"))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockPenaltyService)
+			tc.setupMock(mockService)
+			handler := handlers.NewPenaltyHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Post("/api/v1/parent/children/:childId/penalties", handler.ApplyPenalty)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/parent/children/10/penalties", bytes.NewReader(bodyBytes))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPenaltyHandler_GetPenaltyReport(t *testing.T) {
+	parentID := 1
+	childID := 10
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(mockService *serviceMocks.MockPenaltyService)
+		expectedStatus int
+	}{
+		{
+			name:  "Success",
+			query: "?from=2026-01-01&to=2026-01-31&group_by=week",
+			setupMock: func(mockService *serviceMocks.MockPenaltyService) {
+				mockService.On("GetPenaltyReport", mock.Anything, parentID, childID, &models.PenaltyReportFilter{From: "2026-01-01", To: "2026-01-31", GroupBy: "week"}).
+					Return(&models.PenaltyReport{ChildID: childID, From: "2026-01-01", To: "2026-01-31", GroupBy: models.PenaltyReportByWeek, TotalCount: 2, TotalPointsDeducted: 15}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid Group By",
+			query:          "?group_by=year",
+			setupMock:      func(mockService *serviceMocks.MockPenaltyService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid Date",
+			query:          "?from=01-01-2026",
+			setupMock:      func(mockService *serviceMocks.MockPenaltyService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "Range Too Long",
+			query: "?from=2024-01-01&to=2026-01-01",
+			setupMock: func(mockService *serviceMocks.MockPenaltyService) {
+				mockService.On("GetPenaltyReport", mock.Anything, parentID, childID, mock.Anything).
+					Return(nil, errors.New("invalid range: at most 366 days can be reported"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockPenaltyService)
+			tc.setupMock(mockService)
+			handler := handlers.NewPenaltyHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Get("/api/v1/parent/children/:childId/penalties/report", handler.GetPenaltyReport)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/parent/children/10/penalties/report"+tc.query, nil)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/api/v1/handlers"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPointExpirationHandler_SetPointExpirationPolicy(t *testing.T) {
+	parentID := 1
+	childID := 10
+
+	tests := []struct {
+		name           string
+		childIDParam   string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockPointExpirationService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name:         "Success",
+			childIDParam: "10",
+			body:         models.SetPointExpirationPolicyInput{ExpireAfterDays: 90, WarnBeforeDays: 7},
+			setupMock: func(mockService *serviceMocks.MockPointExpirationService) {
+				mockService.On("SetPolicy", mock.Anything, parentID, childID, mock.AnythingOfType("*models.SetPointExpirationPolicyInput")).
+					Return(&models.PointExpirationPolicy{ChildID: childID, ExpireAfterDays: 90, WarnBeforeDays: 7}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Point expiration policy saved successfully",
+		},
+		{
+			name:           "Validation Error - Missing Expire After Days",
+			childIDParam:   "10",
+			body:           models.SetPointExpirationPolicyInput{WarnBeforeDays: 7},
+			setupMock:      func(mockService *serviceMocks.MockPointExpirationService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name:         "Warning Window Not Shorter Than Expiry",
+			childIDParam: "10",
+			body:         models.SetPointExpirationPolicyInput{ExpireAfterDays: 7, WarnBeforeDays: 7},
+			setupMock: func(mockService *serviceMocks.MockPointExpirationService) {
+				mockService.On("SetPolicy", mock.Anything, parentID, childID, mock.AnythingOfType("*models.SetPointExpirationPolicyInput")).
+					Return(nil, errors.New("invalid policy: warn_before_days must be less than expire_after_days"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "invalid policy: warn_before_days must be less than expire_after_days",
+		},
+		{
+			name:         "Forbidden - Not Parent",
+			childIDParam: "10",
+			body:         models.SetPointExpirationPolicyInput{ExpireAfterDays: 90},
+			setupMock: func(mockService *serviceMocks.MockPointExpirationService) {
+				mockService.On("SetPolicy", mock.Anything, parentID, childID, mock.AnythingOfType("*models.SetPointExpirationPolicyInput")).
+					Return(nil, errors.New("forbidden: you are not authorized to manage point expiration for this child"))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedMsg:    "Forbidden: You are not authorized for this action",
+		},
+		{
+			name:           "Invalid Child ID",
+			childIDParam:   "abc",
+			body:           models.SetPointExpirationPolicyInput{ExpireAfterDays: 90},
+			setupMock:      func(mockService *serviceMocks.MockPointExpirationService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Invalid Child ID parameter",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockPointExpirationService)
+			tc.setupMock(mockService)
+			handler := handlers.NewPointExpirationHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Put("/api/v1/parent/children/:childId/point-expiration-policy", handler.SetPointExpirationPolicy)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/parent/children/"+tc.childIDParam+"/point-expiration-policy", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPointExpirationHandler_SetFamilyPointExpirationPolicy(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockPointExpirationService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "Success - Applies To All Children",
+			body: models.SetPointExpirationPolicyInput{ExpireAfterDays: 90, WarnBeforeDays: 7},
+			setupMock: func(mockService *serviceMocks.MockPointExpirationService) {
+				// childID 0 = kebijakan untuk semua anak parent
+				mockService.On("SetPolicy", mock.Anything, parentID, 0, mock.AnythingOfType("*models.SetPointExpirationPolicyInput")).
+					Return(&models.PointExpirationPolicy{ID: 1, CreatedByUserID: parentID, ExpireAfterDays: 90, WarnBeforeDays: 7}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Point expiration policy saved successfully",
+		},
+		{
+			name:           "Validation Error - Missing Expire After Days",
+			body:           models.SetPointExpirationPolicyInput{WarnBeforeDays: 7},
+			setupMock:      func(mockService *serviceMocks.MockPointExpirationService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockPointExpirationService)
+			tc.setupMock(mockService)
+			handler := handlers.NewPointExpirationHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Put("/api/v1/parent/point-expiration-policy", handler.SetFamilyPointExpirationPolicy)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/parent/point-expiration-policy", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPointExpirationHandler_GetMyExpiringPoints(t *testing.T) {
+	childID := 10
+
+	tests := []struct {
+		name           string
+		setupMock      func(mockService *serviceMocks.MockPointExpirationService)
+		expectedStatus int
+		expectedPoints float64
+	}{
+		{
+			name: "Success - Points Expiring Soon",
+			setupMock: func(mockService *serviceMocks.MockPointExpirationService) {
+				mockService.On("GetExpiringPoints", mock.Anything, childID, mock.AnythingOfType("time.Time")).
+					Return(&models.ExpiringPointsSummary{
+						Policy:         &models.PointExpirationPolicy{ChildID: childID, ExpireAfterDays: 90, WarnBeforeDays: 7},
+						ExpiringPoints: 30,
+						Lots:           []models.PointLot{{TransactionID: 5, Amount: 50, Remaining: 30}},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedPoints: 30,
+		},
+		{
+			name: "Service Error",
+			setupMock: func(mockService *serviceMocks.MockPointExpirationService) {
+				mockService.On("GetExpiringPoints", mock.Anything, childID, mock.AnythingOfType("time.Time")).
+					Return(nil, errors.New("internal server error: could not retrieve point lots"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockPointExpirationService)
+			tc.setupMock(mockService)
+			handler := handlers.NewPointExpirationHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
+			app.Get("/api/v1/child/points/expiring", handler.GetMyExpiringPoints)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/child/points/expiring", nil)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			if tc.expectedStatus == http.StatusOK {
+				var responseBody map[string]interface{}
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+				data := responseBody["data"].(map[string]interface{})
+				assert.Equal(t, tc.expectedPoints, data["expiring_points"])
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -151,7 +151,7 @@ func TestParentHandler_GetMyRewardDefinitions(t *testing.T) {
 					{ID: 1, RewardName: "Reward 1", RewardPoint: 100, RewardDescription: "Description 1", CreatedByUserID: parentID},
 					{ID: 2, RewardName: "Reward 2", RewardPoint: 200, RewardDescription: "Description 2", CreatedByUserID: parentID},
 				}
-				mockRepo.On("GetRewardsByCreatorID", mock.Anything, parentID, 1, 10).Return(mockRewards, 2, nil)
+				mockRepo.On("GetRewardsByCreatorID", mock.Anything, parentID, mock.AnythingOfType("*models.DefinitionFilter"), 1, 10).Return(mockRewards, 2, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
@@ -184,7 +184,7 @@ func TestParentHandler_GetMyRewardDefinitions(t *testing.T) {
 		{
 			name: "No Rewards Found",
 			setupMock: func(mockRepo *mocks.MockRewardRepository, parentID int) {
-				mockRepo.On("GetRewardsByCreatorID", mock.Anything, parentID, 1, 10).Return([]models.Reward{}, 0, nil)
+				mockRepo.On("GetRewardsByCreatorID", mock.Anything, parentID, mock.AnythingOfType("*models.DefinitionFilter"), 1, 10).Return([]models.Reward{}, 0, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
@@ -202,7 +202,7 @@ func TestParentHandler_GetMyRewardDefinitions(t *testing.T) {
 		{
 			name: "Database Error",
 			setupMock: func(mockRepo *mocks.MockRewardRepository, parentID int) {
-				mockRepo.On("GetRewardsByCreatorID", mock.Anything, parentID, 1, 10).Return(nil, 0, errors.New("database error"))
+				mockRepo.On("GetRewardsByCreatorID", mock.Anything, parentID, mock.AnythingOfType("*models.DefinitionFilter"), 1, 10).Return(nil, 0, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: map[string]interface{}{
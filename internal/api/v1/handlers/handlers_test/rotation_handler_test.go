@@ -0,0 +1,217 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/api/v1/handlers"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRotationHandler_CreateRotation(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockRotationService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "Success",
+			body: models.CreateRotationInput{TaskID: 3, ChildIDs: []int{10, 11}, Cadence: "daily"},
+			setupMock: func(mockService *serviceMocks.MockRotationService) {
+				mockService.On("CreateRotation", mock.Anything, parentID, mock.AnythingOfType("*models.CreateRotationInput")).Return(7, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedMsg:    "Rotation created successfully",
+		},
+		{
+			name:           "Validation Error - Single Child",
+			body:           models.CreateRotationInput{TaskID: 3, ChildIDs: []int{10}, Cadence: "daily"},
+			setupMock:      func(mockService *serviceMocks.MockRotationService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name:           "Validation Error - Invalid Cadence",
+			body:           models.CreateRotationInput{TaskID: 3, ChildIDs: []int{10, 11}, Cadence: "hourly"},
+			setupMock:      func(mockService *serviceMocks.MockRotationService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name: "Forbidden - Not Parent Of Child",
+			body: models.CreateRotationInput{TaskID: 3, ChildIDs: []int{10, 99}, Cadence: "weekly"},
+			setupMock: func(mockService *serviceMocks.MockRotationService) {
+				mockService.On("CreateRotation", mock.Anything, parentID, mock.AnythingOfType("*models.CreateRotationInput")).
+					Return(0, errors.New("forbidden: you are not the parent of child 99"))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedMsg:    "Forbidden: You are not authorized for this action",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockRotationService)
+			tc.setupMock(mockService)
+			handler := handlers.NewRotationHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Post("/api/v1/parent/rotations", handler.CreateRotation)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/parent/rotations", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRotationHandler_PreviewRotation(t *testing.T) {
+	parentID := 1
+	scheduledAt := time.Date(2025, 1, 1, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(mockService *serviceMocks.MockRotationService)
+		expectedStatus int
+		expectedCount  int
+	}{
+		{
+			name: "Success - Custom Count",
+			path: "/api/v1/parent/rotations/7/preview?count=2",
+			setupMock: func(mockService *serviceMocks.MockRotationService) {
+				mockService.On("PreviewRotation", mock.Anything, 7, parentID, 2).Return([]models.RotationPreviewItem{
+					{ChildID: 10, ChildUsername: "kid_a", ScheduledAt: scheduledAt},
+					{ChildID: 11, ChildUsername: "kid_b", ScheduledAt: scheduledAt.Add(24 * time.Hour)},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name: "Not Found",
+			path: "/api/v1/parent/rotations/8/preview",
+			setupMock: func(mockService *serviceMocks.MockRotationService) {
+				mockService.On("PreviewRotation", mock.Anything, 8, parentID, 0).Return(nil, pgx.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Invalid Rotation ID",
+			path:           "/api/v1/parent/rotations/abc/preview",
+			setupMock:      func(mockService *serviceMocks.MockRotationService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockRotationService)
+			tc.setupMock(mockService)
+			handler := handlers.NewRotationHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Get("/api/v1/parent/rotations/:rotationId/preview", handler.PreviewRotation)
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			if tc.expectedStatus == http.StatusOK {
+				var responseBody map[string]interface{}
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+				data, ok := responseBody["data"].([]interface{})
+				assert.True(t, ok)
+				assert.Len(t, data, tc.expectedCount)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRotationHandler_SwapRotationMembers(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name           string
+		body           models.SwapRotationMembersInput
+		setupMock      func(mockService *serviceMocks.MockRotationService)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			body: models.SwapRotationMembersInput{FirstChildID: 10, SecondChildID: 11},
+			setupMock: func(mockService *serviceMocks.MockRotationService) {
+				mockService.On("SwapMembers", mock.Anything, 7, parentID, 10, 11).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Validation Error - Same Child",
+			body:           models.SwapRotationMembersInput{FirstChildID: 10, SecondChildID: 10},
+			setupMock:      func(mockService *serviceMocks.MockRotationService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Child Not A Member",
+			body: models.SwapRotationMembersInput{FirstChildID: 10, SecondChildID: 12},
+			setupMock: func(mockService *serviceMocks.MockRotationService) {
+				mockService.On("SwapMembers", mock.Anything, 7, parentID, 10, 12).
+					Return(errors.New("invalid swap: both children must be members of this rotation"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockRotationService)
+			tc.setupMock(mockService)
+			handler := handlers.NewRotationHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Post("/api/v1/parent/rotations/:rotationId/swap", handler.SwapRotationMembers)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/parent/rotations/7/swap", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
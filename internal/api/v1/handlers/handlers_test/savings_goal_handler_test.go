@@ -0,0 +1,227 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/api/v1/handlers"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSavingsGoalHandler_CreateSavingsGoal(t *testing.T) {
+	childID := 10
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockSavingsGoalService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "Success - Pinned Reward",
+			body: models.CreateSavingsGoalInput{RewardID: 4, AutoClaim: true},
+			setupMock: func(mockService *serviceMocks.MockSavingsGoalService) {
+				mockService.On("CreateGoal", mock.Anything, childID, mock.AnythingOfType("*models.CreateSavingsGoalInput")).Return(7, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedMsg:    "Savings goal created successfully",
+		},
+		{
+			name:           "Validation Error - Custom Goal Without Target",
+			body:           models.CreateSavingsGoalInput{GoalName: "New bike"},
+			setupMock:      func(mockService *serviceMocks.MockSavingsGoalService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name: "Auto-Claim On Custom Goal",
+			body: models.CreateSavingsGoalInput{GoalName: "New bike", TargetPoints: 500, AutoClaim: true},
+			setupMock: func(mockService *serviceMocks.MockSavingsGoalService) {
+				mockService.On("CreateGoal", mock.Anything, childID, mock.AnythingOfType("*models.CreateSavingsGoalInput")).
+					Return(0, errors.New("cannot enable auto_claim for a custom savings goal"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "cannot enable auto_claim for a custom savings goal",
+		},
+		{
+			name: "Reward Not Found",
+			body: models.CreateSavingsGoalInput{RewardID: 99},
+			setupMock: func(mockService *serviceMocks.MockSavingsGoalService) {
+				mockService.On("CreateGoal", mock.Anything, childID, mock.AnythingOfType("*models.CreateSavingsGoalInput")).Return(0, pgx.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedMsg:    "Reward not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockSavingsGoalService)
+			tc.setupMock(mockService)
+			handler := handlers.NewSavingsGoalHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
+			app.Post("/api/v1/child/goals", handler.CreateSavingsGoal)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/child/goals", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSavingsGoalHandler_EarmarkPoints(t *testing.T) {
+	childID := 10
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockSavingsGoalService)
+		expectedStatus int
+	}{
+		{
+			name: "Success - Earmark",
+			body: models.EarmarkPointsInput{Points: 50},
+			setupMock: func(mockService *serviceMocks.MockSavingsGoalService) {
+				mockService.On("EarmarkPoints", mock.Anything, 7, childID, 50).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Success - Release",
+			body: models.EarmarkPointsInput{Points: -20},
+			setupMock: func(mockService *serviceMocks.MockSavingsGoalService) {
+				mockService.On("EarmarkPoints", mock.Anything, 7, childID, -20).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Validation Error - Zero Points",
+			body:           models.EarmarkPointsInput{Points: 0},
+			setupMock:      func(mockService *serviceMocks.MockSavingsGoalService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Not Enough Spendable Points",
+			body: models.EarmarkPointsInput{Points: 500},
+			setupMock: func(mockService *serviceMocks.MockSavingsGoalService) {
+				mockService.On("EarmarkPoints", mock.Anything, 7, childID, 500).
+					Return(fmt.Errorf("%w: only %d points are available to earmark", service.ErrInsufficientPoints, 120))
+			},
+			expectedStatus: http.StatusPaymentRequired,
+		},
+		{
+			name: "Goal Not Found",
+			body: models.EarmarkPointsInput{Points: 50},
+			setupMock: func(mockService *serviceMocks.MockSavingsGoalService) {
+				mockService.On("EarmarkPoints", mock.Anything, 7, childID, 50).Return(pgx.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockSavingsGoalService)
+			tc.setupMock(mockService)
+			handler := handlers.NewSavingsGoalHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
+			app.Post("/api/v1/child/goals/:goalId/earmark", handler.EarmarkPoints)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/child/goals/7/earmark", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSavingsGoalHandler_ContributeToSavingsGoal(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockSavingsGoalService)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			body: models.ContributeToGoalInput{Points: 30, Note: "Great report card"},
+			setupMock: func(mockService *serviceMocks.MockSavingsGoalService) {
+				mockService.On("ContributeToGoal", mock.Anything, 7, parentID, mock.AnythingOfType("*models.ContributeToGoalInput")).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Validation Error - Negative Points",
+			body:           models.ContributeToGoalInput{Points: -5},
+			setupMock:      func(mockService *serviceMocks.MockSavingsGoalService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Not Parent Of Goal Owner",
+			body: models.ContributeToGoalInput{Points: 30},
+			setupMock: func(mockService *serviceMocks.MockSavingsGoalService) {
+				mockService.On("ContributeToGoal", mock.Anything, 7, parentID, mock.AnythingOfType("*models.ContributeToGoalInput")).
+					Return(errors.New("forbidden: you are not authorized to contribute to this goal"))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockSavingsGoalService)
+			tc.setupMock(mockService)
+			handler := handlers.NewSavingsGoalHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Post("/api/v1/parent/goals/:goalId/contributions", handler.ContributeToSavingsGoal)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/parent/goals/7/contributions", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
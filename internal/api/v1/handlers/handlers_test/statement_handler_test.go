@@ -0,0 +1,182 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/api/v1/handlers"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// sampleStatement membuat laporan rekening contoh dengan satu kredit tugas dan satu debit hadiah.
+func sampleStatement(childID int) *models.PointStatement {
+	start := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	return &models.PointStatement{
+		ChildID:        childID,
+		ChildUsername:  "child_user",
+		Month:          "2026-09",
+		PeriodStart:    start,
+		PeriodEnd:      start.AddDate(0, 1, 0),
+		Timezone:       "UTC",
+		CurrencyName:   "points",
+		OpeningBalance: 40,
+		TotalCredits:   20,
+		TotalDebits:    30,
+		ClosingBalance: 30,
+		Credits:        []models.StatementTypeTotal{{TransactionType: models.TransactionTypeCompletion, Amount: 20, Count: 1}},
+		Debits:         []models.StatementTypeTotal{{TransactionType: models.TransactionTypeRedemption, Amount: 30, Count: 1}},
+		Lines: []models.PointStatementLine{
+			{TransactionID: 5, CreatedAt: start.Add(48 * time.Hour), TransactionType: models.TransactionTypeCompletion, ChangeAmount: 20, Balance: 60, UserTaskID: 3, TaskName: "Wash dishes"},
+			{TransactionID: 6, CreatedAt: start.Add(72 * time.Hour), TransactionType: models.TransactionTypeRedemption, ChangeAmount: -30, Balance: 30, UserRewardID: 2, RewardName: "Ice cream"},
+		},
+	}
+}
+
+func TestStatementHandler_GetMyStatement(t *testing.T) {
+	childID := 10
+
+	tests := []struct {
+		name                string
+		query               string
+		setupMock           func(mockService *serviceMocks.MockStatementService)
+		expectedStatus      int
+		expectedContentType string
+		checkBody           func(t *testing.T, body []byte)
+	}{
+		{
+			name:  "Success JSON",
+			query: "?month=2026-09",
+			setupMock: func(mockService *serviceMocks.MockStatementService) {
+				mockService.On("GetStatement", mock.Anything, childID, "2026-09", 0).Return(sampleStatement(childID), nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: fiber.MIMEApplicationJSON,
+			checkBody: func(t *testing.T, body []byte) {
+				var responseBody map[string]interface{}
+				assert.NoError(t, json.Unmarshal(body, &responseBody))
+				assert.Equal(t, "Statement retrieved successfully", responseBody["message"])
+				data := responseBody["data"].(map[string]interface{})
+				assert.Equal(t, float64(40), data["opening_balance"])
+				assert.Equal(t, float64(30), data["closing_balance"])
+			},
+		},
+		{
+			name:  "Success CSV",
+			query: "?month=2026-09&format=csv",
+			setupMock: func(mockService *serviceMocks.MockStatementService) {
+				mockService.On("GetStatement", mock.Anything, childID, "2026-09", 0).Return(sampleStatement(childID), nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			checkBody: func(t *testing.T, body []byte) {
+				lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+				assert.Len(t, lines, 5) // header, saldo awal, 2 transaksi, saldo akhir
+				assert.Equal(t, "date,transaction_id,transaction_type,description,related_user_task_id,related_user_reward_id,credit,debit,balance", lines[0])
+				assert.Equal(t, "2026-09-03 00:00,5,task_completion,Task: Wash dishes,3,,20,,60", lines[2])
+				assert.Equal(t, "2026-09-04 00:00,6,reward_redemption,Reward: Ice cream,,2,,30,30", lines[3])
+			},
+		},
+		{
+			name:  "Success PDF",
+			query: "?format=pdf",
+			setupMock: func(mockService *serviceMocks.MockStatementService) {
+				mockService.On("GetStatement", mock.Anything, childID, "", 0).Return(sampleStatement(childID), nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/pdf",
+			checkBody: func(t *testing.T, body []byte) {
+				assert.True(t, strings.HasPrefix(string(body), "%PDF-1.4"))
+				assert.Contains(t, string(body), "(Account Statement - 2026-09)")
+				assert.True(t, strings.HasSuffix(string(body), "%%EOF\n"))
+			},
+		},
+		{
+			name:                "Invalid Format",
+			query:               "?format=xlsx",
+			setupMock:           func(mockService *serviceMocks.MockStatementService) {},
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: fiber.MIMEApplicationJSON,
+			checkBody: func(t *testing.T, body []byte) {
+				assert.Contains(t, string(body), "Invalid format value")
+			},
+		},
+		{
+			name:                "Invalid Currency",
+			query:               "?currency_id=-1",
+			setupMock:           func(mockService *serviceMocks.MockStatementService) {},
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: fiber.MIMEApplicationJSON,
+			checkBody: func(t *testing.T, body []byte) {
+				assert.Contains(t, string(body), "Invalid currency_id parameter")
+			},
+		},
+		{
+			name:  "Service Error",
+			query: "?currency_id=3",
+			setupMock: func(mockService *serviceMocks.MockStatementService) {
+				mockService.On("GetStatement", mock.Anything, childID, "", 3).Return(nil, errors.New("database error"))
+			},
+			expectedStatus:      http.StatusInternalServerError,
+			expectedContentType: fiber.MIMEApplicationJSON,
+			checkBody:           func(t *testing.T, body []byte) {},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockStatementService)
+			tc.setupMock(mockService)
+			handler := handlers.NewStatementHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
+			app.Get("/api/v1/child/statements", handler.GetMyStatement)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/child/statements"+tc.query, nil)
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tc.expectedContentType, resp.Header.Get(fiber.HeaderContentType))
+			body, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			tc.checkBody(t, body)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStatementHandler_GetChildStatement_Forbidden(t *testing.T) {
+	parentID := 1
+	childID := 10
+
+	mockService := new(serviceMocks.MockStatementService)
+	mockService.On("GetChildStatement", mock.Anything, parentID, childID, "2026-09", 0).
+		Return(nil, errors.New("forbidden: you are not authorized to view statements for this child"))
+	handler := handlers.NewStatementHandler(mockService)
+
+	app := fiber.New()
+	app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+	app.Get("/api/v1/parent/children/:childId/statements", handler.GetChildStatement)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/parent/children/10/statements?month=2026-09&format=pdf", nil)
+	resp, err := app.Test(req, -1)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,150 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/api/v1/handlers"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStreakHandler_CreateStreakBonusRule(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockStreakService)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			body: models.StreakBonusRuleInput{Name: "Homework week", TaskID: 4, StreakDays: 5, BonusPoints: 20},
+			setupMock: func(mockService *serviceMocks.MockStreakService) {
+				mockService.On("CreateBonusRule", mock.Anything, parentID, &models.StreakBonusRuleInput{Name: "Homework week", TaskID: 4, StreakDays: 5, BonusPoints: 20}).
+					Return(&models.StreakBonusRule{ID: 2, CreatedByUserID: parentID, Name: "Homework week", TaskID: 4, StreakDays: 5, BonusPoints: 20, IsActive: true}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Validation Failed - Streak Too Short",
+			body:           models.StreakBonusRuleInput{Name: "Homework week", StreakDays: 1, BonusPoints: 20},
+			setupMock:      func(mockService *serviceMocks.MockStreakService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Task Not Owned",
+			body: models.StreakBonusRuleInput{Name: "Homework week", TaskID: 9, StreakDays: 5, BonusPoints: 20},
+			setupMock: func(mockService *serviceMocks.MockStreakService) {
+				mockService.On("CreateBonusRule", mock.Anything, parentID, mock.Anything).
+					Return(nil, errors.New("forbidden: streak task must be created by you"))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "Duplicate Name",
+			body: models.StreakBonusRuleInput{Name: "Homework week", StreakDays: 5, BonusPoints: 20},
+			setupMock: func(mockService *serviceMocks.MockStreakService) {
+				mockService.On("CreateBonusRule", mock.Anything, parentID, mock.Anything).
+					Return(nil, errors.New("streak bonus rule with this name already exists"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockStreakService)
+			tc.setupMock(mockService)
+			handler := handlers.NewStreakHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Post("/api/v1/parent/streak-bonus-rules", handler.CreateStreakBonusRule)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/parent/streak-bonus-rules", bytes.NewReader(bodyBytes))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStreakHandler_BuyStreakFreeze(t *testing.T) {
+	childID := 2
+
+	tests := []struct {
+		name           string
+		setupMock      func(mockService *serviceMocks.MockStreakService)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			setupMock: func(mockService *serviceMocks.MockStreakService) {
+				mockService.On("BuyStreakFreeze", mock.Anything, childID).
+					Return(&models.StreakFreeze{ID: 7, ChildID: childID, PricePoints: 30, TransactionID: 55}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Not Enabled",
+			setupMock: func(mockService *serviceMocks.MockStreakService) {
+				mockService.On("BuyStreakFreeze", mock.Anything, childID).
+					Return(nil, errors.New("forbidden: streak freezes are not enabled for this child"))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "Maximum Owned",
+			setupMock: func(mockService *serviceMocks.MockStreakService) {
+				mockService.On("BuyStreakFreeze", mock.Anything, childID).
+					Return(nil, errors.New("cannot buy streak freeze: you already have 1 unused streak freeze(s)"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Insufficient Points",
+			setupMock: func(mockService *serviceMocks.MockStreakService) {
+				mockService.On("BuyStreakFreeze", mock.Anything, childID).
+					Return(nil, fmt.Errorf("%w: a streak freeze costs 30 points, only 10 are available", service.ErrInsufficientPoints))
+			},
+			expectedStatus: http.StatusPaymentRequired,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockStreakService)
+			tc.setupMock(mockService)
+			handler := handlers.NewStreakHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
+			app.Post("/api/v1/child/streak-freezes", handler.BuyStreakFreeze)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/child/streak-freezes", nil)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
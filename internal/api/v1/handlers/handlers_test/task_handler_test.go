@@ -156,7 +156,7 @@ func TestParentHandler_GetMyTaskDefinitions(t *testing.T) {
 					{ID: 1, TaskName: "Task 1", TaskPoint: 100, TaskDescription: "Description 1", CreatedByUserID: parentID},
 					{ID: 2, TaskName: "Task 2", TaskPoint: 200, TaskDescription: "Description 2", CreatedByUserID: parentID},
 				}
-				mockRepo.On("GetTasksByCreatorID", mock.Anything, parentID, 1, 10).Return(mockTasks, 2, nil)
+				mockRepo.On("GetTasksByCreatorID", mock.Anything, parentID, mock.AnythingOfType("*models.DefinitionFilter"), 1, 10).Return(mockTasks, 2, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
@@ -189,7 +189,7 @@ func TestParentHandler_GetMyTaskDefinitions(t *testing.T) {
 		{
 			name: "No Tasks Found",
 			setupMock: func(mockRepo *mocks.MockTaskRepository, parentID int) {
-				mockRepo.On("GetTasksByCreatorID", mock.Anything, parentID, 1, 10).Return([]models.Task{}, 0, nil)
+				mockRepo.On("GetTasksByCreatorID", mock.Anything, parentID, mock.AnythingOfType("*models.DefinitionFilter"), 1, 10).Return([]models.Task{}, 0, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
@@ -207,7 +207,7 @@ func TestParentHandler_GetMyTaskDefinitions(t *testing.T) {
 		{
 			name: "Database Error",
 			setupMock: func(mockRepo *mocks.MockTaskRepository, parentID int) {
-				mockRepo.On("GetTasksByCreatorID", mock.Anything, parentID, 1, 10).Return(nil, 0, errors.New("database error"))
+				mockRepo.On("GetTasksByCreatorID", mock.Anything, parentID, mock.AnythingOfType("*models.DefinitionFilter"), 1, 10).Return(nil, 0, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: map[string]interface{}{
@@ -287,6 +287,81 @@ func TestParentHandler_GetMyTaskDefinitions(t *testing.T) {
 	}
 }
 
+func TestParentHandler_GetMyTaskDefinitions_Filters(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(mockRepo *mocks.MockTaskRepository)
+		expectedStatus int
+	}{
+		{
+			name:  "Success - Search, Category, Point Range And Sort",
+			query: "?q=dishes&category=chores&tag=kitchen&min_points=5&max_points=50&sort_by=points&sort_order=asc",
+			setupMock: func(mockRepo *mocks.MockTaskRepository) {
+				mockRepo.On("GetTasksByCreatorID", mock.Anything, parentID, mock.MatchedBy(func(f *models.DefinitionFilter) bool {
+					return f.Search == "dishes" && f.Category == "chores" && f.Tag == "kitchen" &&
+						f.MinPoints != nil && *f.MinPoints == 5 && f.MaxPoints != nil && *f.MaxPoints == 50 &&
+						f.SortBy == "points" && f.SortOrder == "asc"
+				}), 1, 10).Return([]models.Task{
+					{ID: 3, TaskName: "Wash dishes", TaskPoint: 10, Category: models.DefinitionCategoryChores, Tags: []string{"kitchen"}, CreatedByUserID: parentID},
+				}, 1, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid Category",
+			query:          "?category=sports",
+			setupMock:      func(mockRepo *mocks.MockTaskRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid Sort Field",
+			query:          "?sort_by=password",
+			setupMock:      func(mockRepo *mocks.MockTaskRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Min Points Greater Than Max Points",
+			query:          "?min_points=50&max_points=5",
+			setupMock:      func(mockRepo *mocks.MockTaskRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			mockTaskRepo := new(mocks.MockTaskRepository)
+			parentHandler := &handlers.ParentHandler{
+				TaskRepo: mockTaskRepo,
+				Validate: validator.New(),
+			}
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Get("/api/v1/parent/tasks", parentHandler.GetMyTaskDefinitions)
+			tc.setupMock(mockTaskRepo)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/parent/tasks"+tc.query, nil)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			if tc.expectedStatus == http.StatusOK {
+				var result map[string]interface{}
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+				data := result["data"].([]interface{})
+				assert.Len(t, data, 1)
+				task := data[0].(map[string]interface{})
+				assert.Equal(t, "chores", task["category"])
+				assert.Equal(t, []interface{}{"kitchen"}, task["tags"])
+			}
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestParentHandler_UpdateMyTaskDefinition(t *testing.T) {
 	parentID := 1
 
@@ -0,0 +1,149 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/api/v1/handlers"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTemplateHandler_ImportTemplates(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockTemplateService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "Success",
+			body: models.ImportTemplatesInput{TemplateIDs: []int{1, 6}, Locale: "id"},
+			setupMock: func(mockService *serviceMocks.MockTemplateService) {
+				mockService.On("ImportTemplates", mock.Anything, parentID, mock.MatchedBy(func(input *models.ImportTemplatesInput) bool {
+					return len(input.TemplateIDs) == 2 && input.Locale == "id"
+				})).Return([]models.ImportedDefinition{
+					{TemplateID: 1, Kind: models.TemplateKindTask, DefinitionID: 11, Name: "Merapikan tempat tidur"},
+					{TemplateID: 6, Kind: models.TemplateKindReward, DefinitionID: 12, Name: "Waktu layar tambahan"},
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedMsg:    "Templates imported successfully",
+		},
+		{
+			name:           "Validation Error - Duplicate IDs",
+			body:           models.ImportTemplatesInput{TemplateIDs: []int{1, 1}},
+			setupMock:      func(mockService *serviceMocks.MockTemplateService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name:           "Validation Error - Empty",
+			body:           models.ImportTemplatesInput{},
+			setupMock:      func(mockService *serviceMocks.MockTemplateService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name: "Inactive Template",
+			body: models.ImportTemplatesInput{TemplateIDs: []int{99}},
+			setupMock: func(mockService *serviceMocks.MockTemplateService) {
+				mockService.On("ImportTemplates", mock.Anything, parentID, mock.AnythingOfType("*models.ImportTemplatesInput")).
+					Return(nil, errors.New("invalid template id 99: template not found or inactive"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "invalid template id 99: template not found or inactive",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockTemplateService)
+			tc.setupMock(mockService)
+			handler := handlers.NewTemplateHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Post("/api/v1/parent/templates/import", handler.ImportTemplates)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/parent/templates/import", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTemplateHandler_BrowseTemplates(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(mockService *serviceMocks.MockTemplateService)
+		expectedStatus int
+	}{
+		{
+			name:  "Success - Filter By Age And Kind",
+			query: "?kind=task&age=7&locale=id",
+			setupMock: func(mockService *serviceMocks.MockTemplateService) {
+				mockService.On("ListTemplates", mock.Anything, mock.MatchedBy(func(filter *models.TemplateFilter) bool {
+					return filter.Kind == "task" && filter.Age != nil && *filter.Age == 7 && filter.Locale == "id"
+				}), false, 1, 10).Return([]models.DefinitionTemplate{{ID: 1, Kind: models.TemplateKindTask, Name: "Merapikan tempat tidur"}}, 1, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid Kind",
+			query:          "?kind=badge",
+			setupMock:      func(mockService *serviceMocks.MockTemplateService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Age Out Of Range",
+			query:          "?age=40",
+			setupMock:      func(mockService *serviceMocks.MockTemplateService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockTemplateService)
+			tc.setupMock(mockService)
+			handler := handlers.NewTemplateHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Get("/api/v1/parent/templates", handler.BrowseTemplates)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/parent/templates"+tc.query, nil)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
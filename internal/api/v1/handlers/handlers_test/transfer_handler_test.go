@@ -0,0 +1,235 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/api/v1/handlers"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	serviceMocks "github.com/rakaarfi/digital-parenting-app-be/internal/service/mocks"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTransferHandler_CreateTransfer(t *testing.T) {
+	childID := 10
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(mockService *serviceMocks.MockTransferService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "Success - Completed Immediately",
+			body: models.CreateTransferInput{ToChildID: 11, Amount: 20, Note: "Happy birthday!"},
+			setupMock: func(mockService *serviceMocks.MockTransferService) {
+				mockService.On("CreateTransfer", mock.Anything, childID, mock.AnythingOfType("*models.CreateTransferInput")).
+					Return(&models.PointTransfer{ID: 1, FromChildID: childID, ToChildID: 11, Amount: 20, Status: models.PointTransferStatusCompleted}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedMsg:    "Points sent successfully",
+		},
+		{
+			name: "Success - Waiting For Approval",
+			body: models.CreateTransferInput{ToChildID: 11, Amount: 20},
+			setupMock: func(mockService *serviceMocks.MockTransferService) {
+				mockService.On("CreateTransfer", mock.Anything, childID, mock.AnythingOfType("*models.CreateTransferInput")).
+					Return(&models.PointTransfer{ID: 2, FromChildID: childID, ToChildID: 11, Amount: 20, Status: models.PointTransferStatusPending}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedMsg:    "Transfer submitted and waiting for parent approval",
+		},
+		{
+			name:           "Validation Error - Zero Amount",
+			body:           models.CreateTransferInput{ToChildID: 11, Amount: 0},
+			setupMock:      func(mockService *serviceMocks.MockTransferService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+		{
+			name: "Recipient Not A Sibling",
+			body: models.CreateTransferInput{ToChildID: 99, Amount: 20},
+			setupMock: func(mockService *serviceMocks.MockTransferService) {
+				mockService.On("CreateTransfer", mock.Anything, childID, mock.AnythingOfType("*models.CreateTransferInput")).
+					Return(nil, errors.New("forbidden: recipient is not your sibling"))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedMsg:    "forbidden: recipient is not your sibling",
+		},
+		{
+			name: "Above Policy Limit",
+			body: models.CreateTransferInput{ToChildID: 11, Amount: 500},
+			setupMock: func(mockService *serviceMocks.MockTransferService) {
+				mockService.On("CreateTransfer", mock.Anything, childID, mock.AnythingOfType("*models.CreateTransferInput")).
+					Return(nil, errors.New("cannot transfer more than 100 points at once"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "cannot transfer more than 100 points at once",
+		},
+		{
+			name: "Insufficient Points",
+			body: models.CreateTransferInput{ToChildID: 11, Amount: 20},
+			setupMock: func(mockService *serviceMocks.MockTransferService) {
+				mockService.On("CreateTransfer", mock.Anything, childID, mock.AnythingOfType("*models.CreateTransferInput")).
+					Return(nil, fmt.Errorf("%w: only 5 points are available to transfer", service.ErrInsufficientPoints))
+			},
+			expectedStatus: http.StatusPaymentRequired,
+			expectedMsg:    "insufficient points to claim reward: only 5 points are available to transfer",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockTransferService)
+			tc.setupMock(mockService)
+			handler := handlers.NewTransferHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(childID, "child_user", "Child"))
+			app.Post("/api/v1/child/transfers", handler.CreateTransfer)
+
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/child/transfers", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransferHandler_ApproveTransfer(t *testing.T) {
+	parentID := 1
+	transferID := 5
+
+	tests := []struct {
+		name           string
+		setupMock      func(mockService *serviceMocks.MockTransferService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "Success",
+			setupMock: func(mockService *serviceMocks.MockTransferService) {
+				mockService.On("ApproveTransfer", mock.Anything, parentID, transferID).
+					Return(&models.PointTransfer{ID: transferID, Status: models.PointTransferStatusCompleted, DebitTransactionID: 7, CreditTransactionID: 8}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Transfer approved successfully",
+		},
+		{
+			name: "Already Reviewed",
+			setupMock: func(mockService *serviceMocks.MockTransferService) {
+				mockService.On("ApproveTransfer", mock.Anything, parentID, transferID).
+					Return(nil, errors.New("cannot approve transfer: transfer is already rejected"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "cannot approve transfer: transfer is already rejected",
+		},
+		{
+			name: "Sender Lacks Points",
+			setupMock: func(mockService *serviceMocks.MockTransferService) {
+				mockService.On("ApproveTransfer", mock.Anything, parentID, transferID).
+					Return(nil, fmt.Errorf("%w: only 0 points are available to transfer", service.ErrInsufficientPoints))
+			},
+			expectedStatus: http.StatusPaymentRequired,
+			expectedMsg:    "insufficient points to claim reward: only 0 points are available to transfer",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockTransferService)
+			tc.setupMock(mockService)
+			handler := handlers.NewTransferHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Post("/api/v1/parent/transfers/:transferId/approve", handler.ApproveTransfer)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/parent/transfers/5/approve", nil)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransferHandler_SetFamilyTransferPolicy(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name           string
+		input          map[string]interface{}
+		setupMock      func(mockService *serviceMocks.MockTransferService)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name:  "Success - Applies To All Children",
+			input: map[string]interface{}{"allowed": true, "max_amount": 50, "requires_approval": false},
+			setupMock: func(mockService *serviceMocks.MockTransferService) {
+				// childID 0 = kebijakan untuk semua anak parent
+				mockService.On("SetPolicy", mock.Anything, parentID, 0, mock.AnythingOfType("*models.SetTransferPolicyInput")).
+					Return(&models.TransferPolicy{ID: 1, CreatedByUserID: parentID, Allowed: true, MaxAmount: 50}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "Transfer policy saved successfully",
+		},
+		{
+			name:           "Validation Error - Missing Allowed",
+			input:          map[string]interface{}{"max_amount": 50, "requires_approval": false},
+			setupMock:      func(mockService *serviceMocks.MockTransferService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Validation failed",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(serviceMocks.MockTransferService)
+			tc.setupMock(mockService)
+			handler := handlers.NewTransferHandler(mockService)
+
+			app := fiber.New()
+			app.Use(test_utils.MockJWTMiddleware(parentID, "parent_user", "Parent"))
+			app.Put("/api/v1/parent/transfer-policy", handler.SetFamilyTransferPolicy)
+
+			body, _ := json.Marshal(tc.input)
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/parent/transfer-policy", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			var responseBody map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+			assert.Equal(t, tc.expectedMsg, responseBody["message"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
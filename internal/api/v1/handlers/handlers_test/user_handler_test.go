@@ -80,6 +80,26 @@ func TestUserHandler_UpdateMyProfile(t *testing.T) {
 				// Data might contain details, only check message
 			},
 		},
+		{
+			name: "Validation Error - Invalid Timezone",
+			inputBody: models.UpdateProfileInput{
+				Username: "newusername",
+				Email:    "new@example.com",
+				Timezone: "Mars/Olympus_Mons",
+			},
+			setupContext: func(c *fiber.Ctx) { setUserContext(c, testUserID) },
+			setupMock: func(mockUserService *serviceMocks.MockUserService, userID int, input models.UpdateProfileInput) {
+				// No service call expected
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"success": false,
+				"message": "Validation failed",
+				"data": map[string]interface{}{
+					"Timezone": "Validation for field 'Timezone' failed on the 'timezone' rule.",
+				},
+			},
+		},
 		{
 			name:         "Bad Request - Invalid Body",
 			inputBody:    models.UpdateProfileInput{}, // Will cause BodyParser error if sent incorrectly
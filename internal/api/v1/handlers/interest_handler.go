@@ -0,0 +1,307 @@
+// internal/api/v1/handlers/interest_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// Batas jumlah periode pada proyeksi bunga.
+const (
+	defaultInterestPreviewPeriods = 12
+	maxInterestPreviewPeriods     = 60
+)
+
+// InterestHandler menangani endpoint kebijakan bunga tabungan (Parent) dan proyeksi bunga (Parent & Child).
+type InterestHandler struct {
+	InterestService service.InterestService
+	Validate        *validator.Validate
+}
+
+// NewInterestHandler membuat instance baru dari InterestHandler.
+func NewInterestHandler(interestService service.InterestService) *InterestHandler {
+	return &InterestHandler{
+		InterestService: interestService,
+		Validate:        validator.New(),
+	}
+}
+
+// parsePreviewPeriods membaca query param 'periods' (default 12, maksimal 60).
+func parsePreviewPeriods(c *fiber.Ctx) (int, bool) {
+	periods := c.QueryInt("periods", defaultInterestPreviewPeriods)
+	if periods < 1 || periods > maxInterestPreviewPeriods {
+		return 0, false
+	}
+	return periods, true
+}
+
+// ==========================================================
+// --- Parent: Interest Policy ---
+// ==========================================================
+
+// SetFamilyInterestPolicy godoc
+// @Summary Set Family Interest Policy
+// @Description Creates or updates the logged-in parent's savings interest policy for all of their children. Each completed period (weekly periods start Monday, monthly periods start on the 1st, UTC) the child earns rate_basis_points/10000 of their balance, rounded as configured (default floor) and capped at max_points_per_period (0 = no cap). Each period is posted at most once. A child-specific policy from any of the child's parents takes precedence.
+// @Tags Parent - Points
+// @Accept json
+// @Produce json
+// @Param policy_input body models.SetInterestPolicyInput true "Policy details"
+// @Success 200 {object} models.Response{data=models.InterestPolicy} "Policy saved"
+// @Failure 400 {object} models.Response "Validation failed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/interest-policy [put]
+func (h *InterestHandler) SetFamilyInterestPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	return h.setInterestPolicy(c, parentID, 0)
+}
+
+// GetFamilyInterestPolicy godoc
+// @Summary Get Family Interest Policy
+// @Description Retrieves the logged-in parent's savings interest policy for all of their children.
+// @Tags Parent - Points
+// @Produce json
+// @Success 200 {object} models.Response{data=models.InterestPolicy} "Policy retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 404 {object} models.Response "No family policy set"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/interest-policy [get]
+func (h *InterestHandler) GetFamilyInterestPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	policy, err := h.InterestService.GetPolicy(c.Context(), parentID, 0)
+	if err != nil {
+		return handleParentError(c, err, "GetFamilyInterestPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Interest policy retrieved successfully", Data: policy})
+}
+
+// DeleteFamilyInterestPolicy godoc
+// @Summary Delete Family Interest Policy
+// @Description Removes the logged-in parent's savings interest policy for all of their children. Child-specific policies and policies of other parents are unaffected.
+// @Tags Parent - Points
+// @Produce json
+// @Success 200 {object} models.Response "Policy deleted"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 404 {object} models.Response "No family policy set"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/interest-policy [delete]
+func (h *InterestHandler) DeleteFamilyInterestPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	if err := h.InterestService.DeletePolicy(c.Context(), parentID, 0); err != nil {
+		return handleParentError(c, err, "DeleteFamilyInterestPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Interest policy deleted successfully"})
+}
+
+// SetInterestPolicy godoc
+// @Summary Set Child Interest Policy
+// @Description Creates or updates the logged-in parent's savings interest policy for one child. It takes precedence over family policies (those set for all children) of any of the child's parents.
+// @Tags Parent - Points
+// @Accept json
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Param policy_input body models.SetInterestPolicyInput true "Policy details"
+// @Success 200 {object} models.Response{data=models.InterestPolicy} "Policy saved"
+// @Failure 400 {object} models.Response "Invalid Child ID or validation failed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/interest-policy [put]
+func (h *InterestHandler) SetInterestPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+	return h.setInterestPolicy(c, parentID, childID)
+}
+
+// setInterestPolicy memvalidasi input lalu menyimpan kebijakan bunga parent untuk childID (0 = semua anak).
+func (h *InterestHandler) setInterestPolicy(c *fiber.Ctx, parentID int, childID int) error {
+	input := new(models.SetInterestPolicyInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	policy, err := h.InterestService.SetPolicy(c.Context(), parentID, childID, input)
+	if err != nil {
+		return handleParentError(c, err, "SetInterestPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Interest policy saved successfully", Data: policy})
+}
+
+// GetInterestPolicy godoc
+// @Summary Get Child Interest Policy
+// @Description Retrieves the savings interest policy that applies to the child: a child-specific policy from any of the child's parents, otherwise the oldest family policy of one of them. created_by_user_id and child_id show which policy applies.
+// @Tags Parent - Points
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response{data=models.InterestPolicy} "Policy retrieved"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 404 {object} models.Response "No policy applies to this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/interest-policy [get]
+func (h *InterestHandler) GetInterestPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	policy, err := h.InterestService.GetPolicy(c.Context(), parentID, childID)
+	if err != nil {
+		return handleParentError(c, err, "GetInterestPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Interest policy retrieved successfully", Data: policy})
+}
+
+// DeleteInterestPolicy godoc
+// @Summary Delete Child Interest Policy
+// @Description Removes the logged-in parent's savings interest policy for this child; family policies then apply again (without any, the balance no longer earns interest). Policies set by other parents are unaffected.
+// @Tags Parent - Points
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response "Policy deleted"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 404 {object} models.Response "No policy of yours set for this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/interest-policy [delete]
+func (h *InterestHandler) DeleteInterestPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	if err := h.InterestService.DeletePolicy(c.Context(), parentID, childID); err != nil {
+		return handleParentError(c, err, "DeleteInterestPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Interest policy deleted successfully"})
+}
+
+// GetChildInterestPreview godoc
+// @Summary Preview Child's Interest
+// @Description Projects how the child's current balance grows with interest over the next periods, assuming no other earnings or spending.
+// @Tags Parent - Points
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Param periods query int false "Number of periods to project (1-60)" default(12)
+// @Success 200 {object} models.Response{data=models.InterestProjection} "Projection calculated"
+// @Failure 400 {object} models.Response "Invalid Child ID or periods"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 404 {object} models.Response "No policy set for this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/interest-preview [get]
+func (h *InterestHandler) GetChildInterestPreview(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+	periods, ok := parsePreviewPeriods(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid periods parameter: must be between 1 and 60"})
+	}
+
+	projection, err := h.InterestService.PreviewForParent(c.Context(), parentID, childID, periods, time.Now())
+	if err != nil {
+		return handleParentError(c, err, "GetChildInterestPreview")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Interest projection calculated successfully", Data: projection})
+}
+
+// ==========================================================
+// --- Child: Interest Preview ---
+// ==========================================================
+
+// GetMyInterestPreview godoc
+// @Summary Preview My Interest
+// @Description Projects how the child's current balance grows with interest over the next periods, assuming no other earnings or spending.
+// @Tags Child - Points & Rewards
+// @Produce json
+// @Param periods query int false "Number of periods to project (1-60)" default(12)
+// @Success 200 {object} models.Response{data=models.InterestProjection} "Projection calculated"
+// @Failure 400 {object} models.Response "Invalid periods"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 404 {object} models.Response "No interest policy set"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/points/interest-preview [get]
+func (h *InterestHandler) GetMyInterestPreview(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	periods, ok := parsePreviewPeriods(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid periods parameter: must be between 1 and 60"})
+	}
+
+	projection, err := h.InterestService.PreviewForChild(c.Context(), childID, periods, time.Now())
+	if err != nil {
+		return handleChildError(c, err, "GetMyInterestPreview")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Interest projection calculated successfully", Data: projection})
+}
@@ -0,0 +1,109 @@
+// internal/api/v1/handlers/ledger_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// LedgerHandler menangani endpoint integritas ledger poin (Admin): verifikasi rantai hash
+// dan koreksi entri lewat entri pembalik.
+type LedgerHandler struct {
+	LedgerService service.LedgerService
+	Validate      *validator.Validate
+}
+
+// NewLedgerHandler membuat instance baru dari LedgerHandler.
+func NewLedgerHandler(ledgerService service.LedgerService) *LedgerHandler {
+	return &LedgerHandler{
+		LedgerService: ledgerService,
+		Validate:      validator.New(),
+	}
+}
+
+// VerifyLedger godoc
+// @Summary Verify Point Ledger Integrity (Admin)
+// @Description Recomputes the per-child hash chain of the point ledger and reports entries that were modified directly in the database (hash_mismatch, broken_link), missing or inserted entries (sequence_gap) and deleted tail entries (head_mismatch). Without user_id all children are checked.
+// @Tags Admin - Ledger
+// @Produce json
+// @Param user_id query int false "Only verify this child's chain"
+// @Success 200 {object} models.Response{data=models.LedgerVerificationReport} "Verification finished (see data.valid)"
+// @Failure 400 {object} models.Response "Invalid user_id"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (User is not an Admin)"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/ledger/verify [get]
+func (h *LedgerHandler) VerifyLedger(c *fiber.Ctx) error {
+	userID := 0
+	if raw := c.Query("user_id"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid user_id query parameter"})
+		}
+		userID = parsed
+	}
+
+	report, err := h.LedgerService.VerifyLedger(c.Context(), userID)
+	if err != nil {
+		zlog.Error().Err(err).Int("user_id", userID).Msg("Handler: Failed to verify point ledger")
+		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{Success: false, Message: "Failed to verify point ledger"})
+	}
+
+	message := "Point ledger is intact"
+	if !report.Valid {
+		message = "Point ledger integrity issues found"
+	}
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: message, Data: report})
+}
+
+// ReverseTransaction godoc
+// @Summary Correct Point Transaction (Admin)
+// @Description The point ledger is append-only, so a wrong entry is corrected by recording a 'correction' entry with the opposite amount (same currency) that references it. Reversal entries cannot be reversed and each transaction can only be reversed once. The correction is recorded in the audit trail.
+// @Tags Admin - Ledger
+// @Accept json
+// @Produce json
+// @Param transactionId path int true "Point Transaction ID"
+// @Param reverse_input body models.ReversePointTransactionInput true "Reason for the correction"
+// @Success 201 {object} models.Response{data=models.PointTransaction} "Correction recorded"
+// @Failure 400 {object} models.Response "Invalid ID, validation failed, already reversed, or balance would become negative"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (User is not an Admin)"
+// @Failure 404 {object} models.Response "Transaction not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/point-transactions/{transactionId}/reverse [post]
+func (h *LedgerHandler) ReverseTransaction(c *fiber.Ctx) error {
+	adminID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract adminID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	transactionID, err := strconv.Atoi(c.Params("transactionId"))
+	if err != nil || transactionID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Transaction ID parameter"})
+	}
+
+	input := new(models.ReversePointTransactionInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	correction, err := h.LedgerService.ReverseTransaction(c.Context(), adminID, transactionID, input)
+	if err != nil {
+		return handleParentError(c, err, "ReverseTransaction")
+	}
+
+	return c.Status(http.StatusCreated).JSON(models.Response{Success: true, Message: "Correction recorded successfully", Data: correction})
+}
@@ -0,0 +1,82 @@
+// internal/api/v1/handlers/level_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// LevelHandler menangani endpoint XP & level anak (Parent dan Child).
+type LevelHandler struct {
+	LevelService service.LevelService
+}
+
+// NewLevelHandler membuat instance baru dari LevelHandler.
+func NewLevelHandler(levelService service.LevelService) *LevelHandler {
+	return &LevelHandler{
+		LevelService: levelService,
+	}
+}
+
+// GetChildLevel godoc
+// @Summary Get Child Level
+// @Description Retrieves the child's level and progress. XP is earned from approved tasks (the task's value, at least 1 XP) and is never spent, so claiming rewards does not lower it. Also returns the level ladder with the parent's rewards unlocked at each level (`min_level`) and the child's level-up history.
+// @Tags Parent - Children
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response{data=models.LevelOverview} "Level retrieved"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Not the parent of this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/level [get]
+func (h *LevelHandler) GetChildLevel(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	overview, err := h.LevelService.GetChildLevel(c.Context(), parentID, childID)
+	if err != nil {
+		return handleParentError(c, err, "GetChildLevel")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Level retrieved successfully", Data: overview})
+}
+
+// GetMyLevel godoc
+// @Summary Get My Level
+// @Description Retrieves the child's level, XP and progress to the next level, the level ladder with rewards unlocked at each level, and the level-up history. XP comes from approved tasks and is not spent on rewards.
+// @Tags Child - Points & Rewards
+// @Produce json
+// @Success 200 {object} models.Response{data=models.LevelOverview} "Level retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/level [get]
+func (h *LevelHandler) GetMyLevel(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	overview, err := h.LevelService.GetMyLevel(c.Context(), childID)
+	if err != nil {
+		return handleChildError(c, err, "GetMyLevel")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Level retrieved successfully", Data: overview})
+}
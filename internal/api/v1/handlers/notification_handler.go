@@ -0,0 +1,120 @@
+// internal/api/v1/handlers/notification_handler.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// NotificationHandler menangani endpoint notifikasi in-app milik pengguna yang sedang login.
+// Logikanya sederhana, sehingga langsung memakai repository.
+type NotificationHandler struct {
+	NotificationRepo repository.NotificationRepository
+}
+
+// NewNotificationHandler membuat instance baru dari NotificationHandler.
+func NewNotificationHandler(notificationRepo repository.NotificationRepository) *NotificationHandler {
+	return &NotificationHandler{
+		NotificationRepo: notificationRepo,
+	}
+}
+
+// GetMyNotifications godoc
+// @Summary Get My Notifications
+// @Description Retrieves the logged-in user's notifications (newest first), optionally only unread ones.
+// @Tags User - Notifications
+// @Produce json
+// @Param unread query bool false "Only unread notifications"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponseGeneric "Notifications retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /user/notifications [get]
+func (h *NotificationHandler) GetMyNotifications(c *fiber.Ctx) error {
+	userID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract userID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	pagination := utils.ParsePaginationParams(c)
+	notifications, totalCount, err := h.NotificationRepo.GetNotificationsByUserID(c.Context(), userID, c.QueryBool("unread"), pagination.Page, pagination.Limit)
+	if err != nil {
+		zlog.Error().Err(err).Int("user_id", userID).Msg("Handler: Failed to get notifications")
+		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{Success: false, Message: "Failed to retrieve notifications"})
+	}
+
+	meta := utils.BuildPaginationMeta(totalCount, pagination.Limit, pagination.Page)
+	return c.Status(http.StatusOK).JSON(utils.NewPaginatedResponse("Notifications retrieved successfully", notifications, meta))
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark Notification as Read
+// @Description Marks one of the logged-in user's notifications as read.
+// @Tags User - Notifications
+// @Produce json
+// @Param notificationId path int true "Notification ID"
+// @Success 200 {object} models.Response "Notification marked as read"
+// @Failure 400 {object} models.Response "Invalid Notification ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 404 {object} models.Response "Notification not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /user/notifications/{notificationId}/read [patch]
+func (h *NotificationHandler) MarkNotificationRead(c *fiber.Ctx) error {
+	userID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract userID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	notificationID, err := strconv.ParseInt(c.Params("notificationId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Notification ID parameter"})
+	}
+
+	if err := h.NotificationRepo.MarkAsRead(c.Context(), notificationID, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(models.Response{Success: false, Message: "Notification not found"})
+		}
+		zlog.Error().Err(err).Int64("notification_id", notificationID).Msg("Handler: Failed to mark notification as read")
+		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{Success: false, Message: "Failed to update notification"})
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Notification marked as read"})
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary Mark All Notifications as Read
+// @Description Marks all unread notifications of the logged-in user as read.
+// @Tags User - Notifications
+// @Produce json
+// @Success 200 {object} models.Response{data=map[string]int64} "Notifications marked as read, returns updated count"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /user/notifications/read-all [patch]
+func (h *NotificationHandler) MarkAllNotificationsRead(c *fiber.Ctx) error {
+	userID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract userID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	updated, err := h.NotificationRepo.MarkAllAsRead(c.Context(), userID)
+	if err != nil {
+		zlog.Error().Err(err).Int("user_id", userID).Msg("Handler: Failed to mark all notifications as read")
+		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{Success: false, Message: "Failed to update notifications"})
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Notifications marked as read", Data: fiber.Map{"updated": updated}})
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -35,6 +36,8 @@ type ParentHandler struct {
 	RewardService     service.RewardService
 	UserService       service.UserService
 	InvitationService service.InvitationService
+	LevelService      service.LevelService // Level & progres anak pada daftar anak
+	BadgeService      service.BadgeService // Evaluasi badge saldo setelah penyesuaian poin manual
 
 	Validate *validator.Validate
 }
@@ -52,6 +55,8 @@ func NewParentHandler(
 	rewardService service.RewardService,
 	userService service.UserService,
 	invitationService service.InvitationService,
+	levelService service.LevelService,
+	badgeService service.BadgeService,
 ) *ParentHandler {
 	return &ParentHandler{
 		UserRelRepo:       userRelRepo,
@@ -65,6 +70,8 @@ func NewParentHandler(
 		RewardService:     rewardService,
 		UserService:       userService,
 		InvitationService: invitationService,
+		LevelService:      levelService,
+		BadgeService:      badgeService,
 		Validate:          validator.New(),
 	}
 }
@@ -108,16 +115,33 @@ func handleParentError(c *fiber.Ctx, err error, operation string) error {
 	return c.Status(fiber.StatusInternalServerError).JSON(models.Response{Success: false, Message: "An internal error occurred"})
 }
 
+// parseDefinitionFilter membaca & memvalidasi query parameter pencarian definisi Task/Reward.
+// Jika gagal, response 400 sudah dikirim dan ok bernilai false.
+func (h *ParentHandler) parseDefinitionFilter(c *fiber.Ctx) (filter *models.DefinitionFilter, ok bool, err error) {
+	filter = new(models.DefinitionFilter)
+	if err := c.QueryParser(filter); err != nil {
+		return nil, false, c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid query parameters"})
+	}
+	if err := h.Validate.Struct(filter); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return nil, false, c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+	if filter.MinPoints != nil && filter.MaxPoints != nil && *filter.MinPoints > *filter.MaxPoints {
+		return nil, false, c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid query parameters: min_points cannot be greater than max_points"})
+	}
+	return filter, true, nil
+}
+
 // ==========================================================
 // // --- Child Management ---
 // ==========================================================
 
 // GetMyChildren godoc
 // @Summary Get My Children
-// @Description Retrieves a list of child user accounts associated with the logged-in parent account.
+// @Description Retrieves a list of child user accounts associated with the logged-in parent account, each with the child's level and XP progress (`level`).
 // @Tags Parent - Children
 // @Produce json
-// @Success 200 {object} models.Response "Children retrieved"
+// @Success 200 {object} models.Response{data=[]models.ChildOverview} "Children retrieved"
 // @Failure 400 {object} models.Response "Invalid query parameters"
 // @Failure 401 {object} models.Response "Unauthorized"
 // @Failure 500 {object} models.Response "Internal server error"
@@ -138,7 +162,24 @@ func (h *ParentHandler) GetMyChildren(c *fiber.Ctx) error {
 		return handleParentError(c, err, "GetMyChildren")
 	}
 
-	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Children retrieved successfully", Data: children})
+	// Lengkapi setiap anak dengan level & progres XP-nya
+	childIDs := make([]int, len(children))
+	for i, child := range children {
+		childIDs[i] = child.ID
+	}
+	levels, err := h.LevelService.GetLevelProgressForUsers(ctx, childIDs)
+	if err != nil {
+		return handleParentError(c, err, "GetMyChildren")
+	}
+	overviews := make([]models.ChildOverview, len(children))
+	for i, child := range children {
+		overviews[i] = models.ChildOverview{User: child}
+		if level, ok := levels[child.ID]; ok {
+			overviews[i].Level = &level
+		}
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Children retrieved successfully", Data: overviews})
 }
 
 // AddChild godoc
@@ -314,7 +355,10 @@ func (h *ParentHandler) CreateTaskDefinition(c *fiber.Ctx) error {
 	task := &models.Task{
 		TaskName:        input.TaskName,
 		TaskPoint:       input.TaskPoint,
+		CurrencyID:      input.CurrencyID,
 		TaskDescription: input.TaskDescription,
+		Category:        models.DefinitionCategory(input.Category),
+		Tags:            input.Tags,
 		CreatedByUserID: parentID, // Set creator dari JWT
 	}
 
@@ -330,12 +374,20 @@ func (h *ParentHandler) CreateTaskDefinition(c *fiber.Ctx) error {
 
 // GetMyTaskDefinitions godoc
 // @Summary Get My Task Definitions
-// @Description Retrieves a paginated list of task definitions created by the logged-in parent.
+// @Description Retrieves a paginated list of task definitions created by the logged-in parent, with optional search, category/tag filters, point range and sorting.
 // @Tags Parent - Tasks
 // @Produce json
+// @Param q query string false "Full-text search on name and description"
+// @Param category query string false "Filter by category" Enums(chores, homework, behaviour, health)
+// @Param tag query string false "Filter by tag"
+// @Param min_points query int false "Minimum points (inclusive)"
+// @Param max_points query int false "Maximum points (inclusive)"
+// @Param sort_by query string false "Sort field (default: created_at, or relevance when q is set)" Enums(created_at, name, points, relevance)
+// @Param sort_order query string false "Sort direction" Enums(asc, desc) default(desc)
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Success 200 {object} utils.PaginatedResponseGeneric "Task definitions retrieved"
+// @Failure 400 {object} models.Response "Invalid query parameters"
 // @Failure 401 {object} models.Response "Unauthorized"
 // @Failure 500 {object} models.Response "Internal server error"
 // @Security ApiKeyAuth
@@ -347,10 +399,14 @@ func (h *ParentHandler) GetMyTaskDefinitions(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
 	}
 
+	filter, ok, err := h.parseDefinitionFilter(c)
+	if !ok {
+		return err
+	}
 	pagination := utils.ParsePaginationParams(c)
 	ctx := c.Context()
 
-	tasks, totalCount, err := h.TaskRepo.GetTasksByCreatorID(ctx, parentID, pagination.Page, pagination.Limit)
+	tasks, totalCount, err := h.TaskRepo.GetTasksByCreatorID(ctx, parentID, filter, pagination.Page, pagination.Limit)
 	if err != nil {
 		return handleParentError(c, err, "GetMyTaskDefinitions")
 	}
@@ -428,7 +484,10 @@ func (h *ParentHandler) UpdateMyTaskDefinition(c *fiber.Ctx) error {
 		ID:              taskID,
 		TaskName:        input.TaskName,
 		TaskPoint:       input.TaskPoint,
+		CurrencyID:      input.CurrencyID,
 		TaskDescription: input.TaskDescription,
+		Category:        models.DefinitionCategory(input.Category),
+		Tags:            input.Tags,
 	}
 	// Panggil UpdateTask repo (yang masih cek ownership di WHERE - ini jadi lapisan kedua)
 	err = h.TaskRepo.UpdateTask(ctx, taskToUpdate, parentID)
@@ -520,7 +579,7 @@ func (h *ParentHandler) DeleteMyTaskDefinition(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param childId path int true "Child User ID to assign task to"
-// @Param assign_task_input body models.AssignTaskInput true "Task Definition ID to assign (e.g., {\"task_id\": 1})"
+// @Param assign_task_input body models.AssignTaskInput true "Task Definition ID to assign, with optional due_at after which the task expires (e.g., {\"task_id\": 1})"
 // @Success 201 {object} models.Response{data=map[string]int} "Task assigned to child successfully, returns user_task_id"
 // @Failure 400 {object} models.Response "Invalid Child ID, Task ID, or request body"
 // @Failure 401 {object} models.Response "Unauthorized"
@@ -563,6 +622,9 @@ func (h *ParentHandler) AssignTaskToChild(c *fiber.Ctx) error {
 		errorDetails := utils.FormatValidationErrors(err)
 		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
 	}
+	if input.DueAt != nil && !input.DueAt.After(time.Now()) {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "due_at must be in the future"})
+	}
 
 	// 3. Cek apakah Task Definition ADA
 	taskDefinition, err := h.TaskRepo.GetTaskByID(ctx, input.TaskID)
@@ -617,9 +679,16 @@ func (h *ParentHandler) AssignTaskToChild(c *fiber.Ctx) error {
 	}
 
 	// 6. Assign task (Karena sudah divalidasi)
-	userTaskID, err := h.UserTaskRepo.AssignTask(ctx, childID, taskDefinition.ID, parentID)
+	userTaskID, err := h.UserTaskRepo.AssignTask(ctx, childID, taskDefinition.ID, parentID, input.DueAt)
 	if err != nil {
 		// Handle error saat assign (misal FK violation jika task/child dihapus setelah cek)
+		// Penugasan bersamaan bisa lolos cek di atas; repo mengecek ulang di bawah kunci
+		if errors.Is(err, repository.ErrTaskAlreadyActive) {
+			return c.Status(fiber.StatusConflict).JSON(models.Response{
+				Success: false,
+				Message: fmt.Sprintf("Task '%s' is already assigned or submitted for this child and needs to be completed or verified first.", taskDefinition.TaskName),
+			})
+		}
 		if strings.Contains(err.Error(), "invalid user, task, or assigner ID") {
 			return c.Status(fiber.StatusNotFound).JSON(models.Response{Success: false, Message: "Child or Task definition became invalid during assignment."})
 		}
@@ -680,13 +749,168 @@ func (h *ParentHandler) VerifySubmittedTask(c *fiber.Ctx) error {
 	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Task status updated successfully"})
 }
 
+// UnassignTask godoc
+// @Summary Unassign Task
+// @Description Withdraws a mistaken task assignment by marking it 'cancelled' (kept in the timeline). Only assignments that are not approved can be unassigned (revert an approval first).
+// @Tags Parent - Tasks
+// @Produce json
+// @Param userTaskId path int true "UserTask ID to unassign"
+// @Success 200 {object} models.Response "Task unassigned successfully"
+// @Failure 400 {object} models.Response "Invalid UserTask ID or task already approved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not parent of this child)"
+// @Failure 404 {object} models.Response "Task assignment not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/tasks/{userTaskId}/unassign [patch]
+func (h *ParentHandler) UnassignTask(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	userTaskID, err := strconv.Atoi(c.Params("userTaskId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid UserTask ID parameter"})
+	}
+
+	if err := h.TaskService.UnassignTask(c.Context(), userTaskID, parentID); err != nil {
+		return handleParentError(c, err, "UnassignTask")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Task unassigned successfully"})
+}
+
+// ReassignTask godoc
+// @Summary Reassign Task to Another Child
+// @Description Moves a task assignment that has not been started ('assigned') to another child of the logged-in parent.
+// @Tags Parent - Tasks
+// @Accept json
+// @Produce json
+// @Param userTaskId path int true "UserTask ID to reassign"
+// @Param reassign_input body models.ReassignTaskInput true "Target child"
+// @Success 200 {object} models.Response "Task reassigned successfully"
+// @Failure 400 {object} models.Response "Invalid UserTask ID, request body, or task not in 'assigned' state"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not parent of the current or target child)"
+// @Failure 404 {object} models.Response "Task assignment not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/tasks/{userTaskId}/reassign [patch]
+func (h *ParentHandler) ReassignTask(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	userTaskID, err := strconv.Atoi(c.Params("userTaskId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid UserTask ID parameter"})
+	}
+
+	input := new(models.ReassignTaskInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	if err := h.TaskService.ReassignTask(c.Context(), userTaskID, parentID, input.ChildID); err != nil {
+		return handleParentError(c, err, "ReassignTask")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Task reassigned successfully"})
+}
+
+// RevertTaskVerification godoc
+// @Summary Revert Task Verification
+// @Description Reverts an approved or rejected task back to 'submitted' within the configured revert window (TASK_REVERT_WINDOW_HOURS). Points from an approval are reversed with a compensating ledger entry.
+// @Tags Parent - Tasks
+// @Accept json
+// @Produce json
+// @Param userTaskId path int true "UserTask ID to revert"
+// @Param revert_input body models.RevertVerificationInput true "Reason for the revert"
+// @Success 200 {object} models.Response "Task verification reverted successfully"
+// @Failure 400 {object} models.Response "Invalid input, wrong status, revert window passed, or insufficient balance"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not parent of this child)"
+// @Failure 404 {object} models.Response "Task assignment not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/tasks/{userTaskId}/revert [patch]
+func (h *ParentHandler) RevertTaskVerification(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	userTaskID, err := strconv.Atoi(c.Params("userTaskId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid UserTask ID parameter"})
+	}
+
+	input := new(models.RevertVerificationInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	if err := h.TaskService.RevertVerification(c.Context(), userTaskID, parentID, input.Reason); err != nil {
+		return handleParentError(c, err, "RevertTaskVerification")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Task verification reverted successfully"})
+}
+
+// GetTaskTimeline godoc
+// @Summary Get Task Timeline
+// @Description Retrieves the status transition history of a task assignment (oldest first), including who made each change and why.
+// @Tags Parent - Tasks
+// @Produce json
+// @Param userTaskId path int true "UserTask ID"
+// @Success 200 {object} models.Response{data=[]models.UserTaskEvent} "Task timeline retrieved"
+// @Failure 400 {object} models.Response "Invalid UserTask ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not parent of this child)"
+// @Failure 404 {object} models.Response "Task assignment not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/tasks/{userTaskId}/timeline [get]
+func (h *ParentHandler) GetTaskTimeline(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	userTaskID, err := strconv.Atoi(c.Params("userTaskId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid UserTask ID parameter"})
+	}
+
+	events, err := h.TaskService.GetTaskTimeline(c.Context(), userTaskID, parentID)
+	if err != nil {
+		return handleParentError(c, err, "GetTaskTimeline")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Task timeline retrieved successfully", Data: events})
+}
+
 // GetTasksForChild godoc
 // @Summary Get Tasks Assigned to a Specific Child
 // @Description Retrieves a paginated list of tasks assigned to a specific child by the logged-in parent.
 // @Tags Parent - Tasks
 // @Produce json
 // @Param childId path int true "Child User ID"
-// @Param status query string false "Filter by status (assigned, submitted, approved, rejected)" Enums(assigned, submitted, approved, rejected)
+// @Param status query string false "Filter by status (assigned, submitted, approved, rejected, cancelled, expired)" Enums(assigned, submitted, approved, rejected, cancelled, expired)
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Success 200 {object} utils.PaginatedResponseGeneric "Tasks retrieved"
@@ -742,7 +966,7 @@ func (h *ParentHandler) GetTasksForChild(c *fiber.Ctx) error {
 // @Tags Parent - Rewards
 // @Accept json
 // @Produce json
-// @Param reward_input body models.Reward true "Reward Details (Name, Point, Description)"
+// @Param reward_input body models.CreateRewardInput true "Reward Details (Name, Point, Description, optional stock, per-child claim limit and cooldown)"
 // @Success 201 {object} models.Response{data=map[string]int} "Reward definition created"
 // @Failure 400 {object} models.Response "Validation failed"
 // @Failure 401 {object} models.Response "Unauthorized"
@@ -771,7 +995,11 @@ func (h *ParentHandler) CreateRewardDefinition(c *fiber.Ctx) error {
 	reward := &models.Reward{
 		RewardName:        input.RewardName,
 		RewardPoint:       input.RewardPoint,
+		CurrencyID:        input.CurrencyID,
 		RewardDescription: input.RewardDescription,
+		Category:          models.DefinitionCategory(input.Category),
+		Tags:              input.Tags,
+		RewardLimits:      input.ToLimits(),
 		CreatedByUserID:   parentID, // Set creator dari JWT
 	}
 
@@ -786,12 +1014,20 @@ func (h *ParentHandler) CreateRewardDefinition(c *fiber.Ctx) error {
 
 // GetMyRewardDefinitions godoc
 // @Summary Get My Reward Definitions
-// @Description Retrieves reward definitions created by the logged-in parent (paginated).
+// @Description Retrieves reward definitions created by the logged-in parent (paginated), with optional search, category/tag filters, point range and sorting.
 // @Tags Parent - Rewards
 // @Produce json
+// @Param q query string false "Full-text search on name and description"
+// @Param category query string false "Filter by category" Enums(chores, homework, behaviour, health)
+// @Param tag query string false "Filter by tag"
+// @Param min_points query int false "Minimum points (inclusive)"
+// @Param max_points query int false "Maximum points (inclusive)"
+// @Param sort_by query string false "Sort field (default: created_at, or relevance when q is set)" Enums(created_at, name, points, relevance)
+// @Param sort_order query string false "Sort direction" Enums(asc, desc) default(desc)
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Success 200 {object} utils.PaginatedResponseGeneric "Reward definitions retrieved"
+// @Failure 400 {object} models.Response "Invalid query parameters"
 // @Failure 401 {object} models.Response "Unauthorized"
 // @Failure 500 {object} models.Response "Internal server error"
 // @Security ApiKeyAuth
@@ -803,10 +1039,14 @@ func (h *ParentHandler) GetMyRewardDefinitions(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
 	}
 
+	filter, ok, err := h.parseDefinitionFilter(c)
+	if !ok {
+		return err
+	}
 	pagination := utils.ParsePaginationParams(c)
 	ctx := c.Context()
 
-	rewards, totalCount, err := h.RewardRepo.GetRewardsByCreatorID(ctx, parentID, pagination.Page, pagination.Limit)
+	rewards, totalCount, err := h.RewardRepo.GetRewardsByCreatorID(ctx, parentID, filter, pagination.Page, pagination.Limit)
 	if err != nil {
 		return handleParentError(c, err, "GetMyRewardDefinitions")
 	}
@@ -824,7 +1064,7 @@ func (h *ParentHandler) GetMyRewardDefinitions(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param rewardId path int true "Reward Definition ID"
-// @Param reward_input body models.Reward true "Updated Reward Details"
+// @Param reward_input body models.UpdateRewardInput true "Updated Reward Details (limits replace the previous ones)"
 // @Success 200 {object} models.Response "Reward definition updated"
 // @Failure 400 {object} models.Response "Invalid input or Reward ID"
 // @Failure 401 {object} models.Response "Unauthorized"
@@ -882,7 +1122,11 @@ func (h *ParentHandler) UpdateMyRewardDefinition(c *fiber.Ctx) error {
 		ID:                rewardID,
 		RewardName:        input.RewardName,
 		RewardPoint:       input.RewardPoint,
+		CurrencyID:        input.CurrencyID,
 		RewardDescription: input.RewardDescription,
+		Category:          models.DefinitionCategory(input.Category),
+		Tags:              input.Tags,
+		RewardLimits:      input.ToLimits(),
 	}
 
 	err = h.RewardRepo.UpdateReward(ctx, rewardToUpdate, parentID) // repo masih cek ownership juga
@@ -970,13 +1214,13 @@ func (h *ParentHandler) DeleteMyRewardDefinition(c *fiber.Ctx) error {
 
 // ReviewRewardClaim godoc
 // @Summary Review Reward Claim
-// @Description Approve or reject a reward claim submitted by a child.
+// @Description Approve or reject a reward claim submitted by a child. If the child's approval policy requires several parents for this claim value, an approval is recorded as a vote and the claim stays pending until quorum is reached; any rejection rejects the claim and refunds the points.
 // @Tags Parent - Rewards
 // @Accept json
 // @Produce json
 // @Param claimId path int true "UserReward Claim ID"
 // @Param review_input body models.ReviewClaimInput true "Review Input (e.g., {\"status\": \"approved\"})"
-// @Success 200 {object} models.Response "Claim review successful"
+// @Success 200 {object} models.Response{data=models.ClaimReviewResult} "Claim review successful or approval recorded"
 // @Failure 400 {object} models.Response "Invalid input, Claim ID, or status"
 // @Failure 401 {object} models.Response "Unauthorized"
 // @Failure 402 {object} models.Response "Insufficient points (if approving)"
@@ -1010,13 +1254,202 @@ func (h *ParentHandler) ReviewRewardClaim(c *fiber.Ctx) error {
 
 	// --- Panggil Service Layer ---
 	ctx := c.Context()
-	err = h.RewardService.ReviewClaim(ctx, claimID, parentID, newStatus) // Gunakan RewardService
+	result, err := h.RewardService.ReviewClaim(ctx, claimID, parentID, newStatus) // Gunakan RewardService
 	if err != nil {
 		// Gunakan helper untuk tangani error dari service
 		return handleParentError(c, err, "ReviewRewardClaim")
 	}
 
-	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Reward claim reviewed successfully"})
+	// Persetujuan bersama belum lengkap: suara dicatat, klaim masih menunggu orang tua lain
+	if result.Status == models.UserRewardStatusPending {
+		return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Approval recorded, waiting for other parents", Data: result})
+	}
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Reward claim reviewed successfully", Data: result})
+}
+
+// SetFamilyRewardApprovalPolicy godoc
+// @Summary Set Family Reward Approval Policy
+// @Description Creates or updates the logged-in parent's consensus policy for all of their children: claims worth more than points_threshold need approval from required_approvals distinct parents (capped at the number of linked parents). A child-specific policy from any of the child's parents takes precedence.
+// @Tags Parent - Rewards
+// @Accept json
+// @Produce json
+// @Param policy_input body models.SetRewardApprovalPolicyInput true "Policy details"
+// @Success 200 {object} models.Response{data=models.RewardApprovalPolicy} "Policy saved"
+// @Failure 400 {object} models.Response "Validation failed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/reward-approval-policy [put]
+func (h *ParentHandler) SetFamilyRewardApprovalPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	return h.setRewardApprovalPolicy(c, parentID, 0)
+}
+
+// GetFamilyRewardApprovalPolicy godoc
+// @Summary Get Family Reward Approval Policy
+// @Description Retrieves the logged-in parent's consensus approval policy for all of their children.
+// @Tags Parent - Rewards
+// @Produce json
+// @Success 200 {object} models.Response{data=models.RewardApprovalPolicy} "Policy retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 404 {object} models.Response "No family policy set"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/reward-approval-policy [get]
+func (h *ParentHandler) GetFamilyRewardApprovalPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	policy, err := h.RewardService.GetApprovalPolicy(c.Context(), parentID, 0)
+	if err != nil {
+		return handleParentError(c, err, "GetFamilyRewardApprovalPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Reward approval policy retrieved successfully", Data: policy})
+}
+
+// DeleteFamilyRewardApprovalPolicy godoc
+// @Summary Delete Family Reward Approval Policy
+// @Description Removes the logged-in parent's consensus policy for all of their children. Child-specific policies and policies of other parents are unaffected.
+// @Tags Parent - Rewards
+// @Produce json
+// @Success 200 {object} models.Response "Policy deleted"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 404 {object} models.Response "No family policy set"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/reward-approval-policy [delete]
+func (h *ParentHandler) DeleteFamilyRewardApprovalPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	if err := h.RewardService.DeleteApprovalPolicy(c.Context(), parentID, 0); err != nil {
+		return handleParentError(c, err, "DeleteFamilyRewardApprovalPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Reward approval policy deleted successfully"})
+}
+
+// SetRewardApprovalPolicy godoc
+// @Summary Set Child Reward Approval Policy
+// @Description Creates or updates the logged-in parent's consensus policy for one child. It takes precedence over family policies (those set for all children) of any of the child's parents.
+// @Tags Parent - Rewards
+// @Accept json
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Param policy_input body models.SetRewardApprovalPolicyInput true "Policy details"
+// @Success 200 {object} models.Response{data=models.RewardApprovalPolicy} "Policy saved"
+// @Failure 400 {object} models.Response "Invalid Child ID or validation failed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/reward-approval-policy [put]
+func (h *ParentHandler) SetRewardApprovalPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+	return h.setRewardApprovalPolicy(c, parentID, childID)
+}
+
+// setRewardApprovalPolicy memvalidasi input lalu menyimpan kebijakan parent untuk childID (0 = semua anak).
+func (h *ParentHandler) setRewardApprovalPolicy(c *fiber.Ctx, parentID int, childID int) error {
+	input := new(models.SetRewardApprovalPolicyInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	policy, err := h.RewardService.SetApprovalPolicy(c.Context(), parentID, childID, input)
+	if err != nil {
+		return handleParentError(c, err, "SetRewardApprovalPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Reward approval policy saved successfully", Data: policy})
+}
+
+// GetRewardApprovalPolicy godoc
+// @Summary Get Child Reward Approval Policy
+// @Description Retrieves the consensus approval policy that applies to the child: a child-specific policy from any of the child's parents, otherwise the oldest family policy of one of them. created_by_user_id and child_id show which policy applies.
+// @Tags Parent - Rewards
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response{data=models.RewardApprovalPolicy} "Policy retrieved"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 404 {object} models.Response "No policy applies to this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/reward-approval-policy [get]
+func (h *ParentHandler) GetRewardApprovalPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	policy, err := h.RewardService.GetApprovalPolicy(c.Context(), parentID, childID)
+	if err != nil {
+		return handleParentError(c, err, "GetRewardApprovalPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Reward approval policy retrieved successfully", Data: policy})
+}
+
+// DeleteRewardApprovalPolicy godoc
+// @Summary Delete Child Reward Approval Policy
+// @Description Removes the logged-in parent's policy for this child; family policies then apply again. Policies set by other parents are unaffected.
+// @Tags Parent - Rewards
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response "Policy deleted"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 404 {object} models.Response "No policy of yours set for this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/reward-approval-policy [delete]
+func (h *ParentHandler) DeleteRewardApprovalPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	if err := h.RewardService.DeleteApprovalPolicy(c.Context(), parentID, childID); err != nil {
+		return handleParentError(c, err, "DeleteRewardApprovalPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Reward approval policy deleted successfully"})
 }
 
 // GetPendingClaims godoc
@@ -1052,6 +1485,169 @@ func (h *ParentHandler) GetPendingClaims(c *fiber.Ctx) error {
 	return c.Status(http.StatusOK).JSON(response)
 }
 
+// GetUnfulfilledClaims godoc
+// @Summary Get Unfulfilled Reward Claims
+// @Description Retrieves approved or scheduled reward claims that have not been delivered yet, from children associated with the logged-in parent (paginated, earliest delivery date first).
+// @Tags Parent - Rewards
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponseGeneric "Unfulfilled claims retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/claims/unfulfilled [get]
+func (h *ParentHandler) GetUnfulfilledClaims(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	pagination := utils.ParsePaginationParams(c)
+	ctx := c.Context()
+
+	claims, totalCount, err := h.UserRewardRepo.GetUnfulfilledClaimsByParentID(ctx, parentID, pagination.Page, pagination.Limit)
+	if err != nil {
+		return handleParentError(c, err, "GetUnfulfilledClaims")
+	}
+
+	meta := utils.BuildPaginationMeta(totalCount, pagination.Limit, pagination.Page)
+	response := utils.NewPaginatedResponse("Unfulfilled reward claims retrieved successfully", claims, meta)
+
+	return c.Status(http.StatusOK).JSON(response)
+}
+
+// ScheduleClaimFulfillment godoc
+// @Summary Schedule Reward Delivery
+// @Description Sets (or moves) the planned delivery date of an approved reward claim. The claim moves to 'scheduled'.
+// @Tags Parent - Rewards
+// @Accept json
+// @Produce json
+// @Param claimId path int true "UserReward Claim ID"
+// @Param schedule_input body models.ScheduleClaimInput true "Delivery date and optional note"
+// @Success 200 {object} models.Response "Reward delivery scheduled"
+// @Failure 400 {object} models.Response "Invalid input, Claim ID, or claim not approved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not parent of this child)"
+// @Failure 404 {object} models.Response "Claim not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/claims/{claimId}/schedule [patch]
+func (h *ParentHandler) ScheduleClaimFulfillment(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	claimID, err := strconv.Atoi(c.Params("claimId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Claim ID parameter"})
+	}
+
+	input := new(models.ScheduleClaimInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+	if !input.DeliveryDate.After(time.Now()) {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "delivery_date must be in the future"})
+	}
+
+	err = h.RewardService.ScheduleClaimFulfillment(c.Context(), claimID, parentID, input.DeliveryDate, input.Note)
+	if err != nil {
+		return handleParentError(c, err, "ScheduleClaimFulfillment")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Reward delivery scheduled successfully"})
+}
+
+// FulfillRewardClaim godoc
+// @Summary Mark Reward as Delivered
+// @Description Marks an approved or scheduled reward claim as fulfilled (the reward was handed over to the child).
+// @Tags Parent - Rewards
+// @Accept json
+// @Produce json
+// @Param claimId path int true "UserReward Claim ID"
+// @Param fulfill_input body models.FulfillClaimInput false "Optional delivery note"
+// @Success 200 {object} models.Response "Reward marked as fulfilled"
+// @Failure 400 {object} models.Response "Invalid input, Claim ID, or claim not approved/scheduled"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not parent of this child)"
+// @Failure 404 {object} models.Response "Claim not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/claims/{claimId}/fulfill [patch]
+func (h *ParentHandler) FulfillRewardClaim(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	claimID, err := strconv.Atoi(c.Params("claimId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Claim ID parameter"})
+	}
+
+	// Body opsional: hanya berisi catatan
+	input := new(models.FulfillClaimInput)
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+		}
+		if err := h.Validate.Struct(input); err != nil {
+			errorDetails := utils.FormatValidationErrors(err)
+			return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+		}
+	}
+
+	err = h.RewardService.FulfillClaim(c.Context(), claimID, parentID, input.Note)
+	if err != nil {
+		return handleParentError(c, err, "FulfillRewardClaim")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Reward marked as fulfilled successfully"})
+}
+
+// GetClaimHistory godoc
+// @Summary Get Reward Claim History
+// @Description Retrieves the status transition history of a reward claim (claim, review, scheduling, delivery, receipt confirmation), oldest first.
+// @Tags Parent - Rewards
+// @Produce json
+// @Param claimId path int true "UserReward Claim ID"
+// @Success 200 {object} models.Response{data=[]models.UserRewardEvent} "Claim history retrieved"
+// @Failure 400 {object} models.Response "Invalid Claim ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not parent of this child)"
+// @Failure 404 {object} models.Response "Claim not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/claims/{claimId}/history [get]
+func (h *ParentHandler) GetClaimHistory(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	claimID, err := strconv.Atoi(c.Params("claimId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Claim ID parameter"})
+	}
+
+	events, err := h.RewardService.GetClaimHistory(c.Context(), claimID, parentID)
+	if err != nil {
+		return handleParentError(c, err, "GetClaimHistory")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Claim history retrieved successfully", Data: events})
+}
+
 // AdjustChildPoints godoc
 // @Summary Adjust Child Points Manually
 // @Description Allows a parent to manually add or subtract points from a child's balance.
@@ -1139,7 +1735,14 @@ func (h *ParentHandler) AdjustChildPoints(c *fiber.Ctx) error {
 		return handleParentError(c, err, "AdjustChildPoints - Create Transaction")
 	}
 
-	// 8. Kirim Respons Sukses
+	// 8. Evaluasi badge saldo (kegagalan tidak memengaruhi penyesuaian yang sudah tersimpan)
+	if input.ChangeAmount > 0 && h.BadgeService != nil {
+		if _, err := h.BadgeService.EvaluateChild(ctx, childID); err != nil {
+			zlog.Warn().Err(err).Int("child_id", childID).Msg("Handler: Failed to evaluate badges after point adjustment")
+		}
+	}
+
+	// 9. Kirim Respons Sukses
 	zlog.Info().Int("parent_id", parentID).Int("child_id", childID).Int("change_amount", input.ChangeAmount).Msg("Handler: Points adjusted manually successfully")
 	return c.Status(http.StatusOK).JSON(models.Response{
 		Success: true,
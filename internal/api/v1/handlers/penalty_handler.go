@@ -0,0 +1,333 @@
+// internal/api/v1/handlers/penalty_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// PenaltyHandler menangani endpoint katalog pelanggaran & sanksi (Parent) serta riwayat sanksi (Child).
+type PenaltyHandler struct {
+	PenaltyService service.PenaltyService
+	Validate       *validator.Validate
+}
+
+// NewPenaltyHandler membuat instance baru dari PenaltyHandler.
+func NewPenaltyHandler(penaltyService service.PenaltyService) *PenaltyHandler {
+	return &PenaltyHandler{
+		PenaltyService: penaltyService,
+		Validate:       validator.New(),
+	}
+}
+
+// ==========================================================
+// --- Parent: Infraction Types ---
+// ==========================================================
+
+// CreateInfractionType godoc
+// @Summary Create Infraction Type
+// @Description Adds an infraction (e.g. "late to bed", 5 points) to the parent's catalogue. max_per_day limits how often it can be applied to a child per day (in the child's timezone). earn_back_task_id (a task created by the parent) is assigned to the child with each penalty; approving it returns the deducted points.
+// @Tags Parent - Penalties
+// @Accept json
+// @Produce json
+// @Param infraction_input body models.InfractionTypeInput true "Infraction type details"
+// @Success 201 {object} models.Response{data=models.InfractionType} "Infraction type created"
+// @Failure 400 {object} models.Response "Validation failed or earn-back task not found"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Earn-back task was not created by the parent"
+// @Failure 409 {object} models.Response "Infraction type with this name already exists"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/infraction-types [post]
+func (h *PenaltyHandler) CreateInfractionType(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	input := new(models.InfractionTypeInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	infraction, err := h.PenaltyService.CreateInfractionType(c.Context(), parentID, input)
+	if err != nil {
+		return handleParentError(c, err, "CreateInfractionType")
+	}
+
+	return c.Status(http.StatusCreated).JSON(models.Response{Success: true, Message: "Infraction type created successfully", Data: infraction})
+}
+
+// GetInfractionTypes godoc
+// @Summary Get My Infraction Types
+// @Description Retrieves the parent's infraction catalogue.
+// @Tags Parent - Penalties
+// @Produce json
+// @Success 200 {object} models.Response{data=[]models.InfractionType} "Infraction types retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/infraction-types [get]
+func (h *PenaltyHandler) GetInfractionTypes(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	infractions, err := h.PenaltyService.GetInfractionTypes(c.Context(), parentID)
+	if err != nil {
+		return handleParentError(c, err, "GetInfractionTypes")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Infraction types retrieved successfully", Data: infractions})
+}
+
+// UpdateInfractionType godoc
+// @Summary Update Infraction Type
+// @Description Updates an infraction type. Penalties already applied keep their original name and points.
+// @Tags Parent - Penalties
+// @Accept json
+// @Produce json
+// @Param infractionTypeId path int true "Infraction Type ID"
+// @Param infraction_input body models.InfractionTypeInput true "Infraction type details"
+// @Success 200 {object} models.Response{data=models.InfractionType} "Infraction type updated"
+// @Failure 400 {object} models.Response "Invalid Infraction Type ID or validation failed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Not the owner of the infraction type or earn-back task"
+// @Failure 404 {object} models.Response "Infraction type not found"
+// @Failure 409 {object} models.Response "Infraction type with this name already exists"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/infraction-types/{infractionTypeId} [put]
+func (h *PenaltyHandler) UpdateInfractionType(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	infractionTypeID, err := strconv.Atoi(c.Params("infractionTypeId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Infraction Type ID parameter"})
+	}
+
+	input := new(models.InfractionTypeInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	infraction, err := h.PenaltyService.UpdateInfractionType(c.Context(), parentID, infractionTypeID, input)
+	if err != nil {
+		return handleParentError(c, err, "UpdateInfractionType")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Infraction type updated successfully", Data: infraction})
+}
+
+// DeleteInfractionType godoc
+// @Summary Delete Infraction Type
+// @Description Deletes an infraction type. Penalties already applied remain in the child's history.
+// @Tags Parent - Penalties
+// @Produce json
+// @Param infractionTypeId path int true "Infraction Type ID"
+// @Success 200 {object} models.Response "Infraction type deleted"
+// @Failure 400 {object} models.Response "Invalid Infraction Type ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Not the owner of the infraction type"
+// @Failure 404 {object} models.Response "Infraction type not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/infraction-types/{infractionTypeId} [delete]
+func (h *PenaltyHandler) DeleteInfractionType(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	infractionTypeID, err := strconv.Atoi(c.Params("infractionTypeId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Infraction Type ID parameter"})
+	}
+
+	if err := h.PenaltyService.DeleteInfractionType(c.Context(), parentID, infractionTypeID); err != nil {
+		return handleParentError(c, err, "DeleteInfractionType")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Infraction type deleted successfully"})
+}
+
+// ==========================================================
+// --- Parent: Penalties ---
+// ==========================================================
+
+// ApplyPenalty godoc
+// @Summary Apply Penalty
+// @Description Applies an infraction from the parent's catalogue to a child. The deduction is capped at the child's balance, so the balance never goes negative. If the infraction has an earn-back task it is assigned to the child.
+// @Tags Parent - Penalties
+// @Accept json
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Param penalty_input body models.ApplyPenaltyInput true "Penalty details"
+// @Success 201 {object} models.Response{data=models.Penalty} "Penalty applied"
+// @Failure 400 {object} models.Response "Validation failed, unknown infraction type or daily limit reached"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Not the parent of this child or not the owner of the infraction type"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/penalties [post]
+func (h *PenaltyHandler) ApplyPenalty(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	input := new(models.ApplyPenaltyInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	penalty, err := h.PenaltyService.ApplyPenalty(c.Context(), parentID, childID, input)
+	if err != nil {
+		return handleParentError(c, err, "ApplyPenalty")
+	}
+
+	return c.Status(http.StatusCreated).JSON(models.Response{Success: true, Message: "Penalty applied successfully", Data: penalty})
+}
+
+// GetChildPenalties godoc
+// @Summary Get Child Penalties
+// @Description Retrieves the penalties applied to a child (newest first), including the earn-back task status.
+// @Tags Parent - Penalties
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponseGeneric "Penalties retrieved"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Not the parent of this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/penalties [get]
+func (h *PenaltyHandler) GetChildPenalties(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	pagination := utils.ParsePaginationParams(c)
+	penalties, totalCount, err := h.PenaltyService.GetChildPenalties(c.Context(), parentID, childID, pagination.Page, pagination.Limit)
+	if err != nil {
+		return handleParentError(c, err, "GetChildPenalties")
+	}
+
+	meta := utils.BuildPaginationMeta(totalCount, pagination.Limit, pagination.Page)
+	return c.Status(http.StatusOK).JSON(utils.NewPaginatedResponse("Penalties retrieved successfully", penalties, meta))
+}
+
+// GetPenaltyReport godoc
+// @Summary Get Penalty Report
+// @Description Summarises a child's penalties over time, per day, week (starting Monday) or month in the child's timezone, plus totals per infraction. Periods without penalties are included. Defaults to the last 30 days grouped by week; at most 366 days can be reported.
+// @Tags Parent - Penalties
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Param from query string false "Start date (YYYY-MM-DD, inclusive)"
+// @Param to query string false "End date (YYYY-MM-DD, inclusive, default: today)"
+// @Param group_by query string false "Grouping (day, week, month)" default(week)
+// @Success 200 {object} models.Response{data=models.PenaltyReport} "Report retrieved"
+// @Failure 400 {object} models.Response "Invalid Child ID or query parameters"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Not the parent of this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/penalties/report [get]
+func (h *PenaltyHandler) GetPenaltyReport(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	filter := new(models.PenaltyReportFilter)
+	if err := c.QueryParser(filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid query parameters"})
+	}
+	if err := h.Validate.Struct(filter); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	report, err := h.PenaltyService.GetPenaltyReport(c.Context(), parentID, childID, filter)
+	if err != nil {
+		return handleParentError(c, err, "GetPenaltyReport")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Penalty report retrieved successfully", Data: report})
+}
+
+// ==========================================================
+// --- Child: Penalties ---
+// ==========================================================
+
+// GetMyPenalties godoc
+// @Summary Get My Penalties
+// @Description Retrieves the penalties applied to the child (newest first), including any earn-back task and whether it has been earned back.
+// @Tags Child - Points & Rewards
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponseGeneric "Penalties retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/penalties [get]
+func (h *PenaltyHandler) GetMyPenalties(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	pagination := utils.ParsePaginationParams(c)
+	penalties, totalCount, err := h.PenaltyService.GetMyPenalties(c.Context(), childID, pagination.Page, pagination.Limit)
+	if err != nil {
+		return handleChildError(c, err, "GetMyPenalties")
+	}
+
+	meta := utils.BuildPaginationMeta(totalCount, pagination.Limit, pagination.Page)
+	return c.Status(http.StatusOK).JSON(utils.NewPaginatedResponse("Penalties retrieved successfully", penalties, meta))
+}
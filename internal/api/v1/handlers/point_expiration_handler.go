@@ -0,0 +1,247 @@
+// internal/api/v1/handlers/point_expiration_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// PointExpirationHandler menangani endpoint kebijakan kedaluwarsa poin (Parent) dan peringatan poin yang akan kedaluwarsa (Child).
+type PointExpirationHandler struct {
+	PointExpirationService service.PointExpirationService
+	Validate               *validator.Validate
+}
+
+// NewPointExpirationHandler membuat instance baru dari PointExpirationHandler.
+func NewPointExpirationHandler(pointExpirationService service.PointExpirationService) *PointExpirationHandler {
+	return &PointExpirationHandler{
+		PointExpirationService: pointExpirationService,
+		Validate:               validator.New(),
+	}
+}
+
+// ==========================================================
+// --- Parent: Point Expiration Policy ---
+// ==========================================================
+
+// SetFamilyPointExpirationPolicy godoc
+// @Summary Set Family Point Expiration Policy
+// @Description Creates or updates the logged-in parent's expiration policy for all of their children: points expire expire_after_days after being earned (spent oldest first), and the child is warned warn_before_days beforehand. Points earmarked for savings goals never expire. A child-specific policy from any of the child's parents takes precedence.
+// @Tags Parent - Points
+// @Accept json
+// @Produce json
+// @Param policy_input body models.SetPointExpirationPolicyInput true "Policy details"
+// @Success 200 {object} models.Response{data=models.PointExpirationPolicy} "Policy saved"
+// @Failure 400 {object} models.Response "Validation failed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/point-expiration-policy [put]
+func (h *PointExpirationHandler) SetFamilyPointExpirationPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	return h.setPointExpirationPolicy(c, parentID, 0)
+}
+
+// GetFamilyPointExpirationPolicy godoc
+// @Summary Get Family Point Expiration Policy
+// @Description Retrieves the logged-in parent's point expiration policy for all of their children.
+// @Tags Parent - Points
+// @Produce json
+// @Success 200 {object} models.Response{data=models.PointExpirationPolicy} "Policy retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 404 {object} models.Response "No family policy set"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/point-expiration-policy [get]
+func (h *PointExpirationHandler) GetFamilyPointExpirationPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	policy, err := h.PointExpirationService.GetPolicy(c.Context(), parentID, 0)
+	if err != nil {
+		return handleParentError(c, err, "GetFamilyPointExpirationPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Point expiration policy retrieved successfully", Data: policy})
+}
+
+// DeleteFamilyPointExpirationPolicy godoc
+// @Summary Delete Family Point Expiration Policy
+// @Description Removes the logged-in parent's point expiration policy for all of their children. Child-specific policies and policies of other parents are unaffected.
+// @Tags Parent - Points
+// @Produce json
+// @Success 200 {object} models.Response "Policy deleted"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 404 {object} models.Response "No family policy set"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/point-expiration-policy [delete]
+func (h *PointExpirationHandler) DeleteFamilyPointExpirationPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	if err := h.PointExpirationService.DeletePolicy(c.Context(), parentID, 0); err != nil {
+		return handleParentError(c, err, "DeleteFamilyPointExpirationPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Point expiration policy deleted successfully"})
+}
+
+// SetPointExpirationPolicy godoc
+// @Summary Set Child Point Expiration Policy
+// @Description Creates or updates the logged-in parent's point expiration policy for one child. It takes precedence over family policies (those set for all children) of any of the child's parents.
+// @Tags Parent - Points
+// @Accept json
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Param policy_input body models.SetPointExpirationPolicyInput true "Policy details"
+// @Success 200 {object} models.Response{data=models.PointExpirationPolicy} "Policy saved"
+// @Failure 400 {object} models.Response "Invalid Child ID or validation failed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/point-expiration-policy [put]
+func (h *PointExpirationHandler) SetPointExpirationPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+	return h.setPointExpirationPolicy(c, parentID, childID)
+}
+
+// setPointExpirationPolicy memvalidasi input lalu menyimpan kebijakan kedaluwarsa poin parent untuk childID (0 = semua anak).
+func (h *PointExpirationHandler) setPointExpirationPolicy(c *fiber.Ctx, parentID int, childID int) error {
+	input := new(models.SetPointExpirationPolicyInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	policy, err := h.PointExpirationService.SetPolicy(c.Context(), parentID, childID, input)
+	if err != nil {
+		return handleParentError(c, err, "SetPointExpirationPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Point expiration policy saved successfully", Data: policy})
+}
+
+// GetPointExpirationPolicy godoc
+// @Summary Get Child Point Expiration Policy
+// @Description Retrieves the point expiration policy that applies to the child: a child-specific policy from any of the child's parents, otherwise the oldest family policy of one of them. created_by_user_id and child_id show which policy applies.
+// @Tags Parent - Points
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response{data=models.PointExpirationPolicy} "Policy retrieved"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 404 {object} models.Response "No policy applies to this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/point-expiration-policy [get]
+func (h *PointExpirationHandler) GetPointExpirationPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	policy, err := h.PointExpirationService.GetPolicy(c.Context(), parentID, childID)
+	if err != nil {
+		return handleParentError(c, err, "GetPointExpirationPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Point expiration policy retrieved successfully", Data: policy})
+}
+
+// DeletePointExpirationPolicy godoc
+// @Summary Delete Child Point Expiration Policy
+// @Description Removes the logged-in parent's point expiration policy for this child; family policies then apply again (without any, points no longer expire). Policies set by other parents are unaffected.
+// @Tags Parent - Points
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response "Policy deleted"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 404 {object} models.Response "No policy of yours set for this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/point-expiration-policy [delete]
+func (h *PointExpirationHandler) DeletePointExpirationPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	if err := h.PointExpirationService.DeletePolicy(c.Context(), parentID, childID); err != nil {
+		return handleParentError(c, err, "DeletePointExpirationPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Point expiration policy deleted successfully"})
+}
+
+// ==========================================================
+// --- Child: Expiring Points ---
+// ==========================================================
+
+// GetMyExpiringPoints godoc
+// @Summary Get My Expiring Points
+// @Description Retrieves the child's points that expire within the policy's warning window, soonest first. Returns an empty list when no expiration policy is set.
+// @Tags Child - Points & Rewards
+// @Produce json
+// @Success 200 {object} models.Response{data=models.ExpiringPointsSummary} "Expiring points retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/points/expiring [get]
+func (h *PointExpirationHandler) GetMyExpiringPoints(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	summary, err := h.PointExpirationService.GetExpiringPoints(c.Context(), childID, time.Now())
+	if err != nil {
+		return handleChildError(c, err, "GetMyExpiringPoints")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Expiring points retrieved successfully", Data: summary})
+}
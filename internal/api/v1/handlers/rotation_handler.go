@@ -0,0 +1,340 @@
+// internal/api/v1/handlers/rotation_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// RotationHandler menangani endpoint Parent untuk rotasi tugas antar saudara.
+type RotationHandler struct {
+	RotationService service.RotationService
+	Validate        *validator.Validate
+}
+
+// NewRotationHandler membuat instance baru dari RotationHandler.
+func NewRotationHandler(rotationService service.RotationService) *RotationHandler {
+	return &RotationHandler{
+		RotationService: rotationService,
+		Validate:        validator.New(),
+	}
+}
+
+// parseRotationRequest mengambil parentID dari JWT dan rotationId dari path.
+// Jika gagal, response error sudah dikirim dan ok bernilai false.
+func parseRotationRequest(c *fiber.Ctx) (parentID int, rotationID int, ok bool, err error) {
+	parentID, err = utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return 0, 0, false, c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	rotationID, err = strconv.Atoi(c.Params("rotationId"))
+	if err != nil {
+		return 0, 0, false, c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Rotation ID parameter"})
+	}
+	return parentID, rotationID, true, nil
+}
+
+// ==========================================================
+// --- Task Rotation Management ---
+// ==========================================================
+
+// CreateRotation godoc
+// @Summary Create Task Rotation
+// @Description Creates a rotation group that hands a task definition to an ordered list of children on a daily or weekly cadence.
+// @Tags Parent - Rotations
+// @Accept json
+// @Produce json
+// @Param rotation_input body models.CreateRotationInput true "Rotation details"
+// @Success 201 {object} models.Response{data=map[string]int} "Rotation created, returns rotation_id"
+// @Failure 400 {object} models.Response "Invalid request body or validation failed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of every child or no access to the task)"
+// @Failure 404 {object} models.Response "Task definition not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/rotations [post]
+func (h *RotationHandler) CreateRotation(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	input := new(models.CreateRotationInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	rotationID, err := h.RotationService.CreateRotation(c.Context(), parentID, input)
+	if err != nil {
+		return handleParentError(c, err, "CreateRotation")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.Response{Success: true, Message: "Rotation created successfully", Data: fiber.Map{"rotation_id": rotationID}})
+}
+
+// GetMyRotations godoc
+// @Summary Get My Task Rotations
+// @Description Retrieves a paginated list of task rotations created by the logged-in parent.
+// @Tags Parent - Rotations
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponseGeneric "Rotations retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/rotations [get]
+func (h *RotationHandler) GetMyRotations(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	pagination := utils.ParsePaginationParams(c)
+	rotations, totalCount, err := h.RotationService.GetRotationsForParent(c.Context(), parentID, pagination.Page, pagination.Limit)
+	if err != nil {
+		return handleParentError(c, err, "GetMyRotations")
+	}
+
+	meta := utils.BuildPaginationMeta(totalCount, pagination.Limit, pagination.Page)
+	return c.Status(http.StatusOK).JSON(utils.NewPaginatedResponse("Rotations retrieved successfully", rotations, meta))
+}
+
+// GetRotation godoc
+// @Summary Get Task Rotation Detail
+// @Description Retrieves a task rotation with its ordered members.
+// @Tags Parent - Rotations
+// @Produce json
+// @Param rotationId path int true "Rotation ID"
+// @Success 200 {object} models.Response{data=models.TaskRotation} "Rotation retrieved"
+// @Failure 400 {object} models.Response "Invalid Rotation ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden"
+// @Failure 404 {object} models.Response "Rotation not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/rotations/{rotationId} [get]
+func (h *RotationHandler) GetRotation(c *fiber.Ctx) error {
+	parentID, rotationID, ok, err := parseRotationRequest(c)
+	if !ok {
+		return err
+	}
+
+	rotation, err := h.RotationService.GetRotation(c.Context(), rotationID, parentID)
+	if err != nil {
+		return handleParentError(c, err, "GetRotation")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Rotation retrieved successfully", Data: rotation})
+}
+
+// DeleteRotation godoc
+// @Summary Delete Task Rotation
+// @Description Deletes a task rotation and its turn history. Tasks already assigned by the rotation are kept.
+// @Tags Parent - Rotations
+// @Produce json
+// @Param rotationId path int true "Rotation ID"
+// @Success 200 {object} models.Response "Rotation deleted"
+// @Failure 400 {object} models.Response "Invalid Rotation ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden"
+// @Failure 404 {object} models.Response "Rotation not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/rotations/{rotationId} [delete]
+func (h *RotationHandler) DeleteRotation(c *fiber.Ctx) error {
+	parentID, rotationID, ok, err := parseRotationRequest(c)
+	if !ok {
+		return err
+	}
+
+	if err := h.RotationService.DeleteRotation(c.Context(), rotationID, parentID); err != nil {
+		return handleParentError(c, err, "DeleteRotation")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Rotation deleted successfully"})
+}
+
+// AdvanceRotation godoc
+// @Summary Hand Out Next Rotation Turn Now
+// @Description Immediately assigns the task to the child whose turn is next and advances the rotation.
+// @Tags Parent - Rotations
+// @Produce json
+// @Param rotationId path int true "Rotation ID"
+// @Success 200 {object} models.Response{data=models.TaskRotationTurn} "Turn handed out"
+// @Failure 400 {object} models.Response "Invalid Rotation ID or rotation cannot be advanced"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden"
+// @Failure 404 {object} models.Response "Rotation not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/rotations/{rotationId}/advance [post]
+func (h *RotationHandler) AdvanceRotation(c *fiber.Ctx) error {
+	parentID, rotationID, ok, err := parseRotationRequest(c)
+	if !ok {
+		return err
+	}
+
+	turn, err := h.RotationService.AdvanceRotation(c.Context(), rotationID, parentID)
+	if err != nil {
+		return handleParentError(c, err, "AdvanceRotation")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Rotation turn handed out", Data: turn})
+}
+
+// SkipRotationTurn godoc
+// @Summary Skip Next Rotation Turn
+// @Description Skips the child whose turn is next; the upcoming scheduled turn goes to the following child.
+// @Tags Parent - Rotations
+// @Accept json
+// @Produce json
+// @Param rotationId path int true "Rotation ID"
+// @Param skip_input body models.SkipRotationTurnInput false "Optional reason"
+// @Success 200 {object} models.Response{data=models.TaskRotationTurn} "Turn skipped"
+// @Failure 400 {object} models.Response "Invalid Rotation ID or request body"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden"
+// @Failure 404 {object} models.Response "Rotation not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/rotations/{rotationId}/skip [post]
+func (h *RotationHandler) SkipRotationTurn(c *fiber.Ctx) error {
+	parentID, rotationID, ok, err := parseRotationRequest(c)
+	if !ok {
+		return err
+	}
+
+	input := new(models.SkipRotationTurnInput)
+	if len(c.Body()) > 0 { // Body bersifat opsional
+		if err := c.BodyParser(input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+		}
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	turn, err := h.RotationService.SkipTurn(c.Context(), rotationID, parentID, input.Notes)
+	if err != nil {
+		return handleParentError(c, err, "SkipRotationTurn")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Rotation turn skipped", Data: turn})
+}
+
+// SwapRotationMembers godoc
+// @Summary Swap Two Children in a Rotation
+// @Description Swaps the order positions of two children within a rotation.
+// @Tags Parent - Rotations
+// @Accept json
+// @Produce json
+// @Param rotationId path int true "Rotation ID"
+// @Param swap_input body models.SwapRotationMembersInput true "Children to swap"
+// @Success 200 {object} models.Response "Members swapped"
+// @Failure 400 {object} models.Response "Invalid input or children are not members"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden"
+// @Failure 404 {object} models.Response "Rotation not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/rotations/{rotationId}/swap [post]
+func (h *RotationHandler) SwapRotationMembers(c *fiber.Ctx) error {
+	parentID, rotationID, ok, err := parseRotationRequest(c)
+	if !ok {
+		return err
+	}
+
+	input := new(models.SwapRotationMembersInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	err = h.RotationService.SwapMembers(c.Context(), rotationID, parentID, input.FirstChildID, input.SecondChildID)
+	if err != nil {
+		return handleParentError(c, err, "SwapRotationMembers")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Rotation members swapped successfully"})
+}
+
+// GetRotationHistory godoc
+// @Summary Get Rotation Turn History
+// @Description Retrieves a paginated history of who received (or skipped) each turn of a rotation.
+// @Tags Parent - Rotations
+// @Produce json
+// @Param rotationId path int true "Rotation ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponseGeneric "History retrieved"
+// @Failure 400 {object} models.Response "Invalid Rotation ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden"
+// @Failure 404 {object} models.Response "Rotation not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/rotations/{rotationId}/history [get]
+func (h *RotationHandler) GetRotationHistory(c *fiber.Ctx) error {
+	parentID, rotationID, ok, err := parseRotationRequest(c)
+	if !ok {
+		return err
+	}
+
+	pagination := utils.ParsePaginationParams(c)
+	turns, totalCount, err := h.RotationService.GetRotationHistory(c.Context(), rotationID, parentID, pagination.Page, pagination.Limit)
+	if err != nil {
+		return handleParentError(c, err, "GetRotationHistory")
+	}
+
+	meta := utils.BuildPaginationMeta(totalCount, pagination.Limit, pagination.Page)
+	return c.Status(http.StatusOK).JSON(utils.NewPaginatedResponse("Rotation history retrieved successfully", turns, meta))
+}
+
+// PreviewRotation godoc
+// @Summary Preview Upcoming Rotation Turns
+// @Description Shows which child gets each of the next turns and when, without changing anything.
+// @Tags Parent - Rotations
+// @Produce json
+// @Param rotationId path int true "Rotation ID"
+// @Param count query int false "Number of upcoming turns" default(5) maximum(20)
+// @Success 200 {object} models.Response{data=[]models.RotationPreviewItem} "Preview generated"
+// @Failure 400 {object} models.Response "Invalid Rotation ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden"
+// @Failure 404 {object} models.Response "Rotation not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/rotations/{rotationId}/preview [get]
+func (h *RotationHandler) PreviewRotation(c *fiber.Ctx) error {
+	parentID, rotationID, ok, err := parseRotationRequest(c)
+	if !ok {
+		return err
+	}
+
+	count := c.QueryInt("count", 0) // 0 = gunakan default service
+	preview, err := h.RotationService.PreviewRotation(c.Context(), rotationID, parentID, count)
+	if err != nil {
+		return handleParentError(c, err, "PreviewRotation")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Rotation preview generated", Data: preview})
+}
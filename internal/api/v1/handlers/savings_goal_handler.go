@@ -0,0 +1,249 @@
+// internal/api/v1/handlers/savings_goal_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// SavingsGoalHandler menangani endpoint target tabungan (wishlist) untuk Child dan Parent.
+type SavingsGoalHandler struct {
+	SavingsGoalService service.SavingsGoalService
+	Validate           *validator.Validate
+}
+
+// NewSavingsGoalHandler membuat instance baru dari SavingsGoalHandler.
+func NewSavingsGoalHandler(savingsGoalService service.SavingsGoalService) *SavingsGoalHandler {
+	return &SavingsGoalHandler{
+		SavingsGoalService: savingsGoalService,
+		Validate:           validator.New(),
+	}
+}
+
+// ==========================================================
+// --- Child: Savings Goals ---
+// ==========================================================
+
+// GetMySavingsGoals godoc
+// @Summary Get My Savings Goals
+// @Description Retrieves the child's balance, earmarked and spendable points, and open savings goals with progress computed from the points ledger.
+// @Tags Child - Savings Goals
+// @Produce json
+// @Success 200 {object} models.Response{data=models.SavingsOverview} "Savings goals retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/goals [get]
+func (h *SavingsGoalHandler) GetMySavingsGoals(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	overview, err := h.SavingsGoalService.GetSavingsOverview(c.Context(), childID)
+	if err != nil {
+		return handleChildError(c, err, "GetMySavingsGoals")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Savings goals retrieved successfully", Data: overview})
+}
+
+// CreateSavingsGoal godoc
+// @Summary Create Savings Goal
+// @Description Pins a reward (name and target taken from the reward) or creates a custom goal with goal_name and target_points. auto_claim is only allowed for reward goals.
+// @Tags Child - Savings Goals
+// @Accept json
+// @Produce json
+// @Param goal_input body models.CreateSavingsGoalInput true "Savings goal details"
+// @Success 201 {object} models.Response{data=map[string]int} "Savings goal created, returns goal_id"
+// @Failure 400 {object} models.Response "Invalid request body, validation failed, or duplicate goal for the reward"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Reward not created by your parent)"
+// @Failure 404 {object} models.Response "Reward not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/goals [post]
+func (h *SavingsGoalHandler) CreateSavingsGoal(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	input := new(models.CreateSavingsGoalInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	goalID, err := h.SavingsGoalService.CreateGoal(c.Context(), childID, input)
+	if err != nil {
+		return handleChildError(c, err, "CreateSavingsGoal")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.Response{Success: true, Message: "Savings goal created successfully", Data: fiber.Map{"goal_id": goalID}})
+}
+
+// EarmarkPoints godoc
+// @Summary Earmark Points for Savings Goal
+// @Description Sets aside (positive points) or releases (negative points) points for one of the child's goals. Earmarked points cannot be spent on other rewards.
+// @Tags Child - Savings Goals
+// @Accept json
+// @Produce json
+// @Param goalId path int true "Savings Goal ID"
+// @Param earmark_input body models.EarmarkPointsInput true "Points to earmark or release"
+// @Success 200 {object} models.Response "Earmark updated"
+// @Failure 400 {object} models.Response "Invalid Goal ID, validation failed, goal closed, or amount exceeds the goal"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 402 {object} models.Response "Not enough spendable points"
+// @Failure 404 {object} models.Response "Savings goal not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/goals/{goalId}/earmark [post]
+func (h *SavingsGoalHandler) EarmarkPoints(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	goalID, err := strconv.Atoi(c.Params("goalId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Goal ID parameter"})
+	}
+
+	input := new(models.EarmarkPointsInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	if err := h.SavingsGoalService.EarmarkPoints(c.Context(), goalID, childID, input.Points); err != nil {
+		return handleChildError(c, err, "EarmarkPoints")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Earmarked points updated successfully"})
+}
+
+// CancelSavingsGoal godoc
+// @Summary Cancel Savings Goal
+// @Description Cancels one of the child's open goals and releases its earmarked points.
+// @Tags Child - Savings Goals
+// @Produce json
+// @Param goalId path int true "Savings Goal ID"
+// @Success 200 {object} models.Response "Savings goal cancelled"
+// @Failure 400 {object} models.Response "Invalid Goal ID or goal already closed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 404 {object} models.Response "Savings goal not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/goals/{goalId} [delete]
+func (h *SavingsGoalHandler) CancelSavingsGoal(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	goalID, err := strconv.Atoi(c.Params("goalId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Goal ID parameter"})
+	}
+
+	if err := h.SavingsGoalService.CancelGoal(c.Context(), goalID, childID); err != nil {
+		return handleChildError(c, err, "CancelSavingsGoal")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Savings goal cancelled successfully"})
+}
+
+// ==========================================================
+// --- Parent: Child Savings Goals ---
+// ==========================================================
+
+// GetChildSavingsGoals godoc
+// @Summary Get Child's Savings Goals
+// @Description Retrieves a child's balance, earmarked points, and open savings goals with progress.
+// @Tags Parent - Savings Goals
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response{data=models.SavingsOverview} "Savings goals retrieved"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/goals [get]
+func (h *SavingsGoalHandler) GetChildSavingsGoals(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	overview, err := h.SavingsGoalService.GetChildSavingsOverview(c.Context(), parentID, childID)
+	if err != nil {
+		return handleParentError(c, err, "GetChildSavingsGoals")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Savings goals retrieved successfully", Data: overview})
+}
+
+// ContributeToSavingsGoal godoc
+// @Summary Contribute to Child's Savings Goal
+// @Description Adds bonus points to the child's balance and earmarks them for the goal (up to the remaining target). The child is notified.
+// @Tags Parent - Savings Goals
+// @Accept json
+// @Produce json
+// @Param goalId path int true "Savings Goal ID"
+// @Param contribution_input body models.ContributeToGoalInput true "Contribution details"
+// @Success 201 {object} models.Response "Contribution recorded"
+// @Failure 400 {object} models.Response "Invalid Goal ID, validation failed, or goal already closed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of the goal's child)"
+// @Failure 404 {object} models.Response "Savings goal not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/goals/{goalId}/contributions [post]
+func (h *SavingsGoalHandler) ContributeToSavingsGoal(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	goalID, err := strconv.Atoi(c.Params("goalId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Goal ID parameter"})
+	}
+
+	input := new(models.ContributeToGoalInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	if err := h.SavingsGoalService.ContributeToGoal(c.Context(), goalID, parentID, input); err != nil {
+		return handleParentError(c, err, "ContributeToSavingsGoal")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.Response{Success: true, Message: "Contribution recorded successfully"})
+}
@@ -0,0 +1,304 @@
+// internal/api/v1/handlers/statement_handler.go
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// Format keluaran laporan rekening yang didukung (query ?format=).
+const (
+	statementFormatJSON = "json"
+	statementFormatCSV  = "csv"
+	statementFormatPDF  = "pdf"
+)
+
+// StatementHandler menangani endpoint laporan rekening poin bulanan untuk Parent (per anak) dan Child (diri sendiri).
+type StatementHandler struct {
+	StatementService service.StatementService
+}
+
+// NewStatementHandler membuat instance baru dari StatementHandler.
+func NewStatementHandler(statementService service.StatementService) *StatementHandler {
+	return &StatementHandler{
+		StatementService: statementService,
+	}
+}
+
+// statementQuery berisi parameter query laporan rekening yang sudah divalidasi.
+type statementQuery struct {
+	Month      string
+	CurrencyID int
+	Format     string
+}
+
+// parseStatementQuery membaca ?month=, ?currency_id=, dan ?format=. Mengembalikan pesan error untuk respons 400.
+func parseStatementQuery(c *fiber.Ctx) (*statementQuery, string) {
+	query := &statementQuery{Month: c.Query("month"), Format: c.Query("format", statementFormatJSON)}
+	if !isValidStatementMonth(query.Month) {
+		return nil, "Invalid month parameter, expected YYYY-MM"
+	}
+	if raw := c.Query("currency_id"); raw != "" {
+		currencyID, err := strconv.Atoi(raw)
+		if err != nil || currencyID < 0 {
+			return nil, "Invalid currency_id parameter"
+		}
+		query.CurrencyID = currencyID
+	}
+	switch query.Format {
+	case statementFormatJSON, statementFormatCSV, statementFormatPDF:
+	default:
+		return nil, fmt.Sprintf("Invalid format value: '%s'. Valid formats are json, csv, pdf.", query.Format)
+	}
+	return query, ""
+}
+
+// sendStatement mengirim laporan sebagai JSON, atau sebagai berkas unduhan CSV/PDF.
+func sendStatement(c *fiber.Ctx, statement *models.PointStatement, format string) error {
+	filename := fmt.Sprintf("statement-child-%d-%s", statement.ChildID, statement.Month)
+	switch format {
+	case statementFormatCSV:
+		body, err := renderStatementCSV(statement)
+		if err != nil {
+			zlog.Error().Err(err).Int("child_id", statement.ChildID).Msg("Handler: Failed to render statement CSV")
+			return c.Status(fiber.StatusInternalServerError).JSON(models.Response{Success: false, Message: "Failed to generate statement"})
+		}
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.csv"`, filename))
+		return c.Status(http.StatusOK).Send(body)
+	case statementFormatPDF:
+		c.Set(fiber.HeaderContentType, "application/pdf")
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.pdf"`, filename))
+		return c.Status(http.StatusOK).Send(renderStatementPDF(statement))
+	default:
+		return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Statement retrieved successfully", Data: statement})
+	}
+}
+
+// ==========================================================
+// --- Parent: Statements ---
+// ==========================================================
+
+// GetChildStatement godoc
+// @Summary Get Child's Monthly Account Statement
+// @Description Builds the child's account statement for a calendar month (in the child's timezone): opening balance, credits and debits by transaction type, closing balance and itemized lines linked to tasks and rewards. Returned as JSON or as a downloadable CSV or PDF.
+// @Tags Parent - Points
+// @Produce json
+// @Produce text/csv
+// @Produce application/pdf
+// @Param childId path int true "Child User ID"
+// @Param month query string false "Month in YYYY-MM format (default: current month)"
+// @Param currency_id query int false "Currency ID (default 0 = points)"
+// @Param format query string false "Output format (json, csv, pdf)" default(json)
+// @Success 200 {object} models.Response{data=models.PointStatement} "Statement retrieved (or CSV/PDF file)"
+// @Failure 400 {object} models.Response "Invalid Child ID, month, currency or format"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 404 {object} models.Response "Child or currency not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/statements [get]
+func (h *StatementHandler) GetChildStatement(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+	query, message := parseStatementQuery(c)
+	if query == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: message})
+	}
+
+	statement, err := h.StatementService.GetChildStatement(c.Context(), parentID, childID, query.Month, query.CurrencyID)
+	if err != nil {
+		return handleParentError(c, err, "GetChildStatement")
+	}
+
+	return sendStatement(c, statement, query.Format)
+}
+
+// ==========================================================
+// --- Child: Statements ---
+// ==========================================================
+
+// GetMyStatement godoc
+// @Summary Get My Monthly Account Statement
+// @Description Builds the child's own account statement for a calendar month: opening balance, credits and debits by transaction type, closing balance and itemized lines linked to tasks and rewards. Returned as JSON or as a downloadable CSV or PDF.
+// @Tags Child - Points & Rewards
+// @Produce json
+// @Produce text/csv
+// @Produce application/pdf
+// @Param month query string false "Month in YYYY-MM format (default: current month)"
+// @Param currency_id query int false "Currency ID (default 0 = points)"
+// @Param format query string false "Output format (json, csv, pdf)" default(json)
+// @Success 200 {object} models.Response{data=models.PointStatement} "Statement retrieved (or CSV/PDF file)"
+// @Failure 400 {object} models.Response "Invalid month, currency or format"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 404 {object} models.Response "Currency not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/statements [get]
+func (h *StatementHandler) GetMyStatement(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	query, message := parseStatementQuery(c)
+	if query == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: message})
+	}
+
+	statement, err := h.StatementService.GetStatement(c.Context(), childID, query.Month, query.CurrencyID)
+	if err != nil {
+		return handleChildError(c, err, "GetMyStatement")
+	}
+
+	return sendStatement(c, statement, query.Format)
+}
+
+// ==========================================================
+// --- Export Helpers ---
+// ==========================================================
+
+const (
+	statementDateLayout     = "2006-01-02"
+	statementDateTimeLayout = "2006-01-02 15:04"
+)
+
+// statementLineDescription mengembalikan keterangan baris: nama tugas/hadiah terkait, atau catatan transaksi.
+func statementLineDescription(line models.PointStatementLine) string {
+	switch {
+	case line.TaskName != "":
+		return "Task: " + line.TaskName
+	case line.RewardName != "":
+		return "Reward: " + line.RewardName
+	default:
+		return line.Notes
+	}
+}
+
+// renderStatementCSV menulis laporan sebagai CSV: baris saldo awal, satu baris per transaksi, lalu baris saldo akhir.
+// Waktu ditampilkan di zona waktu laporan.
+func renderStatementCSV(statement *models.PointStatement) ([]byte, error) {
+	loc := models.LoadTimezone(statement.Timezone)
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	records := [][]string{
+		{"date", "transaction_id", "transaction_type", "description", "related_user_task_id", "related_user_reward_id", "credit", "debit", "balance"},
+		{statement.PeriodStart.In(loc).Format(statementDateTimeLayout), "", "opening_balance", "Opening balance", "", "", "", "", strconv.Itoa(statement.OpeningBalance)},
+	}
+	for _, line := range statement.Lines {
+		credit, debit := "", ""
+		if line.ChangeAmount >= 0 {
+			credit = strconv.Itoa(line.ChangeAmount)
+		} else {
+			debit = strconv.Itoa(-line.ChangeAmount)
+		}
+		records = append(records, []string{
+			line.CreatedAt.In(loc).Format(statementDateTimeLayout),
+			strconv.Itoa(line.TransactionID),
+			string(line.TransactionType),
+			statementLineDescription(line),
+			optionalID(line.UserTaskID),
+			optionalID(line.UserRewardID),
+			credit,
+			debit,
+			strconv.Itoa(line.Balance),
+		})
+	}
+	records = append(records, []string{
+		statement.PeriodEnd.In(loc).Format(statementDateTimeLayout), "", "closing_balance", "Closing balance", "", "",
+		strconv.Itoa(statement.TotalCredits), strconv.Itoa(statement.TotalDebits), strconv.Itoa(statement.ClosingBalance),
+	})
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// optionalID mengubah ID menjadi string, atau string kosong jika 0.
+func optionalID(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
+
+// renderStatementPDF menulis laporan sebagai PDF: ringkasan saldo, total per jenis transaksi, dan rincian transaksi.
+func renderStatementPDF(statement *models.PointStatement) []byte {
+	const bodySize, lineSize = 10, 8
+	loc := models.LoadTimezone(statement.Timezone)
+	doc := utils.NewPDFDocument()
+
+	doc.WriteLine(utils.PDFFontBold, 16, fmt.Sprintf("Account Statement - %s", statement.Month))
+	doc.AddSpace(4)
+	doc.WriteLine(utils.PDFFontRegular, bodySize, fmt.Sprintf("Child: %s", statement.ChildUsername))
+	doc.WriteLine(utils.PDFFontRegular, bodySize, fmt.Sprintf("Currency: %s", statement.CurrencyName))
+	doc.WriteLine(utils.PDFFontRegular, bodySize, fmt.Sprintf("Period: %s to %s (%s)",
+		statement.PeriodStart.In(loc).Format(statementDateLayout),
+		statement.PeriodEnd.In(loc).AddDate(0, 0, -1).Format(statementDateLayout), statement.Timezone))
+	doc.AddSpace(8)
+
+	doc.WriteLine(utils.PDFFontBold, 12, "Summary")
+	for _, row := range [][2]string{
+		{"Opening balance", strconv.Itoa(statement.OpeningBalance)},
+		{"Total credits", fmt.Sprintf("+%d", statement.TotalCredits)},
+		{"Total debits", fmt.Sprintf("-%d", statement.TotalDebits)},
+		{"Closing balance", strconv.Itoa(statement.ClosingBalance)},
+	} {
+		doc.WriteLine(utils.PDFFontMono, bodySize, fmt.Sprintf("%-20s %12s", row[0], row[1]))
+	}
+
+	for _, section := range []struct {
+		title  string
+		sign   string
+		totals []models.StatementTypeTotal
+	}{
+		{"Credits by type", "+", statement.Credits},
+		{"Debits by type", "-", statement.Debits},
+	} {
+		doc.AddSpace(8)
+		doc.WriteLine(utils.PDFFontBold, 12, section.title)
+		if len(section.totals) == 0 {
+			doc.WriteLine(utils.PDFFontRegular, bodySize, "None")
+			continue
+		}
+		for _, total := range section.totals {
+			doc.WriteLine(utils.PDFFontMono, bodySize, fmt.Sprintf("%-20s %5dx %12s", total.TransactionType, total.Count, fmt.Sprintf("%s%d", section.sign, total.Amount)))
+		}
+	}
+
+	doc.AddSpace(8)
+	doc.WriteLine(utils.PDFFontBold, 12, "Transactions")
+	if len(statement.Lines) == 0 {
+		doc.WriteLine(utils.PDFFontRegular, bodySize, "No transactions in this period")
+		return doc.Bytes()
+	}
+	// Lebar kolom keterangan mengisi sisa baris Courier
+	lineChars := int(doc.TextWidth() / (lineSize * utils.PDFMonoCharWidth))
+	descWidth := lineChars - (16 + 1 + 18 + 1 + 1 + 9 + 1 + 9)
+	doc.WriteLine(utils.PDFFontMono, lineSize, fmt.Sprintf("%-16s %-18s %-*s %9s %9s", "Date", "Type", descWidth, "Description", "Amount", "Balance"))
+	for _, line := range statement.Lines {
+		desc := []rune(statementLineDescription(line))
+		if len(desc) > descWidth {
+			desc = append(desc[:descWidth-3], []rune("...")...)
+		}
+		doc.WriteLine(utils.PDFFontMono, lineSize, fmt.Sprintf("%-16s %-18s %-*s %+9d %9d",
+			line.CreatedAt.In(loc).Format(statementDateTimeLayout), line.TransactionType, descWidth, string(desc), line.ChangeAmount, line.Balance))
+	}
+	return doc.Bytes()
+}
@@ -0,0 +1,374 @@
+// internal/api/v1/handlers/streak_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// StreakHandler menangani endpoint aturan bonus streak & harga streak freeze (Parent) serta streak anak (Child).
+type StreakHandler struct {
+	StreakService service.StreakService
+	Validate      *validator.Validate
+}
+
+// NewStreakHandler membuat instance baru dari StreakHandler.
+func NewStreakHandler(streakService service.StreakService) *StreakHandler {
+	return &StreakHandler{
+		StreakService: streakService,
+		Validate:      validator.New(),
+	}
+}
+
+// ==========================================================
+// --- Parent: Streak Bonus Rules ---
+// ==========================================================
+
+// GetStreakBonusRules godoc
+// @Summary Get Streak Bonus Rules
+// @Description Retrieves the parent's streak bonus rules.
+// @Tags Parent - Streaks
+// @Produce json
+// @Success 200 {object} models.Response{data=[]models.StreakBonusRule} "Streak bonus rules retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/streak-bonus-rules [get]
+func (h *StreakHandler) GetStreakBonusRules(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	rules, err := h.StreakService.GetBonusRules(c.Context(), parentID)
+	if err != nil {
+		return handleParentError(c, err, "GetStreakBonusRules")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Streak bonus rules retrieved successfully", Data: rules})
+}
+
+// CreateStreakBonusRule godoc
+// @Summary Create Streak Bonus Rule
+// @Description Creates a rule that credits `bonus_points` (a `streak_bonus` ledger entry) when one of the parent's children reaches `streak_days` days in a row with an approved task, counted in the child's timezone. With `task_id` only approvals of that task count. Each rule pays once per streak; after a missed day a new streak can earn it again.
+// @Tags Parent - Streaks
+// @Accept json
+// @Produce json
+// @Param rule_input body models.StreakBonusRuleInput true "Rule details"
+// @Success 201 {object} models.Response{data=models.StreakBonusRule} "Streak bonus rule created"
+// @Failure 400 {object} models.Response "Validation failed or task not found"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Task was not created by the parent"
+// @Failure 409 {object} models.Response "Rule with this name already exists"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/streak-bonus-rules [post]
+func (h *StreakHandler) CreateStreakBonusRule(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	input := new(models.StreakBonusRuleInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	rule, err := h.StreakService.CreateBonusRule(c.Context(), parentID, input)
+	if err != nil {
+		return handleParentError(c, err, "CreateStreakBonusRule")
+	}
+
+	return c.Status(http.StatusCreated).JSON(models.Response{Success: true, Message: "Streak bonus rule created successfully", Data: rule})
+}
+
+// UpdateStreakBonusRule godoc
+// @Summary Update Streak Bonus Rule
+// @Description Updates a streak bonus rule. Bonuses already paid are kept.
+// @Tags Parent - Streaks
+// @Accept json
+// @Produce json
+// @Param ruleId path int true "Streak Bonus Rule ID"
+// @Param rule_input body models.StreakBonusRuleInput true "Rule details"
+// @Success 200 {object} models.Response{data=models.StreakBonusRule} "Streak bonus rule updated"
+// @Failure 400 {object} models.Response "Invalid Rule ID, validation failed or task not found"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Rule or task of another parent"
+// @Failure 404 {object} models.Response "Rule not found"
+// @Failure 409 {object} models.Response "Rule with this name already exists"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/streak-bonus-rules/{ruleId} [put]
+func (h *StreakHandler) UpdateStreakBonusRule(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	ruleID, err := strconv.Atoi(c.Params("ruleId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Rule ID parameter"})
+	}
+
+	input := new(models.StreakBonusRuleInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	rule, err := h.StreakService.UpdateBonusRule(c.Context(), parentID, ruleID, input)
+	if err != nil {
+		return handleParentError(c, err, "UpdateStreakBonusRule")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Streak bonus rule updated successfully", Data: rule})
+}
+
+// DeleteStreakBonusRule godoc
+// @Summary Delete Streak Bonus Rule
+// @Description Deletes a streak bonus rule. Bonuses already paid stay in the ledger.
+// @Tags Parent - Streaks
+// @Produce json
+// @Param ruleId path int true "Streak Bonus Rule ID"
+// @Success 200 {object} models.Response "Streak bonus rule deleted"
+// @Failure 400 {object} models.Response "Invalid Rule ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Rule of another parent"
+// @Failure 404 {object} models.Response "Rule not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/streak-bonus-rules/{ruleId} [delete]
+func (h *StreakHandler) DeleteStreakBonusRule(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	ruleID, err := strconv.Atoi(c.Params("ruleId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Rule ID parameter"})
+	}
+
+	if err := h.StreakService.DeleteBonusRule(c.Context(), parentID, ruleID); err != nil {
+		return handleParentError(c, err, "DeleteStreakBonusRule")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Streak bonus rule deleted successfully"})
+}
+
+// ==========================================================
+// --- Parent: Child Streaks & Streak Freezes ---
+// ==========================================================
+
+// GetChildStreaks godoc
+// @Summary Get Child Streaks
+// @Description Retrieves the child's current streak (days in a row with at least one approved task) and per-task streaks, counted in the child's timezone, plus unused streak freezes and the freeze price.
+// @Tags Parent - Streaks
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response{data=models.StreakSummary} "Streaks retrieved"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Not the parent of this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/streaks [get]
+func (h *StreakHandler) GetChildStreaks(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	summary, err := h.StreakService.GetChildStreaks(c.Context(), parentID, childID)
+	if err != nil {
+		return handleParentError(c, err, "GetChildStreaks")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Streaks retrieved successfully", Data: summary})
+}
+
+// SetStreakFreezePolicy godoc
+// @Summary Set Streak Freeze Policy
+// @Description Lets the child buy streak freezes for `price_points` points each, holding at most `max_owned` unused freezes. A freeze is used automatically for a missed day so the streak continues. Without a policy the child cannot buy freezes.
+// @Tags Parent - Streaks
+// @Accept json
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Param policy_input body models.SetStreakFreezePolicyInput true "Policy details"
+// @Success 200 {object} models.Response{data=models.StreakFreezePolicy} "Policy saved"
+// @Failure 400 {object} models.Response "Invalid Child ID or validation failed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Not the parent of this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/streak-freeze-policy [put]
+func (h *StreakHandler) SetStreakFreezePolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	input := new(models.SetStreakFreezePolicyInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	policy, err := h.StreakService.SetFreezePolicy(c.Context(), parentID, childID, input)
+	if err != nil {
+		return handleParentError(c, err, "SetStreakFreezePolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Streak freeze policy saved successfully", Data: policy})
+}
+
+// GetStreakFreezePolicy godoc
+// @Summary Get Streak Freeze Policy
+// @Description Retrieves the child's streak freeze price.
+// @Tags Parent - Streaks
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response{data=models.StreakFreezePolicy} "Policy retrieved"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Not the parent of this child"
+// @Failure 404 {object} models.Response "No policy set for this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/streak-freeze-policy [get]
+func (h *StreakHandler) GetStreakFreezePolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	policy, err := h.StreakService.GetFreezePolicy(c.Context(), parentID, childID)
+	if err != nil {
+		return handleParentError(c, err, "GetStreakFreezePolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Streak freeze policy retrieved successfully", Data: policy})
+}
+
+// DeleteStreakFreezePolicy godoc
+// @Summary Delete Streak Freeze Policy
+// @Description Removes the child's streak freeze price; the child can no longer buy freezes. Freezes already bought can still be used.
+// @Tags Parent - Streaks
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response "Policy deleted"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Not the parent of this child"
+// @Failure 404 {object} models.Response "No policy set for this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/streak-freeze-policy [delete]
+func (h *StreakHandler) DeleteStreakFreezePolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	if err := h.StreakService.DeleteFreezePolicy(c.Context(), parentID, childID); err != nil {
+		return handleParentError(c, err, "DeleteStreakFreezePolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Streak freeze policy deleted successfully"})
+}
+
+// ==========================================================
+// --- Child: Streaks ---
+// ==========================================================
+
+// GetMyStreaks godoc
+// @Summary Get My Streaks
+// @Description Retrieves the child's current streak and per-task streaks (counted in the child's timezone), whether today already counts, unused streak freezes and the freeze price.
+// @Tags Child - Points & Rewards
+// @Produce json
+// @Success 200 {object} models.Response{data=models.StreakSummary} "Streaks retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/streaks [get]
+func (h *StreakHandler) GetMyStreaks(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	summary, err := h.StreakService.GetMyStreaks(c.Context(), childID)
+	if err != nil {
+		return handleChildError(c, err, "GetMyStreaks")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Streaks retrieved successfully", Data: summary})
+}
+
+// BuyStreakFreeze godoc
+// @Summary Buy Streak Freeze
+// @Description Buys one streak freeze at the price set by a parent (a `streak_freeze` ledger entry). A freeze is used automatically for the first missed day of a running streak. Points earmarked for savings goals cannot be spent.
+// @Tags Child - Points & Rewards
+// @Produce json
+// @Success 201 {object} models.Response{data=models.StreakFreeze} "Streak freeze bought"
+// @Failure 400 {object} models.Response "Already holding the maximum number of freezes"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 402 {object} models.Response "Not enough available points"
+// @Failure 403 {object} models.Response "Streak freezes are not enabled for this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/streak-freezes [post]
+func (h *StreakHandler) BuyStreakFreeze(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	freeze, err := h.StreakService.BuyStreakFreeze(c.Context(), childID)
+	if err != nil {
+		return handleChildError(c, err, "BuyStreakFreeze")
+	}
+
+	return c.Status(http.StatusCreated).JSON(models.Response{Success: true, Message: "Streak freeze bought successfully", Data: freeze})
+}
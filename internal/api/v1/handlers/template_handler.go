@@ -0,0 +1,312 @@
+// internal/api/v1/handlers/template_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// TemplateHandler menangani endpoint katalog template tugas/hadiah untuk Admin dan Parent.
+type TemplateHandler struct {
+	TemplateService service.TemplateService
+	Validate        *validator.Validate
+}
+
+// NewTemplateHandler membuat instance baru dari TemplateHandler.
+func NewTemplateHandler(templateService service.TemplateService) *TemplateHandler {
+	return &TemplateHandler{
+		TemplateService: templateService,
+		Validate:        validator.New(),
+	}
+}
+
+// parseTemplateFilter membaca & memvalidasi query parameter penelusuran template.
+// Jika gagal, response 400 sudah dikirim dan ok bernilai false.
+func (h *TemplateHandler) parseTemplateFilter(c *fiber.Ctx) (filter *models.TemplateFilter, ok bool, err error) {
+	filter = new(models.TemplateFilter)
+	if err := c.QueryParser(filter); err != nil {
+		return nil, false, c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid query parameters"})
+	}
+	if err := h.Validate.Struct(filter); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return nil, false, c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+	return filter, true, nil
+}
+
+// ==========================================================
+// --- Admin: Template Catalogue Management ---
+// ==========================================================
+
+// CreateTemplate godoc
+// @Summary Create Definition Template (Admin)
+// @Description Adds a task or reward template to the system-wide catalogue, with age range, suggested points and localized names/descriptions.
+// @Tags Admin - Templates
+// @Accept json
+// @Produce json
+// @Param template_input body models.TemplateInput true "Template details"
+// @Success 201 {object} models.Response{data=map[string]int} "Template created, returns template_id"
+// @Failure 400 {object} models.Response "Invalid request body, validation failed, or invalid age range"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (User is not an Admin)"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/templates [post]
+func (h *TemplateHandler) CreateTemplate(c *fiber.Ctx) error {
+	adminID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract adminID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	input := new(models.TemplateInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	templateID, err := h.TemplateService.CreateTemplate(c.Context(), adminID, input)
+	if err != nil {
+		return handleParentError(c, err, "CreateTemplate")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.Response{Success: true, Message: "Template created successfully", Data: fiber.Map{"template_id": templateID}})
+}
+
+// GetAllTemplates godoc
+// @Summary Get All Definition Templates (Admin)
+// @Description Retrieves a paginated list of catalogue templates, including inactive ones.
+// @Tags Admin - Templates
+// @Produce json
+// @Param kind query string false "Filter by kind" Enums(task, reward)
+// @Param category query string false "Filter by category" Enums(chores, homework, behaviour, health)
+// @Param age query int false "Only templates suitable for this age"
+// @Param locale query string false "Display locale (default en)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponseGeneric "Templates retrieved"
+// @Failure 400 {object} models.Response "Invalid query parameters"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (User is not an Admin)"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/templates [get]
+func (h *TemplateHandler) GetAllTemplates(c *fiber.Ctx) error {
+	filter, ok, err := h.parseTemplateFilter(c)
+	if !ok {
+		return err
+	}
+
+	pagination := utils.ParsePaginationParams(c)
+	templates, totalCount, err := h.TemplateService.ListTemplates(c.Context(), filter, true, pagination.Page, pagination.Limit)
+	if err != nil {
+		return handleParentError(c, err, "GetAllTemplates")
+	}
+
+	meta := utils.BuildPaginationMeta(totalCount, pagination.Limit, pagination.Page)
+	return c.Status(http.StatusOK).JSON(utils.NewPaginatedResponse("Templates retrieved successfully", templates, meta))
+}
+
+// GetTemplate godoc
+// @Summary Get Definition Template (Admin)
+// @Description Retrieves a catalogue template with all of its translations.
+// @Tags Admin - Templates
+// @Produce json
+// @Param templateId path int true "Template ID"
+// @Param locale query string false "Display locale (default en)"
+// @Success 200 {object} models.Response{data=models.DefinitionTemplate} "Template retrieved"
+// @Failure 400 {object} models.Response "Invalid Template ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (User is not an Admin)"
+// @Failure 404 {object} models.Response "Template not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/templates/{templateId} [get]
+func (h *TemplateHandler) GetTemplate(c *fiber.Ctx) error {
+	templateID, err := strconv.Atoi(c.Params("templateId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Template ID parameter"})
+	}
+
+	template, err := h.TemplateService.GetTemplateByID(c.Context(), templateID, c.Query("locale"))
+	if err != nil {
+		return handleParentError(c, err, "GetTemplate")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Template retrieved successfully", Data: template})
+}
+
+// UpdateTemplate godoc
+// @Summary Update Definition Template (Admin)
+// @Description Replaces a catalogue template's details and translations. Each update bumps the template version so parents who imported it get an update suggestion.
+// @Tags Admin - Templates
+// @Accept json
+// @Produce json
+// @Param templateId path int true "Template ID"
+// @Param template_input body models.TemplateInput true "Template details"
+// @Success 200 {object} models.Response "Template updated"
+// @Failure 400 {object} models.Response "Invalid Template ID, request body, or validation failed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (User is not an Admin)"
+// @Failure 404 {object} models.Response "Template not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/templates/{templateId} [patch]
+func (h *TemplateHandler) UpdateTemplate(c *fiber.Ctx) error {
+	templateID, err := strconv.Atoi(c.Params("templateId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Template ID parameter"})
+	}
+
+	input := new(models.TemplateInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	if err := h.TemplateService.UpdateTemplate(c.Context(), templateID, input); err != nil {
+		return handleParentError(c, err, "UpdateTemplate")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Template updated successfully"})
+}
+
+// DeleteTemplate godoc
+// @Summary Delete Definition Template (Admin)
+// @Description Deletes a catalogue template. Definitions already imported by parents are kept.
+// @Tags Admin - Templates
+// @Produce json
+// @Param templateId path int true "Template ID"
+// @Success 200 {object} models.Response "Template deleted"
+// @Failure 400 {object} models.Response "Invalid Template ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (User is not an Admin)"
+// @Failure 404 {object} models.Response "Template not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/templates/{templateId} [delete]
+func (h *TemplateHandler) DeleteTemplate(c *fiber.Ctx) error {
+	templateID, err := strconv.Atoi(c.Params("templateId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Template ID parameter"})
+	}
+
+	if err := h.TemplateService.DeleteTemplate(c.Context(), templateID); err != nil {
+		return handleParentError(c, err, "DeleteTemplate")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Template deleted successfully"})
+}
+
+// ==========================================================
+// --- Parent: Browse & Import Templates ---
+// ==========================================================
+
+// BrowseTemplates godoc
+// @Summary Browse Definition Templates
+// @Description Retrieves active catalogue templates, filtered by kind, category and the child's age, with names in the requested locale.
+// @Tags Parent - Templates
+// @Produce json
+// @Param kind query string false "Filter by kind" Enums(task, reward)
+// @Param category query string false "Filter by category" Enums(chores, homework, behaviour, health)
+// @Param age query int false "Only templates suitable for this age"
+// @Param locale query string false "Display locale (default en)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponseGeneric "Templates retrieved"
+// @Failure 400 {object} models.Response "Invalid query parameters"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/templates [get]
+func (h *TemplateHandler) BrowseTemplates(c *fiber.Ctx) error {
+	filter, ok, err := h.parseTemplateFilter(c)
+	if !ok {
+		return err
+	}
+
+	pagination := utils.ParsePaginationParams(c)
+	templates, totalCount, err := h.TemplateService.ListTemplates(c.Context(), filter, false, pagination.Page, pagination.Limit)
+	if err != nil {
+		return handleParentError(c, err, "BrowseTemplates")
+	}
+
+	meta := utils.BuildPaginationMeta(totalCount, pagination.Limit, pagination.Page)
+	return c.Status(http.StatusOK).JSON(utils.NewPaginatedResponse("Templates retrieved successfully", templates, meta))
+}
+
+// ImportTemplates godoc
+// @Summary Import Definition Templates
+// @Description Copies one or more active templates into the parent's own task/reward definitions in a single transaction. Each created definition keeps a link to its source template.
+// @Tags Parent - Templates
+// @Accept json
+// @Produce json
+// @Param import_input body models.ImportTemplatesInput true "Template IDs to import"
+// @Success 201 {object} models.Response{data=[]models.ImportedDefinition} "Templates imported"
+// @Failure 400 {object} models.Response "Invalid request body, validation failed, or template not found/inactive"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/templates/import [post]
+func (h *TemplateHandler) ImportTemplates(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	input := new(models.ImportTemplatesInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	imported, err := h.TemplateService.ImportTemplates(c.Context(), parentID, input)
+	if err != nil {
+		return handleParentError(c, err, "ImportTemplates")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.Response{Success: true, Message: "Templates imported successfully", Data: imported})
+}
+
+// GetTemplateUpdates godoc
+// @Summary Get Template Update Suggestions
+// @Description Lists the parent's imported definitions whose source template has been improved since import, with the latest suggested name, description and points.
+// @Tags Parent - Templates
+// @Produce json
+// @Param locale query string false "Display locale (default en)"
+// @Success 200 {object} models.Response{data=[]models.TemplateUpdateSuggestion} "Update suggestions retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/templates/updates [get]
+func (h *TemplateHandler) GetTemplateUpdates(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	suggestions, err := h.TemplateService.GetUpdateSuggestions(c.Context(), parentID, c.Query("locale"))
+	if err != nil {
+		return handleParentError(c, err, "GetTemplateUpdates")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Template update suggestions retrieved successfully", Data: suggestions})
+}
@@ -0,0 +1,459 @@
+// internal/api/v1/handlers/transfer_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// TransferHandler menangani endpoint transfer poin antar saudara (Child) beserta kebijakan dan persetujuannya (Parent).
+type TransferHandler struct {
+	TransferService service.TransferService
+	Validate        *validator.Validate
+}
+
+// NewTransferHandler membuat instance baru dari TransferHandler.
+func NewTransferHandler(transferService service.TransferService) *TransferHandler {
+	return &TransferHandler{
+		TransferService: transferService,
+		Validate:        validator.New(),
+	}
+}
+
+// isValidTransferStatus memeriksa apakah string status valid untuk filter transfer.
+func isValidTransferStatus(status string) bool {
+	switch models.PointTransferStatus(status) {
+	case models.PointTransferStatusPending,
+		models.PointTransferStatusCompleted,
+		models.PointTransferStatusRejected,
+		models.PointTransferStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// ==========================================================
+// --- Parent: Transfer Policy ---
+// ==========================================================
+
+// SetFamilyTransferPolicy godoc
+// @Summary Set Family Transfer Policy
+// @Description Sets the logged-in parent's sibling transfer policy for all of their children: whether they may send points to siblings, the maximum points per transfer (0 = unlimited) and whether each transfer needs parent approval. A child-specific policy from any of the child's parents takes precedence; without any policy, transfers are allowed but require approval.
+// @Tags Parent - Points
+// @Accept json
+// @Produce json
+// @Param policy_input body models.SetTransferPolicyInput true "Policy details"
+// @Success 200 {object} models.Response{data=models.TransferPolicy} "Policy saved"
+// @Failure 400 {object} models.Response "Validation failed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/transfer-policy [put]
+func (h *TransferHandler) SetFamilyTransferPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	return h.setTransferPolicy(c, parentID, 0)
+}
+
+// GetFamilyTransferPolicy godoc
+// @Summary Get Family Transfer Policy
+// @Description Retrieves the logged-in parent's sibling transfer policy for all of their children.
+// @Tags Parent - Points
+// @Produce json
+// @Success 200 {object} models.Response{data=models.TransferPolicy} "Policy retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 404 {object} models.Response "No family policy set"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/transfer-policy [get]
+func (h *TransferHandler) GetFamilyTransferPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	policy, err := h.TransferService.GetPolicy(c.Context(), parentID, 0)
+	if err != nil {
+		return handleParentError(c, err, "GetFamilyTransferPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Transfer policy retrieved successfully", Data: policy})
+}
+
+// DeleteFamilyTransferPolicy godoc
+// @Summary Delete Family Transfer Policy
+// @Description Removes the logged-in parent's transfer policy for all of their children. Child-specific policies and policies of other parents are unaffected.
+// @Tags Parent - Points
+// @Produce json
+// @Success 200 {object} models.Response "Policy deleted"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 404 {object} models.Response "No family policy set"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/transfer-policy [delete]
+func (h *TransferHandler) DeleteFamilyTransferPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	if err := h.TransferService.DeletePolicy(c.Context(), parentID, 0); err != nil {
+		return handleParentError(c, err, "DeleteFamilyTransferPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Transfer policy deleted successfully"})
+}
+
+// SetTransferPolicy godoc
+// @Summary Set Child Transfer Policy
+// @Description Sets the logged-in parent's sibling transfer policy for one child. It takes precedence over family policies (those set for all children) of any of the child's parents.
+// @Tags Parent - Points
+// @Accept json
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Param policy_input body models.SetTransferPolicyInput true "Policy details"
+// @Success 200 {object} models.Response{data=models.TransferPolicy} "Policy saved"
+// @Failure 400 {object} models.Response "Invalid Child ID or validation failed"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/transfer-policy [put]
+func (h *TransferHandler) SetTransferPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+	return h.setTransferPolicy(c, parentID, childID)
+}
+
+// setTransferPolicy memvalidasi input lalu menyimpan kebijakan transfer parent untuk childID (0 = semua anak).
+func (h *TransferHandler) setTransferPolicy(c *fiber.Ctx, parentID int, childID int) error {
+	input := new(models.SetTransferPolicyInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	policy, err := h.TransferService.SetPolicy(c.Context(), parentID, childID, input)
+	if err != nil {
+		return handleParentError(c, err, "SetTransferPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Transfer policy saved successfully", Data: policy})
+}
+
+// GetTransferPolicy godoc
+// @Summary Get Child Transfer Policy
+// @Description Retrieves the sibling transfer policy that applies to the child: a child-specific policy from any of the child's parents, otherwise the oldest family policy of one of them. created_by_user_id and child_id show which policy applies.
+// @Tags Parent - Points
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response{data=models.TransferPolicy} "Policy retrieved"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 404 {object} models.Response "No policy applies to this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/transfer-policy [get]
+func (h *TransferHandler) GetTransferPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	policy, err := h.TransferService.GetPolicy(c.Context(), parentID, childID)
+	if err != nil {
+		return handleParentError(c, err, "GetTransferPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Transfer policy retrieved successfully", Data: policy})
+}
+
+// DeleteTransferPolicy godoc
+// @Summary Delete Child Transfer Policy
+// @Description Removes the logged-in parent's transfer policy for this child; family policies, or the default (allowed with parent approval), then apply again. Policies set by other parents are unaffected.
+// @Tags Parent - Points
+// @Produce json
+// @Param childId path int true "Child User ID"
+// @Success 200 {object} models.Response "Policy deleted"
+// @Failure 400 {object} models.Response "Invalid Child ID"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of this child)"
+// @Failure 404 {object} models.Response "No policy of yours set for this child"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/children/{childId}/transfer-policy [delete]
+func (h *TransferHandler) DeleteTransferPolicy(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	childID, err := strconv.Atoi(c.Params("childId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Child ID parameter"})
+	}
+
+	if err := h.TransferService.DeletePolicy(c.Context(), parentID, childID); err != nil {
+		return handleParentError(c, err, "DeleteTransferPolicy")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Transfer policy deleted successfully"})
+}
+
+// ==========================================================
+// --- Parent: Transfer Review ---
+// ==========================================================
+
+// GetTransfers godoc
+// @Summary Get Children's Transfers
+// @Description Retrieves point transfers sent by the parent's children (newest first), optionally filtered by status.
+// @Tags Parent - Points
+// @Produce json
+// @Param status query string false "Filter by status (pending, completed, rejected, cancelled)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponseGeneric "Transfers retrieved"
+// @Failure 400 {object} models.Response "Invalid status filter"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/transfers [get]
+func (h *TransferHandler) GetTransfers(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	statusFilter := c.Query("status")
+	if statusFilter != "" && !isValidTransferStatus(statusFilter) {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
+			Success: false,
+			Message: fmt.Sprintf("Invalid status filter value: '%s'. Valid statuses are pending, completed, rejected, cancelled.", statusFilter),
+		})
+	}
+
+	pagination := utils.ParsePaginationParams(c)
+	transfers, totalCount, err := h.TransferService.GetTransfersForParent(c.Context(), parentID, models.PointTransferStatus(statusFilter), pagination.Page, pagination.Limit)
+	if err != nil {
+		return handleParentError(c, err, "GetTransfers")
+	}
+
+	meta := utils.BuildPaginationMeta(totalCount, pagination.Limit, pagination.Page)
+	return c.Status(http.StatusOK).JSON(utils.NewPaginatedResponse("Transfers retrieved successfully", transfers, meta))
+}
+
+// ApproveTransfer godoc
+// @Summary Approve Transfer
+// @Description Approves a pending transfer and moves the points. The sender's available points (excluding points earmarked for savings goals) are checked again; if they are no longer sufficient the transfer stays pending.
+// @Tags Parent - Points
+// @Produce json
+// @Param transferId path int true "Transfer ID"
+// @Success 200 {object} models.Response{data=models.PointTransfer} "Transfer approved"
+// @Failure 400 {object} models.Response "Invalid Transfer ID or transfer is not pending"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 402 {object} models.Response "Sender no longer has enough points"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of the sender)"
+// @Failure 404 {object} models.Response "Transfer not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/transfers/{transferId}/approve [post]
+func (h *TransferHandler) ApproveTransfer(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	transferID, err := strconv.Atoi(c.Params("transferId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Transfer ID parameter"})
+	}
+
+	transfer, err := h.TransferService.ApproveTransfer(c.Context(), parentID, transferID)
+	if err != nil {
+		return handleParentError(c, err, "ApproveTransfer")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Transfer approved successfully", Data: transfer})
+}
+
+// RejectTransfer godoc
+// @Summary Reject Transfer
+// @Description Rejects a pending transfer without moving any points. The sender is notified with the optional reason.
+// @Tags Parent - Points
+// @Accept json
+// @Produce json
+// @Param transferId path int true "Transfer ID"
+// @Param reject_input body models.RejectTransferInput false "Rejection reason"
+// @Success 200 {object} models.Response "Transfer rejected"
+// @Failure 400 {object} models.Response "Invalid Transfer ID, validation failed or transfer is not pending"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 403 {object} models.Response "Forbidden (Not the parent of the sender)"
+// @Failure 404 {object} models.Response "Transfer not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /parent/transfers/{transferId}/reject [post]
+func (h *TransferHandler) RejectTransfer(c *fiber.Ctx) error {
+	parentID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract parentID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	transferID, err := strconv.Atoi(c.Params("transferId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Transfer ID parameter"})
+	}
+
+	input := new(models.RejectTransferInput)
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+		}
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	if err := h.TransferService.RejectTransfer(c.Context(), parentID, transferID, input.Reason); err != nil {
+		return handleParentError(c, err, "RejectTransfer")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Transfer rejected successfully"})
+}
+
+// ==========================================================
+// --- Child: Transfers ---
+// ==========================================================
+
+// CreateTransfer godoc
+// @Summary Send Points to a Sibling
+// @Description Sends points to a sibling (a child sharing at least one parent). Depending on the family's transfer policy the points move immediately or the transfer waits for parent approval. Points earmarked for savings goals cannot be sent.
+// @Tags Child - Points & Rewards
+// @Accept json
+// @Produce json
+// @Param transfer_input body models.CreateTransferInput true "Transfer details"
+// @Success 201 {object} models.Response{data=models.PointTransfer} "Transfer created (status pending or completed)"
+// @Failure 400 {object} models.Response "Validation failed, transfer to self or amount above the policy limit"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 402 {object} models.Response "Not enough available points"
+// @Failure 403 {object} models.Response "Recipient is not a sibling or transfers are not allowed"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/transfers [post]
+func (h *TransferHandler) CreateTransfer(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	input := new(models.CreateTransferInput)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid request body"})
+	}
+	if err := h.Validate.Struct(input); err != nil {
+		errorDetails := utils.FormatValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Validation failed", Data: errorDetails})
+	}
+
+	transfer, err := h.TransferService.CreateTransfer(c.Context(), childID, input)
+	if err != nil {
+		return handleChildError(c, err, "CreateTransfer")
+	}
+
+	message := "Points sent successfully"
+	if transfer.Status == models.PointTransferStatusPending {
+		message = "Transfer submitted and waiting for parent approval"
+	}
+	return c.Status(http.StatusCreated).JSON(models.Response{Success: true, Message: message, Data: transfer})
+}
+
+// GetMyTransfers godoc
+// @Summary Get My Transfers
+// @Description Retrieves transfers the child has sent or received (newest first).
+// @Tags Child - Points & Rewards
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponseGeneric "Transfers retrieved"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/transfers [get]
+func (h *TransferHandler) GetMyTransfers(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+
+	pagination := utils.ParsePaginationParams(c)
+	transfers, totalCount, err := h.TransferService.GetMyTransfers(c.Context(), childID, pagination.Page, pagination.Limit)
+	if err != nil {
+		return handleChildError(c, err, "GetMyTransfers")
+	}
+
+	meta := utils.BuildPaginationMeta(totalCount, pagination.Limit, pagination.Page)
+	return c.Status(http.StatusOK).JSON(utils.NewPaginatedResponse("Transfers retrieved successfully", transfers, meta))
+}
+
+// CancelTransfer godoc
+// @Summary Cancel My Transfer
+// @Description Cancels a transfer the child sent that is still waiting for approval.
+// @Tags Child - Points & Rewards
+// @Produce json
+// @Param transferId path int true "Transfer ID"
+// @Success 200 {object} models.Response "Transfer cancelled"
+// @Failure 400 {object} models.Response "Invalid Transfer ID or transfer is not pending"
+// @Failure 401 {object} models.Response "Unauthorized"
+// @Failure 404 {object} models.Response "Transfer not found"
+// @Failure 500 {object} models.Response "Internal server error"
+// @Security ApiKeyAuth
+// @Router /child/transfers/{transferId}/cancel [post]
+func (h *TransferHandler) CancelTransfer(c *fiber.Ctx) error {
+	childID, err := utils.ExtractUserIDFromJWT(c)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Handler: Failed to extract childID from JWT")
+		return c.Status(fiber.StatusUnauthorized).JSON(models.Response{Success: false, Message: "Unauthorized: Invalid token"})
+	}
+	transferID, err := strconv.Atoi(c.Params("transferId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.Response{Success: false, Message: "Invalid Transfer ID parameter"})
+	}
+
+	if err := h.TransferService.CancelTransfer(c.Context(), childID, transferID); err != nil {
+		return handleChildError(c, err, "CancelTransfer")
+	}
+
+	return c.Status(http.StatusOK).JSON(models.Response{Success: true, Message: "Transfer cancelled successfully"})
+}
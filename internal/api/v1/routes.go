@@ -18,6 +18,25 @@ func SetupRoutes(
 	userHandler *handlers.UserHandler, // Handler untuk endpoint pengguna umum
 	parentHandler *handlers.ParentHandler, // Handler untuk endpoint khusus Parent
 	childHandler *handlers.ChildHandler, // Handler untuk endpoint khusus Child
+	rotationHandler *handlers.RotationHandler, // Handler untuk rotasi tugas antar saudara (Parent)
+	bountyHandler *handlers.BountyHandler, // Handler untuk bounty / tugas terbuka (Parent & Child)
+	templateHandler *handlers.TemplateHandler, // Handler untuk katalog template tugas/hadiah (Admin & Parent)
+	autoApprovalHandler *handlers.AutoApprovalHandler, // Handler untuk kebijakan auto-approval tugas (Parent)
+	auditHandler *handlers.AuditHandler, // Handler untuk jejak audit (Admin)
+	savingsGoalHandler *handlers.SavingsGoalHandler, // Handler untuk target tabungan anak (Child & Parent)
+	notificationHandler *handlers.NotificationHandler, // Handler untuk notifikasi in-app (semua peran)
+	pointExpirationHandler *handlers.PointExpirationHandler, // Handler untuk kebijakan kedaluwarsa poin (Parent & Child)
+	interestHandler *handlers.InterestHandler, // Handler untuk bunga tabungan poin (Parent & Child)
+	allowanceHandler *handlers.AllowanceHandler, // Handler untuk uang saku otomatis (Parent & Child)
+	transferHandler *handlers.TransferHandler, // Handler untuk transfer poin antar saudara (Parent & Child)
+	currencyHandler *handlers.CurrencyHandler, // Handler untuk mata uang tambahan & penukaran (Parent & Child)
+	cashOutHandler *handlers.CashOutHandler, // Handler untuk pencairan poin menjadi uang saku (Parent & Child)
+	statementHandler *handlers.StatementHandler, // Handler untuk laporan rekening poin bulanan (Parent & Child)
+	ledgerHandler *handlers.LedgerHandler, // Handler untuk integritas ledger poin & koreksi (Admin)
+	penaltyHandler *handlers.PenaltyHandler, // Handler untuk katalog pelanggaran & sanksi poin (Parent & Child)
+	badgeHandler *handlers.BadgeHandler, // Handler untuk badge & pencapaian (Parent & Child)
+	streakHandler *handlers.StreakHandler, // Handler untuk streak, bonus streak & streak freeze (Parent & Child)
+	levelHandler *handlers.LevelHandler, // Handler untuk XP & level anak (Parent & Child)
 ) {
 	// Membuat grup rute utama dengan prefix /api/v1
 	// Semua rute yang didefinisikan di bawah ini akan memiliki prefix ini.
@@ -62,6 +81,28 @@ func SetupRoutes(
 		admin.Patch("/roles/:roleId", adminHandler.UpdateRole)
 		// DELETE /api/v1/admin/roles/:roleId - Menghapus peran berdasarkan ID
 		admin.Delete("/roles/:roleId", adminHandler.DeleteRole)
+
+		// --- Manajemen Katalog Template Tugas/Hadiah oleh Admin ---
+		// POST   /api/v1/admin/templates - Membuat template baru
+		admin.Post("/templates", templateHandler.CreateTemplate)
+		// GET    /api/v1/admin/templates - Mendapatkan daftar template (termasuk yang nonaktif)
+		admin.Get("/templates", templateHandler.GetAllTemplates)
+		// GET    /api/v1/admin/templates/:templateId - Mendapatkan detail template beserta semua terjemahannya
+		admin.Get("/templates/:templateId", templateHandler.GetTemplate)
+		// PATCH  /api/v1/admin/templates/:templateId - Memperbarui template (versi template naik)
+		admin.Patch("/templates/:templateId", templateHandler.UpdateTemplate)
+		// DELETE /api/v1/admin/templates/:templateId - Menghapus template
+		admin.Delete("/templates/:templateId", templateHandler.DeleteTemplate)
+
+		// --- Jejak Audit ---
+		// GET    /api/v1/admin/audit-logs - Melihat jejak audit (filter entitas, pelaku, aksi)
+		admin.Get("/audit-logs", auditHandler.GetAuditLogs)
+
+		// --- Integritas Ledger Poin ---
+		// GET    /api/v1/admin/ledger/verify - Memverifikasi rantai hash ledger poin (opsional ?user_id=)
+		admin.Get("/ledger/verify", ledgerHandler.VerifyLedger)
+		// POST   /api/v1/admin/point-transactions/:transactionId/reverse - Mengoreksi entri ledger dengan entri pembalik
+		admin.Post("/point-transactions/:transactionId/reverse", ledgerHandler.ReverseTransaction)
 	}
 
 	// =========================================================================
@@ -77,6 +118,14 @@ func SetupRoutes(
 		user.Patch("/profile", userHandler.UpdateMyProfile)
 		// PATCH /api/v1/user/password - Mengubah kata sandi pengguna yang sedang login
 		user.Patch("/password", userHandler.UpdateMyPassword)
+
+		// --- Notifikasi In-App ---
+		// GET   /api/v1/user/notifications - Melihat notifikasi (?unread=true untuk yang belum dibaca saja)
+		user.Get("/notifications", notificationHandler.GetMyNotifications)
+		// PATCH /api/v1/user/notifications/read-all - Menandai semua notifikasi sebagai sudah dibaca
+		user.Patch("/notifications/read-all", notificationHandler.MarkAllNotificationsRead)
+		// PATCH /api/v1/user/notifications/:notificationId/read - Menandai satu notifikasi sebagai sudah dibaca
+		user.Patch("/notifications/:notificationId/read", notificationHandler.MarkNotificationRead)
 	}
 
 	// =========================================================================
@@ -116,6 +165,14 @@ func SetupRoutes(
 		parent.Get("/children/:childId/tasks", parentHandler.GetTasksForChild)
 		// PATCH  /api/v1/parent/tasks/:userTaskId/verify - Memverifikasi (approve/reject) tugas yang sudah disubmit anak (berdasarkan ID UserTask)
 		parent.Patch("/tasks/:userTaskId/verify", parentHandler.VerifySubmittedTask)
+		// PATCH  /api/v1/parent/tasks/:userTaskId/unassign - Membatalkan penugasan yang belum disetujui
+		parent.Patch("/tasks/:userTaskId/unassign", parentHandler.UnassignTask)
+		// PATCH  /api/v1/parent/tasks/:userTaskId/reassign - Memindahkan penugasan ke anak lain
+		parent.Patch("/tasks/:userTaskId/reassign", parentHandler.ReassignTask)
+		// PATCH  /api/v1/parent/tasks/:userTaskId/revert - Membatalkan verifikasi (dengan transaksi poin kompensasi)
+		parent.Patch("/tasks/:userTaskId/revert", parentHandler.RevertTaskVerification)
+		// GET    /api/v1/parent/tasks/:userTaskId/timeline - Melihat riwayat transisi status penugasan
+		parent.Get("/tasks/:userTaskId/timeline", parentHandler.GetTaskTimeline)
 
 		// --- Manajemen Definisi Hadiah (Reward Definition) ---
 		// POST   /api/v1/parent/rewards - Membuat definisi hadiah baru
@@ -132,10 +189,238 @@ func SetupRoutes(
 		parent.Get("/claims/pending", parentHandler.GetPendingClaims)
 		// PATCH  /api/v1/parent/claims/:claimId/review - Meninjau (approve/reject) klaim hadiah tertentu (berdasarkan ID UserReward)
 		parent.Patch("/claims/:claimId/review", parentHandler.ReviewRewardClaim)
+		// GET    /api/v1/parent/claims/unfulfilled - Dashboard klaim yang sudah disetujui tetapi belum diserahkan
+		parent.Get("/claims/unfulfilled", parentHandler.GetUnfulfilledClaims)
+		// PATCH  /api/v1/parent/claims/:claimId/schedule - Menjadwalkan tanggal penyerahan hadiah
+		parent.Patch("/claims/:claimId/schedule", parentHandler.ScheduleClaimFulfillment)
+		// PATCH  /api/v1/parent/claims/:claimId/fulfill - Menandai hadiah sudah diserahkan
+		parent.Patch("/claims/:claimId/fulfill", parentHandler.FulfillRewardClaim)
+		// GET    /api/v1/parent/claims/:claimId/history - Melihat riwayat transisi status klaim
+		parent.Get("/claims/:claimId/history", parentHandler.GetClaimHistory)
+		// GET    /api/v1/parent/reward-approval-policy - Melihat kebijakan persetujuan bersama untuk semua anak
+		parent.Get("/reward-approval-policy", parentHandler.GetFamilyRewardApprovalPolicy)
+		// PUT    /api/v1/parent/reward-approval-policy - Mengatur ambang poin & jumlah persetujuan untuk semua anak
+		parent.Put("/reward-approval-policy", parentHandler.SetFamilyRewardApprovalPolicy)
+		// DELETE /api/v1/parent/reward-approval-policy - Menghapus kebijakan persetujuan bersama untuk semua anak
+		parent.Delete("/reward-approval-policy", parentHandler.DeleteFamilyRewardApprovalPolicy)
+		// GET    /api/v1/parent/children/:childId/reward-approval-policy - Melihat kebijakan yang berlaku untuk anak
+		parent.Get("/children/:childId/reward-approval-policy", parentHandler.GetRewardApprovalPolicy)
+		// PUT    /api/v1/parent/children/:childId/reward-approval-policy - Mengatur kebijakan khusus anak
+		parent.Put("/children/:childId/reward-approval-policy", parentHandler.SetRewardApprovalPolicy)
+		// DELETE /api/v1/parent/children/:childId/reward-approval-policy - Menghapus kebijakan khusus anak
+		parent.Delete("/children/:childId/reward-approval-policy", parentHandler.DeleteRewardApprovalPolicy)
 
 		// --- Penyesuaian Poin Anak (Point Adjustment) ---
 		// POST   /api/v1/parent/children/:childId/points - Menyesuaikan poin anak tertentu secara manual (tambah/kurang)
 		parent.Post("/children/:childId/points", parentHandler.AdjustChildPoints)
+		// GET    /api/v1/parent/children/:childId/statements - Laporan rekening bulanan anak (?month=YYYY-MM&currency_id=&format=json|csv|pdf)
+		parent.Get("/children/:childId/statements", statementHandler.GetChildStatement)
+
+		// --- Rotasi Tugas antar Saudara (Task Rotation) ---
+		// POST   /api/v1/parent/rotations - Membuat rotasi tugas untuk beberapa anak
+		parent.Post("/rotations", rotationHandler.CreateRotation)
+		// GET    /api/v1/parent/rotations - Mendapatkan daftar rotasi yang dibuat oleh Parent ini
+		parent.Get("/rotations", rotationHandler.GetMyRotations)
+		// GET    /api/v1/parent/rotations/:rotationId - Mendapatkan detail rotasi beserta urutan anak
+		parent.Get("/rotations/:rotationId", rotationHandler.GetRotation)
+		// DELETE /api/v1/parent/rotations/:rotationId - Menghapus rotasi
+		parent.Delete("/rotations/:rotationId", rotationHandler.DeleteRotation)
+		// POST   /api/v1/parent/rotations/:rotationId/advance - Memberikan giliran berikutnya sekarang juga
+		parent.Post("/rotations/:rotationId/advance", rotationHandler.AdvanceRotation)
+		// POST   /api/v1/parent/rotations/:rotationId/skip - Melewati giliran anak berikutnya
+		parent.Post("/rotations/:rotationId/skip", rotationHandler.SkipRotationTurn)
+		// POST   /api/v1/parent/rotations/:rotationId/swap - Menukar urutan dua anak dalam rotasi
+		parent.Post("/rotations/:rotationId/swap", rotationHandler.SwapRotationMembers)
+		// GET    /api/v1/parent/rotations/:rotationId/history - Melihat riwayat giliran rotasi
+		parent.Get("/rotations/:rotationId/history", rotationHandler.GetRotationHistory)
+		// GET    /api/v1/parent/rotations/:rotationId/preview - Melihat pratinjau giliran mendatang
+		parent.Get("/rotations/:rotationId/preview", rotationHandler.PreviewRotation)
+
+		// --- Bounty (Tugas Terbuka yang bisa diklaim anak) ---
+		// POST   /api/v1/parent/bounties - Memposting definisi tugas sebagai bounty
+		parent.Post("/bounties", bountyHandler.CreateBounty)
+		// GET    /api/v1/parent/bounties - Mendapatkan daftar bounty yang diposting Parent ini (beserta klaimnya)
+		parent.Get("/bounties", bountyHandler.GetMyBounties)
+		// DELETE /api/v1/parent/bounties/:bountyId - Membatalkan bounty yang masih terbuka
+		parent.Delete("/bounties/:bountyId", bountyHandler.CancelBounty)
+
+		// --- Katalog Template (Impor Definisi Tugas/Hadiah) ---
+		// GET    /api/v1/parent/templates - Menelusuri template aktif (filter jenis, kategori, usia, bahasa)
+		parent.Get("/templates", templateHandler.BrowseTemplates)
+		// POST   /api/v1/parent/templates/import - Mengimpor beberapa template menjadi definisi tugas/hadiah milik Parent
+		parent.Post("/templates/import", templateHandler.ImportTemplates)
+		// GET    /api/v1/parent/templates/updates - Melihat saran pembaruan untuk definisi hasil impor
+		parent.Get("/templates/updates", templateHandler.GetTemplateUpdates)
+
+		// --- Kebijakan Auto-Approval (Tugas Terpercaya) ---
+		// POST   /api/v1/parent/auto-approval-policies - Membuat kebijakan auto-approval per tugas/anak
+		parent.Post("/auto-approval-policies", autoApprovalHandler.CreatePolicy)
+		// GET    /api/v1/parent/auto-approval-policies - Mendapatkan daftar kebijakan milik Parent ini
+		parent.Get("/auto-approval-policies", autoApprovalHandler.GetMyPolicies)
+		// PATCH  /api/v1/parent/auto-approval-policies/:policyId - Mengubah masa tenggang / menjeda kebijakan
+		parent.Patch("/auto-approval-policies/:policyId", autoApprovalHandler.UpdatePolicy)
+		// DELETE /api/v1/parent/auto-approval-policies/:policyId - Menghapus kebijakan
+		parent.Delete("/auto-approval-policies/:policyId", autoApprovalHandler.DeletePolicy)
+
+		// --- Target Tabungan Anak (Savings Goals) ---
+		// GET    /api/v1/parent/children/:childId/goals - Melihat target tabungan anak beserta progresnya
+		parent.Get("/children/:childId/goals", savingsGoalHandler.GetChildSavingsGoals)
+		// POST   /api/v1/parent/goals/:goalId/contributions - Menambahkan bonus poin untuk target anak
+		parent.Post("/goals/:goalId/contributions", savingsGoalHandler.ContributeToSavingsGoal)
+
+		// --- Kedaluwarsa Poin ---
+		// GET    /api/v1/parent/point-expiration-policy - Melihat kebijakan kedaluwarsa poin untuk semua anak
+		parent.Get("/point-expiration-policy", pointExpirationHandler.GetFamilyPointExpirationPolicy)
+		// PUT    /api/v1/parent/point-expiration-policy - Mengatur masa berlaku poin & masa peringatan untuk semua anak
+		parent.Put("/point-expiration-policy", pointExpirationHandler.SetFamilyPointExpirationPolicy)
+		// DELETE /api/v1/parent/point-expiration-policy - Menghapus kebijakan kedaluwarsa poin untuk semua anak
+		parent.Delete("/point-expiration-policy", pointExpirationHandler.DeleteFamilyPointExpirationPolicy)
+		// GET    /api/v1/parent/children/:childId/point-expiration-policy - Melihat kebijakan yang berlaku untuk anak
+		parent.Get("/children/:childId/point-expiration-policy", pointExpirationHandler.GetPointExpirationPolicy)
+		// PUT    /api/v1/parent/children/:childId/point-expiration-policy - Mengatur kebijakan khusus anak
+		parent.Put("/children/:childId/point-expiration-policy", pointExpirationHandler.SetPointExpirationPolicy)
+		// DELETE /api/v1/parent/children/:childId/point-expiration-policy - Menghapus kebijakan khusus anak
+		parent.Delete("/children/:childId/point-expiration-policy", pointExpirationHandler.DeletePointExpirationPolicy)
+
+		// --- Bunga Tabungan ---
+		// GET    /api/v1/parent/interest-policy - Melihat kebijakan bunga untuk semua anak
+		parent.Get("/interest-policy", interestHandler.GetFamilyInterestPolicy)
+		// PUT    /api/v1/parent/interest-policy - Mengatur tarif, jadwal, pembulatan & batas bunga untuk semua anak
+		parent.Put("/interest-policy", interestHandler.SetFamilyInterestPolicy)
+		// DELETE /api/v1/parent/interest-policy - Menghapus kebijakan bunga untuk semua anak
+		parent.Delete("/interest-policy", interestHandler.DeleteFamilyInterestPolicy)
+		// GET    /api/v1/parent/children/:childId/interest-policy - Melihat kebijakan bunga yang berlaku untuk anak
+		parent.Get("/children/:childId/interest-policy", interestHandler.GetInterestPolicy)
+		// PUT    /api/v1/parent/children/:childId/interest-policy - Mengatur kebijakan khusus anak
+		parent.Put("/children/:childId/interest-policy", interestHandler.SetInterestPolicy)
+		// DELETE /api/v1/parent/children/:childId/interest-policy - Menghapus kebijakan khusus anak
+		parent.Delete("/children/:childId/interest-policy", interestHandler.DeleteInterestPolicy)
+		// GET    /api/v1/parent/children/:childId/interest-preview - Proyeksi pertumbuhan saldo anak (?periods=)
+		parent.Get("/children/:childId/interest-preview", interestHandler.GetChildInterestPreview)
+
+		// --- Uang Saku Otomatis ---
+		// GET    /api/v1/parent/children/:childId/allowance-plan - Melihat rencana uang saku anak
+		parent.Get("/children/:childId/allowance-plan", allowanceHandler.GetAllowancePlan)
+		// PUT    /api/v1/parent/children/:childId/allowance-plan - Mengatur jumlah, frekuensi, tanggal mulai & syarat
+		parent.Put("/children/:childId/allowance-plan", allowanceHandler.SetAllowancePlan)
+		// DELETE /api/v1/parent/children/:childId/allowance-plan - Menghapus rencana (riwayat tetap disimpan)
+		parent.Delete("/children/:childId/allowance-plan", allowanceHandler.DeleteAllowancePlan)
+		// POST   /api/v1/parent/children/:childId/allowance-plan/pause - Menjeda uang saku
+		parent.Post("/children/:childId/allowance-plan/pause", allowanceHandler.PauseAllowancePlan)
+		// POST   /api/v1/parent/children/:childId/allowance-plan/resume - Melanjutkan uang saku mulai periode berjalan
+		parent.Post("/children/:childId/allowance-plan/resume", allowanceHandler.ResumeAllowancePlan)
+		// GET    /api/v1/parent/children/:childId/allowance-payouts - Riwayat pembayaran uang saku anak
+		parent.Get("/children/:childId/allowance-payouts", allowanceHandler.GetChildAllowancePayouts)
+
+		// --- Transfer Poin Antar Saudara ---
+		// GET    /api/v1/parent/transfer-policy - Melihat kebijakan transfer untuk semua anak
+		parent.Get("/transfer-policy", transferHandler.GetFamilyTransferPolicy)
+		// PUT    /api/v1/parent/transfer-policy - Mengatur izin, batas & kebutuhan persetujuan untuk semua anak
+		parent.Put("/transfer-policy", transferHandler.SetFamilyTransferPolicy)
+		// DELETE /api/v1/parent/transfer-policy - Menghapus kebijakan transfer untuk semua anak
+		parent.Delete("/transfer-policy", transferHandler.DeleteFamilyTransferPolicy)
+		// GET    /api/v1/parent/children/:childId/transfer-policy - Melihat kebijakan transfer yang berlaku untuk anak
+		parent.Get("/children/:childId/transfer-policy", transferHandler.GetTransferPolicy)
+		// PUT    /api/v1/parent/children/:childId/transfer-policy - Mengatur kebijakan khusus anak
+		parent.Put("/children/:childId/transfer-policy", transferHandler.SetTransferPolicy)
+		// DELETE /api/v1/parent/children/:childId/transfer-policy - Menghapus kebijakan khusus anak
+		parent.Delete("/children/:childId/transfer-policy", transferHandler.DeleteTransferPolicy)
+		// GET    /api/v1/parent/transfers - Daftar transfer yang dikirim anak-anak (bisa filter status)
+		parent.Get("/transfers", transferHandler.GetTransfers)
+		// POST   /api/v1/parent/transfers/:transferId/approve - Menyetujui transfer & memindahkan poin
+		parent.Post("/transfers/:transferId/approve", transferHandler.ApproveTransfer)
+		// POST   /api/v1/parent/transfers/:transferId/reject - Menolak transfer
+		parent.Post("/transfers/:transferId/reject", transferHandler.RejectTransfer)
+
+		// --- Mata Uang Tambahan & Aturan Tukar ---
+		// GET    /api/v1/parent/currencies - Daftar mata uang milik parent
+		parent.Get("/currencies", currencyHandler.GetCurrencies)
+		// POST   /api/v1/parent/currencies - Membuat mata uang baru
+		parent.Post("/currencies", currencyHandler.CreateCurrency)
+		// PUT    /api/v1/parent/currencies/:currencyId - Mengubah nama/simbol mata uang
+		parent.Put("/currencies/:currencyId", currencyHandler.UpdateCurrency)
+		// DELETE /api/v1/parent/currencies/:currencyId - Menghapus mata uang yang belum dipakai
+		parent.Delete("/currencies/:currencyId", currencyHandler.DeleteCurrency)
+		// GET    /api/v1/parent/currency-exchange-rules - Daftar aturan tukar milik parent
+		parent.Get("/currency-exchange-rules", currencyHandler.GetExchangeRules)
+		// POST   /api/v1/parent/currency-exchange-rules - Membuat aturan tukar
+		parent.Post("/currency-exchange-rules", currencyHandler.CreateExchangeRule)
+		// DELETE /api/v1/parent/currency-exchange-rules/:ruleId - Menghapus aturan tukar
+		parent.Delete("/currency-exchange-rules/:ruleId", currencyHandler.DeleteExchangeRule)
+		// GET    /api/v1/parent/children/:childId/balances - Saldo anak per mata uang
+		parent.Get("/children/:childId/balances", currencyHandler.GetChildBalances)
+
+		// --- Pencairan Poin Menjadi Uang (Cash-Out) ---
+		// GET    /api/v1/parent/cash-out-policy - Melihat kurs pencairan untuk semua anak
+		parent.Get("/cash-out-policy", cashOutHandler.GetFamilyCashOutPolicy)
+		// PUT    /api/v1/parent/cash-out-policy - Membuat/mengubah kurs pencairan untuk semua anak
+		parent.Put("/cash-out-policy", cashOutHandler.SetFamilyCashOutPolicy)
+		// DELETE /api/v1/parent/cash-out-policy - Menghapus kurs pencairan untuk semua anak
+		parent.Delete("/cash-out-policy", cashOutHandler.DeleteFamilyCashOutPolicy)
+		// GET    /api/v1/parent/children/:childId/cash-out-policy - Melihat kurs pencairan yang berlaku untuk anak
+		parent.Get("/children/:childId/cash-out-policy", cashOutHandler.GetCashOutPolicy)
+		// PUT    /api/v1/parent/children/:childId/cash-out-policy - Membuat/mengubah kurs khusus anak
+		parent.Put("/children/:childId/cash-out-policy", cashOutHandler.SetCashOutPolicy)
+		// DELETE /api/v1/parent/children/:childId/cash-out-policy - Menghapus kurs khusus anak
+		parent.Delete("/children/:childId/cash-out-policy", cashOutHandler.DeleteCashOutPolicy)
+		// GET    /api/v1/parent/cash-outs - Daftar permintaan cash-out anak-anak (?status=)
+		parent.Get("/cash-outs", cashOutHandler.GetCashOuts)
+		// PATCH  /api/v1/parent/cash-outs/:cashOutId/review - Menyetujui (mencatat pembayaran) atau menolak cash-out
+		parent.Patch("/cash-outs/:cashOutId/review", cashOutHandler.ReviewCashOut)
+		// GET    /api/v1/parent/children/:childId/money-statements - Laporan uang bulanan anak (?month=YYYY-MM)
+		parent.Get("/children/:childId/money-statements", cashOutHandler.GetChildMoneyStatement)
+
+		// --- Katalog Pelanggaran & Sanksi ---
+		// POST   /api/v1/parent/infraction-types - Menambah jenis pelanggaran ke katalog
+		parent.Post("/infraction-types", penaltyHandler.CreateInfractionType)
+		// GET    /api/v1/parent/infraction-types - Melihat katalog pelanggaran milik sendiri
+		parent.Get("/infraction-types", penaltyHandler.GetInfractionTypes)
+		// PUT    /api/v1/parent/infraction-types/:infractionTypeId - Mengubah jenis pelanggaran
+		parent.Put("/infraction-types/:infractionTypeId", penaltyHandler.UpdateInfractionType)
+		// DELETE /api/v1/parent/infraction-types/:infractionTypeId - Menghapus jenis pelanggaran
+		parent.Delete("/infraction-types/:infractionTypeId", penaltyHandler.DeleteInfractionType)
+		// POST   /api/v1/parent/children/:childId/penalties - Menerapkan sanksi ke anak
+		parent.Post("/children/:childId/penalties", penaltyHandler.ApplyPenalty)
+		// GET    /api/v1/parent/children/:childId/penalties - Riwayat sanksi anak
+		parent.Get("/children/:childId/penalties", penaltyHandler.GetChildPenalties)
+		// GET    /api/v1/parent/children/:childId/penalties/report - Laporan sanksi per periode (?from=&to=&group_by=)
+		parent.Get("/children/:childId/penalties/report", penaltyHandler.GetPenaltyReport)
+
+		// --- Badge & Pencapaian ---
+		// GET    /api/v1/parent/badges - Badge sistem & badge custom milik sendiri
+		parent.Get("/badges", badgeHandler.GetBadges)
+		// POST   /api/v1/parent/badges - Membuat badge custom
+		parent.Post("/badges", badgeHandler.CreateBadge)
+		// PUT    /api/v1/parent/badges/:badgeId - Mengubah badge custom
+		parent.Put("/badges/:badgeId", badgeHandler.UpdateBadge)
+		// DELETE /api/v1/parent/badges/:badgeId - Menghapus badge custom
+		parent.Delete("/badges/:badgeId", badgeHandler.DeleteBadge)
+		// GET    /api/v1/parent/children/:childId/badges - Badge yang sudah diraih anak
+		parent.Get("/children/:childId/badges", badgeHandler.GetChildBadges)
+		// POST   /api/v1/parent/children/:childId/badges/:badgeId - Memberikan badge manual ke anak
+		parent.Post("/children/:childId/badges/:badgeId", badgeHandler.AwardBadge)
+
+		// --- Streak & Bonus Streak ---
+		// GET    /api/v1/parent/streak-bonus-rules - Aturan bonus streak milik sendiri
+		parent.Get("/streak-bonus-rules", streakHandler.GetStreakBonusRules)
+		// POST   /api/v1/parent/streak-bonus-rules - Membuat aturan bonus streak
+		parent.Post("/streak-bonus-rules", streakHandler.CreateStreakBonusRule)
+		// PUT    /api/v1/parent/streak-bonus-rules/:ruleId - Mengubah aturan bonus streak
+		parent.Put("/streak-bonus-rules/:ruleId", streakHandler.UpdateStreakBonusRule)
+		// DELETE /api/v1/parent/streak-bonus-rules/:ruleId - Menghapus aturan bonus streak
+		parent.Delete("/streak-bonus-rules/:ruleId", streakHandler.DeleteStreakBonusRule)
+		// GET    /api/v1/parent/children/:childId/streaks - Streak anak (keseluruhan & per tugas)
+		parent.Get("/children/:childId/streaks", streakHandler.GetChildStreaks)
+		// GET    /api/v1/parent/children/:childId/streak-freeze-policy - Harga streak freeze anak
+		parent.Get("/children/:childId/streak-freeze-policy", streakHandler.GetStreakFreezePolicy)
+		// PUT    /api/v1/parent/children/:childId/streak-freeze-policy - Mengatur harga streak freeze anak
+		parent.Put("/children/:childId/streak-freeze-policy", streakHandler.SetStreakFreezePolicy)
+		// DELETE /api/v1/parent/children/:childId/streak-freeze-policy - Menonaktifkan pembelian streak freeze
+		parent.Delete("/children/:childId/streak-freeze-policy", streakHandler.DeleteStreakFreezePolicy)
+
+		// --- XP & Level ---
+		// GET    /api/v1/parent/children/:childId/level - Level, progres XP, perk per level & riwayat level-up anak
+		parent.Get("/children/:childId/level", levelHandler.GetChildLevel)
 	}
 
 	// =========================================================================
@@ -149,18 +434,76 @@ func SetupRoutes(
 		child.Get("/tasks", childHandler.GetMyTasks)
 		// PATCH /api/v1/child/tasks/:userTaskId/submit - Menandai tugas tertentu sebagai selesai (submit)
 		child.Patch("/tasks/:userTaskId/submit", childHandler.SubmitMyTask)
+		// GET /api/v1/child/tasks/:userTaskId/timeline - Melihat riwayat transisi status tugas milik sendiri
+		child.Get("/tasks/:userTaskId/timeline", childHandler.GetMyTaskTimeline)
 
 		// --- Poin & Hadiah ---
 		// GET  /api/v1/child/points - Melihat total poin saat ini
 		child.Get("/points", childHandler.GetMyPoints)
 		// GET  /api/v1/child/points/history - Melihat riwayat transaksi poin
 		child.Get("/points/history", childHandler.GetMyPointHistory)
+		// GET  /api/v1/child/statements - Laporan rekening bulanan (?month=YYYY-MM&currency_id=&format=json|csv|pdf)
+		child.Get("/statements", statementHandler.GetMyStatement)
+		// GET  /api/v1/child/points/expiring - Melihat poin yang akan segera kedaluwarsa
+		child.Get("/points/expiring", pointExpirationHandler.GetMyExpiringPoints)
+		// GET  /api/v1/child/points/interest-preview - Proyeksi pertumbuhan saldo karena bunga (?periods=)
+		child.Get("/points/interest-preview", interestHandler.GetMyInterestPreview)
+		// GET  /api/v1/child/allowance/payouts - Riwayat pembayaran uang saku
+		child.Get("/allowance/payouts", allowanceHandler.GetMyAllowancePayouts)
+		// POST /api/v1/child/transfers - Mengirim poin ke saudara
+		child.Post("/transfers", transferHandler.CreateTransfer)
+		// GET  /api/v1/child/transfers - Riwayat transfer yang dikirim & diterima
+		child.Get("/transfers", transferHandler.GetMyTransfers)
+		// POST /api/v1/child/transfers/:transferId/cancel - Membatalkan transfer yang menunggu persetujuan
+		child.Post("/transfers/:transferId/cancel", transferHandler.CancelTransfer)
+		// GET  /api/v1/child/balances - Saldo per mata uang (poin & mata uang tambahan)
+		child.Get("/balances", currencyHandler.GetMyBalances)
+		// GET  /api/v1/child/currency-exchange-rules - Aturan tukar yang ditetapkan orang tua
+		child.Get("/currency-exchange-rules", currencyHandler.GetMyExchangeRules)
+		// POST /api/v1/child/currency-exchanges - Menukar mata uang sesuai aturan tukar
+		child.Post("/currency-exchanges", currencyHandler.ExchangeCurrency)
+		// POST /api/v1/child/cash-outs - Mengajukan pencairan poin menjadi uang
+		child.Post("/cash-outs", cashOutHandler.RequestCashOut)
+		// GET  /api/v1/child/cash-outs - Riwayat permintaan cash-out (?status=)
+		child.Get("/cash-outs", cashOutHandler.GetMyCashOuts)
+		// GET  /api/v1/child/money-statements - Laporan uang bulanan (?month=YYYY-MM)
+		child.Get("/money-statements", cashOutHandler.GetMyMoneyStatement)
+		// GET  /api/v1/child/penalties - Riwayat sanksi & status tugas penebus
+		child.Get("/penalties", penaltyHandler.GetMyPenalties)
+		// GET  /api/v1/child/badges - Badge yang sudah diraih
+		child.Get("/badges", badgeHandler.GetMyBadges)
+		// GET  /api/v1/child/streaks - Streak sendiri (keseluruhan & per tugas) dan streak freeze
+		child.Get("/streaks", streakHandler.GetMyStreaks)
+		// POST /api/v1/child/streak-freezes - Membeli streak freeze dengan poin
+		child.Post("/streak-freezes", streakHandler.BuyStreakFreeze)
+		// GET  /api/v1/child/level - Level, progres XP, perk per level & riwayat level-up
+		child.Get("/level", levelHandler.GetMyLevel)
 		// GET  /api/v1/child/rewards - Melihat daftar hadiah yang tersedia (dari semua parent yang terhubung)
 		child.Get("/rewards", childHandler.GetAvailableRewards)
 		// POST /api/v1/child/rewards/:rewardId/claim - Mengklaim hadiah tertentu
 		child.Post("/rewards/:rewardId/claim", childHandler.ClaimReward)
 		// GET  /api/v1/child/claims - Melihat riwayat klaim hadiah yang pernah dilakukan
 		child.Get("/claims", childHandler.GetMyClaims)
+		// PATCH /api/v1/child/claims/:claimId/received - Mengonfirmasi hadiah sudah diterima
+		child.Patch("/claims/:claimId/received", childHandler.ConfirmClaimReceived)
+		// GET   /api/v1/child/claims/:claimId/history - Melihat riwayat transisi status klaim milik sendiri
+		child.Get("/claims/:claimId/history", childHandler.GetMyClaimHistory)
+
+		// --- Bounty (Tugas Terbuka) ---
+		// GET  /api/v1/child/bounties - Melihat bounty yang masih bisa diklaim
+		child.Get("/bounties", bountyHandler.GetOpenBounties)
+		// POST /api/v1/child/bounties/:bountyId/claim - Mengklaim bounty (tugas langsung ditugaskan ke anak)
+		child.Post("/bounties/:bountyId/claim", bountyHandler.ClaimBounty)
+
+		// --- Target Tabungan (Savings Goals / Wishlist) ---
+		// GET    /api/v1/child/goals - Melihat saldo, poin yang disisihkan, dan target beserta progresnya
+		child.Get("/goals", savingsGoalHandler.GetMySavingsGoals)
+		// POST   /api/v1/child/goals - Menyematkan hadiah atau membuat target kustom
+		child.Post("/goals", savingsGoalHandler.CreateSavingsGoal)
+		// POST   /api/v1/child/goals/:goalId/earmark - Menyisihkan (positif) atau melepas (negatif) poin untuk target
+		child.Post("/goals/:goalId/earmark", savingsGoalHandler.EarmarkPoints)
+		// DELETE /api/v1/child/goals/:goalId - Membatalkan target dan melepas poin yang disisihkan
+		child.Delete("/goals/:goalId", savingsGoalHandler.CancelSavingsGoal)
 	}
 
 	// =========================================================================
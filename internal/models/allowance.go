@@ -0,0 +1,79 @@
+// internal/models/allowance.go
+package models
+
+import (
+	"time"
+	_ "time/tzdata" // Basis data zona waktu tertanam agar zona waktu pengguna tetap bisa dimuat di image minimal
+)
+
+// AllowanceCadence mendefinisikan frekuensi pembayaran uang saku.
+type AllowanceCadence string
+
+const (
+	AllowanceCadenceWeekly   AllowanceCadence = "weekly"   // Setiap 7 hari sejak tanggal mulai
+	AllowanceCadenceBiweekly AllowanceCadence = "biweekly" // Setiap 14 hari sejak tanggal mulai
+	AllowanceCadenceMonthly  AllowanceCadence = "monthly"  // Setiap bulan pada tanggal yang sama (tanggal mulai maksimal 28)
+)
+
+// AllowancePayoutStatus mendefinisikan hasil pemrosesan uang saku satu periode.
+type AllowancePayoutStatus string
+
+const (
+	AllowancePayoutPaid    AllowancePayoutStatus = "paid"    // Uang saku dibayarkan
+	AllowancePayoutSkipped AllowancePayoutStatus = "skipped" // Syarat tidak terpenuhi, uang saku tidak dibayarkan
+)
+
+// Next mengembalikan awal periode berikutnya setelah periode yang dimulai pada tanggal start.
+func (c AllowanceCadence) Next(start time.Time) time.Time {
+	switch c {
+	case AllowanceCadenceBiweekly:
+		return start.AddDate(0, 0, 14)
+	case AllowanceCadenceMonthly:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 7)
+	}
+}
+
+// LoadTimezone memuat zona waktu IANA; zona kosong atau tidak dikenal dianggap UTC.
+func LoadTimezone(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// LocalMidnight mengembalikan pukul 00:00 pada tanggal kalender date di zona waktu loc.
+func LocalMidnight(date time.Time, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+}
+
+// LocalDate mengembalikan tanggal kalender (sebagai tengah malam UTC, sama seperti kolom DATE) dari waktu t di zona loc.
+func LocalDate(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// DuePeriod mengembalikan periode berikutnya yang belum diproses beserta akhirnya (eksklusif) jika periode
+// tersebut sudah selesai pada waktu now menurut zona waktu anak.
+func (p AllowancePlan) DuePeriod(now time.Time) (start time.Time, end time.Time, due bool) {
+	start = p.NextPeriodStart
+	end = p.Cadence.Next(start)
+	return start, end, !LocalMidnight(end, LoadTimezone(p.Timezone)).After(now)
+}
+
+// CurrentPeriodStart mengembalikan awal periode yang sedang berjalan pada waktu now (atau NextPeriodStart
+// jika periode tersebut belum dimulai). Dipakai saat melanjutkan rencana yang dijeda agar periode selama
+// jeda tidak dibayarkan.
+func (p AllowancePlan) CurrentPeriodStart(now time.Time) time.Time {
+	today := LocalDate(now, LoadTimezone(p.Timezone))
+	start := p.NextPeriodStart
+	for next := p.Cadence.Next(start); !next.After(today); next = p.Cadence.Next(start) {
+		start = next
+	}
+	return start
+}
@@ -0,0 +1,224 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// date membuat tanggal kalender seperti kolom DATE (tengah malam UTC).
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestAllowancePlan_DuePeriod(t *testing.T) {
+	tests := []struct {
+		name          string
+		plan          models.AllowancePlan
+		now           time.Time
+		expectedStart time.Time
+		expectedEnd   time.Time
+		expectedDue   bool
+	}{
+		{
+			name:          "Weekly UTC Not Yet Due",
+			plan:          models.AllowancePlan{Cadence: models.AllowanceCadenceWeekly, NextPeriodStart: date(2025, 1, 6), Timezone: "UTC"},
+			now:           time.Date(2025, 1, 12, 23, 59, 59, 0, time.UTC),
+			expectedStart: date(2025, 1, 6),
+			expectedEnd:   date(2025, 1, 13),
+			expectedDue:   false,
+		},
+		{
+			name:          "Weekly UTC Due At Period End",
+			plan:          models.AllowancePlan{Cadence: models.AllowanceCadenceWeekly, NextPeriodStart: date(2025, 1, 6), Timezone: "UTC"},
+			now:           time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC),
+			expectedStart: date(2025, 1, 6),
+			expectedEnd:   date(2025, 1, 13),
+			expectedDue:   true,
+		},
+		{
+			name:          "Empty Timezone Is UTC",
+			plan:          models.AllowancePlan{Cadence: models.AllowanceCadenceWeekly, NextPeriodStart: date(2025, 1, 6)},
+			now:           time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC),
+			expectedStart: date(2025, 1, 6),
+			expectedEnd:   date(2025, 1, 13),
+			expectedDue:   true,
+		},
+		{
+			name:          "Unknown Timezone Falls Back To UTC",
+			plan:          models.AllowancePlan{Cadence: models.AllowanceCadenceWeekly, NextPeriodStart: date(2025, 1, 6), Timezone: "Mars/Olympus"},
+			now:           time.Date(2025, 1, 12, 23, 0, 0, 0, time.UTC),
+			expectedStart: date(2025, 1, 6),
+			expectedEnd:   date(2025, 1, 13),
+			expectedDue:   false,
+		},
+		{
+			name:          "Ahead Of UTC Due Before UTC Midnight",
+			plan:          models.AllowancePlan{Cadence: models.AllowanceCadenceWeekly, NextPeriodStart: date(2025, 1, 6), Timezone: "Asia/Jakarta"},
+			now:           time.Date(2025, 1, 12, 17, 0, 0, 0, time.UTC), // 13 Jan 00:00 WIB
+			expectedStart: date(2025, 1, 6),
+			expectedEnd:   date(2025, 1, 13),
+			expectedDue:   true,
+		},
+		{
+			name:          "Ahead Of UTC One Second Early",
+			plan:          models.AllowancePlan{Cadence: models.AllowanceCadenceWeekly, NextPeriodStart: date(2025, 1, 6), Timezone: "Asia/Jakarta"},
+			now:           time.Date(2025, 1, 12, 16, 59, 59, 0, time.UTC),
+			expectedStart: date(2025, 1, 6),
+			expectedEnd:   date(2025, 1, 13),
+			expectedDue:   false,
+		},
+		{
+			name:          "Behind UTC Not Due After UTC Midnight",
+			plan:          models.AllowancePlan{Cadence: models.AllowanceCadenceWeekly, NextPeriodStart: date(2025, 1, 6), Timezone: "America/New_York"},
+			now:           time.Date(2025, 1, 13, 4, 59, 59, 0, time.UTC), // 12 Jan 23:59:59 EST
+			expectedStart: date(2025, 1, 6),
+			expectedEnd:   date(2025, 1, 13),
+			expectedDue:   false,
+		},
+		{
+			name:          "DST Start Period Ends At Local Midnight EDT",
+			plan:          models.AllowancePlan{Cadence: models.AllowanceCadenceWeekly, NextPeriodStart: date(2025, 3, 3), Timezone: "America/New_York"},
+			now:           time.Date(2025, 3, 10, 4, 0, 0, 0, time.UTC), // 10 Mar 00:00 EDT (UTC-4)
+			expectedStart: date(2025, 3, 3),
+			expectedEnd:   date(2025, 3, 10),
+			expectedDue:   true,
+		},
+		{
+			name:          "DST Start Offset Before Change Not Used",
+			plan:          models.AllowancePlan{Cadence: models.AllowanceCadenceWeekly, NextPeriodStart: date(2025, 3, 3), Timezone: "America/New_York"},
+			now:           time.Date(2025, 3, 10, 3, 59, 59, 0, time.UTC), // 9 Mar 23:59:59 EDT
+			expectedStart: date(2025, 3, 3),
+			expectedEnd:   date(2025, 3, 10),
+			expectedDue:   false,
+		},
+		{
+			name:          "DST End Period Ends At Local Midnight EST",
+			plan:          models.AllowancePlan{Cadence: models.AllowanceCadenceWeekly, NextPeriodStart: date(2025, 10, 27), Timezone: "America/New_York"},
+			now:           time.Date(2025, 11, 3, 4, 59, 59, 0, time.UTC), // 2 Nov 23:59:59 EST (UTC-5)
+			expectedStart: date(2025, 10, 27),
+			expectedEnd:   date(2025, 11, 3),
+			expectedDue:   false,
+		},
+		{
+			name:          "Biweekly",
+			plan:          models.AllowancePlan{Cadence: models.AllowanceCadenceBiweekly, NextPeriodStart: date(2025, 1, 6), Timezone: "UTC"},
+			now:           time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC),
+			expectedStart: date(2025, 1, 6),
+			expectedEnd:   date(2025, 1, 20),
+			expectedDue:   false,
+		},
+		{
+			name:          "Monthly Same Day Next Month",
+			plan:          models.AllowancePlan{Cadence: models.AllowanceCadenceMonthly, NextPeriodStart: date(2025, 1, 28), Timezone: "Asia/Jakarta"},
+			now:           time.Date(2025, 2, 27, 17, 0, 0, 0, time.UTC), // 28 Feb 00:00 WIB
+			expectedStart: date(2025, 1, 28),
+			expectedEnd:   date(2025, 2, 28),
+			expectedDue:   true,
+		},
+		{
+			name:          "Monthly Across Year",
+			plan:          models.AllowancePlan{Cadence: models.AllowanceCadenceMonthly, NextPeriodStart: date(2024, 12, 15), Timezone: "UTC"},
+			now:           time.Date(2025, 1, 14, 12, 0, 0, 0, time.UTC),
+			expectedStart: date(2024, 12, 15),
+			expectedEnd:   date(2025, 1, 15),
+			expectedDue:   false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			start, end, due := tc.plan.DuePeriod(tc.now)
+			assert.Equal(t, tc.expectedStart, start)
+			assert.Equal(t, tc.expectedEnd, end)
+			assert.Equal(t, tc.expectedDue, due)
+		})
+	}
+}
+
+func TestAllowancePlan_CurrentPeriodStart(t *testing.T) {
+	tests := []struct {
+		name     string
+		plan     models.AllowancePlan
+		now      time.Time
+		expected time.Time
+	}{
+		{
+			name:     "Resume After Pause Skips Paused Periods",
+			plan:     models.AllowancePlan{Cadence: models.AllowanceCadenceWeekly, NextPeriodStart: date(2025, 1, 6), Timezone: "UTC"},
+			now:      time.Date(2025, 2, 5, 12, 0, 0, 0, time.UTC),
+			expected: date(2025, 2, 3),
+		},
+		{
+			name:     "Resume On Period Boundary",
+			plan:     models.AllowancePlan{Cadence: models.AllowanceCadenceWeekly, NextPeriodStart: date(2025, 1, 6), Timezone: "UTC"},
+			now:      time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC),
+			expected: date(2025, 2, 3),
+		},
+		{
+			name:     "Resume Within First Period Keeps It",
+			plan:     models.AllowancePlan{Cadence: models.AllowanceCadenceWeekly, NextPeriodStart: date(2025, 1, 6), Timezone: "UTC"},
+			now:      time.Date(2025, 1, 8, 9, 0, 0, 0, time.UTC),
+			expected: date(2025, 1, 6),
+		},
+		{
+			name:     "Resume Before Plan Starts",
+			plan:     models.AllowancePlan{Cadence: models.AllowanceCadenceWeekly, NextPeriodStart: date(2025, 3, 3), Timezone: "UTC"},
+			now:      time.Date(2025, 2, 5, 12, 0, 0, 0, time.UTC),
+			expected: date(2025, 3, 3),
+		},
+		{
+			name:     "Resume Ahead Of UTC Uses Local Date",
+			plan:     models.AllowancePlan{Cadence: models.AllowanceCadenceWeekly, NextPeriodStart: date(2025, 1, 6), Timezone: "Asia/Jakarta"},
+			now:      time.Date(2025, 2, 2, 18, 0, 0, 0, time.UTC), // 3 Feb 01:00 WIB
+			expected: date(2025, 2, 3),
+		},
+		{
+			name:     "Resume Behind UTC Uses Local Date",
+			plan:     models.AllowancePlan{Cadence: models.AllowanceCadenceWeekly, NextPeriodStart: date(2025, 1, 6), Timezone: "America/New_York"},
+			now:      time.Date(2025, 2, 3, 3, 0, 0, 0, time.UTC), // 2 Feb 22:00 EST
+			expected: date(2025, 1, 27),
+		},
+		{
+			name:     "Resume Across DST Start",
+			plan:     models.AllowancePlan{Cadence: models.AllowanceCadenceWeekly, NextPeriodStart: date(2025, 2, 24), Timezone: "America/New_York"},
+			now:      time.Date(2025, 3, 10, 3, 30, 0, 0, time.UTC), // 9 Mar 23:30 EDT
+			expected: date(2025, 3, 3),
+		},
+		{
+			name:     "Resume Monthly",
+			plan:     models.AllowancePlan{Cadence: models.AllowanceCadenceMonthly, NextPeriodStart: date(2024, 11, 15), Timezone: "UTC"},
+			now:      time.Date(2025, 2, 20, 0, 0, 0, 0, time.UTC),
+			expected: date(2025, 2, 15),
+		},
+		{
+			name:     "Resume Monthly Before Day Of Month",
+			plan:     models.AllowancePlan{Cadence: models.AllowanceCadenceMonthly, NextPeriodStart: date(2024, 11, 15), Timezone: "UTC"},
+			now:      time.Date(2025, 2, 14, 23, 0, 0, 0, time.UTC),
+			expected: date(2025, 1, 15),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.plan.CurrentPeriodStart(tc.now))
+		})
+	}
+}
+
+func TestAllowancePlan_ResumeThenDue(t *testing.T) {
+	// Setelah dilanjutkan, periode pertama yang dibayar adalah periode berjalan, bukan periode selama jeda.
+	plan := models.AllowancePlan{Cadence: models.AllowanceCadenceWeekly, NextPeriodStart: date(2025, 1, 6), Timezone: "Asia/Jakarta"}
+	resumedAt := time.Date(2025, 2, 5, 3, 0, 0, 0, time.UTC)
+	plan.NextPeriodStart = plan.CurrentPeriodStart(resumedAt)
+
+	start, end, due := plan.DuePeriod(resumedAt)
+	assert.Equal(t, date(2025, 2, 3), start)
+	assert.Equal(t, date(2025, 2, 10), end)
+	assert.False(t, due)
+
+	_, _, due = plan.DuePeriod(time.Date(2025, 2, 9, 17, 0, 0, 0, time.UTC)) // 10 Feb 00:00 WIB
+	assert.True(t, due)
+}
@@ -0,0 +1,55 @@
+// internal/models/badge.go
+package models
+
+import "time"
+
+// BadgeRuleType mendefinisikan aturan pemberian badge.
+type BadgeRuleType string
+
+const (
+	BadgeRuleTasksCompleted BadgeRuleType = "tasks_completed" // Jumlah tugas disetujui >= threshold
+	BadgeRuleTaskStreak     BadgeRuleType = "task_streak"     // Hari berturut-turut dengan tugas disetujui >= threshold
+	BadgeRulePointsBalance  BadgeRuleType = "points_balance"  // Saldo poin saat ini >= threshold
+	BadgeRuleRewardsClaimed BadgeRuleType = "rewards_claimed" // Jumlah klaim hadiah (tidak ditolak) >= threshold
+	BadgeRuleManual         BadgeRuleType = "manual"          // Hanya diberikan langsung oleh parent
+)
+
+// Badge merepresentasikan definisi badge: badge sistem (Code terisi, tanpa pemilik) atau badge custom milik parent.
+type Badge struct {
+	ID              int           `json:"id"`                          // ID unik badge
+	Code            string        `json:"code,omitempty"`              // Kode badge sistem (kosong untuk badge custom)
+	CreatedByUserID int           `json:"created_by_user_id,omitzero"` // Parent pemilik badge custom (0 = badge sistem)
+	Name            string        `json:"name"`                        // Nama badge
+	Description     string        `json:"description,omitempty"`       // Deskripsi (opsional)
+	Icon            string        `json:"icon,omitempty"`              // Kode/emoji ikon (opsional)
+	RuleType        BadgeRuleType `json:"rule_type"`                   // Aturan pemberian
+	Threshold       int           `json:"threshold,omitzero"`          // Ambang aturan (0 untuk 'manual')
+	TaskID          int           `json:"task_id,omitzero"`            // Batasi aturan tugas ke satu tugas (opsional)
+	TaskName        string        `json:"task_name,omitempty"`         // Nama tugas (join)
+	CreatedAt       time.Time     `json:"created_at,omitzero"`         // Waktu pembuatan record
+	UpdatedAt       time.Time     `json:"updated_at,omitzero"`         // Waktu terakhir pembaruan record
+}
+
+// IsSystem mengembalikan true untuk badge bawaan sistem.
+func (b *Badge) IsSystem() bool {
+	return b.CreatedByUserID == 0
+}
+
+// ChildBadge merepresentasikan badge yang sudah diraih seorang anak.
+type ChildBadge struct {
+	ID              int       `json:"id"`                          // ID unik pencapaian
+	ChildID         int       `json:"child_id"`                    // Foreign key ke User (Anak)
+	Badge           Badge     `json:"badge"`                       // Badge yang diraih
+	AwardedByUserID int       `json:"awarded_by_user_id,omitzero"` // Parent pemberi badge manual (0 = otomatis)
+	AwardedAt       time.Time `json:"awarded_at"`                  // Waktu badge diraih
+}
+
+// BadgeInput adalah DTO untuk membuat/mengubah badge custom milik parent.
+type BadgeInput struct {
+	Name        string        `json:"name" validate:"required,min=2,max=100"`                                                                // Nama badge
+	Description string        `json:"description,omitempty" validate:"max=500"`                                                              // Deskripsi (opsional)
+	Icon        string        `json:"icon,omitempty" validate:"max=50"`                                                                      // Kode/emoji ikon (opsional)
+	RuleType    BadgeRuleType `json:"rule_type" validate:"required,oneof=tasks_completed task_streak points_balance rewards_claimed manual"` // Aturan pemberian
+	Threshold   int           `json:"threshold,omitempty" validate:"gte=0,lte=1000000"`                                                      // Ambang aturan (wajib > 0 kecuali 'manual')
+	TaskID      int           `json:"task_id,omitempty" validate:"gte=0"`                                                                    // Tugas milik parent untuk aturan tugas (opsional)
+}
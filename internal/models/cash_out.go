@@ -0,0 +1,48 @@
+// internal/models/cash_out.go
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// CashOutStatus mendefinisikan status yang mungkin untuk sebuah CashOutRequest.
+type CashOutStatus string
+
+const (
+	CashOutStatusPending  CashOutStatus = "pending"  // Menunggu persetujuan orang tua (poin sudah dikurangi)
+	CashOutStatusApproved CashOutStatus = "approved" // Disetujui dan uang sudah dibayarkan
+	CashOutStatusRejected CashOutStatus = "rejected" // Ditolak orang tua (poin dikembalikan)
+)
+
+// PayoutMethod mendefinisikan cara uang hasil pencairan diberikan ke anak.
+type PayoutMethod string
+
+const (
+	PayoutMethodCash     PayoutMethod = "cash"     // Dibayar tunai
+	PayoutMethodTransfer PayoutMethod = "transfer" // Ditransfer (bank/e-wallet)
+)
+
+// StatementMonthLayout adalah format bulan laporan bulanan (YYYY-MM).
+const StatementMonthLayout = "2006-01"
+
+// ConvertPoints mengubah poin menjadi nilai uang (satuan terkecil) sesuai kurs kebijakan.
+// Poin harus kelipatan p.Points; sisa pembagian diabaikan.
+func (p *CashOutPolicy) ConvertPoints(points int) int64 {
+	return int64(points/p.Points) * int64(p.MinorUnits)
+}
+
+// StatementPeriod mengembalikan awal bulan month (format YYYY-MM) dan awal bulan berikutnya di zona waktu loc.
+// Bulan kosong berarti bulan berjalan menurut now.
+func StatementPeriod(month string, loc *time.Location, now time.Time) (time.Time, time.Time, error) {
+	if month == "" {
+		local := now.In(loc)
+		start := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(0, 1, 0), nil
+	}
+	parsed, err := time.ParseInLocation(StatementMonthLayout, month, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid month: expected format YYYY-MM")
+	}
+	return parsed, parsed.AddDate(0, 1, 0), nil
+}
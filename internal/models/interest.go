@@ -0,0 +1,91 @@
+// internal/models/interest.go
+package models
+
+import "time"
+
+// InterestSchedule mendefinisikan periode pemajemukan bunga poin.
+type InterestSchedule string
+
+const (
+	InterestScheduleWeekly  InterestSchedule = "weekly"  // Bunga diposting setiap minggu (periode mulai Senin 00:00 UTC)
+	InterestScheduleMonthly InterestSchedule = "monthly" // Bunga diposting setiap bulan (periode mulai tanggal 1 00:00 UTC)
+)
+
+// InterestRounding mendefinisikan cara membulatkan bunga ke poin bulat.
+type InterestRounding string
+
+const (
+	InterestRoundingFloor InterestRounding = "floor" // Selalu dibulatkan ke bawah (default)
+	InterestRoundingRound InterestRounding = "round" // Dibulatkan ke terdekat (0.5 ke atas)
+	InterestRoundingCeil  InterestRounding = "ceil"  // Selalu dibulatkan ke atas
+)
+
+// PeriodStart mengembalikan awal periode (UTC) yang memuat waktu t.
+func (s InterestSchedule) PeriodStart(t time.Time) time.Time {
+	t = t.UTC()
+	if s == InterestScheduleWeekly {
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		offset := (int(day.Weekday()) + 6) % 7 // Senin = 0
+		return day.AddDate(0, 0, -offset)
+	}
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// NextPeriodStart mengembalikan awal periode berikutnya setelah periode yang dimulai pada start.
+func (s InterestSchedule) NextPeriodStart(start time.Time) time.Time {
+	if s == InterestScheduleWeekly {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+// LastCompletedPeriodStart mengembalikan awal periode terakhir yang sudah selesai pada waktu now.
+func (s InterestSchedule) LastCompletedPeriodStart(now time.Time) time.Time {
+	return s.PeriodStart(s.PeriodStart(now).Add(-time.Nanosecond))
+}
+
+// ComputeInterest menghitung bunga satu periode untuk saldo tertentu sesuai tarif, pembulatan, dan batas kebijakan.
+func (p InterestPolicy) ComputeInterest(balance int) int {
+	if balance <= 0 {
+		return 0
+	}
+	numerator := int64(balance) * int64(p.RateBasisPoints)
+	var interest int64
+	switch p.Rounding {
+	case InterestRoundingCeil:
+		interest = (numerator + 9999) / 10000
+	case InterestRoundingRound:
+		interest = (numerator + 5000) / 10000
+	default:
+		interest = numerator / 10000
+	}
+	if p.MaxPointsPerPeriod > 0 && interest > int64(p.MaxPointsPerPeriod) {
+		interest = int64(p.MaxPointsPerPeriod)
+	}
+	return int(interest)
+}
+
+// Project memproyeksikan pertumbuhan saldo selama beberapa periode ke depan, dimulai dari periode berjalan,
+// dengan asumsi tidak ada pemasukan atau pengeluaran lain.
+func (p InterestPolicy) Project(balance int, periods int, now time.Time) InterestProjection {
+	projection := InterestProjection{
+		StartingBalance: balance,
+		Periods:         make([]InterestProjectionPeriod, 0, periods),
+	}
+	start := p.Schedule.PeriodStart(now)
+	for range periods {
+		end := p.Schedule.NextPeriodStart(start)
+		interest := p.ComputeInterest(balance)
+		balance += interest
+		projection.TotalInterest += interest
+		projection.Periods = append(projection.Periods, InterestProjectionPeriod{
+			PeriodStart: start,
+			PostedAt:    end,
+			Interest:    interest,
+			Balance:     balance,
+		})
+		start = end
+	}
+	projection.EndingBalance = balance
+	return projection
+}
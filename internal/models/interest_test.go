@@ -0,0 +1,205 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterestPolicy_ComputeInterest(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   models.InterestPolicy
+		balance  int
+		expected int
+	}{
+		{
+			name:     "Zero Balance",
+			policy:   models.InterestPolicy{RateBasisPoints: 500, Rounding: models.InterestRoundingCeil},
+			balance:  0,
+			expected: 0,
+		},
+		{
+			name:     "Negative Balance",
+			policy:   models.InterestPolicy{RateBasisPoints: 500, Rounding: models.InterestRoundingCeil},
+			balance:  -200,
+			expected: 0,
+		},
+		{
+			name:     "Exact Amount Is Not Rounded",
+			policy:   models.InterestPolicy{RateBasisPoints: 250, Rounding: models.InterestRoundingCeil},
+			balance:  1000,
+			expected: 25,
+		},
+		{
+			name:     "Floor",
+			policy:   models.InterestPolicy{RateBasisPoints: 250, Rounding: models.InterestRoundingFloor},
+			balance:  1234, // 30.85
+			expected: 30,
+		},
+		{
+			name:     "Empty Rounding Defaults To Floor",
+			policy:   models.InterestPolicy{RateBasisPoints: 250},
+			balance:  1234,
+			expected: 30,
+		},
+		{
+			name:     "Round Down Below Half",
+			policy:   models.InterestPolicy{RateBasisPoints: 250, Rounding: models.InterestRoundingRound},
+			balance:  1218, // 30.45
+			expected: 30,
+		},
+		{
+			name:     "Round Half Up",
+			policy:   models.InterestPolicy{RateBasisPoints: 250, Rounding: models.InterestRoundingRound},
+			balance:  1020, // 25.5
+			expected: 26,
+		},
+		{
+			name:     "Ceil",
+			policy:   models.InterestPolicy{RateBasisPoints: 250, Rounding: models.InterestRoundingCeil},
+			balance:  1001, // 25.025
+			expected: 26,
+		},
+		{
+			name:     "Floor Of Small Balance Is Zero",
+			policy:   models.InterestPolicy{RateBasisPoints: 100, Rounding: models.InterestRoundingFloor},
+			balance:  50, // 0.5
+			expected: 0,
+		},
+		{
+			name:     "Capped",
+			policy:   models.InterestPolicy{RateBasisPoints: 500, Rounding: models.InterestRoundingFloor, MaxPointsPerPeriod: 100},
+			balance:  10000, // 500
+			expected: 100,
+		},
+		{
+			name:     "Below Cap",
+			policy:   models.InterestPolicy{RateBasisPoints: 500, Rounding: models.InterestRoundingFloor, MaxPointsPerPeriod: 100},
+			balance:  1000, // 50
+			expected: 50,
+		},
+		{
+			name:     "Cap Applied After Rounding",
+			policy:   models.InterestPolicy{RateBasisPoints: 250, Rounding: models.InterestRoundingCeil, MaxPointsPerPeriod: 25},
+			balance:  1001, // 25.025 -> 26
+			expected: 25,
+		},
+		{
+			name:     "Large Balance Does Not Overflow",
+			policy:   models.InterestPolicy{RateBasisPoints: 10000, Rounding: models.InterestRoundingFloor},
+			balance:  2000000000,
+			expected: 2000000000,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.policy.ComputeInterest(tc.balance))
+		})
+	}
+}
+
+func TestInterestSchedule_LastCompletedPeriodStart(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+
+	tests := []struct {
+		name     string
+		schedule models.InterestSchedule
+		now      time.Time
+		expected time.Time
+	}{
+		{
+			name:     "Weekly Mid Week",
+			schedule: models.InterestScheduleWeekly,
+			now:      time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC), // Rabu
+			expected: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Weekly Exactly Monday Midnight",
+			schedule: models.InterestScheduleWeekly,
+			now:      time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Weekly Just Before Monday",
+			schedule: models.InterestScheduleWeekly,
+			now:      time.Date(2025, 1, 12, 23, 59, 59, 0, time.UTC), // Minggu
+			expected: time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Weekly Non UTC Input Uses UTC Boundary",
+			schedule: models.InterestScheduleWeekly,
+			now:      time.Date(2025, 1, 13, 1, 0, 0, 0, jakarta), // Minggu 18:00 UTC
+			expected: time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Monthly Mid Month",
+			schedule: models.InterestScheduleMonthly,
+			now:      time.Date(2025, 3, 17, 8, 30, 0, 0, time.UTC),
+			expected: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Monthly Exactly Month Start",
+			schedule: models.InterestScheduleMonthly,
+			now:      time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Monthly Just Before Month Start",
+			schedule: models.InterestScheduleMonthly,
+			now:      time.Date(2025, 2, 28, 23, 59, 59, 0, time.UTC),
+			expected: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Monthly Across Year",
+			schedule: models.InterestScheduleMonthly,
+			now:      time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.schedule.LastCompletedPeriodStart(tc.now))
+		})
+	}
+}
+
+func TestInterestSchedule_LastCompletedPeriodStart_StableWithinPeriod(t *testing.T) {
+	// Posting idempoten: setiap waktu di dalam periode yang sama menghasilkan periode selesai yang sama.
+	tests := []struct {
+		name     string
+		schedule models.InterestSchedule
+		from     time.Time
+		to       time.Time
+		step     time.Duration
+	}{
+		{
+			name:     "Weekly",
+			schedule: models.InterestScheduleWeekly,
+			from:     time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC),
+			step:     time.Hour,
+		},
+		{
+			name:     "Monthly",
+			schedule: models.InterestScheduleMonthly,
+			from:     time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			step:     6 * time.Hour,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			expected := tc.schedule.LastCompletedPeriodStart(tc.from)
+			for now := tc.from; now.Before(tc.to); now = now.Add(tc.step) {
+				assert.Equal(t, expected, tc.schedule.LastCompletedPeriodStart(now), "now=%s", now)
+			}
+			assert.Equal(t, tc.schedule.PeriodStart(tc.from), tc.schedule.LastCompletedPeriodStart(tc.to))
+		})
+	}
+}
@@ -0,0 +1,37 @@
+// internal/models/ledger.go
+package models
+
+import "time"
+
+// LedgerIssueKind mendefinisikan jenis pelanggaran integritas rantai hash ledger poin.
+type LedgerIssueKind string
+
+const (
+	LedgerIssueSequenceGap  LedgerIssueKind = "sequence_gap"  // seq tidak berurutan (entri dihapus atau disisipkan)
+	LedgerIssueBrokenLink   LedgerIssueKind = "broken_link"   // prev_hash tidak sama dengan entry_hash entri sebelumnya
+	LedgerIssueHashMismatch LedgerIssueKind = "hash_mismatch" // Isi entri tidak cocok dengan entry_hash (diubah langsung di database)
+	LedgerIssueHeadMismatch LedgerIssueKind = "head_mismatch" // Ujung rantai tersimpan tidak cocok dengan entri terakhir (entri terakhir dihapus)
+)
+
+// LedgerIntegrityIssue merepresentasikan satu temuan saat memverifikasi rantai hash ledger seorang anak.
+type LedgerIntegrityIssue struct {
+	UserID        int             `json:"user_id"`                 // ID anak pemilik rantai
+	TransactionID int             `json:"transaction_id,omitzero"` // Entri yang bermasalah (kosong untuk head_mismatch)
+	Seq           int64           `json:"seq,omitzero"`            // Nomor urut entri yang bermasalah
+	Kind          LedgerIssueKind `json:"kind"`                    // Jenis pelanggaran
+	Detail        string          `json:"detail"`                  // Penjelasan singkat (nilai yang diharapkan vs ditemukan)
+}
+
+// LedgerVerificationReport adalah hasil verifikasi rantai hash ledger poin.
+type LedgerVerificationReport struct {
+	CheckedUsers   int                    `json:"checked_users"`   // Jumlah anak yang rantainya diperiksa
+	CheckedEntries int                    `json:"checked_entries"` // Jumlah entri ledger yang diperiksa
+	Valid          bool                   `json:"valid"`           // True jika tidak ada temuan
+	Issues         []LedgerIntegrityIssue `json:"issues"`          // Temuan (kosong jika valid)
+	VerifiedAt     time.Time              `json:"verified_at"`     // Waktu verifikasi
+}
+
+// ReversePointTransactionInput adalah data input untuk koreksi entri ledger oleh Admin.
+type ReversePointTransactionInput struct {
+	Reason string `json:"reason" validate:"required,min=3,max=255"` // Alasan koreksi (dicatat di notes & jejak audit)
+}
@@ -0,0 +1,107 @@
+// internal/models/level.go
+package models
+
+import "time"
+
+// MinTaskXP adalah XP minimal dari satu tugas yang disetujui (tugas tanpa poin tetap menambah progres).
+const MinTaskXP = 1
+
+// XPSource mendefinisikan asal perubahan XP.
+type XPSource string
+
+const (
+	XPSourceTaskCompletion XPSource = "task_completion" // Tugas disetujui
+	XPSourceTaskReversal   XPSource = "task_reversal"   // Verifikasi tugas di-revert
+)
+
+// TaskXP menghitung XP dari nilai tugas. XP mengikuti nilai tugas (dalam mata uang apa pun) dengan minimal MinTaskXP.
+func TaskXP(taskPoint int) int {
+	return max(taskPoint, MinTaskXP)
+}
+
+// Level merepresentasikan satu anak tangga level.
+type Level struct {
+	Level           int           `json:"level"`                      // Nomor level (mulai dari 1)
+	XPRequired      int           `json:"xp_required"`                // Total XP minimal untuk mencapai level ini
+	Title           string        `json:"title"`                      // Sebutan level
+	UnlockedRewards []LevelReward `json:"unlocked_rewards,omitempty"` // Perk: hadiah yang terbuka di level ini (hanya di LevelOverview)
+}
+
+// LevelReward adalah hadiah yang terbuka saat anak mencapai level tertentu (perk level).
+type LevelReward struct {
+	RewardID   int    `json:"reward_id"`   // ID hadiah
+	RewardName string `json:"reward_name"` // Nama hadiah
+	MinLevel   int    `json:"min_level"`   // Level minimal untuk klaim
+}
+
+// XPTransaction merepresentasikan satu perubahan XP anak.
+type XPTransaction struct {
+	ID                int       `json:"id"`                            // ID unik entri XP
+	UserID            int       `json:"user_id"`                       // Anak pemilik XP
+	ChangeAmount      int       `json:"change_amount"`                 // Perubahan XP (negatif untuk pembalikan)
+	Source            XPSource  `json:"source"`                        // Asal perubahan XP
+	RelatedUserTaskID int       `json:"related_user_task_id,omitzero"` // Penugasan terkait
+	CreatedAt         time.Time `json:"created_at,omitzero"`           // Waktu pencatatan
+}
+
+// LevelUp merepresentasikan level yang dicapai anak.
+type LevelUp struct {
+	ID         int       `json:"id"`                    // ID unik level-up
+	UserID     int       `json:"user_id"`               // Anak yang naik level
+	Level      int       `json:"level"`                 // Level yang dicapai
+	Title      string    `json:"title,omitempty"`       // Sebutan level
+	TotalXP    int       `json:"total_xp"`              // Total XP saat level dicapai
+	UserTaskID int       `json:"user_task_id,omitzero"` // Persetujuan tugas yang memicu level-up
+	ReachedAt  time.Time `json:"reached_at,omitzero"`   // Waktu level dicapai
+}
+
+// LevelProgress menunjukkan level anak saat ini dan progres menuju level berikutnya.
+type LevelProgress struct {
+	Level           int    `json:"level"`                  // Level saat ini
+	Title           string `json:"title"`                  // Sebutan level saat ini
+	TotalXP         int    `json:"total_xp"`               // Total XP (tidak berkurang saat poin dibelanjakan)
+	LevelXP         int    `json:"level_xp"`               // XP minimal level saat ini
+	NextLevel       int    `json:"next_level,omitzero"`    // Level berikutnya (0 = sudah level tertinggi)
+	NextLevelXP     int    `json:"next_level_xp,omitzero"` // XP minimal level berikutnya
+	XPToNextLevel   int    `json:"xp_to_next_level"`       // Sisa XP menuju level berikutnya
+	ProgressPercent int    `json:"progress_percent"`       // Progres dari level saat ini ke berikutnya (0-100)
+}
+
+// LevelOverview merangkum level, tangga level beserta perknya, dan riwayat level-up seorang anak.
+type LevelOverview struct {
+	ChildID  int           `json:"child_id"`  // Anak
+	Progress LevelProgress `json:"progress"`  // Level & progres saat ini
+	Levels   []Level       `json:"levels"`    // Tangga level beserta hadiah yang terbuka di tiap level
+	LevelUps []LevelUp     `json:"level_ups"` // Riwayat level-up (terbaru dulu)
+}
+
+// ChildOverview adalah data anak pada daftar anak milik parent, dilengkapi level & progresnya.
+type ChildOverview struct {
+	User
+	Level *LevelProgress `json:"level,omitempty"` // Level & progres anak
+}
+
+// NewLevelProgress menghitung level dan progres dari totalXP berdasarkan tangga levels (urut naik menurut XPRequired).
+// Tangga kosong dianggap hanya berisi level 1.
+func NewLevelProgress(levels []Level, totalXP int) LevelProgress {
+	progress := LevelProgress{Level: 1, TotalXP: totalXP, ProgressPercent: 100}
+	current := -1
+	for i, level := range levels {
+		if level.XPRequired <= totalXP {
+			current = i
+		}
+	}
+	if current >= 0 {
+		progress.Level = levels[current].Level
+		progress.Title = levels[current].Title
+		progress.LevelXP = levels[current].XPRequired
+	}
+	if current+1 < len(levels) {
+		next := levels[current+1]
+		progress.NextLevel = next.Level
+		progress.NextLevelXP = next.XPRequired
+		progress.XPToNextLevel = next.XPRequired - totalXP
+		progress.ProgressPercent = (totalXP - progress.LevelXP) * 100 / (next.XPRequired - progress.LevelXP)
+	}
+	return progress
+}
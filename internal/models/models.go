@@ -30,6 +30,7 @@ type User struct {
 	LastName  string    `json:"last_name,omitempty"`                        // Nama belakang (opsional)
 	RoleID    int       `json:"role_id" validate:"required,gt=0"`           // Foreign key ke tabel Role
 	Role      *Role     `json:"role,omitempty"`                             // Relasi ke Role (bisa di-preload)
+	Timezone  string    `json:"timezone,omitempty"`                         // Zona waktu IANA pengguna (default UTC)
 	CreatedAt time.Time `json:"created_at,omitzero"`                        // Waktu pembuatan record
 	UpdatedAt time.Time `json:"updated_at,omitzero"`                        // Waktu terakhir pembaruan record
 }
@@ -46,77 +47,504 @@ type UserRelationship struct {
 
 // Task merepresentasikan definisi sebuah tugas yang dibuat oleh orang tua.
 type Task struct {
-	ID              int       `json:"id"`                                          // ID unik tugas
-	TaskName        string    `json:"task_name" validate:"required,min=3,max=100"` // Nama tugas
-	TaskPoint       int       `json:"task_point" validate:"required,gt=0"`         // Jumlah poin yang didapat jika tugas selesai
-	TaskDescription string    `json:"task_description,omitempty"`                  // Deskripsi detail tugas (opsional)
-	CreatedByUserID int       `json:"created_by_user_id" validate:"required,gt=0"` // Foreign key ke User (Parent yang membuat)
-	User            *User     `json:"user,omitempty"`                              // Relasi ke User (Pembuat) (bisa di-preload)
-	CreatedAt       time.Time `json:"created_at,omitzero"`                         // Waktu pembuatan record
-	UpdatedAt       time.Time `json:"updated_at,omitzero"`                         // Waktu terakhir pembaruan record
+	ID                    int                `json:"id"`                                          // ID unik tugas
+	TaskName              string             `json:"task_name" validate:"required,min=3,max=100"` // Nama tugas
+	TaskPoint             int                `json:"task_point" validate:"required,gt=0"`         // Jumlah poin yang didapat jika tugas selesai
+	CurrencyID            int                `json:"currency_id,omitzero"`                        // Mata uang yang diberikan (0/null = poin)
+	TaskDescription       string             `json:"task_description,omitempty"`                  // Deskripsi detail tugas (opsional)
+	Category              DefinitionCategory `json:"category,omitempty"`                          // Kategori tugas (opsional)
+	Tags                  []string           `json:"tags,omitempty"`                              // Tag bebas untuk pencarian (opsional)
+	SourceTemplateID      int                `json:"source_template_id,omitzero"`                 // Template asal jika diimpor dari katalog (nullable)
+	SourceTemplateVersion int                `json:"source_template_version,omitzero"`            // Versi template saat diimpor
+	CreatedByUserID       int                `json:"created_by_user_id" validate:"required,gt=0"` // Foreign key ke User (Parent yang membuat)
+	User                  *User              `json:"user,omitempty"`                              // Relasi ke User (Pembuat) (bisa di-preload)
+	CreatedAt             time.Time          `json:"created_at,omitzero"`                         // Waktu pembuatan record
+	UpdatedAt             time.Time          `json:"updated_at,omitzero"`                         // Waktu terakhir pembaruan record
 }
 
 // Reward merepresentasikan definisi sebuah hadiah yang dapat diklaim oleh anak.
 type Reward struct {
-	ID                int       `json:"id"`                                            // ID unik hadiah
-	RewardName        string    `json:"reward_name" validate:"required,min=3,max=100"` // Nama hadiah
-	RewardPoint       int       `json:"reward_point" validate:"required,gt=0"`         // Jumlah poin yang dibutuhkan untuk klaim
-	RewardDescription string    `json:"reward_description,omitempty"`                  // Deskripsi detail hadiah (opsional)
-	CreatedByUserID   int       `json:"created_by_user_id" validate:"required,gt=0"`   // Foreign key ke User (Parent yang membuat)
-	User              *User     `json:"user,omitempty"`                                // Relasi ke User (Pembuat) (bisa di-preload)
-	CreatedAt         time.Time `json:"created_at,omitzero"`                           // Waktu pembuatan record
-	UpdatedAt         time.Time `json:"updated_at,omitzero"`                           // Waktu terakhir pembaruan record
+	ID                    int                 `json:"id"`                                            // ID unik hadiah
+	RewardName            string              `json:"reward_name" validate:"required,min=3,max=100"` // Nama hadiah
+	RewardPoint           int                 `json:"reward_point" validate:"required,gt=0"`         // Jumlah poin yang dibutuhkan untuk klaim
+	CurrencyID            int                 `json:"currency_id,omitzero"`                          // Mata uang harga hadiah (0/null = poin)
+	RewardDescription     string              `json:"reward_description,omitempty"`                  // Deskripsi detail hadiah (opsional)
+	Category              DefinitionCategory  `json:"category,omitempty"`                            // Kategori hadiah (opsional)
+	Tags                  []string            `json:"tags,omitempty"`                                // Tag bebas untuk pencarian (opsional)
+	RewardLimits                              // Stok, kuota per anak, dan cooldown (opsional)
+	Availability          *RewardAvailability `json:"availability,omitempty"`                      // Ketersediaan untuk anak (hanya di daftar hadiah anak)
+	SourceTemplateID      int                 `json:"source_template_id,omitzero"`                 // Template asal jika diimpor dari katalog (nullable)
+	SourceTemplateVersion int                 `json:"source_template_version,omitzero"`            // Versi template saat diimpor
+	CreatedByUserID       int                 `json:"created_by_user_id" validate:"required,gt=0"` // Foreign key ke User (Parent yang membuat)
+	User                  *User               `json:"user,omitempty"`                              // Relasi ke User (Pembuat) (bisa di-preload)
+	CreatedAt             time.Time           `json:"created_at,omitzero"`                         // Waktu pembuatan record
+	UpdatedAt             time.Time           `json:"updated_at,omitzero"`                         // Waktu terakhir pembaruan record
 }
 
 // UserTask merepresentasikan tugas yang telah ditugaskan (assigned) kepada seorang anak.
 type UserTask struct {
-	ID               int            `json:"id"`                                                                    // ID unik penugasan
-	UserID           int            `json:"user_id" validate:"required,gt=0"`                                      // Foreign key ke User (Anak yang ditugaskan)
-	TaskID           int            `json:"task_id" validate:"required,gt=0"`                                      // Foreign key ke Task (Definisi tugas)
-	AssignedByUserID int            `json:"assigned_by_user_id" validate:"required,gt=0"`                          // Foreign key ke User (Parent yang menugaskan)
-	Status           UserTaskStatus `json:"status" validate:"required,oneof=assigned approved submitted rejected"` // Status penugasan saat ini
-	AssignedAt       time.Time      `json:"assigned_at,omitzero"`                                                  // Waktu penugasan
-	SubmittedAt      *time.Time     `json:"submitted_at,omitzero"`                                                 // Waktu anak submit tugas (nullable)
-	VerifiedByUserID int            `json:"verified_by_user_id,omitzero" validate:"omitempty,gt=0"`                // Foreign key ke User (Parent yang verifikasi) (nullable)
-	VerifiedAt       *time.Time     `json:"verified_at,omitzero"`                                                  // Waktu verifikasi oleh parent (nullable)
-	CompletedAt      *time.Time     `json:"completed_at,omitzero"`                                                 // Waktu tugas dianggap selesai (setelah approved) (nullable)
-	Task             *Task          `json:"task,omitempty"`                                                        // Relasi ke Task (bisa di-preload)
-	User             *User          `json:"user,omitempty"`                                                        // Relasi ke User (Anak) (bisa di-preload)
-	CreatedAt        time.Time      `json:"created_at,omitzero"`                                                   // Waktu pembuatan record
-	UpdatedAt        time.Time      `json:"updated_at,omitzero"`                                                   // Waktu terakhir pembaruan record
+	ID               int            `json:"id"`                                                                                      // ID unik penugasan
+	UserID           int            `json:"user_id" validate:"required,gt=0"`                                                        // Foreign key ke User (Anak yang ditugaskan)
+	TaskID           int            `json:"task_id" validate:"required,gt=0"`                                                        // Foreign key ke Task (Definisi tugas)
+	AssignedByUserID int            `json:"assigned_by_user_id" validate:"required,gt=0"`                                            // Foreign key ke User (Parent yang menugaskan)
+	Status           UserTaskStatus `json:"status" validate:"required,oneof=assigned approved submitted rejected cancelled expired"` // Status penugasan saat ini
+	AssignedAt       time.Time      `json:"assigned_at,omitzero"`                                                                    // Waktu penugasan
+	DueAt            *time.Time     `json:"due_at,omitzero"`                                                                         // Batas waktu pengerjaan (nullable); lewat batas -> 'expired'
+	SubmittedAt      *time.Time     `json:"submitted_at,omitzero"`                                                                   // Waktu anak submit tugas (nullable)
+	VerifiedByUserID int            `json:"verified_by_user_id,omitzero" validate:"omitempty,gt=0"`                                  // Foreign key ke User (Parent yang verifikasi) (nullable)
+	VerifiedAt       *time.Time     `json:"verified_at,omitzero"`                                                                    // Waktu verifikasi oleh parent (nullable)
+	CompletedAt      *time.Time     `json:"completed_at,omitzero"`                                                                   // Waktu tugas dianggap selesai (setelah approved) (nullable)
+	AutoApproved     bool           `json:"auto_approved,omitempty"`                                                                 // True jika disetujui otomatis oleh sistem (kebijakan auto-approval)
+	Task             *Task          `json:"task,omitempty"`                                                                          // Relasi ke Task (bisa di-preload)
+	User             *User          `json:"user,omitempty"`                                                                          // Relasi ke User (Anak) (bisa di-preload)
+	CreatedAt        time.Time      `json:"created_at,omitzero"`                                                                     // Waktu pembuatan record
+	UpdatedAt        time.Time      `json:"updated_at,omitzero"`                                                                     // Waktu terakhir pembaruan record
+}
+
+// UserTaskEvent merepresentasikan satu transisi dalam riwayat (timeline) sebuah UserTask.
+type UserTaskEvent struct {
+	ID          int64          `json:"id"`                     // ID unik event
+	UserTaskID  int            `json:"user_task_id"`           // Foreign key ke UserTask
+	Action      UserTaskAction `json:"action"`                 // Aksi penyebab transisi (assign, submit, approve, ...)
+	FromStatus  UserTaskStatus `json:"from_status,omitempty"`  // Status sebelum transisi (kosong untuk 'assign')
+	ToStatus    UserTaskStatus `json:"to_status"`              // Status setelah transisi
+	ActorUserID int            `json:"actor_user_id,omitzero"` // Pengguna pelaku (0/NULL untuk sistem)
+	ActorType   AuditActorType `json:"actor_type"`             // 'user' atau 'system'
+	Reason      string         `json:"reason,omitempty"`       // Alasan transisi (opsional)
+	CreatedAt   time.Time      `json:"created_at,omitzero"`    // Waktu transisi
+}
+
+// UserRewardEvent mencatat satu transisi status klaim hadiah (riwayat klaim s/d penyerahan).
+type UserRewardEvent struct {
+	ID           int64            `json:"id"`                     // ID unik event
+	UserRewardID int              `json:"user_reward_id"`         // Foreign key ke UserReward
+	Action       UserRewardAction `json:"action"`                 // Aksi penyebab transisi (claim, approve, schedule, ...)
+	FromStatus   UserRewardStatus `json:"from_status,omitempty"`  // Status sebelum transisi (kosong untuk 'claim')
+	ToStatus     UserRewardStatus `json:"to_status"`              // Status setelah transisi
+	ScheduledFor *time.Time       `json:"scheduled_for,omitzero"` // Tanggal penyerahan yang dijadwalkan (untuk 'schedule')
+	ActorUserID  int              `json:"actor_user_id,omitzero"` // Pengguna pelaku (0/NULL untuk sistem)
+	ActorType    AuditActorType   `json:"actor_type"`             // 'user' atau 'system'
+	Note         string           `json:"note,omitempty"`         // Catatan (opsional)
+	CreatedAt    time.Time        `json:"created_at,omitzero"`    // Waktu transisi
+}
+
+// RewardApprovalPolicy mewajibkan persetujuan dari beberapa orang tua berbeda untuk klaim bernilai besar.
+// Kebijakan dimiliki parent dan berlaku untuk semua anaknya atau satu anak; untuk setiap anak dipakai
+// kebijakan khusus anak lebih dulu, lalu kebijakan untuk semua anak (seperti AutoApprovalPolicy).
+type RewardApprovalPolicy struct {
+	ID                int       `json:"id"`                  // ID unik kebijakan
+	CreatedByUserID   int       `json:"created_by_user_id"`  // Parent pemilik kebijakan
+	ChildID           int       `json:"child_id,omitzero"`   // Anak (0/NULL = semua anak Parent)
+	PointsThreshold   int       `json:"points_threshold"`    // Klaim di atas nilai poin ini butuh persetujuan bersama
+	RequiredApprovals int       `json:"required_approvals"`  // Jumlah orang tua berbeda yang harus menyetujui
+	CreatedAt         time.Time `json:"created_at,omitzero"` // Waktu pembuatan record
+	UpdatedAt         time.Time `json:"updated_at,omitzero"` // Waktu terakhir pembaruan record
+}
+
+// PointExpirationPolicy mengatur masa berlaku poin anak. Kebijakan dimiliki parent dan berlaku untuk semua
+// anaknya atau satu anak, diresolusikan seperti AutoApprovalPolicy.
+type PointExpirationPolicy struct {
+	ID              int       `json:"id"`                  // ID unik kebijakan
+	CreatedByUserID int       `json:"created_by_user_id"`  // Parent pemilik kebijakan
+	ChildID         int       `json:"child_id,omitzero"`   // Anak (0/NULL = semua anak Parent)
+	ExpireAfterDays int       `json:"expire_after_days"`   // Poin kedaluwarsa sekian hari setelah didapat
+	WarnBeforeDays  int       `json:"warn_before_days"`    // Anak diperingatkan sekian hari sebelum kedaluwarsa
+	CreatedAt       time.Time `json:"created_at,omitzero"` // Waktu pembuatan record
+	UpdatedAt       time.Time `json:"updated_at,omitzero"` // Waktu terakhir pembaruan record
+}
+
+// InterestPolicy mengatur bunga periodik atas saldo poin anak. Kebijakan dimiliki parent dan berlaku untuk
+// semua anaknya atau satu anak, diresolusikan seperti AutoApprovalPolicy.
+type InterestPolicy struct {
+	ID                 int              `json:"id"`                             // ID unik kebijakan
+	CreatedByUserID    int              `json:"created_by_user_id"`             // Parent pemilik kebijakan
+	ChildID            int              `json:"child_id,omitzero"`              // Anak (0/NULL = semua anak Parent)
+	RateBasisPoints    int              `json:"rate_basis_points"`              // Bunga per periode dalam basis poin (100 = 1%)
+	Schedule           InterestSchedule `json:"schedule"`                       // Periode pemajemukan (weekly/monthly)
+	Rounding           InterestRounding `json:"rounding"`                       // Pembulatan bunga (floor/round/ceil)
+	MaxPointsPerPeriod int              `json:"max_points_per_period,omitzero"` // Batas bunga per periode (0 = tanpa batas)
+	CreatedAt          time.Time        `json:"created_at,omitzero"`            // Waktu pembuatan record (periode sebelum ini tidak berbunga)
+	UpdatedAt          time.Time        `json:"updated_at,omitzero"`            // Waktu terakhir pembaruan record
+}
+
+// InterestProjectionPeriod adalah satu periode pada proyeksi pertumbuhan saldo.
+type InterestProjectionPeriod struct {
+	PeriodStart time.Time `json:"period_start"` // Awal periode yang menghasilkan bunga
+	PostedAt    time.Time `json:"posted_at"`    // Waktu bunga diposting (akhir periode)
+	Interest    int       `json:"interest"`     // Bunga periode ini
+	Balance     int       `json:"balance"`      // Saldo setelah bunga diposting
+}
+
+// InterestProjection adalah proyeksi pertumbuhan saldo karena bunga (tanpa pemasukan/pengeluaran lain).
+type InterestProjection struct {
+	Policy          *InterestPolicy            `json:"policy,omitempty"` // Kebijakan yang dipakai
+	StartingBalance int                        `json:"starting_balance"` // Saldo saat ini
+	TotalInterest   int                        `json:"total_interest"`   // Total bunga selama periode proyeksi
+	EndingBalance   int                        `json:"ending_balance"`   // Saldo di akhir proyeksi
+	Periods         []InterestProjectionPeriod `json:"periods"`          // Rincian per periode
+}
+
+// AllowancePlan adalah rencana uang saku otomatis anak. Setiap periode yang sudah selesai (dihitung
+// dari StartDate sesuai Cadence, pada tanggal lokal zona waktu anak) dibayarkan tepat satu kali.
+type AllowancePlan struct {
+	ID               int              `json:"id"`                          // ID unik rencana
+	ChildID          int              `json:"child_id"`                    // Foreign key ke User (Anak)
+	ParentID         int              `json:"parent_id,omitzero"`          // Parent yang terakhir mengatur rencana (pemberi uang saku)
+	Amount           int              `json:"amount"`                      // Poin yang dibayarkan per periode
+	Cadence          AllowanceCadence `json:"cadence"`                     // Frekuensi pembayaran (weekly/biweekly/monthly)
+	StartDate        time.Time        `json:"start_date"`                  // Awal periode pertama (tanggal lokal)
+	MinApprovedTasks int              `json:"min_approved_tasks,omitzero"` // Syarat minimal tugas disetujui per periode (0 = tanpa syarat)
+	NextPeriodStart  time.Time        `json:"next_period_start"`           // Awal periode berikutnya yang belum diproses
+	IsPaused         bool             `json:"is_paused"`                   // Rencana dijeda (periode selama jeda tidak dibayar)
+	PausedAt         *time.Time       `json:"paused_at,omitzero"`          // Waktu rencana dijeda (nullable)
+	Timezone         string           `json:"timezone"`                    // Zona waktu anak yang dipakai untuk batas periode
+	CreatedAt        time.Time        `json:"created_at,omitzero"`         // Waktu pembuatan record
+	UpdatedAt        time.Time        `json:"updated_at,omitzero"`         // Waktu terakhir pembaruan record
+}
+
+// Currency merepresentasikan mata uang tambahan milik parent (misal: bintang, koin, menit layar).
+// Mata uang utama ("poin") tidak memiliki record; CurrencyID 0 di model lain berarti poin.
+type Currency struct {
+	ID              int       `json:"id"`                  // ID unik mata uang
+	CreatedByUserID int       `json:"created_by_user_id"`  // Foreign key ke User (Parent pemilik)
+	Name            string    `json:"name"`                // Nama mata uang, unik per parent
+	Symbol          string    `json:"symbol,omitempty"`    // Simbol/emoji tampilan (opsional)
+	CreatedAt       time.Time `json:"created_at,omitzero"` // Waktu pembuatan record
+	UpdatedAt       time.Time `json:"updated_at,omitzero"` // Waktu terakhir pembaruan record
+}
+
+// CurrencyBalance adalah saldo anak untuk satu mata uang.
+type CurrencyBalance struct {
+	CurrencyID int    `json:"currency_id"`      // 0 = poin
+	Name       string `json:"name"`             // Nama mata uang ("Points" untuk poin)
+	Symbol     string `json:"symbol,omitempty"` // Simbol/emoji tampilan
+	Balance    int    `json:"balance"`          // Saldo saat ini
+}
+
+// CurrencyExchangeRule adalah aturan tukar milik parent: FromAmount unit mata uang asal
+// ditukar menjadi ToAmount unit mata uang tujuan.
+type CurrencyExchangeRule struct {
+	ID              int       `json:"id"`                  // ID unik aturan
+	CreatedByUserID int       `json:"created_by_user_id"`  // Foreign key ke User (Parent pemilik)
+	FromCurrencyID  int       `json:"from_currency_id"`    // Mata uang asal (0 = poin)
+	ToCurrencyID    int       `json:"to_currency_id"`      // Mata uang tujuan (0 = poin)
+	FromAmount      int       `json:"from_amount"`         // Unit asal per penukaran
+	ToAmount        int       `json:"to_amount"`           // Unit tujuan per penukaran
+	CreatedAt       time.Time `json:"created_at,omitzero"` // Waktu pembuatan record
+	UpdatedAt       time.Time `json:"updated_at,omitzero"` // Waktu terakhir pembaruan record
+}
+
+// CurrencyExchangeResult adalah hasil penukaran mata uang oleh anak.
+type CurrencyExchangeResult struct {
+	RuleID              int `json:"rule_id"`               // Aturan tukar yang dipakai
+	FromCurrencyID      int `json:"from_currency_id"`      // Mata uang asal (0 = poin)
+	ToCurrencyID        int `json:"to_currency_id"`        // Mata uang tujuan (0 = poin)
+	Debited             int `json:"debited"`               // Jumlah mata uang asal yang dikurangi
+	Credited            int `json:"credited"`              // Jumlah mata uang tujuan yang ditambahkan
+	DebitTransactionID  int `json:"debit_transaction_id"`  // Entri ledger pengurangan
+	CreditTransactionID int `json:"credit_transaction_id"` // Entri ledger penambahan
+}
+
+// TransferPolicy mengatur transfer poin dari seorang anak ke saudaranya. Kebijakan dimiliki parent dan berlaku
+// untuk semua anaknya atau satu anak, diresolusikan seperti AutoApprovalPolicy. Anak tanpa kebijakan boleh
+// mentransfer, namun setiap transfer memerlukan persetujuan orang tua.
+type TransferPolicy struct {
+	ID               int       `json:"id"`                  // ID unik kebijakan
+	CreatedByUserID  int       `json:"created_by_user_id"`  // Parent pemilik kebijakan
+	ChildID          int       `json:"child_id,omitzero"`   // Anak pengirim (0/NULL = semua anak Parent)
+	Allowed          bool      `json:"allowed"`             // Anak boleh mengirim poin ke saudaranya
+	MaxAmount        int       `json:"max_amount,omitzero"` // Batas poin per transfer (0 = tanpa batas)
+	RequiresApproval bool      `json:"requires_approval"`   // Transfer menunggu persetujuan orang tua
+	CreatedAt        time.Time `json:"created_at,omitzero"` // Waktu pembuatan record
+	UpdatedAt        time.Time `json:"updated_at,omitzero"` // Waktu terakhir pembaruan record
+}
+
+// CashOutPolicy adalah kurs pencairan poin menjadi uang. Kebijakan dimiliki parent dan berlaku untuk semua
+// anaknya atau satu anak, diresolusikan seperti AutoApprovalPolicy. Anak tanpa kebijakan tidak bisa mencairkan poin.
+type CashOutPolicy struct {
+	ID              int       `json:"id"`                  // ID unik kebijakan
+	CreatedByUserID int       `json:"created_by_user_id"`  // Parent pemilik kebijakan
+	ChildID         int       `json:"child_id,omitzero"`   // Anak (0/NULL = semua anak Parent)
+	Points          int       `json:"points"`              // Jumlah poin per satuan kurs
+	MinorUnits      int       `json:"minor_units"`         // Nilai uang per satuan kurs (satuan terkecil, misal sen)
+	CurrencyCode    string    `json:"currency_code"`       // Kode mata uang ISO 4217 (misal IDR, USD)
+	MinPoints       int       `json:"min_points"`          // Minimal poin per permintaan (0 = tanpa minimum)
+	CreatedAt       time.Time `json:"created_at,omitzero"` // Waktu pembuatan record
+	UpdatedAt       time.Time `json:"updated_at,omitzero"` // Waktu terakhir pembaruan record
+}
+
+// CashOutRequest merepresentasikan permintaan anak untuk mencairkan poin menjadi uang.
+type CashOutRequest struct {
+	ID                  int               `json:"id"`                             // ID unik permintaan
+	ChildID             int               `json:"child_id"`                       // Foreign key ke User (Anak)
+	ChildUsername       string            `json:"child_username,omitempty"`       // Username anak (join)
+	Points              int               `json:"points"`                         // Poin yang dicairkan
+	AmountMinor         int64             `json:"amount_minor"`                   // Nilai uang (satuan terkecil) sesuai kurs saat permintaan
+	CurrencyCode        string            `json:"currency_code"`                  // Kode mata uang ISO 4217
+	Note                string            `json:"note,omitempty"`                 // Catatan dari anak (opsional)
+	Status              CashOutStatus     `json:"status"`                         // Status permintaan
+	ReviewedByUserID    int               `json:"reviewed_by_user_id,omitzero"`   // Parent yang menyetujui/menolak
+	ReviewedAt          *time.Time        `json:"reviewed_at,omitzero"`           // Waktu persetujuan/penolakan (nullable)
+	ReviewNote          string            `json:"review_note,omitempty"`          // Catatan review (opsional)
+	DebitTransactionID  int               `json:"debit_transaction_id,omitzero"`  // Entri ledger poin 'cash_out'
+	RefundTransactionID int               `json:"refund_transaction_id,omitzero"` // Entri ledger poin 'cash_out_refund' (jika ditolak)
+	Payout              *MoneyTransaction `json:"payout,omitempty"`               // Entri ledger uang (jika disetujui)
+	CreatedAt           time.Time         `json:"created_at,omitzero"`            // Waktu pembuatan record
+	UpdatedAt           time.Time         `json:"updated_at,omitzero"`            // Waktu terakhir pembaruan record
+}
+
+// MoneyTransaction adalah entri ledger uang yang mencatat pembayaran satu permintaan cash-out.
+type MoneyTransaction struct {
+	ID               int          `json:"id"`                       // ID unik entri
+	ChildID          int          `json:"child_id"`                 // Foreign key ke User (Anak)
+	CashOutRequestID int          `json:"cash_out_request_id"`      // Foreign key ke CashOutRequest
+	Points           int          `json:"points,omitzero"`          // Poin yang dicairkan (join)
+	AmountMinor      int64        `json:"amount_minor"`             // Nilai uang yang dibayarkan (satuan terkecil)
+	CurrencyCode     string       `json:"currency_code"`            // Kode mata uang ISO 4217
+	PayoutMethod     PayoutMethod `json:"payout_method"`            // 'cash' (dibayar tunai) atau 'transfer' (ditransfer)
+	Reference        string       `json:"reference,omitempty"`      // Referensi transfer (opsional)
+	PaidByUserID     int          `json:"paid_by_user_id,omitzero"` // Parent yang membayar
+	PaidAt           time.Time    `json:"paid_at"`                  // Waktu pembayaran
+	CreatedAt        time.Time    `json:"created_at,omitzero"`      // Waktu pembuatan record
+}
+
+// MoneyStatementTotal adalah total pembayaran dalam satu mata uang pada sebuah laporan bulanan.
+type MoneyStatementTotal struct {
+	CurrencyCode string `json:"currency_code"` // Kode mata uang ISO 4217
+	AmountMinor  int64  `json:"amount_minor"`  // Total uang yang dibayarkan (satuan terkecil)
+	PayoutCount  int    `json:"payout_count"`  // Jumlah pembayaran
+}
+
+// MoneyStatement adalah laporan bulanan uang saku yang dibayarkan ke seorang anak.
+type MoneyStatement struct {
+	ChildID         int                   `json:"child_id"`          // Foreign key ke User (Anak)
+	Month           string                `json:"month"`             // Bulan laporan (format YYYY-MM)
+	PeriodStart     time.Time             `json:"period_start"`      // Awal bulan di zona waktu anak
+	PeriodEnd       time.Time             `json:"period_end"`        // Awal bulan berikutnya (eksklusif)
+	Timezone        string                `json:"timezone"`          // Zona waktu yang dipakai
+	Totals          []MoneyStatementTotal `json:"totals"`            // Total per mata uang
+	PointsCashedOut int                   `json:"points_cashed_out"` // Total poin yang dicairkan dan dibayarkan
+	Payouts         []MoneyTransaction    `json:"payouts"`           // Rincian pembayaran
+}
+
+// PointStatementLine adalah satu baris rincian laporan rekening poin beserta saldo setelah transaksi.
+type PointStatementLine struct {
+	TransactionID   int             `json:"transaction_id"`                  // ID transaksi poin
+	CreatedAt       time.Time       `json:"created_at"`                      // Waktu transaksi
+	TransactionType TransactionType `json:"transaction_type"`                // Jenis transaksi
+	ChangeAmount    int             `json:"change_amount"`                   // Perubahan saldo (positif = kredit, negatif = debit)
+	Balance         int             `json:"balance"`                         // Saldo setelah transaksi ini
+	Notes           string          `json:"notes,omitempty"`                 // Catatan transaksi
+	UserTaskID      int             `json:"related_user_task_id,omitzero"`   // UserTask terkait (jika ada)
+	TaskName        string          `json:"task_name,omitempty"`             // Nama tugas terkait (join)
+	UserRewardID    int             `json:"related_user_reward_id,omitzero"` // UserReward terkait (jika ada)
+	RewardName      string          `json:"reward_name,omitempty"`           // Nama hadiah terkait (join)
+}
+
+// StatementTypeTotal adalah total kredit atau debit untuk satu jenis transaksi dalam laporan rekening.
+type StatementTypeTotal struct {
+	TransactionType TransactionType `json:"transaction_type"` // Jenis transaksi
+	Amount          int             `json:"amount"`           // Total poin (selalu positif)
+	Count           int             `json:"count"`            // Jumlah transaksi
+}
+
+// PointStatement adalah laporan rekening poin bulanan seorang anak untuk satu mata uang.
+type PointStatement struct {
+	ChildID        int                  `json:"child_id"`        // Foreign key ke User (Anak)
+	ChildUsername  string               `json:"child_username"`  // Username anak
+	Month          string               `json:"month"`           // Bulan laporan (format YYYY-MM)
+	PeriodStart    time.Time            `json:"period_start"`    // Awal bulan di zona waktu anak
+	PeriodEnd      time.Time            `json:"period_end"`      // Awal bulan berikutnya (eksklusif)
+	Timezone       string               `json:"timezone"`        // Zona waktu yang dipakai
+	CurrencyID     int                  `json:"currency_id"`     // Mata uang laporan (0 = poin)
+	CurrencyName   string               `json:"currency_name"`   // Nama mata uang ("points" untuk poin)
+	OpeningBalance int                  `json:"opening_balance"` // Saldo awal periode
+	TotalCredits   int                  `json:"total_credits"`   // Total kredit dalam periode
+	TotalDebits    int                  `json:"total_debits"`    // Total debit dalam periode (positif)
+	ClosingBalance int                  `json:"closing_balance"` // Saldo akhir periode
+	Credits        []StatementTypeTotal `json:"credits"`         // Kredit per jenis transaksi
+	Debits         []StatementTypeTotal `json:"debits"`          // Debit per jenis transaksi
+	Lines          []PointStatementLine `json:"lines"`           // Rincian transaksi (terlama dulu)
+}
+
+// InfractionType adalah jenis pelanggaran milik parent beserta poin sanksinya (misal "Terlambat tidur: -5").
+type InfractionType struct {
+	ID               int       `json:"id"`                            // ID unik jenis pelanggaran
+	CreatedByUserID  int       `json:"created_by_user_id"`            // Foreign key ke User (Parent pemilik)
+	Name             string    `json:"name"`                          // Nama pelanggaran
+	Description      string    `json:"description,omitempty"`         // Deskripsi (opsional)
+	Points           int       `json:"points"`                        // Poin yang dipotong setiap kali diterapkan
+	MaxPerDay        int       `json:"max_per_day,omitzero"`          // Batas penerapan per anak per hari (0 = tanpa batas)
+	EarnBackTaskID   int       `json:"earn_back_task_id,omitzero"`    // Tugas penebus yang ditugaskan saat sanksi diterapkan (opsional)
+	EarnBackTaskName string    `json:"earn_back_task_name,omitempty"` // Nama tugas penebus (join)
+	CreatedAt        time.Time `json:"created_at,omitzero"`           // Waktu pembuatan record
+	UpdatedAt        time.Time `json:"updated_at,omitzero"`           // Waktu terakhir pembaruan record
+}
+
+// Penalty merepresentasikan sanksi yang diterapkan ke anak beserta status penebusannya.
+type Penalty struct {
+	ID                    int            `json:"id"`                               // ID unik sanksi
+	ChildID               int            `json:"child_id"`                         // Foreign key ke User (Anak)
+	InfractionTypeID      int            `json:"infraction_type_id,omitzero"`      // Jenis pelanggaran (kosong jika jenisnya sudah dihapus)
+	InfractionName        string         `json:"infraction_name"`                  // Nama pelanggaran saat diterapkan
+	Points                int            `json:"points"`                           // Poin sanksi sesuai jenis pelanggaran
+	PointsDeducted        int            `json:"points_deducted"`                  // Poin yang benar-benar dipotong (dibatasi saldo anak)
+	Notes                 string         `json:"notes,omitempty"`                  // Catatan parent (opsional)
+	AppliedByUserID       int            `json:"applied_by_user_id,omitzero"`      // Parent yang menerapkan sanksi
+	TransactionID         int            `json:"transaction_id,omitzero"`          // Entri ledger 'penalty'
+	EarnBackUserTaskID    int            `json:"earn_back_user_task_id,omitzero"`  // Penugasan tugas penebus (opsional)
+	EarnBackTaskStatus    UserTaskStatus `json:"earn_back_task_status,omitempty"`  // Status tugas penebus (join)
+	EarnedBackAt          *time.Time     `json:"earned_back_at,omitzero"`          // Waktu tugas penebus disetujui (nullable)
+	ReversalTransactionID int            `json:"reversal_transaction_id,omitzero"` // Entri ledger 'penalty_reversal'
+	CreatedAt             time.Time      `json:"created_at,omitzero"`              // Waktu sanksi diterapkan
+	UpdatedAt             time.Time      `json:"updated_at,omitzero"`              // Waktu terakhir pembaruan record
+}
+
+// PenaltyReportPeriod adalah ringkasan sanksi seorang anak dalam satu periode laporan.
+type PenaltyReportPeriod struct {
+	PeriodStart      time.Time `json:"period_start"`       // Awal periode di zona waktu anak
+	Count            int       `json:"count"`              // Jumlah sanksi
+	PointsDeducted   int       `json:"points_deducted"`    // Total poin yang dipotong
+	PointsEarnedBack int       `json:"points_earned_back"` // Total poin yang dikembalikan lewat tugas penebus
+}
+
+// PenaltyInfractionTotal adalah total sanksi per jenis pelanggaran dalam rentang laporan.
+type PenaltyInfractionTotal struct {
+	InfractionTypeID int    `json:"infraction_type_id,omitzero"` // Jenis pelanggaran (kosong jika sudah dihapus)
+	InfractionName   string `json:"infraction_name"`             // Nama pelanggaran
+	Count            int    `json:"count"`                       // Jumlah sanksi
+	PointsDeducted   int    `json:"points_deducted"`             // Total poin yang dipotong
+}
+
+// PenaltyReport adalah laporan sanksi seorang anak dari waktu ke waktu.
+type PenaltyReport struct {
+	ChildID               int                      `json:"child_id"`                 // Foreign key ke User (Anak)
+	From                  string                   `json:"from"`                     // Tanggal awal (YYYY-MM-DD, inklusif)
+	To                    string                   `json:"to"`                       // Tanggal akhir (YYYY-MM-DD, inklusif)
+	Timezone              string                   `json:"timezone"`                 // Zona waktu yang dipakai
+	GroupBy               PenaltyReportGroupBy     `json:"group_by"`                 // Pengelompokan periode
+	TotalCount            int                      `json:"total_count"`              // Jumlah sanksi dalam rentang
+	TotalPointsDeducted   int                      `json:"total_points_deducted"`    // Total poin yang dipotong
+	TotalPointsEarnedBack int                      `json:"total_points_earned_back"` // Total poin yang dikembalikan
+	Periods               []PenaltyReportPeriod    `json:"periods"`                  // Ringkasan per periode (termasuk periode tanpa sanksi)
+	ByInfraction          []PenaltyInfractionTotal `json:"by_infraction"`            // Total per jenis pelanggaran (terbanyak dulu)
+}
+
+// PointTransfer merepresentasikan transfer poin dari satu anak ke saudaranya.
+type PointTransfer struct {
+	ID                  int                 `json:"id"`                             // ID unik transfer
+	FromChildID         int                 `json:"from_child_id"`                  // Foreign key ke User (Anak pengirim)
+	ToChildID           int                 `json:"to_child_id"`                    // Foreign key ke User (Anak penerima)
+	FromUsername        string              `json:"from_username,omitempty"`        // Username pengirim (join)
+	ToUsername          string              `json:"to_username,omitempty"`          // Username penerima (join)
+	Amount              int                 `json:"amount"`                         // Jumlah poin yang ditransfer
+	Note                string              `json:"note,omitempty"`                 // Pesan dari pengirim (misal: "Selamat ulang tahun!")
+	Status              PointTransferStatus `json:"status"`                         // Status transfer
+	ReviewedByUserID    int                 `json:"reviewed_by_user_id,omitzero"`   // Parent yang menyetujui/menolak (0 jika otomatis)
+	ReviewedAt          *time.Time          `json:"reviewed_at,omitzero"`           // Waktu persetujuan/penolakan (nullable)
+	ReviewNote          string              `json:"review_note,omitempty"`          // Alasan penolakan (opsional)
+	DebitTransactionID  int                 `json:"debit_transaction_id,omitzero"`  // Entri ledger pengirim (negatif)
+	CreditTransactionID int                 `json:"credit_transaction_id,omitzero"` // Entri ledger penerima (positif)
+	CreatedAt           time.Time           `json:"created_at,omitzero"`            // Waktu pembuatan record
+	UpdatedAt           time.Time           `json:"updated_at,omitzero"`            // Waktu terakhir pembaruan record
+}
+
+// AllowancePayout adalah hasil pemrosesan uang saku untuk satu periode.
+type AllowancePayout struct {
+	ID                 int                   `json:"id"`                            // ID unik pembayaran
+	PlanID             int                   `json:"plan_id,omitzero"`              // Foreign key ke AllowancePlan (NULL jika rencana dihapus)
+	ChildID            int                   `json:"child_id"`                      // Foreign key ke User (Anak)
+	PeriodStart        time.Time             `json:"period_start"`                  // Awal periode (tanggal lokal)
+	PeriodEnd          time.Time             `json:"period_end"`                    // Akhir periode (eksklusif)
+	Amount             int                   `json:"amount"`                        // Poin yang dibayarkan (0 jika dilewati)
+	Status             AllowancePayoutStatus `json:"status"`                        // 'paid' atau 'skipped'
+	ApprovedTasks      int                   `json:"approved_tasks"`                // Jumlah tugas disetujui dalam periode
+	Reason             string                `json:"reason,omitempty"`              // Alasan periode dilewati
+	PointTransactionID int                   `json:"point_transaction_id,omitzero"` // Transaksi 'allowance' terkait
+	CreatedAt          time.Time             `json:"created_at,omitzero"`           // Waktu pemrosesan
+}
+
+// PointLot adalah sisa poin dari satu transaksi pemasukan setelah pemakaian dihitung FIFO (lot tertua dipakai dulu).
+type PointLot struct {
+	TransactionID int        `json:"transaction_id"`       // ID transaksi pemasukan asal lot
+	Amount        int        `json:"amount"`               // Jumlah poin awal lot
+	Remaining     int        `json:"remaining"`            // Sisa poin lot yang belum terpakai
+	EarnedAt      time.Time  `json:"earned_at"`            // Waktu poin didapat
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"` // Waktu poin kedaluwarsa (jika ada kebijakan)
+}
+
+// ExpiringPointsSummary merangkum poin anak yang akan kedaluwarsa dalam masa peringatan.
+type ExpiringPointsSummary struct {
+	Policy         *PointExpirationPolicy `json:"policy,omitempty"` // Kebijakan aktif (nil jika poin tidak kedaluwarsa)
+	ExpiringPoints int                    `json:"expiring_points"`  // Total poin yang akan kedaluwarsa dalam masa peringatan
+	Lots           []PointLot             `json:"lots"`             // Lot yang akan kedaluwarsa, terdekat lebih dulu
+}
+
+// UserRewardVote mencatat suara satu orang tua untuk klaim yang membutuhkan persetujuan bersama.
+type UserRewardVote struct {
+	ID           int              `json:"id"`                  // ID unik suara
+	UserRewardID int              `json:"user_reward_id"`      // Foreign key ke UserReward
+	ParentID     int              `json:"parent_id"`           // Orang tua yang memberi suara
+	Vote         UserRewardStatus `json:"vote"`                // 'approved' atau 'rejected'
+	CreatedAt    time.Time        `json:"created_at,omitzero"` // Waktu suara diberikan
+}
+
+// ClaimReviewResult adalah hasil review klaim. Jika persetujuan bersama belum lengkap,
+// Status tetap 'pending' dan Approvals/RequiredApprovals menunjukkan progres kuorum.
+type ClaimReviewResult struct {
+	ClaimID           int              `json:"claim_id"`                    // ID klaim yang direview
+	Status            UserRewardStatus `json:"status"`                      // Status klaim setelah review
+	Approvals         int              `json:"approvals,omitzero"`          // Jumlah persetujuan yang sudah masuk
+	RequiredApprovals int              `json:"required_approvals,omitzero"` // Jumlah persetujuan yang dibutuhkan (0 = tanpa kuorum)
 }
 
 // UserReward merepresentasikan hadiah yang telah diklaim oleh seorang anak.
 type UserReward struct {
-	ID               int              `json:"id"`                                                         // ID unik klaim hadiah
-	UserID           int              `json:"user_id" validate:"required,gt=0"`                           // Foreign key ke User (Anak yang klaim)
-	RewardID         int              `json:"reward_id" validate:"required,gt=0"`                         // Foreign key ke Reward (Definisi hadiah)
-	PointsDeducted   int              `json:"points_deducted" validate:"required,gte=0"`                  // Jumlah poin yang dikurangi saat klaim
-	ClaimedAt        time.Time        `json:"claimed_at,omitzero"`                                        // Waktu klaim dibuat
-	Status           UserRewardStatus `json:"status" validate:"required,oneof=pending approved rejected"` // Status klaim saat ini
-	ReviewedByUserID int              `json:"reviewed_by_user_id,omitzero" validate:"omitempty,gt=0"`     // Foreign key ke User (Parent yang review) (nullable)
-	ReviewedAt       *time.Time       `json:"reviewed_at,omitzero"`                                       // Waktu review oleh parent (nullable)
-	Reward           *Reward          `json:"reward,omitempty"`                                           // Relasi ke Reward (bisa di-preload)
-	User             *User            `json:"user,omitempty"`                                             // Relasi ke User (Anak) (bisa di-preload)
-	CreatedAt        time.Time        `json:"created_at,omitzero"`                                        // Waktu pembuatan record
-	UpdatedAt        time.Time        `json:"updated_at,omitzero"`                                        // Waktu terakhir pembaruan record
+	ID               int              `json:"id"`                                                                             // ID unik klaim hadiah
+	UserID           int              `json:"user_id" validate:"required,gt=0"`                                               // Foreign key ke User (Anak yang klaim)
+	RewardID         int              `json:"reward_id" validate:"required,gt=0"`                                             // Foreign key ke Reward (Definisi hadiah)
+	PointsDeducted   int              `json:"points_deducted" validate:"required,gte=0"`                                      // Jumlah poin yang dikurangi saat klaim
+	ClaimedAt        time.Time        `json:"claimed_at,omitzero"`                                                            // Waktu klaim dibuat
+	Status           UserRewardStatus `json:"status" validate:"required,oneof=pending approved rejected scheduled fulfilled"` // Status klaim saat ini
+	ReviewedByUserID int              `json:"reviewed_by_user_id,omitzero" validate:"omitempty,gt=0"`                         // Foreign key ke User (Parent yang review) (nullable)
+	ReviewedAt       *time.Time       `json:"reviewed_at,omitzero"`                                                           // Waktu review oleh parent (nullable)
+	ScheduledFor     *time.Time       `json:"scheduled_for,omitzero"`                                                         // Rencana tanggal penyerahan hadiah (nullable)
+	FulfilledAt      *time.Time       `json:"fulfilled_at,omitzero"`                                                          // Waktu hadiah diserahkan (nullable)
+	ReceivedAt       *time.Time       `json:"received_at,omitzero"`                                                           // Waktu anak mengonfirmasi sudah menerima (nullable)
+	Reward           *Reward          `json:"reward,omitempty"`                                                               // Relasi ke Reward (bisa di-preload)
+	User             *User            `json:"user,omitempty"`                                                                 // Relasi ke User (Anak) (bisa di-preload)
+	CreatedAt        time.Time        `json:"created_at,omitzero"`                                                            // Waktu pembuatan record
+	UpdatedAt        time.Time        `json:"updated_at,omitzero"`                                                            // Waktu terakhir pembaruan record
 }
 
 // PointTransaction merepresentasikan catatan perubahan poin seorang anak.
 type PointTransaction struct {
-	ID                  int             `json:"id"`                                                                                             // ID unik transaksi poin
-	UserID              int             `json:"user_id" validate:"required,gt=0"`                                                               // Foreign key ke User (Anak yang poinnya berubah)
-	ChangeAmount        int             `json:"change_amount" validate:"required"`                                                              // Jumlah perubahan poin (+/-)
-	TransactionType     TransactionType `json:"transaction_type" validate:"required,oneof=task_completion reward_redemption manual_adjustment"` // Jenis transaksi penyebab perubahan poin
-	RelatedUserTaskID   int             `json:"related_user_task_id,omitzero" validate:"omitempty,gt=0"`                                        // Foreign key ke UserTask (jika terkait penyelesaian tugas) (nullable)
-	RelatedUserRewardID int             `json:"related_user_reward_id,omitzero" validate:"omitempty,gt=0"`                                      // Foreign key ke UserReward (jika terkait klaim hadiah) (nullable)
-	CreatedByUserID     int             `json:"created_by_user_id" validate:"required,gt=0"`                                                    // Foreign key ke User (yang menyebabkan transaksi, misal Parent verifikasi, Anak klaim, Admin adjust)
-	Notes               string          `json:"notes,omitempty"`                                                                                // Catatan tambahan (misal: alasan manual adjustment)
-	User                *User           `json:"user,omitempty"`                                                                                 // Relasi ke User (Anak) (bisa di-preload)
-	UserTask            *UserTask       `json:"user_task,omitempty"`                                                                            // Relasi ke UserTask (bisa di-preload)
-	UserReward          *UserReward     `json:"user_reward,omitempty"`                                                                          // Relasi ke UserReward (bisa di-preload)
-	CreatedAt           time.Time       `json:"created_at,omitzero"`                                                                            // Waktu pembuatan record
-	UpdatedAt           time.Time       `json:"updated_at,omitzero"`                                                                            // Waktu terakhir pembaruan record
+	ID                    int             `json:"id"`                                                                                                                                                                                                                                                                 // ID unik transaksi poin
+	UserID                int             `json:"user_id" validate:"required,gt=0"`                                                                                                                                                                                                                                   // Foreign key ke User (Anak yang poinnya berubah)
+	ChangeAmount          int             `json:"change_amount" validate:"required"`                                                                                                                                                                                                                                  // Jumlah perubahan poin (+/-)
+	TransactionType       TransactionType `json:"transaction_type" validate:"required,oneof=task_completion reward_redemption manual_adjustment reward_refund task_reversal penalty allowance transfer expiration interest exchange cash_out cash_out_refund correction penalty_reversal streak_bonus streak_freeze"` // Jenis transaksi penyebab perubahan poin
+	RelatedUserTaskID     int             `json:"related_user_task_id,omitzero" validate:"omitempty,gt=0"`                                                                                                                                                                                                            // Foreign key ke UserTask (jika terkait penyelesaian tugas) (nullable)
+	RelatedUserRewardID   int             `json:"related_user_reward_id,omitzero" validate:"omitempty,gt=0"`                                                                                                                                                                                                          // Foreign key ke UserReward (jika terkait klaim hadiah) (nullable)
+	ReversesTransactionID int             `json:"reverses_transaction_id,omitzero"`                                                                                                                                                                                                                                   // Foreign key ke PointTransaction asli yang dibalik (wajib untuk jenis pembalik, lihat IsReversal) (nullable)
+	CurrencyID            int             `json:"currency_id,omitzero"`                                                                                                                                                                                                                                               // Mata uang entri (0/null = poin)
+	RelatedTransferID     int             `json:"related_transfer_id,omitzero"`                                                                                                                                                                                                                                       // Foreign key ke PointTransfer (untuk entri 'transfer') (nullable)
+	CreatedByUserID       int             `json:"created_by_user_id" validate:"required,gt=0"`                                                                                                                                                                                                                        // Foreign key ke User (yang menyebabkan transaksi, misal Parent verifikasi, Anak klaim, Admin adjust)
+	Notes                 string          `json:"notes,omitempty"`                                                                                                                                                                                                                                                    // Catatan tambahan (misal: alasan manual adjustment)
+	User                  *User           `json:"user,omitempty"`                                                                                                                                                                                                                                                     // Relasi ke User (Anak) (bisa di-preload)
+	UserTask              *UserTask       `json:"user_task,omitempty"`                                                                                                                                                                                                                                                // Relasi ke UserTask (bisa di-preload)
+	UserReward            *UserReward     `json:"user_reward,omitempty"`                                                                                                                                                                                                                                              // Relasi ke UserReward (bisa di-preload)
+	CreatedAt             time.Time       `json:"created_at,omitzero"`                                                                                                                                                                                                                                                // Waktu pembuatan record
+	UpdatedAt             time.Time       `json:"updated_at,omitzero"`                                                                                                                                                                                                                                                // Waktu terakhir pembaruan record
+}
+
+// PointBalanceDrift merepresentasikan selisih antara saldo tersimpan (point_balances/currency_balances) dan total ledger.
+type PointBalanceDrift struct {
+	UserID        int  `json:"user_id"`              // ID anak
+	CurrencyID    int  `json:"currency_id,omitzero"` // Mata uang (0 = poin)
+	StoredBalance int  `json:"stored_balance"`       // Saldo di tabel point_balances
+	LedgerBalance int  `json:"ledger_balance"`       // Total change_amount di point_transactions
+	Drift         int  `json:"drift"`                // StoredBalance - LedgerBalance
+	Fixed         bool `json:"fixed"`                // True jika saldo tersimpan sudah disamakan dengan ledger
 }
 
 // InvitationCode merepresentasikan data kode undangan di database.
@@ -135,6 +563,168 @@ type InvitationCode struct {
 	// Creator *User `json:"creator,omitempty"`
 }
 
+// TaskRotation merepresentasikan grup rotasi tugas yang bergiliran di antara beberapa anak.
+type TaskRotation struct {
+	ID              int                  `json:"id"`                                             // ID unik rotasi
+	TaskID          int                  `json:"task_id" validate:"required,gt=0"`               // Foreign key ke Task (Definisi tugas yang dirotasi)
+	CreatedByUserID int                  `json:"created_by_user_id" validate:"required,gt=0"`    // Foreign key ke User (Parent yang membuat)
+	Cadence         RotationCadence      `json:"cadence" validate:"required,oneof=daily weekly"` // Irama perpindahan giliran
+	CurrentPosition int                  `json:"current_position"`                               // Posisi anggota yang mendapat giliran berikutnya
+	NextRotationAt  time.Time            `json:"next_rotation_at"`                               // Waktu giliran berikutnya diberikan
+	IsActive        bool                 `json:"is_active"`                                      // Rotasi nonaktif tidak diproses scheduler
+	Task            *Task                `json:"task,omitempty"`                                 // Relasi ke Task (bisa di-preload)
+	Members         []TaskRotationMember `json:"members,omitempty"`                              // Anggota rotasi, terurut berdasarkan posisi
+	CreatedAt       time.Time            `json:"created_at,omitzero"`                            // Waktu pembuatan record
+	UpdatedAt       time.Time            `json:"updated_at,omitzero"`                            // Waktu terakhir pembaruan record
+}
+
+// TaskRotationMember merepresentasikan seorang anak beserta urutannya dalam rotasi.
+type TaskRotationMember struct {
+	ChildID  int    `json:"child_id"`           // Foreign key ke User (Anak)
+	Username string `json:"username,omitempty"` // Username anak (untuk tampilan)
+	Position int    `json:"position"`           // Urutan giliran (dimulai dari 0)
+}
+
+// TaskRotationTurn merepresentasikan satu giliran dalam riwayat rotasi.
+type TaskRotationTurn struct {
+	ID              int                `json:"id"`                          // ID unik giliran
+	RotationID      int                `json:"rotation_id"`                 // Foreign key ke TaskRotation
+	ChildID         int                `json:"child_id"`                    // Foreign key ke User (Anak yang mendapat giliran)
+	ChildUsername   string             `json:"child_username,omitempty"`    // Username anak (untuk tampilan)
+	UserTaskID      int                `json:"user_task_id,omitzero"`       // Foreign key ke UserTask yang dibuat (nullable)
+	Status          RotationTurnStatus `json:"status"`                      // Status giliran ('assigned' atau 'skipped')
+	Notes           string             `json:"notes,omitempty"`             // Catatan (misal: alasan dilewati)
+	CreatedByUserID int                `json:"created_by_user_id,omitzero"` // Parent yang memicu giliran (0 = scheduler otomatis)
+	CreatedAt       time.Time          `json:"created_at,omitzero"`         // Waktu giliran dicatat
+}
+
+// TaskBounty merepresentasikan tugas terbuka (bounty) yang bisa diklaim oleh anak mana pun dalam keluarga.
+type TaskBounty struct {
+	ID              int               `json:"id"`                                  // ID unik bounty
+	TaskID          int               `json:"task_id" validate:"required,gt=0"`    // Foreign key ke Task (Definisi tugas yang ditawarkan)
+	CreatedByUserID int               `json:"created_by_user_id"`                  // Foreign key ke User (Parent yang memposting)
+	MaxClaims       int               `json:"max_claims" validate:"required,gt=0"` // Jumlah anak maksimum yang boleh mengklaim
+	ClaimsCount     int               `json:"claims_count"`                        // Jumlah klaim yang sudah terjadi
+	Status          BountyStatus      `json:"status"`                              // Status bounty saat ini
+	ExpiresAt       *time.Time        `json:"expires_at,omitzero"`                 // Batas waktu klaim (nullable)
+	Task            *Task             `json:"task,omitempty"`                      // Relasi ke Task (bisa di-preload)
+	Claims          []TaskBountyClaim `json:"claims,omitempty"`                    // Daftar klaim (hanya untuk tampilan Parent)
+	CreatedAt       time.Time         `json:"created_at,omitzero"`                 // Waktu pembuatan record
+	UpdatedAt       time.Time         `json:"updated_at,omitzero"`                 // Waktu terakhir pembaruan record
+}
+
+// TaskBountyClaim merepresentasikan klaim seorang anak atas sebuah bounty.
+type TaskBountyClaim struct {
+	ID            int       `json:"id"`                       // ID unik klaim
+	BountyID      int       `json:"bounty_id"`                // Foreign key ke TaskBounty
+	ChildID       int       `json:"child_id"`                 // Foreign key ke User (Anak yang mengklaim)
+	ChildUsername string    `json:"child_username,omitempty"` // Username anak (untuk tampilan)
+	UserTaskID    int       `json:"user_task_id,omitzero"`    // Foreign key ke UserTask hasil klaim (nullable)
+	ClaimedAt     time.Time `json:"claimed_at,omitzero"`      // Waktu klaim
+}
+
+// SavingsGoal merepresentasikan target tabungan (wishlist) anak: Reward yang disematkan atau target kustom.
+type SavingsGoal struct {
+	ID                  int                  `json:"id"`                              // ID unik target
+	ChildID             int                  `json:"child_id"`                        // Foreign key ke User (Anak pemilik target)
+	RewardID            int                  `json:"reward_id,omitzero"`              // Foreign key ke Reward yang disematkan (0 = target kustom)
+	GoalName            string               `json:"goal_name"`                       // Nama target (nama Reward jika disematkan)
+	TargetPoints        int                  `json:"target_points"`                   // Jumlah poin yang ingin dicapai
+	EarmarkedPoints     int                  `json:"earmarked_points"`                // Poin yang disisihkan khusus untuk target ini
+	TotalContributed    int                  `json:"total_contributed,omitzero"`      // Total bonus poin dari parent untuk target ini
+	AutoClaim           bool                 `json:"auto_claim"`                      // Klaim Reward otomatis saat target tercapai
+	Status              SavingsGoalStatus    `json:"status"`                          // Status target saat ini
+	ReachedAt           *time.Time           `json:"reached_at,omitzero"`             // Waktu target tercapai (nullable)
+	ClaimedUserRewardID int                  `json:"claimed_user_reward_id,omitzero"` // Klaim hadiah yang menutup target (nullable)
+	Reward              *Reward              `json:"reward,omitempty"`                // Relasi ke Reward (bisa di-preload)
+	Progress            *SavingsGoalProgress `json:"progress,omitempty"`              // Progres dihitung dari saldo poin (bukan kolom DB)
+	CreatedAt           time.Time            `json:"created_at,omitzero"`             // Waktu pembuatan record
+	UpdatedAt           time.Time            `json:"updated_at,omitzero"`             // Waktu terakhir pembaruan record
+}
+
+// SavingsGoalContribution merepresentasikan bonus poin dari parent untuk sebuah target tabungan.
+type SavingsGoalContribution struct {
+	ID                  int       `json:"id"`                              // ID unik kontribusi
+	GoalID              int       `json:"goal_id"`                         // Foreign key ke SavingsGoal
+	ContributedByUserID int       `json:"contributed_by_user_id,omitzero"` // Parent pemberi kontribusi
+	Points              int       `json:"points"`                          // Jumlah bonus poin
+	Note                string    `json:"note,omitempty"`                  // Catatan (opsional)
+	CreatedAt           time.Time `json:"created_at,omitzero"`             // Waktu kontribusi
+}
+
+// SavingsOverview merangkum saldo anak beserta daftar target tabungannya.
+type SavingsOverview struct {
+	Balance         int           `json:"balance"`          // Total poin dari ledger
+	EarmarkedPoints int           `json:"earmarked_points"` // Poin yang disisihkan untuk target terbuka
+	SpendablePoints int           `json:"spendable_points"` // Poin yang masih bisa dipakai untuk klaim lain
+	Goals           []SavingsGoal `json:"goals"`            // Daftar target (dengan progres)
+}
+
+// Notification merepresentasikan notifikasi in-app untuk seorang pengguna.
+type Notification struct {
+	ID         int64            `json:"id"`                    // ID unik notifikasi
+	UserID     int              `json:"user_id"`               // Foreign key ke User penerima
+	Type       NotificationType `json:"type"`                  // Kode jenis notifikasi
+	Title      string           `json:"title"`                 // Judul singkat
+	Message    string           `json:"message"`               // Isi notifikasi
+	EntityType string           `json:"entity_type,omitempty"` // Entitas terkait (opsional, misal: 'savings_goal')
+	EntityID   int              `json:"entity_id,omitzero"`    // ID entitas terkait (opsional)
+	ReadAt     *time.Time       `json:"read_at,omitzero"`      // Waktu dibaca (nil = belum dibaca)
+	CreatedAt  time.Time        `json:"created_at,omitzero"`   // Waktu notifikasi dibuat
+}
+
+// DefinitionTemplate merepresentasikan template tugas/hadiah dari katalog sistem yang dikelola Admin.
+type DefinitionTemplate struct {
+	ID              int                             `json:"id"`                          // ID unik template
+	Kind            TemplateKind                    `json:"kind"`                        // Jenis template (task / reward)
+	Category        DefinitionCategory              `json:"category,omitempty"`          // Kategori (opsional)
+	Tags            []string                        `json:"tags,omitempty"`              // Tag bebas
+	SuggestedPoints int                             `json:"suggested_points"`            // Poin yang disarankan
+	MinAge          *int                            `json:"min_age,omitempty"`           // Usia minimum yang disarankan (nullable)
+	MaxAge          *int                            `json:"max_age,omitempty"`           // Usia maksimum yang disarankan (nullable)
+	IsActive        bool                            `json:"is_active"`                   // Template nonaktif tidak tampil untuk Parent
+	Version         int                             `json:"version"`                     // Naik setiap kali template diperbarui
+	Locale          string                          `json:"locale,omitempty"`            // Bahasa dari Name/Description yang ditampilkan
+	Name            string                          `json:"name,omitempty"`              // Nama sesuai locale yang diminta
+	Description     string                          `json:"description,omitempty"`       // Deskripsi sesuai locale yang diminta
+	Translations    []DefinitionTemplateTranslation `json:"translations,omitempty"`      // Semua terjemahan (detail / tampilan Admin)
+	CreatedByUserID int                             `json:"created_by_user_id,omitzero"` // Admin pembuat (nullable untuk template bawaan)
+	CreatedAt       time.Time                       `json:"created_at,omitzero"`         // Waktu pembuatan record
+	UpdatedAt       time.Time                       `json:"updated_at,omitzero"`         // Waktu terakhir pembaruan record
+}
+
+// DefinitionTemplateTranslation menyimpan nama & deskripsi template untuk satu bahasa.
+type DefinitionTemplateTranslation struct {
+	Locale      string `json:"locale" validate:"required,min=2,max=10"`   // Kode bahasa, misal: "en", "id"
+	Name        string `json:"name" validate:"required,min=3,max=255"`    // Nama template dalam bahasa ini
+	Description string `json:"description,omitempty" validate:"max=1000"` // Deskripsi template dalam bahasa ini
+}
+
+// AutoApprovalPolicy merepresentasikan kebijakan persetujuan otomatis untuk submission tugas.
+// Kebijakan berlaku untuk satu tugas, satu anak, atau kombinasi keduanya.
+type AutoApprovalPolicy struct {
+	ID                 int       `json:"id"`                   // ID unik kebijakan
+	CreatedByUserID    int       `json:"created_by_user_id"`   // Parent pemilik kebijakan
+	TaskID             int       `json:"task_id,omitzero"`     // Definisi tugas (0/NULL = semua tugas)
+	ChildID            int       `json:"child_id,omitzero"`    // Anak (0/NULL = semua anak Parent)
+	GracePeriodMinutes int       `json:"grace_period_minutes"` // Masa tenggang sebelum disetujui (0 = langsung)
+	IsActive           bool      `json:"is_active"`            // Kebijakan nonaktif diabaikan
+	CreatedAt          time.Time `json:"created_at,omitzero"`  // Waktu pembuatan record
+	UpdatedAt          time.Time `json:"updated_at,omitzero"`  // Waktu terakhir pembaruan record
+}
+
+// AuditLog merepresentasikan satu catatan jejak audit atas tindakan pengguna atau sistem.
+type AuditLog struct {
+	ID          int64          `json:"id"`                     // ID unik catatan audit
+	ActorUserID int            `json:"actor_user_id,omitzero"` // Pengguna pelaku (0/NULL = sistem)
+	ActorType   AuditActorType `json:"actor_type"`             // user / system
+	Action      string         `json:"action"`                 // Tindakan, misal: "user_task.approved"
+	EntityType  string         `json:"entity_type"`            // Jenis entitas, misal: "user_task"
+	EntityID    int            `json:"entity_id"`              // ID entitas terkait
+	Details     map[string]any `json:"details,omitempty"`      // Detail tambahan (JSON)
+	CreatedAt   time.Time      `json:"created_at,omitzero"`    // Waktu tindakan
+}
+
 // ====================================================================================
 // Enumerations (Tipe Data Konstanta)
 // ====================================================================================
@@ -147,15 +737,19 @@ const (
 	UserTaskStatusSubmitted UserTaskStatus = "submitted" // Anak telah menandai tugas sebagai selesai
 	UserTaskStatusApproved  UserTaskStatus = "approved"  // Parent telah menyetujui tugas yang disubmit
 	UserTaskStatusRejected  UserTaskStatus = "rejected"  // Parent telah menolak tugas yang disubmit
+	UserTaskStatusCancelled UserTaskStatus = "cancelled" // Parent membatalkan penugasan (unassign)
+	UserTaskStatusExpired   UserTaskStatus = "expired"   // Batas waktu terlewati sebelum anak submit
 )
 
 // UserRewardStatus mendefinisikan status yang mungkin untuk sebuah UserReward (klaim hadiah).
 type UserRewardStatus string
 
 const (
-	UserRewardStatusPending  UserRewardStatus = "pending"  // Klaim hadiah baru dibuat oleh anak, menunggu review parent
-	UserRewardStatusApproved UserRewardStatus = "approved" // Parent telah menyetujui klaim hadiah
-	UserRewardStatusRejected UserRewardStatus = "rejected" // Parent telah menolak klaim hadiah
+	UserRewardStatusPending   UserRewardStatus = "pending"   // Klaim hadiah baru dibuat oleh anak, menunggu review parent
+	UserRewardStatusApproved  UserRewardStatus = "approved"  // Parent telah menyetujui klaim hadiah
+	UserRewardStatusRejected  UserRewardStatus = "rejected"  // Parent telah menolak klaim hadiah
+	UserRewardStatusScheduled UserRewardStatus = "scheduled" // Penyerahan hadiah sudah dijadwalkan parent
+	UserRewardStatusFulfilled UserRewardStatus = "fulfilled" // Hadiah sudah diserahkan ke anak
 )
 
 // TransactionType mendefinisikan jenis transaksi yang menyebabkan perubahan poin.
@@ -165,8 +759,29 @@ const (
 	TransactionTypeCompletion       TransactionType = "task_completion"   // Poin didapat dari menyelesaikan tugas
 	TransactionTypeRedemption       TransactionType = "reward_redemption" // Poin dikurangi karena klaim hadiah
 	TransactionTypeManualAdjustment TransactionType = "manual_adjustment" // Poin diubah manual oleh Parent/Admin
+	TransactionTypeRewardRefund     TransactionType = "reward_refund"     // Poin dikembalikan karena klaim hadiah ditolak
+	TransactionTypeTaskReversal     TransactionType = "task_reversal"     // Poin ditarik karena verifikasi tugas dibatalkan
+	TransactionTypePenalty          TransactionType = "penalty"           // Poin dikurangi sebagai sanksi
+	TransactionTypeAllowance        TransactionType = "allowance"         // Poin dari uang saku berkala
+	TransactionTypeTransfer         TransactionType = "transfer"          // Poin dipindahkan antar saudara
+	TransactionTypeExpiration       TransactionType = "expiration"        // Poin hangus karena melewati masa berlaku
+	TransactionTypeInterest         TransactionType = "interest"          // Bunga tabungan atas saldo poin
+	TransactionTypeExchange         TransactionType = "exchange"          // Penukaran antar mata uang sesuai aturan tukar
+	TransactionTypeCashOut          TransactionType = "cash_out"          // Poin dicairkan menjadi uang saku
+	TransactionTypeCashOutRefund    TransactionType = "cash_out_refund"   // Poin dikembalikan karena pencairan ditolak
+	TransactionTypeCorrection       TransactionType = "correction"        // Koreksi Admin yang membalik entri ledger yang salah
+	TransactionTypePenaltyReversal  TransactionType = "penalty_reversal"  // Poin sanksi dikembalikan karena tugas penebus disetujui
+	TransactionTypeStreakBonus      TransactionType = "streak_bonus"      // Bonus karena mencapai streak sesuai aturan parent
+	TransactionTypeStreakFreeze     TransactionType = "streak_freeze"     // Poin dikurangi untuk membeli streak freeze
 )
 
+// IsReversal mengembalikan true jika jenis transaksi membalik transaksi lain,
+// sehingga wajib mengisi ReversesTransactionID.
+func (t TransactionType) IsReversal() bool {
+	return t == TransactionTypeRewardRefund || t == TransactionTypeTaskReversal || t == TransactionTypeCashOutRefund ||
+		t == TransactionTypeCorrection || t == TransactionTypePenaltyReversal
+}
+
 // InvitationStatus mendefinisikan status yang mungkin untuk kode undangan.
 type InvitationStatus string
 
@@ -176,6 +791,114 @@ const (
 	InvitationStatusExpired InvitationStatus = "expired" // Kode sudah melewati batas waktu penggunaan
 )
 
+// RotationCadence mendefinisikan seberapa sering giliran rotasi berpindah.
+type RotationCadence string
+
+const (
+	RotationCadenceDaily  RotationCadence = "daily"  // Giliran berpindah setiap hari
+	RotationCadenceWeekly RotationCadence = "weekly" // Giliran berpindah setiap minggu
+)
+
+// Interval mengembalikan durasi antar giliran untuk cadence ini.
+func (c RotationCadence) Interval() time.Duration {
+	if c == RotationCadenceWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// RotationTurnStatus mendefinisikan status yang mungkin untuk satu giliran rotasi.
+type RotationTurnStatus string
+
+const (
+	RotationTurnStatusAssigned RotationTurnStatus = "assigned" // Anak menerima penugasan pada giliran ini
+	RotationTurnStatusSkipped  RotationTurnStatus = "skipped"  // Giliran anak dilewati
+)
+
+// BountyStatus mendefinisikan status yang mungkin untuk sebuah TaskBounty.
+type BountyStatus string
+
+const (
+	BountyStatusOpen      BountyStatus = "open"      // Bounty masih bisa diklaim
+	BountyStatusFilled    BountyStatus = "filled"    // Batas klaim sudah tercapai
+	BountyStatusCancelled BountyStatus = "cancelled" // Bounty dibatalkan oleh Parent
+)
+
+// PointTransferStatus mendefinisikan status transfer poin antar saudara.
+type PointTransferStatus string
+
+const (
+	PointTransferStatusPending   PointTransferStatus = "pending"   // Menunggu persetujuan orang tua
+	PointTransferStatusCompleted PointTransferStatus = "completed" // Poin sudah dipindahkan
+	PointTransferStatusRejected  PointTransferStatus = "rejected"  // Ditolak orang tua
+	PointTransferStatusCancelled PointTransferStatus = "cancelled" // Dibatalkan pengirim sebelum disetujui
+)
+
+// SavingsGoalStatus mendefinisikan status yang mungkin untuk sebuah SavingsGoal.
+type SavingsGoalStatus string
+
+const (
+	SavingsGoalStatusActive    SavingsGoalStatus = "active"    // Anak masih menabung untuk target ini
+	SavingsGoalStatusReached   SavingsGoalStatus = "reached"   // Target tercapai (notifikasi sudah dikirim)
+	SavingsGoalStatusClaimed   SavingsGoalStatus = "claimed"   // Reward yang disematkan sudah diklaim
+	SavingsGoalStatusCancelled SavingsGoalStatus = "cancelled" // Target dibatalkan anak (poin yang disisihkan dilepas)
+)
+
+// NotificationType mendefinisikan jenis notifikasi in-app.
+type NotificationType string
+
+const (
+	NotificationSavingsGoalReached         NotificationType = "savings_goal_reached"           // Target tabungan tercapai
+	NotificationSavingsGoalContribution    NotificationType = "savings_goal_contribution"      // Parent menambah bonus poin ke target
+	NotificationSavingsGoalAutoClaimFailed NotificationType = "savings_goal_auto_claim_failed" // Klaim otomatis gagal (misal: stok habis)
+	NotificationPointsExpiring             NotificationType = "points_expiring"                // Sebagian poin akan segera kedaluwarsa
+	NotificationPointsExpired              NotificationType = "points_expired"                 // Poin sudah kedaluwarsa dan dikurangi dari saldo
+	NotificationInterestPosted             NotificationType = "interest_posted"                // Bunga tabungan periode lalu ditambahkan ke saldo
+	NotificationAllowancePaid              NotificationType = "allowance_paid"                 // Uang saku periode lalu dibayarkan
+	NotificationAllowanceSkipped           NotificationType = "allowance_skipped"              // Uang saku periode lalu tidak dibayarkan (syarat tidak terpenuhi)
+	NotificationTransferRequested          NotificationType = "transfer_requested"             // Transfer poin antar saudara menunggu persetujuan
+	NotificationTransferReceived           NotificationType = "transfer_received"              // Anak menerima poin dari saudaranya
+	NotificationTransferRejected           NotificationType = "transfer_rejected"              // Transfer poin ditolak orang tua
+	NotificationCashOutRequested           NotificationType = "cash_out_requested"             // Anak meminta pencairan poin menjadi uang
+	NotificationCashOutApproved            NotificationType = "cash_out_approved"              // Pencairan poin disetujui dan dibayarkan
+	NotificationCashOutRejected            NotificationType = "cash_out_rejected"              // Pencairan poin ditolak, poin dikembalikan
+	NotificationPenaltyApplied             NotificationType = "penalty_applied"                // Anak mendapat sanksi pengurangan poin
+	NotificationPenaltyEarnedBack          NotificationType = "penalty_earned_back"            // Poin sanksi dikembalikan karena tugas penebus disetujui
+	NotificationBadgeAwarded               NotificationType = "badge_awarded"                  // Anak meraih badge baru
+	NotificationStreakBonusAwarded         NotificationType = "streak_bonus_awarded"           // Anak mendapat bonus poin karena streak
+	NotificationStreakFreezeUsed           NotificationType = "streak_freeze_used"             // Streak freeze dipakai untuk hari yang terlewat
+	NotificationLevelUp                    NotificationType = "level_up"                       // Anak naik level karena XP bertambah
+)
+
+// DefinitionCategory mendefinisikan kategori untuk definisi Task dan Reward.
+type DefinitionCategory string
+
+const (
+	DefinitionCategoryChores    DefinitionCategory = "chores"    // Pekerjaan rumah tangga
+	DefinitionCategoryHomework  DefinitionCategory = "homework"  // Tugas sekolah / belajar
+	DefinitionCategoryBehaviour DefinitionCategory = "behaviour" // Perilaku & kebiasaan baik
+	DefinitionCategoryHealth    DefinitionCategory = "health"    // Kesehatan & olahraga
+)
+
+// TemplateKind mendefinisikan jenis definisi yang dihasilkan dari sebuah template.
+type TemplateKind string
+
+const (
+	TemplateKindTask   TemplateKind = "task"   // Diimpor menjadi definisi Task
+	TemplateKindReward TemplateKind = "reward" // Diimpor menjadi definisi Reward
+)
+
+// DefaultTemplateLocale adalah bahasa cadangan jika terjemahan untuk locale yang diminta tidak tersedia.
+const DefaultTemplateLocale = "en"
+
+// AuditActorType mendefinisikan jenis pelaku pada catatan audit.
+type AuditActorType string
+
+const (
+	AuditActorUser   AuditActorType = "user"   // Tindakan oleh pengguna yang login
+	AuditActorSystem AuditActorType = "system" // Tindakan oleh sistem (misal: worker auto-approval)
+)
+
 // ====================================================================================
 // Input Data Transfer Objects (DTOs) - Digunakan untuk menerima data dari request API
 // ====================================================================================
@@ -208,10 +931,11 @@ type AdminUpdateUserInput struct {
 
 // UpdateProfileInput adalah DTO untuk request pembaruan profil oleh pengguna sendiri.
 type UpdateProfileInput struct {
-	Username  string `json:"username" validate:"required,min=3,max=100"` // Username (wajib)
-	Email     string `json:"email" validate:"required,email"`            // Email (wajib)
-	FirstName string `json:"first_name,omitempty"`                       // Nama depan (opsional)
-	LastName  string `json:"last_name,omitempty"`                        // Nama belakang (opsional)
+	Username  string `json:"username" validate:"required,min=3,max=100"`       // Username (wajib)
+	Email     string `json:"email" validate:"required,email"`                  // Email (wajib)
+	FirstName string `json:"first_name,omitempty"`                             // Nama depan (opsional)
+	LastName  string `json:"last_name,omitempty"`                              // Nama belakang (opsional)
+	Timezone  string `json:"timezone,omitempty" validate:"omitempty,timezone"` // Zona waktu IANA, misal "Asia/Jakarta" (opsional, kosong = tidak diubah)
 }
 
 // UpdatePasswordInput adalah DTO untuk request perubahan kata sandi oleh pengguna sendiri.
@@ -227,35 +951,70 @@ type AddChildInput struct {
 
 // CreateTaskInput adalah DTO untuk request pembuatan definisi Task baru oleh Parent.
 type CreateTaskInput struct {
-	TaskName        string `json:"task_name" validate:"required,min=3,max=255"` // Nama tugas (maks 255 char)
-	TaskPoint       int    `json:"task_point" validate:"required,gt=0"`         // Poin tugas (harus > 0)
-	TaskDescription string `json:"task_description,omitempty"`                  // Deskripsi (opsional)
+	TaskName        string   `json:"task_name" validate:"required,min=3,max=255"`                                    // Nama tugas (maks 255 char)
+	TaskPoint       int      `json:"task_point" validate:"required,gt=0"`                                            // Poin tugas (harus > 0)
+	CurrencyID      int      `json:"currency_id,omitempty" validate:"gte=0"`                                         // Mata uang milik parent (0 = poin)
+	TaskDescription string   `json:"task_description,omitempty"`                                                     // Deskripsi (opsional)
+	Category        string   `json:"category,omitempty" validate:"omitempty,oneof=chores homework behaviour health"` // Kategori (opsional)
+	Tags            []string `json:"tags,omitempty" validate:"omitempty,max=10,dive,min=1,max=30"`                   // Tag bebas (opsional, maks 10)
 }
 
 // UpdateTaskInput adalah DTO untuk request pembaruan definisi Task oleh Parent.
 type UpdateTaskInput struct {
-	TaskName        string `json:"task_name" validate:"required,min=3,max=255"` // Nama tugas baru
-	TaskPoint       int    `json:"task_point" validate:"required,gt=0"`         // Poin tugas baru
-	TaskDescription string `json:"task_description,omitempty"`                  // Deskripsi baru
+	TaskName        string   `json:"task_name" validate:"required,min=3,max=255"`                                    // Nama tugas baru
+	TaskPoint       int      `json:"task_point" validate:"required,gt=0"`                                            // Poin tugas baru
+	CurrencyID      int      `json:"currency_id,omitempty" validate:"gte=0"`                                         // Mata uang baru (0 = poin)
+	TaskDescription string   `json:"task_description,omitempty"`                                                     // Deskripsi baru
+	Category        string   `json:"category,omitempty" validate:"omitempty,oneof=chores homework behaviour health"` // Kategori baru (kosong = tanpa kategori)
+	Tags            []string `json:"tags,omitempty" validate:"omitempty,max=10,dive,min=1,max=30"`                   // Tag baru (menggantikan tag lama)
 }
 
 // CreateRewardInput adalah DTO untuk request pembuatan definisi Reward baru oleh Parent.
 type CreateRewardInput struct {
-	RewardName        string `json:"reward_name" validate:"required,min=3,max=255"` // Nama hadiah
-	RewardPoint       int    `json:"reward_point" validate:"required,gt=0"`         // Poin hadiah (harus > 0)
-	RewardDescription string `json:"reward_description,omitempty"`                  // Deskripsi (opsional)
+	RewardName        string   `json:"reward_name" validate:"required,min=3,max=255"`                                  // Nama hadiah
+	RewardPoint       int      `json:"reward_point" validate:"required,gt=0"`                                          // Poin hadiah (harus > 0)
+	CurrencyID        int      `json:"currency_id,omitempty" validate:"gte=0"`                                         // Mata uang milik parent (0 = poin)
+	RewardDescription string   `json:"reward_description,omitempty"`                                                   // Deskripsi (opsional)
+	Category          string   `json:"category,omitempty" validate:"omitempty,oneof=chores homework behaviour health"` // Kategori (opsional)
+	Tags              []string `json:"tags,omitempty" validate:"omitempty,max=10,dive,min=1,max=30"`                   // Tag bebas (opsional, maks 10)
+	RewardLimitsInput          // Stok, kuota per anak, dan cooldown (opsional)
 }
 
 // UpdateRewardInput adalah DTO untuk request pembaruan definisi Reward oleh Parent.
 type UpdateRewardInput struct {
-	RewardName        string `json:"reward_name" validate:"required,min=3,max=255"` // Nama hadiah baru
-	RewardPoint       int    `json:"reward_point" validate:"required,gt=0"`         // Poin hadiah baru
-	RewardDescription string `json:"reward_description,omitempty"`                  // Deskripsi baru
+	RewardName        string   `json:"reward_name" validate:"required,min=3,max=255"`                                  // Nama hadiah baru
+	RewardPoint       int      `json:"reward_point" validate:"required,gt=0"`                                          // Poin hadiah baru
+	CurrencyID        int      `json:"currency_id,omitempty" validate:"gte=0"`                                         // Mata uang baru (0 = poin)
+	RewardDescription string   `json:"reward_description,omitempty"`                                                   // Deskripsi baru
+	Category          string   `json:"category,omitempty" validate:"omitempty,oneof=chores homework behaviour health"` // Kategori baru (kosong = tanpa kategori)
+	Tags              []string `json:"tags,omitempty" validate:"omitempty,max=10,dive,min=1,max=30"`                   // Tag baru (menggantikan tag lama)
+	RewardLimitsInput          // Pembatasan baru (menggantikan yang lama)
+}
+
+// RewardLimitsInput adalah bagian DTO Create/Update Reward untuk stok, kuota per anak, dan cooldown.
+type RewardLimitsInput struct {
+	Stock            *int   `json:"stock" validate:"omitempty,gte=0"`                                                                // Stok (null = tak terbatas)
+	ClaimLimit       int    `json:"claim_limit,omitempty" validate:"required_with=ClaimLimitPeriod,omitempty,gt=0"`                  // Maks klaim per anak per periode
+	ClaimLimitPeriod string `json:"claim_limit_period,omitempty" validate:"required_with=ClaimLimit,omitempty,oneof=day week month"` // Periode kuota
+	CooldownMinutes  int    `json:"cooldown_minutes,omitempty" validate:"omitempty,gte=0,max=525600"`                                // Jeda antar klaim (maks 1 tahun)
+	MinLevel         int    `json:"min_level,omitempty" validate:"omitempty,gte=1,lte=10"`                                           // Level anak minimal untuk klaim (perk level, tangga level 1-10)
+}
+
+// ToLimits mengubah input menjadi RewardLimits untuk disimpan.
+func (in RewardLimitsInput) ToLimits() RewardLimits {
+	return RewardLimits{
+		Stock:            in.Stock,
+		ClaimLimit:       in.ClaimLimit,
+		ClaimLimitPeriod: RewardLimitPeriod(in.ClaimLimitPeriod),
+		CooldownMinutes:  in.CooldownMinutes,
+		MinLevel:         max(in.MinLevel, 1),
+	}
 }
 
 // AssignTaskInput adalah DTO untuk request penugasan Task ke Child oleh Parent.
 type AssignTaskInput struct {
-	TaskID int `json:"task_id" validate:"required,gt=0"` // ID Task yang akan ditugaskan
+	TaskID int        `json:"task_id" validate:"required,gt=0"` // ID Task yang akan ditugaskan
+	DueAt  *time.Time `json:"due_at" validate:"omitempty"`      // Batas waktu pengerjaan (opsional, RFC3339)
 }
 
 // VerifyTaskInput adalah DTO untuk request verifikasi Task yang disubmit Child oleh Parent.
@@ -263,11 +1022,143 @@ type VerifyTaskInput struct {
 	Status string `json:"status" validate:"required,oneof=approved rejected"` // Status verifikasi ('approved' atau 'rejected')
 }
 
+// ReassignTaskInput adalah DTO untuk request pemindahan penugasan ke anak lain oleh Parent.
+type ReassignTaskInput struct {
+	ChildID int `json:"child_id" validate:"required,gt=0"` // ID anak penerima tugas yang baru
+}
+
+// RevertVerificationInput adalah DTO untuk request pembatalan verifikasi Task oleh Parent.
+type RevertVerificationInput struct {
+	Reason string `json:"reason" validate:"required,min=3,max=255"` // Alasan pembatalan (dicatat di ledger & audit)
+}
+
 // ReviewClaimInput adalah DTO untuk request review klaim Reward oleh Parent.
 type ReviewClaimInput struct {
 	Status string `json:"status" validate:"required,oneof=approved rejected"` // Status review ('approved' atau 'rejected')
 }
 
+// SetRewardApprovalPolicyInput adalah DTO untuk membuat/mengubah kebijakan persetujuan bersama (keluarga atau satu anak).
+type SetRewardApprovalPolicyInput struct {
+	PointsThreshold   int `json:"points_threshold" validate:"gte=0"`                   // Klaim di atas nilai ini butuh persetujuan bersama
+	RequiredApprovals int `json:"required_approvals" validate:"required,gte=2,lte=10"` // Jumlah orang tua berbeda yang harus menyetujui
+}
+
+// SetPointExpirationPolicyInput adalah DTO untuk membuat/mengubah kebijakan kedaluwarsa poin (keluarga atau satu anak).
+type SetPointExpirationPolicyInput struct {
+	ExpireAfterDays int `json:"expire_after_days" validate:"required,gte=1,lte=3650"` // Poin kedaluwarsa sekian hari setelah didapat
+	WarnBeforeDays  int `json:"warn_before_days" validate:"gte=0,lte=365"`            // Peringatan sekian hari sebelumnya (harus < expire_after_days)
+}
+
+// SetInterestPolicyInput adalah DTO untuk membuat/mengubah kebijakan bunga poin (keluarga atau satu anak).
+type SetInterestPolicyInput struct {
+	RateBasisPoints    int              `json:"rate_basis_points" validate:"required,gte=1,lte=10000"`          // Bunga per periode (100 = 1%)
+	Schedule           InterestSchedule `json:"schedule" validate:"required,oneof=weekly monthly"`              // Periode pemajemukan
+	Rounding           InterestRounding `json:"rounding,omitempty" validate:"omitempty,oneof=floor round ceil"` // Pembulatan (default floor)
+	MaxPointsPerPeriod int              `json:"max_points_per_period" validate:"gte=0"`                         // Batas bunga per periode (0 = tanpa batas)
+}
+
+// SetTransferPolicyInput adalah DTO untuk membuat/mengubah kebijakan transfer poin (keluarga atau satu anak).
+type SetTransferPolicyInput struct {
+	Allowed          *bool `json:"allowed" validate:"required"`           // Anak boleh mengirim poin ke saudaranya
+	MaxAmount        int   `json:"max_amount" validate:"gte=0"`           // Batas poin per transfer (0 = tanpa batas)
+	RequiresApproval *bool `json:"requires_approval" validate:"required"` // Transfer menunggu persetujuan orang tua
+}
+
+// CreateTransferInput adalah DTO untuk request transfer poin ke saudara oleh Child.
+type CreateTransferInput struct {
+	ToChildID int    `json:"to_child_id" validate:"required,gt=0"` // ID anak penerima (harus saudara)
+	Amount    int    `json:"amount" validate:"required,gt=0"`      // Jumlah poin yang ditransfer
+	Note      string `json:"note,omitempty" validate:"max=255"`    // Pesan untuk penerima (opsional)
+}
+
+// CurrencyInput adalah DTO untuk membuat/mengubah mata uang tambahan oleh Parent.
+type CurrencyInput struct {
+	Name   string `json:"name" validate:"required,min=2,max=50"` // Nama mata uang (unik per parent)
+	Symbol string `json:"symbol,omitempty" validate:"max=16"`    // Simbol/emoji tampilan (opsional)
+}
+
+// CreateExchangeRuleInput adalah DTO untuk membuat aturan tukar mata uang oleh Parent.
+type CreateExchangeRuleInput struct {
+	FromCurrencyID int `json:"from_currency_id" validate:"gte=0"`               // Mata uang asal (0 = poin)
+	ToCurrencyID   int `json:"to_currency_id" validate:"gte=0"`                 // Mata uang tujuan (0 = poin)
+	FromAmount     int `json:"from_amount" validate:"required,gt=0,lte=100000"` // Unit asal per penukaran
+	ToAmount       int `json:"to_amount" validate:"required,gt=0,lte=100000"`   // Unit tujuan per penukaran
+}
+
+// ExchangeCurrencyInput adalah DTO untuk penukaran mata uang oleh Child.
+type ExchangeCurrencyInput struct {
+	RuleID int `json:"rule_id" validate:"required,gt=0"` // Aturan tukar yang dipakai
+	Amount int `json:"amount" validate:"required,gt=0"`  // Jumlah mata uang asal (kelipatan from_amount aturan)
+}
+
+// SetCashOutPolicyInput adalah DTO untuk membuat/mengubah kurs pencairan poin (keluarga atau satu anak).
+type SetCashOutPolicyInput struct {
+	Points       int    `json:"points" validate:"required,gt=0,lte=100000"`              // Jumlah poin per satuan kurs
+	MinorUnits   int    `json:"minor_units" validate:"required,gt=0,lte=100000000"`      // Nilai uang per satuan kurs (satuan terkecil)
+	CurrencyCode string `json:"currency_code" validate:"required,len=3,alpha,uppercase"` // Kode mata uang ISO 4217 (misal IDR)
+	MinPoints    int    `json:"min_points" validate:"gte=0"`                             // Minimal poin per permintaan (0 = tanpa minimum)
+}
+
+// CreateCashOutInput adalah DTO untuk permintaan pencairan poin oleh Child.
+type CreateCashOutInput struct {
+	Points int    `json:"points" validate:"required,gt=0"`   // Poin yang dicairkan (kelipatan poin kurs)
+	Note   string `json:"note,omitempty" validate:"max=255"` // Catatan untuk orang tua (opsional)
+}
+
+// ReviewCashOutInput adalah DTO untuk review permintaan pencairan poin oleh Parent (mirip ReviewClaimInput).
+type ReviewCashOutInput struct {
+	Status       string `json:"status" validate:"required,oneof=approved rejected"`                                           // Status review ('approved' atau 'rejected')
+	PayoutMethod string `json:"payout_method,omitempty" validate:"required_if=Status approved,omitempty,oneof=cash transfer"` // Cara pembayaran (wajib jika disetujui)
+	Reference    string `json:"reference,omitempty" validate:"max=100"`                                                       // Referensi transfer (opsional)
+	Note         string `json:"note,omitempty" validate:"max=255"`                                                            // Catatan review (opsional)
+}
+
+// InfractionTypeInput adalah DTO untuk membuat/mengubah jenis pelanggaran.
+type InfractionTypeInput struct {
+	Name           string `json:"name" validate:"required,min=2,max=100"`         // Nama pelanggaran
+	Description    string `json:"description,omitempty" validate:"max=500"`       // Deskripsi (opsional)
+	Points         int    `json:"points" validate:"required,gt=0,lte=10000"`      // Poin yang dipotong
+	MaxPerDay      int    `json:"max_per_day,omitempty" validate:"gte=0,lte=100"` // Batas penerapan per anak per hari (0 = tanpa batas)
+	EarnBackTaskID int    `json:"earn_back_task_id,omitempty" validate:"gte=0"`   // Tugas penebus milik parent (0 = tanpa tugas penebus)
+}
+
+// ApplyPenaltyInput adalah DTO untuk menerapkan sanksi ke anak.
+type ApplyPenaltyInput struct {
+	InfractionTypeID int    `json:"infraction_type_id" validate:"required,gt=0"` // Jenis pelanggaran milik parent
+	Notes            string `json:"notes,omitempty" validate:"max=255"`          // Catatan (opsional)
+}
+
+// PenaltyReportFilter adalah query parameter laporan sanksi.
+type PenaltyReportFilter struct {
+	From    string `query:"from" validate:"omitempty,datetime=2006-01-02"`      // Tanggal awal (default: 30 hari terakhir)
+	To      string `query:"to" validate:"omitempty,datetime=2006-01-02"`        // Tanggal akhir inklusif (default: hari ini)
+	GroupBy string `query:"group_by" validate:"omitempty,oneof=day week month"` // Pengelompokan (default: week)
+}
+
+// RejectTransferInput adalah DTO untuk menolak transfer poin yang menunggu persetujuan.
+type RejectTransferInput struct {
+	Reason string `json:"reason,omitempty" validate:"max=255"` // Alasan penolakan (opsional)
+}
+
+// SetAllowancePlanInput adalah DTO untuk membuat/mengubah rencana uang saku anak.
+type SetAllowancePlanInput struct {
+	Amount           int              `json:"amount" validate:"required,gt=0,lte=100000"`                // Poin per periode
+	Cadence          AllowanceCadence `json:"cadence" validate:"required,oneof=weekly biweekly monthly"` // Frekuensi pembayaran
+	StartDate        string           `json:"start_date" validate:"required,datetime=2006-01-02"`        // Awal periode pertama (YYYY-MM-DD, zona waktu anak)
+	MinApprovedTasks int              `json:"min_approved_tasks" validate:"gte=0,lte=1000"`              // Syarat minimal tugas disetujui per periode (0 = tanpa syarat)
+}
+
+// ScheduleClaimInput adalah DTO untuk menjadwalkan penyerahan hadiah yang sudah disetujui.
+type ScheduleClaimInput struct {
+	DeliveryDate time.Time `json:"delivery_date" validate:"required"` // Rencana tanggal penyerahan hadiah
+	Note         string    `json:"note,omitempty" validate:"max=255"` // Catatan untuk anak (opsional)
+}
+
+// FulfillClaimInput adalah DTO untuk menandai hadiah sudah diserahkan.
+type FulfillClaimInput struct {
+	Note string `json:"note,omitempty" validate:"max=255"` // Catatan penyerahan (opsional)
+}
+
 // AdjustPointsInput adalah DTO untuk request penyesuaian poin manual oleh Parent/Admin.
 type AdjustPointsInput struct {
 	ChangeAmount int    `json:"change_amount" validate:"required,ne=0"`  // Jumlah perubahan poin (tidak boleh 0)
@@ -288,6 +1179,112 @@ type AcceptInvitationInput struct {
 	Code string `json:"invitation_code" validate:"required,len=10"` // Kode undangan yang diterima (panjang sesuai implementasi)
 }
 
+// CreateRotationInput adalah DTO untuk request pembuatan rotasi tugas oleh Parent.
+type CreateRotationInput struct {
+	TaskID   int        `json:"task_id" validate:"required,gt=0"`                     // ID Task yang akan dirotasi
+	ChildIDs []int      `json:"child_ids" validate:"required,min=2,unique,dive,gt=0"` // Urutan anak dalam rotasi (minimal 2)
+	Cadence  string     `json:"cadence" validate:"required,oneof=daily weekly"`       // Irama rotasi ('daily' atau 'weekly')
+	StartAt  *time.Time `json:"start_at,omitempty"`                                   // Waktu giliran pertama (opsional, default sekarang)
+}
+
+// SkipRotationTurnInput adalah DTO untuk request melewati giliran anak berikutnya.
+type SkipRotationTurnInput struct {
+	Notes string `json:"notes" validate:"omitempty,max=255"` // Alasan giliran dilewati (opsional)
+}
+
+// SwapRotationMembersInput adalah DTO untuk request menukar urutan dua anak dalam rotasi.
+type SwapRotationMembersInput struct {
+	FirstChildID  int `json:"first_child_id" validate:"required,gt=0"`                       // Anak pertama yang ditukar
+	SecondChildID int `json:"second_child_id" validate:"required,gt=0,nefield=FirstChildID"` // Anak kedua yang ditukar
+}
+
+// CreateBountyInput adalah DTO untuk request pembuatan bounty (tugas terbuka) oleh Parent.
+type CreateBountyInput struct {
+	TaskID    int        `json:"task_id" validate:"required,gt=0"`            // ID Task yang ditawarkan
+	MaxClaims int        `json:"max_claims" validate:"omitempty,gt=0,lte=20"` // Jumlah anak maksimum yang boleh klaim (default 1)
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`                        // Batas waktu klaim (opsional)
+}
+
+// CreateSavingsGoalInput adalah DTO untuk request pembuatan target tabungan oleh Child.
+// Jika reward_id diisi, nama dan target diambil dari Reward; jika tidak, goal_name dan target_points wajib.
+type CreateSavingsGoalInput struct {
+	RewardID     int    `json:"reward_id,omitempty" validate:"omitempty,gt=0"`                                           // Reward yang disematkan (opsional)
+	GoalName     string `json:"goal_name,omitempty" validate:"required_without=RewardID,omitempty,max=255"`              // Nama target kustom
+	TargetPoints int    `json:"target_points,omitempty" validate:"required_without=RewardID,omitempty,gt=0,lte=1000000"` // Target poin kustom
+	AutoClaim    bool   `json:"auto_claim"`                                                                              // Klaim Reward otomatis saat tercapai (hanya untuk Reward)
+}
+
+// EarmarkPointsInput adalah DTO untuk menyisihkan (positif) atau melepas (negatif) poin untuk target tabungan.
+type EarmarkPointsInput struct {
+	Points int `json:"points" validate:"required,ne=0"` // Jumlah poin yang disisihkan/dilepas
+}
+
+// ContributeToGoalInput adalah DTO untuk bonus poin dari Parent ke target tabungan anak.
+type ContributeToGoalInput struct {
+	Points int    `json:"points" validate:"required,gt=0,lte=100000"` // Jumlah bonus poin
+	Note   string `json:"note,omitempty" validate:"max=255"`          // Catatan (opsional)
+}
+
+// DefinitionFilter adalah DTO untuk query parameter pencarian definisi Task/Reward.
+// Semua field opsional; filter kosong berarti urutan default (terbaru lebih dulu) tanpa penyaringan.
+type DefinitionFilter struct {
+	Search    string `query:"q" validate:"omitempty,max=100"`                                       // Kata kunci pencarian (full-text pada nama & deskripsi)
+	Category  string `query:"category" validate:"omitempty,oneof=chores homework behaviour health"` // Filter kategori
+	Tag       string `query:"tag" validate:"omitempty,max=30"`                                      // Filter tag (harus dimiliki definisi)
+	MinPoints *int   `query:"min_points" validate:"omitempty,gte=0"`                                // Poin minimum (inklusif)
+	MaxPoints *int   `query:"max_points" validate:"omitempty,gte=0"`                                // Poin maksimum (inklusif)
+	SortBy    string `query:"sort_by" validate:"omitempty,oneof=created_at name points relevance"`  // Field pengurutan
+	SortOrder string `query:"sort_order" validate:"omitempty,oneof=asc desc"`                       // Arah pengurutan
+}
+
+// TemplateInput adalah DTO untuk request pembuatan / pembaruan template katalog oleh Admin.
+type TemplateInput struct {
+	Kind            string                          `json:"kind" validate:"required,oneof=task reward"`                                     // Jenis template
+	Category        string                          `json:"category,omitempty" validate:"omitempty,oneof=chores homework behaviour health"` // Kategori (opsional)
+	Tags            []string                        `json:"tags,omitempty" validate:"omitempty,max=10,dive,min=1,max=30"`                   // Tag bebas (opsional)
+	SuggestedPoints int                             `json:"suggested_points" validate:"required,gt=0"`                                      // Poin yang disarankan
+	MinAge          *int                            `json:"min_age,omitempty" validate:"omitempty,gte=0,lte=18"`                            // Usia minimum (opsional)
+	MaxAge          *int                            `json:"max_age,omitempty" validate:"omitempty,gte=0,lte=18"`                            // Usia maksimum (opsional)
+	IsActive        *bool                           `json:"is_active,omitempty"`                                                            // Status aktif (default true)
+	Translations    []DefinitionTemplateTranslation `json:"translations" validate:"required,min=1,dive"`                                    // Minimal satu terjemahan
+}
+
+// TemplateFilter adalah DTO untuk query parameter penelusuran katalog template.
+type TemplateFilter struct {
+	Kind     string `query:"kind" validate:"omitempty,oneof=task reward"`                          // Filter jenis template
+	Category string `query:"category" validate:"omitempty,oneof=chores homework behaviour health"` // Filter kategori
+	Age      *int   `query:"age" validate:"omitempty,gte=0,lte=18"`                                // Hanya template yang cocok untuk usia ini
+	Locale   string `query:"locale" validate:"omitempty,min=2,max=10"`                             // Bahasa tampilan (default "en")
+}
+
+// ImportTemplatesInput adalah DTO untuk request impor template ke definisi milik Parent.
+type ImportTemplatesInput struct {
+	TemplateIDs []int  `json:"template_ids" validate:"required,min=1,max=50,unique,dive,gt=0"` // Template yang diimpor
+	Locale      string `json:"locale,omitempty" validate:"omitempty,min=2,max=10"`             // Bahasa nama & deskripsi hasil impor
+}
+
+// CreateAutoApprovalPolicyInput adalah DTO untuk request pembuatan kebijakan auto-approval.
+type CreateAutoApprovalPolicyInput struct {
+	TaskID             int `json:"task_id,omitempty" validate:"required_without=ChildID,omitempty,gt=0"` // Tugas yang dicakup (opsional jika child_id diisi)
+	ChildID            int `json:"child_id,omitempty" validate:"required_without=TaskID,omitempty,gt=0"` // Anak yang dicakup (opsional jika task_id diisi)
+	GracePeriodMinutes int `json:"grace_period_minutes" validate:"gte=0,lte=10080"`                      // 0 = langsung, maksimal 7 hari
+}
+
+// UpdateAutoApprovalPolicyInput adalah DTO untuk request pembaruan kebijakan auto-approval.
+type UpdateAutoApprovalPolicyInput struct {
+	GracePeriodMinutes *int  `json:"grace_period_minutes,omitempty" validate:"omitempty,gte=0,lte=10080"` // Masa tenggang baru (opsional)
+	IsActive           *bool `json:"is_active,omitempty"`                                                 // Aktif / nonaktif (opsional)
+}
+
+// AuditLogFilter adalah DTO untuk query parameter penelusuran jejak audit.
+type AuditLogFilter struct {
+	EntityType  string `query:"entity_type" validate:"omitempty,max=50"`           // Filter jenis entitas
+	EntityID    *int   `query:"entity_id" validate:"omitempty,gt=0"`               // Filter ID entitas
+	ActorUserID *int   `query:"actor_user_id" validate:"omitempty,gt=0"`           // Filter pelaku
+	ActorType   string `query:"actor_type" validate:"omitempty,oneof=user system"` // Filter jenis pelaku
+	Action      string `query:"action" validate:"omitempty,max=100"`               // Filter tindakan
+}
+
 // ====================================================================================
 // Response Data Transfer Objects (DTOs) - Digunakan untuk mengirim data ke client
 // ====================================================================================
@@ -298,3 +1295,31 @@ type Response struct {
 	Message string      `json:"message"`        // Pesan deskriptif tentang hasil operasi
 	Data    interface{} `json:"data,omitempty"` // Data payload response (opsional, hanya ada jika sukses dan ada data)
 }
+
+// RotationPreviewItem adalah satu giliran mendatang pada pratinjau rotasi.
+type RotationPreviewItem struct {
+	ChildID       int       `json:"child_id"`       // Anak yang akan mendapat giliran
+	ChildUsername string    `json:"child_username"` // Username anak
+	ScheduledAt   time.Time `json:"scheduled_at"`   // Perkiraan waktu giliran diberikan
+}
+
+// ImportedDefinition adalah satu definisi Task/Reward yang dibuat dari template.
+type ImportedDefinition struct {
+	TemplateID   int          `json:"template_id"`   // Template sumber
+	Kind         TemplateKind `json:"kind"`          // task / reward
+	DefinitionID int          `json:"definition_id"` // ID Task atau Reward yang dibuat
+	Name         string       `json:"name"`          // Nama definisi yang dibuat
+}
+
+// TemplateUpdateSuggestion menandai definisi hasil impor yang template sumbernya sudah diperbarui.
+type TemplateUpdateSuggestion struct {
+	Kind            TemplateKind `json:"kind"`             // task / reward
+	DefinitionID    int          `json:"definition_id"`    // ID Task atau Reward milik Parent
+	DefinitionName  string       `json:"definition_name"`  // Nama definisi saat ini
+	TemplateID      int          `json:"template_id"`      // Template sumber
+	ImportedVersion int          `json:"imported_version"` // Versi template saat diimpor
+	LatestVersion   int          `json:"latest_version"`   // Versi template terbaru
+	Name            string       `json:"name"`             // Nama terbaru dari template
+	Description     string       `json:"description"`      // Deskripsi terbaru dari template
+	SuggestedPoints int          `json:"suggested_points"` // Poin terbaru yang disarankan
+}
@@ -0,0 +1,41 @@
+// internal/models/penalty.go
+package models
+
+import "time"
+
+// PenaltyReportGroupBy mendefinisikan pengelompokan periode pada laporan sanksi.
+type PenaltyReportGroupBy string
+
+const (
+	PenaltyReportByDay   PenaltyReportGroupBy = "day"   // Per hari
+	PenaltyReportByWeek  PenaltyReportGroupBy = "week"  // Per minggu (mulai Senin)
+	PenaltyReportByMonth PenaltyReportGroupBy = "month" // Per bulan kalender
+)
+
+// PenaltyReportDateLayout adalah format tanggal filter laporan sanksi (YYYY-MM-DD).
+const PenaltyReportDateLayout = "2006-01-02"
+
+// PeriodStart mengembalikan awal periode (tengah malam di zona waktu loc) yang memuat waktu t.
+func (g PenaltyReportGroupBy) PeriodStart(t time.Time, loc *time.Location) time.Time {
+	date := LocalDate(t, loc)
+	switch g {
+	case PenaltyReportByWeek:
+		// time.Weekday dimulai dari Minggu (0); minggu laporan dimulai Senin
+		date = date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+	case PenaltyReportByMonth:
+		date = time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return LocalMidnight(date, loc)
+}
+
+// Next mengembalikan awal periode setelah start (start harus hasil PeriodStart).
+func (g PenaltyReportGroupBy) Next(start time.Time) time.Time {
+	switch g {
+	case PenaltyReportByWeek:
+		return start.AddDate(0, 0, 7)
+	case PenaltyReportByMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
@@ -0,0 +1,83 @@
+// internal/models/reward_limits.go
+package models
+
+import "time"
+
+// RewardLimitPeriod mendefinisikan periode kalender untuk kuota klaim per anak.
+type RewardLimitPeriod string
+
+const (
+	RewardLimitPeriodDay   RewardLimitPeriod = "day"   // Kuota direset setiap hari
+	RewardLimitPeriodWeek  RewardLimitPeriod = "week"  // Kuota direset setiap minggu (mulai Senin)
+	RewardLimitPeriodMonth RewardLimitPeriod = "month" // Kuota direset setiap awal bulan
+)
+
+// RewardUnavailableReason adalah kode alasan sebuah hadiah tidak bisa diklaim saat ini.
+type RewardUnavailableReason string
+
+const (
+	RewardUnavailableOutOfStock        RewardUnavailableReason = "out_of_stock"        // Stok hadiah habis
+	RewardUnavailableClaimLimitReached RewardUnavailableReason = "claim_limit_reached" // Kuota klaim periode berjalan sudah terpakai
+	RewardUnavailableCooldown          RewardUnavailableReason = "cooldown"            // Masih dalam masa cooldown sejak klaim terakhir
+	RewardUnavailableLevelLocked       RewardUnavailableReason = "level_locked"        // Level anak belum mencapai min_level hadiah
+)
+
+// RewardLimits berisi pembatasan opsional pada definisi Reward.
+type RewardLimits struct {
+	Stock            *int              `json:"stock,omitempty"`              // Sisa stok (nil = tak terbatas)
+	ClaimLimit       int               `json:"claim_limit,omitzero"`         // Maks klaim per anak per periode (0 = tanpa kuota)
+	ClaimLimitPeriod RewardLimitPeriod `json:"claim_limit_period,omitempty"` // Periode kuota (day/week/month)
+	CooldownMinutes  int               `json:"cooldown_minutes,omitzero"`    // Jeda minimal antar klaim oleh anak yang sama (0 = tanpa cooldown)
+	MinLevel         int               `json:"min_level,omitzero"`           // Level anak minimal untuk klaim (perk level; <= 1 = semua level)
+}
+
+// RewardUsage merangkum riwayat klaim (pending/approved) seorang anak untuk satu Reward.
+type RewardUsage struct {
+	PeriodClaims  int        // Jumlah klaim dalam periode kuota berjalan
+	PeriodEndsAt  *time.Time // Akhir periode kuota berjalan (nil jika tanpa kuota)
+	LastClaimedAt *time.Time // Waktu klaim terakhir (nil jika belum pernah)
+	Level         int        // Level anak saat ini (untuk min_level)
+}
+
+// RewardAvailability menunjukkan sisa ketersediaan hadiah untuk seorang anak.
+type RewardAvailability struct {
+	Claimable       bool                    `json:"claimable"`                  // true jika stok, kuota, dan cooldown mengizinkan klaim sekarang
+	Reason          RewardUnavailableReason `json:"reason,omitempty"`           // Alasan tidak bisa diklaim
+	RemainingStock  *int                    `json:"remaining_stock,omitempty"`  // Sisa stok (nil = tak terbatas)
+	RemainingClaims *int                    `json:"remaining_claims,omitempty"` // Sisa kuota periode berjalan (nil = tanpa kuota)
+	AvailableAt     *time.Time              `json:"available_at,omitempty"`     // Kapan hadiah bisa diklaim lagi (kuota/cooldown)
+	RequiredLevel   int                     `json:"required_level,omitzero"`    // Level yang harus dicapai dulu (level_locked)
+}
+
+// Availability menghitung ketersediaan hadiah untuk anak berdasarkan riwayat klaimnya pada waktu `now`.
+// Level diperiksa lebih dulu, lalu stok habis, lalu kuota, lalu cooldown.
+func (l RewardLimits) Availability(usage RewardUsage, now time.Time) RewardAvailability {
+	availability := RewardAvailability{Claimable: true, RemainingStock: l.Stock}
+
+	if l.ClaimLimit > 0 {
+		remaining := max(l.ClaimLimit-usage.PeriodClaims, 0)
+		availability.RemainingClaims = &remaining
+	}
+
+	switch {
+	case l.MinLevel > 1 && usage.Level < l.MinLevel:
+		availability.Claimable = false
+		availability.Reason = RewardUnavailableLevelLocked
+		availability.RequiredLevel = l.MinLevel
+	case l.Stock != nil && *l.Stock <= 0:
+		availability.Claimable = false
+		availability.Reason = RewardUnavailableOutOfStock
+	case availability.RemainingClaims != nil && *availability.RemainingClaims == 0:
+		availability.Claimable = false
+		availability.Reason = RewardUnavailableClaimLimitReached
+		availability.AvailableAt = usage.PeriodEndsAt
+	case l.CooldownMinutes > 0 && usage.LastClaimedAt != nil:
+		cooldownEndsAt := usage.LastClaimedAt.Add(time.Duration(l.CooldownMinutes) * time.Minute)
+		if now.Before(cooldownEndsAt) {
+			availability.Claimable = false
+			availability.Reason = RewardUnavailableCooldown
+			availability.AvailableAt = &cooldownEndsAt
+		}
+	}
+	return availability
+}
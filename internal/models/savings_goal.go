@@ -0,0 +1,30 @@
+// internal/models/savings_goal.go
+package models
+
+// SavingsGoalProgress menunjukkan seberapa dekat anak dengan target tabungannya.
+type SavingsGoalProgress struct {
+	CurrentPoints   int  `json:"current_points"`   // Poin yang dihitung untuk target (disisihkan + saldo bebas)
+	RemainingPoints int  `json:"remaining_points"` // Kekurangan poin untuk mencapai target
+	Percent         int  `json:"percent"`          // Persentase progres (0-100)
+	Reached         bool `json:"reached"`          // true jika target sudah tercapai
+}
+
+// IsOpen mengembalikan true jika target masih menahan poin yang disisihkan ('active' atau 'reached').
+func (g SavingsGoal) IsOpen() bool {
+	return g.Status == SavingsGoalStatusActive || g.Status == SavingsGoalStatusReached
+}
+
+// ComputeProgress menghitung progres target dari poin yang disisihkan untuknya ditambah saldo bebas anak
+// (saldo ledger dikurangi semua poin yang disisihkan untuk target terbuka). Progres dibatasi pada target.
+func (g SavingsGoal) ComputeProgress(freeBalance int) SavingsGoalProgress {
+	current := min(g.EarmarkedPoints+max(freeBalance, 0), g.TargetPoints)
+	progress := SavingsGoalProgress{
+		CurrentPoints:   current,
+		RemainingPoints: g.TargetPoints - current,
+		Reached:         current >= g.TargetPoints,
+	}
+	if g.TargetPoints > 0 {
+		progress.Percent = current * 100 / g.TargetPoints
+	}
+	return progress
+}
@@ -0,0 +1,134 @@
+// internal/models/streak.go
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Batas perhitungan streak.
+const (
+	MaxStreakBonusDays = 365 // Panjang streak maksimal pada aturan bonus
+	StreakLookbackDays = 400 // Riwayat penyelesaian yang dibaca saat menghitung streak (> MaxStreakBonusDays)
+)
+
+// StreakBonusRule merepresentasikan aturan bonus streak milik parent.
+type StreakBonusRule struct {
+	ID              int       `json:"id"`                  // ID unik aturan
+	CreatedByUserID int       `json:"created_by_user_id"`  // Parent pemilik aturan
+	Name            string    `json:"name"`                // Nama aturan (unik per parent)
+	TaskID          int       `json:"task_id,omitzero"`    // Tugas yang dihitung (0 = hari dengan tugas apa pun disetujui)
+	TaskName        string    `json:"task_name,omitempty"` // Nama tugas (jika TaskID terisi)
+	StreakDays      int       `json:"streak_days"`         // Panjang streak yang memicu bonus
+	BonusPoints     int       `json:"bonus_points"`        // Poin bonus yang diberikan
+	IsActive        bool      `json:"is_active"`           // Aturan nonaktif tidak memberi bonus
+	CreatedAt       time.Time `json:"created_at,omitzero"` // Waktu pembuatan record
+	UpdatedAt       time.Time `json:"updated_at,omitzero"` // Waktu terakhir pembaruan record
+}
+
+// StreakBonusRuleInput adalah DTO untuk membuat / memperbarui aturan bonus streak.
+type StreakBonusRuleInput struct {
+	Name        string `json:"name" validate:"required,min=2,max=100"`           // Nama aturan
+	TaskID      int    `json:"task_id,omitempty" validate:"gte=0"`               // Tugas milik parent (opsional, 0 = semua tugas)
+	StreakDays  int    `json:"streak_days" validate:"required,gte=2,lte=365"`    // Panjang streak yang memicu bonus
+	BonusPoints int    `json:"bonus_points" validate:"required,gt=0,lte=100000"` // Poin bonus
+	IsActive    *bool  `json:"is_active,omitempty"`                              // Status aktif (default true)
+}
+
+// StreakBonusAward merepresentasikan bonus streak yang sudah diberikan ke anak.
+type StreakBonusAward struct {
+	ID              int       `json:"id"`                      // ID unik pemberian bonus
+	RuleID          int       `json:"rule_id"`                 // Aturan yang terpenuhi
+	ChildID         int       `json:"child_id"`                // Anak penerima bonus
+	StreakStartDate time.Time `json:"streak_start_date"`       // Hari pertama streak (tanggal lokal anak)
+	StreakDays      int       `json:"streak_days"`             // Panjang streak saat bonus diberikan
+	BonusPoints     int       `json:"bonus_points"`            // Poin bonus
+	UserTaskID      int       `json:"user_task_id,omitzero"`   // Persetujuan tugas yang memicu bonus
+	TransactionID   int       `json:"transaction_id,omitzero"` // Entri ledger 'streak_bonus'
+	AwardedAt       time.Time `json:"awarded_at,omitzero"`     // Waktu bonus diberikan
+}
+
+// StreakFreezePolicy adalah harga streak freeze yang ditetapkan parent untuk seorang anak.
+type StreakFreezePolicy struct {
+	ChildID         int       `json:"child_id"`                    // Foreign key ke User (Anak)
+	PricePoints     int       `json:"price_points"`                // Harga satu freeze dalam poin
+	MaxOwned        int       `json:"max_owned"`                   // Maksimal freeze belum terpakai yang boleh dimiliki
+	UpdatedByUserID int       `json:"updated_by_user_id,omitzero"` // Parent yang terakhir mengubah kebijakan
+	CreatedAt       time.Time `json:"created_at,omitzero"`         // Waktu pembuatan record
+	UpdatedAt       time.Time `json:"updated_at,omitzero"`         // Waktu terakhir pembaruan record
+}
+
+// SetStreakFreezePolicyInput adalah DTO untuk mengatur harga streak freeze seorang anak.
+type SetStreakFreezePolicyInput struct {
+	PricePoints int `json:"price_points" validate:"required,gt=0,lte=100000"` // Harga satu freeze dalam poin
+	MaxOwned    int `json:"max_owned" validate:"required,gte=1,lte=10"`       // Maksimal freeze belum terpakai
+}
+
+// StreakFreeze merepresentasikan streak freeze yang dibeli anak.
+type StreakFreeze struct {
+	ID            int        `json:"id"`                      // ID unik freeze
+	ChildID       int        `json:"child_id"`                // Anak pemilik freeze
+	PricePoints   int        `json:"price_points"`            // Harga saat dibeli
+	TransactionID int        `json:"transaction_id,omitzero"` // Entri ledger 'streak_freeze'
+	PurchasedAt   time.Time  `json:"purchased_at,omitzero"`   // Waktu pembelian
+	UsedOn        *time.Time `json:"used_on,omitzero"`        // Hari yang dilindungi (nil = belum dipakai)
+}
+
+// TaskStreak adalah streak aktif untuk satu tugas.
+type TaskStreak struct {
+	TaskID   int    `json:"task_id"`   // ID definisi tugas
+	TaskName string `json:"task_name"` // Nama tugas
+	Current  int    `json:"current"`   // Hari berturut-turut tugas ini disetujui
+}
+
+// StreakSummary merangkum streak seorang anak per hari ini (zona waktu anak).
+type StreakSummary struct {
+	ChildID          int                 `json:"child_id"`                // Anak
+	Timezone         string              `json:"timezone"`                // Zona waktu yang dipakai
+	Date             string              `json:"date"`                    // Tanggal lokal hari ini (YYYY-MM-DD)
+	CurrentStreak    int                 `json:"current_streak"`          // Hari berturut-turut dengan minimal satu tugas disetujui
+	ActiveToday      bool                `json:"active_today"`            // Hari ini sudah dihitung (jika false, streak putus bila hari ini terlewat)
+	TaskStreaks      []TaskStreak        `json:"task_streaks"`            // Streak per tugas (hanya yang aktif)
+	FreezesAvailable int                 `json:"freezes_available"`       // Freeze belum terpakai
+	FreezePolicy     *StreakFreezePolicy `json:"freeze_policy,omitempty"` // Harga freeze (nil = tidak bisa dibeli)
+}
+
+// StreakRun menghitung streak yang berakhir pada today (atau kemarin, jika hari ini belum ada aktivitas)
+// dari daftar tanggal lokal days (hasil LocalDate, boleh duplikat dan tidak berurutan).
+// Mengembalikan panjang streak dan hari pertamanya (zero time jika panjang 0).
+func StreakRun(days []time.Time, today time.Time) (int, time.Time) {
+	active := make(map[time.Time]bool, len(days))
+	for _, day := range days {
+		active[day] = true
+	}
+	day := today
+	if !active[day] {
+		day = day.AddDate(0, 0, -1)
+	}
+	streak := 0
+	var start time.Time
+	for active[day] {
+		streak++
+		start = day
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak, start
+}
+
+// StreakRunWithFreezes seperti StreakRun, tetapi hari yang dilindungi streak freeze (frozen) ikut menyambung streak.
+// Streak selalu dimulai dari hari dengan aktivitas nyata (days), sehingga freeze saja tidak membentuk streak.
+func StreakRunWithFreezes(days []time.Time, frozen []time.Time, today time.Time) (int, time.Time) {
+	streak, start := StreakRun(append(slices.Clip(days), frozen...), today)
+	completed := make(map[time.Time]bool, len(days))
+	for _, day := range days {
+		completed[day] = true
+	}
+	for streak > 0 && !completed[start] {
+		streak--
+		start = start.AddDate(0, 0, 1)
+	}
+	if streak == 0 {
+		return 0, time.Time{}
+	}
+	return streak, start
+}
@@ -0,0 +1,55 @@
+// internal/models/user_reward_lifecycle.go
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UserRewardAction mendefinisikan aksi yang mengubah (atau mencatat) status sebuah klaim hadiah.
+type UserRewardAction string
+
+const (
+	UserRewardActionClaim          UserRewardAction = "claim"           // Anak mengklaim hadiah (event pertama)
+	UserRewardActionApprove        UserRewardAction = "approve"         // Parent menyetujui klaim
+	UserRewardActionReject         UserRewardAction = "reject"          // Parent menolak klaim (poin dikembalikan)
+	UserRewardActionSchedule       UserRewardAction = "schedule"        // Parent menjadwalkan (atau menjadwal ulang) penyerahan
+	UserRewardActionFulfill        UserRewardAction = "fulfill"         // Parent menandai hadiah sudah diserahkan
+	UserRewardActionConfirmReceipt UserRewardAction = "confirm_receipt" // Anak mengonfirmasi sudah menerima hadiah
+)
+
+// userRewardTransition mendeskripsikan status asal yang diizinkan dan status tujuan sebuah aksi.
+type userRewardTransition struct {
+	from []UserRewardStatus
+	to   UserRewardStatus
+}
+
+// userRewardTransitions adalah satu-satunya sumber aturan transisi status klaim hadiah.
+// Konfirmasi anak juga menyelesaikan klaim yang belum ditandai diserahkan oleh parent.
+var userRewardTransitions = map[UserRewardAction]userRewardTransition{
+	UserRewardActionApprove:        {from: []UserRewardStatus{UserRewardStatusPending}, to: UserRewardStatusApproved},
+	UserRewardActionReject:         {from: []UserRewardStatus{UserRewardStatusPending}, to: UserRewardStatusRejected},
+	UserRewardActionSchedule:       {from: []UserRewardStatus{UserRewardStatusApproved, UserRewardStatusScheduled}, to: UserRewardStatusScheduled},
+	UserRewardActionFulfill:        {from: []UserRewardStatus{UserRewardStatusApproved, UserRewardStatusScheduled}, to: UserRewardStatusFulfilled},
+	UserRewardActionConfirmReceipt: {from: []UserRewardStatus{UserRewardStatusApproved, UserRewardStatusScheduled, UserRewardStatusFulfilled}, to: UserRewardStatusFulfilled},
+}
+
+// NextUserRewardStatus mengembalikan status tujuan jika aksi diizinkan dari status klaim saat ini.
+// Mengembalikan error "cannot <aksi> claim: ..." jika transisi tidak sah.
+func NextUserRewardStatus(current UserRewardStatus, action UserRewardAction) (UserRewardStatus, error) {
+	transition, ok := userRewardTransitions[action]
+	if !ok {
+		return "", fmt.Errorf("invalid claim action '%s'", action)
+	}
+	for _, from := range transition.from {
+		if from == current {
+			return transition.to, nil
+		}
+	}
+
+	expected := make([]string, len(transition.from))
+	for i, from := range transition.from {
+		expected[i] = fmt.Sprintf("'%s'", from)
+	}
+	return "", fmt.Errorf("cannot %s claim: current status is '%s', expected %s", action, current, strings.Join(expected, " or "))
+}
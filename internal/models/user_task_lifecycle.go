@@ -0,0 +1,71 @@
+// internal/models/user_task_lifecycle.go
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UserTaskAction mendefinisikan aksi yang mengubah (atau mencatat) status sebuah UserTask.
+type UserTaskAction string
+
+const (
+	UserTaskActionAssign   UserTaskAction = "assign"   // Tugas ditugaskan ke anak (event pertama)
+	UserTaskActionSubmit   UserTaskAction = "submit"   // Anak menandai tugas selesai
+	UserTaskActionApprove  UserTaskAction = "approve"  // Parent/sistem menyetujui submission
+	UserTaskActionReject   UserTaskAction = "reject"   // Parent menolak submission
+	UserTaskActionRevert   UserTaskAction = "revert"   // Parent membatalkan verifikasi (kembali ke 'submitted')
+	UserTaskActionReassign UserTaskAction = "reassign" // Penugasan dipindah ke anak lain (status tetap 'assigned')
+	UserTaskActionCancel   UserTaskAction = "cancel"   // Parent membatalkan penugasan (unassign)
+	UserTaskActionExpire   UserTaskAction = "expire"   // Sistem menandai tugas yang melewati batas waktu
+)
+
+// userTaskTransition mendeskripsikan status asal yang diizinkan dan status tujuan sebuah aksi.
+type userTaskTransition struct {
+	from []UserTaskStatus
+	to   UserTaskStatus
+}
+
+// userTaskTransitions adalah satu-satunya sumber aturan transisi status UserTask.
+// Semua perubahan status (submit, verifikasi, revert, unassign, expire) harus melewati NextUserTaskStatus.
+var userTaskTransitions = map[UserTaskAction]userTaskTransition{
+	UserTaskActionSubmit:   {from: []UserTaskStatus{UserTaskStatusAssigned}, to: UserTaskStatusSubmitted},
+	UserTaskActionApprove:  {from: []UserTaskStatus{UserTaskStatusSubmitted}, to: UserTaskStatusApproved},
+	UserTaskActionReject:   {from: []UserTaskStatus{UserTaskStatusSubmitted}, to: UserTaskStatusRejected},
+	UserTaskActionRevert:   {from: []UserTaskStatus{UserTaskStatusApproved, UserTaskStatusRejected}, to: UserTaskStatusSubmitted},
+	UserTaskActionReassign: {from: []UserTaskStatus{UserTaskStatusAssigned}, to: UserTaskStatusAssigned},
+	UserTaskActionCancel:   {from: []UserTaskStatus{UserTaskStatusAssigned, UserTaskStatusSubmitted, UserTaskStatusRejected}, to: UserTaskStatusCancelled},
+	UserTaskActionExpire:   {from: []UserTaskStatus{UserTaskStatusAssigned}, to: UserTaskStatusExpired},
+}
+
+// NextUserTaskStatus mengembalikan status tujuan jika aksi diizinkan dari status saat ini.
+// Mengembalikan error "cannot <aksi> task: ..." jika transisi tidak sah.
+func NextUserTaskStatus(current UserTaskStatus, action UserTaskAction) (UserTaskStatus, error) {
+	transition, ok := userTaskTransitions[action]
+	if !ok {
+		return "", fmt.Errorf("invalid task action '%s'", action)
+	}
+	for _, from := range transition.from {
+		if from == current {
+			return transition.to, nil
+		}
+	}
+
+	expected := make([]string, len(transition.from))
+	for i, from := range transition.from {
+		expected[i] = fmt.Sprintf("'%s'", from)
+	}
+	return "", fmt.Errorf("cannot %s task: current status is '%s', expected %s", action, current, strings.Join(expected, " or "))
+}
+
+// VerificationAction memetakan status hasil verifikasi (approved/rejected) ke aksi lifecycle-nya.
+func VerificationAction(newStatus UserTaskStatus) (UserTaskAction, error) {
+	switch newStatus {
+	case UserTaskStatusApproved:
+		return UserTaskActionApprove, nil
+	case UserTaskStatusRejected:
+		return UserTaskActionReject, nil
+	default:
+		return "", fmt.Errorf("invalid verification status '%s'", newStatus)
+	}
+}
@@ -0,0 +1,268 @@
+// internal/repository/allowance_repo.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type allowanceRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewAllowanceRepository membuat instance baru dari AllowanceRepository.
+func NewAllowanceRepository(db *pgxpool.Pool) AllowanceRepository {
+	return &allowanceRepo{db: db}
+}
+
+// allowancePlanSelect memilih kolom rencana beserta zona waktu anak (dipakai untuk batas periode).
+const allowancePlanSelect = `SELECT ap.id, ap.child_id, COALESCE(ap.parent_id, 0), ap.amount, ap.cadence, ap.start_date,
+                ap.min_approved_tasks, ap.next_period_start, ap.is_paused, ap.paused_at, u.timezone,
+                ap.created_at, ap.updated_at
+              FROM allowance_plans ap
+              JOIN users u ON u.id = ap.child_id`
+
+// scanAllowancePlan memindai satu baris rencana uang saku.
+func scanAllowancePlan(row pgx.Row, plan *models.AllowancePlan) error {
+	return row.Scan(&plan.ID, &plan.ChildID, &plan.ParentID, &plan.Amount, &plan.Cadence, &plan.StartDate,
+		&plan.MinApprovedTasks, &plan.NextPeriodStart, &plan.IsPaused, &plan.PausedAt, &plan.Timezone,
+		&plan.CreatedAt, &plan.UpdatedAt)
+}
+
+// UpsertPlan membuat atau memperbarui rencana uang saku anak.
+// Jika tanggal mulai atau frekuensi berubah, periode berikutnya diatur ulang ke tanggal mulai yang baru;
+// jika tidak, periode yang sedang berjalan dipertahankan.
+func (r *allowanceRepo) UpsertPlan(ctx context.Context, plan *models.AllowancePlan) error {
+	query := `INSERT INTO allowance_plans (child_id, parent_id, amount, cadence, start_date, min_approved_tasks, next_period_start)
+              VALUES ($1, $2, $3, $4, $5, $6, $5)
+              ON CONFLICT (child_id) DO UPDATE
+              SET parent_id = EXCLUDED.parent_id,
+                  amount = EXCLUDED.amount,
+                  min_approved_tasks = EXCLUDED.min_approved_tasks,
+                  next_period_start = CASE
+                      WHEN allowance_plans.start_date <> EXCLUDED.start_date OR allowance_plans.cadence <> EXCLUDED.cadence
+                      THEN EXCLUDED.start_date
+                      ELSE allowance_plans.next_period_start
+                  END,
+                  start_date = EXCLUDED.start_date,
+                  cadence = EXCLUDED.cadence
+              RETURNING id, next_period_start, is_paused, paused_at, created_at, updated_at`
+	err := r.db.QueryRow(ctx, query, plan.ChildID, nullableID(plan.ParentID), plan.Amount, plan.Cadence, plan.StartDate, plan.MinApprovedTasks).
+		Scan(&plan.ID, &plan.NextPeriodStart, &plan.IsPaused, &plan.PausedAt, &plan.CreatedAt, &plan.UpdatedAt)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", plan.ChildID).Msg("Error upserting allowance plan")
+		return fmt.Errorf("error saving allowance plan for child %d: %w", plan.ChildID, err)
+	}
+	zlog.Info().Int("plan_id", plan.ID).Int("child_id", plan.ChildID).Int("amount", plan.Amount).Str("cadence", string(plan.Cadence)).Msg("Allowance plan saved")
+	return nil
+}
+
+// GetPlanByChildID mendapatkan rencana uang saku anak. Mengembalikan pgx.ErrNoRows jika belum ada.
+func (r *allowanceRepo) GetPlanByChildID(ctx context.Context, childID int) (*models.AllowancePlan, error) {
+	plan := &models.AllowancePlan{}
+	if err := scanAllowancePlan(r.db.QueryRow(ctx, allowancePlanSelect+` WHERE ap.child_id = $1`, childID), plan); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error getting allowance plan")
+		return nil, fmt.Errorf("error getting allowance plan for child %d: %w", childID, err)
+	}
+	return plan, nil
+}
+
+// DeletePlan menghapus rencana uang saku anak (riwayat pembayaran tetap disimpan).
+// Mengembalikan pgx.ErrNoRows jika belum ada.
+func (r *allowanceRepo) DeletePlan(ctx context.Context, childID int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM allowance_plans WHERE child_id = $1`, childID)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error deleting allowance plan")
+		return fmt.Errorf("error deleting allowance plan for child %d: %w", childID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// PausePlan menjeda rencana uang saku anak. Mengembalikan pgx.ErrNoRows jika rencana tidak ada atau sudah dijeda.
+func (r *allowanceRepo) PausePlan(ctx context.Context, childID int) error {
+	tag, err := r.db.Exec(ctx, `UPDATE allowance_plans SET is_paused = TRUE, paused_at = NOW()
+                                WHERE child_id = $1 AND NOT is_paused`, childID)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error pausing allowance plan")
+		return fmt.Errorf("error pausing allowance plan for child %d: %w", childID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// ResumePlan melanjutkan rencana yang dijeda mulai dari periode nextPeriodStart.
+// Mengembalikan pgx.ErrNoRows jika rencana tidak ada atau tidak sedang dijeda.
+func (r *allowanceRepo) ResumePlan(ctx context.Context, childID int, nextPeriodStart time.Time) error {
+	tag, err := r.db.Exec(ctx, `UPDATE allowance_plans SET is_paused = FALSE, paused_at = NULL, next_period_start = $2
+                                WHERE child_id = $1 AND is_paused`, childID, nextPeriodStart)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error resuming allowance plan")
+		return fmt.Errorf("error resuming allowance plan for child %d: %w", childID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// GetActivePlansAfter mengambil rencana yang tidak dijeda dengan id > afterID (keyset pagination untuk worker).
+func (r *allowanceRepo) GetActivePlansAfter(ctx context.Context, afterID int, limit int) ([]models.AllowancePlan, error) {
+	query := allowancePlanSelect + `
+              WHERE ap.id > $1 AND NOT ap.is_paused
+              ORDER BY ap.id
+              LIMIT $2`
+	rows, err := r.db.Query(ctx, query, afterID, limit)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Error querying active allowance plans")
+		return nil, fmt.Errorf("error getting active allowance plans: %w", err)
+	}
+	defer rows.Close()
+
+	plans := []models.AllowancePlan{}
+	for rows.Next() {
+		var plan models.AllowancePlan
+		if err := scanAllowancePlan(rows, &plan); err != nil {
+			zlog.Warn().Err(err).Msg("Error scanning allowance plan row")
+			return nil, fmt.Errorf("error scanning allowance plan: %w", err)
+		}
+		plans = append(plans, plan)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating allowance plans: %w", err)
+	}
+	return plans, nil
+}
+
+// GetPayoutsByChildID mengambil riwayat pembayaran uang saku anak (terbaru dulu) dengan paginasi.
+func (r *allowanceRepo) GetPayoutsByChildID(ctx context.Context, childID int, page, limit int) ([]models.AllowancePayout, int, error) {
+	var totalCount int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM allowance_payouts WHERE child_id = $1`, childID).Scan(&totalCount)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error counting allowance payouts")
+		return nil, 0, fmt.Errorf("error counting allowance payouts for child %d: %w", childID, err)
+	}
+	if totalCount == 0 {
+		return []models.AllowancePayout{}, 0, nil
+	}
+
+	offset := (page - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := `SELECT id, COALESCE(plan_id, 0), child_id, period_start, period_end, amount, status,
+                     approved_tasks, reason, COALESCE(point_transaction_id, 0), created_at
+              FROM allowance_payouts
+              WHERE child_id = $1
+              ORDER BY period_start DESC, id DESC
+              LIMIT $2 OFFSET $3`
+	rows, err := r.db.Query(ctx, query, childID, limit, offset)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error querying allowance payouts")
+		return nil, totalCount, fmt.Errorf("error getting allowance payouts for child %d: %w", childID, err)
+	}
+	defer rows.Close()
+
+	payouts := []models.AllowancePayout{}
+	for rows.Next() {
+		var payout models.AllowancePayout
+		var reason sql.NullString
+		if err := rows.Scan(&payout.ID, &payout.PlanID, &payout.ChildID, &payout.PeriodStart, &payout.PeriodEnd, &payout.Amount,
+			&payout.Status, &payout.ApprovedTasks, &reason, &payout.PointTransactionID, &payout.CreatedAt); err != nil {
+			zlog.Warn().Err(err).Int("child_id", childID).Msg("Error scanning allowance payout row")
+			return nil, totalCount, fmt.Errorf("error scanning allowance payout: %w", err)
+		}
+		payout.Reason = reason.String
+		payouts = append(payouts, payout)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, totalCount, fmt.Errorf("error iterating allowance payouts: %w", err)
+	}
+	return payouts, totalCount, nil
+}
+
+// --- Metode Transaksional ---
+
+// GetPlanForUpdateTx mengambil rencana berdasarkan ID dan menguncinya (FOR UPDATE) hingga transaksi selesai.
+func (r *allowanceRepo) GetPlanForUpdateTx(ctx context.Context, tx pgx.Tx, planID int) (*models.AllowancePlan, error) {
+	plan := &models.AllowancePlan{}
+	if err := scanAllowancePlan(tx.QueryRow(ctx, allowancePlanSelect+` WHERE ap.id = $1 FOR UPDATE OF ap`, planID), plan); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("plan_id", planID).Msg("Error locking allowance plan")
+		return nil, fmt.Errorf("error getting allowance plan %d: %w", planID, err)
+	}
+	return plan, nil
+}
+
+// CountApprovedTasksTx menghitung tugas anak yang disetujui dalam rentang [from, to).
+func (r *allowanceRepo) CountApprovedTasksTx(ctx context.Context, tx pgx.Tx, childID int, from time.Time, to time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM user_tasks
+              WHERE user_id = $1 AND status = 'approved' AND verified_at >= $2 AND verified_at < $3`
+	var count int
+	if err := tx.QueryRow(ctx, query, childID, from, to).Scan(&count); err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error counting approved tasks for allowance")
+		return 0, fmt.Errorf("error counting approved tasks for child %d: %w", childID, err)
+	}
+	return count, nil
+}
+
+// CreatePayoutTx mencatat hasil pemrosesan satu periode dan mengisi ID serta CreatedAt.
+// Mengembalikan false jika periode tersebut sudah pernah diproses.
+func (r *allowanceRepo) CreatePayoutTx(ctx context.Context, tx pgx.Tx, payout *models.AllowancePayout) (bool, error) {
+	query := `INSERT INTO allowance_payouts (plan_id, child_id, period_start, period_end, amount, status, approved_tasks, reason)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+              ON CONFLICT (plan_id, period_start) DO NOTHING
+              RETURNING id, created_at`
+	var reason sql.NullString
+	if payout.Reason != "" {
+		reason = sql.NullString{String: payout.Reason, Valid: true}
+	}
+	err := tx.QueryRow(ctx, query, payout.PlanID, payout.ChildID, payout.PeriodStart, payout.PeriodEnd, payout.Amount,
+		payout.Status, payout.ApprovedTasks, reason).Scan(&payout.ID, &payout.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil // Periode sudah diproses
+		}
+		zlog.Error().Err(err).Int("plan_id", payout.PlanID).Msg("Error recording allowance payout")
+		return false, fmt.Errorf("error recording allowance payout for plan %d: %w", payout.PlanID, err)
+	}
+	return true, nil
+}
+
+// SetPayoutTransactionTx mengaitkan pembayaran uang saku dengan transaksi poin 'allowance'.
+func (r *allowanceRepo) SetPayoutTransactionTx(ctx context.Context, tx pgx.Tx, payoutID int, pointTransactionID int) error {
+	_, err := tx.Exec(ctx, `UPDATE allowance_payouts SET point_transaction_id = $2 WHERE id = $1`, payoutID, pointTransactionID)
+	if err != nil {
+		zlog.Error().Err(err).Int("payout_id", payoutID).Msg("Error linking allowance payout to transaction")
+		return fmt.Errorf("error updating allowance payout %d: %w", payoutID, err)
+	}
+	return nil
+}
+
+// AdvancePlanTx memajukan periode berikutnya yang belum diproses.
+func (r *allowanceRepo) AdvancePlanTx(ctx context.Context, tx pgx.Tx, planID int, nextPeriodStart time.Time) error {
+	_, err := tx.Exec(ctx, `UPDATE allowance_plans SET next_period_start = $2 WHERE id = $1`, planID, nextPeriodStart)
+	if err != nil {
+		zlog.Error().Err(err).Int("plan_id", planID).Msg("Error advancing allowance plan")
+		return fmt.Errorf("error advancing allowance plan %d: %w", planID, err)
+	}
+	return nil
+}
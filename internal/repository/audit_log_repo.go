@@ -0,0 +1,138 @@
+// internal/repository/audit_log_repo.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type auditLogRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewAuditLogRepository membuat instance baru dari AuditLogRepository.
+func NewAuditLogRepository(db *pgxpool.Pool) AuditLogRepository {
+	return &auditLogRepo{db: db}
+}
+
+// --- Helper Functions ---
+
+const insertAuditLogQuery = `INSERT INTO audit_logs (actor_user_id, actor_type, action, entity_type, entity_id, details)
+              VALUES ($1, $2, $3, $4, $5, $6)`
+
+// auditLogArgs menyiapkan argumen INSERT; pelaku 0 disimpan sebagai NULL (sistem).
+func auditLogArgs(entry *models.AuditLog) []any {
+	details := entry.Details
+	if details == nil {
+		details = map[string]any{}
+	}
+	return []any{nullableID(entry.ActorUserID), entry.ActorType, entry.Action, entry.EntityType, entry.EntityID, details}
+}
+
+// --- Repository Methods ---
+
+// CreateLog mencatat entri audit baru.
+func (r *auditLogRepo) CreateLog(ctx context.Context, entry *models.AuditLog) error {
+	if _, err := r.db.Exec(ctx, insertAuditLogQuery, auditLogArgs(entry)...); err != nil {
+		zlog.Error().Err(err).Str("action", entry.Action).Int("entity_id", entry.EntityID).Msg("Error creating audit log")
+		return fmt.Errorf("error creating audit log: %w", err)
+	}
+	return nil
+}
+
+// GetLogs mengambil entri audit dengan filter & paginasi.
+func (r *auditLogRepo) GetLogs(ctx context.Context, filter *models.AuditLogFilter, page, limit int) ([]models.AuditLog, int, error) {
+	if filter == nil {
+		filter = &models.AuditLogFilter{}
+	}
+	args := []any{}
+	conditions := []string{"TRUE"}
+	if filter.EntityType != "" {
+		args = append(args, filter.EntityType)
+		conditions = append(conditions, fmt.Sprintf("entity_type = $%d", len(args)))
+	}
+	if filter.EntityID != nil {
+		args = append(args, *filter.EntityID)
+		conditions = append(conditions, fmt.Sprintf("entity_id = $%d", len(args)))
+	}
+	if filter.ActorUserID != nil {
+		args = append(args, *filter.ActorUserID)
+		conditions = append(conditions, fmt.Sprintf("actor_user_id = $%d", len(args)))
+	}
+	if filter.ActorType != "" {
+		args = append(args, filter.ActorType)
+		conditions = append(conditions, fmt.Sprintf("actor_type = $%d", len(args)))
+	}
+	if filter.Action != "" {
+		args = append(args, filter.Action)
+		conditions = append(conditions, fmt.Sprintf("action = $%d", len(args)))
+	}
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	// 1. Hitung Total
+	var totalCount int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM audit_logs `+whereClause, args...).Scan(&totalCount); err != nil {
+		zlog.Error().Err(err).Msg("Error counting audit logs")
+		return nil, 0, fmt.Errorf("error counting audit logs: %w", err)
+	}
+	if totalCount == 0 {
+		return []models.AuditLog{}, 0, nil
+	}
+
+	// 2. Hitung Offset
+	offset := (page - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	// 3. Query dengan Pagination
+	query := fmt.Sprintf(`SELECT id, actor_user_id, actor_type, action, entity_type, entity_id, details, created_at
+              FROM audit_logs
+              %s
+              ORDER BY created_at DESC, id DESC
+              LIMIT $%d OFFSET $%d`, whereClause, len(args)+1, len(args)+2)
+	rows, err := r.db.Query(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Error querying paginated audit logs")
+		return nil, totalCount, fmt.Errorf("error getting audit logs: %w", err)
+	}
+	defer rows.Close()
+
+	logs := []models.AuditLog{}
+	for rows.Next() {
+		var entry models.AuditLog
+		var actorUserID sql.NullInt32
+		scanErr := rows.Scan(&entry.ID, &actorUserID, &entry.ActorType, &entry.Action, &entry.EntityType, &entry.EntityID, &entry.Details, &entry.CreatedAt)
+		if scanErr != nil {
+			zlog.Warn().Err(scanErr).Msg("Error scanning audit log row")
+			return logs, totalCount, fmt.Errorf("error scanning audit log data: %w", scanErr)
+		}
+		if actorUserID.Valid {
+			entry.ActorUserID = int(actorUserID.Int32)
+		}
+		logs = append(logs, entry)
+	}
+	if err := rows.Err(); err != nil {
+		zlog.Error().Err(err).Msg("Error iterating audit log rows")
+		return logs, totalCount, fmt.Errorf("error iterating audit logs: %w", err)
+	}
+	return logs, totalCount, nil
+}
+
+// --- Metode Transaksional ---
+
+// CreateLogTx mencatat entri audit dalam transaksi.
+func (r *auditLogRepo) CreateLogTx(ctx context.Context, tx pgx.Tx, entry *models.AuditLog) error {
+	if _, err := tx.Exec(ctx, insertAuditLogQuery, auditLogArgs(entry)...); err != nil {
+		zlog.Error().Err(err).Str("action", entry.Action).Int("entity_id", entry.EntityID).Msg("RepoTx: Error creating audit log")
+		return fmt.Errorf("repoTx error creating audit log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+// internal/repository/auto_approval_policy_repo.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type autoApprovalPolicyRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewAutoApprovalPolicyRepository membuat instance baru dari AutoApprovalPolicyRepository.
+func NewAutoApprovalPolicyRepository(db *pgxpool.Pool) AutoApprovalPolicyRepository {
+	return &autoApprovalPolicyRepo{db: db}
+}
+
+// --- Helper Functions ---
+
+const autoApprovalPolicyColumns = `p.id, p.created_by_user_id, p.task_id, p.child_id, p.grace_period_minutes,
+                p.is_active, p.created_at, p.updated_at`
+
+// applicablePolicyJoin memilih kebijakan aktif paling spesifik untuk baris user_tasks `ut`:
+// tugas + anak, lalu tugas saja, lalu anak saja. Pemilik kebijakan harus parent dari anak tersebut.
+const applicablePolicyJoin = `JOIN LATERAL (
+                    SELECT ` + autoApprovalPolicyColumns + `
+                    FROM auto_approval_policies p
+                    JOIN user_relationship ur ON ur.parent_id = p.created_by_user_id AND ur.child_id = ut.user_id
+                    WHERE p.is_active = TRUE
+                      AND (p.task_id IS NULL OR p.task_id = ut.task_id)
+                      AND (p.child_id IS NULL OR p.child_id = ut.user_id)
+                    ORDER BY (p.task_id IS NOT NULL AND p.child_id IS NOT NULL) DESC, (p.task_id IS NOT NULL) DESC, p.id ASC
+                    LIMIT 1
+                ) pol ON TRUE`
+
+// scanAutoApprovalPolicyRow adalah helper untuk scan baris AutoApprovalPolicy.
+func scanAutoApprovalPolicyRow(row pgx.Row, policy *models.AutoApprovalPolicy) error {
+	var taskID, childID sql.NullInt32
+	err := row.Scan(
+		&policy.ID, &policy.CreatedByUserID, &taskID, &childID, &policy.GracePeriodMinutes,
+		&policy.IsActive, &policy.CreatedAt, &policy.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if taskID.Valid {
+		policy.TaskID = int(taskID.Int32)
+	}
+	if childID.Valid {
+		policy.ChildID = int(childID.Int32)
+	}
+	return nil
+}
+
+// --- Repository Methods ---
+
+// CreatePolicy membuat kebijakan auto-approval baru.
+func (r *autoApprovalPolicyRepo) CreatePolicy(ctx context.Context, policy *models.AutoApprovalPolicy) (int, error) {
+	query := `INSERT INTO auto_approval_policies (created_by_user_id, task_id, child_id, grace_period_minutes, is_active)
+              VALUES ($1, $2, $3, $4, TRUE) RETURNING id`
+	var policyID int
+	err := r.db.QueryRow(ctx, query,
+		policy.CreatedByUserID, nullableID(policy.TaskID), nullableID(policy.ChildID), policy.GracePeriodMinutes,
+	).Scan(&policyID)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case "23505":
+				return 0, fmt.Errorf("auto-approval policy for this task/child already exists")
+			case "23503":
+				return 0, fmt.Errorf("invalid task or child for auto-approval policy")
+			}
+		}
+		zlog.Error().Err(err).Int("creator_id", policy.CreatedByUserID).Msg("Error creating auto-approval policy")
+		return 0, fmt.Errorf("error creating auto-approval policy: %w", err)
+	}
+
+	zlog.Info().Int("policy_id", policyID).Int("creator_id", policy.CreatedByUserID).Msg("Auto-approval policy created successfully")
+	return policyID, nil
+}
+
+// GetPolicyByID mengambil kebijakan berdasarkan ID.
+func (r *autoApprovalPolicyRepo) GetPolicyByID(ctx context.Context, id int) (*models.AutoApprovalPolicy, error) {
+	query := `SELECT ` + autoApprovalPolicyColumns + ` FROM auto_approval_policies p WHERE p.id = $1`
+	policy := &models.AutoApprovalPolicy{}
+	if err := scanAutoApprovalPolicyRow(r.db.QueryRow(ctx, query, id), policy); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("policy_id", id).Msg("Error getting auto-approval policy by ID")
+		return nil, fmt.Errorf("error getting auto-approval policy %d: %w", id, err)
+	}
+	return policy, nil
+}
+
+// GetPoliciesByCreatorID mengambil semua kebijakan milik parent.
+func (r *autoApprovalPolicyRepo) GetPoliciesByCreatorID(ctx context.Context, creatorID int) ([]models.AutoApprovalPolicy, error) {
+	query := `SELECT ` + autoApprovalPolicyColumns + `
+              FROM auto_approval_policies p
+              WHERE p.created_by_user_id = $1
+              ORDER BY p.created_at DESC`
+	rows, err := r.db.Query(ctx, query, creatorID)
+	if err != nil {
+		zlog.Error().Err(err).Int("creator_id", creatorID).Msg("Error querying auto-approval policies")
+		return nil, fmt.Errorf("error getting auto-approval policies for parent %d: %w", creatorID, err)
+	}
+	defer rows.Close()
+
+	policies := []models.AutoApprovalPolicy{}
+	for rows.Next() {
+		var policy models.AutoApprovalPolicy
+		if scanErr := scanAutoApprovalPolicyRow(rows, &policy); scanErr != nil {
+			return nil, fmt.Errorf("error scanning auto-approval policy: %w", scanErr)
+		}
+		policies = append(policies, policy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating auto-approval policies: %w", err)
+	}
+	return policies, nil
+}
+
+// UpdatePolicy memperbarui masa tenggang dan status aktif kebijakan.
+func (r *autoApprovalPolicyRepo) UpdatePolicy(ctx context.Context, policy *models.AutoApprovalPolicy) error {
+	query := `UPDATE auto_approval_policies SET grace_period_minutes = $1, is_active = $2 WHERE id = $3`
+	tag, err := r.db.Exec(ctx, query, policy.GracePeriodMinutes, policy.IsActive, policy.ID)
+	if err != nil {
+		zlog.Error().Err(err).Int("policy_id", policy.ID).Msg("Error updating auto-approval policy")
+		return fmt.Errorf("error updating auto-approval policy %d: %w", policy.ID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// DeletePolicy menghapus kebijakan.
+func (r *autoApprovalPolicyRepo) DeletePolicy(ctx context.Context, id int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM auto_approval_policies WHERE id = $1`, id)
+	if err != nil {
+		zlog.Error().Err(err).Int("policy_id", id).Msg("Error deleting auto-approval policy")
+		return fmt.Errorf("error deleting auto-approval policy %d: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	zlog.Info().Int("policy_id", id).Msg("Auto-approval policy deleted successfully")
+	return nil
+}
+
+// GetDueUserTaskIDs mengambil submission yang sudah jatuh tempo untuk disetujui otomatis (terlama lebih dulu).
+func (r *autoApprovalPolicyRepo) GetDueUserTaskIDs(ctx context.Context, now time.Time, limit int) ([]int, error) {
+	query := `SELECT ut.id
+              FROM user_tasks ut
+              ` + applicablePolicyJoin + `
+              WHERE ut.status = $1
+                AND ut.submitted_at + make_interval(mins => pol.grace_period_minutes) <= $2
+              ORDER BY ut.submitted_at ASC
+              LIMIT $3`
+	rows, err := r.db.Query(ctx, query, models.UserTaskStatusSubmitted, now, limit)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Error querying due auto-approvals")
+		return nil, fmt.Errorf("error getting due auto-approvals: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if scanErr := rows.Scan(&id); scanErr != nil {
+			return nil, fmt.Errorf("error scanning due auto-approval: %w", scanErr)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating due auto-approvals: %w", err)
+	}
+	return ids, nil
+}
+
+// --- Metode Transaksional ---
+
+// GetDuePolicyForUserTaskTx mengambil kebijakan yang berlaku untuk UserTask jika sudah jatuh tempo.
+func (r *autoApprovalPolicyRepo) GetDuePolicyForUserTaskTx(ctx context.Context, tx pgx.Tx, userTaskID int, now time.Time) (*models.AutoApprovalPolicy, error) {
+	query := `SELECT pol.id, pol.created_by_user_id, pol.task_id, pol.child_id, pol.grace_period_minutes,
+                     pol.is_active, pol.created_at, pol.updated_at
+              FROM user_tasks ut
+              ` + applicablePolicyJoin + `
+              WHERE ut.id = $1
+                AND ut.submitted_at + make_interval(mins => pol.grace_period_minutes) <= $2`
+	policy := &models.AutoApprovalPolicy{}
+	if err := scanAutoApprovalPolicyRow(tx.QueryRow(ctx, query, userTaskID, now), policy); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("user_task_id", userTaskID).Msg("RepoTx: Error getting due auto-approval policy")
+		return nil, fmt.Errorf("repoTx error getting auto-approval policy for user_task %d: %w", userTaskID, err)
+	}
+	return policy, nil
+}
@@ -0,0 +1,311 @@
+// internal/repository/badge_repo.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type badgeRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewBadgeRepository membuat instance baru dari BadgeRepository.
+func NewBadgeRepository(db *pgxpool.Pool) BadgeRepository {
+	return &badgeRepo{db: db}
+}
+
+// badgeColumns adalah kolom badge (alias b) beserta nama tugas (alias t) untuk scanBadge.
+const badgeColumns = `b.id, b.code, COALESCE(b.created_by_user_id, 0), b.name, b.description, b.icon, b.rule_type,
+                COALESCE(b.threshold, 0), COALESCE(b.task_id, 0), t.task_name, b.created_at, b.updated_at`
+
+// badgeSelect memilih badge beserta nama tugas terkait.
+const badgeSelect = `SELECT ` + badgeColumns + `
+              FROM badges b
+              LEFT JOIN tasks t ON t.id = b.task_id`
+
+// badgeApplicableToChild membatasi badge ke badge sistem dan badge custom milik orang tua anak ($1).
+const badgeApplicableToChild = `(b.created_by_user_id IS NULL
+                   OR b.created_by_user_id IN (SELECT parent_id FROM user_relationship WHERE child_id = $1))`
+
+// scanBadge memindai satu baris badge (urutan kolom badgeColumns).
+func scanBadge(row pgx.Row, badge *models.Badge, extra ...any) error {
+	var code, description, icon, taskName sql.NullString
+	dest := []any{&badge.ID, &code, &badge.CreatedByUserID, &badge.Name, &description, &icon, &badge.RuleType,
+		&badge.Threshold, &badge.TaskID, &taskName, &badge.CreatedAt, &badge.UpdatedAt}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+	badge.Code = code.String
+	badge.Description = description.String
+	badge.Icon = icon.String
+	badge.TaskName = taskName.String
+	return nil
+}
+
+// collectBadges memindai semua baris badge lalu menutup rows.
+func collectBadges(rows pgx.Rows) ([]models.Badge, error) {
+	defer rows.Close()
+	badges := []models.Badge{}
+	for rows.Next() {
+		var badge models.Badge
+		if err := scanBadge(rows, &badge); err != nil {
+			zlog.Warn().Err(err).Msg("Error scanning badge row")
+			return nil, fmt.Errorf("error scanning badge: %w", err)
+		}
+		badges = append(badges, badge)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating badges: %w", err)
+	}
+	return badges, nil
+}
+
+// nullableThreshold menyimpan ambang 0 (badge manual) sebagai NULL.
+func nullableThreshold(badge *models.Badge) any {
+	if badge.RuleType == models.BadgeRuleManual {
+		return nil
+	}
+	return badge.Threshold
+}
+
+// CreateBadge membuat badge custom milik parent dan mengisi ID serta timestamp.
+func (r *badgeRepo) CreateBadge(ctx context.Context, badge *models.Badge) error {
+	query := `INSERT INTO badges (created_by_user_id, name, description, icon, rule_type, threshold, task_id)
+              VALUES ($1, $2, $3, $4, $5, $6, $7)
+              RETURNING id, created_at, updated_at`
+	err := r.db.QueryRow(ctx, query, badge.CreatedByUserID, badge.Name, nullableText(badge.Description), nullableText(badge.Icon),
+		badge.RuleType, nullableThreshold(badge), nullableID(badge.TaskID)).
+		Scan(&badge.ID, &badge.CreatedAt, &badge.UpdatedAt)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			return fmt.Errorf("badge with this name already exists")
+		}
+		zlog.Error().Err(err).Int("parent_id", badge.CreatedByUserID).Msg("Error creating badge")
+		return fmt.Errorf("error creating badge: %w", err)
+	}
+	zlog.Info().Int("badge_id", badge.ID).Int("parent_id", badge.CreatedByUserID).Msg("Badge created")
+	return nil
+}
+
+// GetBadgesForParent mengambil badge sistem dan badge custom milik parent (badge sistem dulu).
+func (r *badgeRepo) GetBadgesForParent(ctx context.Context, parentID int) ([]models.Badge, error) {
+	query := badgeSelect + ` WHERE b.created_by_user_id IS NULL OR b.created_by_user_id = $1
+              ORDER BY b.created_by_user_id NULLS FIRST, b.name`
+	rows, err := r.db.Query(ctx, query, parentID)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", parentID).Msg("Error querying badges")
+		return nil, fmt.Errorf("error getting badges for parent %d: %w", parentID, err)
+	}
+	return collectBadges(rows)
+}
+
+// GetBadgeByID mengambil badge berdasarkan ID. Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+func (r *badgeRepo) GetBadgeByID(ctx context.Context, badgeID int) (*models.Badge, error) {
+	badge := &models.Badge{}
+	if err := scanBadge(r.db.QueryRow(ctx, badgeSelect+` WHERE b.id = $1`, badgeID), badge); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("badge_id", badgeID).Msg("Error getting badge")
+		return nil, fmt.Errorf("error getting badge %d: %w", badgeID, err)
+	}
+	return badge, nil
+}
+
+// UpdateBadge memperbarui badge custom milik badge.CreatedByUserID. Badge yang sudah diraih tidak dicabut.
+// Mengembalikan pgx.ErrNoRows jika tidak ada atau bukan milik parent.
+func (r *badgeRepo) UpdateBadge(ctx context.Context, badge *models.Badge) error {
+	query := `UPDATE badges
+              SET name = $1, description = $2, icon = $3, rule_type = $4, threshold = $5, task_id = $6
+              WHERE id = $7 AND created_by_user_id = $8
+              RETURNING created_at, updated_at`
+	err := r.db.QueryRow(ctx, query, badge.Name, nullableText(badge.Description), nullableText(badge.Icon), badge.RuleType,
+		nullableThreshold(badge), nullableID(badge.TaskID), badge.ID, badge.CreatedByUserID).
+		Scan(&badge.CreatedAt, &badge.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgx.ErrNoRows
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			return fmt.Errorf("badge with this name already exists")
+		}
+		zlog.Error().Err(err).Int("badge_id", badge.ID).Msg("Error updating badge")
+		return fmt.Errorf("error updating badge %d: %w", badge.ID, err)
+	}
+	return nil
+}
+
+// DeleteBadge menghapus badge custom milik parent (beserta pencapaian anak atas badge tersebut).
+// Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+func (r *badgeRepo) DeleteBadge(ctx context.Context, badgeID int, parentID int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM badges WHERE id = $1 AND created_by_user_id = $2`, badgeID, parentID)
+	if err != nil {
+		zlog.Error().Err(err).Int("badge_id", badgeID).Msg("Error deleting badge")
+		return fmt.Errorf("error deleting badge %d: %w", badgeID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	zlog.Info().Int("badge_id", badgeID).Int("parent_id", parentID).Msg("Badge deleted")
+	return nil
+}
+
+// GetUnearnedRuleBadges mengambil badge berbasis aturan (bukan 'manual') yang berlaku untuk anak
+// namun belum diraihnya.
+func (r *badgeRepo) GetUnearnedRuleBadges(ctx context.Context, childID int) ([]models.Badge, error) {
+	query := badgeSelect + ` WHERE ` + badgeApplicableToChild + `
+                AND b.rule_type <> 'manual'
+                AND NOT EXISTS (SELECT 1 FROM child_badges cb WHERE cb.child_id = $1 AND cb.badge_id = b.id)
+              ORDER BY b.id`
+	rows, err := r.db.Query(ctx, query, childID)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error querying unearned badges")
+		return nil, fmt.Errorf("error getting unearned badges for child %d: %w", childID, err)
+	}
+	return collectBadges(rows)
+}
+
+// AwardBadge mencatat badge yang diraih anak dan mengisi ID serta AwardedAt.
+// Mengembalikan false (tanpa error) jika anak sudah memiliki badge tersebut.
+func (r *badgeRepo) AwardBadge(ctx context.Context, award *models.ChildBadge) (bool, error) {
+	query := `INSERT INTO child_badges (child_id, badge_id, awarded_by_user_id)
+              VALUES ($1, $2, $3)
+              ON CONFLICT (child_id, badge_id) DO NOTHING
+              RETURNING id, awarded_at`
+	err := r.db.QueryRow(ctx, query, award.ChildID, award.Badge.ID, nullableID(award.AwardedByUserID)).Scan(&award.ID, &award.AwardedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		zlog.Error().Err(err).Int("child_id", award.ChildID).Int("badge_id", award.Badge.ID).Msg("Error awarding badge")
+		return false, fmt.Errorf("error awarding badge %d to child %d: %w", award.Badge.ID, award.ChildID, err)
+	}
+	zlog.Info().Int("child_id", award.ChildID).Int("badge_id", award.Badge.ID).Msg("Badge awarded")
+	return true, nil
+}
+
+// GetChildBadges mengambil badge yang sudah diraih anak (terbaru dulu).
+func (r *badgeRepo) GetChildBadges(ctx context.Context, childID int) ([]models.ChildBadge, error) {
+	query := `SELECT ` + badgeColumns + `, cb.id, COALESCE(cb.awarded_by_user_id, 0), cb.awarded_at
+              FROM child_badges cb
+              JOIN badges b ON b.id = cb.badge_id
+              LEFT JOIN tasks t ON t.id = b.task_id
+              WHERE cb.child_id = $1
+              ORDER BY cb.awarded_at DESC, cb.id DESC`
+	rows, err := r.db.Query(ctx, query, childID)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error querying child badges")
+		return nil, fmt.Errorf("error getting badges of child %d: %w", childID, err)
+	}
+	defer rows.Close()
+
+	awards := []models.ChildBadge{}
+	for rows.Next() {
+		award := models.ChildBadge{ChildID: childID}
+		if err := scanBadge(rows, &award.Badge, &award.ID, &award.AwardedByUserID, &award.AwardedAt); err != nil {
+			zlog.Warn().Err(err).Int("child_id", childID).Msg("Error scanning child badge row")
+			return nil, fmt.Errorf("error scanning child badge: %w", err)
+		}
+		awards = append(awards, award)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating child badges: %w", err)
+	}
+	return awards, nil
+}
+
+// --- Metrik Aturan Badge ---
+
+// CountApprovedTasks menghitung tugas anak yang disetujui (taskID 0 = semua tugas).
+func (r *badgeRepo) CountApprovedTasks(ctx context.Context, childID int, taskID int) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM user_tasks
+              WHERE user_id = $1 AND status = $2 AND ($3::INT IS NULL OR task_id = $3)`
+	if err := r.db.QueryRow(ctx, query, childID, models.UserTaskStatusApproved, nullableID(taskID)).Scan(&count); err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error counting approved tasks")
+		return 0, fmt.Errorf("error counting approved tasks for child %d: %w", childID, err)
+	}
+	return count, nil
+}
+
+// GetApprovedTaskCompletionTimes mengambil waktu penyelesaian tugas anak yang disetujui sejak waktu since
+// (taskID 0 = semua tugas), untuk menghitung streak di zona waktu anak.
+func (r *badgeRepo) GetApprovedTaskCompletionTimes(ctx context.Context, childID int, taskID int, since time.Time) ([]time.Time, error) {
+	query := `SELECT completed_at FROM user_tasks
+              WHERE user_id = $1 AND status = $2 AND completed_at >= $3 AND ($4::INT IS NULL OR task_id = $4)
+              ORDER BY completed_at`
+	rows, err := r.db.Query(ctx, query, childID, models.UserTaskStatusApproved, since, nullableID(taskID))
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error querying task completion times")
+		return nil, fmt.Errorf("error getting task completion times for child %d: %w", childID, err)
+	}
+	defer rows.Close()
+
+	times := []time.Time{}
+	for rows.Next() {
+		var completedAt time.Time
+		if err := rows.Scan(&completedAt); err != nil {
+			return nil, fmt.Errorf("error scanning completion time: %w", err)
+		}
+		times = append(times, completedAt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating completion times: %w", err)
+	}
+	return times, nil
+}
+
+// CountRewardClaims menghitung klaim hadiah anak yang tidak ditolak.
+func (r *badgeRepo) CountRewardClaims(ctx context.Context, childID int) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM user_rewards WHERE user_id = $1 AND status <> $2`
+	if err := r.db.QueryRow(ctx, query, childID, models.UserRewardStatusRejected).Scan(&count); err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error counting reward claims")
+		return 0, fmt.Errorf("error counting reward claims for child %d: %w", childID, err)
+	}
+	return count, nil
+}
+
+// GetChildIDsWithReachableBalanceBadges mengambil ID anak yang saldo poinnya sudah memenuhi badge
+// 'points_balance' yang berlaku namun belum diraih (saldo bisa berubah oleh entri ledger apa pun),
+// dengan ID > afterChildID (keyset pagination untuk worker).
+func (r *badgeRepo) GetChildIDsWithReachableBalanceBadges(ctx context.Context, afterChildID int, limit int) ([]int, error) {
+	query := `SELECT DISTINCT pb.user_id
+              FROM point_balances pb
+              JOIN badges b ON b.rule_type = $1 AND pb.balance >= b.threshold
+              WHERE (b.created_by_user_id IS NULL
+                     OR b.created_by_user_id IN (SELECT parent_id FROM user_relationship WHERE child_id = pb.user_id))
+                AND NOT EXISTS (SELECT 1 FROM child_badges cb WHERE cb.child_id = pb.user_id AND cb.badge_id = b.id)
+                AND pb.user_id > $2
+              ORDER BY pb.user_id
+              LIMIT $3`
+	rows, err := r.db.Query(ctx, query, models.BadgeRulePointsBalance, afterChildID, limit)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Error querying children with reachable balance badges")
+		return nil, fmt.Errorf("error getting children with reachable balance badges: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error scanning child ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating children with reachable balance badges: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,281 @@
+// internal/repository/cash_out_repo.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type cashOutRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewCashOutRepository membuat instance baru dari CashOutRepository.
+func NewCashOutRepository(db *pgxpool.Pool) CashOutRepository {
+	return &cashOutRepo{db: db}
+}
+
+const cashOutPolicyColumns = `p.id, p.created_by_user_id, COALESCE(p.child_id, 0), p.points, p.minor_units, p.currency_code, p.min_points,
+                p.created_at, p.updated_at`
+
+// scanCashOutPolicy memindai satu baris kurs pencairan.
+func scanCashOutPolicy(row pgx.Row, policy *models.CashOutPolicy) error {
+	return row.Scan(&policy.ID, &policy.CreatedByUserID, &policy.ChildID, &policy.Points, &policy.MinorUnits, &policy.CurrencyCode,
+		&policy.MinPoints, &policy.CreatedAt, &policy.UpdatedAt)
+}
+
+// cashOutRequestSelect memilih kolom permintaan cash-out beserta username anak dan entri ledger uang (jika sudah dibayar).
+const cashOutRequestSelect = `SELECT cr.id, cr.child_id, u.username, cr.points, cr.amount_minor, cr.currency_code, cr.note, cr.status,
+                COALESCE(cr.reviewed_by_user_id, 0), cr.reviewed_at, cr.review_note,
+                COALESCE(cr.debit_transaction_id, 0), COALESCE(cr.refund_transaction_id, 0), cr.created_at, cr.updated_at,
+                mt.id, mt.payout_method, mt.reference, COALESCE(mt.paid_by_user_id, 0), mt.paid_at, mt.created_at
+              FROM cash_out_requests cr
+              JOIN users u ON u.id = cr.child_id
+              LEFT JOIN money_transactions mt ON mt.cash_out_request_id = cr.id`
+
+// scanCashOutRequest memindai satu baris permintaan cash-out.
+func scanCashOutRequest(row pgx.Row, request *models.CashOutRequest) error {
+	var note, reviewNote, reference, payoutMethod sql.NullString
+	var payoutID sql.NullInt64
+	var paidBy int
+	var paidAt, payoutCreatedAt *time.Time
+	err := row.Scan(&request.ID, &request.ChildID, &request.ChildUsername, &request.Points, &request.AmountMinor,
+		&request.CurrencyCode, &note, &request.Status, &request.ReviewedByUserID, &request.ReviewedAt, &reviewNote,
+		&request.DebitTransactionID, &request.RefundTransactionID, &request.CreatedAt, &request.UpdatedAt,
+		&payoutID, &payoutMethod, &reference, &paidBy, &paidAt, &payoutCreatedAt)
+	if err != nil {
+		return err
+	}
+	request.Note = note.String
+	request.ReviewNote = reviewNote.String
+	if payoutID.Valid {
+		request.Payout = &models.MoneyTransaction{
+			ID:               int(payoutID.Int64),
+			ChildID:          request.ChildID,
+			CashOutRequestID: request.ID,
+			Points:           request.Points,
+			AmountMinor:      request.AmountMinor,
+			CurrencyCode:     request.CurrencyCode,
+			PayoutMethod:     models.PayoutMethod(payoutMethod.String),
+			Reference:        reference.String,
+			PaidByUserID:     paidBy,
+			PaidAt:           *paidAt,
+			CreatedAt:        *payoutCreatedAt,
+		}
+	}
+	return nil
+}
+
+// queryCashOutRequests menjalankan query hitung dan query data permintaan cash-out dengan paginasi.
+func (r *cashOutRepo) queryCashOutRequests(ctx context.Context, where string, args []any, page, limit int) ([]models.CashOutRequest, int, error) {
+	var totalCount int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM cash_out_requests cr WHERE `+where, args...).Scan(&totalCount); err != nil {
+		zlog.Error().Err(err).Msg("Error counting cash-out requests")
+		return nil, 0, fmt.Errorf("error counting cash-out requests: %w", err)
+	}
+	if totalCount == 0 {
+		return []models.CashOutRequest{}, 0, nil
+	}
+
+	offset := (page - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+	query := fmt.Sprintf(`%s WHERE %s ORDER BY cr.created_at DESC, cr.id DESC LIMIT $%d OFFSET $%d`,
+		cashOutRequestSelect, where, len(args)+1, len(args)+2)
+	rows, err := r.db.Query(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Error querying cash-out requests")
+		return nil, totalCount, fmt.Errorf("error getting cash-out requests: %w", err)
+	}
+	defer rows.Close()
+
+	requests := []models.CashOutRequest{}
+	for rows.Next() {
+		var request models.CashOutRequest
+		if err := scanCashOutRequest(rows, &request); err != nil {
+			zlog.Warn().Err(err).Msg("Error scanning cash-out request row")
+			return nil, totalCount, fmt.Errorf("error scanning cash-out request: %w", err)
+		}
+		requests = append(requests, request)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, totalCount, fmt.Errorf("error iterating cash-out requests: %w", err)
+	}
+	return requests, totalCount, nil
+}
+
+// UpsertPolicy membuat atau memperbarui kurs pencairan poin milik parent untuk cakupan anaknya.
+func (r *cashOutRepo) UpsertPolicy(ctx context.Context, policy *models.CashOutPolicy) error {
+	query := `INSERT INTO cash_out_policies (created_by_user_id, child_id, points, minor_units, currency_code, min_points)
+              VALUES ($1, $2, $3, $4, $5, $6)
+              ON CONFLICT ` + familyPolicyConflict + ` DO UPDATE
+              SET points = EXCLUDED.points,
+                  minor_units = EXCLUDED.minor_units,
+                  currency_code = EXCLUDED.currency_code,
+                  min_points = EXCLUDED.min_points
+              RETURNING id, created_at, updated_at`
+	err := r.db.QueryRow(ctx, query, policy.CreatedByUserID, nullableID(policy.ChildID), policy.Points, policy.MinorUnits, policy.CurrencyCode,
+		policy.MinPoints).Scan(&policy.ID, &policy.CreatedAt, &policy.UpdatedAt)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", policy.CreatedByUserID).Int("child_id", policy.ChildID).Msg("Error upserting cash-out policy")
+		return fmt.Errorf("error saving cash-out policy: %w", err)
+	}
+	zlog.Info().Int("policy_id", policy.ID).Int("parent_id", policy.CreatedByUserID).Int("child_id", policy.ChildID).
+		Int("points", policy.Points).Int("minor_units", policy.MinorUnits).Str("currency_code", policy.CurrencyCode).Msg("Cash-out policy saved")
+	return nil
+}
+
+// getCashOutPolicy membaca satu kurs pencairan dengan klausa filter kebijakan keluarga.
+func (r *cashOutRepo) getCashOutPolicy(ctx context.Context, filter string, args ...any) (*models.CashOutPolicy, error) {
+	query := `SELECT ` + cashOutPolicyColumns + ` FROM cash_out_policies p` + filter
+	policy := &models.CashOutPolicy{}
+	if err := scanCashOutPolicy(r.db.QueryRow(ctx, query, args...), policy); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Interface("args", args).Msg("Error getting cash-out policy")
+		return nil, fmt.Errorf("error getting cash-out policy: %w", err)
+	}
+	return policy, nil
+}
+
+// GetPolicyByOwner mendapatkan kurs pencairan milik parent untuk cakupan anak (0 = semua anak).
+func (r *cashOutRepo) GetPolicyByOwner(ctx context.Context, parentID int, childID int) (*models.CashOutPolicy, error) {
+	return r.getCashOutPolicy(ctx, familyPolicyOwnedBy, parentID, childID)
+}
+
+// GetPolicyForChild mendapatkan kurs pencairan yang berlaku untuk anak dari kebijakan orang tuanya.
+func (r *cashOutRepo) GetPolicyForChild(ctx context.Context, childID int) (*models.CashOutPolicy, error) {
+	return r.getCashOutPolicy(ctx, familyPolicyForChild, childID)
+}
+
+// DeletePolicy menghapus kurs pencairan milik parent untuk cakupan anak (0 = semua anak). Mengembalikan
+// pgx.ErrNoRows jika belum ada. Permintaan yang masih 'pending' tetap bisa direview dengan nilai yang tercatat saat permintaan.
+func (r *cashOutRepo) DeletePolicy(ctx context.Context, parentID int, childID int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM cash_out_policies p`+familyPolicyOwnedBy, parentID, childID)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", parentID).Int("child_id", childID).Msg("Error deleting cash-out policy")
+		return fmt.Errorf("error deleting cash-out policy: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// GetRequestsByChildID mengambil permintaan cash-out anak (terbaru dulu), opsional difilter status.
+func (r *cashOutRepo) GetRequestsByChildID(ctx context.Context, childID int, status models.CashOutStatus, page, limit int) ([]models.CashOutRequest, int, error) {
+	where := `cr.child_id = $1 AND ($2 = '' OR cr.status::TEXT = $2)`
+	return r.queryCashOutRequests(ctx, where, []any{childID, string(status)}, page, limit)
+}
+
+// GetRequestsByParentID mengambil permintaan cash-out anak-anak parent, opsional difilter status.
+func (r *cashOutRepo) GetRequestsByParentID(ctx context.Context, parentID int, status models.CashOutStatus, page, limit int) ([]models.CashOutRequest, int, error) {
+	where := `cr.child_id IN (SELECT child_id FROM user_relationship WHERE parent_id = $1)
+              AND ($2 = '' OR cr.status::TEXT = $2)`
+	return r.queryCashOutRequests(ctx, where, []any{parentID, string(status)}, page, limit)
+}
+
+// GetMoneyTransactionsByChildID mengambil entri ledger uang anak dengan paid_at dalam rentang [from, to), terlama dulu.
+func (r *cashOutRepo) GetMoneyTransactionsByChildID(ctx context.Context, childID int, from, to time.Time) ([]models.MoneyTransaction, error) {
+	query := `SELECT mt.id, mt.child_id, mt.cash_out_request_id, cr.points, mt.amount_minor, mt.currency_code,
+                     mt.payout_method, mt.reference, COALESCE(mt.paid_by_user_id, 0), mt.paid_at, mt.created_at
+              FROM money_transactions mt
+              JOIN cash_out_requests cr ON cr.id = mt.cash_out_request_id
+              WHERE mt.child_id = $1 AND mt.paid_at >= $2 AND mt.paid_at < $3
+              ORDER BY mt.paid_at, mt.id`
+	rows, err := r.db.Query(ctx, query, childID, from, to)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error querying money transactions")
+		return nil, fmt.Errorf("error getting money transactions for child %d: %w", childID, err)
+	}
+	defer rows.Close()
+
+	payouts := []models.MoneyTransaction{}
+	for rows.Next() {
+		var payout models.MoneyTransaction
+		var reference sql.NullString
+		if err := rows.Scan(&payout.ID, &payout.ChildID, &payout.CashOutRequestID, &payout.Points, &payout.AmountMinor,
+			&payout.CurrencyCode, &payout.PayoutMethod, &reference, &payout.PaidByUserID, &payout.PaidAt, &payout.CreatedAt); err != nil {
+			zlog.Warn().Err(err).Msg("Error scanning money transaction row")
+			return nil, fmt.Errorf("error scanning money transaction: %w", err)
+		}
+		payout.Reference = reference.String
+		payouts = append(payouts, payout)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating money transactions: %w", err)
+	}
+	return payouts, nil
+}
+
+// --- Metode Transaksional ---
+
+// CreateRequestTx menyimpan permintaan cash-out baru dan mengisi ID serta timestamp.
+func (r *cashOutRepo) CreateRequestTx(ctx context.Context, tx pgx.Tx, request *models.CashOutRequest) error {
+	query := `INSERT INTO cash_out_requests (child_id, points, amount_minor, currency_code, note, status, debit_transaction_id)
+              VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7)
+              RETURNING id, created_at, updated_at`
+	err := tx.QueryRow(ctx, query, request.ChildID, request.Points, request.AmountMinor, request.CurrencyCode, request.Note,
+		request.Status, nullableID(request.DebitTransactionID)).Scan(&request.ID, &request.CreatedAt, &request.UpdatedAt)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", request.ChildID).Msg("RepoTx: Error creating cash-out request")
+		return fmt.Errorf("repoTx error creating cash-out request: %w", err)
+	}
+	return nil
+}
+
+// GetRequestForUpdateTx mengambil permintaan cash-out dan menguncinya hingga transaksi selesai.
+func (r *cashOutRepo) GetRequestForUpdateTx(ctx context.Context, tx pgx.Tx, requestID int) (*models.CashOutRequest, error) {
+	request := &models.CashOutRequest{}
+	if err := scanCashOutRequest(tx.QueryRow(ctx, cashOutRequestSelect+` WHERE cr.id = $1 FOR UPDATE OF cr`, requestID), request); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("cash_out_id", requestID).Msg("RepoTx: Error locking cash-out request")
+		return nil, fmt.Errorf("repoTx error getting cash-out request %d: %w", requestID, err)
+	}
+	return request, nil
+}
+
+// UpdateRequestReviewTx menyimpan hasil review permintaan cash-out. Hanya permintaan 'pending' yang bisa diubah.
+func (r *cashOutRepo) UpdateRequestReviewTx(ctx context.Context, tx pgx.Tx, request *models.CashOutRequest) error {
+	query := `UPDATE cash_out_requests
+              SET status = $2, reviewed_by_user_id = $3, reviewed_at = NOW(), review_note = NULLIF($4, ''),
+                  refund_transaction_id = $5
+              WHERE id = $1 AND status = 'pending'
+              RETURNING reviewed_at, updated_at`
+	err := tx.QueryRow(ctx, query, request.ID, request.Status, nullableID(request.ReviewedByUserID), request.ReviewNote,
+		nullableID(request.RefundTransactionID)).Scan(&request.ReviewedAt, &request.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("cash_out_id", request.ID).Msg("RepoTx: Error updating cash-out review")
+		return fmt.Errorf("repoTx error updating cash-out request %d: %w", request.ID, err)
+	}
+	return nil
+}
+
+// CreateMoneyTransactionTx mencatat pembayaran ke ledger uang dan mengisi ID serta timestamp.
+func (r *cashOutRepo) CreateMoneyTransactionTx(ctx context.Context, tx pgx.Tx, payout *models.MoneyTransaction) error {
+	query := `INSERT INTO money_transactions (child_id, cash_out_request_id, amount_minor, currency_code, payout_method, reference, paid_by_user_id)
+              VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)
+              RETURNING id, paid_at, created_at`
+	err := tx.QueryRow(ctx, query, payout.ChildID, payout.CashOutRequestID, payout.AmountMinor, payout.CurrencyCode,
+		payout.PayoutMethod, payout.Reference, nullableID(payout.PaidByUserID)).Scan(&payout.ID, &payout.PaidAt, &payout.CreatedAt)
+	if err != nil {
+		zlog.Error().Err(err).Int("cash_out_id", payout.CashOutRequestID).Msg("RepoTx: Error creating money transaction")
+		return fmt.Errorf("repoTx error creating money transaction: %w", err)
+	}
+	return nil
+}
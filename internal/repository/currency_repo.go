@@ -0,0 +1,264 @@
+// internal/repository/currency_repo.go
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type currencyRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewCurrencyRepository membuat instance baru dari CurrencyRepository.
+func NewCurrencyRepository(db *pgxpool.Pool) CurrencyRepository {
+	return &currencyRepo{db: db}
+}
+
+const currencyColumns = `id, created_by_user_id, name, COALESCE(symbol, ''), created_at, updated_at`
+
+// scanCurrency memindai satu baris mata uang.
+func scanCurrency(row pgx.Row, currency *models.Currency) error {
+	return row.Scan(&currency.ID, &currency.CreatedByUserID, &currency.Name, &currency.Symbol, &currency.CreatedAt, &currency.UpdatedAt)
+}
+
+const exchangeRuleColumns = `id, created_by_user_id, COALESCE(from_currency_id, 0), COALESCE(to_currency_id, 0),
+                from_amount, to_amount, created_at, updated_at`
+
+// scanExchangeRule memindai satu baris aturan tukar.
+func scanExchangeRule(row pgx.Row, rule *models.CurrencyExchangeRule) error {
+	return row.Scan(&rule.ID, &rule.CreatedByUserID, &rule.FromCurrencyID, &rule.ToCurrencyID,
+		&rule.FromAmount, &rule.ToAmount, &rule.CreatedAt, &rule.UpdatedAt)
+}
+
+// nullableSymbol mengubah simbol kosong menjadi NULL.
+func nullableSymbol(symbol string) any {
+	if symbol == "" {
+		return nil
+	}
+	return symbol
+}
+
+// CreateCurrency membuat mata uang baru milik parent dan mengisi ID serta timestamp.
+func (r *currencyRepo) CreateCurrency(ctx context.Context, currency *models.Currency) error {
+	query := `INSERT INTO currencies (created_by_user_id, name, symbol)
+              VALUES ($1, $2, $3)
+              RETURNING id, created_at, updated_at`
+	err := r.db.QueryRow(ctx, query, currency.CreatedByUserID, currency.Name, nullableSymbol(currency.Symbol)).
+		Scan(&currency.ID, &currency.CreatedAt, &currency.UpdatedAt)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			return fmt.Errorf("currency with this name already exists")
+		}
+		zlog.Error().Err(err).Int("parent_id", currency.CreatedByUserID).Msg("Error creating currency")
+		return fmt.Errorf("error creating currency: %w", err)
+	}
+	zlog.Info().Int("currency_id", currency.ID).Int("parent_id", currency.CreatedByUserID).Msg("Currency created")
+	return nil
+}
+
+// GetCurrenciesByOwnerID mengambil semua mata uang milik parent.
+func (r *currencyRepo) GetCurrenciesByOwnerID(ctx context.Context, parentID int) ([]models.Currency, error) {
+	query := `SELECT ` + currencyColumns + ` FROM currencies WHERE created_by_user_id = $1 ORDER BY name`
+	rows, err := r.db.Query(ctx, query, parentID)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", parentID).Msg("Error querying currencies")
+		return nil, fmt.Errorf("error getting currencies for parent %d: %w", parentID, err)
+	}
+	defer rows.Close()
+
+	currencies := []models.Currency{}
+	for rows.Next() {
+		var currency models.Currency
+		if err := scanCurrency(rows, &currency); err != nil {
+			zlog.Warn().Err(err).Int("parent_id", parentID).Msg("Error scanning currency row")
+			return nil, fmt.Errorf("error scanning currency: %w", err)
+		}
+		currencies = append(currencies, currency)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating currencies: %w", err)
+	}
+	return currencies, nil
+}
+
+// GetCurrencyByID mengambil mata uang berdasarkan ID. Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+func (r *currencyRepo) GetCurrencyByID(ctx context.Context, currencyID int) (*models.Currency, error) {
+	currency := &models.Currency{}
+	err := scanCurrency(r.db.QueryRow(ctx, `SELECT `+currencyColumns+` FROM currencies WHERE id = $1`, currencyID), currency)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("currency_id", currencyID).Msg("Error getting currency")
+		return nil, fmt.Errorf("error getting currency %d: %w", currencyID, err)
+	}
+	return currency, nil
+}
+
+// UpdateCurrency memperbarui nama dan simbol mata uang milik parent.
+// Mengembalikan pgx.ErrNoRows jika mata uang tidak ada atau bukan milik parent.
+func (r *currencyRepo) UpdateCurrency(ctx context.Context, currency *models.Currency) error {
+	query := `UPDATE currencies SET name = $1, symbol = $2
+              WHERE id = $3 AND created_by_user_id = $4
+              RETURNING created_at, updated_at`
+	err := r.db.QueryRow(ctx, query, currency.Name, nullableSymbol(currency.Symbol), currency.ID, currency.CreatedByUserID).
+		Scan(&currency.CreatedAt, &currency.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgx.ErrNoRows
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			return fmt.Errorf("currency with this name already exists")
+		}
+		zlog.Error().Err(err).Int("currency_id", currency.ID).Msg("Error updating currency")
+		return fmt.Errorf("error updating currency %d: %w", currency.ID, err)
+	}
+	return nil
+}
+
+// DeleteCurrency menghapus mata uang milik parent. Mata uang yang masih dipakai tugas, hadiah,
+// atau sudah tercatat di ledger tidak bisa dihapus. Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+func (r *currencyRepo) DeleteCurrency(ctx context.Context, currencyID int, parentID int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM currencies WHERE id = $1 AND created_by_user_id = $2`, currencyID, parentID)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
+			zlog.Warn().Err(err).Int("currency_id", currencyID).Msg("Attempted to delete currency that is still referenced")
+			return fmt.Errorf("cannot delete currency: it is still used by tasks, rewards or point transactions")
+		}
+		zlog.Error().Err(err).Int("currency_id", currencyID).Msg("Error deleting currency")
+		return fmt.Errorf("error deleting currency %d: %w", currencyID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	zlog.Info().Int("currency_id", currencyID).Int("parent_id", parentID).Msg("Currency deleted")
+	return nil
+}
+
+// GetBalancesByUserID mengambil saldo anak untuk poin dan setiap mata uang milik orang tuanya
+// (ditambah mata uang lain yang masih memiliki saldo, misal setelah relasi dilepas).
+func (r *currencyRepo) GetBalancesByUserID(ctx context.Context, childID int) ([]models.CurrencyBalance, error) {
+	query := `SELECT 0, 'Points', '', COALESCE((SELECT balance FROM point_balances WHERE user_id = $1), 0)
+              UNION ALL
+              SELECT * FROM (
+                  SELECT c.id, c.name, COALESCE(c.symbol, ''), COALESCE(cb.balance, 0)
+                  FROM currencies c
+                  LEFT JOIN currency_balances cb ON cb.currency_id = c.id AND cb.user_id = $1
+                  WHERE c.created_by_user_id IN (SELECT parent_id FROM user_relationship WHERE child_id = $1)
+                     OR cb.user_id IS NOT NULL
+                  ORDER BY c.name, c.id
+              ) extra`
+	rows, err := r.db.Query(ctx, query, childID)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error querying currency balances")
+		return nil, fmt.Errorf("error getting balances for child %d: %w", childID, err)
+	}
+	defer rows.Close()
+
+	balances := []models.CurrencyBalance{}
+	for rows.Next() {
+		var balance models.CurrencyBalance
+		if err := rows.Scan(&balance.CurrencyID, &balance.Name, &balance.Symbol, &balance.Balance); err != nil {
+			zlog.Warn().Err(err).Int("child_id", childID).Msg("Error scanning currency balance row")
+			return nil, fmt.Errorf("error scanning currency balance: %w", err)
+		}
+		balances = append(balances, balance)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating currency balances: %w", err)
+	}
+	return balances, nil
+}
+
+// CreateExchangeRule membuat aturan tukar milik parent dan mengisi ID serta timestamp.
+func (r *currencyRepo) CreateExchangeRule(ctx context.Context, rule *models.CurrencyExchangeRule) error {
+	query := `INSERT INTO currency_exchange_rules (created_by_user_id, from_currency_id, to_currency_id, from_amount, to_amount)
+              VALUES ($1, $2, $3, $4, $5)
+              RETURNING id, created_at, updated_at`
+	err := r.db.QueryRow(ctx, query, rule.CreatedByUserID, nullableID(rule.FromCurrencyID), nullableID(rule.ToCurrencyID),
+		rule.FromAmount, rule.ToAmount).Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case "23503":
+				return fmt.Errorf("invalid currency: currency not found")
+			case "23505":
+				return fmt.Errorf("exchange rule for this currency pair already exists")
+			}
+		}
+		zlog.Error().Err(err).Int("parent_id", rule.CreatedByUserID).Msg("Error creating exchange rule")
+		return fmt.Errorf("error creating exchange rule: %w", err)
+	}
+	zlog.Info().Int("rule_id", rule.ID).Int("parent_id", rule.CreatedByUserID).Msg("Exchange rule created")
+	return nil
+}
+
+// queryExchangeRules menjalankan query aturan tukar dengan kondisi WHERE tertentu.
+func (r *currencyRepo) queryExchangeRules(ctx context.Context, where string, arg int) ([]models.CurrencyExchangeRule, error) {
+	query := `SELECT ` + exchangeRuleColumns + ` FROM currency_exchange_rules WHERE ` + where + ` ORDER BY id`
+	rows, err := r.db.Query(ctx, query, arg)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Error querying exchange rules")
+		return nil, fmt.Errorf("error getting exchange rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []models.CurrencyExchangeRule{}
+	for rows.Next() {
+		var rule models.CurrencyExchangeRule
+		if err := scanExchangeRule(rows, &rule); err != nil {
+			zlog.Warn().Err(err).Msg("Error scanning exchange rule row")
+			return nil, fmt.Errorf("error scanning exchange rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating exchange rules: %w", err)
+	}
+	return rules, nil
+}
+
+// GetExchangeRulesByOwnerID mengambil semua aturan tukar milik parent.
+func (r *currencyRepo) GetExchangeRulesByOwnerID(ctx context.Context, parentID int) ([]models.CurrencyExchangeRule, error) {
+	return r.queryExchangeRules(ctx, `created_by_user_id = $1`, parentID)
+}
+
+// GetExchangeRulesForChild mengambil aturan tukar milik semua orang tua anak.
+func (r *currencyRepo) GetExchangeRulesForChild(ctx context.Context, childID int) ([]models.CurrencyExchangeRule, error) {
+	return r.queryExchangeRules(ctx, `created_by_user_id IN (SELECT parent_id FROM user_relationship WHERE child_id = $1)`, childID)
+}
+
+// GetExchangeRuleByID mengambil aturan tukar berdasarkan ID. Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+func (r *currencyRepo) GetExchangeRuleByID(ctx context.Context, ruleID int) (*models.CurrencyExchangeRule, error) {
+	rule := &models.CurrencyExchangeRule{}
+	err := scanExchangeRule(r.db.QueryRow(ctx, `SELECT `+exchangeRuleColumns+` FROM currency_exchange_rules WHERE id = $1`, ruleID), rule)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("rule_id", ruleID).Msg("Error getting exchange rule")
+		return nil, fmt.Errorf("error getting exchange rule %d: %w", ruleID, err)
+	}
+	return rule, nil
+}
+
+// DeleteExchangeRule menghapus aturan tukar milik parent. Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+func (r *currencyRepo) DeleteExchangeRule(ctx context.Context, ruleID int, parentID int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM currency_exchange_rules WHERE id = $1 AND created_by_user_id = $2`, ruleID, parentID)
+	if err != nil {
+		zlog.Error().Err(err).Int("rule_id", ruleID).Msg("Error deleting exchange rule")
+		return fmt.Errorf("error deleting exchange rule %d: %w", ruleID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
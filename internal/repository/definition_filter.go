@@ -0,0 +1,131 @@
+// internal/repository/definition_filter.go
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+)
+
+// definitionColumns memetakan nama kolom tabel definisi (tasks / rewards)
+// agar filter pencarian yang sama bisa dipakai di kedua repository.
+type definitionColumns struct {
+	name  string // Kolom nama (task_name / reward_name)
+	point string // Kolom poin (task_point / reward_point)
+}
+
+var (
+	taskDefinitionColumns   = definitionColumns{name: "task_name", point: "task_point"}
+	rewardDefinitionColumns = definitionColumns{name: "reward_name", point: "reward_point"}
+)
+
+// escapeLikePattern meng-escape karakter wildcard ILIKE agar input pengguna dicari apa adanya.
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// buildDefinitionFilter menyusun klausa WHERE tambahan (diawali " AND ...") dan klausa ORDER BY
+// dari DefinitionFilter. args berisi argumen yang sudah ada (misal: creatorID); argumen filter
+// ditambahkan setelahnya sehingga placeholder tetap berurutan.
+// Nilai sort hanya diambil dari daftar kolom yang diizinkan (tidak pernah dari input mentah).
+func buildDefinitionFilter(filter *models.DefinitionFilter, cols definitionColumns, args []any) (where string, orderBy string, outArgs []any) {
+	outArgs = args
+	if filter == nil {
+		return "", "created_at DESC", outArgs
+	}
+
+	var conditions []string
+	addArg := func(v any) string {
+		outArgs = append(outArgs, v)
+		return fmt.Sprintf("$%d", len(outArgs))
+	}
+
+	searchParam := ""
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		// Full-text pada nama & deskripsi, ditambah ILIKE pada nama untuk kata yang belum lengkap
+		searchParam = addArg(search)
+		likeParam := addArg("%" + escapeLikePattern(search) + "%")
+		conditions = append(conditions, fmt.Sprintf("(search_vector @@ websearch_to_tsquery('simple', %s) OR %s ILIKE %s)", searchParam, cols.name, likeParam))
+	}
+	if filter.Category != "" {
+		conditions = append(conditions, fmt.Sprintf("category = %s::definition_category", addArg(filter.Category)))
+	}
+	if tag := strings.ToLower(strings.TrimSpace(filter.Tag)); tag != "" {
+		// Operator @> agar index GIN pada kolom tags terpakai
+		conditions = append(conditions, fmt.Sprintf("tags @> ARRAY[%s]::text[]", addArg(tag)))
+	}
+	if filter.MinPoints != nil {
+		conditions = append(conditions, fmt.Sprintf("%s >= %s", cols.point, addArg(*filter.MinPoints)))
+	}
+	if filter.MaxPoints != nil {
+		conditions = append(conditions, fmt.Sprintf("%s <= %s", cols.point, addArg(*filter.MaxPoints)))
+	}
+	if len(conditions) > 0 {
+		where = " AND " + strings.Join(conditions, " AND ")
+	}
+
+	direction := "DESC"
+	if filter.SortOrder == "asc" {
+		direction = "ASC"
+	}
+	switch filter.SortBy {
+	case "name":
+		orderBy = fmt.Sprintf("%s %s, id %s", cols.name, direction, direction)
+	case "points":
+		orderBy = fmt.Sprintf("%s %s, id %s", cols.point, direction, direction)
+	case "relevance", "":
+		if searchParam != "" {
+			// Default saat mencari: paling relevan lebih dulu
+			orderBy = fmt.Sprintf("ts_rank(search_vector, websearch_to_tsquery('simple', %s)) DESC, created_at DESC", searchParam)
+		} else {
+			orderBy = "created_at " + direction
+		}
+	default:
+		orderBy = "created_at " + direction
+	}
+	return where, orderBy, outArgs
+}
+
+// normalizeTags merapikan tag (huruf kecil, tanpa spasi di tepi, tanpa duplikat).
+// Selalu mengembalikan slice non-nil karena kolom tags bersifat NOT NULL.
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// nullableCategory mengubah kategori kosong menjadi NULL untuk disimpan.
+func nullableCategory(category models.DefinitionCategory) any {
+	if category == "" {
+		return nil
+	}
+	return string(category)
+}
+
+// nullableLimitPeriod mengubah periode kuota kosong menjadi NULL.
+func nullableLimitPeriod(period models.RewardLimitPeriod) any {
+	if period == "" {
+		return nil
+	}
+	return string(period)
+}
+
+// nullableID mengubah ID/angka nol menjadi NULL untuk kolom referensi opsional.
+func nullableID(id int) any {
+	if id <= 0 {
+		return nil
+	}
+	return id
+}
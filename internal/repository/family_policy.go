@@ -0,0 +1,38 @@
+// internal/repository/family_policy.go
+package repository
+
+// Kebijakan keluarga (persetujuan bersama, transfer, kedaluwarsa poin, bunga, kurs cash-out) dimiliki parent
+// (created_by_user_id) dan berlaku untuk semua anaknya (child_id NULL) atau satu anak saja. Resolusinya
+// mengikuti applicablePolicyJoin pada auto-approval.
+
+// familyPolicyForChild memilih kebijakan `p` yang berlaku untuk anak $1: kebijakan khusus anak lebih dulu,
+// lalu kebijakan untuk semua anak, yang terlama lebih dulu. Pemilik kebijakan harus parent dari anak tersebut.
+const familyPolicyForChild = `
+              JOIN user_relationship ur ON ur.parent_id = p.created_by_user_id AND ur.child_id = $1
+              WHERE p.child_id IS NULL OR p.child_id = $1
+              ORDER BY (p.child_id IS NOT NULL) DESC, p.id ASC
+              LIMIT 1`
+
+// familyPolicyOwnedBy memilih kebijakan `p` milik parent $1 untuk cakupan anak $2 (0 = semua anak).
+const familyPolicyOwnedBy = `
+              WHERE p.created_by_user_id = $1 AND COALESCE(p.child_id, 0) = $2`
+
+// familyPolicyConflict adalah target ON CONFLICT untuk upsert kebijakan per parent & cakupan anak.
+const familyPolicyConflict = `(created_by_user_id, COALESCE(child_id, 0))`
+
+// familyPoliciesAfter memilih, untuk setiap anak `c` dengan child_id > $1 (maksimal $2 anak, terurut), kebijakan
+// `p` dari tabel yang berlaku untuknya dengan urutan yang sama seperti familyPolicyForChild. Anak tanpa
+// kebijakan dilewati. Dipakai worker untuk keyset pagination; gunakan c.child_id sebagai anak kebijakan.
+func familyPoliciesAfter(table string) string {
+	return `
+              FROM (SELECT DISTINCT child_id FROM user_relationship WHERE child_id > $1) c
+              CROSS JOIN LATERAL (
+                  SELECT p.* FROM ` + table + ` p
+                  JOIN user_relationship ur ON ur.parent_id = p.created_by_user_id AND ur.child_id = c.child_id
+                  WHERE p.child_id IS NULL OR p.child_id = c.child_id
+                  ORDER BY (p.child_id IS NOT NULL) DESC, p.id ASC
+                  LIMIT 1
+              ) p
+              ORDER BY c.child_id
+              LIMIT $2`
+}
@@ -0,0 +1,141 @@
+// internal/repository/interest_repo.go
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type interestRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewInterestRepository membuat instance baru dari InterestRepository.
+func NewInterestRepository(db *pgxpool.Pool) InterestRepository {
+	return &interestRepo{db: db}
+}
+
+const interestPolicyFields = `p.rate_basis_points, p.schedule, p.rounding, p.max_points_per_period, p.created_at, p.updated_at`
+
+const interestPolicyColumns = `p.id, p.created_by_user_id, COALESCE(p.child_id, 0), ` + interestPolicyFields
+
+// scanInterestPolicy memindai satu baris kebijakan bunga.
+func scanInterestPolicy(row pgx.Row, policy *models.InterestPolicy) error {
+	return row.Scan(&policy.ID, &policy.CreatedByUserID, &policy.ChildID, &policy.RateBasisPoints, &policy.Schedule, &policy.Rounding,
+		&policy.MaxPointsPerPeriod, &policy.CreatedAt, &policy.UpdatedAt)
+}
+
+// UpsertPolicy membuat atau memperbarui kebijakan bunga milik parent untuk cakupan anaknya.
+func (r *interestRepo) UpsertPolicy(ctx context.Context, policy *models.InterestPolicy) error {
+	query := `INSERT INTO interest_policies (created_by_user_id, child_id, rate_basis_points, schedule, rounding, max_points_per_period)
+              VALUES ($1, $2, $3, $4, $5, $6)
+              ON CONFLICT ` + familyPolicyConflict + ` DO UPDATE
+              SET rate_basis_points = EXCLUDED.rate_basis_points,
+                  schedule = EXCLUDED.schedule,
+                  rounding = EXCLUDED.rounding,
+                  max_points_per_period = EXCLUDED.max_points_per_period
+              RETURNING id, created_at, updated_at`
+	err := r.db.QueryRow(ctx, query, policy.CreatedByUserID, nullableID(policy.ChildID), policy.RateBasisPoints, policy.Schedule, policy.Rounding,
+		policy.MaxPointsPerPeriod).Scan(&policy.ID, &policy.CreatedAt, &policy.UpdatedAt)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", policy.CreatedByUserID).Int("child_id", policy.ChildID).Msg("Error upserting interest policy")
+		return fmt.Errorf("error saving interest policy: %w", err)
+	}
+	zlog.Info().Int("policy_id", policy.ID).Int("parent_id", policy.CreatedByUserID).Int("child_id", policy.ChildID).
+		Int("rate_basis_points", policy.RateBasisPoints).Str("schedule", string(policy.Schedule)).Msg("Interest policy saved")
+	return nil
+}
+
+// getInterestPolicy membaca satu kebijakan bunga dengan klausa filter kebijakan keluarga.
+func (r *interestRepo) getInterestPolicy(ctx context.Context, filter string, args ...any) (*models.InterestPolicy, error) {
+	query := `SELECT ` + interestPolicyColumns + ` FROM interest_policies p` + filter
+	policy := &models.InterestPolicy{}
+	if err := scanInterestPolicy(r.db.QueryRow(ctx, query, args...), policy); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Interface("args", args).Msg("Error getting interest policy")
+		return nil, fmt.Errorf("error getting interest policy: %w", err)
+	}
+	return policy, nil
+}
+
+// GetPolicyByOwner mendapatkan kebijakan bunga milik parent untuk cakupan anak (0 = semua anak).
+func (r *interestRepo) GetPolicyByOwner(ctx context.Context, parentID int, childID int) (*models.InterestPolicy, error) {
+	return r.getInterestPolicy(ctx, familyPolicyOwnedBy, parentID, childID)
+}
+
+// GetPolicyForChild mendapatkan kebijakan bunga yang berlaku untuk anak dari kebijakan orang tuanya.
+func (r *interestRepo) GetPolicyForChild(ctx context.Context, childID int) (*models.InterestPolicy, error) {
+	return r.getInterestPolicy(ctx, familyPolicyForChild, childID)
+}
+
+// DeletePolicy menghapus kebijakan bunga milik parent untuk cakupan anak (0 = semua anak).
+func (r *interestRepo) DeletePolicy(ctx context.Context, parentID int, childID int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM interest_policies p`+familyPolicyOwnedBy, parentID, childID)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", parentID).Int("child_id", childID).Msg("Error deleting interest policy")
+		return fmt.Errorf("error deleting interest policy: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// GetPoliciesAfter mengambil kebijakan yang berlaku untuk setiap anak dengan child_id > afterChildID
+// (keyset pagination untuk worker). ChildID setiap kebijakan adalah anak yang dituju.
+func (r *interestRepo) GetPoliciesAfter(ctx context.Context, afterChildID int, limit int) ([]models.InterestPolicy, error) {
+	query := `SELECT p.id, p.created_by_user_id, c.child_id, ` + interestPolicyFields + familyPoliciesAfter("interest_policies")
+	rows, err := r.db.Query(ctx, query, afterChildID, limit)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Error querying interest policies")
+		return nil, fmt.Errorf("error getting interest policies: %w", err)
+	}
+	defer rows.Close()
+
+	policies := []models.InterestPolicy{}
+	for rows.Next() {
+		var policy models.InterestPolicy
+		if err := scanInterestPolicy(rows, &policy); err != nil {
+			zlog.Warn().Err(err).Msg("Error scanning interest policy row")
+			return nil, fmt.Errorf("error scanning interest policy: %w", err)
+		}
+		policies = append(policies, policy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating interest policies: %w", err)
+	}
+	return policies, nil
+}
+
+// ClaimPeriodTx mencatat bahwa bunga periode tertentu sedang diposting untuk anak.
+// Mengembalikan false jika periode tersebut sudah pernah diposting (idempotensi).
+func (r *interestRepo) ClaimPeriodTx(ctx context.Context, tx pgx.Tx, childID int, periodStart time.Time, balance int, points int) (bool, error) {
+	query := `INSERT INTO interest_postings (child_id, period_start, balance, points)
+              VALUES ($1, $2, $3, $4)
+              ON CONFLICT (child_id, period_start) DO NOTHING`
+	tag, err := tx.Exec(ctx, query, childID, periodStart, balance, points)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Time("period_start", periodStart).Msg("RepoTx: Error recording interest posting")
+		return false, fmt.Errorf("repoTx error recording interest posting for child %d: %w", childID, err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// SetPostingTransactionTx mengaitkan catatan bunga periode dengan transaksi 'interest' yang dibuat.
+func (r *interestRepo) SetPostingTransactionTx(ctx context.Context, tx pgx.Tx, childID int, periodStart time.Time, pointTransactionID int) error {
+	query := `UPDATE interest_postings SET point_transaction_id = $1 WHERE child_id = $2 AND period_start = $3`
+	if _, err := tx.Exec(ctx, query, pointTransactionID, childID, periodStart); err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("RepoTx: Error linking interest posting to transaction")
+		return fmt.Errorf("repoTx error linking interest posting for child %d: %w", childID, err)
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+// internal/repository/ledger_repo.go
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// ledgerGenesisHash adalah prev_hash entri pertama setiap anak (sama dengan default point_ledger_heads.last_hash).
+var ledgerGenesisHash = strings.Repeat("0", 64)
+
+type ledgerRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewLedgerRepository membuat instance baru dari LedgerRepository.
+func NewLedgerRepository(db *pgxpool.Pool) LedgerRepository {
+	return &ledgerRepo{db: db}
+}
+
+// ledgerEntry adalah kolom point_transactions yang ikut membentuk rantai hash.
+type ledgerEntry struct {
+	ID              int
+	UserID          int
+	Seq             int64
+	ChangeAmount    int
+	TransactionType string
+	CurrencyID      int
+	CreatedAt       time.Time
+	Notes           string
+	PrevHash        string
+	EntryHash       string
+}
+
+// ledgerEntryHash menghitung ulang entry_hash. Format harus sama persis dengan fungsi
+// point_ledger_entry_hash di migrasi 000030 (created_at dalam UTC dengan presisi mikrodetik).
+func ledgerEntryHash(e *ledgerEntry) string {
+	payload := fmt.Sprintf("%d|%d|%d|%d|%s|%d|%s|%s|%s",
+		e.ID, e.UserID, e.Seq, e.ChangeAmount, e.TransactionType, e.CurrencyID,
+		e.CreatedAt.UTC().Format("2006-01-02T15:04:05.000000Z"), e.PrevHash, e.Notes)
+	sum := sha256.Sum256([]byte(payload))
+	return hex.EncodeToString(sum[:])
+}
+
+// ledgerHead adalah ujung rantai tersimpan di point_ledger_heads.
+type ledgerHead struct {
+	LastSeq  int64
+	LastHash string
+}
+
+// VerifyChain menelusuri rantai setiap anak (urut seq) dan menghitung ulang hash setiap entri.
+// Semua pembacaan dilakukan dalam satu snapshot (REPEATABLE READ) agar insert yang sedang berjalan
+// tidak terbaca sebagai celah.
+func (r *ledgerRepo) VerifyChain(ctx context.Context, userID int) (*models.LedgerVerificationReport, error) {
+	report := &models.LedgerVerificationReport{Issues: []models.LedgerIntegrityIssue{}}
+	txOptions := pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+	err := pgx.BeginTxFunc(ctx, r.db, txOptions, func(tx pgx.Tx) error {
+		heads, err := getLedgerHeadsTx(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		query := `SELECT id, user_id, seq, change_amount, transaction_type::TEXT, COALESCE(currency_id, 0),
+                         created_at, notes, prev_hash, entry_hash
+                  FROM point_transactions
+                  WHERE $1 = 0 OR user_id = $1
+                  ORDER BY user_id, seq`
+		rows, err := tx.Query(ctx, query, userID)
+		if err != nil {
+			return fmt.Errorf("error querying point ledger: %w", err)
+		}
+		defer rows.Close()
+
+		var current *ledgerChainState
+		for rows.Next() {
+			var e ledgerEntry
+			var notes sql.NullString
+			if err := rows.Scan(&e.ID, &e.UserID, &e.Seq, &e.ChangeAmount, &e.TransactionType, &e.CurrencyID,
+				&e.CreatedAt, &notes, &e.PrevHash, &e.EntryHash); err != nil {
+				return fmt.Errorf("error scanning point ledger entry: %w", err)
+			}
+			e.Notes = notes.String
+
+			if current == nil || current.userID != e.UserID {
+				if current != nil {
+					report.Issues = append(report.Issues, current.finish(heads)...)
+				}
+				current = newLedgerChainState(e.UserID)
+				report.CheckedUsers++
+			}
+			report.Issues = append(report.Issues, current.check(&e)...)
+			report.CheckedEntries++
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating point ledger: %w", err)
+		}
+		if current != nil {
+			report.Issues = append(report.Issues, current.finish(heads)...)
+		}
+
+		// Ujung rantai tanpa entri sama sekali: semua entri anak tersebut sudah dihapus
+		orphanUserIDs := make([]int, 0, len(heads))
+		for headUserID := range heads {
+			orphanUserIDs = append(orphanUserIDs, headUserID)
+		}
+		slices.Sort(orphanUserIDs)
+		for _, headUserID := range orphanUserIDs {
+			if head := heads[headUserID]; head.LastSeq > 0 {
+				report.Issues = append(report.Issues, models.LedgerIntegrityIssue{
+					UserID: headUserID,
+					Kind:   models.LedgerIssueHeadMismatch,
+					Detail: fmt.Sprintf("chain head expects %d entries but none were found", head.LastSeq),
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		zlog.Error().Err(err).Int("user_id", userID).Msg("Error verifying point ledger chain")
+		return nil, fmt.Errorf("error verifying point ledger: %w", err)
+	}
+
+	report.Valid = len(report.Issues) == 0
+	report.VerifiedAt = time.Now().UTC()
+	return report, nil
+}
+
+// getLedgerHeadsTx mengambil ujung rantai per anak (userID 0 = semua anak).
+func getLedgerHeadsTx(ctx context.Context, tx pgx.Tx, userID int) (map[int]ledgerHead, error) {
+	rows, err := tx.Query(ctx, `SELECT user_id, last_seq, last_hash FROM point_ledger_heads WHERE $1 = 0 OR user_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying point ledger heads: %w", err)
+	}
+	defer rows.Close()
+
+	heads := map[int]ledgerHead{}
+	for rows.Next() {
+		var headUserID int
+		var head ledgerHead
+		if err := rows.Scan(&headUserID, &head.LastSeq, &head.LastHash); err != nil {
+			return nil, fmt.Errorf("error scanning point ledger head: %w", err)
+		}
+		heads[headUserID] = head
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating point ledger heads: %w", err)
+	}
+	return heads, nil
+}
+
+// ledgerChainState menyimpan posisi penelusuran rantai satu anak.
+type ledgerChainState struct {
+	userID   int
+	nextSeq  int64
+	lastHash string
+}
+
+func newLedgerChainState(userID int) *ledgerChainState {
+	return &ledgerChainState{userID: userID, nextSeq: 1, lastHash: ledgerGenesisHash}
+}
+
+// check memeriksa satu entri terhadap entri sebelumnya, lalu memajukan posisi rantai.
+// Posisi dimajukan memakai nilai tersimpan agar satu kerusakan tidak membuat semua entri berikutnya ikut dilaporkan.
+func (s *ledgerChainState) check(e *ledgerEntry) []models.LedgerIntegrityIssue {
+	var issues []models.LedgerIntegrityIssue
+	if e.Seq != s.nextSeq {
+		issues = append(issues, models.LedgerIntegrityIssue{
+			UserID: e.UserID, TransactionID: e.ID, Seq: e.Seq,
+			Kind:   models.LedgerIssueSequenceGap,
+			Detail: fmt.Sprintf("expected seq %d, found %d", s.nextSeq, e.Seq),
+		})
+	}
+	if e.PrevHash != s.lastHash {
+		issues = append(issues, models.LedgerIntegrityIssue{
+			UserID: e.UserID, TransactionID: e.ID, Seq: e.Seq,
+			Kind:   models.LedgerIssueBrokenLink,
+			Detail: "prev_hash does not match the previous entry's hash",
+		})
+	}
+	if ledgerEntryHash(e) != e.EntryHash {
+		issues = append(issues, models.LedgerIntegrityIssue{
+			UserID: e.UserID, TransactionID: e.ID, Seq: e.Seq,
+			Kind:   models.LedgerIssueHashMismatch,
+			Detail: "entry contents do not match entry_hash",
+		})
+	}
+	s.nextSeq = e.Seq + 1
+	s.lastHash = e.EntryHash
+	return issues
+}
+
+// finish membandingkan entri terakhir dengan ujung rantai tersimpan. Ujung yang sudah dicek
+// dihapus dari heads agar sisanya bisa dilaporkan sebagai rantai tanpa entri.
+func (s *ledgerChainState) finish(heads map[int]ledgerHead) []models.LedgerIntegrityIssue {
+	head, ok := heads[s.userID]
+	delete(heads, s.userID)
+	lastSeq := s.nextSeq - 1
+	switch {
+	case !ok:
+		return []models.LedgerIntegrityIssue{{
+			UserID: s.userID, Kind: models.LedgerIssueHeadMismatch,
+			Detail: "chain head is missing",
+		}}
+	case head.LastSeq != lastSeq || head.LastHash != s.lastHash:
+		return []models.LedgerIntegrityIssue{{
+			UserID: s.userID, Seq: lastSeq, Kind: models.LedgerIssueHeadMismatch,
+			Detail: fmt.Sprintf("chain head is at seq %d but the last entry is seq %d", head.LastSeq, lastSeq),
+		}}
+	}
+	return nil
+}
+
+// GetTransactionTx mengambil satu entri ledger beserta status apakah sudah pernah dibalik.
+// Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+func (r *ledgerRepo) GetTransactionTx(ctx context.Context, tx pgx.Tx, transactionID int) (*models.PointTransaction, bool, error) {
+	query := `SELECT id, user_id, change_amount, transaction_type, COALESCE(currency_id, 0), notes, created_at,
+                     EXISTS (SELECT 1 FROM point_transactions r WHERE r.reverses_transaction_id = pt.id)
+              FROM point_transactions pt
+              WHERE pt.id = $1`
+	var txData models.PointTransaction
+	var notes sql.NullString
+	var reversed bool
+	err := tx.QueryRow(ctx, query, transactionID).Scan(
+		&txData.ID, &txData.UserID, &txData.ChangeAmount, &txData.TransactionType, &txData.CurrencyID,
+		&notes, &txData.CreatedAt, &reversed,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("transaction_id", transactionID).Msg("RepoTx: Error getting point transaction")
+		return nil, false, fmt.Errorf("repoTx error getting point transaction %d: %w", transactionID, err)
+	}
+	txData.Notes = notes.String
+	return &txData, reversed, nil
+}
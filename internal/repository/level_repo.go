@@ -0,0 +1,209 @@
+// internal/repository/level_repo.go
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type levelRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewLevelRepository membuat instance baru dari LevelRepository.
+func NewLevelRepository(db *pgxpool.Pool) LevelRepository {
+	return &levelRepo{db: db}
+}
+
+// queryLevels mengambil tangga level (urut naik menurut XP) memakai pool atau transaksi.
+func queryLevels(ctx context.Context, db rowQuerier) ([]models.Level, error) {
+	rows, err := db.Query(ctx, `SELECT level, xp_required, title FROM levels ORDER BY xp_required`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	levels := []models.Level{}
+	for rows.Next() {
+		var level models.Level
+		if err := rows.Scan(&level.Level, &level.XPRequired, &level.Title); err != nil {
+			return nil, fmt.Errorf("error scanning level: %w", err)
+		}
+		levels = append(levels, level)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating levels: %w", err)
+	}
+	return levels, nil
+}
+
+// GetLevels mengambil tangga level, urut naik menurut XP minimal.
+func (r *levelRepo) GetLevels(ctx context.Context) ([]models.Level, error) {
+	levels, err := queryLevels(ctx, r.db)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Error getting levels")
+		return nil, fmt.Errorf("error getting levels: %w", err)
+	}
+	return levels, nil
+}
+
+// GetTotalXP mengambil total XP anak (0 jika belum pernah mendapat XP).
+func (r *levelRepo) GetTotalXP(ctx context.Context, userID int) (int, error) {
+	var totalXP int
+	query := `SELECT COALESCE((SELECT total_xp FROM user_xp WHERE user_id = $1), 0)`
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&totalXP); err != nil {
+		zlog.Error().Err(err).Int("user_id", userID).Msg("Error getting total XP")
+		return 0, fmt.Errorf("error getting total XP for user %d: %w", userID, err)
+	}
+	return totalXP, nil
+}
+
+// GetTotalXPByUserIDs mengambil total XP beberapa anak sekaligus. Anak tanpa XP tidak ada di map (berarti 0).
+func (r *levelRepo) GetTotalXPByUserIDs(ctx context.Context, userIDs []int) (map[int]int, error) {
+	totals := make(map[int]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return totals, nil
+	}
+	rows, err := r.db.Query(ctx, `SELECT user_id, total_xp FROM user_xp WHERE user_id = ANY($1::int[])`, userIDs)
+	if err != nil {
+		zlog.Error().Err(err).Ints("user_ids", userIDs).Msg("Error getting total XP for users")
+		return nil, fmt.Errorf("error getting total XP for users: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var userID, totalXP int
+		if err := rows.Scan(&userID, &totalXP); err != nil {
+			return nil, fmt.Errorf("error scanning total XP: %w", err)
+		}
+		totals[userID] = totalXP
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating total XP: %w", err)
+	}
+	return totals, nil
+}
+
+// GetLevelUpsByUserID mengambil riwayat level-up anak, terbaru dulu.
+func (r *levelRepo) GetLevelUpsByUserID(ctx context.Context, userID int) ([]models.LevelUp, error) {
+	query := `SELECT lu.id, lu.user_id, lu.level, COALESCE(lv.title, ''), lu.total_xp, COALESCE(lu.user_task_id, 0), lu.reached_at
+              FROM level_ups lu
+              LEFT JOIN levels lv ON lv.level = lu.level
+              WHERE lu.user_id = $1
+              ORDER BY lu.reached_at DESC, lu.level DESC`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		zlog.Error().Err(err).Int("user_id", userID).Msg("Error getting level-ups")
+		return nil, fmt.Errorf("error getting level-ups for user %d: %w", userID, err)
+	}
+	defer rows.Close()
+	levelUps := []models.LevelUp{}
+	for rows.Next() {
+		var levelUp models.LevelUp
+		if err := rows.Scan(&levelUp.ID, &levelUp.UserID, &levelUp.Level, &levelUp.Title, &levelUp.TotalXP,
+			&levelUp.UserTaskID, &levelUp.ReachedAt); err != nil {
+			return nil, fmt.Errorf("error scanning level-up: %w", err)
+		}
+		levelUps = append(levelUps, levelUp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating level-ups: %w", err)
+	}
+	return levelUps, nil
+}
+
+// GetLevelRewardsForChild mengambil hadiah milik orang tua anak yang terkunci level (min_level > 1).
+func (r *levelRepo) GetLevelRewardsForChild(ctx context.Context, childID int) ([]models.LevelReward, error) {
+	query := `SELECT rw.id, rw.reward_name, rw.min_level
+              FROM rewards rw
+              WHERE rw.min_level > 1
+                AND rw.created_by_user_id IN (SELECT parent_id FROM user_relationship WHERE child_id = $1)
+              ORDER BY rw.min_level, rw.reward_name`
+	rows, err := r.db.Query(ctx, query, childID)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error getting level rewards for child")
+		return nil, fmt.Errorf("error getting level rewards for child %d: %w", childID, err)
+	}
+	defer rows.Close()
+	rewards := []models.LevelReward{}
+	for rows.Next() {
+		var reward models.LevelReward
+		if err := rows.Scan(&reward.RewardID, &reward.RewardName, &reward.MinLevel); err != nil {
+			return nil, fmt.Errorf("error scanning level reward: %w", err)
+		}
+		rewards = append(rewards, reward)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating level rewards: %w", err)
+	}
+	return rewards, nil
+}
+
+// --- Metode Transaksional ---
+
+// GetLevelsTx mengambil tangga level dalam konteks transaksi.
+func (r *levelRepo) GetLevelsTx(ctx context.Context, tx pgx.Tx) ([]models.Level, error) {
+	levels, err := queryLevels(ctx, tx)
+	if err != nil {
+		zlog.Error().Err(err).Msg("RepoTx: Error getting levels")
+		return nil, fmt.Errorf("repoTx error getting levels: %w", err)
+	}
+	return levels, nil
+}
+
+// AddXPTx mencatat perubahan XP, memperbarui total XP anak (baris user_xp terkunci sampai transaksi selesai),
+// dan mengembalikan total XP yang baru. ID serta created_at entri diisi.
+func (r *levelRepo) AddXPTx(ctx context.Context, tx pgx.Tx, xp *models.XPTransaction) (int, error) {
+	query := `INSERT INTO xp_transactions (user_id, change_amount, source, related_user_task_id)
+              VALUES ($1, $2, $3, $4)
+              RETURNING id, created_at`
+	err := tx.QueryRow(ctx, query, xp.UserID, xp.ChangeAmount, xp.Source, nullableID(xp.RelatedUserTaskID)).
+		Scan(&xp.ID, &xp.CreatedAt)
+	if err != nil {
+		zlog.Error().Err(err).Int("user_id", xp.UserID).Msg("RepoTx: Error creating XP transaction")
+		return 0, fmt.Errorf("repoTx error creating XP transaction for user %d: %w", xp.UserID, err)
+	}
+
+	var totalXP int
+	upsert := `INSERT INTO user_xp (user_id, total_xp) VALUES ($1, $2)
+               ON CONFLICT (user_id) DO UPDATE SET total_xp = user_xp.total_xp + EXCLUDED.total_xp
+               RETURNING total_xp`
+	if err := tx.QueryRow(ctx, upsert, xp.UserID, xp.ChangeAmount).Scan(&totalXP); err != nil {
+		zlog.Error().Err(err).Int("user_id", xp.UserID).Msg("RepoTx: Error updating total XP")
+		return 0, fmt.Errorf("repoTx error updating total XP for user %d: %w", xp.UserID, err)
+	}
+	return totalXP, nil
+}
+
+// GetNetTaskXPTx menghitung XP bersih yang saat ini berasal dari penugasan userTaskID dalam konteks transaksi.
+func (r *levelRepo) GetNetTaskXPTx(ctx context.Context, tx pgx.Tx, userTaskID int) (int, error) {
+	var netXP int
+	query := `SELECT COALESCE(SUM(change_amount), 0) FROM xp_transactions WHERE related_user_task_id = $1`
+	if err := tx.QueryRow(ctx, query, userTaskID).Scan(&netXP); err != nil {
+		zlog.Error().Err(err).Int("user_task_id", userTaskID).Msg("RepoTx: Error getting task XP")
+		return 0, fmt.Errorf("repoTx error getting XP for user task %d: %w", userTaskID, err)
+	}
+	return netXP, nil
+}
+
+// CreateLevelUpTx mencatat level yang dicapai anak. Mengembalikan false jika level tersebut sudah pernah dicapai.
+func (r *levelRepo) CreateLevelUpTx(ctx context.Context, tx pgx.Tx, levelUp *models.LevelUp) (bool, error) {
+	query := `INSERT INTO level_ups (user_id, level, total_xp, user_task_id)
+              VALUES ($1, $2, $3, $4)
+              ON CONFLICT (user_id, level) DO NOTHING
+              RETURNING id, reached_at`
+	err := tx.QueryRow(ctx, query, levelUp.UserID, levelUp.Level, levelUp.TotalXP, nullableID(levelUp.UserTaskID)).
+		Scan(&levelUp.ID, &levelUp.ReachedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		zlog.Error().Err(err).Int("user_id", levelUp.UserID).Int("level", levelUp.Level).Msg("RepoTx: Error creating level-up")
+		return false, fmt.Errorf("repoTx error creating level-up for user %d: %w", levelUp.UserID, err)
+	}
+	return true, nil
+}
@@ -113,6 +113,73 @@ func (_m *MockPointTransactionRepository) CalculateTotalPointsByUserIDTx(ctx con
 	return r0, r1
 }
 
+// CalculateBalanceTx provides a mock function with given fields: ctx, tx, userID, currencyID
+func (_m *MockPointTransactionRepository) CalculateBalanceTx(ctx context.Context, tx pgx.Tx, userID int, currencyID int) (int, error) {
+	ret := _m.Called(ctx, tx, userID, currencyID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, int, int) int); ok {
+		r0 = rf(ctx, tx, userID, currencyID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx, int, int) error); ok {
+		r1 = rf(ctx, tx, userID, currencyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUnreversedTransactionTx provides a mock function with given fields: ctx, tx, txType, userTaskID, userRewardID
+func (_m *MockPointTransactionRepository) GetUnreversedTransactionTx(ctx context.Context, tx pgx.Tx, txType models.TransactionType, userTaskID int, userRewardID int) (*models.PointTransaction, error) {
+	ret := _m.Called(ctx, tx, txType, userTaskID, userRewardID)
+
+	var r0 *models.PointTransaction
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, models.TransactionType, int, int) *models.PointTransaction); ok {
+		r0 = rf(ctx, tx, txType, userTaskID, userRewardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PointTransaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx, models.TransactionType, int, int) error); ok {
+		r1 = rf(ctx, tx, txType, userTaskID, userRewardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReconcileBalances provides a mock function with given fields: ctx, fix
+func (_m *MockPointTransactionRepository) ReconcileBalances(ctx context.Context, fix bool) ([]models.PointBalanceDrift, error) {
+	ret := _m.Called(ctx, fix)
+
+	var r0 []models.PointBalanceDrift
+	if rf, ok := ret.Get(0).(func(context.Context, bool) []models.PointBalanceDrift); ok {
+		r0 = rf(ctx, fix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PointBalanceDrift)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, fix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockPointTransactionRepository creates a new instance of MockPointTransactionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockPointTransactionRepository(t interface {
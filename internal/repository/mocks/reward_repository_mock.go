@@ -58,13 +58,13 @@ func (_m *MockRewardRepository) GetRewardByID(ctx context.Context, id int) (*mod
 	return r0, r1
 }
 
-// GetRewardsByCreatorID provides a mock function with given fields: ctx, creatorID, page, limit
-func (_m *MockRewardRepository) GetRewardsByCreatorID(ctx context.Context, creatorID int, page int, limit int) ([]models.Reward, int, error) {
-	ret := _m.Called(ctx, creatorID, page, limit)
+// GetRewardsByCreatorID provides a mock function with given fields: ctx, creatorID, filter, page, limit
+func (_m *MockRewardRepository) GetRewardsByCreatorID(ctx context.Context, creatorID int, filter *models.DefinitionFilter, page int, limit int) ([]models.Reward, int, error) {
+	ret := _m.Called(ctx, creatorID, filter, page, limit)
 
 	var r0 []models.Reward
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) []models.Reward); ok {
-		r0 = rf(ctx, creatorID, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, int, *models.DefinitionFilter, int, int) []models.Reward); ok {
+		r0 = rf(ctx, creatorID, filter, page, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Reward)
@@ -72,15 +72,15 @@ func (_m *MockRewardRepository) GetRewardsByCreatorID(ctx context.Context, creat
 	}
 
 	var r1 int
-	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) int); ok {
-		r1 = rf(ctx, creatorID, page, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, int, *models.DefinitionFilter, int, int) int); ok {
+		r1 = rf(ctx, creatorID, filter, page, limit)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, int, int, int) error); ok {
-		r2 = rf(ctx, creatorID, page, limit)
+	if rf, ok := ret.Get(2).(func(context.Context, int, *models.DefinitionFilter, int, int) error); ok {
+		r2 = rf(ctx, creatorID, filter, page, limit)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -88,6 +88,27 @@ func (_m *MockRewardRepository) GetRewardsByCreatorID(ctx context.Context, creat
 	return r0, r1, r2
 }
 
+// CreateRewardTx provides a mock function with given fields: ctx, tx, reward
+func (_m *MockRewardRepository) CreateRewardTx(ctx context.Context, tx pgx.Tx, reward *models.Reward) (int, error) {
+	ret := _m.Called(ctx, tx, reward)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, *models.Reward) int); ok {
+		r0 = rf(ctx, tx, reward)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx, *models.Reward) error); ok {
+		r1 = rf(ctx, tx, reward)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAvailableRewardsForChild provides a mock function with given fields: ctx, childID, page, limit
 func (_m *MockRewardRepository) GetAvailableRewardsForChild(ctx context.Context, childID int, page int, limit int) ([]models.Reward, int, error) {
 	ret := _m.Called(ctx, childID, page, limit)
@@ -169,6 +190,57 @@ func (_m *MockRewardRepository) GetRewardDetailsTx(ctx context.Context, tx pgx.T
 	return r0, r1
 }
 
+// GetRewardUsageTx provides a mock function with given fields: ctx, tx, childID, rewardID
+func (_m *MockRewardRepository) GetRewardUsageTx(ctx context.Context, tx pgx.Tx, childID int, rewardID int) (*models.RewardUsage, error) {
+	ret := _m.Called(ctx, tx, childID, rewardID)
+
+	var r0 *models.RewardUsage
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, int, int) *models.RewardUsage); ok {
+		r0 = rf(ctx, tx, childID, rewardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RewardUsage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx, int, int) error); ok {
+		r1 = rf(ctx, tx, childID, rewardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DecrementStockTx provides a mock function with given fields: ctx, tx, rewardID
+func (_m *MockRewardRepository) DecrementStockTx(ctx context.Context, tx pgx.Tx, rewardID int) error {
+	ret := _m.Called(ctx, tx, rewardID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, int) error); ok {
+		r0 = rf(ctx, tx, rewardID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RestoreStockTx provides a mock function with given fields: ctx, tx, rewardID
+func (_m *MockRewardRepository) RestoreStockTx(ctx context.Context, tx pgx.Tx, rewardID int) error {
+	ret := _m.Called(ctx, tx, rewardID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, int) error); ok {
+		r0 = rf(ctx, tx, rewardID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMockRewardRepository creates a new instance of MockRewardRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRewardRepository(t interface {
@@ -3,6 +3,7 @@ package mocks
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
 	"github.com/stretchr/testify/mock"
 )
@@ -56,13 +57,13 @@ func (_m *MockTaskRepository) GetTaskByID(ctx context.Context, id int) (*models.
 	return r0, r1
 }
 
-// GetTasksByCreatorID provides a mock function with given fields: ctx, creatorID, page, limit
-func (_m *MockTaskRepository) GetTasksByCreatorID(ctx context.Context, creatorID int, page int, limit int) ([]models.Task, int, error) {
-	ret := _m.Called(ctx, creatorID, page, limit)
+// GetTasksByCreatorID provides a mock function with given fields: ctx, creatorID, filter, page, limit
+func (_m *MockTaskRepository) GetTasksByCreatorID(ctx context.Context, creatorID int, filter *models.DefinitionFilter, page int, limit int) ([]models.Task, int, error) {
+	ret := _m.Called(ctx, creatorID, filter, page, limit)
 
 	var r0 []models.Task
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) []models.Task); ok {
-		r0 = rf(ctx, creatorID, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, int, *models.DefinitionFilter, int, int) []models.Task); ok {
+		r0 = rf(ctx, creatorID, filter, page, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Task)
@@ -70,15 +71,15 @@ func (_m *MockTaskRepository) GetTasksByCreatorID(ctx context.Context, creatorID
 	}
 
 	var r1 int
-	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) int); ok {
-		r1 = rf(ctx, creatorID, page, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, int, *models.DefinitionFilter, int, int) int); ok {
+		r1 = rf(ctx, creatorID, filter, page, limit)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, int, int, int) error); ok {
-		r2 = rf(ctx, creatorID, page, limit)
+	if rf, ok := ret.Get(2).(func(context.Context, int, *models.DefinitionFilter, int, int) error); ok {
+		r2 = rf(ctx, creatorID, filter, page, limit)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -86,6 +87,27 @@ func (_m *MockTaskRepository) GetTasksByCreatorID(ctx context.Context, creatorID
 	return r0, r1, r2
 }
 
+// CreateTaskTx provides a mock function with given fields: ctx, tx, task
+func (_m *MockTaskRepository) CreateTaskTx(ctx context.Context, tx pgx.Tx, task *models.Task) (int, error) {
+	ret := _m.Called(ctx, tx, task)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, *models.Task) int); ok {
+		r0 = rf(ctx, tx, task)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx, *models.Task) error); ok {
+		r1 = rf(ctx, tx, task)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateTask provides a mock function with given fields: ctx, task, parentID
 func (_m *MockTaskRepository) UpdateTask(ctx context.Context, task *models.Task, parentID int) error {
 	ret := _m.Called(ctx, task, parentID)
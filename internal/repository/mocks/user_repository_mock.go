@@ -187,6 +187,29 @@ func (_m *MockUserRepository) CreateUserTx(ctx context.Context, tx pgx.Tx, user
 	return r0, r1
 }
 
+// GetUserByIDTx provides a mock function with given fields: ctx, tx, id
+func (_m *MockUserRepository) GetUserByIDTx(ctx context.Context, tx pgx.Tx, id int) (*models.User, error) {
+	ret := _m.Called(ctx, tx, id)
+
+	var r0 *models.User
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, int) *models.User); ok {
+		r0 = rf(ctx, tx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx, int) error); ok {
+		r1 = rf(ctx, tx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockUserRepository creates a new instance of MockUserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockUserRepository(t interface {
@@ -132,20 +132,20 @@ func (_m *MockUserRewardRepository) UpdateClaimStatus(ctx context.Context, id in
 	return r0
 }
 
-// CreateClaimTx provides a mock function with given fields: ctx, tx, userID, rewardID, pointsDeducted
-func (_m *MockUserRewardRepository) CreateClaimTx(ctx context.Context, tx pgx.Tx, userID int, rewardID int, pointsDeducted int) (int, error) {
-	ret := _m.Called(ctx, tx, userID, rewardID, pointsDeducted)
+// CreateClaimTx provides a mock function with given fields: ctx, tx, userID, rewardID, pointsDeducted, stockReserved
+func (_m *MockUserRewardRepository) CreateClaimTx(ctx context.Context, tx pgx.Tx, userID int, rewardID int, pointsDeducted int, stockReserved bool) (int, error) {
+	ret := _m.Called(ctx, tx, userID, rewardID, pointsDeducted, stockReserved)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, int, int, int) int); ok {
-		r0 = rf(ctx, tx, userID, rewardID, pointsDeducted)
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, int, int, int, bool) int); ok {
+		r0 = rf(ctx, tx, userID, rewardID, pointsDeducted, stockReserved)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx, int, int, int) error); ok {
-		r1 = rf(ctx, tx, userID, rewardID, pointsDeducted)
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx, int, int, int, bool) error); ok {
+		r1 = rf(ctx, tx, userID, rewardID, pointsDeducted, stockReserved)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -190,6 +190,87 @@ func (_m *MockUserRewardRepository) GetClaimDetailsForReviewTx(ctx context.Conte
 	return r0, r1
 }
 
+// ApplyClaimTransitionTx provides a mock function with given fields: ctx, tx, event
+func (_m *MockUserRewardRepository) ApplyClaimTransitionTx(ctx context.Context, tx pgx.Tx, event *models.UserRewardEvent) error {
+	ret := _m.Called(ctx, tx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, *models.UserRewardEvent) error); ok {
+		r0 = rf(ctx, tx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateClaimEventTx provides a mock function with given fields: ctx, tx, event
+func (_m *MockUserRewardRepository) CreateClaimEventTx(ctx context.Context, tx pgx.Tx, event *models.UserRewardEvent) error {
+	ret := _m.Called(ctx, tx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, *models.UserRewardEvent) error); ok {
+		r0 = rf(ctx, tx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetClaimEvents provides a mock function with given fields: ctx, claimID
+func (_m *MockUserRewardRepository) GetClaimEvents(ctx context.Context, claimID int) ([]models.UserRewardEvent, error) {
+	ret := _m.Called(ctx, claimID)
+
+	var r0 []models.UserRewardEvent
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.UserRewardEvent); ok {
+		r0 = rf(ctx, claimID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UserRewardEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, claimID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUnfulfilledClaimsByParentID provides a mock function with given fields: ctx, parentID, page, limit
+func (_m *MockUserRewardRepository) GetUnfulfilledClaimsByParentID(ctx context.Context, parentID int, page int, limit int) ([]models.UserReward, int, error) {
+	ret := _m.Called(ctx, parentID, page, limit)
+
+	var r0 []models.UserReward
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) []models.UserReward); ok {
+		r0 = rf(ctx, parentID, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UserReward)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) int); ok {
+		r1 = rf(ctx, parentID, page, limit)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, int) error); ok {
+		r2 = rf(ctx, parentID, page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewMockUserRewardRepository creates a new instance of MockUserRewardRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockUserRewardRepository(t interface {
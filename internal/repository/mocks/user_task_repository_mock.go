@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
@@ -14,20 +15,41 @@ type MockUserTaskRepository struct {
 	mock.Mock
 }
 
-// AssignTask provides a mock function with given fields: ctx, userID, taskID, assignedByID
-func (_m *MockUserTaskRepository) AssignTask(ctx context.Context, userID int, taskID int, assignedByID int) (int, error) {
-	ret := _m.Called(ctx, userID, taskID, assignedByID)
+// AssignTask provides a mock function with given fields: ctx, userID, taskID, assignedByID, dueAt
+func (_m *MockUserTaskRepository) AssignTask(ctx context.Context, userID int, taskID int, assignedByID int, dueAt *time.Time) (int, error) {
+	ret := _m.Called(ctx, userID, taskID, assignedByID, dueAt)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) int); ok {
-		r0 = rf(ctx, userID, taskID, assignedByID)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, *time.Time) int); ok {
+		r0 = rf(ctx, userID, taskID, assignedByID, dueAt)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
-		r1 = rf(ctx, userID, taskID, assignedByID)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int, *time.Time) error); ok {
+		r1 = rf(ctx, userID, taskID, assignedByID, dueAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AssignTaskTx provides a mock function with given fields: ctx, tx, userID, taskID, assignedByID, dueAt
+func (_m *MockUserTaskRepository) AssignTaskTx(ctx context.Context, tx pgx.Tx, userID int, taskID int, assignedByID int, dueAt *time.Time) (int, error) {
+	ret := _m.Called(ctx, tx, userID, taskID, assignedByID, dueAt)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, int, int, int, *time.Time) int); ok {
+		r0 = rf(ctx, tx, userID, taskID, assignedByID, dueAt)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx, int, int, int, *time.Time) error); ok {
+		r1 = rf(ctx, tx, userID, taskID, assignedByID, dueAt)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -118,20 +140,6 @@ func (_m *MockUserTaskRepository) GetTasksByParentID(ctx context.Context, parent
 	return r0, r1, r2
 }
 
-// UpdateUserTaskStatus provides a mock function with given fields: ctx, id, newStatus, verifierID
-func (_m *MockUserTaskRepository) UpdateUserTaskStatus(ctx context.Context, id int, newStatus models.UserTaskStatus, verifierID *int) error {
-	ret := _m.Called(ctx, id, newStatus, verifierID)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, models.UserTaskStatus, *int) error); ok {
-		r0 = rf(ctx, id, newStatus, verifierID)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // SubmitTask provides a mock function with given fields: ctx, id, childID
 func (_m *MockUserTaskRepository) SubmitTask(ctx context.Context, id int, childID int) error {
 	ret := _m.Called(ctx, id, childID)
@@ -146,22 +154,20 @@ func (_m *MockUserTaskRepository) SubmitTask(ctx context.Context, id int, childI
 	return r0
 }
 
-// VerifyTask provides a mock function with given fields: ctx, id, parentID, newStatus
-func (_m *MockUserTaskRepository) VerifyTask(ctx context.Context, id int, parentID int, newStatus models.UserTaskStatus) (*models.UserTask, error) {
-	ret := _m.Called(ctx, id, parentID, newStatus)
+// CheckExistingActiveTask provides a mock function with given fields: ctx, userID, taskID
+func (_m *MockUserTaskRepository) CheckExistingActiveTask(ctx context.Context, userID int, taskID int) (bool, error) {
+	ret := _m.Called(ctx, userID, taskID)
 
-	var r0 *models.UserTask
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, models.UserTaskStatus) *models.UserTask); ok {
-		r0 = rf(ctx, id, parentID, newStatus)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) bool); ok {
+		r0 = rf(ctx, userID, taskID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.UserTask)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, int, int, models.UserTaskStatus) error); ok {
-		r1 = rf(ctx, id, parentID, newStatus)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, taskID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -169,20 +175,20 @@ func (_m *MockUserTaskRepository) VerifyTask(ctx context.Context, id int, parent
 	return r0, r1
 }
 
-// CheckExistingActiveTask provides a mock function with given fields: ctx, userID, taskID
-func (_m *MockUserTaskRepository) CheckExistingActiveTask(ctx context.Context, userID int, taskID int) (bool, error) {
-	ret := _m.Called(ctx, userID, taskID)
+// CheckExistingActiveTaskTx provides a mock function with given fields: ctx, tx, userID, taskID
+func (_m *MockUserTaskRepository) CheckExistingActiveTaskTx(ctx context.Context, tx pgx.Tx, userID int, taskID int) (bool, error) {
+	ret := _m.Called(ctx, tx, userID, taskID)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) bool); ok {
-		r0 = rf(ctx, userID, taskID)
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, int, int) bool); ok {
+		r0 = rf(ctx, tx, userID, taskID)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, userID, taskID)
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx, int, int) error); ok {
+		r1 = rf(ctx, tx, userID, taskID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -213,13 +219,87 @@ func (_m *MockUserTaskRepository) GetTaskDetailsForVerificationTx(ctx context.Co
 	return r0, r1
 }
 
-// UpdateStatusTx provides a mock function with given fields: ctx, tx, id, newStatus, verifierID
-func (_m *MockUserTaskRepository) UpdateStatusTx(ctx context.Context, tx pgx.Tx, id int, newStatus models.UserTaskStatus, verifierID int) error {
-	ret := _m.Called(ctx, tx, id, newStatus, verifierID)
+// ReassignTaskTx provides a mock function with given fields: ctx, tx, id, newChildID
+func (_m *MockUserTaskRepository) ReassignTaskTx(ctx context.Context, tx pgx.Tx, id int, newChildID int) error {
+	ret := _m.Called(ctx, tx, id, newChildID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, int, int) error); ok {
+		r0 = rf(ctx, tx, id, newChildID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetEventsByUserTaskID provides a mock function with given fields: ctx, userTaskID
+func (_m *MockUserTaskRepository) GetEventsByUserTaskID(ctx context.Context, userTaskID int) ([]models.UserTaskEvent, error) {
+	ret := _m.Called(ctx, userTaskID)
+
+	var r0 []models.UserTaskEvent
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.UserTaskEvent); ok {
+		r0 = rf(ctx, userTaskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UserTaskEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userTaskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOverdueUserTaskIDs provides a mock function with given fields: ctx, now, limit
+func (_m *MockUserTaskRepository) GetOverdueUserTaskIDs(ctx context.Context, now time.Time, limit int) ([]int, error) {
+	ret := _m.Called(ctx, now, limit)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []int); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ApplyTransitionTx provides a mock function with given fields: ctx, tx, event
+func (_m *MockUserTaskRepository) ApplyTransitionTx(ctx context.Context, tx pgx.Tx, event *models.UserTaskEvent) error {
+	ret := _m.Called(ctx, tx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, *models.UserTaskEvent) error); ok {
+		r0 = rf(ctx, tx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateEventTx provides a mock function with given fields: ctx, tx, event
+func (_m *MockUserTaskRepository) CreateEventTx(ctx context.Context, tx pgx.Tx, event *models.UserTaskEvent) error {
+	ret := _m.Called(ctx, tx, event)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, int, models.UserTaskStatus, int) error); ok {
-		r0 = rf(ctx, tx, id, newStatus, verifierID)
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, *models.UserTaskEvent) error); ok {
+		r0 = rf(ctx, tx, event)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,142 @@
+// internal/repository/notification_repo.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type notificationRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewNotificationRepository membuat instance baru dari NotificationRepository.
+func NewNotificationRepository(db *pgxpool.Pool) NotificationRepository {
+	return &notificationRepo{db: db}
+}
+
+// execer adalah subset method yang dimiliki oleh *pgxpool.Pool maupun pgx.Tx untuk perintah tanpa hasil baris.
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// insertNotification menyimpan satu notifikasi memakai pool atau transaksi.
+func insertNotification(ctx context.Context, db execer, notification *models.Notification) error {
+	query := `INSERT INTO notifications (user_id, type, title, message, entity_type, entity_id)
+              VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)`
+	_, err := db.Exec(ctx, query, notification.UserID, notification.Type, notification.Title, notification.Message,
+		notification.EntityType, nullableID(notification.EntityID))
+	if err != nil {
+		zlog.Error().Err(err).Int("user_id", notification.UserID).Str("type", string(notification.Type)).Msg("Error creating notification")
+		return fmt.Errorf("error creating notification for user %d: %w", notification.UserID, err)
+	}
+	return nil
+}
+
+// CreateNotification menyimpan notifikasi baru di luar transaksi.
+func (r *notificationRepo) CreateNotification(ctx context.Context, notification *models.Notification) error {
+	return insertNotification(ctx, r.db, notification)
+}
+
+// CreateNotificationTx menyimpan notifikasi baru dalam transaksi sehingga ikut di-rollback bila operasi gagal.
+func (r *notificationRepo) CreateNotificationTx(ctx context.Context, tx pgx.Tx, notification *models.Notification) error {
+	return insertNotification(ctx, tx, notification)
+}
+
+// GetNotificationsByUserID mengambil notifikasi pengguna (terbaru lebih dulu) dengan paginasi.
+func (r *notificationRepo) GetNotificationsByUserID(ctx context.Context, userID int, unreadOnly bool, page, limit int) ([]models.Notification, int, error) {
+	where := ` WHERE user_id = $1`
+	if unreadOnly {
+		where += ` AND read_at IS NULL`
+	}
+
+	var totalCount int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM notifications`+where, userID).Scan(&totalCount); err != nil {
+		zlog.Error().Err(err).Int("user_id", userID).Msg("Error counting notifications")
+		return nil, 0, fmt.Errorf("error counting notifications for user %d: %w", userID, err)
+	}
+	if totalCount == 0 {
+		return []models.Notification{}, 0, nil
+	}
+
+	offset := (page - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+	query := `SELECT id, user_id, type, title, message, entity_type, entity_id, read_at, created_at
+              FROM notifications` + where + `
+              ORDER BY created_at DESC, id DESC
+              LIMIT $2 OFFSET $3`
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		zlog.Error().Err(err).Int("user_id", userID).Msg("Error querying notifications")
+		return nil, totalCount, fmt.Errorf("error getting notifications for user %d: %w", userID, err)
+	}
+	defer rows.Close()
+
+	notifications := []models.Notification{}
+	for rows.Next() {
+		var n models.Notification
+		var entityType sql.NullString
+		var entityID sql.NullInt32
+		if err := rows.Scan(&n.ID, &n.UserID, &n.Type, &n.Title, &n.Message, &entityType, &entityID, &n.ReadAt, &n.CreatedAt); err != nil {
+			zlog.Warn().Err(err).Int("user_id", userID).Msg("Error scanning notification row")
+			return notifications, totalCount, fmt.Errorf("error scanning notification data: %w", err)
+		}
+		n.EntityType = entityType.String
+		if entityID.Valid {
+			n.EntityID = int(entityID.Int32)
+		}
+		notifications = append(notifications, n)
+	}
+	if err := rows.Err(); err != nil {
+		zlog.Error().Err(err).Int("user_id", userID).Msg("Error iterating notification rows")
+		return notifications, totalCount, fmt.Errorf("error iterating notifications: %w", err)
+	}
+	return notifications, totalCount, nil
+}
+
+// MarkAsRead menandai satu notifikasi milik pengguna sebagai sudah dibaca.
+// Mengembalikan pgx.ErrNoRows jika notifikasi tidak ditemukan atau bukan milik pengguna.
+func (r *notificationRepo) MarkAsRead(ctx context.Context, id int64, userID int) error {
+	query := `UPDATE notifications SET read_at = COALESCE(read_at, NOW())
+              WHERE id = $1 AND user_id = $2`
+	tag, err := r.db.Exec(ctx, query, id, userID)
+	if err != nil {
+		zlog.Error().Err(err).Int64("notification_id", id).Int("user_id", userID).Msg("Error marking notification as read")
+		return fmt.Errorf("error marking notification %d as read: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// HasNotification memeriksa apakah notifikasi berjenis tertentu untuk entitas tertentu sudah pernah dikirim ke pengguna.
+func (r *notificationRepo) HasNotification(ctx context.Context, userID int, notificationType models.NotificationType, entityType string, entityID int) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM notifications
+                             WHERE user_id = $1 AND type = $2 AND entity_type = $3 AND entity_id = $4)`
+	var exists bool
+	if err := r.db.QueryRow(ctx, query, userID, notificationType, entityType, entityID).Scan(&exists); err != nil {
+		zlog.Error().Err(err).Int("user_id", userID).Str("type", string(notificationType)).Msg("Error checking existing notification")
+		return false, fmt.Errorf("error checking notification for user %d: %w", userID, err)
+	}
+	return exists, nil
+}
+
+// MarkAllAsRead menandai semua notifikasi pengguna yang belum dibaca. Mengembalikan jumlah notifikasi yang diubah.
+func (r *notificationRepo) MarkAllAsRead(ctx context.Context, userID int) (int64, error) {
+	tag, err := r.db.Exec(ctx, `UPDATE notifications SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL`, userID)
+	if err != nil {
+		zlog.Error().Err(err).Int("user_id", userID).Msg("Error marking all notifications as read")
+		return 0, fmt.Errorf("error marking notifications as read for user %d: %w", userID, err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,284 @@
+// internal/repository/penalty_repo.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type penaltyRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewPenaltyRepository membuat instance baru dari PenaltyRepository.
+func NewPenaltyRepository(db *pgxpool.Pool) PenaltyRepository {
+	return &penaltyRepo{db: db}
+}
+
+// infractionTypeSelect memilih kolom jenis pelanggaran beserta nama tugas penebus.
+const infractionTypeSelect = `SELECT it.id, it.created_by_user_id, it.name, it.description, it.points, COALESCE(it.max_per_day, 0),
+                COALESCE(it.earn_back_task_id, 0), t.task_name, it.created_at, it.updated_at
+              FROM infraction_types it
+              LEFT JOIN tasks t ON t.id = it.earn_back_task_id`
+
+// scanInfractionType memindai satu baris jenis pelanggaran.
+func scanInfractionType(row pgx.Row, infraction *models.InfractionType) error {
+	var description, taskName sql.NullString
+	err := row.Scan(&infraction.ID, &infraction.CreatedByUserID, &infraction.Name, &description, &infraction.Points,
+		&infraction.MaxPerDay, &infraction.EarnBackTaskID, &taskName, &infraction.CreatedAt, &infraction.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	infraction.Description = description.String
+	infraction.EarnBackTaskName = taskName.String
+	return nil
+}
+
+// penaltySelect memilih kolom sanksi beserta status tugas penebusnya.
+const penaltySelect = `SELECT p.id, p.child_id, COALESCE(p.infraction_type_id, 0), p.infraction_name, p.points, p.points_deducted,
+                p.notes, COALESCE(p.applied_by_user_id, 0), COALESCE(p.transaction_id, 0),
+                COALESCE(p.earn_back_user_task_id, 0), ut.status, p.earned_back_at, COALESCE(p.reversal_transaction_id, 0),
+                p.created_at, p.updated_at
+              FROM penalties p
+              LEFT JOIN user_tasks ut ON ut.id = p.earn_back_user_task_id`
+
+// scanPenalty memindai satu baris sanksi.
+func scanPenalty(row pgx.Row, penalty *models.Penalty) error {
+	var notes, taskStatus sql.NullString
+	err := row.Scan(&penalty.ID, &penalty.ChildID, &penalty.InfractionTypeID, &penalty.InfractionName, &penalty.Points,
+		&penalty.PointsDeducted, &notes, &penalty.AppliedByUserID, &penalty.TransactionID,
+		&penalty.EarnBackUserTaskID, &taskStatus, &penalty.EarnedBackAt, &penalty.ReversalTransactionID,
+		&penalty.CreatedAt, &penalty.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	penalty.Notes = notes.String
+	penalty.EarnBackTaskStatus = models.UserTaskStatus(taskStatus.String)
+	return nil
+}
+
+// nullableText mengubah string kosong menjadi NULL.
+func nullableText(text string) any {
+	if text == "" {
+		return nil
+	}
+	return text
+}
+
+// CreateInfractionType membuat jenis pelanggaran baru milik parent dan mengisi ID serta timestamp.
+func (r *penaltyRepo) CreateInfractionType(ctx context.Context, infraction *models.InfractionType) error {
+	query := `INSERT INTO infraction_types (created_by_user_id, name, description, points, max_per_day, earn_back_task_id)
+              VALUES ($1, $2, $3, $4, $5, $6)
+              RETURNING id, created_at, updated_at`
+	err := r.db.QueryRow(ctx, query, infraction.CreatedByUserID, infraction.Name, nullableText(infraction.Description),
+		infraction.Points, nullableID(infraction.MaxPerDay), nullableID(infraction.EarnBackTaskID)).
+		Scan(&infraction.ID, &infraction.CreatedAt, &infraction.UpdatedAt)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			return fmt.Errorf("infraction type with this name already exists")
+		}
+		zlog.Error().Err(err).Int("parent_id", infraction.CreatedByUserID).Msg("Error creating infraction type")
+		return fmt.Errorf("error creating infraction type: %w", err)
+	}
+	zlog.Info().Int("infraction_type_id", infraction.ID).Int("parent_id", infraction.CreatedByUserID).Msg("Infraction type created")
+	return nil
+}
+
+// GetInfractionTypesByOwnerID mengambil semua jenis pelanggaran milik parent.
+func (r *penaltyRepo) GetInfractionTypesByOwnerID(ctx context.Context, parentID int) ([]models.InfractionType, error) {
+	rows, err := r.db.Query(ctx, infractionTypeSelect+` WHERE it.created_by_user_id = $1 ORDER BY it.name`, parentID)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", parentID).Msg("Error querying infraction types")
+		return nil, fmt.Errorf("error getting infraction types for parent %d: %w", parentID, err)
+	}
+	defer rows.Close()
+
+	infractions := []models.InfractionType{}
+	for rows.Next() {
+		var infraction models.InfractionType
+		if err := scanInfractionType(rows, &infraction); err != nil {
+			zlog.Warn().Err(err).Int("parent_id", parentID).Msg("Error scanning infraction type row")
+			return nil, fmt.Errorf("error scanning infraction type: %w", err)
+		}
+		infractions = append(infractions, infraction)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating infraction types: %w", err)
+	}
+	return infractions, nil
+}
+
+// GetInfractionTypeByID mengambil jenis pelanggaran berdasarkan ID. Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+func (r *penaltyRepo) GetInfractionTypeByID(ctx context.Context, infractionTypeID int) (*models.InfractionType, error) {
+	infraction := &models.InfractionType{}
+	err := scanInfractionType(r.db.QueryRow(ctx, infractionTypeSelect+` WHERE it.id = $1`, infractionTypeID), infraction)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("infraction_type_id", infractionTypeID).Msg("Error getting infraction type")
+		return nil, fmt.Errorf("error getting infraction type %d: %w", infractionTypeID, err)
+	}
+	return infraction, nil
+}
+
+// UpdateInfractionType memperbarui jenis pelanggaran milik parent. Sanksi yang sudah diterapkan tidak berubah.
+// Mengembalikan pgx.ErrNoRows jika tidak ada atau bukan milik parent.
+func (r *penaltyRepo) UpdateInfractionType(ctx context.Context, infraction *models.InfractionType) error {
+	query := `UPDATE infraction_types
+              SET name = $1, description = $2, points = $3, max_per_day = $4, earn_back_task_id = $5
+              WHERE id = $6 AND created_by_user_id = $7
+              RETURNING created_at, updated_at`
+	err := r.db.QueryRow(ctx, query, infraction.Name, nullableText(infraction.Description), infraction.Points,
+		nullableID(infraction.MaxPerDay), nullableID(infraction.EarnBackTaskID), infraction.ID, infraction.CreatedByUserID).
+		Scan(&infraction.CreatedAt, &infraction.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgx.ErrNoRows
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			return fmt.Errorf("infraction type with this name already exists")
+		}
+		zlog.Error().Err(err).Int("infraction_type_id", infraction.ID).Msg("Error updating infraction type")
+		return fmt.Errorf("error updating infraction type %d: %w", infraction.ID, err)
+	}
+	return nil
+}
+
+// DeleteInfractionType menghapus jenis pelanggaran milik parent. Sanksi yang sudah diterapkan tetap tersimpan
+// (dengan salinan namanya). Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+func (r *penaltyRepo) DeleteInfractionType(ctx context.Context, infractionTypeID int, parentID int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM infraction_types WHERE id = $1 AND created_by_user_id = $2`, infractionTypeID, parentID)
+	if err != nil {
+		zlog.Error().Err(err).Int("infraction_type_id", infractionTypeID).Msg("Error deleting infraction type")
+		return fmt.Errorf("error deleting infraction type %d: %w", infractionTypeID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	zlog.Info().Int("infraction_type_id", infractionTypeID).Int("parent_id", parentID).Msg("Infraction type deleted")
+	return nil
+}
+
+// GetPenaltiesByChildID mengambil sanksi anak dengan paginasi (terbaru dulu).
+func (r *penaltyRepo) GetPenaltiesByChildID(ctx context.Context, childID int, page, limit int) ([]models.Penalty, int, error) {
+	var totalCount int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM penalties WHERE child_id = $1`, childID).Scan(&totalCount); err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error counting penalties")
+		return nil, 0, fmt.Errorf("error counting penalties for child %d: %w", childID, err)
+	}
+	if totalCount == 0 {
+		return []models.Penalty{}, 0, nil
+	}
+
+	offset := (page - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+	rows, err := r.db.Query(ctx, penaltySelect+` WHERE p.child_id = $1 ORDER BY p.created_at DESC, p.id DESC LIMIT $2 OFFSET $3`,
+		childID, limit, offset)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error querying penalties")
+		return nil, totalCount, fmt.Errorf("error getting penalties for child %d: %w", childID, err)
+	}
+	penalties, err := collectPenalties(rows)
+	return penalties, totalCount, err
+}
+
+// GetPenaltiesBetween mengambil semua sanksi anak dalam rentang [from, to), terlama dulu.
+func (r *penaltyRepo) GetPenaltiesBetween(ctx context.Context, childID int, from, to time.Time) ([]models.Penalty, error) {
+	rows, err := r.db.Query(ctx, penaltySelect+` WHERE p.child_id = $1 AND p.created_at >= $2 AND p.created_at < $3
+              ORDER BY p.created_at, p.id`, childID, from, to)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error querying penalties for report")
+		return nil, fmt.Errorf("error getting penalties for child %d: %w", childID, err)
+	}
+	return collectPenalties(rows)
+}
+
+// collectPenalties memindai semua baris sanksi lalu menutup rows.
+func collectPenalties(rows pgx.Rows) ([]models.Penalty, error) {
+	defer rows.Close()
+	penalties := []models.Penalty{}
+	for rows.Next() {
+		var penalty models.Penalty
+		if err := scanPenalty(rows, &penalty); err != nil {
+			zlog.Warn().Err(err).Msg("Error scanning penalty row")
+			return nil, fmt.Errorf("error scanning penalty: %w", err)
+		}
+		penalties = append(penalties, penalty)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating penalties: %w", err)
+	}
+	return penalties, nil
+}
+
+// --- Metode Tx untuk Service Layer ---
+
+// CountPenaltiesSinceTx menghitung sanksi dengan jenis pelanggaran tertentu yang diterapkan ke anak sejak waktu since.
+func (r *penaltyRepo) CountPenaltiesSinceTx(ctx context.Context, tx pgx.Tx, childID int, infractionTypeID int, since time.Time) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM penalties WHERE child_id = $1 AND infraction_type_id = $2 AND created_at >= $3`
+	if err := tx.QueryRow(ctx, query, childID, infractionTypeID, since).Scan(&count); err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Int("infraction_type_id", infractionTypeID).Msg("RepoTx: Error counting penalties")
+		return 0, fmt.Errorf("repoTx error counting penalties for child %d: %w", childID, err)
+	}
+	return count, nil
+}
+
+// CreatePenaltyTx menyimpan sanksi baru dan mengisi ID serta timestamp.
+func (r *penaltyRepo) CreatePenaltyTx(ctx context.Context, tx pgx.Tx, penalty *models.Penalty) error {
+	query := `INSERT INTO penalties (child_id, infraction_type_id, infraction_name, points, points_deducted, notes,
+                                     applied_by_user_id, transaction_id, earn_back_user_task_id)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+              RETURNING id, created_at, updated_at`
+	err := tx.QueryRow(ctx, query, penalty.ChildID, nullableID(penalty.InfractionTypeID), penalty.InfractionName,
+		penalty.Points, penalty.PointsDeducted, nullableText(penalty.Notes), nullableID(penalty.AppliedByUserID),
+		nullableID(penalty.TransactionID), nullableID(penalty.EarnBackUserTaskID)).
+		Scan(&penalty.ID, &penalty.CreatedAt, &penalty.UpdatedAt)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", penalty.ChildID).Msg("RepoTx: Error creating penalty")
+		return fmt.Errorf("repoTx error creating penalty: %w", err)
+	}
+	return nil
+}
+
+// GetPenaltyByEarnBackUserTaskIDForUpdateTx mengambil sanksi yang ditebus oleh penugasan userTaskID dan mengunci barisnya.
+// Mengembalikan pgx.ErrNoRows jika penugasan tersebut bukan tugas penebus.
+func (r *penaltyRepo) GetPenaltyByEarnBackUserTaskIDForUpdateTx(ctx context.Context, tx pgx.Tx, userTaskID int) (*models.Penalty, error) {
+	penalty := &models.Penalty{}
+	err := scanPenalty(tx.QueryRow(ctx, penaltySelect+` WHERE p.earn_back_user_task_id = $1 FOR UPDATE OF p`, userTaskID), penalty)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("user_task_id", userTaskID).Msg("RepoTx: Error getting penalty by earn-back task")
+		return nil, fmt.Errorf("repoTx error getting penalty for user task %d: %w", userTaskID, err)
+	}
+	return penalty, nil
+}
+
+// MarkEarnedBackTx menandai sanksi sudah ditebus beserta entri ledger pengembaliannya (0 = tanpa entri).
+func (r *penaltyRepo) MarkEarnedBackTx(ctx context.Context, tx pgx.Tx, penaltyID int, reversalTransactionID int) error {
+	query := `UPDATE penalties SET earned_back_at = CURRENT_TIMESTAMP, reversal_transaction_id = $2
+              WHERE id = $1 AND earned_back_at IS NULL`
+	tag, err := tx.Exec(ctx, query, penaltyID, nullableID(reversalTransactionID))
+	if err != nil {
+		zlog.Error().Err(err).Int("penalty_id", penaltyID).Msg("RepoTx: Error marking penalty as earned back")
+		return fmt.Errorf("repoTx error updating penalty %d: %w", penaltyID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+// internal/repository/point_expiration_repo.go
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type pointExpirationRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewPointExpirationRepository membuat instance baru dari PointExpirationRepository.
+func NewPointExpirationRepository(db *pgxpool.Pool) PointExpirationRepository {
+	return &pointExpirationRepo{db: db}
+}
+
+const pointExpirationPolicyFields = `p.expire_after_days, p.warn_before_days, p.created_at, p.updated_at`
+
+const pointExpirationPolicyColumns = `p.id, p.created_by_user_id, COALESCE(p.child_id, 0), ` + pointExpirationPolicyFields
+
+// scanPointExpirationPolicy memindai satu baris kebijakan kedaluwarsa poin.
+func scanPointExpirationPolicy(row pgx.Row, policy *models.PointExpirationPolicy) error {
+	return row.Scan(&policy.ID, &policy.CreatedByUserID, &policy.ChildID, &policy.ExpireAfterDays, &policy.WarnBeforeDays,
+		&policy.CreatedAt, &policy.UpdatedAt)
+}
+
+// UpsertPolicy membuat atau memperbarui kebijakan kedaluwarsa poin milik parent untuk cakupan anaknya.
+func (r *pointExpirationRepo) UpsertPolicy(ctx context.Context, policy *models.PointExpirationPolicy) error {
+	query := `INSERT INTO point_expiration_policies (created_by_user_id, child_id, expire_after_days, warn_before_days)
+              VALUES ($1, $2, $3, $4)
+              ON CONFLICT ` + familyPolicyConflict + ` DO UPDATE
+              SET expire_after_days = EXCLUDED.expire_after_days,
+                  warn_before_days = EXCLUDED.warn_before_days
+              RETURNING id, created_at, updated_at`
+	err := r.db.QueryRow(ctx, query, policy.CreatedByUserID, nullableID(policy.ChildID), policy.ExpireAfterDays, policy.WarnBeforeDays).
+		Scan(&policy.ID, &policy.CreatedAt, &policy.UpdatedAt)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", policy.CreatedByUserID).Int("child_id", policy.ChildID).Msg("Error upserting point expiration policy")
+		return fmt.Errorf("error saving point expiration policy: %w", err)
+	}
+	zlog.Info().Int("policy_id", policy.ID).Int("parent_id", policy.CreatedByUserID).Int("child_id", policy.ChildID).
+		Int("expire_after_days", policy.ExpireAfterDays).Msg("Point expiration policy saved")
+	return nil
+}
+
+// getPointExpirationPolicy membaca satu kebijakan kedaluwarsa poin dengan klausa filter kebijakan keluarga.
+func (r *pointExpirationRepo) getPointExpirationPolicy(ctx context.Context, filter string, args ...any) (*models.PointExpirationPolicy, error) {
+	query := `SELECT ` + pointExpirationPolicyColumns + ` FROM point_expiration_policies p` + filter
+	policy := &models.PointExpirationPolicy{}
+	if err := scanPointExpirationPolicy(r.db.QueryRow(ctx, query, args...), policy); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Interface("args", args).Msg("Error getting point expiration policy")
+		return nil, fmt.Errorf("error getting point expiration policy: %w", err)
+	}
+	return policy, nil
+}
+
+// GetPolicyByOwner mendapatkan kebijakan kedaluwarsa poin milik parent untuk cakupan anak (0 = semua anak).
+func (r *pointExpirationRepo) GetPolicyByOwner(ctx context.Context, parentID int, childID int) (*models.PointExpirationPolicy, error) {
+	return r.getPointExpirationPolicy(ctx, familyPolicyOwnedBy, parentID, childID)
+}
+
+// GetPolicyForChild mendapatkan kebijakan kedaluwarsa poin yang berlaku untuk anak dari kebijakan orang tuanya.
+func (r *pointExpirationRepo) GetPolicyForChild(ctx context.Context, childID int) (*models.PointExpirationPolicy, error) {
+	return r.getPointExpirationPolicy(ctx, familyPolicyForChild, childID)
+}
+
+// DeletePolicy menghapus kebijakan kedaluwarsa poin milik parent untuk cakupan anak (0 = semua anak).
+func (r *pointExpirationRepo) DeletePolicy(ctx context.Context, parentID int, childID int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM point_expiration_policies p`+familyPolicyOwnedBy, parentID, childID)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", parentID).Int("child_id", childID).Msg("Error deleting point expiration policy")
+		return fmt.Errorf("error deleting point expiration policy: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// GetPoliciesAfter mengambil kebijakan yang berlaku untuk setiap anak dengan child_id > afterChildID
+// (keyset pagination untuk worker). ChildID setiap kebijakan adalah anak yang dituju.
+func (r *pointExpirationRepo) GetPoliciesAfter(ctx context.Context, afterChildID int, limit int) ([]models.PointExpirationPolicy, error) {
+	query := `SELECT p.id, p.created_by_user_id, c.child_id, ` + pointExpirationPolicyFields + familyPoliciesAfter("point_expiration_policies")
+	rows, err := r.db.Query(ctx, query, afterChildID, limit)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Error querying point expiration policies")
+		return nil, fmt.Errorf("error getting point expiration policies: %w", err)
+	}
+	defer rows.Close()
+
+	policies := []models.PointExpirationPolicy{}
+	for rows.Next() {
+		var policy models.PointExpirationPolicy
+		if err := scanPointExpirationPolicy(rows, &policy); err != nil {
+			zlog.Warn().Err(err).Msg("Error scanning point expiration policy row")
+			return nil, fmt.Errorf("error scanning point expiration policy: %w", err)
+		}
+		policies = append(policies, policy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating point expiration policies: %w", err)
+	}
+	return policies, nil
+}
+
+// getOpenLots menghitung sisa setiap lot pemasukan anak dengan FIFO memakai pool atau transaksi.
+//
+// Lot adalah transaksi dengan change_amount positif; semua transaksi negatif (redemption, penalty,
+// expiration, ...) memakai lot tertua lebih dulu. Pasangan transaksi yang sudah dibalik
+// (misal redemption + reward_refund, task_completion + task_reversal) saling meniadakan
+// sehingga tidak dihitung sebagai pemasukan maupun pemakaian. Hanya poin (currency_id NULL) yang memiliki lot.
+// Sisa lot ke-i = MIN(jumlah_i, kumulatif_i - total_pemakaian) untuk lot yang kumulatifnya melebihi total pemakaian.
+func getOpenLots(ctx context.Context, db rowQuerier, childID int) ([]models.PointLot, error) {
+	query := `WITH ledger AS (
+                  SELECT pt.id, pt.change_amount, pt.created_at
+                  FROM point_transactions pt
+                  WHERE pt.user_id = $1
+                    AND pt.currency_id IS NULL
+                    AND pt.reverses_transaction_id IS NULL
+                    AND NOT EXISTS (SELECT 1 FROM point_transactions rv WHERE rv.reverses_transaction_id = pt.id)
+              ), consumed AS (
+                  SELECT COALESCE(-SUM(change_amount), 0) AS total FROM ledger WHERE change_amount < 0
+              ), lots AS (
+                  SELECT id, change_amount, created_at,
+                         SUM(change_amount) OVER (ORDER BY created_at, id) AS cumulative
+                  FROM ledger WHERE change_amount > 0
+              )
+              SELECT l.id, l.change_amount, LEAST(l.change_amount, l.cumulative - c.total)::INT, l.created_at
+              FROM lots l CROSS JOIN consumed c
+              WHERE l.cumulative > c.total
+              ORDER BY l.created_at, l.id`
+	rows, err := db.Query(ctx, query, childID)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error querying open point lots")
+		return nil, fmt.Errorf("error getting point lots for child %d: %w", childID, err)
+	}
+	defer rows.Close()
+
+	lots := []models.PointLot{}
+	for rows.Next() {
+		var lot models.PointLot
+		if err := rows.Scan(&lot.TransactionID, &lot.Amount, &lot.Remaining, &lot.EarnedAt); err != nil {
+			zlog.Warn().Err(err).Int("child_id", childID).Msg("Error scanning point lot row")
+			return nil, fmt.Errorf("error scanning point lot: %w", err)
+		}
+		lots = append(lots, lot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating point lots: %w", err)
+	}
+	return lots, nil
+}
+
+// GetOpenLots mengambil lot poin anak yang masih tersisa (tertua lebih dulu).
+func (r *pointExpirationRepo) GetOpenLots(ctx context.Context, childID int) ([]models.PointLot, error) {
+	return getOpenLots(ctx, r.db, childID)
+}
+
+// GetOpenLotsTx mengambil lot poin anak yang masih tersisa dalam transaksi.
+func (r *pointExpirationRepo) GetOpenLotsTx(ctx context.Context, tx pgx.Tx, childID int) ([]models.PointLot, error) {
+	return getOpenLots(ctx, tx, childID)
+}
@@ -4,6 +4,7 @@ package repository
 import (
 	"context"
 	"database/sql" // Untuk sql.NullInt64 (atau sql.NullInt32) dan sql.NullString
+	"errors"
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
@@ -13,6 +14,20 @@ import (
 	zlog "github.com/rs/zerolog/log"
 )
 
+// ErrNegativeBalance dikembalikan saat transaksi poin akan membuat saldo anak negatif.
+var ErrNegativeBalance = errors.New("cannot record point transaction: balance cannot become negative")
+
+// errAlreadyReversed dikembalikan saat transaksi asli sudah pernah dibalik (unique index reverses_transaction_id).
+var errAlreadyReversed = fmt.Errorf("cannot reverse point transaction: it has already been reversed")
+
+// validateReversal memastikan setiap transaksi pembalikan menunjuk transaksi asli yang dibaliknya.
+func validateReversal(txData *models.PointTransaction) error {
+	if txData.TransactionType.IsReversal() && txData.ReversesTransactionID == 0 {
+		return fmt.Errorf("invalid point transaction: %s must reference the transaction it reverses", txData.TransactionType)
+	}
+	return nil
+}
+
 type pointTransactionRepo struct {
 	db *pgxpool.Pool
 }
@@ -23,44 +38,13 @@ func NewPointTransactionRepository(db *pgxpool.Pool) PointTransactionRepository
 }
 
 // CreateTransaction menyimpan record transaksi poin baru.
-// Biasanya dipanggil dari dalam Service Layer yang mengelola transaksi DB.
+// Insert ledger dan pembaruan saldo dijalankan dalam satu transaksi DB agar keduanya selalu konsisten.
 func (r *pointTransactionRepo) CreateTransaction(ctx context.Context, txData *models.PointTransaction) error {
-	// Kolom `updated_at` tidak perlu di query INSERT karena ada trigger
-	query := `INSERT INTO point_transactions
-                (user_id, change_amount, transaction_type, related_user_task_id, related_user_reward_id, created_by_user_id, notes)
-              VALUES ($1, $2, $3, $4, $5, $6, $7)`
-
-	// Gunakan Nullable types untuk FK yang opsional
-	var relatedTaskID sql.NullInt64 // Gunakan NullInt64 jika ID bisa besar, atau NullInt32
-	if txData.RelatedUserTaskID != 0 {
-		relatedTaskID = sql.NullInt64{Int64: int64(txData.RelatedUserTaskID), Valid: true}
-	}
-
-	var relatedRewardID sql.NullInt64
-	if txData.RelatedUserRewardID != 0 {
-		relatedRewardID = sql.NullInt64{Int64: int64(txData.RelatedUserRewardID), Valid: true}
-	}
-
-	_, err := r.db.Exec(ctx, query,
-		txData.UserID,
-		txData.ChangeAmount,
-		txData.TransactionType,
-		relatedTaskID,
-		relatedRewardID,
-		txData.CreatedByUserID,
-		txData.Notes,
-	)
-
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		return r.CreateTransactionTx(ctx, tx, txData)
+	})
 	if err != nil {
-		// Handle FK violation
-		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
-			zlog.Warn().Err(err).Interface("transaction_data", txData).Msg("Foreign key violation on point transaction creation")
-			// Perlu identifikasi FK mana yang gagal jika ingin pesan lebih spesifik
-			return fmt.Errorf("invalid user, creator, task, or reward ID provided for point transaction")
-		}
-		// Error umum
-		zlog.Error().Err(err).Interface("transaction_data", txData).Msg("Error creating point transaction")
-		return fmt.Errorf("error creating point transaction: %w", err)
+		return err
 	}
 
 	zlog.Info().Int("user_id", txData.UserID).Int("change", txData.ChangeAmount).Str("type", string(txData.TransactionType)).Msg("Point transaction created successfully")
@@ -92,7 +76,7 @@ func (r *pointTransactionRepo) GetTransactionsByUserID(ctx context.Context, user
 	query := `SELECT
                 id, user_id, change_amount, transaction_type,
                 related_user_task_id, related_user_reward_id,
-                created_by_user_id, notes, created_at, updated_at
+                created_by_user_id, notes, reverses_transaction_id, related_transfer_id, COALESCE(currency_id, 0), created_at, updated_at
               FROM point_transactions
               WHERE user_id = $1
               ORDER BY created_at DESC -- Tampilkan riwayat terbaru dulu
@@ -111,7 +95,10 @@ func (r *pointTransactionRepo) GetTransactionsByUserID(ctx context.Context, user
 		var tx models.PointTransaction
 		var relatedTaskID sql.NullInt64
 		var relatedRewardID sql.NullInt64
+		var createdByUserID sql.NullInt64 // NULL untuk transaksi oleh sistem
 		var notes sql.NullString
+		var reversesID sql.NullInt64
+		var transferID sql.NullInt64
 
 		scanErr := rows.Scan(
 			&tx.ID,
@@ -120,8 +107,11 @@ func (r *pointTransactionRepo) GetTransactionsByUserID(ctx context.Context, user
 			&tx.TransactionType,
 			&relatedTaskID,
 			&relatedRewardID,
-			&tx.CreatedByUserID,
+			&createdByUserID,
 			&notes,
+			&reversesID,
+			&transferID,
+			&tx.CurrencyID,
 			&tx.CreatedAt,
 			&tx.UpdatedAt, // Pastikan ada di model dan tabel
 		)
@@ -141,6 +131,15 @@ func (r *pointTransactionRepo) GetTransactionsByUserID(ctx context.Context, user
 		} else {
 			tx.RelatedUserRewardID = 0 // Atau sesuai default model
 		}
+		if createdByUserID.Valid {
+			tx.CreatedByUserID = int(createdByUserID.Int64)
+		}
+		if reversesID.Valid {
+			tx.ReversesTransactionID = int(reversesID.Int64)
+		}
+		if transferID.Valid {
+			tx.RelatedTransferID = int(transferID.Int64)
+		}
 		if notes.Valid {
 			tx.Notes = notes.String
 		} else {
@@ -160,7 +159,8 @@ func (r *pointTransactionRepo) GetTransactionsByUserID(ctx context.Context, user
 
 // CalculateTotalPointsByUserID menghitung total poin saat ini untuk user tertentu.
 func (r *pointTransactionRepo) CalculateTotalPointsByUserID(ctx context.Context, userID int) (int, error) {
-	query := `SELECT COALESCE(SUM(change_amount), 0) FROM point_transactions WHERE user_id = $1`
+	// Saldo dibaca dari point_balances; user tanpa transaksi belum punya baris (saldo 0)
+	query := `SELECT COALESCE((SELECT balance FROM point_balances WHERE user_id = $1), 0)`
 	var totalPoints int
 	err := r.db.QueryRow(ctx, query, userID).Scan(&totalPoints)
 	if err != nil {
@@ -178,10 +178,15 @@ func (r *pointTransactionRepo) CalculateTotalPointsByUserID(ctx context.Context,
 // --- Metode Tx untuk Service Layer ---
 
 // CreateTransactionTx menyimpan transaksi poin dalam konteks transaksi DB yang lebih besar.
+// ID dan created_at transaksi baru diisi ke txData.
 func (r *pointTransactionRepo) CreateTransactionTx(ctx context.Context, tx pgx.Tx, txData *models.PointTransaction) error {
+	if err := validateReversal(txData); err != nil {
+		return err
+	}
 	query := `INSERT INTO point_transactions
-                (user_id, change_amount, transaction_type, related_user_task_id, related_user_reward_id, created_by_user_id, notes)
-              VALUES ($1, $2, $3, $4, $5, $6, $7)`
+                (user_id, change_amount, transaction_type, related_user_task_id, related_user_reward_id, created_by_user_id, notes, reverses_transaction_id, related_transfer_id, currency_id)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+              RETURNING id, created_at`
 
 	var relatedTaskID sql.NullInt64
 	if txData.RelatedUserTaskID != 0 {
@@ -192,17 +197,25 @@ func (r *pointTransactionRepo) CreateTransactionTx(ctx context.Context, tx pgx.T
 		relatedRewardID = sql.NullInt64{Int64: int64(txData.RelatedUserRewardID), Valid: true}
 	}
 
-	_, err := tx.Exec(ctx, query, // Gunakan tx.Exec bukan r.db.Exec
+	// ID & created_at diisi kembali ke txData agar pemanggil bisa mereferensikan transaksi ini
+	err := tx.QueryRow(ctx, query, // Gunakan tx bukan r.db
 		txData.UserID,
 		txData.ChangeAmount,
 		txData.TransactionType,
 		relatedTaskID,
 		relatedRewardID,
-		txData.CreatedByUserID,
+		nullableID(txData.CreatedByUserID), // 0 = sistem (NULL)
 		txData.Notes,
-	)
+		nullableID(txData.ReversesTransactionID),
+		nullableID(txData.RelatedTransferID),
+		nullableID(txData.CurrencyID), // 0 = poin (NULL)
+	).Scan(&txData.ID, &txData.CreatedAt)
 
 	if err != nil {
+		// Transaksi asli sudah pernah dibalik (unique index reverses_transaction_id)
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_point_transactions_reverses" {
+			return errAlreadyReversed
+		}
 		// Handle FK violation
 		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
 			zlog.Warn().Err(err).Interface("transaction_data", txData).Msg("RepoTx: Foreign key violation on point transaction creation")
@@ -212,25 +225,170 @@ func (r *pointTransactionRepo) CreateTransactionTx(ctx context.Context, tx pgx.T
 		zlog.Error().Err(err).Interface("transaction_data", txData).Msg("RepoTx: Error creating point transaction")
 		return fmt.Errorf("repoTx error creating point transaction: %w", err)
 	}
-	return nil // Sukses dalam konteks transaksi ini
+
+	// Saldo materialized diperbarui di transaksi yang sama dengan insert ledger
+	return applyBalanceChangeTx(ctx, tx, txData.UserID, txData.CurrencyID, txData.ChangeAmount)
 }
 
-// CalculateTotalPointsByUserIDTx menghitung total poin dalam transaksi (mungkin perlu locking).
+// applyBalanceChangeTx menambahkan delta ke point_balances (poin, currencyID 0) atau currency_balances
+// (membuat baris jika belum ada). CHECK constraint balance >= 0 menolak perubahan yang membuat saldo negatif.
+func applyBalanceChangeTx(ctx context.Context, tx pgx.Tx, userID int, currencyID int, delta int) error {
+	query := `INSERT INTO point_balances (user_id, balance) VALUES ($1, $2)
+              ON CONFLICT (user_id) DO UPDATE SET balance = point_balances.balance + EXCLUDED.balance`
+	args := []any{userID, delta}
+	if currencyID != 0 {
+		query = `INSERT INTO currency_balances (user_id, currency_id, balance) VALUES ($1, $3, $2)
+                 ON CONFLICT (user_id, currency_id) DO UPDATE SET balance = currency_balances.balance + EXCLUDED.balance`
+		args = append(args, currencyID)
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23514" {
+			zlog.Warn().Int("user_id", userID).Int("delta", delta).Msg("RepoTx: Point transaction would make balance negative")
+			return ErrNegativeBalance
+		}
+		zlog.Error().Err(err).Int("user_id", userID).Int("delta", delta).Msg("RepoTx: Error updating point balance")
+		return fmt.Errorf("repoTx error updating point balance for user %d: %w", userID, err)
+	}
+	return nil
+}
+
+// CalculateBalanceTx membaca saldo satu mata uang (0 = poin) dalam transaksi dan mengunci barisnya,
+// sama seperti CalculateTotalPointsByUserIDTx untuk poin.
+func (r *pointTransactionRepo) CalculateBalanceTx(ctx context.Context, tx pgx.Tx, userID int, currencyID int) (int, error) {
+	if currencyID == 0 {
+		return r.CalculateTotalPointsByUserIDTx(ctx, tx, userID)
+	}
+	if _, err := tx.Exec(ctx, `INSERT INTO currency_balances (user_id, currency_id) VALUES ($1, $2) ON CONFLICT (user_id, currency_id) DO NOTHING`, userID, currencyID); err != nil {
+		zlog.Error().Err(err).Int("user_id", userID).Int("currency_id", currencyID).Msg("RepoTx: Error ensuring currency balance row")
+		return 0, fmt.Errorf("repoTx error ensuring balance of currency %d for user %d: %w", currencyID, userID, err)
+	}
+	var balance int
+	err := tx.QueryRow(ctx, `SELECT balance FROM currency_balances WHERE user_id = $1 AND currency_id = $2 FOR UPDATE`, userID, currencyID).Scan(&balance)
+	if err != nil {
+		zlog.Error().Err(err).Int("user_id", userID).Int("currency_id", currencyID).Msg("RepoTx: Error locking currency balance for user")
+		return 0, fmt.Errorf("repoTx error getting balance of currency %d for user %d: %w", currencyID, userID, err)
+	}
+	return balance, nil
+}
+
+// CalculateTotalPointsByUserIDTx membaca saldo poin dalam transaksi dan mengunci baris point_balances
+// (SELECT ... FOR UPDATE), sehingga dua operasi yang membaca lalu mengurangi saldo anak yang sama
+// (misal dua ClaimReward bersamaan) berjalan berurutan dan tidak bisa membelanjakan poin yang sama dua kali.
 func (r *pointTransactionRepo) CalculateTotalPointsByUserIDTx(ctx context.Context, tx pgx.Tx, userID int) (int, error) {
-	// Tambahkan 'FOR UPDATE' pada tabel point_transactions jika perlu mencegah race condition
-	// saat membaca total poin dalam sebuah transaksi yang mungkin akan mengubahnya.
-	// Namun, ini bisa menyebabkan contention. Alternatifnya adalah mengandalkan isolation level DB.
-	// Untuk perhitungan sederhana, 'FOR UPDATE' mungkin tidak perlu jika isolasi READ COMMITTED cukup.
-	// Jika Anda menggunakan SELECT SUM(...) lalu INSERT/UPDATE berdasarkan hasil SUM itu,
-	// Anda MUNGKIN perlu locking yang lebih cermat atau menggunakan CTE dengan locking.
-	// Untuk 'ClaimReward', mungkin cukup lock baris reward dan user (jika ada tabel user balance).
-	// Karena kita pakai ledger, lock mungkin tidak sekrusial itu untuk SUM saja.
-	query := `SELECT COALESCE(SUM(change_amount), 0) FROM point_transactions WHERE user_id = $1`
+	// Pastikan baris saldo ada agar selalu ada yang bisa dikunci
+	if _, err := tx.Exec(ctx, `INSERT INTO point_balances (user_id) VALUES ($1) ON CONFLICT (user_id) DO NOTHING`, userID); err != nil {
+		zlog.Error().Err(err).Int("user_id", userID).Msg("RepoTx: Error ensuring point balance row")
+		return 0, fmt.Errorf("repoTx error ensuring point balance for user %d: %w", userID, err)
+	}
 	var totalPoints int
-	err := tx.QueryRow(ctx, query, userID).Scan(&totalPoints)
+	err := tx.QueryRow(ctx, `SELECT balance FROM point_balances WHERE user_id = $1 FOR UPDATE`, userID).Scan(&totalPoints)
 	if err != nil {
-		zlog.Error().Err(err).Int("user_id", userID).Msg("RepoTx: Error calculating total points for user")
+		zlog.Error().Err(err).Int("user_id", userID).Msg("RepoTx: Error locking point balance for user")
 		return 0, fmt.Errorf("repoTx error calculating points for user %d: %w", userID, err)
 	}
 	return totalPoints, nil
 }
+
+// GetUnreversedTransactionTx mengambil transaksi terbaru berjenis txType yang terkait UserTask/UserReward
+// tertentu dan belum pernah dibalik. Baris dikunci agar tidak dibalik dua kali secara bersamaan.
+// Mengembalikan pgx.ErrNoRows jika tidak ada transaksi yang bisa dibalik.
+func (r *pointTransactionRepo) GetUnreversedTransactionTx(ctx context.Context, tx pgx.Tx, txType models.TransactionType, userTaskID, userRewardID int) (*models.PointTransaction, error) {
+	query := `SELECT o.id, o.user_id, o.change_amount, o.transaction_type, COALESCE(o.currency_id, 0)
+              FROM point_transactions o
+              WHERE o.transaction_type = $1
+                AND ($2 = 0 OR o.related_user_task_id = $2)
+                AND ($3 = 0 OR o.related_user_reward_id = $3)
+                AND NOT EXISTS (SELECT 1 FROM point_transactions rv WHERE rv.reverses_transaction_id = o.id)
+              ORDER BY o.id DESC
+              LIMIT 1
+              FOR UPDATE`
+	var original models.PointTransaction
+	err := tx.QueryRow(ctx, query, txType, userTaskID, userRewardID).Scan(
+		&original.ID, &original.UserID, &original.ChangeAmount, &original.TransactionType, &original.CurrencyID,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		zlog.Error().Err(err).Str("type", string(txType)).Int("user_task_id", userTaskID).Int("user_reward_id", userRewardID).Msg("RepoTx: Error getting unreversed point transaction")
+		return nil, fmt.Errorf("repoTx error getting unreversed point transaction: %w", err)
+	}
+	original.RelatedUserTaskID = userTaskID
+	original.RelatedUserRewardID = userRewardID
+	return &original, nil
+}
+
+// ReconcileBalances menghitung ulang saldo dari ledger dan melaporkan setiap user (per mata uang) yang saldo
+// tersimpannya berbeda. Jika fix bernilai true, saldo tersimpan disamakan dengan ledger (kecuali ledger negatif,
+// yang hanya dilaporkan).
+func (r *pointTransactionRepo) ReconcileBalances(ctx context.Context, fix bool) ([]models.PointBalanceDrift, error) {
+	query := `WITH stored AS (
+                  SELECT user_id, 0 AS currency_id, balance FROM point_balances
+                  UNION ALL
+                  SELECT user_id, currency_id, balance FROM currency_balances
+              ), ledger AS (
+                  SELECT user_id, COALESCE(currency_id, 0) AS currency_id, SUM(change_amount)::INT AS total
+                  FROM point_transactions GROUP BY user_id, COALESCE(currency_id, 0)
+              )
+              SELECT COALESCE(b.user_id, l.user_id), COALESCE(b.currency_id, l.currency_id), COALESCE(b.balance, 0), COALESCE(l.total, 0)
+              FROM stored b
+              FULL OUTER JOIN ledger l ON l.user_id = b.user_id AND l.currency_id = b.currency_id
+              WHERE COALESCE(b.balance, 0) <> COALESCE(l.total, 0)
+              ORDER BY 1, 2`
+
+	drifts := []models.PointBalanceDrift{}
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		// Kunci tabel dari penulisan agar ledger dan saldo dibandingkan pada snapshot yang sama
+		if _, err := tx.Exec(ctx, `LOCK TABLE point_balances, currency_balances IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+			return fmt.Errorf("error locking point balances: %w", err)
+		}
+		rows, err := tx.Query(ctx, query)
+		if err != nil {
+			return fmt.Errorf("error comparing point balances with ledger: %w", err)
+		}
+		for rows.Next() {
+			var d models.PointBalanceDrift
+			if err := rows.Scan(&d.UserID, &d.CurrencyID, &d.StoredBalance, &d.LedgerBalance); err != nil {
+				rows.Close()
+				return fmt.Errorf("error scanning point balance drift: %w", err)
+			}
+			d.Drift = d.StoredBalance - d.LedgerBalance
+			drifts = append(drifts, d)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating point balance drift: %w", err)
+		}
+
+		if !fix {
+			return nil
+		}
+		for i := range drifts {
+			if drifts[i].LedgerBalance < 0 {
+				continue
+			}
+			var err error
+			if drifts[i].CurrencyID == 0 {
+				_, err = tx.Exec(ctx, `INSERT INTO point_balances (user_id, balance) VALUES ($1, $2)
+                                       ON CONFLICT (user_id) DO UPDATE SET balance = EXCLUDED.balance`,
+					drifts[i].UserID, drifts[i].LedgerBalance)
+			} else {
+				_, err = tx.Exec(ctx, `INSERT INTO currency_balances (user_id, currency_id, balance) VALUES ($1, $2, $3)
+                                       ON CONFLICT (user_id, currency_id) DO UPDATE SET balance = EXCLUDED.balance`,
+					drifts[i].UserID, drifts[i].CurrencyID, drifts[i].LedgerBalance)
+			}
+			if err != nil {
+				return fmt.Errorf("error fixing point balance for user %d: %w", drifts[i].UserID, err)
+			}
+			drifts[i].Fixed = true
+		}
+		return nil
+	})
+	if err != nil {
+		zlog.Error().Err(err).Bool("fix", fix).Msg("Error reconciling point balances")
+		return nil, err
+	}
+
+	zlog.Info().Int("drift_count", len(drifts)).Bool("fix", fix).Msg("Point balances reconciled")
+	return drifts, nil
+}
@@ -0,0 +1,254 @@
+// internal/repository/point_transfer_repo.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type pointTransferRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewPointTransferRepository membuat instance baru dari PointTransferRepository.
+func NewPointTransferRepository(db *pgxpool.Pool) PointTransferRepository {
+	return &pointTransferRepo{db: db}
+}
+
+const transferPolicyColumns = `p.id, p.created_by_user_id, COALESCE(p.child_id, 0), p.allowed, p.max_amount, p.requires_approval,
+                p.created_at, p.updated_at`
+
+// scanTransferPolicy memindai satu baris kebijakan transfer.
+func scanTransferPolicy(row pgx.Row, policy *models.TransferPolicy) error {
+	return row.Scan(&policy.ID, &policy.CreatedByUserID, &policy.ChildID, &policy.Allowed, &policy.MaxAmount, &policy.RequiresApproval,
+		&policy.CreatedAt, &policy.UpdatedAt)
+}
+
+// pointTransferSelect memilih kolom transfer beserta username pengirim dan penerima.
+const pointTransferSelect = `SELECT pt.id, pt.from_child_id, pt.to_child_id, fu.username, tu.username, pt.amount, pt.note,
+                pt.status, COALESCE(pt.reviewed_by_user_id, 0), pt.reviewed_at, pt.review_note,
+                COALESCE(pt.debit_transaction_id, 0), COALESCE(pt.credit_transaction_id, 0), pt.created_at, pt.updated_at
+              FROM point_transfers pt
+              JOIN users fu ON fu.id = pt.from_child_id
+              JOIN users tu ON tu.id = pt.to_child_id`
+
+// scanPointTransfer memindai satu baris transfer poin.
+func scanPointTransfer(row pgx.Row, transfer *models.PointTransfer) error {
+	var note, reviewNote sql.NullString
+	err := row.Scan(&transfer.ID, &transfer.FromChildID, &transfer.ToChildID, &transfer.FromUsername, &transfer.ToUsername,
+		&transfer.Amount, &note, &transfer.Status, &transfer.ReviewedByUserID, &transfer.ReviewedAt, &reviewNote,
+		&transfer.DebitTransactionID, &transfer.CreditTransactionID, &transfer.CreatedAt, &transfer.UpdatedAt)
+	transfer.Note = note.String
+	transfer.ReviewNote = reviewNote.String
+	return err
+}
+
+// queryPointTransfers menjalankan query hitung dan query data transfer dengan paginasi.
+func (r *pointTransferRepo) queryPointTransfers(ctx context.Context, where string, args []any, page, limit int) ([]models.PointTransfer, int, error) {
+	var totalCount int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM point_transfers pt WHERE `+where, args...).Scan(&totalCount); err != nil {
+		zlog.Error().Err(err).Msg("Error counting point transfers")
+		return nil, 0, fmt.Errorf("error counting point transfers: %w", err)
+	}
+	if totalCount == 0 {
+		return []models.PointTransfer{}, 0, nil
+	}
+
+	offset := (page - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+	query := fmt.Sprintf(`%s WHERE %s ORDER BY pt.created_at DESC, pt.id DESC LIMIT $%d OFFSET $%d`,
+		pointTransferSelect, where, len(args)+1, len(args)+2)
+	rows, err := r.db.Query(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Error querying point transfers")
+		return nil, totalCount, fmt.Errorf("error getting point transfers: %w", err)
+	}
+	defer rows.Close()
+
+	transfers := []models.PointTransfer{}
+	for rows.Next() {
+		var transfer models.PointTransfer
+		if err := scanPointTransfer(rows, &transfer); err != nil {
+			zlog.Warn().Err(err).Msg("Error scanning point transfer row")
+			return nil, totalCount, fmt.Errorf("error scanning point transfer: %w", err)
+		}
+		transfers = append(transfers, transfer)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, totalCount, fmt.Errorf("error iterating point transfers: %w", err)
+	}
+	return transfers, totalCount, nil
+}
+
+// UpsertPolicy membuat atau memperbarui kebijakan transfer milik parent untuk cakupan anaknya.
+func (r *pointTransferRepo) UpsertPolicy(ctx context.Context, policy *models.TransferPolicy) error {
+	query := `INSERT INTO transfer_policies (created_by_user_id, child_id, allowed, max_amount, requires_approval)
+              VALUES ($1, $2, $3, $4, $5)
+              ON CONFLICT ` + familyPolicyConflict + ` DO UPDATE
+              SET allowed = EXCLUDED.allowed,
+                  max_amount = EXCLUDED.max_amount,
+                  requires_approval = EXCLUDED.requires_approval
+              RETURNING id, created_at, updated_at`
+	err := r.db.QueryRow(ctx, query, policy.CreatedByUserID, nullableID(policy.ChildID), policy.Allowed, policy.MaxAmount, policy.RequiresApproval).
+		Scan(&policy.ID, &policy.CreatedAt, &policy.UpdatedAt)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", policy.CreatedByUserID).Int("child_id", policy.ChildID).Msg("Error upserting transfer policy")
+		return fmt.Errorf("error saving transfer policy: %w", err)
+	}
+	zlog.Info().Int("policy_id", policy.ID).Int("parent_id", policy.CreatedByUserID).Int("child_id", policy.ChildID).
+		Bool("allowed", policy.Allowed).Bool("requires_approval", policy.RequiresApproval).Msg("Transfer policy saved")
+	return nil
+}
+
+// getTransferPolicy membaca satu kebijakan transfer dengan klausa filter kebijakan keluarga.
+func (r *pointTransferRepo) getTransferPolicy(ctx context.Context, filter string, args ...any) (*models.TransferPolicy, error) {
+	query := `SELECT ` + transferPolicyColumns + ` FROM transfer_policies p` + filter
+	policy := &models.TransferPolicy{}
+	if err := scanTransferPolicy(r.db.QueryRow(ctx, query, args...), policy); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Interface("args", args).Msg("Error getting transfer policy")
+		return nil, fmt.Errorf("error getting transfer policy: %w", err)
+	}
+	return policy, nil
+}
+
+// GetPolicyByOwner mendapatkan kebijakan transfer milik parent untuk cakupan anak (0 = semua anak).
+func (r *pointTransferRepo) GetPolicyByOwner(ctx context.Context, parentID int, childID int) (*models.TransferPolicy, error) {
+	return r.getTransferPolicy(ctx, familyPolicyOwnedBy, parentID, childID)
+}
+
+// GetPolicyForChild mendapatkan kebijakan transfer yang berlaku untuk anak dari kebijakan orang tuanya.
+func (r *pointTransferRepo) GetPolicyForChild(ctx context.Context, childID int) (*models.TransferPolicy, error) {
+	return r.getTransferPolicy(ctx, familyPolicyForChild, childID)
+}
+
+// DeletePolicy menghapus kebijakan transfer milik parent untuk cakupan anak (0 = semua anak).
+func (r *pointTransferRepo) DeletePolicy(ctx context.Context, parentID int, childID int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM transfer_policies p`+familyPolicyOwnedBy, parentID, childID)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", parentID).Int("child_id", childID).Msg("Error deleting transfer policy")
+		return fmt.Errorf("error deleting transfer policy: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// AreSiblings memeriksa apakah dua anak memiliki setidaknya satu orang tua yang sama.
+func (r *pointTransferRepo) AreSiblings(ctx context.Context, childID1 int, childID2 int) (bool, error) {
+	query := `SELECT EXISTS (
+                  SELECT 1 FROM user_relationship a
+                  JOIN user_relationship b ON b.parent_id = a.parent_id
+                  WHERE a.child_id = $1 AND b.child_id = $2
+              )`
+	var siblings bool
+	if err := r.db.QueryRow(ctx, query, childID1, childID2).Scan(&siblings); err != nil {
+		zlog.Error().Err(err).Int("child_id_1", childID1).Int("child_id_2", childID2).Msg("Error checking sibling relationship")
+		return false, fmt.Errorf("error checking sibling relationship: %w", err)
+	}
+	return siblings, nil
+}
+
+// GetTransferByID mendapatkan transfer berdasarkan ID. Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+func (r *pointTransferRepo) GetTransferByID(ctx context.Context, transferID int) (*models.PointTransfer, error) {
+	transfer := &models.PointTransfer{}
+	if err := scanPointTransfer(r.db.QueryRow(ctx, pointTransferSelect+` WHERE pt.id = $1`, transferID), transfer); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("transfer_id", transferID).Msg("Error getting point transfer")
+		return nil, fmt.Errorf("error getting point transfer %d: %w", transferID, err)
+	}
+	return transfer, nil
+}
+
+// GetTransfersByChildID mengambil transfer yang dikirim atau diterima anak (terbaru dulu) dengan paginasi.
+func (r *pointTransferRepo) GetTransfersByChildID(ctx context.Context, childID int, page, limit int) ([]models.PointTransfer, int, error) {
+	return r.queryPointTransfers(ctx, `(pt.from_child_id = $1 OR pt.to_child_id = $1)`, []any{childID}, page, limit)
+}
+
+// GetTransfersByParentID mengambil transfer yang dikirim anak-anak parent, opsional difilter status.
+func (r *pointTransferRepo) GetTransfersByParentID(ctx context.Context, parentID int, status models.PointTransferStatus, page, limit int) ([]models.PointTransfer, int, error) {
+	where := `pt.from_child_id IN (SELECT child_id FROM user_relationship WHERE parent_id = $1)
+              AND ($2 = '' OR pt.status::TEXT = $2)`
+	return r.queryPointTransfers(ctx, where, []any{parentID, string(status)}, page, limit)
+}
+
+// UpdateTransferStatus mengubah status transfer 'pending' menjadi status akhir tanpa memindahkan poin
+// (penolakan oleh parent atau pembatalan oleh pengirim). Mengembalikan pgx.ErrNoRows jika transfer
+// tidak ada atau sudah tidak 'pending'.
+func (r *pointTransferRepo) UpdateTransferStatus(ctx context.Context, transferID int, status models.PointTransferStatus, reviewerID int, reviewNote string) error {
+	query := `UPDATE point_transfers
+              SET status = $2, reviewed_by_user_id = $3, reviewed_at = CASE WHEN $3::INT IS NULL THEN NULL ELSE NOW() END,
+                  review_note = NULLIF($4, '')
+              WHERE id = $1 AND status = 'pending'`
+	tag, err := r.db.Exec(ctx, query, transferID, status, nullableID(reviewerID), reviewNote)
+	if err != nil {
+		zlog.Error().Err(err).Int("transfer_id", transferID).Msg("Error updating point transfer status")
+		return fmt.Errorf("error updating point transfer %d: %w", transferID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// --- Metode Transaksional ---
+
+// CreateTransferTx menyimpan transfer baru dan mengisi ID serta timestamp.
+func (r *pointTransferRepo) CreateTransferTx(ctx context.Context, tx pgx.Tx, transfer *models.PointTransfer) error {
+	query := `INSERT INTO point_transfers (from_child_id, to_child_id, amount, note, status)
+              VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+              RETURNING id, created_at, updated_at`
+	err := tx.QueryRow(ctx, query, transfer.FromChildID, transfer.ToChildID, transfer.Amount, transfer.Note, transfer.Status).
+		Scan(&transfer.ID, &transfer.CreatedAt, &transfer.UpdatedAt)
+	if err != nil {
+		zlog.Error().Err(err).Int("from_child_id", transfer.FromChildID).Int("to_child_id", transfer.ToChildID).Msg("RepoTx: Error creating point transfer")
+		return fmt.Errorf("repoTx error creating point transfer: %w", err)
+	}
+	return nil
+}
+
+// GetTransferForUpdateTx mengambil transfer dan menguncinya hingga transaksi selesai.
+func (r *pointTransferRepo) GetTransferForUpdateTx(ctx context.Context, tx pgx.Tx, transferID int) (*models.PointTransfer, error) {
+	transfer := &models.PointTransfer{}
+	if err := scanPointTransfer(tx.QueryRow(ctx, pointTransferSelect+` WHERE pt.id = $1 FOR UPDATE OF pt`, transferID), transfer); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("transfer_id", transferID).Msg("RepoTx: Error locking point transfer")
+		return nil, fmt.Errorf("repoTx error getting point transfer %d: %w", transferID, err)
+	}
+	return transfer, nil
+}
+
+// CompleteTransferTx menandai transfer selesai dan mengaitkannya dengan kedua entri ledger.
+// reviewerID 0 berarti transfer selesai otomatis tanpa persetujuan.
+func (r *pointTransferRepo) CompleteTransferTx(ctx context.Context, tx pgx.Tx, transfer *models.PointTransfer, reviewerID int) error {
+	query := `UPDATE point_transfers
+              SET status = 'completed', debit_transaction_id = $2, credit_transaction_id = $3,
+                  reviewed_by_user_id = $4, reviewed_at = CASE WHEN $4::INT IS NULL THEN NULL ELSE NOW() END
+              WHERE id = $1
+              RETURNING reviewed_at, updated_at`
+	err := tx.QueryRow(ctx, query, transfer.ID, transfer.DebitTransactionID, transfer.CreditTransactionID, nullableID(reviewerID)).
+		Scan(&transfer.ReviewedAt, &transfer.UpdatedAt)
+	if err != nil {
+		zlog.Error().Err(err).Int("transfer_id", transfer.ID).Msg("RepoTx: Error completing point transfer")
+		return fmt.Errorf("repoTx error completing point transfer %d: %w", transfer.ID, err)
+	}
+	transfer.Status = models.PointTransferStatusCompleted
+	transfer.ReviewedByUserID = reviewerID
+	return nil
+}
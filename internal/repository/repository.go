@@ -58,6 +58,10 @@ type UserRepository interface {
 	// CreateUserTx membuat pengguna baru dalam konteks transaksi database.
 	// Mengembalikan ID pengguna baru atau error jika terjadi kesalahan.
 	CreateUserTx(ctx context.Context, tx pgx.Tx, user *models.RegisterUserInput, hashedPassword string) (int, error)
+
+	// GetUserByIDTx mencari pengguna berdasarkan ID dalam konteks transaksi.
+	// Mengembalikan data pengguna (termasuk role) atau error jika tidak ditemukan.
+	GetUserByIDTx(ctx context.Context, tx pgx.Tx, id int) (*models.User, error)
 }
 
 // ====================================================================================
@@ -152,7 +156,11 @@ type TaskRepository interface {
 
 	// GetTasksByCreatorID mendapatkan daftar tugas yang dibuat oleh pengguna tertentu (orang tua) dengan paginasi.
 	// Mengembalikan slice tugas, total jumlah tugas, dan error jika ada.
-	GetTasksByCreatorID(ctx context.Context, creatorID int, page, limit int) ([]models.Task, int, error)
+	GetTasksByCreatorID(ctx context.Context, creatorID int, filter *models.DefinitionFilter, page, limit int) ([]models.Task, int, error)
+
+	// CreateTaskTx membuat definisi tugas dalam konteks transaksi (misal: impor template secara massal),
+	// termasuk tautan ke template sumbernya. Mengembalikan ID tugas baru atau error.
+	CreateTaskTx(ctx context.Context, tx pgx.Tx, task *models.Task) (int, error)
 
 	// UpdateTask memperbarui definisi tugas.
 	// Memerlukan parentID untuk validasi kepemilikan. Mengembalikan error jika terjadi kesalahan.
@@ -173,13 +181,17 @@ type TaskVerificationDetails struct {
 	ChildID       int                 // ID Anak yang mengerjakan tugas
 	CurrentStatus models.UserTaskStatus // Status tugas saat ini sebelum verifikasi
 	TaskPoint     int                 // Jumlah poin yang terkait dengan tugas
+	CurrencyID    int                 // Mata uang hadiah tugas (0 = poin)
+	TaskID        int                 // ID definisi tugas (dibutuhkan saat reassign)
+	VerifiedAt    *time.Time          // Waktu verifikasi terakhir (dibutuhkan saat revert), nil jika belum diverifikasi
 }
 
 // UserTaskRepository: Kontrak untuk operasi data terkait Tugas yang Ditugaskan kepada Pengguna (UserTask).
 type UserTaskRepository interface {
-	// AssignTask menugaskan sebuah tugas kepada pengguna (anak).
-	// Mengembalikan ID UserTask baru atau error jika terjadi kesalahan.
-	AssignTask(ctx context.Context, userID, taskID, assignedByID int) (int, error)
+	// AssignTask menugaskan sebuah tugas kepada pengguna (anak) dan mencatat event 'assign'.
+	// dueAt bersifat opsional (nil = tanpa batas waktu). Mengembalikan ID UserTask baru, ErrTaskAlreadyActive jika
+	// anak masih memiliki tugas yang sama yang aktif, atau error jika terjadi kesalahan.
+	AssignTask(ctx context.Context, userID, taskID, assignedByID int, dueAt *time.Time) (int, error)
 
 	// GetUserTaskByID mencari tugas yang ditugaskan berdasarkan ID UserTask.
 	// Mengembalikan data UserTask (mungkin perlu JOIN dengan Task dan User) atau error.
@@ -193,31 +205,44 @@ type UserTaskRepository interface {
 	// Mengembalikan slice UserTask, total jumlah, dan error jika ada.
 	GetTasksByParentID(ctx context.Context, parentID int, statusFilter string, page, limit int) ([]models.UserTask, int, error)
 
-	// UpdateUserTaskStatus memperbarui status tugas yang ditugaskan.
-	// verifierID bersifat opsional (digunakan saat orang tua memverifikasi). Mengembalikan error jika terjadi kesalahan.
-	UpdateUserTaskStatus(ctx context.Context, id int, newStatus models.UserTaskStatus, verifierID *int) error
-
-	// SubmitTask menandai tugas sebagai selesai oleh anak.
+	// SubmitTask menandai tugas sebagai selesai oleh anak (transisi 'submit') dalam transaksinya sendiri.
 	// Memerlukan childID untuk validasi kepemilikan. Mengembalikan error jika terjadi kesalahan.
 	SubmitTask(ctx context.Context, id int, childID int) error
 
-	// VerifyTask memverifikasi tugas yang telah disubmit oleh anak (dilakukan oleh orang tua).
-	// Memerlukan parentID untuk validasi relasi. Mengembalikan data UserTask yang diperbarui atau error.
-	VerifyTask(ctx context.Context, id int, parentID int, newStatus models.UserTaskStatus) (*models.UserTask, error)
+	// GetEventsByUserTaskID mengambil riwayat transisi (timeline) sebuah penugasan, urut dari yang terlama.
+	GetEventsByUserTaskID(ctx context.Context, userTaskID int) ([]models.UserTaskEvent, error)
+
+	// GetOverdueUserTaskIDs mengambil ID penugasan 'assigned' yang batas waktunya sudah lewat pada waktu `now`.
+	GetOverdueUserTaskIDs(ctx context.Context, now time.Time, limit int) ([]int, error)
 
 	// CheckExistingActiveTask memeriksa apakah anak sudah memiliki tugas yang sama yang masih aktif (belum selesai/ditolak).
 	// Mengembalikan boolean (true jika ada) dan error jika terjadi kesalahan.
 	CheckExistingActiveTask(ctx context.Context, userID, taskID int) (bool, error)
 
+	// CheckExistingActiveTaskTx sama seperti CheckExistingActiveTask dalam konteks transaksi, sambil mengunci
+	// pasangan anak & tugas hingga transaksi selesai agar penugasan bersamaan diproses berurutan.
+	CheckExistingActiveTaskTx(ctx context.Context, tx pgx.Tx, userID, taskID int) (bool, error)
+
 	// --- Metode Transaksional ---
 
 	// GetTaskDetailsForVerificationTx mendapatkan detail tugas yang diperlukan untuk proses verifikasi dalam konteks transaksi.
 	// Mengembalikan detail verifikasi atau error.
 	GetTaskDetailsForVerificationTx(ctx context.Context, tx pgx.Tx, userTaskID int) (*TaskVerificationDetails, error)
 
-	// UpdateStatusTx memperbarui status tugas dalam konteks transaksi.
-	// Memerlukan verifierID. Mengembalikan error jika terjadi kesalahan.
-	UpdateStatusTx(ctx context.Context, tx pgx.Tx, id int, newStatus models.UserTaskStatus, verifierID int) error
+	// ApplyTransitionTx menerapkan transisi status (hasil models.NextUserTaskStatus) dalam konteks transaksi:
+	// memperbarui status & kolom timestamp terkait lalu mencatat event-nya. Status asal dicek ulang di SQL.
+	ApplyTransitionTx(ctx context.Context, tx pgx.Tx, event *models.UserTaskEvent) error
+
+	// CreateEventTx mencatat event timeline tanpa mengubah status (misal: reassign) dalam konteks transaksi.
+	CreateEventTx(ctx context.Context, tx pgx.Tx, event *models.UserTaskEvent) error
+
+	// AssignTaskTx sama seperti AssignTask tetapi dijalankan dalam konteks transaksi
+	// (misal: saat anak mengklaim bounty). Mengembalikan ID UserTask baru atau error.
+	AssignTaskTx(ctx context.Context, tx pgx.Tx, userID, taskID, assignedByID int, dueAt *time.Time) (int, error)
+
+	// ReassignTaskTx memindahkan penugasan berstatus 'assigned' ke anak lain dalam konteks transaksi.
+	// Mengembalikan error jika status sudah berubah.
+	ReassignTaskTx(ctx context.Context, tx pgx.Tx, id int, newChildID int) error
 }
 
 // ====================================================================================
@@ -227,9 +252,11 @@ type UserTaskRepository interface {
 // RewardDetails adalah struct helper untuk membawa data yang diperlukan
 // saat klaim reward dalam transaksi.
 type RewardDetails struct {
-	ID              int // ID Reward
-	RequiredPoints  int // Poin yang dibutuhkan untuk klaim
-	CreatedByUserID int // ID Pengguna (Orang Tua) yang membuat reward
+	ID              int                 // ID Reward
+	RequiredPoints  int                 // Poin yang dibutuhkan untuk klaim
+	CreatedByUserID int                 // ID Pengguna (Orang Tua) yang membuat reward
+	CurrencyID      int                 // Mata uang harga reward (0 = poin)
+	Limits          models.RewardLimits // Stok, kuota per anak, dan cooldown
 }
 
 // RewardRepository: Kontrak untuk operasi data terkait definisi Hadiah (Reward).
@@ -244,9 +271,14 @@ type RewardRepository interface {
 
 	// GetRewardsByCreatorID mendapatkan daftar hadiah yang dibuat oleh pengguna tertentu (orang tua) dengan paginasi.
 	// Mengembalikan slice hadiah, total jumlah hadiah, dan error jika ada.
-	GetRewardsByCreatorID(ctx context.Context, creatorID int, page, limit int) ([]models.Reward, int, error)
+	GetRewardsByCreatorID(ctx context.Context, creatorID int, filter *models.DefinitionFilter, page, limit int) ([]models.Reward, int, error)
+
+	// CreateRewardTx membuat definisi hadiah dalam konteks transaksi (misal: impor template secara massal),
+	// termasuk tautan ke template sumbernya. Mengembalikan ID hadiah baru atau error.
+	CreateRewardTx(ctx context.Context, tx pgx.Tx, reward *models.Reward) (int, error)
 
 	// GetAvailableRewardsForChild mendapatkan daftar hadiah yang tersedia untuk anak tertentu (dari orang tuanya) dengan paginasi.
+	// Setiap hadiah menyertakan Availability (sisa stok, sisa kuota, cooldown) untuk anak tersebut.
 	// Mengembalikan slice hadiah, total jumlah hadiah, dan error jika ada.
 	GetAvailableRewardsForChild(ctx context.Context, childID int, page, limit int) ([]models.Reward, int, error)
 
@@ -263,6 +295,16 @@ type RewardRepository interface {
 	// GetRewardDetailsTx mendapatkan detail hadiah yang diperlukan untuk proses klaim dalam konteks transaksi.
 	// Mengembalikan detail hadiah atau error.
 	GetRewardDetailsTx(ctx context.Context, tx pgx.Tx, rewardID int) (*RewardDetails, error)
+
+	// GetRewardUsageTx menghitung klaim aktif (pending/approved) anak untuk hadiah ini dalam periode kuota berjalan
+	// beserta waktu klaim terakhirnya, dalam konteks transaksi.
+	GetRewardUsageTx(ctx context.Context, tx pgx.Tx, childID int, rewardID int) (*models.RewardUsage, error)
+
+	// DecrementStockTx mengurangi stok hadiah sebanyak satu. Mengembalikan error jika stok sudah habis.
+	DecrementStockTx(ctx context.Context, tx pgx.Tx, rewardID int) error
+
+	// RestoreStockTx mengembalikan satu unit stok hadiah (misal: klaim ditolak).
+	RestoreStockTx(ctx context.Context, tx pgx.Tx, rewardID int) error
 }
 
 // ====================================================================================
@@ -275,6 +317,9 @@ type ClaimReviewDetails struct {
 	CurrentStatus   models.UserRewardStatus // Status klaim saat ini sebelum direview
 	PointsDeducted  int                   // Jumlah poin yang dikurangkan saat klaim dibuat
 	RewardCreatorID int                   // ID Orang Tua yang membuat reward (untuk validasi reviewer)
+	RewardID        int                   // ID Reward yang diklaim (untuk mengembalikan stok)
+	ReceivedAt      *time.Time            // Waktu anak mengonfirmasi penerimaan (nil jika belum)
+	StockReserved   bool                  // true jika klaim mengurangi stok saat dibuat (stok dikembalikan saat ditolak)
 }
 
 // UserRewardRepository: Kontrak untuk operasi data terkait Klaim Hadiah oleh Pengguna (UserReward).
@@ -302,8 +347,8 @@ type UserRewardRepository interface {
 	// --- Metode Transaksional ---
 
 	// CreateClaimTx membuat catatan klaim hadiah baru dalam konteks transaksi.
-	// Mengembalikan ID UserReward baru atau error.
-	CreateClaimTx(ctx context.Context, tx pgx.Tx, userID, rewardID, pointsDeducted int) (int, error)
+	// stockReserved menandai bahwa klaim mengurangi stok hadiah. Mengembalikan ID UserReward baru atau error.
+	CreateClaimTx(ctx context.Context, tx pgx.Tx, userID, rewardID, pointsDeducted int, stockReserved bool) (int, error)
 
 	// UpdateClaimStatusTx memperbarui status klaim hadiah dalam konteks transaksi.
 	// Mengembalikan error jika terjadi kesalahan.
@@ -312,6 +357,22 @@ type UserRewardRepository interface {
 	// GetClaimDetailsForReviewTx mendapatkan detail klaim yang diperlukan untuk proses review dalam konteks transaksi.
 	// Mengembalikan detail klaim atau error.
 	GetClaimDetailsForReviewTx(ctx context.Context, tx pgx.Tx, claimID int) (*ClaimReviewDetails, error)
+
+	// --- Penyerahan Hadiah & Riwayat ---
+
+	// ApplyClaimTransitionTx menerapkan transisi status klaim (schedule/fulfill/confirm_receipt) dan mencatat event-nya.
+	// Update hanya berhasil jika status klaim masih sama dengan event.FromStatus.
+	ApplyClaimTransitionTx(ctx context.Context, tx pgx.Tx, event *models.UserRewardEvent) error
+
+	// CreateClaimEventTx mencatat satu event riwayat klaim tanpa mengubah status (misal: 'claim', 'approve', 'reject').
+	CreateClaimEventTx(ctx context.Context, tx pgx.Tx, event *models.UserRewardEvent) error
+
+	// GetClaimEvents mengambil riwayat transisi sebuah klaim (urut kronologis).
+	GetClaimEvents(ctx context.Context, claimID int) ([]models.UserRewardEvent, error)
+
+	// GetUnfulfilledClaimsByParentID mendapatkan klaim 'approved'/'scheduled' (belum diserahkan) dari anak-anak
+	// orang tua tertentu, diurutkan dari yang paling lama menunggu, dengan paginasi.
+	GetUnfulfilledClaimsByParentID(ctx context.Context, parentID int, page, limit int) ([]models.UserReward, int, error)
 }
 
 // ====================================================================================
@@ -334,13 +395,28 @@ type PointTransactionRepository interface {
 
 	// --- Metode Transaksional ---
 
-	// CreateTransactionTx mencatat transaksi poin baru dalam konteks transaksi.
+	// CreateTransactionTx mencatat transaksi poin baru dalam konteks transaksi dan mengisi txData.ID.
 	// Mengembalikan error jika terjadi kesalahan.
 	CreateTransactionTx(ctx context.Context, tx pgx.Tx, txData *models.PointTransaction) error
 
-	// CalculateTotalPointsByUserIDTx menghitung total poin terkini dalam konteks transaksi.
-	// Mengembalikan total poin atau error jika terjadi kesalahan.
+	// CalculateTotalPointsByUserIDTx membaca saldo poin terkini dalam konteks transaksi dan mengunci
+	// baris saldo anak sampai transaksi selesai. Mengembalikan total poin atau error jika terjadi kesalahan.
 	CalculateTotalPointsByUserIDTx(ctx context.Context, tx pgx.Tx, userID int) (int, error)
+
+	// CalculateBalanceTx membaca saldo satu mata uang (0 = poin) dalam konteks transaksi dan mengunci
+	// baris saldonya sampai transaksi selesai.
+	CalculateBalanceTx(ctx context.Context, tx pgx.Tx, userID int, currencyID int) (int, error)
+
+	// GetUnreversedTransactionTx mengambil transaksi terbaru berjenis txType untuk UserTask/UserReward
+	// tertentu (0 = abaikan filter) yang belum pernah dibalik, terkunci dalam transaksi.
+	// Mengembalikan pgx.ErrNoRows jika tidak ada.
+	GetUnreversedTransactionTx(ctx context.Context, tx pgx.Tx, txType models.TransactionType, userTaskID, userRewardID int) (*models.PointTransaction, error)
+
+	// --- Rekonsiliasi ---
+
+	// ReconcileBalances membandingkan point_balances dengan total ledger dan mengembalikan daftar selisih.
+	// Jika fix bernilai true, saldo tersimpan disamakan dengan ledger.
+	ReconcileBalances(ctx context.Context, fix bool) ([]models.PointBalanceDrift, error)
 }
 
 // ====================================================================================
@@ -367,3 +443,735 @@ type InvitationCodeRepository interface {
 	// Mengembalikan error jika kode tidak ditemukan atau gagal diperbarui.
 	MarkCodeAsUsedTx(ctx context.Context, tx pgx.Tx, code string) error
 }
+
+// ====================================================================================
+// Task Rotation Repository
+// ====================================================================================
+
+// TaskRotationRepository: Kontrak untuk operasi data terkait Rotasi Tugas antar saudara.
+type TaskRotationRepository interface {
+	// GetRotationByID mencari rotasi berdasarkan ID, termasuk anggota (terurut posisi) dan definisi tugasnya.
+	// Mengembalikan data rotasi atau pgx.ErrNoRows jika tidak ditemukan.
+	GetRotationByID(ctx context.Context, id int) (*models.TaskRotation, error)
+
+	// GetRotationsByCreatorID mendapatkan daftar rotasi yang dibuat oleh parent tertentu dengan paginasi.
+	// Mengembalikan slice rotasi (termasuk anggota), total jumlah, dan error jika ada.
+	GetRotationsByCreatorID(ctx context.Context, creatorID int, page, limit int) ([]models.TaskRotation, int, error)
+
+	// GetTurnsByRotationID mendapatkan riwayat giliran sebuah rotasi (terbaru dulu) dengan paginasi.
+	// Mengembalikan slice giliran, total jumlah, dan error jika ada.
+	GetTurnsByRotationID(ctx context.Context, rotationID int, page, limit int) ([]models.TaskRotationTurn, int, error)
+
+	// GetDueRotationIDs mendapatkan ID rotasi aktif yang jadwal gilirannya sudah tiba (next_rotation_at <= now).
+	// Mengembalikan slice ID atau error.
+	GetDueRotationIDs(ctx context.Context, now time.Time) ([]int, error)
+
+	// DeleteRotation menghapus rotasi beserta anggota dan riwayatnya.
+	// Mengembalikan pgx.ErrNoRows jika rotasi tidak ditemukan.
+	DeleteRotation(ctx context.Context, id int) error
+
+	// --- Metode Transaksional ---
+
+	// CreateRotationTx membuat rotasi baru beserta anggotanya (urutan sesuai childIDs) dalam konteks transaksi.
+	// Mengembalikan ID rotasi baru atau error.
+	CreateRotationTx(ctx context.Context, tx pgx.Tx, rotation *models.TaskRotation, childIDs []int) (int, error)
+
+	// GetRotationForUpdateTx mengambil rotasi beserta anggotanya dan mengunci baris rotasi (FOR UPDATE).
+	// Mengembalikan data rotasi atau pgx.ErrNoRows jika tidak ditemukan.
+	GetRotationForUpdateTx(ctx context.Context, tx pgx.Tx, id int) (*models.TaskRotation, error)
+
+	// UpdateRotationStateTx memperbarui posisi giliran berikutnya dan jadwal rotasi dalam konteks transaksi.
+	// Mengembalikan error jika terjadi kesalahan.
+	UpdateRotationStateTx(ctx context.Context, tx pgx.Tx, id int, currentPosition int, nextRotationAt time.Time) error
+
+	// SwapMemberPositionsTx menukar posisi dua anak dalam rotasi dalam konteks transaksi.
+	// Mengembalikan pgx.ErrNoRows jika salah satu anak bukan anggota rotasi.
+	SwapMemberPositionsTx(ctx context.Context, tx pgx.Tx, rotationID int, firstChildID int, secondChildID int) error
+
+	// CreateTurnTx mencatat satu giliran ke riwayat rotasi dalam konteks transaksi.
+	// Mengembalikan ID giliran baru atau error.
+	CreateTurnTx(ctx context.Context, tx pgx.Tx, turn *models.TaskRotationTurn) (int, error)
+}
+
+// ====================================================================================
+// Task Bounty Repository
+// ====================================================================================
+
+// TaskBountyRepository: Kontrak untuk operasi data terkait Bounty (tugas terbuka yang bisa diklaim anak).
+type TaskBountyRepository interface {
+	// CreateBounty menyimpan bounty baru dengan status 'open'.
+	// Mengembalikan ID bounty baru atau error.
+	CreateBounty(ctx context.Context, bounty *models.TaskBounty) (int, error)
+
+	// GetBountyByID mencari bounty berdasarkan ID (termasuk definisi tugasnya).
+	// Mengembalikan data bounty atau pgx.ErrNoRows jika tidak ditemukan.
+	GetBountyByID(ctx context.Context, id int) (*models.TaskBounty, error)
+
+	// GetBountiesByCreatorID mendapatkan daftar bounty yang diposting parent tertentu (termasuk klaimnya) dengan paginasi.
+	// Mengembalikan slice bounty, total jumlah, dan error jika ada.
+	GetBountiesByCreatorID(ctx context.Context, creatorID int, page, limit int) ([]models.TaskBounty, int, error)
+
+	// GetOpenBountiesForChild mendapatkan bounty yang masih bisa diklaim oleh anak: diposting oleh salah satu parent-nya,
+	// berstatus 'open', belum kedaluwarsa, dan belum pernah diklaim oleh anak tersebut.
+	// Mengembalikan slice bounty, total jumlah, dan error jika ada.
+	GetOpenBountiesForChild(ctx context.Context, childID int, page, limit int) ([]models.TaskBounty, int, error)
+
+	// CancelBounty mengubah status bounty 'open' menjadi 'cancelled'.
+	// Mengembalikan error jika bounty tidak ditemukan atau sudah tidak 'open'.
+	CancelBounty(ctx context.Context, id int) error
+
+	// --- Metode Transaksional ---
+
+	// GetBountyForUpdateTx mengambil bounty dan mengunci barisnya (FOR UPDATE) agar klaim bersamaan diproses berurutan.
+	// Mengembalikan data bounty atau pgx.ErrNoRows jika tidak ditemukan.
+	GetBountyForUpdateTx(ctx context.Context, tx pgx.Tx, id int) (*models.TaskBounty, error)
+
+	// HasChildClaimedTx memeriksa apakah anak sudah pernah mengklaim bounty ini.
+	HasChildClaimedTx(ctx context.Context, tx pgx.Tx, bountyID int, childID int) (bool, error)
+
+	// CreateClaimTx mencatat klaim anak atas bounty beserta penugasan yang dihasilkan.
+	// Mengembalikan ID klaim baru atau error.
+	CreateClaimTx(ctx context.Context, tx pgx.Tx, bountyID int, childID int, userTaskID int) (int, error)
+
+	// IncrementClaimsTx menambah jumlah klaim dan menandai bounty 'filled' jika batas klaim tercapai.
+	// Mengembalikan error jika terjadi kesalahan.
+	IncrementClaimsTx(ctx context.Context, tx pgx.Tx, id int) error
+}
+
+// ====================================================================================
+// Definition Template Repository
+// ====================================================================================
+
+// TemplateRepository: Kontrak untuk operasi data katalog template tugas/hadiah (dikelola Admin).
+type TemplateRepository interface {
+	// GetTemplateByID mencari template berdasarkan ID beserta semua terjemahannya.
+	// Name/Description diisi sesuai locale (dengan fallback). Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+	GetTemplateByID(ctx context.Context, id int, locale string) (*models.DefinitionTemplate, error)
+
+	// ListTemplates mendapatkan daftar template dengan filter & paginasi.
+	// includeInactive=false hanya mengembalikan template aktif (tampilan Parent).
+	ListTemplates(ctx context.Context, filter *models.TemplateFilter, includeInactive bool, page, limit int) ([]models.DefinitionTemplate, int, error)
+
+	// DeleteTemplate menghapus template. Definisi hasil impor tetap ada, tautannya menjadi NULL.
+	DeleteTemplate(ctx context.Context, id int) error
+
+	// GetUpdateSuggestions mendapatkan definisi milik parent yang template sumbernya sudah memiliki versi lebih baru.
+	GetUpdateSuggestions(ctx context.Context, parentID int, locale string) ([]models.TemplateUpdateSuggestion, error)
+
+	// --- Metode Transaksional ---
+
+	// CreateTemplateTx membuat template beserta terjemahannya. Mengembalikan ID template baru atau error.
+	CreateTemplateTx(ctx context.Context, tx pgx.Tx, template *models.DefinitionTemplate) (int, error)
+
+	// UpdateTemplateTx memperbarui template, mengganti semua terjemahannya, dan menaikkan versinya.
+	// Mengembalikan pgx.ErrNoRows jika template tidak ditemukan.
+	UpdateTemplateTx(ctx context.Context, tx pgx.Tx, template *models.DefinitionTemplate) error
+
+	// GetActiveTemplatesByIDsTx mengambil template aktif berdasarkan daftar ID (Name/Description sesuai locale).
+	GetActiveTemplatesByIDsTx(ctx context.Context, tx pgx.Tx, ids []int, locale string) ([]models.DefinitionTemplate, error)
+}
+
+// ====================================================================================
+// Auto-Approval Policy Repository
+// ====================================================================================
+
+// AutoApprovalPolicyRepository: Kontrak untuk operasi data kebijakan auto-approval submission tugas.
+type AutoApprovalPolicyRepository interface {
+	// CreatePolicy membuat kebijakan baru. Mengembalikan ID kebijakan baru atau error
+	// (misal: kebijakan dengan cakupan yang sama sudah ada).
+	CreatePolicy(ctx context.Context, policy *models.AutoApprovalPolicy) (int, error)
+
+	// GetPolicyByID mencari kebijakan berdasarkan ID. Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+	GetPolicyByID(ctx context.Context, id int) (*models.AutoApprovalPolicy, error)
+
+	// GetPoliciesByCreatorID mendapatkan semua kebijakan milik parent.
+	GetPoliciesByCreatorID(ctx context.Context, creatorID int) ([]models.AutoApprovalPolicy, error)
+
+	// UpdatePolicy memperbarui masa tenggang dan status aktif kebijakan.
+	UpdatePolicy(ctx context.Context, policy *models.AutoApprovalPolicy) error
+
+	// DeletePolicy menghapus kebijakan. Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+	DeletePolicy(ctx context.Context, id int) error
+
+	// GetDueUserTaskIDs mendapatkan ID UserTask berstatus 'submitted' yang memiliki kebijakan aktif
+	// dan masa tenggangnya sudah lewat pada waktu `now`. Dibatasi `limit` baris.
+	GetDueUserTaskIDs(ctx context.Context, now time.Time, limit int) ([]int, error)
+
+	// --- Metode Transaksional ---
+
+	// GetDuePolicyForUserTaskTx mencari kebijakan paling spesifik yang berlaku untuk UserTask dan sudah jatuh tempo
+	// pada waktu `now`. Mengembalikan pgx.ErrNoRows jika tidak ada.
+	GetDuePolicyForUserTaskTx(ctx context.Context, tx pgx.Tx, userTaskID int, now time.Time) (*models.AutoApprovalPolicy, error)
+}
+
+// ====================================================================================
+// Audit Log Repository
+// ====================================================================================
+
+// AuditLogRepository: Kontrak untuk operasi data jejak audit.
+type AuditLogRepository interface {
+	// CreateLog mencatat entri audit baru.
+	CreateLog(ctx context.Context, entry *models.AuditLog) error
+
+	// GetLogs mendapatkan entri audit (terbaru lebih dulu) dengan filter & paginasi.
+	GetLogs(ctx context.Context, filter *models.AuditLogFilter, page, limit int) ([]models.AuditLog, int, error)
+
+	// --- Metode Transaksional ---
+
+	// CreateLogTx mencatat entri audit dalam konteks transaksi sehingga ikut di-rollback bila operasi gagal.
+	CreateLogTx(ctx context.Context, tx pgx.Tx, entry *models.AuditLog) error
+}
+
+// ====================================================================================
+// Savings Goal Repository
+// ====================================================================================
+
+// SavingsGoalRepository: Kontrak untuk operasi data target tabungan (savings goal) anak.
+type SavingsGoalRepository interface {
+	// CreateGoal menyimpan target tabungan baru dan mengembalikan ID-nya.
+	CreateGoal(ctx context.Context, goal *models.SavingsGoal) (int, error)
+
+	// GetGoalByID mendapatkan detail target tabungan berdasarkan ID.
+	GetGoalByID(ctx context.Context, id int) (*models.SavingsGoal, error)
+
+	// GetGoalsByChildID mendapatkan target tabungan anak; target yang sudah ditutup hanya jika includeClosed.
+	GetGoalsByChildID(ctx context.Context, childID int, includeClosed bool) ([]models.SavingsGoal, error)
+
+	// GetChildIDsWithReachableGoals mendapatkan ID anak (> afterChildID, terurut) yang memiliki target 'active'
+	// yang sudah tercapai (keyset pagination untuk worker).
+	GetChildIDsWithReachableGoals(ctx context.Context, afterChildID int, limit int) ([]int, error)
+
+	// --- Metode Transaksional ---
+
+	// GetGoalForUpdateTx mendapatkan target tabungan dan mengunci barisnya (FOR UPDATE).
+	GetGoalForUpdateTx(ctx context.Context, tx pgx.Tx, id int) (*models.SavingsGoal, error)
+
+	// GetOpenGoalsByChildIDForUpdateTx mendapatkan dan mengunci semua target terbuka milik anak.
+	GetOpenGoalsByChildIDForUpdateTx(ctx context.Context, tx pgx.Tx, childID int) ([]models.SavingsGoal, error)
+
+	// SumEarmarkedPointsTx menjumlahkan poin yang disisihkan pada target terbuka anak,
+	// kecuali target yang menyematkan excludeRewardID (0 = hitung semua).
+	SumEarmarkedPointsTx(ctx context.Context, tx pgx.Tx, childID int, excludeRewardID int) (int, error)
+
+	// AdjustEarmarkTx menambah atau melepas poin yang disisihkan pada target terbuka.
+	AdjustEarmarkTx(ctx context.Context, tx pgx.Tx, id int, delta int) error
+
+	// MarkReachedTx mengubah target 'active' menjadi 'reached'. Mengembalikan false jika status sudah berubah.
+	MarkReachedTx(ctx context.Context, tx pgx.Tx, id int) (bool, error)
+
+	// CancelGoalTx membatalkan target terbuka.
+	CancelGoalTx(ctx context.Context, tx pgx.Tx, id int) error
+
+	// MarkClaimedByRewardTx menutup target terbuka anak yang menyematkan rewardID dengan klaim yang baru dibuat.
+	MarkClaimedByRewardTx(ctx context.Context, tx pgx.Tx, childID int, rewardID int, userRewardID int) (int64, error)
+
+	// CreateContributionTx mencatat bonus poin dari parent untuk sebuah target.
+	CreateContributionTx(ctx context.Context, tx pgx.Tx, contribution *models.SavingsGoalContribution) error
+}
+
+// ====================================================================================
+// Notification Repository
+// ====================================================================================
+
+// NotificationRepository: Kontrak untuk operasi data notifikasi in-app.
+type NotificationRepository interface {
+	// CreateNotification menyimpan notifikasi baru.
+	CreateNotification(ctx context.Context, notification *models.Notification) error
+
+	// GetNotificationsByUserID mendapatkan notifikasi pengguna dengan paginasi (opsional hanya yang belum dibaca).
+	GetNotificationsByUserID(ctx context.Context, userID int, unreadOnly bool, page, limit int) ([]models.Notification, int, error)
+
+	// MarkAsRead menandai notifikasi milik pengguna sebagai sudah dibaca. Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+	MarkAsRead(ctx context.Context, id int64, userID int) error
+
+	// MarkAllAsRead menandai semua notifikasi pengguna sebagai sudah dibaca.
+	MarkAllAsRead(ctx context.Context, userID int) (int64, error)
+
+	// HasNotification memeriksa apakah pengguna sudah pernah menerima notifikasi berjenis tertentu
+	// untuk entitas tertentu (dipakai worker agar peringatan tidak dikirim berulang).
+	HasNotification(ctx context.Context, userID int, notificationType models.NotificationType, entityType string, entityID int) (bool, error)
+
+	// --- Metode Transaksional ---
+
+	// CreateNotificationTx menyimpan notifikasi baru dalam konteks transaksi.
+	CreateNotificationTx(ctx context.Context, tx pgx.Tx, notification *models.Notification) error
+}
+
+// ====================================================================================
+// Reward Approval Repository
+// ====================================================================================
+
+// RewardApprovalRepository: Kontrak untuk kebijakan persetujuan bersama klaim hadiah dan suara orang tua.
+type RewardApprovalRepository interface {
+	// UpsertPolicy membuat atau memperbarui kebijakan milik policy.CreatedByUserID untuk policy.ChildID
+	// (0 = semua anak parent tersebut).
+	UpsertPolicy(ctx context.Context, policy *models.RewardApprovalPolicy) error
+
+	// GetPolicyByOwner mendapatkan kebijakan milik parent untuk cakupan anak (0 = semua anak).
+	// Mengembalikan pgx.ErrNoRows jika belum ada.
+	GetPolicyByOwner(ctx context.Context, parentID int, childID int) (*models.RewardApprovalPolicy, error)
+
+	// GetPolicyForChild mendapatkan kebijakan yang berlaku untuk anak: kebijakan khusus anak dari salah satu
+	// orang tuanya, lalu kebijakan untuk semua anak (terlama lebih dulu). Mengembalikan pgx.ErrNoRows jika tidak ada.
+	GetPolicyForChild(ctx context.Context, childID int) (*models.RewardApprovalPolicy, error)
+
+	// DeletePolicy menghapus kebijakan milik parent untuk cakupan anak (0 = semua anak).
+	// Mengembalikan pgx.ErrNoRows jika belum ada.
+	DeletePolicy(ctx context.Context, parentID int, childID int) error
+
+	// GetVotesByClaimID mendapatkan suara orang tua untuk sebuah klaim (terlama lebih dulu).
+	GetVotesByClaimID(ctx context.Context, claimID int) ([]models.UserRewardVote, error)
+
+	// --- Metode Transaksional ---
+
+	// GetPolicyForChildTx sama seperti GetPolicyForChild dalam transaksi.
+	GetPolicyForChildTx(ctx context.Context, tx pgx.Tx, childID int) (*models.RewardApprovalPolicy, error)
+
+	// CreateVoteTx mencatat suara orang tua. Orang tua yang sama hanya bisa memberi satu suara per klaim.
+	CreateVoteTx(ctx context.Context, tx pgx.Tx, claimID int, parentID int, vote models.UserRewardStatus) error
+
+	// CountApprovalsTx menghitung jumlah orang tua berbeda yang sudah menyetujui klaim.
+	CountApprovalsTx(ctx context.Context, tx pgx.Tx, claimID int) (int, error)
+}
+
+// ====================================================================================
+// Point Expiration Repository
+// ====================================================================================
+
+// PointExpirationRepository: Kontrak untuk kebijakan kedaluwarsa poin dan perhitungan lot poin (FIFO).
+type PointExpirationRepository interface {
+	// UpsertPolicy membuat atau memperbarui kebijakan kedaluwarsa poin milik parent untuk cakupan anak (0 = semua anak).
+	UpsertPolicy(ctx context.Context, policy *models.PointExpirationPolicy) error
+
+	// GetPolicyByOwner mendapatkan kebijakan milik parent untuk cakupan anak (0 = semua anak).
+	// Mengembalikan pgx.ErrNoRows jika belum ada.
+	GetPolicyByOwner(ctx context.Context, parentID int, childID int) (*models.PointExpirationPolicy, error)
+
+	// GetPolicyForChild mendapatkan kebijakan yang berlaku untuk anak: kebijakan khusus anak dari salah satu
+	// orang tuanya, lalu kebijakan untuk semua anak (terlama lebih dulu). Mengembalikan pgx.ErrNoRows jika tidak ada.
+	GetPolicyForChild(ctx context.Context, childID int) (*models.PointExpirationPolicy, error)
+
+	// DeletePolicy menghapus kebijakan milik parent untuk cakupan anak (0 = semua anak).
+	// Mengembalikan pgx.ErrNoRows jika belum ada.
+	DeletePolicy(ctx context.Context, parentID int, childID int) error
+
+	// GetPoliciesAfter mengambil kebijakan yang berlaku untuk setiap anak dengan child_id > afterChildID,
+	// terurut per anak (keyset pagination untuk worker). ChildID kebijakan adalah anak yang dituju.
+	GetPoliciesAfter(ctx context.Context, afterChildID int, limit int) ([]models.PointExpirationPolicy, error)
+
+	// GetOpenLots mengambil lot poin anak yang masih tersisa setelah pemakaian dihitung FIFO (tertua lebih dulu).
+	GetOpenLots(ctx context.Context, childID int) ([]models.PointLot, error)
+
+	// --- Metode Transaksional ---
+
+	// GetOpenLotsTx sama seperti GetOpenLots dalam konteks transaksi.
+	GetOpenLotsTx(ctx context.Context, tx pgx.Tx, childID int) ([]models.PointLot, error)
+}
+
+// ====================================================================================
+// Interest Repository
+// ====================================================================================
+
+// InterestRepository: Kontrak untuk kebijakan bunga poin dan catatan posting bunga per periode.
+type InterestRepository interface {
+	// UpsertPolicy membuat atau memperbarui kebijakan bunga milik parent untuk cakupan anak (0 = semua anak).
+	UpsertPolicy(ctx context.Context, policy *models.InterestPolicy) error
+
+	// GetPolicyByOwner mendapatkan kebijakan milik parent untuk cakupan anak (0 = semua anak).
+	// Mengembalikan pgx.ErrNoRows jika belum ada.
+	GetPolicyByOwner(ctx context.Context, parentID int, childID int) (*models.InterestPolicy, error)
+
+	// GetPolicyForChild mendapatkan kebijakan yang berlaku untuk anak: kebijakan khusus anak dari salah satu
+	// orang tuanya, lalu kebijakan untuk semua anak (terlama lebih dulu). Mengembalikan pgx.ErrNoRows jika tidak ada.
+	GetPolicyForChild(ctx context.Context, childID int) (*models.InterestPolicy, error)
+
+	// DeletePolicy menghapus kebijakan milik parent untuk cakupan anak (0 = semua anak).
+	// Mengembalikan pgx.ErrNoRows jika belum ada.
+	DeletePolicy(ctx context.Context, parentID int, childID int) error
+
+	// GetPoliciesAfter mengambil kebijakan yang berlaku untuk setiap anak dengan child_id > afterChildID,
+	// terurut per anak (keyset pagination untuk worker). ChildID kebijakan adalah anak yang dituju.
+	GetPoliciesAfter(ctx context.Context, afterChildID int, limit int) ([]models.InterestPolicy, error)
+
+	// --- Metode Transaksional ---
+
+	// ClaimPeriodTx mencatat posting bunga untuk satu periode. Mengembalikan false jika periode
+	// tersebut sudah pernah diposting sehingga pemanggil tidak membuat transaksi ganda.
+	ClaimPeriodTx(ctx context.Context, tx pgx.Tx, childID int, periodStart time.Time, balance int, points int) (bool, error)
+
+	// SetPostingTransactionTx mengaitkan posting bunga periode dengan transaksi poin 'interest'.
+	SetPostingTransactionTx(ctx context.Context, tx pgx.Tx, childID int, periodStart time.Time, pointTransactionID int) error
+}
+
+// ====================================================================================
+// Allowance Repository
+// ====================================================================================
+
+// AllowanceRepository mendefinisikan operasi untuk rencana uang saku otomatis dan riwayat pembayarannya.
+type AllowanceRepository interface {
+	// UpsertPlan membuat atau memperbarui rencana uang saku anak (satu rencana per anak).
+	UpsertPlan(ctx context.Context, plan *models.AllowancePlan) error
+
+	// GetPlanByChildID mendapatkan rencana anak beserta zona waktunya. Mengembalikan pgx.ErrNoRows jika belum ada.
+	GetPlanByChildID(ctx context.Context, childID int) (*models.AllowancePlan, error)
+
+	// DeletePlan menghapus rencana anak. Mengembalikan pgx.ErrNoRows jika belum ada.
+	DeletePlan(ctx context.Context, childID int) error
+
+	// PausePlan menjeda rencana. Mengembalikan pgx.ErrNoRows jika rencana tidak ada atau sudah dijeda.
+	PausePlan(ctx context.Context, childID int) error
+
+	// ResumePlan melanjutkan rencana mulai dari periode nextPeriodStart.
+	// Mengembalikan pgx.ErrNoRows jika rencana tidak ada atau tidak sedang dijeda.
+	ResumePlan(ctx context.Context, childID int, nextPeriodStart time.Time) error
+
+	// GetActivePlansAfter mengambil rencana yang tidak dijeda dengan id > afterID, terurut (keyset pagination untuk worker).
+	GetActivePlansAfter(ctx context.Context, afterID int, limit int) ([]models.AllowancePlan, error)
+
+	// GetPayoutsByChildID mengambil riwayat pembayaran uang saku anak dengan paginasi.
+	GetPayoutsByChildID(ctx context.Context, childID int, page, limit int) ([]models.AllowancePayout, int, error)
+
+	// --- Metode Transaksional ---
+
+	// GetPlanForUpdateTx mengambil rencana berdasarkan ID dan menguncinya hingga transaksi selesai.
+	GetPlanForUpdateTx(ctx context.Context, tx pgx.Tx, planID int) (*models.AllowancePlan, error)
+
+	// CountApprovedTasksTx menghitung tugas anak yang disetujui dalam rentang [from, to).
+	CountApprovedTasksTx(ctx context.Context, tx pgx.Tx, childID int, from time.Time, to time.Time) (int, error)
+
+	// CreatePayoutTx mencatat hasil pemrosesan satu periode. Mengembalikan false jika periode
+	// tersebut sudah pernah diproses sehingga pemanggil tidak membayar dua kali.
+	CreatePayoutTx(ctx context.Context, tx pgx.Tx, payout *models.AllowancePayout) (bool, error)
+
+	// SetPayoutTransactionTx mengaitkan pembayaran dengan transaksi poin 'allowance'.
+	SetPayoutTransactionTx(ctx context.Context, tx pgx.Tx, payoutID int, pointTransactionID int) error
+
+	// AdvancePlanTx memajukan periode berikutnya yang belum diproses.
+	AdvancePlanTx(ctx context.Context, tx pgx.Tx, planID int, nextPeriodStart time.Time) error
+}
+
+// ====================================================================================
+// Point Transfer Repository
+// ====================================================================================
+
+// PointTransferRepository mendefinisikan operasi untuk transfer poin antar saudara dan kebijakannya.
+type PointTransferRepository interface {
+	// UpsertPolicy membuat atau memperbarui kebijakan transfer milik parent untuk cakupan anak (0 = semua anak).
+	UpsertPolicy(ctx context.Context, policy *models.TransferPolicy) error
+
+	// GetPolicyByOwner mendapatkan kebijakan transfer milik parent untuk cakupan anak (0 = semua anak).
+	// Mengembalikan pgx.ErrNoRows jika belum ada.
+	GetPolicyByOwner(ctx context.Context, parentID int, childID int) (*models.TransferPolicy, error)
+
+	// GetPolicyForChild mendapatkan kebijakan transfer yang berlaku untuk anak pengirim: kebijakan khusus anak dari
+	// salah satu orang tuanya, lalu kebijakan untuk semua anak (terlama lebih dulu). Mengembalikan pgx.ErrNoRows jika tidak ada.
+	GetPolicyForChild(ctx context.Context, childID int) (*models.TransferPolicy, error)
+
+	// DeletePolicy menghapus kebijakan transfer milik parent untuk cakupan anak (0 = semua anak).
+	// Mengembalikan pgx.ErrNoRows jika belum ada.
+	DeletePolicy(ctx context.Context, parentID int, childID int) error
+
+	// AreSiblings memeriksa apakah dua anak memiliki setidaknya satu orang tua yang sama.
+	AreSiblings(ctx context.Context, childID1 int, childID2 int) (bool, error)
+
+	// GetTransferByID mendapatkan transfer berdasarkan ID. Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+	GetTransferByID(ctx context.Context, transferID int) (*models.PointTransfer, error)
+
+	// GetTransfersByChildID mengambil transfer yang dikirim atau diterima anak dengan paginasi.
+	GetTransfersByChildID(ctx context.Context, childID int, page, limit int) ([]models.PointTransfer, int, error)
+
+	// GetTransfersByParentID mengambil transfer yang dikirim anak-anak parent (status kosong = semua) dengan paginasi.
+	GetTransfersByParentID(ctx context.Context, parentID int, status models.PointTransferStatus, page, limit int) ([]models.PointTransfer, int, error)
+
+	// UpdateTransferStatus menolak/membatalkan transfer 'pending' tanpa memindahkan poin.
+	// Mengembalikan pgx.ErrNoRows jika transfer tidak ada atau sudah tidak 'pending'.
+	UpdateTransferStatus(ctx context.Context, transferID int, status models.PointTransferStatus, reviewerID int, reviewNote string) error
+
+	// --- Metode Transaksional ---
+
+	// CreateTransferTx menyimpan transfer baru (status sesuai transfer.Status).
+	CreateTransferTx(ctx context.Context, tx pgx.Tx, transfer *models.PointTransfer) error
+
+	// GetTransferForUpdateTx mengambil transfer dan menguncinya hingga transaksi selesai.
+	GetTransferForUpdateTx(ctx context.Context, tx pgx.Tx, transferID int) (*models.PointTransfer, error)
+
+	// CompleteTransferTx menandai transfer selesai dan mengaitkannya dengan entri debit & kredit.
+	CompleteTransferTx(ctx context.Context, tx pgx.Tx, transfer *models.PointTransfer, reviewerID int) error
+}
+
+// ====================================================================================
+// Currency Repository
+// ====================================================================================
+
+// CurrencyRepository mendefinisikan operasi untuk mata uang tambahan, saldonya, dan aturan tukar.
+type CurrencyRepository interface {
+	// CreateCurrency membuat mata uang baru milik parent dan mengisi ID serta timestamp.
+	CreateCurrency(ctx context.Context, currency *models.Currency) error
+
+	// GetCurrenciesByOwnerID mengambil semua mata uang milik parent.
+	GetCurrenciesByOwnerID(ctx context.Context, parentID int) ([]models.Currency, error)
+
+	// GetCurrencyByID mengambil mata uang berdasarkan ID. Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+	GetCurrencyByID(ctx context.Context, currencyID int) (*models.Currency, error)
+
+	// UpdateCurrency memperbarui nama dan simbol mata uang milik currency.CreatedByUserID.
+	// Mengembalikan pgx.ErrNoRows jika tidak ditemukan atau bukan miliknya.
+	UpdateCurrency(ctx context.Context, currency *models.Currency) error
+
+	// DeleteCurrency menghapus mata uang yang belum dipakai. Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+	DeleteCurrency(ctx context.Context, currencyID int, parentID int) error
+
+	// GetBalancesByUserID mengambil saldo anak untuk poin dan setiap mata uang yang berlaku baginya.
+	GetBalancesByUserID(ctx context.Context, childID int) ([]models.CurrencyBalance, error)
+
+	// CreateExchangeRule membuat aturan tukar milik parent dan mengisi ID serta timestamp.
+	CreateExchangeRule(ctx context.Context, rule *models.CurrencyExchangeRule) error
+
+	// GetExchangeRulesByOwnerID mengambil semua aturan tukar milik parent.
+	GetExchangeRulesByOwnerID(ctx context.Context, parentID int) ([]models.CurrencyExchangeRule, error)
+
+	// GetExchangeRulesForChild mengambil aturan tukar milik semua orang tua anak.
+	GetExchangeRulesForChild(ctx context.Context, childID int) ([]models.CurrencyExchangeRule, error)
+
+	// GetExchangeRuleByID mengambil aturan tukar berdasarkan ID. Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+	GetExchangeRuleByID(ctx context.Context, ruleID int) (*models.CurrencyExchangeRule, error)
+
+	// DeleteExchangeRule menghapus aturan tukar milik parent. Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+	DeleteExchangeRule(ctx context.Context, ruleID int, parentID int) error
+}
+
+// ====================================================================================
+// Cash-Out Repository
+// ====================================================================================
+
+// CashOutRepository mendefinisikan operasi untuk kurs pencairan poin, permintaan cash-out, dan ledger uang.
+type CashOutRepository interface {
+	// UpsertPolicy membuat atau memperbarui kurs pencairan poin milik parent untuk cakupan anak (0 = semua anak).
+	UpsertPolicy(ctx context.Context, policy *models.CashOutPolicy) error
+
+	// GetPolicyByOwner mendapatkan kurs pencairan milik parent untuk cakupan anak (0 = semua anak).
+	// Mengembalikan pgx.ErrNoRows jika belum ada.
+	GetPolicyByOwner(ctx context.Context, parentID int, childID int) (*models.CashOutPolicy, error)
+
+	// GetPolicyForChild mendapatkan kurs pencairan yang berlaku untuk anak: kebijakan khusus anak dari salah satu
+	// orang tuanya, lalu kebijakan untuk semua anak (terlama lebih dulu). Mengembalikan pgx.ErrNoRows jika tidak ada.
+	GetPolicyForChild(ctx context.Context, childID int) (*models.CashOutPolicy, error)
+
+	// DeletePolicy menghapus kurs pencairan milik parent untuk cakupan anak (0 = semua anak).
+	// Mengembalikan pgx.ErrNoRows jika belum ada.
+	DeletePolicy(ctx context.Context, parentID int, childID int) error
+
+	// GetRequestsByChildID mengambil permintaan cash-out anak (status kosong = semua) dengan paginasi.
+	GetRequestsByChildID(ctx context.Context, childID int, status models.CashOutStatus, page, limit int) ([]models.CashOutRequest, int, error)
+
+	// GetRequestsByParentID mengambil permintaan cash-out anak-anak parent (status kosong = semua) dengan paginasi.
+	GetRequestsByParentID(ctx context.Context, parentID int, status models.CashOutStatus, page, limit int) ([]models.CashOutRequest, int, error)
+
+	// GetMoneyTransactionsByChildID mengambil entri ledger uang anak dengan paid_at dalam rentang [from, to).
+	GetMoneyTransactionsByChildID(ctx context.Context, childID int, from, to time.Time) ([]models.MoneyTransaction, error)
+
+	// --- Metode Transaksional ---
+
+	// CreateRequestTx menyimpan permintaan cash-out baru dan mengisi ID serta timestamp.
+	CreateRequestTx(ctx context.Context, tx pgx.Tx, request *models.CashOutRequest) error
+
+	// GetRequestForUpdateTx mengambil permintaan cash-out dan menguncinya hingga transaksi selesai.
+	// Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+	GetRequestForUpdateTx(ctx context.Context, tx pgx.Tx, requestID int) (*models.CashOutRequest, error)
+
+	// UpdateRequestReviewTx menyimpan hasil review (status, reviewer, catatan, dan entri refund jika ditolak).
+	UpdateRequestReviewTx(ctx context.Context, tx pgx.Tx, request *models.CashOutRequest) error
+
+	// CreateMoneyTransactionTx mencatat pembayaran ke ledger uang dan mengisi ID serta timestamp.
+	CreateMoneyTransactionTx(ctx context.Context, tx pgx.Tx, payout *models.MoneyTransaction) error
+}
+
+// ====================================================================================
+// Statement Repository
+// ====================================================================================
+
+// StatementRepository mendefinisikan operasi baca ledger poin untuk laporan rekening per periode.
+type StatementRepository interface {
+	// GetBalanceBefore menghitung saldo anak untuk currencyID (0 = poin) dari semua transaksi sebelum waktu before.
+	GetBalanceBefore(ctx context.Context, childID int, currencyID int, before time.Time) (int, error)
+
+	// GetStatementLines mengambil transaksi anak untuk currencyID dalam rentang [from, to) (terlama dulu),
+	// beserta nama tugas dan hadiah terkait. Kolom Balance belum diisi.
+	GetStatementLines(ctx context.Context, childID int, currencyID int, from, to time.Time) ([]models.PointStatementLine, error)
+}
+
+// ====================================================================================
+// Ledger Repository
+// ====================================================================================
+
+// LedgerRepository mendefinisikan operasi integritas ledger poin (rantai hash per anak dan koreksi).
+type LedgerRepository interface {
+	// VerifyChain memverifikasi rantai hash ledger untuk userID (0 = semua anak): urutan seq,
+	// sambungan prev_hash, hash setiap entri, dan kecocokan dengan ujung rantai tersimpan.
+	VerifyChain(ctx context.Context, userID int) (*models.LedgerVerificationReport, error)
+
+	// --- Metode Transaksional ---
+
+	// GetTransactionTx mengambil satu entri ledger beserta status apakah sudah dibalik oleh entri lain.
+	// Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+	GetTransactionTx(ctx context.Context, tx pgx.Tx, transactionID int) (*models.PointTransaction, bool, error)
+}
+
+// ====================================================================================
+// Penalty Repository
+// ====================================================================================
+
+// PenaltyRepository mendefinisikan operasi katalog pelanggaran milik parent dan sanksi yang diterapkan ke anak.
+type PenaltyRepository interface {
+	// CreateInfractionType membuat jenis pelanggaran baru dan mengisi ID serta timestamp.
+	CreateInfractionType(ctx context.Context, infraction *models.InfractionType) error
+	// GetInfractionTypesByOwnerID mengambil semua jenis pelanggaran milik parent (urut nama).
+	GetInfractionTypesByOwnerID(ctx context.Context, parentID int) ([]models.InfractionType, error)
+	// GetInfractionTypeByID mengambil jenis pelanggaran berdasarkan ID. Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+	GetInfractionTypeByID(ctx context.Context, infractionTypeID int) (*models.InfractionType, error)
+	// UpdateInfractionType memperbarui jenis pelanggaran milik infraction.CreatedByUserID.
+	UpdateInfractionType(ctx context.Context, infraction *models.InfractionType) error
+	// DeleteInfractionType menghapus jenis pelanggaran milik parent.
+	DeleteInfractionType(ctx context.Context, infractionTypeID int, parentID int) error
+
+	// GetPenaltiesByChildID mengambil sanksi anak dengan paginasi (terbaru dulu) beserta total.
+	GetPenaltiesByChildID(ctx context.Context, childID int, page, limit int) ([]models.Penalty, int, error)
+	// GetPenaltiesBetween mengambil semua sanksi anak dalam rentang [from, to), terlama dulu.
+	GetPenaltiesBetween(ctx context.Context, childID int, from, to time.Time) ([]models.Penalty, error)
+
+	// --- Metode Transaksional ---
+
+	// CountPenaltiesSinceTx menghitung sanksi jenis tertentu pada anak sejak waktu since (untuk batas harian).
+	CountPenaltiesSinceTx(ctx context.Context, tx pgx.Tx, childID int, infractionTypeID int, since time.Time) (int, error)
+	// CreatePenaltyTx menyimpan sanksi baru dan mengisi ID serta timestamp.
+	CreatePenaltyTx(ctx context.Context, tx pgx.Tx, penalty *models.Penalty) error
+	// GetPenaltyByEarnBackUserTaskIDForUpdateTx mengambil (dan mengunci) sanksi yang ditebus oleh penugasan userTaskID.
+	// Mengembalikan pgx.ErrNoRows jika penugasan tersebut bukan tugas penebus.
+	GetPenaltyByEarnBackUserTaskIDForUpdateTx(ctx context.Context, tx pgx.Tx, userTaskID int) (*models.Penalty, error)
+	// MarkEarnedBackTx menandai sanksi sudah ditebus beserta entri ledger pengembaliannya (0 = tanpa entri).
+	MarkEarnedBackTx(ctx context.Context, tx pgx.Tx, penaltyID int, reversalTransactionID int) error
+}
+
+// ====================================================================================
+// Badge Repository
+// ====================================================================================
+
+// BadgeRepository mendefinisikan operasi badge (sistem & custom milik parent), pencapaian anak,
+// serta metrik yang dipakai untuk mengevaluasi aturan badge.
+type BadgeRepository interface {
+	// CreateBadge membuat badge custom milik parent dan mengisi ID serta timestamp.
+	CreateBadge(ctx context.Context, badge *models.Badge) error
+	// GetBadgesForParent mengambil badge sistem dan badge custom milik parent.
+	GetBadgesForParent(ctx context.Context, parentID int) ([]models.Badge, error)
+	// GetBadgeByID mengambil badge berdasarkan ID. Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+	GetBadgeByID(ctx context.Context, badgeID int) (*models.Badge, error)
+	// UpdateBadge memperbarui badge custom milik badge.CreatedByUserID.
+	UpdateBadge(ctx context.Context, badge *models.Badge) error
+	// DeleteBadge menghapus badge custom milik parent.
+	DeleteBadge(ctx context.Context, badgeID int, parentID int) error
+
+	// GetUnearnedRuleBadges mengambil badge berbasis aturan yang berlaku untuk anak namun belum diraih.
+	GetUnearnedRuleBadges(ctx context.Context, childID int) ([]models.Badge, error)
+	// AwardBadge mencatat badge yang diraih anak. Mengembalikan false jika anak sudah memilikinya.
+	AwardBadge(ctx context.Context, award *models.ChildBadge) (bool, error)
+	// GetChildBadges mengambil badge yang sudah diraih anak (terbaru dulu).
+	GetChildBadges(ctx context.Context, childID int) ([]models.ChildBadge, error)
+
+	// CountApprovedTasks menghitung tugas anak yang disetujui (taskID 0 = semua tugas).
+	CountApprovedTasks(ctx context.Context, childID int, taskID int) (int, error)
+	// GetApprovedTaskCompletionTimes mengambil waktu penyelesaian tugas yang disetujui sejak since (taskID 0 = semua tugas).
+	GetApprovedTaskCompletionTimes(ctx context.Context, childID int, taskID int, since time.Time) ([]time.Time, error)
+	// CountRewardClaims menghitung klaim hadiah anak yang tidak ditolak.
+	CountRewardClaims(ctx context.Context, childID int) (int, error)
+	// GetChildIDsWithReachableBalanceBadges mengambil ID anak (> afterChildID, terurut) yang saldonya memenuhi
+	// badge 'points_balance' yang belum diraih (keyset pagination untuk worker).
+	GetChildIDsWithReachableBalanceBadges(ctx context.Context, afterChildID int, limit int) ([]int, error)
+}
+
+// ====================================================================================
+// Streak Repository
+// ====================================================================================
+
+// StreakCompletion adalah satu penyelesaian tugas yang disetujui, dipakai untuk menghitung streak per tugas.
+type StreakCompletion struct {
+	TaskID      int       // ID definisi tugas
+	TaskName    string    // Nama tugas
+	CompletedAt time.Time // Waktu tugas disetujui
+}
+
+// StreakRepository mendefinisikan operasi aturan bonus streak, pemberian bonus, streak freeze,
+// serta riwayat penyelesaian yang dipakai untuk menghitung streak.
+type StreakRepository interface {
+	// CreateBonusRule membuat aturan bonus streak milik parent dan mengisi ID serta timestamp.
+	CreateBonusRule(ctx context.Context, rule *models.StreakBonusRule) error
+	// GetBonusRulesByOwnerID mengambil semua aturan bonus streak milik parent.
+	GetBonusRulesByOwnerID(ctx context.Context, parentID int) ([]models.StreakBonusRule, error)
+	// GetBonusRuleByID mengambil aturan bonus streak berdasarkan ID. Mengembalikan pgx.ErrNoRows jika tidak ditemukan.
+	GetBonusRuleByID(ctx context.Context, ruleID int) (*models.StreakBonusRule, error)
+	// UpdateBonusRule memperbarui aturan bonus streak milik rule.CreatedByUserID.
+	UpdateBonusRule(ctx context.Context, rule *models.StreakBonusRule) error
+	// DeleteBonusRule menghapus aturan bonus streak milik parent.
+	DeleteBonusRule(ctx context.Context, ruleID int, parentID int) error
+
+	// GetApprovedCompletions mengambil penyelesaian tugas anak yang disetujui sejak since beserta tugasnya.
+	GetApprovedCompletions(ctx context.Context, childID int, since time.Time) ([]StreakCompletion, error)
+	// GetFrozenDays mengambil hari yang dilindungi streak freeze sejak tanggal since.
+	GetFrozenDays(ctx context.Context, childID int, since time.Time) ([]time.Time, error)
+
+	// UpsertFreezePolicy membuat atau memperbarui harga streak freeze anak.
+	UpsertFreezePolicy(ctx context.Context, policy *models.StreakFreezePolicy) error
+	// GetFreezePolicyByChildID mengambil harga streak freeze anak. Mengembalikan pgx.ErrNoRows jika belum diatur.
+	GetFreezePolicyByChildID(ctx context.Context, childID int) (*models.StreakFreezePolicy, error)
+	// DeleteFreezePolicy menghapus harga streak freeze anak.
+	DeleteFreezePolicy(ctx context.Context, childID int) error
+	// CountUnusedFreezes menghitung streak freeze anak yang belum terpakai.
+	CountUnusedFreezes(ctx context.Context, childID int) (int, error)
+	// UseFreeze memakai freeze tertua anak untuk tanggal day. Mengembalikan ID freeze, atau 0 jika tidak ada yang dipakai.
+	UseFreeze(ctx context.Context, childID int, day time.Time) (int, error)
+	// GetChildIDsWithUnusedFreezes mengambil ID anak (> afterID) yang masih memiliki freeze belum terpakai.
+	GetChildIDsWithUnusedFreezes(ctx context.Context, afterID int, limit int) ([]int, error)
+
+	// --- Metode Transaksional ---
+
+	// GetActiveBonusRulesForChildTx mengambil aturan aktif milik orang tua anak untuk tugas taskID (termasuk aturan semua tugas).
+	GetActiveBonusRulesForChildTx(ctx context.Context, tx pgx.Tx, childID int, taskID int) ([]models.StreakBonusRule, error)
+	// GetApprovedCompletionTimesTx mengambil waktu penyelesaian tugas yang disetujui sejak since (taskID 0 = semua tugas).
+	GetApprovedCompletionTimesTx(ctx context.Context, tx pgx.Tx, childID int, taskID int, since time.Time) ([]time.Time, error)
+	// GetFrozenDaysTx mengambil hari yang dilindungi streak freeze sejak tanggal since.
+	GetFrozenDaysTx(ctx context.Context, tx pgx.Tx, childID int, since time.Time) ([]time.Time, error)
+	// HasBonusAwardSinceTx memeriksa apakah aturan sudah memberi bonus untuk streak yang dimulai pada/sesudah since.
+	HasBonusAwardSinceTx(ctx context.Context, tx pgx.Tx, ruleID int, childID int, since time.Time) (bool, error)
+	// CreateBonusAwardTx mencatat bonus streak yang diberikan dan mengisi ID serta awarded_at.
+	CreateBonusAwardTx(ctx context.Context, tx pgx.Tx, award *models.StreakBonusAward) error
+	// HasBonusAwardForUserTaskTx memeriksa apakah persetujuan tugas userTaskID memicu bonus streak.
+	HasBonusAwardForUserTaskTx(ctx context.Context, tx pgx.Tx, userTaskID int) (bool, error)
+	// CountUnusedFreezesTx menghitung streak freeze anak yang belum terpakai.
+	CountUnusedFreezesTx(ctx context.Context, tx pgx.Tx, childID int) (int, error)
+	// CreateFreezeTx mencatat streak freeze yang dibeli dan mengisi ID serta purchased_at.
+	CreateFreezeTx(ctx context.Context, tx pgx.Tx, freeze *models.StreakFreeze) error
+}
+
+// LevelRepository: Kontrak untuk XP (terpisah dari poin, tidak bisa dibelanjakan), tangga level, dan level-up anak.
+type LevelRepository interface {
+	// GetLevels mengambil tangga level, urut naik menurut XP minimal.
+	GetLevels(ctx context.Context) ([]models.Level, error)
+	// GetTotalXP mengambil total XP anak (0 jika belum pernah mendapat XP).
+	GetTotalXP(ctx context.Context, userID int) (int, error)
+	// GetTotalXPByUserIDs mengambil total XP beberapa anak sekaligus (anak tanpa XP tidak ada di map).
+	GetTotalXPByUserIDs(ctx context.Context, userIDs []int) (map[int]int, error)
+	// GetLevelUpsByUserID mengambil riwayat level-up anak, terbaru dulu.
+	GetLevelUpsByUserID(ctx context.Context, userID int) ([]models.LevelUp, error)
+	// GetLevelRewardsForChild mengambil hadiah milik orang tua anak yang terkunci level (min_level > 1).
+	GetLevelRewardsForChild(ctx context.Context, childID int) ([]models.LevelReward, error)
+
+	// --- Metode Transaksional ---
+
+	// GetLevelsTx mengambil tangga level dalam transaksi.
+	GetLevelsTx(ctx context.Context, tx pgx.Tx) ([]models.Level, error)
+	// AddXPTx mencatat perubahan XP dan mengembalikan total XP anak yang baru (baris total terkunci).
+	AddXPTx(ctx context.Context, tx pgx.Tx, xp *models.XPTransaction) (int, error)
+	// GetNetTaskXPTx menghitung XP bersih yang berasal dari penugasan userTaskID.
+	GetNetTaskXPTx(ctx context.Context, tx pgx.Tx, userTaskID int) (int, error)
+	// CreateLevelUpTx mencatat level yang dicapai anak. Mengembalikan false jika level tersebut sudah pernah dicapai.
+	CreateLevelUpTx(ctx context.Context, tx pgx.Tx, levelUp *models.LevelUp) (bool, error)
+}
@@ -0,0 +1,146 @@
+// internal/repository/reward_approval_repo.go
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type rewardApprovalRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewRewardApprovalRepository membuat instance baru dari RewardApprovalRepository.
+func NewRewardApprovalRepository(db *pgxpool.Pool) RewardApprovalRepository {
+	return &rewardApprovalRepo{db: db}
+}
+
+const rewardApprovalPolicyColumns = `p.id, p.created_by_user_id, COALESCE(p.child_id, 0), p.points_threshold, p.required_approvals,
+                p.created_at, p.updated_at`
+
+// getPolicy membaca satu kebijakan persetujuan bersama memakai pool atau transaksi.
+func getPolicy(ctx context.Context, db rowQuerier, query string, args ...any) (*models.RewardApprovalPolicy, error) {
+	policy := &models.RewardApprovalPolicy{}
+	err := db.QueryRow(ctx, `SELECT `+rewardApprovalPolicyColumns+` FROM reward_approval_policies p`+query, args...).Scan(
+		&policy.ID, &policy.CreatedByUserID, &policy.ChildID, &policy.PointsThreshold, &policy.RequiredApprovals, &policy.CreatedAt, &policy.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Interface("args", args).Msg("Error getting reward approval policy")
+		return nil, fmt.Errorf("error getting reward approval policy: %w", err)
+	}
+	return policy, nil
+}
+
+// UpsertPolicy membuat atau memperbarui kebijakan persetujuan bersama milik parent untuk cakupan anaknya.
+func (r *rewardApprovalRepo) UpsertPolicy(ctx context.Context, policy *models.RewardApprovalPolicy) error {
+	query := `INSERT INTO reward_approval_policies (created_by_user_id, child_id, points_threshold, required_approvals)
+              VALUES ($1, $2, $3, $4)
+              ON CONFLICT ` + familyPolicyConflict + ` DO UPDATE
+              SET points_threshold = EXCLUDED.points_threshold,
+                  required_approvals = EXCLUDED.required_approvals
+              RETURNING id, created_at, updated_at`
+	err := r.db.QueryRow(ctx, query, policy.CreatedByUserID, nullableID(policy.ChildID), policy.PointsThreshold, policy.RequiredApprovals).
+		Scan(&policy.ID, &policy.CreatedAt, &policy.UpdatedAt)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
+			return fmt.Errorf("invalid child for reward approval policy")
+		}
+		zlog.Error().Err(err).Int("parent_id", policy.CreatedByUserID).Int("child_id", policy.ChildID).Msg("Error upserting reward approval policy")
+		return fmt.Errorf("error saving reward approval policy: %w", err)
+	}
+	zlog.Info().Int("policy_id", policy.ID).Int("parent_id", policy.CreatedByUserID).Int("child_id", policy.ChildID).
+		Int("points_threshold", policy.PointsThreshold).Int("required_approvals", policy.RequiredApprovals).Msg("Reward approval policy saved")
+	return nil
+}
+
+// GetPolicyByOwner mendapatkan kebijakan milik parent untuk cakupan anak (0 = semua anak).
+func (r *rewardApprovalRepo) GetPolicyByOwner(ctx context.Context, parentID int, childID int) (*models.RewardApprovalPolicy, error) {
+	return getPolicy(ctx, r.db, familyPolicyOwnedBy, parentID, childID)
+}
+
+// GetPolicyForChild mendapatkan kebijakan yang berlaku untuk anak dari kebijakan orang tuanya.
+func (r *rewardApprovalRepo) GetPolicyForChild(ctx context.Context, childID int) (*models.RewardApprovalPolicy, error) {
+	return getPolicy(ctx, r.db, familyPolicyForChild, childID)
+}
+
+// DeletePolicy menghapus kebijakan milik parent untuk cakupan anak (0 = semua anak).
+func (r *rewardApprovalRepo) DeletePolicy(ctx context.Context, parentID int, childID int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM reward_approval_policies p`+familyPolicyOwnedBy, parentID, childID)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", parentID).Int("child_id", childID).Msg("Error deleting reward approval policy")
+		return fmt.Errorf("error deleting reward approval policy: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// GetVotesByClaimID mendapatkan suara orang tua untuk sebuah klaim.
+func (r *rewardApprovalRepo) GetVotesByClaimID(ctx context.Context, claimID int) ([]models.UserRewardVote, error) {
+	query := `SELECT id, user_reward_id, parent_id, vote, created_at
+              FROM user_reward_votes WHERE user_reward_id = $1
+              ORDER BY created_at, id`
+	rows, err := r.db.Query(ctx, query, claimID)
+	if err != nil {
+		zlog.Error().Err(err).Int("claim_id", claimID).Msg("Error querying reward claim votes")
+		return nil, fmt.Errorf("error getting votes for claim %d: %w", claimID, err)
+	}
+	defer rows.Close()
+
+	votes := []models.UserRewardVote{}
+	for rows.Next() {
+		var vote models.UserRewardVote
+		if err := rows.Scan(&vote.ID, &vote.UserRewardID, &vote.ParentID, &vote.Vote, &vote.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning claim vote: %w", err)
+		}
+		votes = append(votes, vote)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating claim votes: %w", err)
+	}
+	return votes, nil
+}
+
+// --- Metode Transaksional ---
+
+// GetPolicyForChildTx mendapatkan kebijakan yang berlaku untuk anak dalam transaksi.
+func (r *rewardApprovalRepo) GetPolicyForChildTx(ctx context.Context, tx pgx.Tx, childID int) (*models.RewardApprovalPolicy, error) {
+	return getPolicy(ctx, tx, familyPolicyForChild, childID)
+}
+
+// CreateVoteTx mencatat suara orang tua untuk klaim.
+func (r *rewardApprovalRepo) CreateVoteTx(ctx context.Context, tx pgx.Tx, claimID int, parentID int, vote models.UserRewardStatus) error {
+	query := `INSERT INTO user_reward_votes (user_reward_id, parent_id, vote) VALUES ($1, $2, $3)`
+	_, err := tx.Exec(ctx, query, claimID, parentID, vote)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			zlog.Warn().Int("claim_id", claimID).Int("parent_id", parentID).Msg("RepoTx: Parent already voted on claim")
+			return fmt.Errorf("cannot review claim: you have already voted on this claim")
+		}
+		zlog.Error().Err(err).Int("claim_id", claimID).Int("parent_id", parentID).Msg("RepoTx: Error creating claim vote")
+		return fmt.Errorf("repoTx error creating vote for claim %d: %w", claimID, err)
+	}
+	return nil
+}
+
+// CountApprovalsTx menghitung jumlah persetujuan untuk klaim.
+func (r *rewardApprovalRepo) CountApprovalsTx(ctx context.Context, tx pgx.Tx, claimID int) (int, error) {
+	var count int
+	err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM user_reward_votes WHERE user_reward_id = $1 AND vote = $2`, claimID, models.UserRewardStatusApproved).Scan(&count)
+	if err != nil {
+		zlog.Error().Err(err).Int("claim_id", claimID).Msg("RepoTx: Error counting claim approvals")
+		return 0, fmt.Errorf("repoTx error counting approvals for claim %d: %w", claimID, err)
+	}
+	return count, nil
+}
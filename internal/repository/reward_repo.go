@@ -6,6 +6,7 @@ import (
 	"database/sql" // Untuk sql.NullString
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -18,6 +19,44 @@ type rewardRepo struct {
 	db *pgxpool.Pool
 }
 
+// rewardLimitColumns adalah kolom pembatasan reward (stok, kuota, cooldown, level minimal); NULL dinormalisasi ke nilai nol.
+const rewardLimitColumns = `stock, COALESCE(claim_limit, 0), COALESCE(claim_limit_period, ''), cooldown_minutes, min_level`
+
+// rewardUsageJoin menghitung klaim aktif (pending/approved) anak ($%[1]d) untuk reward `rw` dalam periode kuota berjalan
+// beserta waktu klaim terakhir. Klaim yang ditolak tidak dihitung karena poinnya sudah dikembalikan.
+// Level anak (dari total XP) ikut dihitung untuk pemeriksaan min_level.
+const rewardUsageJoin = `LEFT JOIN LATERAL (
+                  SELECT COUNT(*) FILTER (
+                             WHERE rw.claim_limit_period IS NOT NULL
+                               AND ur.claimed_at >= date_trunc(rw.claim_limit_period, NOW())
+                         ) AS period_claims,
+                         MAX(ur.claimed_at) AS last_claimed_at
+                  FROM user_rewards ur
+                  WHERE ur.reward_id = rw.id AND ur.user_id = $%[1]d AND ur.status <> 'rejected'
+              ) cu ON TRUE
+              LEFT JOIN LATERAL (
+                  SELECT COALESCE(MAX(lv.level), 1) AS level
+                  FROM levels lv
+                  WHERE lv.xp_required <= COALESCE((SELECT ux.total_xp FROM user_xp ux WHERE ux.user_id = $%[1]d), 0)
+              ) cl ON TRUE`
+
+// rewardUsageColumns adalah kolom hasil rewardUsageJoin, urutannya sesuai field models.RewardUsage.
+const rewardUsageColumns = `cu.period_claims,
+              CASE WHEN rw.claim_limit_period IS NOT NULL
+                   THEN date_trunc(rw.claim_limit_period, NOW()) + ('1 ' || rw.claim_limit_period)::INTERVAL
+              END,
+              cu.last_claimed_at,
+              cl.level`
+
+// errRewardCurrencyNotFound dikembalikan jika currency_id reward bukan mata uang milik pembuat reward.
+var errRewardCurrencyNotFound = errors.New("invalid currency_id: currency not found")
+
+// isRewardCurrencyViolation memeriksa pelanggaran FK (currency_id, created_by_user_id) ke currencies.
+func isRewardCurrencyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "fk_reward_currency"
+}
+
 // NewRewardRepository membuat instance baru dari RewardRepository.
 func NewRewardRepository(db *pgxpool.Pool) RewardRepository {
 	return &rewardRepo{db: db}
@@ -25,17 +64,29 @@ func NewRewardRepository(db *pgxpool.Pool) RewardRepository {
 
 // CreateReward membuat definisi reward baru.
 func (r *rewardRepo) CreateReward(ctx context.Context, reward *models.Reward) (int, error) {
-	query := `INSERT INTO rewards (reward_name, reward_point, reward_description, created_by_user_id)
-              VALUES ($1, $2, $3, $4) RETURNING id`
+	query := `INSERT INTO rewards (reward_name, reward_point, reward_description, category, tags, created_by_user_id,
+                                   stock, claim_limit, claim_limit_period, cooldown_minutes, currency_id, min_level)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`
 	var rewardID int
 	err := r.db.QueryRow(ctx, query,
 		reward.RewardName,
 		reward.RewardPoint,
 		reward.RewardDescription,
+		nullableCategory(reward.Category),
+		normalizeTags(reward.Tags),
 		reward.CreatedByUserID, // ID Parent pembuat
+		reward.Stock,
+		nullableID(reward.ClaimLimit),
+		nullableLimitPeriod(reward.ClaimLimitPeriod),
+		reward.CooldownMinutes,
+		nullableID(reward.CurrencyID),
+		max(reward.MinLevel, 1),
 	).Scan(&rewardID)
 
 	if err != nil {
+		if isRewardCurrencyViolation(err) {
+			return 0, errRewardCurrencyNotFound
+		}
 		// Handle FK violation jika created_by_user_id tidak valid
 		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
 			zlog.Warn().Err(err).Int("creator_id", reward.CreatedByUserID).Msg("Foreign key violation on reward creation (creator not found?)")
@@ -50,26 +101,68 @@ func (r *rewardRepo) CreateReward(ctx context.Context, reward *models.Reward) (i
 	return rewardID, nil
 }
 
+// CreateRewardTx membuat definisi reward baru dalam transaksi (dipakai saat impor template).
+func (r *rewardRepo) CreateRewardTx(ctx context.Context, tx pgx.Tx, reward *models.Reward) (int, error) {
+	query := `INSERT INTO rewards (reward_name, reward_point, reward_description, category, tags, created_by_user_id,
+                                   source_template_id, source_template_version, currency_id)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+	var rewardID int
+	err := tx.QueryRow(ctx, query,
+		reward.RewardName,
+		reward.RewardPoint,
+		reward.RewardDescription,
+		nullableCategory(reward.Category),
+		normalizeTags(reward.Tags),
+		reward.CreatedByUserID,
+		nullableID(reward.SourceTemplateID),
+		nullableID(reward.SourceTemplateVersion),
+		nullableID(reward.CurrencyID),
+	).Scan(&rewardID)
+	if err != nil {
+		if isRewardCurrencyViolation(err) {
+			return 0, errRewardCurrencyNotFound
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
+			zlog.Warn().Err(err).Int("creator_id", reward.CreatedByUserID).Msg("RepoTx: Foreign key violation on reward creation")
+			return 0, fmt.Errorf("invalid creator or source template for reward definition")
+		}
+		zlog.Error().Err(err).Str("reward_name", reward.RewardName).Int("creator_id", reward.CreatedByUserID).Msg("RepoTx: Error creating reward definition")
+		return 0, fmt.Errorf("repoTx error creating reward definition: %w", err)
+	}
+	return rewardID, nil
+}
+
 // GetRewardByID (Family Visibility Implementation)
 // GetRewardByID hanya mengambil berdasarkan ID, tanpa validasi ownership/family.
 // Validasi akses dilakukan di Handler/Service.
 func (r *rewardRepo) GetRewardByID(ctx context.Context, id int) (*models.Reward, error) {
 	query := `
         SELECT
-            id, reward_name, reward_point, reward_description,
-            created_by_user_id, created_at, updated_at
+            id, reward_name, reward_point, reward_description, category, tags, ` + rewardLimitColumns + `,
+            source_template_id, source_template_version, created_by_user_id, COALESCE(currency_id, 0), created_at, updated_at
         FROM rewards
         WHERE id = $1
     `
 	reward := &models.Reward{}
-	var description sql.NullString
+	var description, category sql.NullString
+	var sourceTemplateID, sourceTemplateVersion sql.NullInt32
 
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&reward.ID,
 		&reward.RewardName,
 		&reward.RewardPoint,
 		&description,
+		&category,
+		&reward.Tags,
+		&reward.Stock,
+		&reward.ClaimLimit,
+		&reward.ClaimLimitPeriod,
+		&reward.CooldownMinutes,
+		&reward.MinLevel,
+		&sourceTemplateID,
+		&sourceTemplateVersion,
 		&reward.CreatedByUserID,
+		&reward.CurrencyID,
 		&reward.CreatedAt,
 		&reward.UpdatedAt,
 	)
@@ -88,16 +181,26 @@ func (r *rewardRepo) GetRewardByID(ctx context.Context, id int) (*models.Reward,
 	if description.Valid {
 		reward.RewardDescription = description.String
 	}
+	if category.Valid {
+		reward.Category = models.DefinitionCategory(category.String)
+	}
+	if sourceTemplateID.Valid {
+		reward.SourceTemplateID = int(sourceTemplateID.Int32)
+		reward.SourceTemplateVersion = int(sourceTemplateVersion.Int32)
+	}
 
 	return reward, nil
 }
 
-// GetRewardsByCreatorID mengambil daftar reward (paginated) yang dibuat oleh parent tertentu.
-func (r *rewardRepo) GetRewardsByCreatorID(ctx context.Context, creatorID int, page, limit int) ([]models.Reward, int, error) {
-	// 1. Hitung total
-	countQuery := `SELECT COUNT(*) FROM rewards WHERE created_by_user_id = $1`
+// GetRewardsByCreatorID mengambil daftar reward (paginated) yang dibuat oleh parent tertentu,
+// dengan filter pencarian opsional (teks, kategori, tag, rentang poin) dan pengurutan.
+func (r *rewardRepo) GetRewardsByCreatorID(ctx context.Context, creatorID int, filter *models.DefinitionFilter, page, limit int) ([]models.Reward, int, error) {
+	filterClause, orderBy, args := buildDefinitionFilter(filter, rewardDefinitionColumns, []any{creatorID})
+
+	// 1. Hitung total (setelah filter)
+	countQuery := `SELECT COUNT(*) FROM rewards WHERE created_by_user_id = $1` + filterClause
 	var totalCount int
-	err := r.db.QueryRow(ctx, countQuery, creatorID).Scan(&totalCount)
+	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&totalCount)
 	if err != nil {
 		zlog.Error().Err(err).Int("creator_id", creatorID).Msg("Error counting rewards by creator ID")
 		return nil, 0, fmt.Errorf("error counting rewards for creator %d: %w", creatorID, err)
@@ -113,14 +216,14 @@ func (r *rewardRepo) GetRewardsByCreatorID(ctx context.Context, creatorID int, p
 		offset = 0
 	}
 
-	// 3. Query data
-	query := `SELECT id, reward_name, reward_point, reward_description, created_by_user_id, created_at, updated_at
+	// 3. Query data (default: urutkan dari terbaru)
+	query := fmt.Sprintf(`SELECT id, reward_name, reward_point, reward_description, category, tags, %s, source_template_id, source_template_version, created_by_user_id, COALESCE(currency_id, 0), created_at, updated_at
               FROM rewards
-              WHERE created_by_user_id = $1
-              ORDER BY created_at DESC
-              LIMIT $2 OFFSET $3`
+              WHERE created_by_user_id = $1%s
+              ORDER BY %s
+              LIMIT $%d OFFSET $%d`, rewardLimitColumns, filterClause, orderBy, len(args)+1, len(args)+2)
 
-	rows, err := r.db.Query(ctx, query, creatorID, limit, offset)
+	rows, err := r.db.Query(ctx, query, append(args, limit, offset)...)
 	if err != nil {
 		zlog.Error().Err(err).Int("creator_id", creatorID).Msg("Error querying paginated rewards by creator ID")
 		return nil, totalCount, fmt.Errorf("error getting rewards for creator %d: %w", creatorID, err)
@@ -131,13 +234,24 @@ func (r *rewardRepo) GetRewardsByCreatorID(ctx context.Context, creatorID int, p
 	rewards := []models.Reward{}
 	for rows.Next() {
 		var reward models.Reward
-		var description sql.NullString
+		var description, category sql.NullString
+		var sourceTemplateID, sourceTemplateVersion sql.NullInt32
 		scanErr := rows.Scan(
 			&reward.ID,
 			&reward.RewardName,
 			&reward.RewardPoint,
 			&description,
+			&category,
+			&reward.Tags,
+			&reward.Stock,
+			&reward.ClaimLimit,
+			&reward.ClaimLimitPeriod,
+			&reward.CooldownMinutes,
+			&reward.MinLevel,
+			&sourceTemplateID,
+			&sourceTemplateVersion,
 			&reward.CreatedByUserID,
+			&reward.CurrencyID,
 			&reward.CreatedAt,
 			&reward.UpdatedAt,
 		)
@@ -148,6 +262,13 @@ func (r *rewardRepo) GetRewardsByCreatorID(ctx context.Context, creatorID int, p
 		if description.Valid {
 			reward.RewardDescription = description.String
 		}
+		if category.Valid {
+			reward.Category = models.DefinitionCategory(category.String)
+		}
+		if sourceTemplateID.Valid {
+			reward.SourceTemplateID = int(sourceTemplateID.Int32)
+			reward.SourceTemplateVersion = int(sourceTemplateVersion.Int32)
+		}
 		rewards = append(rewards, reward)
 	}
 
@@ -210,31 +331,49 @@ func (r *rewardRepo) GetAvailableRewardsForChild(ctx context.Context, childID in
 		offset = 0
 	}
 
-	// 3. Query reward dari semua parent anak ini dengan pagination
-	query := `SELECT id, reward_name, reward_point, reward_description, created_by_user_id, created_at, updated_at
-              FROM rewards
-              WHERE created_by_user_id = ANY($1::int[])
-              ORDER BY created_at DESC
-              LIMIT $2 OFFSET $3`
-
-	rows, err := r.db.Query(ctx, query, parentIDs, limit, offset)
+	// 3. Query reward dari semua parent anak ini dengan pagination, beserta riwayat klaim anak (kuota & cooldown)
+	query := fmt.Sprintf(`SELECT rw.id, rw.reward_name, rw.reward_point, rw.reward_description, rw.category, rw.tags,
+                     rw.stock, COALESCE(rw.claim_limit, 0), COALESCE(rw.claim_limit_period, ''), rw.cooldown_minutes, rw.min_level,
+                     %s,
+                     rw.created_by_user_id, COALESCE(rw.currency_id, 0), rw.created_at, rw.updated_at
+              FROM rewards rw
+              %s
+              WHERE rw.created_by_user_id = ANY($1::int[])
+              ORDER BY rw.created_at DESC
+              LIMIT $2 OFFSET $3`, rewardUsageColumns, fmt.Sprintf(rewardUsageJoin, 4))
+
+	rows, err := r.db.Query(ctx, query, parentIDs, limit, offset, childID)
 	if err != nil {
 		zlog.Error().Err(err).Int("child_id", childID).Msg("Error querying available rewards for child")
 		return nil, totalCount, fmt.Errorf("error getting available rewards for child %d: %w", childID, err)
 	}
 	defer rows.Close()
 
-	// 4. Scan hasil
+	// 4. Scan hasil dan hitung ketersediaan untuk anak
+	now := time.Now()
 	rewards := []models.Reward{}
 	for rows.Next() {
 		var reward models.Reward
-		var description sql.NullString
+		var description, category sql.NullString
+		var usage models.RewardUsage
 		scanErr := rows.Scan(
 			&reward.ID,
 			&reward.RewardName,
 			&reward.RewardPoint,
 			&description,
+			&category,
+			&reward.Tags,
+			&reward.Stock,
+			&reward.ClaimLimit,
+			&reward.ClaimLimitPeriod,
+			&reward.CooldownMinutes,
+			&reward.MinLevel,
+			&usage.PeriodClaims,
+			&usage.PeriodEndsAt,
+			&usage.LastClaimedAt,
+			&usage.Level,
 			&reward.CreatedByUserID,
+			&reward.CurrencyID,
 			&reward.CreatedAt,
 			&reward.UpdatedAt,
 		)
@@ -245,6 +384,11 @@ func (r *rewardRepo) GetAvailableRewardsForChild(ctx context.Context, childID in
 		if description.Valid {
 			reward.RewardDescription = description.String
 		}
+		if category.Valid {
+			reward.Category = models.DefinitionCategory(category.String)
+		}
+		availability := reward.RewardLimits.Availability(usage, now)
+		reward.Availability = &availability
 		rewards = append(rewards, reward)
 	}
 
@@ -260,18 +404,31 @@ func (r *rewardRepo) GetAvailableRewardsForChild(ctx context.Context, childID in
 // Hanya pembuat asli (Strict Ownership) yang bisa mengedit.
 func (r *rewardRepo) UpdateReward(ctx context.Context, reward *models.Reward, parentID int) error {
 	query := `UPDATE rewards
-              SET reward_name = $1, reward_point = $2, reward_description = $3
-              WHERE id = $4 AND created_by_user_id = $5` // Validasi ID dan kepemilikan
+              SET reward_name = $1, reward_point = $2, reward_description = $3, category = $4, tags = $5,
+                  stock = $6, claim_limit = $7, claim_limit_period = $8, cooldown_minutes = $9, currency_id = $12,
+                  min_level = $13
+              WHERE id = $10 AND created_by_user_id = $11` // Validasi ID dan kepemilikan
 
 	tag, err := r.db.Exec(ctx, query,
 		reward.RewardName,
 		reward.RewardPoint,
 		reward.RewardDescription,
+		nullableCategory(reward.Category),
+		normalizeTags(reward.Tags),
+		reward.Stock,
+		nullableID(reward.ClaimLimit),
+		nullableLimitPeriod(reward.ClaimLimitPeriod),
+		reward.CooldownMinutes,
 		reward.ID, // ID reward yang diupdate
 		parentID,  // ID parent yang melakukan request (harus == created_by_user_id)
+		nullableID(reward.CurrencyID),
+		max(reward.MinLevel, 1),
 	)
 
 	if err != nil {
+		if isRewardCurrencyViolation(err) {
+			return errRewardCurrencyNotFound
+		}
 		zlog.Error().Err(err).Int("reward_id", reward.ID).Int("requesting_parent_id", parentID).Msg("Error updating reward definition")
 		return fmt.Errorf("error updating reward definition %d: %w", reward.ID, err)
 	}
@@ -320,14 +477,21 @@ func (r *rewardRepo) DeleteReward(ctx context.Context, id int, parentID int) err
 // validasi kepemilikan dilakukan di service layer atau handler yang memanggil (berdasarkan relasi anak-parent).
 func (r *rewardRepo) GetRewardDetailsTx(ctx context.Context, tx pgx.Tx, rewardID int) (*RewardDetails, error) {
 	// Tambahkan created_by_user_id ke SELECT
-	query := `SELECT id, reward_point, created_by_user_id
-              FROM rewards WHERE id = $1 FOR UPDATE` // Lock baris
+	// Lock baris: klaim untuk reward yang sama diserialisasi sehingga stok & kuota tidak terlampaui
+	query := `SELECT id, reward_point, created_by_user_id, COALESCE(currency_id, 0), ` + rewardLimitColumns + `
+              FROM rewards WHERE id = $1 FOR UPDATE`
 	details := &RewardDetails{}
 	// Tambahkan &details.CreatedByUserID ke Scan
 	err := tx.QueryRow(ctx, query, rewardID).Scan(
         &details.ID,
         &details.RequiredPoints,
         &details.CreatedByUserID,
+        &details.CurrencyID,
+        &details.Limits.Stock,
+        &details.Limits.ClaimLimit,
+        &details.Limits.ClaimLimitPeriod,
+        &details.Limits.CooldownMinutes,
+        &details.Limits.MinLevel,
     )
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -338,3 +502,47 @@ func (r *rewardRepo) GetRewardDetailsTx(ctx context.Context, tx pgx.Tx, rewardID
 	}
 	return details, nil
 }
+
+// GetRewardUsageTx menghitung riwayat klaim aktif anak untuk satu reward dalam transaksi (untuk kuota & cooldown).
+func (r *rewardRepo) GetRewardUsageTx(ctx context.Context, tx pgx.Tx, childID int, rewardID int) (*models.RewardUsage, error) {
+	query := fmt.Sprintf(`SELECT %s
+              FROM rewards rw
+              %s
+              WHERE rw.id = $1`, rewardUsageColumns, fmt.Sprintf(rewardUsageJoin, 2))
+	usage := &models.RewardUsage{}
+	err := tx.QueryRow(ctx, query, rewardID, childID).Scan(&usage.PeriodClaims, &usage.PeriodEndsAt, &usage.LastClaimedAt, &usage.Level)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("reward_id", rewardID).Int("child_id", childID).Msg("RepoTx: Error getting reward usage")
+		return nil, fmt.Errorf("repoTx error getting reward usage %d: %w", rewardID, err)
+	}
+	return usage, nil
+}
+
+// DecrementStockTx mengurangi stok reward sebanyak satu. Reward tanpa stok (NULL) tidak diubah.
+func (r *rewardRepo) DecrementStockTx(ctx context.Context, tx pgx.Tx, rewardID int) error {
+	query := `UPDATE rewards SET stock = stock - 1, updated_at = NOW()
+              WHERE id = $1 AND stock IS NOT NULL AND stock > 0`
+	tag, err := tx.Exec(ctx, query, rewardID)
+	if err != nil {
+		zlog.Error().Err(err).Int("reward_id", rewardID).Msg("RepoTx: Error decrementing reward stock")
+		return fmt.Errorf("repoTx error decrementing stock for reward %d: %w", rewardID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("reward %d is out of stock", rewardID)
+	}
+	return nil
+}
+
+// RestoreStockTx mengembalikan satu unit stok reward (misal: klaim ditolak). Reward tanpa stok (NULL) tidak diubah.
+func (r *rewardRepo) RestoreStockTx(ctx context.Context, tx pgx.Tx, rewardID int) error {
+	query := `UPDATE rewards SET stock = stock + 1, updated_at = NOW()
+              WHERE id = $1 AND stock IS NOT NULL`
+	if _, err := tx.Exec(ctx, query, rewardID); err != nil {
+		zlog.Error().Err(err).Int("reward_id", rewardID).Msg("RepoTx: Error restoring reward stock")
+		return fmt.Errorf("repoTx error restoring stock for reward %d: %w", rewardID, err)
+	}
+	return nil
+}
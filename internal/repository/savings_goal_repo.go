@@ -0,0 +1,294 @@
+// internal/repository/savings_goal_repo.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type savingsGoalRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewSavingsGoalRepository membuat instance baru dari SavingsGoalRepository.
+func NewSavingsGoalRepository(db *pgxpool.Pool) SavingsGoalRepository {
+	return &savingsGoalRepo{db: db}
+}
+
+// --- Helper Functions ---
+
+const savingsGoalSelectColumns = `sg.id, sg.child_id, sg.reward_id, sg.goal_name, sg.target_points, sg.earmarked_points,
+                (SELECT COALESCE(SUM(c.points), 0) FROM savings_goal_contributions c WHERE c.goal_id = sg.id),
+                sg.auto_claim, sg.status, sg.reached_at, sg.claimed_user_reward_id, sg.created_at, sg.updated_at,
+                rw.reward_name, rw.reward_point`
+
+// scanSavingsGoalRow adalah helper untuk scan baris SavingsGoal (termasuk ringkasan Reward jika disematkan).
+func scanSavingsGoalRow(row pgx.Row, goal *models.SavingsGoal) error {
+	var rewardID, claimedUserRewardID sql.NullInt32
+	var rewardName sql.NullString
+	var rewardPoint sql.NullInt32
+	err := row.Scan(
+		&goal.ID, &goal.ChildID, &rewardID, &goal.GoalName, &goal.TargetPoints, &goal.EarmarkedPoints,
+		&goal.TotalContributed,
+		&goal.AutoClaim, &goal.Status, &goal.ReachedAt, &claimedUserRewardID, &goal.CreatedAt, &goal.UpdatedAt,
+		&rewardName, &rewardPoint,
+	)
+	if err != nil {
+		return err
+	}
+	if rewardID.Valid {
+		goal.RewardID = int(rewardID.Int32)
+		if rewardName.Valid {
+			goal.Reward = &models.Reward{ID: goal.RewardID, RewardName: rewardName.String, RewardPoint: int(rewardPoint.Int32)}
+		}
+	}
+	if claimedUserRewardID.Valid {
+		goal.ClaimedUserRewardID = int(claimedUserRewardID.Int32)
+	}
+	return nil
+}
+
+// collectSavingsGoalRows membaca seluruh baris hasil query target tabungan lalu menutup rows.
+func collectSavingsGoalRows(rows pgx.Rows) ([]models.SavingsGoal, error) {
+	defer rows.Close()
+	goals := []models.SavingsGoal{}
+	for rows.Next() {
+		var goal models.SavingsGoal
+		if scanErr := scanSavingsGoalRow(rows, &goal); scanErr != nil {
+			return nil, fmt.Errorf("error scanning savings goal data: %w", scanErr)
+		}
+		goals = append(goals, goal)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating savings goals: %w", err)
+	}
+	return goals, nil
+}
+
+// --- Repository Methods ---
+
+// CreateGoal menyimpan target tabungan baru dengan status 'active'.
+func (r *savingsGoalRepo) CreateGoal(ctx context.Context, goal *models.SavingsGoal) (int, error) {
+	query := `INSERT INTO savings_goals (child_id, reward_id, goal_name, target_points, auto_claim, status)
+              VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	var goalID int
+	err := r.db.QueryRow(ctx, query, goal.ChildID, nullableID(goal.RewardID), goal.GoalName, goal.TargetPoints, goal.AutoClaim, models.SavingsGoalStatusActive).Scan(&goalID)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case "23505": // Sudah ada target terbuka untuk Reward yang sama
+				zlog.Warn().Err(err).Int("child_id", goal.ChildID).Int("reward_id", goal.RewardID).Msg("Duplicate open savings goal for reward")
+				return 0, fmt.Errorf("cannot create savings goal: an open goal for this reward already exists")
+			case "23503":
+				zlog.Warn().Err(err).Int("child_id", goal.ChildID).Int("reward_id", goal.RewardID).Msg("Foreign key violation on savings goal creation")
+				return 0, fmt.Errorf("invalid child or reward ID for savings goal")
+			}
+		}
+		zlog.Error().Err(err).Int("child_id", goal.ChildID).Msg("Error creating savings goal")
+		return 0, fmt.Errorf("error creating savings goal: %w", err)
+	}
+
+	zlog.Info().Int("goal_id", goalID).Int("child_id", goal.ChildID).Int("target_points", goal.TargetPoints).Msg("Savings goal created successfully")
+	return goalID, nil
+}
+
+// GetGoalByID mengambil detail target tabungan.
+func (r *savingsGoalRepo) GetGoalByID(ctx context.Context, id int) (*models.SavingsGoal, error) {
+	query := `SELECT ` + savingsGoalSelectColumns + `
+              FROM savings_goals sg
+              LEFT JOIN rewards rw ON rw.id = sg.reward_id
+              WHERE sg.id = $1`
+	goal := &models.SavingsGoal{}
+	err := scanSavingsGoalRow(r.db.QueryRow(ctx, query, id), goal)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zlog.Warn().Int("goal_id", id).Msg("Savings goal not found by ID")
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("goal_id", id).Msg("Error getting savings goal by ID")
+		return nil, fmt.Errorf("error getting savings goal %d: %w", id, err)
+	}
+	return goal, nil
+}
+
+// GetGoalsByChildID mengambil target tabungan anak. Target yang sudah ditutup hanya disertakan jika includeClosed.
+func (r *savingsGoalRepo) GetGoalsByChildID(ctx context.Context, childID int, includeClosed bool) ([]models.SavingsGoal, error) {
+	query := `SELECT ` + savingsGoalSelectColumns + `
+              FROM savings_goals sg
+              LEFT JOIN rewards rw ON rw.id = sg.reward_id
+              WHERE sg.child_id = $1`
+	if !includeClosed {
+		query += ` AND sg.status IN ('active', 'reached')`
+	}
+	query += ` ORDER BY sg.created_at DESC, sg.id DESC`
+
+	rows, err := r.db.Query(ctx, query, childID)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error querying savings goals for child")
+		return nil, fmt.Errorf("error getting savings goals for child %d: %w", childID, err)
+	}
+	return collectSavingsGoalRows(rows)
+}
+
+// GetChildIDsWithReachableGoals mengambil ID anak yang memiliki target 'active' yang sudah tercapai
+// menurut saldo poin saat ini (poin yang disisihkan + saldo bebas >= target), dengan child_id > afterChildID
+// (keyset pagination untuk worker).
+func (r *savingsGoalRepo) GetChildIDsWithReachableGoals(ctx context.Context, afterChildID int, limit int) ([]int, error) {
+	query := `SELECT DISTINCT sg.child_id
+              FROM savings_goals sg
+              LEFT JOIN point_balances pb ON pb.user_id = sg.child_id
+              JOIN LATERAL (SELECT COALESCE(SUM(o.earmarked_points), 0) AS earmarked
+                            FROM savings_goals o
+                            WHERE o.child_id = sg.child_id AND o.status IN ('active', 'reached')) e ON TRUE
+              WHERE sg.status = $1
+                AND sg.child_id > $2
+                AND sg.earmarked_points + GREATEST(COALESCE(pb.balance, 0) - e.earmarked, 0) >= sg.target_points
+              ORDER BY sg.child_id
+              LIMIT $3`
+	rows, err := r.db.Query(ctx, query, models.SavingsGoalStatusActive, afterChildID, limit)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Error querying children with reachable savings goals")
+		return nil, fmt.Errorf("error getting children with reachable savings goals: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error scanning child ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating children with reachable savings goals: %w", err)
+	}
+	return ids, nil
+}
+
+// --- Metode Transaksional ---
+
+// GetGoalForUpdateTx mengambil target tabungan dan mengunci barisnya (FOR UPDATE).
+func (r *savingsGoalRepo) GetGoalForUpdateTx(ctx context.Context, tx pgx.Tx, id int) (*models.SavingsGoal, error) {
+	query := `SELECT ` + savingsGoalSelectColumns + `
+              FROM savings_goals sg
+              LEFT JOIN rewards rw ON rw.id = sg.reward_id
+              WHERE sg.id = $1
+              FOR UPDATE OF sg`
+	goal := &models.SavingsGoal{}
+	err := scanSavingsGoalRow(tx.QueryRow(ctx, query, id), goal)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("goal_id", id).Msg("RepoTx: Error locking savings goal")
+		return nil, fmt.Errorf("repoTx error getting savings goal %d: %w", id, err)
+	}
+	return goal, nil
+}
+
+// GetOpenGoalsByChildIDForUpdateTx mengambil dan mengunci semua target terbuka ('active'/'reached') milik anak.
+func (r *savingsGoalRepo) GetOpenGoalsByChildIDForUpdateTx(ctx context.Context, tx pgx.Tx, childID int) ([]models.SavingsGoal, error) {
+	query := `SELECT ` + savingsGoalSelectColumns + `
+              FROM savings_goals sg
+              LEFT JOIN rewards rw ON rw.id = sg.reward_id
+              WHERE sg.child_id = $1 AND sg.status IN ('active', 'reached')
+              ORDER BY sg.id
+              FOR UPDATE OF sg`
+	rows, err := tx.Query(ctx, query, childID)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("RepoTx: Error locking open savings goals")
+		return nil, fmt.Errorf("repoTx error getting open savings goals for child %d: %w", childID, err)
+	}
+	return collectSavingsGoalRows(rows)
+}
+
+// SumEarmarkedPointsTx menjumlahkan poin yang disisihkan pada target terbuka milik anak.
+// Target yang menyematkan excludeRewardID tidak dihitung (0 = hitung semua).
+func (r *savingsGoalRepo) SumEarmarkedPointsTx(ctx context.Context, tx pgx.Tx, childID int, excludeRewardID int) (int, error) {
+	query := `SELECT COALESCE(SUM(earmarked_points), 0) FROM savings_goals
+              WHERE child_id = $1 AND status IN ('active', 'reached')
+                AND ($2::INT IS NULL OR reward_id IS DISTINCT FROM $2)`
+	var total int
+	if err := tx.QueryRow(ctx, query, childID, nullableID(excludeRewardID)).Scan(&total); err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("RepoTx: Error summing earmarked points")
+		return 0, fmt.Errorf("repoTx error summing earmarked points for child %d: %w", childID, err)
+	}
+	return total, nil
+}
+
+// AdjustEarmarkTx menambah (delta positif) atau melepas (delta negatif) poin yang disisihkan pada target terbuka.
+func (r *savingsGoalRepo) AdjustEarmarkTx(ctx context.Context, tx pgx.Tx, id int, delta int) error {
+	query := `UPDATE savings_goals SET earmarked_points = earmarked_points + $1
+              WHERE id = $2 AND status IN ('active', 'reached')
+                AND earmarked_points + $1 BETWEEN 0 AND target_points`
+	tag, err := tx.Exec(ctx, query, delta, id)
+	if err != nil {
+		zlog.Error().Err(err).Int("goal_id", id).Int("delta", delta).Msg("RepoTx: Error adjusting earmarked points")
+		return fmt.Errorf("repoTx error adjusting earmark for savings goal %d: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("cannot earmark points: goal is closed or the amount is out of range")
+	}
+	return nil
+}
+
+// MarkReachedTx mengubah target 'active' menjadi 'reached'. Mengembalikan false jika status sudah berubah.
+func (r *savingsGoalRepo) MarkReachedTx(ctx context.Context, tx pgx.Tx, id int) (bool, error) {
+	query := `UPDATE savings_goals SET status = $1, reached_at = NOW()
+              WHERE id = $2 AND status = $3`
+	tag, err := tx.Exec(ctx, query, models.SavingsGoalStatusReached, id, models.SavingsGoalStatusActive)
+	if err != nil {
+		zlog.Error().Err(err).Int("goal_id", id).Msg("RepoTx: Error marking savings goal as reached")
+		return false, fmt.Errorf("repoTx error marking savings goal %d as reached: %w", id, err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// CancelGoalTx mengubah target terbuka menjadi 'cancelled' sehingga poin yang disisihkan dilepas.
+func (r *savingsGoalRepo) CancelGoalTx(ctx context.Context, tx pgx.Tx, id int) error {
+	query := `UPDATE savings_goals SET status = $1
+              WHERE id = $2 AND status IN ('active', 'reached')`
+	tag, err := tx.Exec(ctx, query, models.SavingsGoalStatusCancelled, id)
+	if err != nil {
+		zlog.Error().Err(err).Int("goal_id", id).Msg("RepoTx: Error cancelling savings goal")
+		return fmt.Errorf("repoTx error cancelling savings goal %d: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("cannot cancel savings goal: goal is already closed")
+	}
+	return nil
+}
+
+// MarkClaimedByRewardTx menutup target terbuka anak yang menyematkan rewardID dengan klaim hadiah yang baru dibuat.
+// Mengembalikan jumlah target yang ditutup.
+func (r *savingsGoalRepo) MarkClaimedByRewardTx(ctx context.Context, tx pgx.Tx, childID int, rewardID int, userRewardID int) (int64, error) {
+	query := `UPDATE savings_goals SET status = $1, claimed_user_reward_id = $2
+              WHERE child_id = $3 AND reward_id = $4 AND status IN ('active', 'reached')`
+	tag, err := tx.Exec(ctx, query, models.SavingsGoalStatusClaimed, userRewardID, childID, rewardID)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Int("reward_id", rewardID).Msg("RepoTx: Error closing savings goals for claimed reward")
+		return 0, fmt.Errorf("repoTx error closing savings goals for reward %d: %w", rewardID, err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// CreateContributionTx mencatat bonus poin dari parent untuk sebuah target.
+func (r *savingsGoalRepo) CreateContributionTx(ctx context.Context, tx pgx.Tx, contribution *models.SavingsGoalContribution) error {
+	query := `INSERT INTO savings_goal_contributions (goal_id, contributed_by_user_id, points, note)
+              VALUES ($1, $2, $3, NULLIF($4, ''))`
+	_, err := tx.Exec(ctx, query, contribution.GoalID, nullableID(contribution.ContributedByUserID), contribution.Points, contribution.Note)
+	if err != nil {
+		zlog.Error().Err(err).Int("goal_id", contribution.GoalID).Msg("RepoTx: Error creating savings goal contribution")
+		return fmt.Errorf("repoTx error creating contribution for savings goal %d: %w", contribution.GoalID, err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// internal/repository/statement_repo.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type statementRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewStatementRepository membuat instance baru dari StatementRepository.
+func NewStatementRepository(db *pgxpool.Pool) StatementRepository {
+	return &statementRepo{db: db}
+}
+
+// GetBalanceBefore menghitung saldo anak dari ledger (bukan tabel saldo) sampai sebelum waktu before.
+func (r *statementRepo) GetBalanceBefore(ctx context.Context, childID int, currencyID int, before time.Time) (int, error) {
+	query := `SELECT COALESCE(SUM(change_amount), 0)
+              FROM point_transactions
+              WHERE user_id = $1 AND COALESCE(currency_id, 0) = $2 AND created_at < $3`
+	var balance int
+	if err := r.db.QueryRow(ctx, query, childID, currencyID, before).Scan(&balance); err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Int("currency_id", currencyID).Msg("Error calculating opening balance")
+		return 0, fmt.Errorf("error calculating balance for child %d: %w", childID, err)
+	}
+	return balance, nil
+}
+
+// GetStatementLines mengambil transaksi anak dalam rentang [from, to), terlama dulu.
+func (r *statementRepo) GetStatementLines(ctx context.Context, childID int, currencyID int, from, to time.Time) ([]models.PointStatementLine, error) {
+	query := `SELECT pt.id, pt.created_at, pt.transaction_type, pt.change_amount, pt.notes,
+                     COALESCE(pt.related_user_task_id, 0), t.task_name,
+                     COALESCE(pt.related_user_reward_id, 0), rw.reward_name
+              FROM point_transactions pt
+              LEFT JOIN user_tasks ut ON ut.id = pt.related_user_task_id
+              LEFT JOIN tasks t ON t.id = ut.task_id
+              LEFT JOIN user_rewards ur ON ur.id = pt.related_user_reward_id
+              LEFT JOIN rewards rw ON rw.id = ur.reward_id
+              WHERE pt.user_id = $1 AND COALESCE(pt.currency_id, 0) = $2
+                AND pt.created_at >= $3 AND pt.created_at < $4
+              ORDER BY pt.created_at, pt.id`
+	rows, err := r.db.Query(ctx, query, childID, currencyID, from, to)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error querying statement lines")
+		return nil, fmt.Errorf("error getting statement lines for child %d: %w", childID, err)
+	}
+	defer rows.Close()
+
+	lines := []models.PointStatementLine{}
+	for rows.Next() {
+		var line models.PointStatementLine
+		var notes, taskName, rewardName sql.NullString
+		if err := rows.Scan(&line.TransactionID, &line.CreatedAt, &line.TransactionType, &line.ChangeAmount, &notes,
+			&line.UserTaskID, &taskName, &line.UserRewardID, &rewardName); err != nil {
+			zlog.Warn().Err(err).Int("child_id", childID).Msg("Error scanning statement line row")
+			return nil, fmt.Errorf("error scanning statement line: %w", err)
+		}
+		line.Notes = notes.String
+		line.TaskName = taskName.String
+		line.RewardName = rewardName.String
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating statement lines: %w", err)
+	}
+	return lines, nil
+}
@@ -0,0 +1,409 @@
+// internal/repository/streak_repo.go
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type streakRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewStreakRepository membuat instance baru dari StreakRepository.
+func NewStreakRepository(db *pgxpool.Pool) StreakRepository {
+	return &streakRepo{db: db}
+}
+
+// streakBonusRuleSelect memilih aturan bonus streak (alias r) beserta nama tugas terkait.
+const streakBonusRuleSelect = `SELECT r.id, r.created_by_user_id, r.name, COALESCE(r.task_id, 0), COALESCE(t.task_name, ''),
+                     r.streak_days, r.bonus_points, r.is_active, r.created_at, r.updated_at
+              FROM streak_bonus_rules r
+              LEFT JOIN tasks t ON t.id = r.task_id`
+
+const streakFreezePolicyColumns = `child_id, price_points, max_owned, COALESCE(updated_by_user_id, 0), created_at, updated_at`
+
+// scanStreakBonusRule memindai satu baris aturan bonus streak (urutan kolom streakBonusRuleSelect).
+func scanStreakBonusRule(row pgx.Row, rule *models.StreakBonusRule) error {
+	return row.Scan(&rule.ID, &rule.CreatedByUserID, &rule.Name, &rule.TaskID, &rule.TaskName,
+		&rule.StreakDays, &rule.BonusPoints, &rule.IsActive, &rule.CreatedAt, &rule.UpdatedAt)
+}
+
+// queryStreakBonusRules menjalankan query aturan bonus streak memakai pool atau transaksi.
+func queryStreakBonusRules(ctx context.Context, db rowQuerier, query string, args ...any) ([]models.StreakBonusRule, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	rules := []models.StreakBonusRule{}
+	for rows.Next() {
+		var rule models.StreakBonusRule
+		if err := scanStreakBonusRule(rows, &rule); err != nil {
+			return nil, fmt.Errorf("error scanning streak bonus rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating streak bonus rules: %w", err)
+	}
+	return rules, nil
+}
+
+// queryTimes menjalankan query satu kolom waktu/tanggal memakai pool atau transaksi.
+func queryTimes(ctx context.Context, db rowQuerier, query string, args ...any) ([]time.Time, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	times := []time.Time{}
+	for rows.Next() {
+		var t time.Time
+		if err := rows.Scan(&t); err != nil {
+			return nil, fmt.Errorf("error scanning time: %w", err)
+		}
+		times = append(times, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating times: %w", err)
+	}
+	return times, nil
+}
+
+// CreateBonusRule membuat aturan bonus streak baru milik parent dan mengisi ID serta timestamp.
+func (r *streakRepo) CreateBonusRule(ctx context.Context, rule *models.StreakBonusRule) error {
+	query := `INSERT INTO streak_bonus_rules (created_by_user_id, name, task_id, streak_days, bonus_points, is_active)
+              VALUES ($1, $2, $3, $4, $5, $6)
+              RETURNING id, created_at, updated_at`
+	err := r.db.QueryRow(ctx, query, rule.CreatedByUserID, rule.Name, nullableID(rule.TaskID), rule.StreakDays,
+		rule.BonusPoints, rule.IsActive).Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			return fmt.Errorf("streak bonus rule with this name already exists")
+		}
+		zlog.Error().Err(err).Int("parent_id", rule.CreatedByUserID).Msg("Error creating streak bonus rule")
+		return fmt.Errorf("error creating streak bonus rule: %w", err)
+	}
+	zlog.Info().Int("rule_id", rule.ID).Int("parent_id", rule.CreatedByUserID).Msg("Streak bonus rule created")
+	return nil
+}
+
+// GetBonusRulesByOwnerID mengambil semua aturan bonus streak milik parent, diurutkan berdasarkan nama.
+func (r *streakRepo) GetBonusRulesByOwnerID(ctx context.Context, parentID int) ([]models.StreakBonusRule, error) {
+	rules, err := queryStreakBonusRules(ctx, r.db, streakBonusRuleSelect+` WHERE r.created_by_user_id = $1 ORDER BY r.name`, parentID)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", parentID).Msg("Error getting streak bonus rules")
+		return nil, fmt.Errorf("error getting streak bonus rules for parent %d: %w", parentID, err)
+	}
+	return rules, nil
+}
+
+// GetBonusRuleByID mengambil aturan bonus streak berdasarkan ID. Mengembalikan pgx.ErrNoRows jika tidak ada.
+func (r *streakRepo) GetBonusRuleByID(ctx context.Context, ruleID int) (*models.StreakBonusRule, error) {
+	rule := &models.StreakBonusRule{}
+	if err := scanStreakBonusRule(r.db.QueryRow(ctx, streakBonusRuleSelect+` WHERE r.id = $1`, ruleID), rule); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("rule_id", ruleID).Msg("Error getting streak bonus rule")
+		return nil, fmt.Errorf("error getting streak bonus rule %d: %w", ruleID, err)
+	}
+	return rule, nil
+}
+
+// UpdateBonusRule memperbarui aturan bonus streak milik parent (rule.CreatedByUserID).
+// Mengembalikan pgx.ErrNoRows jika aturan tidak ditemukan.
+func (r *streakRepo) UpdateBonusRule(ctx context.Context, rule *models.StreakBonusRule) error {
+	query := `UPDATE streak_bonus_rules
+              SET name = $1, task_id = $2, streak_days = $3, bonus_points = $4, is_active = $5
+              WHERE id = $6 AND created_by_user_id = $7
+              RETURNING created_at, updated_at`
+	err := r.db.QueryRow(ctx, query, rule.Name, nullableID(rule.TaskID), rule.StreakDays, rule.BonusPoints, rule.IsActive,
+		rule.ID, rule.CreatedByUserID).Scan(&rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgx.ErrNoRows
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			return fmt.Errorf("streak bonus rule with this name already exists")
+		}
+		zlog.Error().Err(err).Int("rule_id", rule.ID).Msg("Error updating streak bonus rule")
+		return fmt.Errorf("error updating streak bonus rule %d: %w", rule.ID, err)
+	}
+	return nil
+}
+
+// DeleteBonusRule menghapus aturan bonus streak milik parent. Bonus yang sudah diberikan tetap ada di ledger.
+// Mengembalikan pgx.ErrNoRows jika aturan tidak ditemukan.
+func (r *streakRepo) DeleteBonusRule(ctx context.Context, ruleID int, parentID int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM streak_bonus_rules WHERE id = $1 AND created_by_user_id = $2`, ruleID, parentID)
+	if err != nil {
+		zlog.Error().Err(err).Int("rule_id", ruleID).Msg("Error deleting streak bonus rule")
+		return fmt.Errorf("error deleting streak bonus rule %d: %w", ruleID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	zlog.Info().Int("rule_id", ruleID).Int("parent_id", parentID).Msg("Streak bonus rule deleted")
+	return nil
+}
+
+// GetActiveBonusRulesForChildTx mengambil aturan bonus streak aktif milik orang tua anak yang berlaku
+// untuk tugas taskID (aturan semua tugas atau aturan tugas tersebut) dalam konteks transaksi.
+func (r *streakRepo) GetActiveBonusRulesForChildTx(ctx context.Context, tx pgx.Tx, childID int, taskID int) ([]models.StreakBonusRule, error) {
+	query := streakBonusRuleSelect + `
+              WHERE r.is_active
+                AND r.created_by_user_id IN (SELECT parent_id FROM user_relationship WHERE child_id = $1)
+                AND (r.task_id IS NULL OR r.task_id = $2)
+              ORDER BY r.streak_days, r.id`
+	rules, err := queryStreakBonusRules(ctx, tx, query, childID, taskID)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("RepoTx: Error getting streak bonus rules for child")
+		return nil, fmt.Errorf("repoTx error getting streak bonus rules for child %d: %w", childID, err)
+	}
+	return rules, nil
+}
+
+// HasBonusAwardSinceTx memeriksa apakah aturan sudah memberi bonus ke anak untuk streak yang dimulai
+// pada atau setelah tanggal since (yaitu streak yang sedang berjalan) dalam konteks transaksi.
+func (r *streakRepo) HasBonusAwardSinceTx(ctx context.Context, tx pgx.Tx, ruleID int, childID int, since time.Time) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM streak_bonus_awards
+                             WHERE rule_id = $1 AND child_id = $2 AND streak_start_date >= $3)`
+	if err := tx.QueryRow(ctx, query, ruleID, childID, since).Scan(&exists); err != nil {
+		zlog.Error().Err(err).Int("rule_id", ruleID).Int("child_id", childID).Msg("RepoTx: Error checking streak bonus award")
+		return false, fmt.Errorf("repoTx error checking streak bonus award for child %d: %w", childID, err)
+	}
+	return exists, nil
+}
+
+// CreateBonusAwardTx mencatat bonus streak yang diberikan dalam konteks transaksi dan mengisi ID serta awarded_at.
+func (r *streakRepo) CreateBonusAwardTx(ctx context.Context, tx pgx.Tx, award *models.StreakBonusAward) error {
+	query := `INSERT INTO streak_bonus_awards (rule_id, child_id, streak_start_date, streak_days, bonus_points, user_task_id, transaction_id)
+              VALUES ($1, $2, $3, $4, $5, $6, $7)
+              RETURNING id, awarded_at`
+	err := tx.QueryRow(ctx, query, award.RuleID, award.ChildID, award.StreakStartDate, award.StreakDays, award.BonusPoints,
+		nullableID(award.UserTaskID), nullableID(award.TransactionID)).Scan(&award.ID, &award.AwardedAt)
+	if err != nil {
+		zlog.Error().Err(err).Int("rule_id", award.RuleID).Int("child_id", award.ChildID).Msg("RepoTx: Error creating streak bonus award")
+		return fmt.Errorf("repoTx error creating streak bonus award for child %d: %w", award.ChildID, err)
+	}
+	return nil
+}
+
+// HasBonusAwardForUserTaskTx memeriksa apakah persetujuan tugas userTaskID memicu bonus streak dalam konteks transaksi.
+func (r *streakRepo) HasBonusAwardForUserTaskTx(ctx context.Context, tx pgx.Tx, userTaskID int) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM streak_bonus_awards WHERE user_task_id = $1)`
+	if err := tx.QueryRow(ctx, query, userTaskID).Scan(&exists); err != nil {
+		zlog.Error().Err(err).Int("user_task_id", userTaskID).Msg("RepoTx: Error checking streak bonus award for user task")
+		return false, fmt.Errorf("repoTx error checking streak bonus award for user task %d: %w", userTaskID, err)
+	}
+	return exists, nil
+}
+
+// GetApprovedCompletions mengambil penyelesaian tugas yang disetujui sejak waktu since beserta tugasnya.
+func (r *streakRepo) GetApprovedCompletions(ctx context.Context, childID int, since time.Time) ([]StreakCompletion, error) {
+	query := `SELECT ut.task_id, t.task_name, ut.completed_at
+              FROM user_tasks ut
+              JOIN tasks t ON t.id = ut.task_id
+              WHERE ut.user_id = $1 AND ut.status = $2 AND ut.completed_at >= $3
+              ORDER BY ut.completed_at`
+	rows, err := r.db.Query(ctx, query, childID, models.UserTaskStatusApproved, since)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error querying approved completions")
+		return nil, fmt.Errorf("error getting approved completions for child %d: %w", childID, err)
+	}
+	defer rows.Close()
+
+	completions := []StreakCompletion{}
+	for rows.Next() {
+		var completion StreakCompletion
+		if err := rows.Scan(&completion.TaskID, &completion.TaskName, &completion.CompletedAt); err != nil {
+			return nil, fmt.Errorf("error scanning approved completion: %w", err)
+		}
+		completions = append(completions, completion)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating approved completions: %w", err)
+	}
+	return completions, nil
+}
+
+// GetApprovedCompletionTimesTx mengambil waktu penyelesaian tugas yang disetujui sejak since
+// (taskID 0 = semua tugas) dalam konteks transaksi.
+func (r *streakRepo) GetApprovedCompletionTimesTx(ctx context.Context, tx pgx.Tx, childID int, taskID int, since time.Time) ([]time.Time, error) {
+	query := `SELECT completed_at FROM user_tasks
+              WHERE user_id = $1 AND status = $2 AND completed_at >= $3 AND ($4::INT IS NULL OR task_id = $4)`
+	times, err := queryTimes(ctx, tx, query, childID, models.UserTaskStatusApproved, since, nullableID(taskID))
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("RepoTx: Error querying approved completion times")
+		return nil, fmt.Errorf("repoTx error getting completion times for child %d: %w", childID, err)
+	}
+	return times, nil
+}
+
+// GetFrozenDays mengambil hari yang dilindungi streak freeze sejak tanggal since.
+func (r *streakRepo) GetFrozenDays(ctx context.Context, childID int, since time.Time) ([]time.Time, error) {
+	return r.getFrozenDays(ctx, r.db, childID, since)
+}
+
+// GetFrozenDaysTx sama seperti GetFrozenDays tetapi dijalankan dalam konteks transaksi.
+func (r *streakRepo) GetFrozenDaysTx(ctx context.Context, tx pgx.Tx, childID int, since time.Time) ([]time.Time, error) {
+	return r.getFrozenDays(ctx, tx, childID, since)
+}
+
+func (r *streakRepo) getFrozenDays(ctx context.Context, db rowQuerier, childID int, since time.Time) ([]time.Time, error) {
+	query := `SELECT used_on FROM streak_freezes WHERE child_id = $1 AND used_on >= $2`
+	days, err := queryTimes(ctx, db, query, childID, since)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error querying frozen days")
+		return nil, fmt.Errorf("error getting frozen days for child %d: %w", childID, err)
+	}
+	return days, nil
+}
+
+// UpsertFreezePolicy membuat atau memperbarui harga streak freeze anak.
+func (r *streakRepo) UpsertFreezePolicy(ctx context.Context, policy *models.StreakFreezePolicy) error {
+	query := `INSERT INTO streak_freeze_policies (child_id, price_points, max_owned, updated_by_user_id)
+              VALUES ($1, $2, $3, $4)
+              ON CONFLICT (child_id) DO UPDATE
+              SET price_points = EXCLUDED.price_points,
+                  max_owned = EXCLUDED.max_owned,
+                  updated_by_user_id = EXCLUDED.updated_by_user_id
+              RETURNING created_at, updated_at`
+	err := r.db.QueryRow(ctx, query, policy.ChildID, policy.PricePoints, policy.MaxOwned, nullableID(policy.UpdatedByUserID)).
+		Scan(&policy.CreatedAt, &policy.UpdatedAt)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", policy.ChildID).Msg("Error upserting streak freeze policy")
+		return fmt.Errorf("error saving streak freeze policy for child %d: %w", policy.ChildID, err)
+	}
+	zlog.Info().Int("child_id", policy.ChildID).Int("price_points", policy.PricePoints).Int("max_owned", policy.MaxOwned).
+		Msg("Streak freeze policy saved")
+	return nil
+}
+
+// GetFreezePolicyByChildID mengambil harga streak freeze anak. Mengembalikan pgx.ErrNoRows jika belum diatur.
+func (r *streakRepo) GetFreezePolicyByChildID(ctx context.Context, childID int) (*models.StreakFreezePolicy, error) {
+	query := `SELECT ` + streakFreezePolicyColumns + ` FROM streak_freeze_policies WHERE child_id = $1`
+	policy := &models.StreakFreezePolicy{}
+	err := r.db.QueryRow(ctx, query, childID).Scan(&policy.ChildID, &policy.PricePoints, &policy.MaxOwned,
+		&policy.UpdatedByUserID, &policy.CreatedAt, &policy.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error getting streak freeze policy")
+		return nil, fmt.Errorf("error getting streak freeze policy for child %d: %w", childID, err)
+	}
+	return policy, nil
+}
+
+// DeleteFreezePolicy menghapus harga streak freeze anak. Freeze yang sudah dibeli tetap bisa dipakai.
+// Mengembalikan pgx.ErrNoRows jika belum diatur.
+func (r *streakRepo) DeleteFreezePolicy(ctx context.Context, childID int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM streak_freeze_policies WHERE child_id = $1`, childID)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error deleting streak freeze policy")
+		return fmt.Errorf("error deleting streak freeze policy for child %d: %w", childID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// CountUnusedFreezes menghitung streak freeze anak yang belum terpakai.
+func (r *streakRepo) CountUnusedFreezes(ctx context.Context, childID int) (int, error) {
+	return r.countUnusedFreezes(ctx, r.db, childID)
+}
+
+// CountUnusedFreezesTx sama seperti CountUnusedFreezes tetapi dijalankan dalam konteks transaksi.
+func (r *streakRepo) CountUnusedFreezesTx(ctx context.Context, tx pgx.Tx, childID int) (int, error) {
+	return r.countUnusedFreezes(ctx, tx, childID)
+}
+
+func (r *streakRepo) countUnusedFreezes(ctx context.Context, db rowQuerier, childID int) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM streak_freezes WHERE child_id = $1 AND used_on IS NULL`
+	if err := db.QueryRow(ctx, query, childID).Scan(&count); err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error counting unused streak freezes")
+		return 0, fmt.Errorf("error counting unused streak freezes for child %d: %w", childID, err)
+	}
+	return count, nil
+}
+
+// CreateFreezeTx mencatat streak freeze yang dibeli dalam konteks transaksi dan mengisi ID serta purchased_at.
+func (r *streakRepo) CreateFreezeTx(ctx context.Context, tx pgx.Tx, freeze *models.StreakFreeze) error {
+	query := `INSERT INTO streak_freezes (child_id, price_points, transaction_id)
+              VALUES ($1, $2, $3)
+              RETURNING id, purchased_at`
+	err := tx.QueryRow(ctx, query, freeze.ChildID, freeze.PricePoints, nullableID(freeze.TransactionID)).
+		Scan(&freeze.ID, &freeze.PurchasedAt)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", freeze.ChildID).Msg("RepoTx: Error creating streak freeze")
+		return fmt.Errorf("repoTx error creating streak freeze for child %d: %w", freeze.ChildID, err)
+	}
+	return nil
+}
+
+// UseFreeze memakai streak freeze tertua anak yang belum terpakai untuk tanggal day.
+// Mengembalikan ID freeze yang dipakai, atau 0 jika tidak ada freeze tersisa atau hari tersebut sudah dilindungi.
+func (r *streakRepo) UseFreeze(ctx context.Context, childID int, day time.Time) (int, error) {
+	query := `UPDATE streak_freezes SET used_on = $2
+              WHERE id = (SELECT id FROM streak_freezes
+                          WHERE child_id = $1 AND used_on IS NULL
+                          ORDER BY purchased_at, id
+                          LIMIT 1
+                          FOR UPDATE SKIP LOCKED)
+              RETURNING id`
+	var freezeID int
+	if err := r.db.QueryRow(ctx, query, childID, day).Scan(&freezeID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			return 0, nil
+		}
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error using streak freeze")
+		return 0, fmt.Errorf("error using streak freeze for child %d: %w", childID, err)
+	}
+	zlog.Info().Int("freeze_id", freezeID).Int("child_id", childID).Time("used_on", day).Msg("Streak freeze used")
+	return freezeID, nil
+}
+
+// GetChildIDsWithUnusedFreezes mengambil ID anak (> afterID, urut naik) yang masih memiliki streak freeze belum terpakai.
+func (r *streakRepo) GetChildIDsWithUnusedFreezes(ctx context.Context, afterID int, limit int) ([]int, error) {
+	query := `SELECT DISTINCT child_id FROM streak_freezes
+              WHERE used_on IS NULL AND child_id > $1
+              ORDER BY child_id
+              LIMIT $2`
+	rows, err := r.db.Query(ctx, query, afterID, limit)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Error querying children with unused streak freezes")
+		return nil, fmt.Errorf("error getting children with unused streak freezes: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error scanning child ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating children with unused streak freezes: %w", err)
+	}
+	return ids, nil
+}
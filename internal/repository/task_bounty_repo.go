@@ -0,0 +1,323 @@
+// internal/repository/task_bounty_repo.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type taskBountyRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewTaskBountyRepository membuat instance baru dari TaskBountyRepository.
+func NewTaskBountyRepository(db *pgxpool.Pool) TaskBountyRepository {
+	return &taskBountyRepo{db: db}
+}
+
+// --- Helper Functions ---
+
+const bountySelectColumns = `tb.id, tb.task_id, tb.created_by_user_id, tb.max_claims, tb.claims_count,
+                tb.status, tb.expires_at, tb.created_at, tb.updated_at,
+                t.id, t.task_name, t.task_point, t.task_description, t.created_by_user_id`
+
+// scanBountyRow adalah helper untuk scan baris TaskBounty (termasuk data Task).
+func scanBountyRow(row pgx.Row, bounty *models.TaskBounty) error {
+	bounty.Task = &models.Task{}
+	var taskDescription sql.NullString
+	var expiresAt sql.NullTime
+	err := row.Scan(
+		&bounty.ID, &bounty.TaskID, &bounty.CreatedByUserID, &bounty.MaxClaims, &bounty.ClaimsCount,
+		&bounty.Status, &expiresAt, &bounty.CreatedAt, &bounty.UpdatedAt,
+		&bounty.Task.ID, &bounty.Task.TaskName, &bounty.Task.TaskPoint, &taskDescription, &bounty.Task.CreatedByUserID,
+	)
+	if err != nil {
+		return err
+	}
+	if taskDescription.Valid {
+		bounty.Task.TaskDescription = taskDescription.String
+	}
+	if expiresAt.Valid {
+		bounty.ExpiresAt = &expiresAt.Time
+	}
+	return nil
+}
+
+// collectBountyRows membaca seluruh baris hasil query bounty lalu menutup rows.
+func collectBountyRows(rows pgx.Rows) ([]models.TaskBounty, error) {
+	defer rows.Close()
+	bounties := []models.TaskBounty{}
+	for rows.Next() {
+		var bounty models.TaskBounty
+		if scanErr := scanBountyRow(rows, &bounty); scanErr != nil {
+			return nil, fmt.Errorf("error scanning task bounty data: %w", scanErr)
+		}
+		bounties = append(bounties, bounty)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating task bounties: %w", err)
+	}
+	return bounties, nil
+}
+
+// loadBountyClaims mengambil daftar klaim sebuah bounty terurut berdasarkan waktu klaim.
+func loadBountyClaims(ctx context.Context, q rowQuerier, bountyID int) ([]models.TaskBountyClaim, error) {
+	query := `SELECT c.id, c.bounty_id, c.child_id, u.username, c.user_task_id, c.claimed_at
+              FROM task_bounty_claims c
+              JOIN users u ON u.id = c.child_id
+              WHERE c.bounty_id = $1
+              ORDER BY c.claimed_at ASC, c.id ASC`
+	rows, err := q.Query(ctx, query, bountyID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying claims for bounty %d: %w", bountyID, err)
+	}
+	defer rows.Close()
+
+	claims := []models.TaskBountyClaim{}
+	for rows.Next() {
+		var claim models.TaskBountyClaim
+		var userTaskID sql.NullInt32
+		if scanErr := rows.Scan(&claim.ID, &claim.BountyID, &claim.ChildID, &claim.ChildUsername, &userTaskID, &claim.ClaimedAt); scanErr != nil {
+			return nil, fmt.Errorf("error scanning bounty claim: %w", scanErr)
+		}
+		if userTaskID.Valid {
+			claim.UserTaskID = int(userTaskID.Int32)
+		}
+		claims = append(claims, claim)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bounty claims: %w", err)
+	}
+	return claims, nil
+}
+
+// --- Repository Methods ---
+
+// CreateBounty menyimpan bounty baru dengan status 'open'.
+func (r *taskBountyRepo) CreateBounty(ctx context.Context, bounty *models.TaskBounty) (int, error) {
+	query := `INSERT INTO task_bounties (task_id, created_by_user_id, max_claims, claims_count, status, expires_at)
+              VALUES ($1, $2, $3, 0, $4, $5) RETURNING id`
+	var bountyID int
+	err := r.db.QueryRow(ctx, query, bounty.TaskID, bounty.CreatedByUserID, bounty.MaxClaims, models.BountyStatusOpen, bounty.ExpiresAt).Scan(&bountyID)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
+			zlog.Warn().Err(err).Int("task_id", bounty.TaskID).Msg("Foreign key violation on task bounty creation")
+			return 0, fmt.Errorf("invalid task or creator ID for bounty")
+		}
+		zlog.Error().Err(err).Int("task_id", bounty.TaskID).Msg("Error creating task bounty")
+		return 0, fmt.Errorf("error creating task bounty: %w", err)
+	}
+
+	zlog.Info().Int("bounty_id", bountyID).Int("task_id", bounty.TaskID).Int("max_claims", bounty.MaxClaims).Msg("Task bounty created successfully")
+	return bountyID, nil
+}
+
+// GetBountyByID mengambil detail bounty beserta definisi tugas dan daftar klaimnya.
+func (r *taskBountyRepo) GetBountyByID(ctx context.Context, id int) (*models.TaskBounty, error) {
+	query := `SELECT ` + bountySelectColumns + `
+              FROM task_bounties tb
+              JOIN tasks t ON t.id = tb.task_id
+              WHERE tb.id = $1`
+	bounty := &models.TaskBounty{}
+	err := scanBountyRow(r.db.QueryRow(ctx, query, id), bounty)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zlog.Warn().Int("bounty_id", id).Msg("Task bounty not found by ID")
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("bounty_id", id).Msg("Error getting task bounty by ID")
+		return nil, fmt.Errorf("error getting task bounty %d: %w", id, err)
+	}
+
+	bounty.Claims, err = loadBountyClaims(ctx, r.db, id)
+	if err != nil {
+		zlog.Error().Err(err).Int("bounty_id", id).Msg("Error loading task bounty claims")
+		return nil, err
+	}
+	return bounty, nil
+}
+
+// GetBountiesByCreatorID mengambil daftar bounty (paginated) yang diposting oleh parent tertentu.
+func (r *taskBountyRepo) GetBountiesByCreatorID(ctx context.Context, creatorID int, page, limit int) ([]models.TaskBounty, int, error) {
+	// 1. Hitung Total
+	countQuery := `SELECT COUNT(*) FROM task_bounties WHERE created_by_user_id = $1`
+	var totalCount int
+	if err := r.db.QueryRow(ctx, countQuery, creatorID).Scan(&totalCount); err != nil {
+		zlog.Error().Err(err).Int("creator_id", creatorID).Msg("Error counting task bounties by creator ID")
+		return nil, 0, fmt.Errorf("error counting task bounties for creator %d: %w", creatorID, err)
+	}
+	if totalCount == 0 {
+		return []models.TaskBounty{}, 0, nil
+	}
+
+	// 2. Hitung Offset
+	offset := (page - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	// 3. Query dengan Pagination
+	query := `SELECT ` + bountySelectColumns + `
+              FROM task_bounties tb
+              JOIN tasks t ON t.id = tb.task_id
+              WHERE tb.created_by_user_id = $1
+              ORDER BY tb.created_at DESC
+              LIMIT $2 OFFSET $3`
+	rows, err := r.db.Query(ctx, query, creatorID, limit, offset)
+	if err != nil {
+		zlog.Error().Err(err).Int("creator_id", creatorID).Msg("Error querying paginated task bounties")
+		return nil, totalCount, fmt.Errorf("error getting task bounties for creator %d: %w", creatorID, err)
+	}
+	bounties, err := collectBountyRows(rows)
+	if err != nil {
+		zlog.Error().Err(err).Int("creator_id", creatorID).Msg("Error reading task bounty rows")
+		return nil, totalCount, err
+	}
+
+	// 4. Muat klaim setiap bounty (rows sudah ditutup oleh collectBountyRows)
+	for i := range bounties {
+		claims, claimErr := loadBountyClaims(ctx, r.db, bounties[i].ID)
+		if claimErr != nil {
+			zlog.Error().Err(claimErr).Int("bounty_id", bounties[i].ID).Msg("Error loading task bounty claims")
+			return nil, totalCount, claimErr
+		}
+		bounties[i].Claims = claims
+	}
+
+	return bounties, totalCount, nil
+}
+
+// GetOpenBountiesForChild mengambil bounty (paginated) yang masih bisa diklaim oleh anak.
+func (r *taskBountyRepo) GetOpenBountiesForChild(ctx context.Context, childID int, page, limit int) ([]models.TaskBounty, int, error) {
+	// Bounty terlihat jika diposting oleh salah satu parent anak, masih open, belum kedaluwarsa,
+	// dan anak belum pernah mengklaimnya.
+	whereClause := `WHERE tb.status = 'open'
+                AND (tb.expires_at IS NULL OR tb.expires_at > NOW())
+                AND tb.created_by_user_id IN (SELECT parent_id FROM user_relationship WHERE child_id = $1)
+                AND NOT EXISTS (SELECT 1 FROM task_bounty_claims c WHERE c.bounty_id = tb.id AND c.child_id = $1)`
+
+	// 1. Hitung Total
+	countQuery := `SELECT COUNT(*) FROM task_bounties tb ` + whereClause
+	var totalCount int
+	if err := r.db.QueryRow(ctx, countQuery, childID).Scan(&totalCount); err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error counting open task bounties for child")
+		return nil, 0, fmt.Errorf("error counting open bounties for child %d: %w", childID, err)
+	}
+	if totalCount == 0 {
+		return []models.TaskBounty{}, 0, nil
+	}
+
+	// 2. Hitung Offset
+	offset := (page - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	// 3. Query dengan Pagination (bounty yang paling cepat kedaluwarsa ditampilkan lebih dulu)
+	query := `SELECT ` + bountySelectColumns + `
+              FROM task_bounties tb
+              JOIN tasks t ON t.id = tb.task_id
+              ` + whereClause + `
+              ORDER BY tb.expires_at ASC NULLS LAST, tb.created_at DESC
+              LIMIT $2 OFFSET $3`
+	rows, err := r.db.Query(ctx, query, childID, limit, offset)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error querying paginated open task bounties")
+		return nil, totalCount, fmt.Errorf("error getting open bounties for child %d: %w", childID, err)
+	}
+	bounties, err := collectBountyRows(rows)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Msg("Error reading open task bounty rows")
+		return nil, totalCount, err
+	}
+
+	return bounties, totalCount, nil
+}
+
+// CancelBounty membatalkan bounty yang masih 'open'. Klaim yang sudah terjadi tidak terpengaruh.
+func (r *taskBountyRepo) CancelBounty(ctx context.Context, id int) error {
+	query := `UPDATE task_bounties SET status = $1 WHERE id = $2 AND status = $3`
+	tag, err := r.db.Exec(ctx, query, models.BountyStatusCancelled, id, models.BountyStatusOpen)
+	if err != nil {
+		zlog.Error().Err(err).Int("bounty_id", id).Msg("Error cancelling task bounty")
+		return fmt.Errorf("error cancelling task bounty %d: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("cannot cancel bounty %d: bounty is not open", id)
+	}
+	zlog.Info().Int("bounty_id", id).Msg("Task bounty cancelled successfully")
+	return nil
+}
+
+// --- Metode Transaksional ---
+
+// GetBountyForUpdateTx mengambil dan mengunci bounty dalam transaksi.
+func (r *taskBountyRepo) GetBountyForUpdateTx(ctx context.Context, tx pgx.Tx, id int) (*models.TaskBounty, error) {
+	query := `SELECT ` + bountySelectColumns + `
+              FROM task_bounties tb
+              JOIN tasks t ON t.id = tb.task_id
+              WHERE tb.id = $1
+              FOR UPDATE OF tb` // Kunci baris bounty agar hanya satu klaim yang diproses pada satu waktu
+	bounty := &models.TaskBounty{}
+	err := scanBountyRow(tx.QueryRow(ctx, query, id), bounty)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("bounty_id", id).Msg("RepoTx: Error locking task bounty")
+		return nil, fmt.Errorf("repoTx error getting task bounty %d: %w", id, err)
+	}
+	return bounty, nil
+}
+
+// HasChildClaimedTx memeriksa apakah anak sudah pernah mengklaim bounty ini.
+func (r *taskBountyRepo) HasChildClaimedTx(ctx context.Context, tx pgx.Tx, bountyID int, childID int) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM task_bounty_claims WHERE bounty_id = $1 AND child_id = $2)`
+	var exists bool
+	if err := tx.QueryRow(ctx, query, bountyID, childID).Scan(&exists); err != nil {
+		zlog.Error().Err(err).Int("bounty_id", bountyID).Int("child_id", childID).Msg("RepoTx: Error checking existing bounty claim")
+		return false, fmt.Errorf("repoTx error checking bounty claim: %w", err)
+	}
+	return exists, nil
+}
+
+// CreateClaimTx mencatat klaim bounty dalam transaksi.
+func (r *taskBountyRepo) CreateClaimTx(ctx context.Context, tx pgx.Tx, bountyID int, childID int, userTaskID int) (int, error) {
+	query := `INSERT INTO task_bounty_claims (bounty_id, child_id, user_task_id, claimed_at)
+              VALUES ($1, $2, $3, NOW()) RETURNING id`
+	var claimID int
+	err := tx.QueryRow(ctx, query, bountyID, childID, userTaskID).Scan(&claimID)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			return 0, fmt.Errorf("bounty claim already exists for child %d", childID)
+		}
+		zlog.Error().Err(err).Int("bounty_id", bountyID).Int("child_id", childID).Msg("RepoTx: Error creating bounty claim")
+		return 0, fmt.Errorf("repoTx error creating bounty claim: %w", err)
+	}
+	return claimID, nil
+}
+
+// IncrementClaimsTx menambah jumlah klaim dan menandai bounty 'filled' jika batas klaim tercapai.
+func (r *taskBountyRepo) IncrementClaimsTx(ctx context.Context, tx pgx.Tx, id int) error {
+	query := `UPDATE task_bounties
+              SET claims_count = claims_count + 1,
+                  status = CASE WHEN claims_count + 1 >= max_claims THEN $1::bounty_status ELSE status END
+              WHERE id = $2`
+	tag, err := tx.Exec(ctx, query, models.BountyStatusFilled, id)
+	if err != nil {
+		zlog.Error().Err(err).Int("bounty_id", id).Msg("RepoTx: Error incrementing bounty claims")
+		return fmt.Errorf("repoTx error incrementing claims for bounty %d: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
@@ -17,6 +17,15 @@ type taskRepo struct {
 	db *pgxpool.Pool
 }
 
+// errTaskCurrencyNotFound dikembalikan jika currency_id task bukan mata uang milik pembuat task.
+var errTaskCurrencyNotFound = errors.New("invalid currency_id: currency not found")
+
+// isTaskCurrencyViolation memeriksa pelanggaran FK (currency_id, created_by_user_id) ke currencies.
+func isTaskCurrencyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "fk_task_currency"
+}
+
 // NewTaskRepository membuat instance baru dari TaskRepository.
 func NewTaskRepository(db *pgxpool.Pool) TaskRepository {
 	return &taskRepo{db: db}
@@ -24,17 +33,23 @@ func NewTaskRepository(db *pgxpool.Pool) TaskRepository {
 
 // CreateTask membuat definisi task baru di database.
 func (r *taskRepo) CreateTask(ctx context.Context, task *models.Task) (int, error) {
-	query := `INSERT INTO tasks (task_name, task_point, task_description, created_by_user_id)
-              VALUES ($1, $2, $3, $4) RETURNING id`
+	query := `INSERT INTO tasks (task_name, task_point, task_description, category, tags, created_by_user_id, currency_id)
+              VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
 	var taskID int
 	err := r.db.QueryRow(ctx, query,
 		task.TaskName,
 		task.TaskPoint,
 		task.TaskDescription,
+		nullableCategory(task.Category),
+		normalizeTags(task.Tags),
 		task.CreatedByUserID, // ID Parent yang membuat
+		nullableID(task.CurrencyID),
 	).Scan(&taskID)
 
 	if err != nil {
+		if isTaskCurrencyViolation(err) {
+			return 0, errTaskCurrencyNotFound
+		}
 		// Handle FK violation jika created_by_user_id tidak valid
 		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
 			zlog.Warn().Err(err).Int("creator_id", task.CreatedByUserID).Msg("Foreign key violation on task creation (creator not found?)")
@@ -49,20 +64,57 @@ func (r *taskRepo) CreateTask(ctx context.Context, task *models.Task) (int, erro
 	return taskID, nil
 }
 
+// CreateTaskTx membuat definisi task baru dalam transaksi (dipakai saat impor template).
+func (r *taskRepo) CreateTaskTx(ctx context.Context, tx pgx.Tx, task *models.Task) (int, error) {
+	query := `INSERT INTO tasks (task_name, task_point, task_description, category, tags, created_by_user_id,
+                                 source_template_id, source_template_version, currency_id)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+	var taskID int
+	err := tx.QueryRow(ctx, query,
+		task.TaskName,
+		task.TaskPoint,
+		task.TaskDescription,
+		nullableCategory(task.Category),
+		normalizeTags(task.Tags),
+		task.CreatedByUserID,
+		nullableID(task.SourceTemplateID),
+		nullableID(task.SourceTemplateVersion),
+		nullableID(task.CurrencyID),
+	).Scan(&taskID)
+	if err != nil {
+		if isTaskCurrencyViolation(err) {
+			return 0, errTaskCurrencyNotFound
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
+			zlog.Warn().Err(err).Int("creator_id", task.CreatedByUserID).Msg("RepoTx: Foreign key violation on task creation")
+			return 0, fmt.Errorf("invalid creator or source template for task definition")
+		}
+		zlog.Error().Err(err).Str("task_name", task.TaskName).Int("creator_id", task.CreatedByUserID).Msg("RepoTx: Error creating task definition")
+		return 0, fmt.Errorf("repoTx error creating task definition: %w", err)
+	}
+	return taskID, nil
+}
+
 // GetTaskByID mengambil detail definisi task berdasarkan ID-nya.
 // Memerlukan parentID untuk memvalidasi bahwa task tersebut dibuat oleh parent yang meminta.
 func (r *taskRepo) GetTaskByID(ctx context.Context, id int) (*models.Task, error) {
-	query := `SELECT id, task_name, task_point, task_description, created_by_user_id, created_at, updated_at
+	query := `SELECT id, task_name, task_point, task_description, category, tags, source_template_id, source_template_version, created_by_user_id, COALESCE(currency_id, 0), created_at, updated_at
               FROM tasks
               WHERE id = $1` // Validasi kepemilikan di query
 	task := &models.Task{}
-	var description sql.NullString
+	var description, category sql.NullString
+	var sourceTemplateID, sourceTemplateVersion sql.NullInt32
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&task.ID,
 		&task.TaskName,
 		&task.TaskPoint,
 		&description,
+		&category,
+		&task.Tags,
+		&sourceTemplateID,
+		&sourceTemplateVersion,
 		&task.CreatedByUserID,
+		&task.CurrencyID,
 		&task.CreatedAt,
 		&task.UpdatedAt,
 	)
@@ -83,16 +135,26 @@ func (r *taskRepo) GetTaskByID(ctx context.Context, id int) (*models.Task, error
 	} else {
 		task.TaskDescription = ""
 	}
+	if category.Valid {
+		task.Category = models.DefinitionCategory(category.String)
+	}
+	if sourceTemplateID.Valid {
+		task.SourceTemplateID = int(sourceTemplateID.Int32)
+		task.SourceTemplateVersion = int(sourceTemplateVersion.Int32)
+	}
 
 	return task, nil
 }
 
-// GetTasksByCreatorID mengambil daftar definisi task (paginated) yang dibuat oleh parent tertentu.
-func (r *taskRepo) GetTasksByCreatorID(ctx context.Context, creatorID int, page, limit int) ([]models.Task, int, error) {
-	// 1. Hitung Total Task untuk creator ini
-	countQuery := `SELECT COUNT(*) FROM tasks WHERE created_by_user_id = $1`
+// GetTasksByCreatorID mengambil daftar definisi task (paginated) yang dibuat oleh parent tertentu,
+// dengan filter pencarian opsional (teks, kategori, tag, rentang poin) dan pengurutan.
+func (r *taskRepo) GetTasksByCreatorID(ctx context.Context, creatorID int, filter *models.DefinitionFilter, page, limit int) ([]models.Task, int, error) {
+	filterClause, orderBy, args := buildDefinitionFilter(filter, taskDefinitionColumns, []any{creatorID})
+
+	// 1. Hitung Total Task untuk creator ini (setelah filter)
+	countQuery := `SELECT COUNT(*) FROM tasks WHERE created_by_user_id = $1` + filterClause
 	var totalCount int
-	err := r.db.QueryRow(ctx, countQuery, creatorID).Scan(&totalCount)
+	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&totalCount)
 	if err != nil {
 		zlog.Error().Err(err).Int("creator_id", creatorID).Msg("Error counting tasks by creator ID")
 		return nil, 0, fmt.Errorf("error counting tasks for creator %d: %w", creatorID, err)
@@ -108,14 +170,14 @@ func (r *taskRepo) GetTasksByCreatorID(ctx context.Context, creatorID int, page,
 		offset = 0
 	}
 
-	// 3. Query Task dengan Pagination
-	query := `SELECT id, task_name, task_point, task_description, created_by_user_id, created_at, updated_at
+	// 3. Query Task dengan Pagination (default: urutkan dari terbaru)
+	query := fmt.Sprintf(`SELECT id, task_name, task_point, task_description, category, tags, source_template_id, source_template_version, created_by_user_id, COALESCE(currency_id, 0), created_at, updated_at
 			FROM tasks
-			WHERE created_by_user_id = $1
-			ORDER BY created_at DESC -- Urutkan dari terbaru
-			LIMIT $2 OFFSET $3`
+			WHERE created_by_user_id = $1%s
+			ORDER BY %s
+			LIMIT $%d OFFSET $%d`, filterClause, orderBy, len(args)+1, len(args)+2)
 
-	rows, err := r.db.Query(ctx, query, creatorID, limit, offset)
+	rows, err := r.db.Query(ctx, query, append(args, limit, offset)...)
 	if err != nil {
 		zlog.Error().Err(err).Int("creator_id", creatorID).Msg("Error querying paginated tasks by creator ID")
 		return nil, totalCount, fmt.Errorf("error getting tasks for creator %d: %w", creatorID, err)
@@ -126,12 +188,19 @@ func (r *taskRepo) GetTasksByCreatorID(ctx context.Context, creatorID int, page,
 	tasks := []models.Task{}
 	for rows.Next() {
 		var task models.Task
+		var description, category sql.NullString
+		var sourceTemplateID, sourceTemplateVersion sql.NullInt32
 		scanErr := rows.Scan(
 			&task.ID,
 			&task.TaskName,
 			&task.TaskPoint,
-			&task.TaskDescription,
+			&description,
+			&category,
+			&task.Tags,
+			&sourceTemplateID,
+			&sourceTemplateVersion,
 			&task.CreatedByUserID,
+			&task.CurrencyID,
 			&task.CreatedAt,
 			&task.UpdatedAt,
 		)
@@ -139,6 +208,14 @@ func (r *taskRepo) GetTasksByCreatorID(ctx context.Context, creatorID int, page,
 			zlog.Warn().Err(scanErr).Int("creator_id", creatorID).Msg("Error scanning task row (paginated)")
 			return tasks, totalCount, fmt.Errorf("error scanning task data: %w", scanErr)
 		}
+		task.TaskDescription = description.String
+		if category.Valid {
+			task.Category = models.DefinitionCategory(category.String)
+		}
+		if sourceTemplateID.Valid {
+			task.SourceTemplateID = int(sourceTemplateID.Int32)
+			task.SourceTemplateVersion = int(sourceTemplateVersion.Int32)
+		}
 		tasks = append(tasks, task)
 	}
 
@@ -154,18 +231,24 @@ func (r *taskRepo) GetTasksByCreatorID(ctx context.Context, creatorID int, page,
 // Memerlukan parentID untuk memastikan hanya pembuat asli yang bisa mengedit.
 func (r *taskRepo) UpdateTask(ctx context.Context, task *models.Task, parentID int) error {
 	query := `UPDATE tasks
-              SET task_name = $1, task_point = $2, task_description = $3
-              WHERE id = $4 AND created_by_user_id = $5` // Validasi ID dan kepemilikan
+              SET task_name = $1, task_point = $2, task_description = $3, category = $4, tags = $5, currency_id = $8
+              WHERE id = $6 AND created_by_user_id = $7` // Validasi ID dan kepemilikan
 
 	tag, err := r.db.Exec(ctx, query,
 		task.TaskName,
 		task.TaskPoint,
 		task.TaskDescription,
+		nullableCategory(task.Category),
+		normalizeTags(task.Tags),
 		task.ID,  // ID task yang diupdate
 		parentID, // ID parent yang melakukan request
+		nullableID(task.CurrencyID),
 	)
 
 	if err != nil {
+		if isTaskCurrencyViolation(err) {
+			return errTaskCurrencyNotFound
+		}
 		// Error umum
 		zlog.Error().Err(err).Int("task_id", task.ID).Int("requesting_parent_id", parentID).Msg("Error updating task definition")
 		return fmt.Errorf("error updating task definition %d: %w", task.ID, err)
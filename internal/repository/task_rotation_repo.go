@@ -0,0 +1,394 @@
+// internal/repository/task_rotation_repo.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type taskRotationRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewTaskRotationRepository membuat instance baru dari TaskRotationRepository.
+func NewTaskRotationRepository(db *pgxpool.Pool) TaskRotationRepository {
+	return &taskRotationRepo{db: db}
+}
+
+// --- Helper Functions ---
+
+// rowQuerier adalah subset method yang dimiliki oleh *pgxpool.Pool maupun pgx.Tx,
+// agar helper bisa dipakai baik di dalam maupun di luar transaksi.
+type rowQuerier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+const rotationSelectColumns = `tr.id, tr.task_id, tr.created_by_user_id, tr.cadence, tr.current_position,
+                tr.next_rotation_at, tr.is_active, tr.created_at, tr.updated_at,
+                t.id, t.task_name, t.task_point, t.task_description, t.created_by_user_id`
+
+// scanRotationRow adalah helper untuk scan baris TaskRotation (termasuk data Task).
+func scanRotationRow(row pgx.Row, rotation *models.TaskRotation) error {
+	rotation.Task = &models.Task{}
+	var taskDescription sql.NullString
+	err := row.Scan(
+		&rotation.ID, &rotation.TaskID, &rotation.CreatedByUserID, &rotation.Cadence, &rotation.CurrentPosition,
+		&rotation.NextRotationAt, &rotation.IsActive, &rotation.CreatedAt, &rotation.UpdatedAt,
+		&rotation.Task.ID, &rotation.Task.TaskName, &rotation.Task.TaskPoint, &taskDescription, &rotation.Task.CreatedByUserID,
+	)
+	if err != nil {
+		return err
+	}
+	if taskDescription.Valid {
+		rotation.Task.TaskDescription = taskDescription.String
+	}
+	return nil
+}
+
+// loadRotationMembers mengambil anggota rotasi terurut berdasarkan posisi.
+func loadRotationMembers(ctx context.Context, q rowQuerier, rotationID int) ([]models.TaskRotationMember, error) {
+	query := `SELECT m.child_id, u.username, m.position
+              FROM task_rotation_members m
+              JOIN users u ON u.id = m.child_id
+              WHERE m.rotation_id = $1
+              ORDER BY m.position ASC`
+	rows, err := q.Query(ctx, query, rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying rotation members for rotation %d: %w", rotationID, err)
+	}
+	defer rows.Close()
+
+	members := []models.TaskRotationMember{}
+	for rows.Next() {
+		var member models.TaskRotationMember
+		if scanErr := rows.Scan(&member.ChildID, &member.Username, &member.Position); scanErr != nil {
+			return nil, fmt.Errorf("error scanning rotation member: %w", scanErr)
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rotation members: %w", err)
+	}
+	return members, nil
+}
+
+// --- Repository Methods ---
+
+// GetRotationByID mengambil detail rotasi beserta anggota dan definisi tugasnya.
+func (r *taskRotationRepo) GetRotationByID(ctx context.Context, id int) (*models.TaskRotation, error) {
+	query := `SELECT ` + rotationSelectColumns + `
+              FROM task_rotations tr
+              JOIN tasks t ON t.id = tr.task_id
+              WHERE tr.id = $1`
+	rotation := &models.TaskRotation{}
+	err := scanRotationRow(r.db.QueryRow(ctx, query, id), rotation)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zlog.Warn().Int("rotation_id", id).Msg("Task rotation not found by ID")
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("rotation_id", id).Msg("Error getting task rotation by ID")
+		return nil, fmt.Errorf("error getting task rotation %d: %w", id, err)
+	}
+
+	rotation.Members, err = loadRotationMembers(ctx, r.db, id)
+	if err != nil {
+		zlog.Error().Err(err).Int("rotation_id", id).Msg("Error loading task rotation members")
+		return nil, err
+	}
+	return rotation, nil
+}
+
+// GetRotationsByCreatorID mengambil daftar rotasi (paginated) yang dibuat oleh parent tertentu.
+func (r *taskRotationRepo) GetRotationsByCreatorID(ctx context.Context, creatorID int, page, limit int) ([]models.TaskRotation, int, error) {
+	// 1. Hitung Total
+	countQuery := `SELECT COUNT(*) FROM task_rotations WHERE created_by_user_id = $1`
+	var totalCount int
+	if err := r.db.QueryRow(ctx, countQuery, creatorID).Scan(&totalCount); err != nil {
+		zlog.Error().Err(err).Int("creator_id", creatorID).Msg("Error counting task rotations by creator ID")
+		return nil, 0, fmt.Errorf("error counting task rotations for creator %d: %w", creatorID, err)
+	}
+	if totalCount == 0 {
+		return []models.TaskRotation{}, 0, nil
+	}
+
+	// 2. Hitung Offset
+	offset := (page - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	// 3. Query dengan Pagination
+	query := `SELECT ` + rotationSelectColumns + `
+              FROM task_rotations tr
+              JOIN tasks t ON t.id = tr.task_id
+              WHERE tr.created_by_user_id = $1
+              ORDER BY tr.created_at DESC
+              LIMIT $2 OFFSET $3`
+	rows, err := r.db.Query(ctx, query, creatorID, limit, offset)
+	if err != nil {
+		zlog.Error().Err(err).Int("creator_id", creatorID).Msg("Error querying paginated task rotations")
+		return nil, totalCount, fmt.Errorf("error getting task rotations for creator %d: %w", creatorID, err)
+	}
+
+	rotations := []models.TaskRotation{}
+	for rows.Next() {
+		var rotation models.TaskRotation
+		if scanErr := scanRotationRow(rows, &rotation); scanErr != nil {
+			rows.Close()
+			zlog.Warn().Err(scanErr).Int("creator_id", creatorID).Msg("Error scanning task rotation row")
+			return nil, totalCount, fmt.Errorf("error scanning task rotation data: %w", scanErr)
+		}
+		rotations = append(rotations, rotation)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		zlog.Error().Err(err).Int("creator_id", creatorID).Msg("Error iterating task rotation rows")
+		return nil, totalCount, fmt.Errorf("error iterating task rotations: %w", err)
+	}
+
+	// 4. Muat anggota setiap rotasi (rows harus sudah ditutup sebelum query berikutnya)
+	for i := range rotations {
+		members, memberErr := loadRotationMembers(ctx, r.db, rotations[i].ID)
+		if memberErr != nil {
+			zlog.Error().Err(memberErr).Int("rotation_id", rotations[i].ID).Msg("Error loading task rotation members")
+			return nil, totalCount, memberErr
+		}
+		rotations[i].Members = members
+	}
+
+	return rotations, totalCount, nil
+}
+
+// GetTurnsByRotationID mengambil riwayat giliran (paginated) untuk sebuah rotasi.
+func (r *taskRotationRepo) GetTurnsByRotationID(ctx context.Context, rotationID int, page, limit int) ([]models.TaskRotationTurn, int, error) {
+	countQuery := `SELECT COUNT(*) FROM task_rotation_turns WHERE rotation_id = $1`
+	var totalCount int
+	if err := r.db.QueryRow(ctx, countQuery, rotationID).Scan(&totalCount); err != nil {
+		zlog.Error().Err(err).Int("rotation_id", rotationID).Msg("Error counting task rotation turns")
+		return nil, 0, fmt.Errorf("error counting turns for rotation %d: %w", rotationID, err)
+	}
+	if totalCount == 0 {
+		return []models.TaskRotationTurn{}, 0, nil
+	}
+
+	offset := (page - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := `SELECT rt.id, rt.rotation_id, rt.child_id, u.username, rt.user_task_id, rt.status,
+                     rt.notes, rt.created_by_user_id, rt.created_at
+              FROM task_rotation_turns rt
+              JOIN users u ON u.id = rt.child_id
+              WHERE rt.rotation_id = $1
+              ORDER BY rt.created_at DESC, rt.id DESC
+              LIMIT $2 OFFSET $3`
+	rows, err := r.db.Query(ctx, query, rotationID, limit, offset)
+	if err != nil {
+		zlog.Error().Err(err).Int("rotation_id", rotationID).Msg("Error querying paginated task rotation turns")
+		return nil, totalCount, fmt.Errorf("error getting turns for rotation %d: %w", rotationID, err)
+	}
+	defer rows.Close()
+
+	turns := []models.TaskRotationTurn{}
+	for rows.Next() {
+		var turn models.TaskRotationTurn
+		var userTaskID, createdByUserID sql.NullInt32
+		var notes sql.NullString
+		scanErr := rows.Scan(
+			&turn.ID, &turn.RotationID, &turn.ChildID, &turn.ChildUsername, &userTaskID, &turn.Status,
+			&notes, &createdByUserID, &turn.CreatedAt,
+		)
+		if scanErr != nil {
+			zlog.Warn().Err(scanErr).Int("rotation_id", rotationID).Msg("Error scanning task rotation turn row")
+			return turns, totalCount, fmt.Errorf("error scanning rotation turn data: %w", scanErr)
+		}
+		if userTaskID.Valid {
+			turn.UserTaskID = int(userTaskID.Int32)
+		}
+		if createdByUserID.Valid {
+			turn.CreatedByUserID = int(createdByUserID.Int32)
+		}
+		if notes.Valid {
+			turn.Notes = notes.String
+		}
+		turns = append(turns, turn)
+	}
+	if err := rows.Err(); err != nil {
+		zlog.Error().Err(err).Int("rotation_id", rotationID).Msg("Error iterating task rotation turn rows")
+		return turns, totalCount, fmt.Errorf("error iterating rotation turns: %w", err)
+	}
+
+	return turns, totalCount, nil
+}
+
+// GetDueRotationIDs mengambil ID rotasi aktif yang jadwal gilirannya sudah tiba.
+func (r *taskRotationRepo) GetDueRotationIDs(ctx context.Context, now time.Time) ([]int, error) {
+	query := `SELECT id FROM task_rotations
+              WHERE is_active = TRUE AND next_rotation_at <= $1
+              ORDER BY next_rotation_at ASC`
+	rows, err := r.db.Query(ctx, query, now)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Error querying due task rotations")
+		return nil, fmt.Errorf("error getting due task rotations: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if scanErr := rows.Scan(&id); scanErr != nil {
+			return nil, fmt.Errorf("error scanning due rotation id: %w", scanErr)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating due rotations: %w", err)
+	}
+	return ids, nil
+}
+
+// DeleteRotation menghapus rotasi (anggota dan riwayat ikut terhapus via ON DELETE CASCADE).
+func (r *taskRotationRepo) DeleteRotation(ctx context.Context, id int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM task_rotations WHERE id = $1`, id)
+	if err != nil {
+		zlog.Error().Err(err).Int("rotation_id", id).Msg("Error deleting task rotation")
+		return fmt.Errorf("error deleting task rotation %d: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	zlog.Info().Int("rotation_id", id).Msg("Task rotation deleted successfully")
+	return nil
+}
+
+// --- Metode Transaksional ---
+
+// CreateRotationTx membuat rotasi dan anggotanya dalam konteks transaksi.
+func (r *taskRotationRepo) CreateRotationTx(ctx context.Context, tx pgx.Tx, rotation *models.TaskRotation, childIDs []int) (int, error) {
+	query := `INSERT INTO task_rotations (task_id, created_by_user_id, cadence, current_position, next_rotation_at, is_active)
+              VALUES ($1, $2, $3, 0, $4, TRUE) RETURNING id`
+	var rotationID int
+	err := tx.QueryRow(ctx, query, rotation.TaskID, rotation.CreatedByUserID, rotation.Cadence, rotation.NextRotationAt).Scan(&rotationID)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
+			zlog.Warn().Err(err).Int("task_id", rotation.TaskID).Msg("RepoTx: Foreign key violation on task rotation creation")
+			return 0, fmt.Errorf("invalid task or creator ID for rotation")
+		}
+		zlog.Error().Err(err).Int("task_id", rotation.TaskID).Msg("RepoTx: Error creating task rotation")
+		return 0, fmt.Errorf("repoTx error creating task rotation: %w", err)
+	}
+
+	memberQuery := `INSERT INTO task_rotation_members (rotation_id, child_id, position) VALUES ($1, $2, $3)`
+	for position, childID := range childIDs {
+		if _, err := tx.Exec(ctx, memberQuery, rotationID, childID, position); err != nil {
+			if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+				return 0, fmt.Errorf("child %d already exists in this rotation", childID)
+			}
+			if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
+				return 0, fmt.Errorf("invalid child ID %d for rotation", childID)
+			}
+			zlog.Error().Err(err).Int("rotation_id", rotationID).Int("child_id", childID).Msg("RepoTx: Error adding task rotation member")
+			return 0, fmt.Errorf("repoTx error adding rotation member: %w", err)
+		}
+	}
+
+	zlog.Info().Int("rotation_id", rotationID).Int("task_id", rotation.TaskID).Int("member_count", len(childIDs)).Msg("RepoTx: Task rotation created successfully")
+	return rotationID, nil
+}
+
+// GetRotationForUpdateTx mengambil dan mengunci rotasi (beserta anggotanya) dalam transaksi.
+func (r *taskRotationRepo) GetRotationForUpdateTx(ctx context.Context, tx pgx.Tx, id int) (*models.TaskRotation, error) {
+	query := `SELECT ` + rotationSelectColumns + `
+              FROM task_rotations tr
+              JOIN tasks t ON t.id = tr.task_id
+              WHERE tr.id = $1
+              FOR UPDATE OF tr` // Kunci baris rotasi agar scheduler & parent tidak memproses giliran bersamaan
+	rotation := &models.TaskRotation{}
+	err := scanRotationRow(tx.QueryRow(ctx, query, id), rotation)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("rotation_id", id).Msg("RepoTx: Error locking task rotation")
+		return nil, fmt.Errorf("repoTx error getting task rotation %d: %w", id, err)
+	}
+
+	rotation.Members, err = loadRotationMembers(ctx, tx, id)
+	if err != nil {
+		zlog.Error().Err(err).Int("rotation_id", id).Msg("RepoTx: Error loading task rotation members")
+		return nil, err
+	}
+	return rotation, nil
+}
+
+// UpdateRotationStateTx memperbarui posisi dan jadwal rotasi dalam transaksi.
+func (r *taskRotationRepo) UpdateRotationStateTx(ctx context.Context, tx pgx.Tx, id int, currentPosition int, nextRotationAt time.Time) error {
+	query := `UPDATE task_rotations SET current_position = $1, next_rotation_at = $2 WHERE id = $3`
+	tag, err := tx.Exec(ctx, query, currentPosition, nextRotationAt, id)
+	if err != nil {
+		zlog.Error().Err(err).Int("rotation_id", id).Msg("RepoTx: Error updating task rotation state")
+		return fmt.Errorf("repoTx error updating task rotation %d: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// SwapMemberPositionsTx menukar posisi dua anggota rotasi dalam transaksi.
+// Constraint unique_rotation_position bersifat DEFERRED sehingga pertukaran aman dalam satu statement.
+func (r *taskRotationRepo) SwapMemberPositionsTx(ctx context.Context, tx pgx.Tx, rotationID int, firstChildID int, secondChildID int) error {
+	query := `UPDATE task_rotation_members m
+              SET position = other.position
+              FROM task_rotation_members other
+              WHERE m.rotation_id = $1 AND other.rotation_id = $1
+                AND ((m.child_id = $2 AND other.child_id = $3) OR (m.child_id = $3 AND other.child_id = $2))`
+	tag, err := tx.Exec(ctx, query, rotationID, firstChildID, secondChildID)
+	if err != nil {
+		zlog.Error().Err(err).Int("rotation_id", rotationID).Msg("RepoTx: Error swapping task rotation members")
+		return fmt.Errorf("repoTx error swapping rotation members: %w", err)
+	}
+	if tag.RowsAffected() != 2 {
+		// Salah satu anak bukan anggota rotasi
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// CreateTurnTx mencatat giliran rotasi dalam transaksi.
+func (r *taskRotationRepo) CreateTurnTx(ctx context.Context, tx pgx.Tx, turn *models.TaskRotationTurn) (int, error) {
+	query := `INSERT INTO task_rotation_turns (rotation_id, child_id, user_task_id, status, notes, created_by_user_id)
+              VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+
+	var userTaskID, createdByUserID sql.NullInt32
+	if turn.UserTaskID > 0 {
+		userTaskID = sql.NullInt32{Int32: int32(turn.UserTaskID), Valid: true}
+	}
+	if turn.CreatedByUserID > 0 {
+		createdByUserID = sql.NullInt32{Int32: int32(turn.CreatedByUserID), Valid: true}
+	}
+	var notes sql.NullString
+	if turn.Notes != "" {
+		notes = sql.NullString{String: turn.Notes, Valid: true}
+	}
+
+	var turnID int
+	err := tx.QueryRow(ctx, query, turn.RotationID, turn.ChildID, userTaskID, turn.Status, notes, createdByUserID).Scan(&turnID)
+	if err != nil {
+		zlog.Error().Err(err).Int("rotation_id", turn.RotationID).Int("child_id", turn.ChildID).Msg("RepoTx: Error creating task rotation turn")
+		return 0, fmt.Errorf("repoTx error creating rotation turn: %w", err)
+	}
+	return turnID, nil
+}
@@ -0,0 +1,357 @@
+// internal/repository/template_repo.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type templateRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewTemplateRepository membuat instance baru dari TemplateRepository.
+func NewTemplateRepository(db *pgxpool.Pool) TemplateRepository {
+	return &templateRepo{db: db}
+}
+
+// --- Helper Functions ---
+
+// templateSelectColumns memilih kolom template beserta terjemahan terbaik untuk locale $1:
+// locale yang diminta, lalu DefaultTemplateLocale, lalu terjemahan apa pun.
+const templateSelectColumns = `dt.id, dt.kind, dt.category, dt.tags, dt.suggested_points, dt.min_age, dt.max_age,
+                dt.is_active, dt.version, dt.created_by_user_id, dt.created_at, dt.updated_at,
+                tr.locale, tr.name, tr.description`
+
+const templateTranslationJoin = `LEFT JOIN LATERAL (
+                    SELECT locale, name, description
+                    FROM definition_template_translations
+                    WHERE template_id = dt.id
+                    ORDER BY (locale = $1) DESC, (locale = '` + models.DefaultTemplateLocale + `') DESC, locale ASC
+                    LIMIT 1
+                ) tr ON TRUE`
+
+// scanTemplateRow adalah helper untuk scan baris DefinitionTemplate (dengan terjemahan terpilih).
+func scanTemplateRow(row pgx.Row, template *models.DefinitionTemplate) error {
+	var category, locale, name, description sql.NullString
+	var minAge, maxAge, createdBy sql.NullInt32
+	err := row.Scan(
+		&template.ID, &template.Kind, &category, &template.Tags, &template.SuggestedPoints, &minAge, &maxAge,
+		&template.IsActive, &template.Version, &createdBy, &template.CreatedAt, &template.UpdatedAt,
+		&locale, &name, &description,
+	)
+	if err != nil {
+		return err
+	}
+	if category.Valid {
+		template.Category = models.DefinitionCategory(category.String)
+	}
+	if minAge.Valid {
+		v := int(minAge.Int32)
+		template.MinAge = &v
+	}
+	if maxAge.Valid {
+		v := int(maxAge.Int32)
+		template.MaxAge = &v
+	}
+	if createdBy.Valid {
+		template.CreatedByUserID = int(createdBy.Int32)
+	}
+	template.Locale = locale.String
+	template.Name = name.String
+	template.Description = description.String
+	return nil
+}
+
+// normalizeLocale mengembalikan locale huruf kecil, atau DefaultTemplateLocale jika kosong.
+func normalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if locale == "" {
+		return models.DefaultTemplateLocale
+	}
+	return locale
+}
+
+// nullableInt mengubah pointer int menjadi nilai untuk kolom nullable.
+func nullableInt(v *int) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+// insertTemplateTranslationsTx menyimpan semua terjemahan template dalam transaksi.
+func insertTemplateTranslationsTx(ctx context.Context, tx pgx.Tx, templateID int, translations []models.DefinitionTemplateTranslation) error {
+	query := `INSERT INTO definition_template_translations (template_id, locale, name, description)
+              VALUES ($1, $2, $3, $4)
+              ON CONFLICT (template_id, locale) DO UPDATE SET name = EXCLUDED.name, description = EXCLUDED.description`
+	for _, translation := range translations {
+		_, err := tx.Exec(ctx, query, templateID, normalizeLocale(translation.Locale), translation.Name, translation.Description)
+		if err != nil {
+			zlog.Error().Err(err).Int("template_id", templateID).Str("locale", translation.Locale).Msg("RepoTx: Error saving template translation")
+			return fmt.Errorf("repoTx error saving template translation: %w", err)
+		}
+	}
+	return nil
+}
+
+// --- Repository Methods ---
+
+// GetTemplateByID mengambil template beserta semua terjemahannya.
+func (r *templateRepo) GetTemplateByID(ctx context.Context, id int, locale string) (*models.DefinitionTemplate, error) {
+	query := `SELECT ` + templateSelectColumns + `
+              FROM definition_templates dt
+              ` + templateTranslationJoin + `
+              WHERE dt.id = $2`
+	template := &models.DefinitionTemplate{}
+	err := scanTemplateRow(r.db.QueryRow(ctx, query, normalizeLocale(locale), id), template)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			zlog.Warn().Int("template_id", id).Msg("Definition template not found by ID")
+			return nil, pgx.ErrNoRows
+		}
+		zlog.Error().Err(err).Int("template_id", id).Msg("Error getting definition template by ID")
+		return nil, fmt.Errorf("error getting definition template %d: %w", id, err)
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT locale, name, description FROM definition_template_translations
+                                  WHERE template_id = $1 ORDER BY locale ASC`, id)
+	if err != nil {
+		zlog.Error().Err(err).Int("template_id", id).Msg("Error querying definition template translations")
+		return nil, fmt.Errorf("error getting translations for template %d: %w", id, err)
+	}
+	defer rows.Close()
+
+	template.Translations = []models.DefinitionTemplateTranslation{}
+	for rows.Next() {
+		var translation models.DefinitionTemplateTranslation
+		var description sql.NullString
+		if scanErr := rows.Scan(&translation.Locale, &translation.Name, &description); scanErr != nil {
+			return nil, fmt.Errorf("error scanning template translation: %w", scanErr)
+		}
+		translation.Description = description.String
+		template.Translations = append(template.Translations, translation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating template translations: %w", err)
+	}
+	return template, nil
+}
+
+// ListTemplates mengambil daftar template (paginated) sesuai filter.
+func (r *templateRepo) ListTemplates(ctx context.Context, filter *models.TemplateFilter, includeInactive bool, page, limit int) ([]models.DefinitionTemplate, int, error) {
+	if filter == nil {
+		filter = &models.TemplateFilter{}
+	}
+	// $1 selalu locale (dipakai oleh join terjemahan)
+	args := []any{normalizeLocale(filter.Locale)}
+	conditions := []string{"TRUE"}
+	if !includeInactive {
+		conditions = append(conditions, "dt.is_active = TRUE")
+	}
+	if filter.Kind != "" {
+		args = append(args, filter.Kind)
+		conditions = append(conditions, fmt.Sprintf("dt.kind = $%d::template_kind", len(args)))
+	}
+	if filter.Category != "" {
+		args = append(args, filter.Category)
+		conditions = append(conditions, fmt.Sprintf("dt.category = $%d::definition_category", len(args)))
+	}
+	if filter.Age != nil {
+		args = append(args, *filter.Age)
+		conditions = append(conditions, fmt.Sprintf("(dt.min_age IS NULL OR dt.min_age <= $%d) AND (dt.max_age IS NULL OR dt.max_age >= $%d)", len(args), len(args)))
+	}
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	// 1. Hitung Total ($1 tidak dipakai di query count, jadi diberi ekspresi netral agar placeholder tetap berurutan)
+	countQuery := `SELECT COUNT(*) FROM definition_templates dt ` + whereClause + ` AND $1::text IS NOT NULL`
+	var totalCount int
+	if err := r.db.QueryRow(ctx, countQuery, args...).Scan(&totalCount); err != nil {
+		zlog.Error().Err(err).Msg("Error counting definition templates")
+		return nil, 0, fmt.Errorf("error counting definition templates: %w", err)
+	}
+	if totalCount == 0 {
+		return []models.DefinitionTemplate{}, 0, nil
+	}
+
+	// 2. Hitung Offset
+	offset := (page - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	// 3. Query dengan Pagination
+	query := fmt.Sprintf(`SELECT `+templateSelectColumns+`
+              FROM definition_templates dt
+              `+templateTranslationJoin+`
+              %s
+              ORDER BY dt.kind ASC, dt.category ASC NULLS LAST, dt.suggested_points ASC, dt.id ASC
+              LIMIT $%d OFFSET $%d`, whereClause, len(args)+1, len(args)+2)
+	rows, err := r.db.Query(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		zlog.Error().Err(err).Msg("Error querying paginated definition templates")
+		return nil, totalCount, fmt.Errorf("error getting definition templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []models.DefinitionTemplate{}
+	for rows.Next() {
+		var template models.DefinitionTemplate
+		if scanErr := scanTemplateRow(rows, &template); scanErr != nil {
+			zlog.Warn().Err(scanErr).Msg("Error scanning definition template row")
+			return templates, totalCount, fmt.Errorf("error scanning definition template data: %w", scanErr)
+		}
+		templates = append(templates, template)
+	}
+	if err := rows.Err(); err != nil {
+		zlog.Error().Err(err).Msg("Error iterating definition template rows")
+		return templates, totalCount, fmt.Errorf("error iterating definition templates: %w", err)
+	}
+	return templates, totalCount, nil
+}
+
+// DeleteTemplate menghapus template (terjemahan ikut terhapus via ON DELETE CASCADE).
+func (r *templateRepo) DeleteTemplate(ctx context.Context, id int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM definition_templates WHERE id = $1`, id)
+	if err != nil {
+		zlog.Error().Err(err).Int("template_id", id).Msg("Error deleting definition template")
+		return fmt.Errorf("error deleting definition template %d: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	zlog.Info().Int("template_id", id).Msg("Definition template deleted successfully")
+	return nil
+}
+
+// GetUpdateSuggestions mengambil definisi milik parent yang template sumbernya sudah diperbarui.
+func (r *templateRepo) GetUpdateSuggestions(ctx context.Context, parentID int, locale string) ([]models.TemplateUpdateSuggestion, error) {
+	query := `SELECT d.kind, d.definition_id, d.definition_name, dt.id, d.imported_version, dt.version,
+                     COALESCE(tr.name, ''), COALESCE(tr.description, ''), dt.suggested_points
+              FROM (
+                  SELECT 'task' AS kind, id AS definition_id, task_name AS definition_name,
+                         source_template_id, COALESCE(source_template_version, 0) AS imported_version
+                  FROM tasks WHERE created_by_user_id = $2 AND source_template_id IS NOT NULL
+                  UNION ALL
+                  SELECT 'reward', id, reward_name, source_template_id, COALESCE(source_template_version, 0)
+                  FROM rewards WHERE created_by_user_id = $2 AND source_template_id IS NOT NULL
+              ) d
+              JOIN definition_templates dt ON dt.id = d.source_template_id
+              ` + templateTranslationJoin + `
+              WHERE dt.version > d.imported_version AND dt.is_active = TRUE
+              ORDER BY d.kind ASC, d.definition_id ASC`
+	rows, err := r.db.Query(ctx, query, normalizeLocale(locale), parentID)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", parentID).Msg("Error querying template update suggestions")
+		return nil, fmt.Errorf("error getting template update suggestions for parent %d: %w", parentID, err)
+	}
+	defer rows.Close()
+
+	suggestions := []models.TemplateUpdateSuggestion{}
+	for rows.Next() {
+		var suggestion models.TemplateUpdateSuggestion
+		scanErr := rows.Scan(
+			&suggestion.Kind, &suggestion.DefinitionID, &suggestion.DefinitionName, &suggestion.TemplateID,
+			&suggestion.ImportedVersion, &suggestion.LatestVersion,
+			&suggestion.Name, &suggestion.Description, &suggestion.SuggestedPoints,
+		)
+		if scanErr != nil {
+			return nil, fmt.Errorf("error scanning template update suggestion: %w", scanErr)
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating template update suggestions: %w", err)
+	}
+	return suggestions, nil
+}
+
+// --- Metode Transaksional ---
+
+// CreateTemplateTx membuat template beserta terjemahannya dalam transaksi.
+func (r *templateRepo) CreateTemplateTx(ctx context.Context, tx pgx.Tx, template *models.DefinitionTemplate) (int, error) {
+	query := `INSERT INTO definition_templates (kind, category, tags, suggested_points, min_age, max_age, is_active, version, created_by_user_id)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, 1, $8) RETURNING id`
+	var templateID int
+	err := tx.QueryRow(ctx, query,
+		template.Kind, nullableCategory(template.Category), normalizeTags(template.Tags), template.SuggestedPoints,
+		nullableInt(template.MinAge), nullableInt(template.MaxAge), template.IsActive, nullableID(template.CreatedByUserID),
+	).Scan(&templateID)
+	if err != nil {
+		zlog.Error().Err(err).Str("kind", string(template.Kind)).Msg("RepoTx: Error creating definition template")
+		return 0, fmt.Errorf("repoTx error creating definition template: %w", err)
+	}
+
+	if err := insertTemplateTranslationsTx(ctx, tx, templateID, template.Translations); err != nil {
+		return 0, err
+	}
+
+	zlog.Info().Int("template_id", templateID).Str("kind", string(template.Kind)).Msg("RepoTx: Definition template created successfully")
+	return templateID, nil
+}
+
+// UpdateTemplateTx memperbarui template, mengganti terjemahannya, dan menaikkan versinya.
+func (r *templateRepo) UpdateTemplateTx(ctx context.Context, tx pgx.Tx, template *models.DefinitionTemplate) error {
+	query := `UPDATE definition_templates
+              SET kind = $1, category = $2, tags = $3, suggested_points = $4, min_age = $5, max_age = $6,
+                  is_active = $7, version = version + 1
+              WHERE id = $8`
+	tag, err := tx.Exec(ctx, query,
+		template.Kind, nullableCategory(template.Category), normalizeTags(template.Tags), template.SuggestedPoints,
+		nullableInt(template.MinAge), nullableInt(template.MaxAge), template.IsActive, template.ID,
+	)
+	if err != nil {
+		zlog.Error().Err(err).Int("template_id", template.ID).Msg("RepoTx: Error updating definition template")
+		return fmt.Errorf("repoTx error updating definition template %d: %w", template.ID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM definition_template_translations WHERE template_id = $1`, template.ID); err != nil {
+		zlog.Error().Err(err).Int("template_id", template.ID).Msg("RepoTx: Error clearing template translations")
+		return fmt.Errorf("repoTx error clearing template translations: %w", err)
+	}
+	if err := insertTemplateTranslationsTx(ctx, tx, template.ID, template.Translations); err != nil {
+		return err
+	}
+
+	zlog.Info().Int("template_id", template.ID).Msg("RepoTx: Definition template updated successfully")
+	return nil
+}
+
+// GetActiveTemplatesByIDsTx mengambil template aktif berdasarkan daftar ID dalam transaksi.
+func (r *templateRepo) GetActiveTemplatesByIDsTx(ctx context.Context, tx pgx.Tx, ids []int, locale string) ([]models.DefinitionTemplate, error) {
+	query := `SELECT ` + templateSelectColumns + `
+              FROM definition_templates dt
+              ` + templateTranslationJoin + `
+              WHERE dt.id = ANY($2::int[]) AND dt.is_active = TRUE
+              ORDER BY dt.id ASC`
+	rows, err := tx.Query(ctx, query, normalizeLocale(locale), ids)
+	if err != nil {
+		zlog.Error().Err(err).Ints("template_ids", ids).Msg("RepoTx: Error querying templates for import")
+		return nil, fmt.Errorf("repoTx error getting templates for import: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []models.DefinitionTemplate{}
+	for rows.Next() {
+		var template models.DefinitionTemplate
+		if scanErr := scanTemplateRow(rows, &template); scanErr != nil {
+			return nil, fmt.Errorf("repoTx error scanning template for import: %w", scanErr)
+		}
+		templates = append(templates, template)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repoTx error iterating templates for import: %w", err)
+	}
+	return templates, nil
+}
@@ -57,7 +57,7 @@ func (r *userRepo) CreateUser(ctx context.Context, input *models.RegisterUserInp
 }
 
 func (r *userRepo) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
-	query := `SELECT u.id, u.username, u.password, u.email, u.first_name, u.last_name, u.role_id, u.created_at, u.updated_at,
+	query := `SELECT u.id, u.username, u.password, u.email, u.first_name, u.last_name, u.role_id, u.timezone, u.created_at, u.updated_at,
 	                 r.id as roleid, r.name as rolename
 	          FROM users u
 	          JOIN roles r ON u.role_id = r.id
@@ -71,6 +71,7 @@ func (r *userRepo) GetUserByUsername(ctx context.Context, username string) (*mod
 		&user.FirstName,
 		&user.LastName,
 		&user.RoleID,
+		&user.Timezone,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&user.Role.ID,   // Scan ke field Role
@@ -85,15 +86,16 @@ func (r *userRepo) GetUserByUsername(ctx context.Context, username string) (*mod
 	return user, nil
 }
 
-func (r *userRepo) GetUserByID(ctx context.Context, id int) (*models.User, error) {
+// queryUserByID mengambil pengguna (beserta role) berdasarkan ID memakai pool atau transaksi.
+func queryUserByID(ctx context.Context, db rowQuerier, id int) (*models.User, error) {
 	query := `SELECT 
-				u.id, u.username, u.password, u.email, u.first_name, u.last_name, u.role_id, u.created_at, u.updated_at,
+				u.id, u.username, u.password, u.email, u.first_name, u.last_name, u.role_id, u.timezone, u.created_at, u.updated_at,
 				r.id as roleid, r.name as rolename
 			FROM users u
 			JOIN roles r ON u.role_id = r.id
 			WHERE u.id = $1`
 	user := &models.User{Role: &models.Role{}} // Inisialisasi Role
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := db.QueryRow(ctx, query, id).Scan(
 		&user.ID,
 		&user.Username,
 		&user.Password,
@@ -101,11 +103,20 @@ func (r *userRepo) GetUserByID(ctx context.Context, id int) (*models.User, error
 		&user.FirstName,
 		&user.LastName,
 		&user.RoleID,
+		&user.Timezone,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&user.Role.ID,   // Scan ke field Role
 		&user.Role.Name, // Scan ke field Role
 	)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *userRepo) GetUserByID(ctx context.Context, id int) (*models.User, error) {
+	user, err := queryUserByID(ctx, r.db, id)
 	if err != nil {
 		zlog.Error().Err(err).Int("user_id", id).Msg("Error getting user by id")
 		return nil, fmt.Errorf("error getting user by id %d: %w", id, err)
@@ -264,10 +275,12 @@ func (r *userRepo) UpdateUserPassword(ctx context.Context, id int, hashedPasswor
 
 func (r *userRepo) UpdateUserProfile(ctx context.Context, id int, input *models.UpdateProfileInput) error {
 	// Hanya update field yang relevan untuk profil
-	query := `UPDATE users SET username = $1, email = $2, first_name = $3, last_name = $4
-              WHERE id = $5` // updated_at akan dihandle trigger
+	// Timezone kosong berarti tidak diubah
+	query := `UPDATE users SET username = $1, email = $2, first_name = $3, last_name = $4,
+                     timezone = COALESCE(NULLIF($5, ''), timezone)
+              WHERE id = $6` // updated_at akan dihandle trigger
 
-	tag, err := r.db.Exec(ctx, query, input.Username, input.Email, input.FirstName, input.LastName, id)
+	tag, err := r.db.Exec(ctx, query, input.Username, input.Email, input.FirstName, input.LastName, input.Timezone, id)
 	if err != nil {
 		// Handle unique constraint (username/email exists)
 		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
@@ -294,6 +307,16 @@ func (r *userRepo) UpdateUserProfile(ctx context.Context, id int, input *models.
 	return nil
 }
 
+// GetUserByIDTx mencari pengguna berdasarkan ID dalam konteks transaksi (membaca snapshot transaksi yang sama).
+func (r *userRepo) GetUserByIDTx(ctx context.Context, tx pgx.Tx, id int) (*models.User, error) {
+	user, err := queryUserByID(ctx, tx, id)
+	if err != nil {
+		zlog.Error().Err(err).Int("user_id", id).Msg("RepoTx: Error getting user by id")
+		return nil, fmt.Errorf("repoTx error getting user by id %d: %w", id, err)
+	}
+	return user, nil
+}
+
 func (r *userRepo) CreateUserTx(ctx context.Context, tx pgx.Tx, input *models.RegisterUserInput, hashedPassword string) (int, error) {
 	query := `INSERT INTO users (username, password, email, first_name, last_name, role_id)
               VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
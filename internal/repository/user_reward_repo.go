@@ -65,7 +65,8 @@ func (r *userRewardRepo) CreateClaim(ctx context.Context, userID, rewardID, poin
 func (r *userRewardRepo) GetUserRewardByID(ctx context.Context, id int) (*models.UserReward, error) {
 	query := `SELECT
                 ur.id, ur.user_id, ur.reward_id, ur.points_deducted, ur.claimed_at, ur.status,
-                ur.reviewed_by_user_id, ur.reviewed_at, ur.created_at, ur.updated_at,
+                ur.reviewed_by_user_id, ur.reviewed_at, ur.scheduled_for, ur.fulfilled_at, ur.received_at,
+                ur.created_at, ur.updated_at,
                 -- Reward details
                 rw.id as rewardid, rw.reward_name, rw.reward_point, rw.reward_description,
                 rw.created_by_user_id as reward_creator_id, rw.created_at as reward_created_at, rw.updated_at as reward_updated_at,
@@ -93,7 +94,8 @@ func (r *userRewardRepo) GetUserRewardByID(ctx context.Context, id int) (*models
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		// UserReward fields
 		&ur.ID, &ur.UserID, &ur.RewardID, &ur.PointsDeducted, &ur.ClaimedAt, &ur.Status,
-		&reviewedByUserID, &reviewedAt, &ur.CreatedAt, &ur.UpdatedAt,
+		&reviewedByUserID, &reviewedAt, &ur.ScheduledFor, &ur.FulfilledAt, &ur.ReceivedAt,
+		&ur.CreatedAt, &ur.UpdatedAt,
 		// Reward fields
 		&ur.Reward.ID, &ur.Reward.RewardName, &ur.Reward.RewardPoint, &rewardDescription,
 		&ur.Reward.CreatedByUserID, &ur.Reward.CreatedAt, &ur.Reward.UpdatedAt,
@@ -142,7 +144,8 @@ func scanUserRewardRow(rows pgx.Rows, ur *models.UserReward) error {
 	err := rows.Scan(
 		// UserReward fields
 		&ur.ID, &ur.UserID, &ur.RewardID, &ur.PointsDeducted, &ur.ClaimedAt, &ur.Status,
-		&reviewedByUserID, &reviewedAt, &ur.CreatedAt, &ur.UpdatedAt,
+		&reviewedByUserID, &reviewedAt, &ur.ScheduledFor, &ur.FulfilledAt, &ur.ReceivedAt,
+		&ur.CreatedAt, &ur.UpdatedAt,
 		// Reward fields
 		&ur.Reward.ID, &ur.Reward.RewardName, &ur.Reward.RewardPoint, &rewardDescription,
 		&ur.Reward.CreatedByUserID, &ur.Reward.CreatedAt, &ur.Reward.UpdatedAt,
@@ -199,7 +202,8 @@ func (r *userRewardRepo) GetClaimsByChildID(ctx context.Context, childID int, st
 	queryArgs := []interface{}{childID}
 	query := `SELECT
                 ur.id, ur.user_id, ur.reward_id, ur.points_deducted, ur.claimed_at, ur.status,
-                ur.reviewed_by_user_id, ur.reviewed_at, ur.created_at, ur.updated_at,
+                ur.reviewed_by_user_id, ur.reviewed_at, ur.scheduled_for, ur.fulfilled_at, ur.received_at,
+                ur.created_at, ur.updated_at,
                 rw.id as rewardid, rw.reward_name, rw.reward_point, rw.reward_description,
                 rw.created_by_user_id as reward_creator_id, rw.created_at as reward_created_at, rw.updated_at as reward_updated_at
              FROM user_rewards ur
@@ -299,7 +303,8 @@ func (r *userRewardRepo) GetPendingClaimsByParentID(ctx context.Context, parentI
 	// 4. Query klaim 'pending' dari anak-anak ini dengan JOIN dan pagination
 	query := `SELECT
                 ur.id, ur.user_id, ur.reward_id, ur.points_deducted, ur.claimed_at, ur.status,
-                ur.reviewed_by_user_id, ur.reviewed_at, ur.created_at, ur.updated_at,
+                ur.reviewed_by_user_id, ur.reviewed_at, ur.scheduled_for, ur.fulfilled_at, ur.received_at,
+                ur.created_at, ur.updated_at,
                 rw.id as rewardid, rw.reward_name, rw.reward_point, rw.reward_description,
                 rw.created_by_user_id as reward_creator_id, rw.created_at as reward_created_at, rw.updated_at as reward_updated_at
              FROM user_rewards ur
@@ -405,14 +410,15 @@ func (r *userRewardRepo) UpdateClaimStatus(ctx context.Context, id int, newStatu
 
 // CreateClaimTx membuat klaim dalam transaksi.
 // Pengecekan poin cukup dilakukan oleh service sebelum memanggil ini.
-func (r *userRewardRepo) CreateClaimTx(ctx context.Context, tx pgx.Tx, userID, rewardID, pointsDeducted int) (int, error) {
-	query := `INSERT INTO user_rewards (user_id, reward_id, points_deducted, status, claimed_at)
-              VALUES ($1, $2, $3, $4, $5) RETURNING id`
+// stockReserved menandai bahwa klaim ini mengurangi stok hadiah (dikembalikan hanya jika true).
+func (r *userRewardRepo) CreateClaimTx(ctx context.Context, tx pgx.Tx, userID, rewardID, pointsDeducted int, stockReserved bool) (int, error) {
+	query := `INSERT INTO user_rewards (user_id, reward_id, points_deducted, status, claimed_at, stock_reserved)
+              VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
 	var claimID int
 	initialStatus := models.UserRewardStatusPending
 	claimedAt := time.Now()
 
-	err := tx.QueryRow(ctx, query, userID, rewardID, pointsDeducted, initialStatus, claimedAt).Scan(&claimID)
+	err := tx.QueryRow(ctx, query, userID, rewardID, pointsDeducted, initialStatus, claimedAt, stockReserved).Scan(&claimID)
 	if err != nil {
 		// Handle FK violation
 		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
@@ -450,7 +456,7 @@ func (r *userRewardRepo) UpdateClaimStatusTx(ctx context.Context, tx pgx.Tx, id
 
 // GetClaimDetailsForReviewTx mengambil detail minimal klaim untuk proses review dalam transaksi.
 func (r *userRewardRepo) GetClaimDetailsForReviewTx(ctx context.Context, tx pgx.Tx, claimID int) (*ClaimReviewDetails, error) {
-	query := `SELECT ur.user_id, ur.status, ur.points_deducted, rw.created_by_user_id -- Tambahkan creator reward
+	query := `SELECT ur.user_id, ur.status, ur.points_deducted, rw.created_by_user_id, ur.reward_id, ur.received_at, ur.stock_reserved -- Tambahkan creator reward
 				FROM user_rewards ur
 				JOIN rewards rw ON ur.reward_id = rw.id -- Perlu JOIN ke rewards
 				WHERE ur.id = $1 FOR UPDATE`
@@ -460,6 +466,9 @@ func (r *userRewardRepo) GetClaimDetailsForReviewTx(ctx context.Context, tx pgx.
 		&details.CurrentStatus,
 		&details.PointsDeducted,
 		&details.RewardCreatorID,
+		&details.RewardID,
+		&details.ReceivedAt,
+		&details.StockReserved,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -470,3 +479,163 @@ func (r *userRewardRepo) GetClaimDetailsForReviewTx(ctx context.Context, tx pgx.
 	}
 	return details, nil
 }
+
+// --- Penyerahan Hadiah & Riwayat ---
+
+// userRewardTransitionSet menentukan kolom tambahan yang diubah oleh aksi penyerahan.
+// Mengembalikan true jika klausa memakai tanggal penyerahan ($4).
+func userRewardTransitionSet(event *models.UserRewardEvent) (string, bool) {
+	switch event.Action {
+	case models.UserRewardActionSchedule:
+		return `scheduled_for = $4`, true
+	case models.UserRewardActionFulfill:
+		return `fulfilled_at = NOW()`, false
+	case models.UserRewardActionConfirmReceipt:
+		// Konfirmasi anak sekaligus menyelesaikan klaim yang belum ditandai diserahkan parent
+		return `fulfilled_at = COALESCE(fulfilled_at, NOW()), received_at = NOW()`, false
+	default:
+		return `updated_at = NOW()`, false
+	}
+}
+
+// ApplyClaimTransitionTx menerapkan transisi status klaim dan mencatat event-nya dalam transaksi.
+func (r *userRewardRepo) ApplyClaimTransitionTx(ctx context.Context, tx pgx.Tx, event *models.UserRewardEvent) error {
+	setClause, usesSchedule := userRewardTransitionSet(event)
+	query := `UPDATE user_rewards SET status = $1, ` + setClause + `
+			  WHERE id = $2 AND status = $3` // Pastikan status asal belum berubah
+	if event.Action == models.UserRewardActionConfirmReceipt {
+		query += ` AND received_at IS NULL` // Konfirmasi hanya sekali
+	}
+	args := []any{event.ToStatus, event.UserRewardID, event.FromStatus}
+	if usesSchedule {
+		args = append(args, event.ScheduledFor)
+	}
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		zlog.Error().Err(err).Int("user_reward_id", event.UserRewardID).Str("action", string(event.Action)).Msg("RepoTx: Error applying claim transition")
+		return fmt.Errorf("repoTx error updating status for user_reward %d: %w", event.UserRewardID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		zlog.Warn().Int("user_reward_id", event.UserRewardID).Str("action", string(event.Action)).Msg("RepoTx: Failed to apply claim transition, likely due to status change or concurrency.")
+		var latestStatus models.UserRewardStatus
+		if errStatus := tx.QueryRow(ctx, `SELECT status FROM user_rewards WHERE id = $1`, event.UserRewardID).Scan(&latestStatus); errStatus != nil {
+			return fmt.Errorf("claim status update failed, possibly due to prior change")
+		}
+		return fmt.Errorf("failed to update claim status: current status is already '%s'", latestStatus)
+	}
+	return r.CreateClaimEventTx(ctx, tx, event)
+}
+
+// CreateClaimEventTx mencatat satu event riwayat klaim dalam transaksi.
+func (r *userRewardRepo) CreateClaimEventTx(ctx context.Context, tx pgx.Tx, event *models.UserRewardEvent) error {
+	query := `INSERT INTO user_reward_events (user_reward_id, action, from_status, to_status, scheduled_for, actor_user_id, actor_type, note)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''))`
+	var fromStatus any
+	if event.FromStatus != "" {
+		fromStatus = event.FromStatus
+	}
+	actorType := event.ActorType
+	if actorType == "" {
+		actorType = models.AuditActorUser
+	}
+	_, err := tx.Exec(ctx, query, event.UserRewardID, event.Action, fromStatus, event.ToStatus, event.ScheduledFor, nullableID(event.ActorUserID), actorType, event.Note)
+	if err != nil {
+		zlog.Error().Err(err).Int("user_reward_id", event.UserRewardID).Str("action", string(event.Action)).Msg("RepoTx: Error creating user reward event")
+		return fmt.Errorf("repoTx error creating event for user_reward %d: %w", event.UserRewardID, err)
+	}
+	return nil
+}
+
+// GetClaimEvents mengambil riwayat transisi sebuah klaim (urut kronologis).
+func (r *userRewardRepo) GetClaimEvents(ctx context.Context, claimID int) ([]models.UserRewardEvent, error) {
+	query := `SELECT id, user_reward_id, action, from_status, to_status, scheduled_for, actor_user_id, actor_type, note, created_at
+              FROM user_reward_events
+              WHERE user_reward_id = $1
+              ORDER BY created_at ASC, id ASC`
+	rows, err := r.db.Query(ctx, query, claimID)
+	if err != nil {
+		zlog.Error().Err(err).Int("user_reward_id", claimID).Msg("Error querying user reward events")
+		return nil, fmt.Errorf("error getting events for user_reward %d: %w", claimID, err)
+	}
+	defer rows.Close()
+
+	events := []models.UserRewardEvent{}
+	for rows.Next() {
+		var event models.UserRewardEvent
+		var fromStatus, note sql.NullString
+		var actorUserID sql.NullInt32
+		if err := rows.Scan(&event.ID, &event.UserRewardID, &event.Action, &fromStatus, &event.ToStatus, &event.ScheduledFor, &actorUserID, &event.ActorType, &note, &event.CreatedAt); err != nil {
+			zlog.Warn().Err(err).Int("user_reward_id", claimID).Msg("Error scanning user reward event row")
+			return events, fmt.Errorf("error scanning user reward event data: %w", err)
+		}
+		event.FromStatus = models.UserRewardStatus(fromStatus.String)
+		event.Note = note.String
+		if actorUserID.Valid {
+			event.ActorUserID = int(actorUserID.Int32)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		zlog.Error().Err(err).Int("user_reward_id", claimID).Msg("Error iterating user reward event rows")
+		return events, fmt.Errorf("error iterating user reward events: %w", err)
+	}
+	return events, nil
+}
+
+// GetUnfulfilledClaimsByParentID mengambil klaim yang sudah disetujui tetapi belum diserahkan
+// dari semua anak yang terhubung ke parent tertentu.
+func (r *userRewardRepo) GetUnfulfilledClaimsByParentID(ctx context.Context, parentID int, page, limit int) ([]models.UserReward, int, error) {
+	countQuery := `SELECT COUNT(*) FROM user_rewards ur
+                   JOIN user_relationship rel ON rel.child_id = ur.user_id
+                   WHERE rel.parent_id = $1 AND ur.status IN ($2, $3)`
+	var totalCount int
+	err := r.db.QueryRow(ctx, countQuery, parentID, models.UserRewardStatusApproved, models.UserRewardStatusScheduled).Scan(&totalCount)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", parentID).Msg("Error counting unfulfilled claims for parent's children")
+		return nil, 0, fmt.Errorf("error counting unfulfilled claims: %w", err)
+	}
+	if totalCount == 0 {
+		return []models.UserReward{}, 0, nil
+	}
+
+	offset := (page - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	// Yang paling lama menunggu (jadwal terdekat, lalu waktu persetujuan) tampil lebih dulu
+	query := `SELECT
+                ur.id, ur.user_id, ur.reward_id, ur.points_deducted, ur.claimed_at, ur.status,
+                ur.reviewed_by_user_id, ur.reviewed_at, ur.scheduled_for, ur.fulfilled_at, ur.received_at,
+                ur.created_at, ur.updated_at,
+                rw.id as rewardid, rw.reward_name, rw.reward_point, rw.reward_description,
+                rw.created_by_user_id as reward_creator_id, rw.created_at as reward_created_at, rw.updated_at as reward_updated_at
+             FROM user_rewards ur
+             JOIN rewards rw ON ur.reward_id = rw.id
+             JOIN user_relationship rel ON rel.child_id = ur.user_id
+             WHERE rel.parent_id = $1 AND ur.status IN ($2, $3)
+             ORDER BY ur.scheduled_for ASC NULLS LAST, ur.reviewed_at ASC
+             LIMIT $4 OFFSET $5`
+	rows, err := r.db.Query(ctx, query, parentID, models.UserRewardStatusApproved, models.UserRewardStatusScheduled, limit, offset)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", parentID).Msg("Error querying unfulfilled claims for parent's children")
+		return nil, totalCount, fmt.Errorf("error getting unfulfilled claims: %w", err)
+	}
+	defer rows.Close()
+
+	claims := []models.UserReward{}
+	for rows.Next() {
+		var ur models.UserReward
+		ur.Reward = &models.Reward{}
+		if scanErr := scanUserRewardRow(rows, &ur); scanErr != nil {
+			zlog.Warn().Err(scanErr).Int("parent_id", parentID).Msg("Error scanning unfulfilled claim row")
+			return claims, totalCount, fmt.Errorf("error scanning unfulfilled claim data: %w", scanErr)
+		}
+		claims = append(claims, ur)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		zlog.Error().Err(rowsErr).Int("parent_id", parentID).Msg("Error iterating unfulfilled claim rows")
+		return claims, totalCount, fmt.Errorf("error iterating unfulfilled claim data: %w", rowsErr)
+	}
+	return claims, totalCount, nil
+}
@@ -15,6 +15,9 @@ import (
 	zlog "github.com/rs/zerolog/log"
 )
 
+// ErrTaskAlreadyActive dikembalikan saat anak masih memiliki penugasan 'assigned'/'submitted' untuk tugas yang sama.
+var ErrTaskAlreadyActive = errors.New("the child already has this task assigned or submitted")
+
 type userTaskRepo struct {
 	db *pgxpool.Pool
 	// Tambahkan dependensi lain jika perlu, misal UserRelationshipRepo untuk cek relasi di VerifyTask
@@ -93,7 +96,7 @@ func scanUserTaskRow(rows pgx.Rows, ut *models.UserTask) error {
 	err := rows.Scan(
 		// UserTask fields
 		&ut.ID, &ut.UserID, &ut.TaskID, &ut.AssignedByUserID, &ut.Status,
-		&ut.AssignedAt, &submittedAt, &verifiedByUserID, &verifiedAt, &completedAt,
+		&ut.AssignedAt, &submittedAt, &verifiedByUserID, &verifiedAt, &completedAt, &ut.AutoApproved, &ut.DueAt,
 		&ut.CreatedAt, &ut.UpdatedAt,
 		// Task fields
 		&ut.Task.ID, &ut.Task.TaskName, &ut.Task.TaskPoint, &taskDescription, &ut.Task.CreatedByUserID,
@@ -138,22 +141,59 @@ func scanUserTaskRow(rows pgx.Rows, ut *models.UserTask) error {
 
 // --- Repository Methods ---
 
+// assignTaskQuery menyisipkan penugasan baru sekaligus event 'assign' pertamanya dalam satu statement.
+const assignTaskQuery = `WITH inserted AS (
+                INSERT INTO user_tasks (user_id, task_id, assigned_by_user_id, status, assigned_at, due_at, created_at, updated_at)
+                VALUES ($1, $2, $3, $4, NOW(), $5, NOW(), NOW())
+                RETURNING id, assigned_by_user_id, assigned_at
+              )
+              INSERT INTO user_task_events (user_task_id, action, from_status, to_status, actor_user_id, actor_type, created_at)
+              SELECT id, $6, NULL, $4, assigned_by_user_id, $7, assigned_at FROM inserted
+              RETURNING user_task_id`
+
+// activeTaskExistsQuery memeriksa penugasan 'assigned'/'submitted' untuk pasangan anak & tugas.
+const activeTaskExistsQuery = `SELECT EXISTS (
+                SELECT 1
+                FROM user_tasks
+                WHERE user_id = $1
+                  AND task_id = $2
+                  AND status IN ($3, $4)
+            )`
+
+// assignTaskArgs menyiapkan argumen assignTaskQuery.
+func assignTaskArgs(userID, taskID, assignedByID int, dueAt *time.Time) []any {
+	return []any{userID, taskID, assignedByID, models.UserTaskStatusAssigned, dueAt, models.UserTaskActionAssign, models.AuditActorUser}
+}
+
 // AssignTask menugaskan sebuah task definition (taskID) kepada user (userID) oleh parent (assignedByID).
-func (r *userTaskRepo) AssignTask(ctx context.Context, userID, taskID, assignedByID int) (int, error) {
-	query := `INSERT INTO user_tasks (user_id, task_id, assigned_by_user_id, status, assigned_at, created_at, updated_at)
-              VALUES ($1, $2, $3, $4, $5, NOW(), NOW()) RETURNING id` // Tambahkan created_at, updated_at
-	var userTaskID int
-	assignedStatus := models.UserTaskStatusAssigned // Status awal
-	assignedAt := time.Now()                        // Waktu penugasan
+// Pemeriksaan penugasan aktif & insert berjalan dalam satu transaksi di bawah kunci pasangan anak & tugas,
+// sehingga penugasan bersamaan tidak menghasilkan dua penugasan aktif (ErrTaskAlreadyActive).
+func (r *userTaskRepo) AssignTask(ctx context.Context, userID, taskID, assignedByID int, dueAt *time.Time) (userTaskID int, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		zlog.Error().Err(err).Int("user_id", userID).Int("task_id", taskID).Msg("Error beginning transaction for task assignment")
+		return 0, fmt.Errorf("error assigning task: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+			return
+		}
+		if err = tx.Commit(ctx); err != nil {
+			zlog.Error().Err(err).Int("user_id", userID).Int("task_id", taskID).Msg("Error committing task assignment")
+			userTaskID, err = 0, fmt.Errorf("error assigning task: %w", err)
+		}
+	}()
 
-	err := r.db.QueryRow(ctx, query,
-		userID,       // ID Anak
-		taskID,       // ID Task Definition
-		assignedByID, // ID Parent yang assign
-		assignedStatus,
-		assignedAt,
-	).Scan(&userTaskID)
+	exists, err := r.CheckExistingActiveTaskTx(ctx, tx, userID, taskID)
+	if err != nil {
+		return 0, err
+	}
+	if exists {
+		return 0, ErrTaskAlreadyActive
+	}
 
+	err = tx.QueryRow(ctx, assignTaskQuery, assignTaskArgs(userID, taskID, assignedByID, dueAt)...).Scan(&userTaskID)
 	if err != nil {
 		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
 			zlog.Warn().Err(err).Int("user_id", userID).Int("task_id", taskID).Int("assigned_by_id", assignedByID).Msg("Foreign key violation on task assignment")
@@ -167,11 +207,28 @@ func (r *userTaskRepo) AssignTask(ctx context.Context, userID, taskID, assignedB
 	return userTaskID, nil
 }
 
+// AssignTaskTx menugaskan task kepada user dalam konteks transaksi (misal: klaim bounty).
+func (r *userTaskRepo) AssignTaskTx(ctx context.Context, tx pgx.Tx, userID, taskID, assignedByID int, dueAt *time.Time) (int, error) {
+	var userTaskID int
+	err := tx.QueryRow(ctx, assignTaskQuery, assignTaskArgs(userID, taskID, assignedByID, dueAt)...).Scan(&userTaskID)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
+			zlog.Warn().Err(err).Int("user_id", userID).Int("task_id", taskID).Int("assigned_by_id", assignedByID).Msg("RepoTx: Foreign key violation on task assignment")
+			return 0, fmt.Errorf("invalid user, task, or assigner ID provided")
+		}
+		zlog.Error().Err(err).Int("user_id", userID).Int("task_id", taskID).Msg("RepoTx: Error assigning task")
+		return 0, fmt.Errorf("repoTx error assigning task: %w", err)
+	}
+
+	zlog.Info().Int("user_task_id", userTaskID).Int("user_id", userID).Int("task_id", taskID).Int("assigned_by_id", assignedByID).Msg("RepoTx: Task assigned successfully")
+	return userTaskID, nil
+}
+
 // GetUserTaskByID mengambil detail penugasan tugas spesifik, termasuk data Task dan User (Anak).
 func (r *userTaskRepo) GetUserTaskByID(ctx context.Context, id int) (*models.UserTask, error) {
 	query := `SELECT
 				ut.id, ut.user_id, ut.task_id, ut.assigned_by_user_id, ut.status,
-				ut.assigned_at, ut.submitted_at, ut.verified_by_user_id, ut.verified_at, ut.completed_at,
+				ut.assigned_at, ut.submitted_at, ut.verified_by_user_id, ut.verified_at, ut.completed_at, ut.auto_approved, ut.due_at,
 				ut.created_at, ut.updated_at,
 				-- Task details
 				t.id as taskid, t.task_name, t.task_point, t.task_description, t.created_by_user_id as task_creator_id,
@@ -200,7 +257,7 @@ func (r *userTaskRepo) GetUserTaskByID(ctx context.Context, id int) (*models.Use
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		// UserTask fields
 		&ut.ID, &ut.UserID, &ut.TaskID, &ut.AssignedByUserID, &ut.Status,
-		&ut.AssignedAt, &submittedAt, &verifiedByUserID, &verifiedAt, &completedAt,
+		&ut.AssignedAt, &submittedAt, &verifiedByUserID, &verifiedAt, &completedAt, &ut.AutoApproved, &ut.DueAt,
 		&ut.CreatedAt, &ut.UpdatedAt, // Pastikan sudah ada di model dan tabel
 		// Task fields
 		&ut.Task.ID, &ut.Task.TaskName, &ut.Task.TaskPoint, &taskDescription, &ut.Task.CreatedByUserID,
@@ -278,7 +335,7 @@ func (r *userTaskRepo) GetTasksByChildID(ctx context.Context, childID int, statu
 	// 2. Buat query utama dengan JOIN dan pagination
 	baseQuery := `SELECT
 					ut.id, ut.user_id, ut.task_id, ut.assigned_by_user_id, ut.status,
-					ut.assigned_at, ut.submitted_at, ut.verified_by_user_id, ut.verified_at, ut.completed_at,
+					ut.assigned_at, ut.submitted_at, ut.verified_by_user_id, ut.verified_at, ut.completed_at, ut.auto_approved, ut.due_at,
 					ut.created_at, ut.updated_at,
 					t.id as taskid, t.task_name, t.task_point, t.task_description, t.created_by_user_id as task_creator_id,
 					t.created_at as task_created_at, t.updated_at as task_updated_at
@@ -344,7 +401,7 @@ func (r *userTaskRepo) GetTasksByParentID(ctx context.Context, parentID int, sta
 	// 2. Buat query utama dengan JOIN dan pagination
 	baseQuery := `SELECT
 					ut.id, ut.user_id, ut.task_id, ut.assigned_by_user_id, ut.status,
-					ut.assigned_at, ut.submitted_at, ut.verified_by_user_id, ut.verified_at, ut.completed_at,
+					ut.assigned_at, ut.submitted_at, ut.verified_by_user_id, ut.verified_at, ut.completed_at, ut.auto_approved, ut.due_at,
 					ut.created_at, ut.updated_at,
 					t.id as taskid, t.task_name, t.task_point, t.task_description, t.created_by_user_id as task_creator_id,
 					t.created_at as task_created_at, t.updated_at as task_updated_at
@@ -383,12 +440,28 @@ func (r *userTaskRepo) GetTasksByParentID(ctx context.Context, parentID int, sta
 }
 
 // SubmitTask mengubah status UserTask menjadi 'submitted' oleh anak.
-// Melakukan validasi ownership (task milik childID) dan status saat ini ('assigned').
-func (r *userTaskRepo) SubmitTask(ctx context.Context, id int, childID int) error {
-	// Tambahkan logika untuk memeriksa status saat ini sebelum update
-	getTaskQuery := `SELECT status FROM user_tasks WHERE id = $1 AND user_id = $2`
+// Melakukan validasi ownership (task milik childID) dan transisi status lewat models.NextUserTaskStatus.
+func (r *userTaskRepo) SubmitTask(ctx context.Context, id int, childID int) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		zlog.Error().Err(err).Int("user_task_id", id).Msg("Error beginning transaction for task submission")
+		return fmt.Errorf("error submitting task %d: %w", id, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+			return
+		}
+		if err = tx.Commit(ctx); err != nil {
+			zlog.Error().Err(err).Int("user_task_id", id).Msg("Error committing task submission")
+			err = fmt.Errorf("failed to submit task, please try again")
+		}
+	}()
+
+	// Kunci baris agar transisi tidak balapan dengan verifikasi/unassign
+	getTaskQuery := `SELECT status FROM user_tasks WHERE id = $1 AND user_id = $2 FOR UPDATE`
 	var currentStatus models.UserTaskStatus
-	err := r.db.QueryRow(ctx, getTaskQuery, id, childID).Scan(&currentStatus)
+	err = tx.QueryRow(ctx, getTaskQuery, id, childID).Scan(&currentStatus)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			zlog.Warn().Int("user_task_id", id).Int("child_id", childID).Msg("Submit task failed: Task not found or does not belong to the child")
@@ -398,154 +471,99 @@ func (r *userTaskRepo) SubmitTask(ctx context.Context, id int, childID int) erro
 		return fmt.Errorf("error submitting task %d: %w", id, err)
 	}
 
-	if currentStatus != models.UserTaskStatusAssigned {
+	newStatus, transitionErr := models.NextUserTaskStatus(currentStatus, models.UserTaskActionSubmit)
+	if transitionErr != nil {
 		zlog.Warn().Int("user_task_id", id).Int("child_id", childID).Str("current_status", string(currentStatus)).Msg("Submit task failed: Task not in 'assigned' status")
-		return fmt.Errorf("task submission failed: task status is already '%s'", currentStatus)
+		err = fmt.Errorf("task submission failed: task status is already '%s'", currentStatus)
+		return err
 	}
 
-	// Lanjutkan dengan update jika status 'assigned'
-	query := `UPDATE user_tasks
-              SET status = $1, submitted_at = $2
-              WHERE id = $3` // Tidak perlu cek user_id dan status lagi karena sudah dicek
-	newStatus := models.UserTaskStatusSubmitted
-	submittedAt := time.Now()
-
-	tag, err := r.db.Exec(ctx, query, newStatus, submittedAt, id)
+	err = r.ApplyTransitionTx(ctx, tx, &models.UserTaskEvent{
+		UserTaskID:  id,
+		Action:      models.UserTaskActionSubmit,
+		FromStatus:  currentStatus,
+		ToStatus:    newStatus,
+		ActorUserID: childID,
+		ActorType:   models.AuditActorUser,
+	})
 	if err != nil {
-		zlog.Error().Err(err).Int("user_task_id", id).Int("child_id", childID).Msg("Error submitting task during update")
-		return fmt.Errorf("error submitting task %d: %w", id, err)
-	}
-
-	if tag.RowsAffected() == 0 {
-		// Ini seharusnya tidak terjadi jika pengecekan status di atas lolos
-		zlog.Error().Int("user_task_id", id).Msg("Failed to submit task status despite passing initial checks (concurrency issue?)")
-		return fmt.Errorf("failed to submit task, please try again")
+		return err
 	}
 
 	zlog.Info().Int("user_task_id", id).Int("child_id", childID).Msg("Task submitted successfully by child")
 	return nil
 }
 
-// VerifyTask memverifikasi task yang sudah disubmit ('submitted') oleh parent.
-// Mengubah status menjadi 'approved' atau 'rejected'.
-// Memvalidasi bahwa parentID adalah parent dari anak yang mengerjakan task.
-// Mengembalikan data UserTask yang sudah diupdate (terutama untuk dapatkan poin jika approved).
-func (r *userTaskRepo) VerifyTask(ctx context.Context, id int, parentID int, newStatus models.UserTaskStatus) (*models.UserTask, error) {
-	// --- LANGKAH 1: Get UserTask untuk validasi ---
-	getTaskQuery := `SELECT user_id, status FROM user_tasks WHERE id = $1`
-	var childID int
-	var currentStatus models.UserTaskStatus
-	err := r.db.QueryRow(ctx, getTaskQuery, id).Scan(&childID, &currentStatus)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			zlog.Warn().Int("user_task_id", id).Msg("Verify task failed: UserTask not found")
-			return nil, pgx.ErrNoRows
-		}
-		zlog.Error().Err(err).Int("user_task_id", id).Msg("Error getting UserTask details before verification")
-		return nil, fmt.Errorf("error retrieving task details for verification: %w", err)
-	}
-
-	// --- LANGKAH 2: Validasi Status Saat Ini ---
-	if currentStatus != models.UserTaskStatusSubmitted {
-		zlog.Warn().Int("user_task_id", id).Str("current_status", string(currentStatus)).Msg("Verify task failed: Task not in 'submitted' status")
-		return nil, fmt.Errorf("cannot verify task: current status is '%s', expected 'submitted'", currentStatus)
-	}
-
-	// --- LANGKAH 3: Validasi Relasi Parent-Child ---
-	isParent, err := r.userRelRepo.IsParentOf(ctx, parentID, childID)
-	if err != nil {
-		zlog.Error().Err(err).Int("parent_id", parentID).Int("child_id", childID).Msg("Error checking parent-child relationship during task verification")
-		return nil, fmt.Errorf("error verifying parent-child relationship: %w", err)
-	}
-	if !isParent {
-		zlog.Warn().Int("user_task_id", id).Int("parent_id", parentID).Int("child_id", childID).Msg("Verify task failed: Requesting user is not the parent")
-		return nil, fmt.Errorf("forbidden: you are not authorized to verify tasks for this child")
-	}
-
-	// --- LANGKAH 4: Lakukan Update Status ---
-	updateQuery := `UPDATE user_tasks
-					SET status = $1, verified_by_user_id = $2, verified_at = $3, completed_at = $4
-					WHERE id = $5 AND status = $6` // Tambahkan cek status lagi untuk atomicity
-	verifiedAt := time.Now()
-	var completedAt *time.Time
-	if newStatus == models.UserTaskStatusApproved {
-		ca := verifiedAt
-		completedAt = &ca
-	}
-
-	tag, err := r.db.Exec(ctx, updateQuery, newStatus, parentID, verifiedAt, completedAt, id, models.UserTaskStatusSubmitted) // Cek status 'submitted'
+// GetEventsByUserTaskID mengambil timeline transisi sebuah penugasan (urut kronologis).
+func (r *userTaskRepo) GetEventsByUserTaskID(ctx context.Context, userTaskID int) ([]models.UserTaskEvent, error) {
+	query := `SELECT id, user_task_id, action, from_status, to_status, actor_user_id, actor_type, reason, created_at
+              FROM user_task_events
+              WHERE user_task_id = $1
+              ORDER BY created_at ASC, id ASC`
+	rows, err := r.db.Query(ctx, query, userTaskID)
 	if err != nil {
-		zlog.Error().Err(err).Int("user_task_id", id).Int("parent_id", parentID).Str("new_status", string(newStatus)).Msg("Error updating user_task status during verification")
-		return nil, fmt.Errorf("error updating task status: %w", err)
+		zlog.Error().Err(err).Int("user_task_id", userTaskID).Msg("Error querying user task events")
+		return nil, fmt.Errorf("error getting events for user_task %d: %w", userTaskID, err)
 	}
+	defer rows.Close()
 
-	if tag.RowsAffected() == 0 {
-		// Bisa karena status sudah berubah oleh proses lain (concurrency) atau ID tidak valid (tapi sudah dicek)
-		zlog.Warn().Int("user_task_id", id).Msg("Failed to update task status, likely due to status change or concurrency.")
-		// Query ulang untuk mendapatkan status terbaru
-		var latestStatus models.UserTaskStatus
-		errStatus := r.db.QueryRow(ctx, `SELECT status FROM user_tasks WHERE id = $1`, id).Scan(&latestStatus)
-		if errStatus != nil {
-			return nil, fmt.Errorf("failed to update task status and could not retrieve current status")
+	events := []models.UserTaskEvent{}
+	for rows.Next() {
+		var event models.UserTaskEvent
+		var fromStatus, reason sql.NullString
+		var actorUserID sql.NullInt32
+		if err := rows.Scan(&event.ID, &event.UserTaskID, &event.Action, &fromStatus, &event.ToStatus, &actorUserID, &event.ActorType, &reason, &event.CreatedAt); err != nil {
+			zlog.Warn().Err(err).Int("user_task_id", userTaskID).Msg("Error scanning user task event row")
+			return events, fmt.Errorf("error scanning user task event data: %w", err)
+		}
+		event.FromStatus = models.UserTaskStatus(fromStatus.String)
+		event.Reason = reason.String
+		if actorUserID.Valid {
+			event.ActorUserID = int(actorUserID.Int32)
 		}
-		return nil, fmt.Errorf("failed to update task status: current status is already '%s'", latestStatus) // Beri info status terbaru
+		events = append(events, event)
 	}
-
-	// --- LANGKAH 5: Ambil Data UserTask yang Sudah Diupdate (Termasuk Task) ---
-	updatedUserTask, err := r.GetUserTaskByID(ctx, id)
-	if err != nil {
-		zlog.Error().Err(err).Int("user_task_id", id).Msg("Failed to retrieve updated UserTask data after verification")
-		return nil, fmt.Errorf("task status updated, but failed to retrieve updated details: %w", err)
+	if err := rows.Err(); err != nil {
+		zlog.Error().Err(err).Int("user_task_id", userTaskID).Msg("Error iterating user task event rows")
+		return events, fmt.Errorf("error iterating user task events: %w", err)
 	}
-
-	zlog.Info().Int("user_task_id", id).Int("parent_id", parentID).Str("new_status", string(newStatus)).Msg("Task verified successfully by parent")
-	return updatedUserTask, nil
+	return events, nil
 }
 
-// UpdateUserTaskStatus (Implementasi Internal atau untuk Admin)
-// CATATAN: Jika ini hanya internal, hapus dari interface UserTaskRepository.
-// Jika untuk Admin, perlu validasi role admin di handler yang memanggilnya.
-func (r *userTaskRepo) UpdateUserTaskStatus(ctx context.Context, id int, newStatus models.UserTaskStatus, verifierID *int) error {
-	query := `UPDATE user_tasks SET status = $1, verified_by_user_id = $2, verified_at = $3, completed_at = $4 WHERE id = $5`
-	now := time.Now()
-	var completedAt *time.Time
-	var verifiedAt *time.Time
-
-	// Tentukan logika timestamp berdasarkan status baru dan verifierID
-	var sqlVerifierID sql.NullInt32
-	if verifierID != nil {
-		sqlVerifierID = sql.NullInt32{Int32: int32(*verifierID), Valid: true}
-		verifiedAt = &now
-		if newStatus == models.UserTaskStatusApproved {
-			completedAt = &now
-		}
-	} else {
-		// Jika verifierID nil, mungkin status diubah oleh sistem atau anak?
-		// Set verified_by_user_id ke NULL.
-		sqlVerifierID = sql.NullInt32{Valid: false}
-		// Jangan set verifiedAt atau completedAt jika tidak ada verifier? Tergantung logika.
-	}
-
-	tag, err := r.db.Exec(ctx, query, newStatus, sqlVerifierID, verifiedAt, completedAt, id)
+// GetOverdueUserTaskIDs mengambil penugasan 'assigned' yang sudah melewati due_at.
+func (r *userTaskRepo) GetOverdueUserTaskIDs(ctx context.Context, now time.Time, limit int) ([]int, error) {
+	query := `SELECT id FROM user_tasks
+              WHERE status = $1 AND due_at IS NOT NULL AND due_at <= $2
+              ORDER BY due_at ASC
+              LIMIT $3`
+	rows, err := r.db.Query(ctx, query, models.UserTaskStatusAssigned, now, limit)
 	if err != nil {
-		return fmt.Errorf("error updating status for user_task %d: %w", id, err)
+		zlog.Error().Err(err).Msg("Error querying overdue user tasks")
+		return nil, fmt.Errorf("error getting overdue user tasks: %w", err)
 	}
-	if tag.RowsAffected() == 0 {
-		return pgx.ErrNoRows
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return ids, fmt.Errorf("error scanning overdue user task id: %w", err)
+		}
+		ids = append(ids, id)
 	}
-	return nil
+	return ids, rows.Err()
 }
 
 // --- Metode untuk Service Layer (Menggunakan Transaksi) ---
 
 // GetTaskDetailsForVerificationTx mengambil detail minimal untuk verifikasi dalam transaksi.
 func (r *userTaskRepo) GetTaskDetailsForVerificationTx(ctx context.Context, tx pgx.Tx, userTaskID int) (*TaskVerificationDetails, error) {
-	query := `SELECT ut.user_id, ut.status, t.task_point
+	query := `SELECT ut.user_id, ut.status, t.task_point, COALESCE(t.currency_id, 0), ut.task_id, ut.verified_at
               FROM user_tasks ut
               JOIN tasks t ON ut.task_id = t.id
-              WHERE ut.id = $1 FOR UPDATE` // Tambahkan FOR UPDATE untuk locking
+              WHERE ut.id = $1 FOR UPDATE OF ut` // Tambahkan FOR UPDATE untuk locking
 	details := &TaskVerificationDetails{}
-	err := tx.QueryRow(ctx, query, userTaskID).Scan(&details.ChildID, &details.CurrentStatus, &details.TaskPoint)
+	err := tx.QueryRow(ctx, query, userTaskID).Scan(&details.ChildID, &details.CurrentStatus, &details.TaskPoint, &details.CurrencyID, &details.TaskID, &details.VerifiedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, pgx.ErrNoRows
@@ -556,52 +574,112 @@ func (r *userTaskRepo) GetTaskDetailsForVerificationTx(ctx context.Context, tx p
 	return details, nil // Jangan bungkus ErrNoRows di sini
 }
 
-// UpdateStatusTx mengupdate status dalam transaksi.
-func (r *userTaskRepo) UpdateStatusTx(ctx context.Context, tx pgx.Tx, id int, newStatus models.UserTaskStatus, verifierID int) error {
-	query := `UPDATE user_tasks SET status = $1, verified_by_user_id = $2, verified_at = $3, completed_at = $4
-			  WHERE id = $5 AND status = $6` // Pastikan status masih submitted
-	now := time.Now()
-	var completedAt *time.Time
-	if newStatus == models.UserTaskStatusApproved {
-		ca := now
-		completedAt = &ca
-	}
-	tag, err := tx.Exec(ctx, query, newStatus, verifierID, now, completedAt, id, models.UserTaskStatusSubmitted) // Cek status submitted
+// userTaskTransitionSet menentukan kolom tambahan yang diubah saat memasuki status tujuan.
+// Mengembalikan true jika klausa memakai pelaku ($4, NULL untuk sistem).
+func userTaskTransitionSet(event *models.UserTaskEvent) (string, bool) {
+	switch event.ToStatus {
+	case models.UserTaskStatusSubmitted:
+		if event.Action == models.UserTaskActionRevert {
+			// Revert: hapus hasil verifikasi, submission kembali menunggu verifikasi ulang
+			return `verified_by_user_id = NULL, verified_at = NULL, completed_at = NULL, auto_approved = FALSE`, false
+		}
+		return `submitted_at = NOW()`, false
+	case models.UserTaskStatusApproved:
+		// Pelaku NULL berarti disetujui sistem (auto-approval)
+		return `verified_by_user_id = $4, verified_at = NOW(), completed_at = NOW(), auto_approved = ($4::INT IS NULL)`, true
+	case models.UserTaskStatusRejected:
+		return `verified_by_user_id = $4, verified_at = NOW(), completed_at = NULL, auto_approved = FALSE`, true
+	default:
+		return `updated_at = NOW()`, false
+	}
+}
+
+// ApplyTransitionTx menerapkan transisi status dan mencatat event-nya dalam transaksi.
+func (r *userTaskRepo) ApplyTransitionTx(ctx context.Context, tx pgx.Tx, event *models.UserTaskEvent) error {
+	setClause, usesActor := userTaskTransitionSet(event)
+	query := `UPDATE user_tasks SET status = $1, ` + setClause + `
+			  WHERE id = $2 AND status = $3` // Pastikan status asal belum berubah
+	args := []any{event.ToStatus, event.UserTaskID, event.FromStatus}
+	if usesActor {
+		args = append(args, nullableID(event.ActorUserID))
+	}
+	tag, err := tx.Exec(ctx, query, args...)
 	if err != nil {
-		zlog.Error().Err(err).Int("user_task_id", id).Msg("RepoTx: Error updating task status")
-		return fmt.Errorf("repoTx error updating status for user_task %d: %w", id, err)
+		zlog.Error().Err(err).Int("user_task_id", event.UserTaskID).Str("action", string(event.Action)).Msg("RepoTx: Error applying task transition")
+		return fmt.Errorf("repoTx error updating status for user_task %d: %w", event.UserTaskID, err)
 	}
 	if tag.RowsAffected() == 0 {
-		zlog.Warn().Int("user_task_id", id).Msg("RepoTx: Failed to update task status, likely due to status change or concurrency.")
-		// Query ulang status untuk error message yang lebih baik?
+		zlog.Warn().Int("user_task_id", event.UserTaskID).Str("action", string(event.Action)).Msg("RepoTx: Failed to apply task transition, likely due to status change or concurrency.")
 		var latestStatus models.UserTaskStatus
-		errStatus := tx.QueryRow(ctx, `SELECT status FROM user_tasks WHERE id = $1`, id).Scan(&latestStatus)
-		if errStatus != nil {
-			// Jika query status juga gagal, kembalikan error NoRows/Concurrency umum
-			return fmt.Errorf("task status update failed, possibly due to prior change") // Error NoRows bisa ambigu
+		if errStatus := tx.QueryRow(ctx, `SELECT status FROM user_tasks WHERE id = $1`, event.UserTaskID).Scan(&latestStatus); errStatus != nil {
+			return fmt.Errorf("task status update failed, possibly due to prior change")
 		}
-		// Jika status ditemukan, berikan info status terbaru
 		return fmt.Errorf("failed to update task status: current status is already '%s'", latestStatus)
 	}
+	return r.CreateEventTx(ctx, tx, event)
+}
+
+// CreateEventTx mencatat satu event timeline dalam transaksi.
+func (r *userTaskRepo) CreateEventTx(ctx context.Context, tx pgx.Tx, event *models.UserTaskEvent) error {
+	query := `INSERT INTO user_task_events (user_task_id, action, from_status, to_status, actor_user_id, actor_type, reason)
+              VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''))`
+	var fromStatus any
+	if event.FromStatus != "" {
+		fromStatus = event.FromStatus
+	}
+	actorType := event.ActorType
+	if actorType == "" {
+		actorType = models.AuditActorUser
+	}
+	_, err := tx.Exec(ctx, query, event.UserTaskID, event.Action, fromStatus, event.ToStatus, nullableID(event.ActorUserID), actorType, event.Reason)
+	if err != nil {
+		zlog.Error().Err(err).Int("user_task_id", event.UserTaskID).Str("action", string(event.Action)).Msg("RepoTx: Error creating user task event")
+		return fmt.Errorf("repoTx error creating event for user_task %d: %w", event.UserTaskID, err)
+	}
+	return nil
+}
+
+// ReassignTaskTx memindahkan penugasan ke anak lain dalam transaksi. Hanya untuk status 'assigned'.
+func (r *userTaskRepo) ReassignTaskTx(ctx context.Context, tx pgx.Tx, id int, newChildID int) error {
+	query := `UPDATE user_tasks SET user_id = $1, assigned_at = NOW()
+			  WHERE id = $2 AND status = $3`
+	tag, err := tx.Exec(ctx, query, newChildID, id, models.UserTaskStatusAssigned)
+	if err != nil {
+		zlog.Error().Err(err).Int("user_task_id", id).Int("new_child_id", newChildID).Msg("RepoTx: Error reassigning user task")
+		return fmt.Errorf("repoTx error reassigning user_task %d: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("cannot reassign task: task is no longer in 'assigned' status")
+	}
 	return nil
 }
 
 // CheckExistingActiveTask memeriksa apakah ada penugasan task yang sama
 // dengan status 'assigned' atau 'submitted' untuk user tertentu.
 func (r *userTaskRepo) CheckExistingActiveTask(ctx context.Context, userID, taskID int) (bool, error) {
-	query := `SELECT EXISTS (
-                SELECT 1
-                FROM user_tasks
-                WHERE user_id = $1
-                  AND task_id = $2
-                  AND status IN ($3, $4)
-            )`
 	var exists bool
-	err := r.db.QueryRow(ctx, query, userID, taskID, models.UserTaskStatusAssigned, models.UserTaskStatusSubmitted).Scan(&exists)
+	err := r.db.QueryRow(ctx, activeTaskExistsQuery, userID, taskID, models.UserTaskStatusAssigned, models.UserTaskStatusSubmitted).Scan(&exists)
 	if err != nil {
         // Error saat query, bukan karena tidak ada (EXISTS selalu return 1 baris)
 		zlog.Error().Err(err).Int("user_id", userID).Int("task_id", taskID).Msg("Error checking for existing active task")
 		return false, fmt.Errorf("error checking existing task: %w", err)
 	}
 	return exists, nil
+}
+
+// CheckExistingActiveTaskTx sama seperti CheckExistingActiveTask, tetapi lebih dulu mengambil advisory lock
+// transaksi untuk pasangan (userID, taskID). Penugasan yang memeriksa lewat metode ini diproses berurutan
+// hingga transaksinya selesai, sehingga tidak ada dua penugasan aktif untuk pasangan yang sama.
+func (r *userTaskRepo) CheckExistingActiveTaskTx(ctx context.Context, tx pgx.Tx, userID, taskID int) (bool, error) {
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1::int, $2::int)`, userID, taskID); err != nil {
+		zlog.Error().Err(err).Int("user_id", userID).Int("task_id", taskID).Msg("RepoTx: Error locking task assignment")
+		return false, fmt.Errorf("repoTx error checking existing task: %w", err)
+	}
+	var exists bool
+	err := tx.QueryRow(ctx, activeTaskExistsQuery, userID, taskID, models.UserTaskStatusAssigned, models.UserTaskStatusSubmitted).Scan(&exists)
+	if err != nil {
+		zlog.Error().Err(err).Int("user_id", userID).Int("task_id", taskID).Msg("RepoTx: Error checking for existing active task")
+		return false, fmt.Errorf("repoTx error checking existing task: %w", err)
+	}
+	return exists, nil
 }
\ No newline at end of file
@@ -0,0 +1,297 @@
+// internal/service/allowance_service_impl.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// allowanceBatchSize membatasi jumlah rencana yang dibaca worker per query.
+const allowanceBatchSize = 100
+
+// allowanceForbiddenMessage dipakai saat parent mengatur uang saku anak yang bukan anaknya.
+const allowanceForbiddenMessage = "you are not authorized to manage the allowance for this child"
+
+type allowanceServiceImpl struct {
+	pool             *pgxpool.Pool // Untuk transaksi pembayaran uang saku
+	allowanceRepo    repository.AllowanceRepository
+	pointRepo        repository.PointTransactionRepository
+	userRepo         repository.UserRepository // Zona waktu anak untuk validasi tanggal mulai
+	userRelRepo      repository.UserRelationshipRepository
+	notificationRepo repository.NotificationRepository
+	badgeService     BadgeService // Evaluasi badge saldo setelah uang saku dibayarkan
+}
+
+// NewAllowanceService creates a new instance of AllowanceService.
+func NewAllowanceService(
+	pool *pgxpool.Pool,
+	allowanceRepo repository.AllowanceRepository,
+	pointRepo repository.PointTransactionRepository,
+	userRepo repository.UserRepository,
+	userRelRepo repository.UserRelationshipRepository,
+	notificationRepo repository.NotificationRepository,
+	badgeService BadgeService,
+) AllowanceService {
+	return &allowanceServiceImpl{
+		pool:             pool,
+		allowanceRepo:    allowanceRepo,
+		pointRepo:        pointRepo,
+		userRepo:         userRepo,
+		userRelRepo:      userRelRepo,
+		notificationRepo: notificationRepo,
+		badgeService:     badgeService,
+	}
+}
+
+// --- Helper Functions ---
+
+// processNextPeriod memproses satu periode rencana yang sudah selesai dalam satu transaksi.
+// Rencana dikunci lebih dulu dan hasilnya dicatat di allowance_payouts (unique per periode), sehingga
+// worker yang berjalan berulang atau bersamaan tidak membayar periode yang sama dua kali.
+// Mengembalikan poin yang dibayarkan dan apakah ada periode yang diproses (false = tidak ada periode jatuh tempo).
+func (s *allowanceServiceImpl) processNextPeriod(ctx context.Context, planID int, now time.Time) (int, bool, error) {
+	paid := 0
+	processed := false
+	err := withTx(ctx, s.pool, "ProcessAllowance", func(tx pgx.Tx) error {
+		plan, err := s.allowanceRepo.GetPlanForUpdateTx(ctx, tx, planID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil // Rencana dihapus sejak dibaca
+			}
+			return fmt.Errorf("internal server error: could not retrieve allowance plan")
+		}
+		start, end, due := plan.DuePeriod(now)
+		if plan.IsPaused || !due {
+			return nil
+		}
+
+		loc := models.LoadTimezone(plan.Timezone)
+		approved, err := s.allowanceRepo.CountApprovedTasksTx(ctx, tx, plan.ChildID, models.LocalMidnight(start, loc), models.LocalMidnight(end, loc))
+		if err != nil {
+			return fmt.Errorf("internal server error: could not count approved tasks")
+		}
+		payout := &models.AllowancePayout{
+			PlanID:        plan.ID,
+			ChildID:       plan.ChildID,
+			PeriodStart:   start,
+			PeriodEnd:     end,
+			Amount:        plan.Amount,
+			Status:        models.AllowancePayoutPaid,
+			ApprovedTasks: approved,
+		}
+		if approved < plan.MinApprovedTasks {
+			payout.Amount = 0
+			payout.Status = models.AllowancePayoutSkipped
+			payout.Reason = fmt.Sprintf("only %d of %d required tasks were approved", approved, plan.MinApprovedTasks)
+		}
+
+		claimed, err := s.allowanceRepo.CreatePayoutTx(ctx, tx, payout)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not record allowance payout")
+		}
+		if err := s.allowanceRepo.AdvancePlanTx(ctx, tx, plan.ID, end); err != nil {
+			return fmt.Errorf("internal server error: could not advance allowance plan")
+		}
+		processed = true
+		if !claimed {
+			return nil // Periode sudah diproses sebelumnya
+		}
+
+		period := fmt.Sprintf("%s to %s", start.Format("2006-01-02"), end.AddDate(0, 0, -1).Format("2006-01-02"))
+		notification := &models.Notification{
+			UserID:     plan.ChildID,
+			Type:       models.NotificationAllowanceSkipped,
+			Title:      "Allowance not paid",
+			Message:    fmt.Sprintf("Your %s allowance for %s was not paid: %s.", plan.Cadence, period, payout.Reason),
+			EntityType: "allowance_payout",
+			EntityID:   payout.ID,
+		}
+		if payout.Status == models.AllowancePayoutPaid {
+			pointTx := &models.PointTransaction{
+				UserID:          plan.ChildID,
+				ChangeAmount:    plan.Amount,
+				TransactionType: models.TransactionTypeAllowance,
+				CreatedByUserID: plan.ParentID, // 0 = sistem (parent sudah dihapus)
+				Notes:           fmt.Sprintf("Allowance (%s) for %s", plan.Cadence, period),
+			}
+			if err := s.pointRepo.CreateTransactionTx(ctx, tx, pointTx); err != nil {
+				return fmt.Errorf("internal server error: could not record allowance")
+			}
+			if err := s.allowanceRepo.SetPayoutTransactionTx(ctx, tx, payout.ID, pointTx.ID); err != nil {
+				return fmt.Errorf("internal server error: could not record allowance payout")
+			}
+			notification.Type = models.NotificationAllowancePaid
+			notification.Title = "Allowance received"
+			notification.Message = fmt.Sprintf("You received your %s allowance of %d points for %s.", plan.Cadence, plan.Amount, period)
+			paid = plan.Amount
+		}
+		if err := s.notificationRepo.CreateNotificationTx(ctx, tx, notification); err != nil {
+			return fmt.Errorf("internal server error: could not send notification")
+		}
+		return nil
+	})
+	return paid, processed, err
+}
+
+// --- Public Methods ---
+
+// SetPlan membuat atau memperbarui rencana uang saku anak.
+// Tanggal mulai yang baru (atau frekuensi yang berubah) harus dimulai hari ini atau setelahnya
+// menurut zona waktu anak, sehingga periode yang sudah dibayar tidak terulang.
+func (s *allowanceServiceImpl) SetPlan(ctx context.Context, parentID int, childID int, input *models.SetAllowancePlanInput) (*models.AllowancePlan, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, allowanceForbiddenMessage); err != nil {
+		return nil, err
+	}
+	startDate, err := time.Parse("2006-01-02", input.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date: expected YYYY-MM-DD")
+	}
+	if input.Cadence == models.AllowanceCadenceMonthly && startDate.Day() > 28 {
+		return nil, fmt.Errorf("invalid start date: monthly allowances must start on day 1-28")
+	}
+
+	child, err := s.userRepo.GetUserByID(ctx, childID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		return nil, fmt.Errorf("internal server error: could not retrieve child")
+	}
+	existing, err := s.allowanceRepo.GetPlanByChildID(ctx, childID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("internal server error: could not retrieve allowance plan")
+	}
+	schedulingChanged := existing == nil || !existing.StartDate.Equal(startDate) || existing.Cadence != input.Cadence
+	if schedulingChanged && startDate.Before(models.LocalDate(time.Now(), models.LoadTimezone(child.Timezone))) {
+		return nil, fmt.Errorf("invalid start date: start_date cannot be in the past")
+	}
+
+	plan := &models.AllowancePlan{
+		ChildID:          childID,
+		ParentID:         parentID,
+		Amount:           input.Amount,
+		Cadence:          input.Cadence,
+		StartDate:        startDate,
+		MinApprovedTasks: input.MinApprovedTasks,
+		Timezone:         child.Timezone,
+	}
+	if err := s.allowanceRepo.UpsertPlan(ctx, plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// GetPlan mengambil rencana uang saku anak.
+func (s *allowanceServiceImpl) GetPlan(ctx context.Context, parentID int, childID int) (*models.AllowancePlan, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, allowanceForbiddenMessage); err != nil {
+		return nil, err
+	}
+	return s.allowanceRepo.GetPlanByChildID(ctx, childID)
+}
+
+// DeletePlan menghapus rencana uang saku anak.
+func (s *allowanceServiceImpl) DeletePlan(ctx context.Context, parentID int, childID int) error {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, allowanceForbiddenMessage); err != nil {
+		return err
+	}
+	return s.allowanceRepo.DeletePlan(ctx, childID)
+}
+
+// PausePlan menjeda rencana uang saku anak.
+func (s *allowanceServiceImpl) PausePlan(ctx context.Context, parentID int, childID int) (*models.AllowancePlan, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, allowanceForbiddenMessage); err != nil {
+		return nil, err
+	}
+	if err := s.allowanceRepo.PausePlan(ctx, childID); err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		// Bedakan rencana yang tidak ada dengan yang sudah dijeda
+		if _, getErr := s.allowanceRepo.GetPlanByChildID(ctx, childID); getErr != nil {
+			return nil, getErr
+		}
+		return nil, fmt.Errorf("cannot pause: allowance plan is already paused")
+	}
+	return s.allowanceRepo.GetPlanByChildID(ctx, childID)
+}
+
+// ResumePlan melanjutkan rencana uang saku anak yang dijeda.
+func (s *allowanceServiceImpl) ResumePlan(ctx context.Context, parentID int, childID int, now time.Time) (*models.AllowancePlan, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, allowanceForbiddenMessage); err != nil {
+		return nil, err
+	}
+	plan, err := s.allowanceRepo.GetPlanByChildID(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.IsPaused {
+		return nil, fmt.Errorf("cannot resume: allowance plan is not paused")
+	}
+	if err := s.allowanceRepo.ResumePlan(ctx, childID, plan.CurrentPeriodStart(now)); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("cannot resume: allowance plan is not paused")
+		}
+		return nil, err
+	}
+	return s.allowanceRepo.GetPlanByChildID(ctx, childID)
+}
+
+// GetPayoutsForParent mengambil riwayat pembayaran uang saku anak untuk orang tuanya.
+func (s *allowanceServiceImpl) GetPayoutsForParent(ctx context.Context, parentID int, childID int, page, limit int) ([]models.AllowancePayout, int, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, allowanceForbiddenMessage); err != nil {
+		return nil, 0, err
+	}
+	return s.allowanceRepo.GetPayoutsByChildID(ctx, childID, page, limit)
+}
+
+// GetMyPayouts mengambil riwayat pembayaran uang saku milik anak.
+func (s *allowanceServiceImpl) GetMyPayouts(ctx context.Context, childID int, page, limit int) ([]models.AllowancePayout, int, error) {
+	return s.allowanceRepo.GetPayoutsByChildID(ctx, childID, page, limit)
+}
+
+// ProcessAllowances memproses semua periode yang sudah selesai untuk rencana aktif (dipanggil oleh worker).
+func (s *allowanceServiceImpl) ProcessAllowances(ctx context.Context, now time.Time) (int, error) {
+	total := 0
+	afterID := 0
+	for {
+		plans, err := s.allowanceRepo.GetActivePlansAfter(ctx, afterID, allowanceBatchSize)
+		if err != nil {
+			return total, err
+		}
+		for _, plan := range plans {
+			// Proses periode satu per satu hingga tidak ada lagi yang jatuh tempo (mengejar periode yang terlewat)
+			planPaid := 0
+			for {
+				paid, processed, err := s.processNextPeriod(ctx, plan.ID, now)
+				if err != nil {
+					zlog.Error().Err(err).Int("plan_id", plan.ID).Int("child_id", plan.ChildID).Msg("Service: Failed to process allowance")
+					break
+				}
+				if !processed {
+					break
+				}
+				planPaid += paid
+			}
+			total += planPaid
+			if planPaid > 0 {
+				evaluateBadgesAfterCommit(ctx, s.badgeService, plan.ChildID)
+			}
+		}
+		if len(plans) < allowanceBatchSize {
+			break
+		}
+		afterID = plans[len(plans)-1].ID
+	}
+	if total > 0 {
+		zlog.Info().Int("allowance_points", total).Msg("Service: Allowances paid")
+	}
+	return total, nil
+}
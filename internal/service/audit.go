@@ -0,0 +1,42 @@
+// internal/service/audit.go
+package service
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// newAuditEntry menyusun entri audit. actorID bernilai SystemActorID untuk tindakan oleh sistem
+// (misal: worker background) sehingga pelaku dicatat sebagai 'system'.
+func newAuditEntry(actorID int, action string, entityType string, entityID int, details map[string]any) *models.AuditLog {
+	entry := &models.AuditLog{
+		ActorUserID: actorID,
+		ActorType:   models.AuditActorUser,
+		Action:      action,
+		EntityType:  entityType,
+		EntityID:    entityID,
+		Details:     details,
+	}
+	if actorID == SystemActorID {
+		entry.ActorType = models.AuditActorSystem
+	}
+	return entry
+}
+
+// recordAuditTx mencatat entri audit di dalam transaksi yang sedang berjalan,
+// sehingga catatan audit ikut di-rollback jika operasinya gagal.
+func recordAuditTx(ctx context.Context, tx pgx.Tx, auditRepo repository.AuditLogRepository, actorID int, action string, entityType string, entityID int, details map[string]any) error {
+	return auditRepo.CreateLogTx(ctx, tx, newAuditEntry(actorID, action, entityType, entityID, details))
+}
+
+// recordAudit mencatat entri audit untuk operasi non-transaksional. Kegagalan hanya di-log
+// karena operasi utamanya sudah tersimpan.
+func recordAudit(ctx context.Context, auditRepo repository.AuditLogRepository, actorID int, action string, entityType string, entityID int, details map[string]any) {
+	if err := auditRepo.CreateLog(ctx, newAuditEntry(actorID, action, entityType, entityID, details)); err != nil {
+		zlog.Warn().Err(err).Str("action", action).Int("entity_id", entityID).Msg("Service: Failed to record audit log")
+	}
+}
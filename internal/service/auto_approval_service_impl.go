@@ -0,0 +1,152 @@
+// internal/service/auto_approval_service_impl.go
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type autoApprovalServiceImpl struct {
+	policyRepo  repository.AutoApprovalPolicyRepository
+	taskRepo    repository.TaskRepository
+	userRelRepo repository.UserRelationshipRepository
+	auditRepo   repository.AuditLogRepository
+}
+
+// NewAutoApprovalService creates a new instance of AutoApprovalService.
+func NewAutoApprovalService(
+	policyRepo repository.AutoApprovalPolicyRepository,
+	taskRepo repository.TaskRepository,
+	userRelRepo repository.UserRelationshipRepository,
+	auditRepo repository.AuditLogRepository,
+) AutoApprovalService {
+	return &autoApprovalServiceImpl{
+		policyRepo:  policyRepo,
+		taskRepo:    taskRepo,
+		userRelRepo: userRelRepo,
+		auditRepo:   auditRepo,
+	}
+}
+
+// --- Helper Functions ---
+
+// getOwnedPolicy mengambil kebijakan dan memastikan parent adalah pemiliknya.
+func (s *autoApprovalServiceImpl) getOwnedPolicy(ctx context.Context, policyID int, parentID int) (*models.AutoApprovalPolicy, error) {
+	policy, err := s.policyRepo.GetPolicyByID(ctx, policyID)
+	if err != nil {
+		return nil, err // pgx.ErrNoRows diteruskan agar handler mengembalikan 404
+	}
+	if policy.CreatedByUserID != parentID {
+		return nil, fmt.Errorf("forbidden: you are not authorized to manage this auto-approval policy")
+	}
+	return policy, nil
+}
+
+// policyAuditDetails menyusun detail audit untuk perubahan kebijakan.
+func policyAuditDetails(policy *models.AutoApprovalPolicy) map[string]any {
+	return map[string]any{
+		"task_id":              policy.TaskID,
+		"child_id":             policy.ChildID,
+		"grace_period_minutes": policy.GracePeriodMinutes,
+		"is_active":            policy.IsActive,
+	}
+}
+
+// --- Service Methods ---
+
+// CreatePolicy membuat kebijakan auto-approval untuk tugas dan/atau anak milik parent.
+func (s *autoApprovalServiceImpl) CreatePolicy(ctx context.Context, parentID int, input *models.CreateAutoApprovalPolicyInput) (int, error) {
+	log := zlog.With().Int("parent_id", parentID).Int("task_id", input.TaskID).Int("child_id", input.ChildID).Logger()
+
+	// 1. Validasi definisi tugas (pembuat atau satu keluarga)
+	if input.TaskID > 0 {
+		task, err := s.taskRepo.GetTaskByID(ctx, input.TaskID)
+		if err != nil {
+			return 0, err
+		}
+		if task.CreatedByUserID != parentID {
+			hasSharedChild, err := s.userRelRepo.HasSharedChild(ctx, parentID, task.CreatedByUserID)
+			if err != nil {
+				log.Error().Err(err).Msg("Service: Error checking shared child for auto-approval policy")
+				return 0, fmt.Errorf("internal server error: could not verify relationship")
+			}
+			if !hasSharedChild {
+				return 0, fmt.Errorf("forbidden: you are not authorized to use this task definition")
+			}
+		}
+	}
+
+	// 2. Validasi anak
+	if input.ChildID > 0 {
+		isParent, err := s.userRelRepo.IsParentOf(ctx, parentID, input.ChildID)
+		if err != nil {
+			log.Error().Err(err).Msg("Service: Error checking relationship for auto-approval policy")
+			return 0, fmt.Errorf("internal server error: could not verify relationship")
+		}
+		if !isParent {
+			return 0, fmt.Errorf("forbidden: you are not authorized to manage this child")
+		}
+	}
+
+	policy := &models.AutoApprovalPolicy{
+		CreatedByUserID:    parentID,
+		TaskID:             input.TaskID,
+		ChildID:            input.ChildID,
+		GracePeriodMinutes: input.GracePeriodMinutes,
+		IsActive:           true,
+	}
+	policyID, err := s.policyRepo.CreatePolicy(ctx, policy)
+	if err != nil {
+		return 0, err
+	}
+
+	recordAudit(ctx, s.auditRepo, parentID, "auto_approval_policy.created", "auto_approval_policy", policyID, policyAuditDetails(policy))
+	log.Info().Int("policy_id", policyID).Msg("Service: Auto-approval policy created")
+	return policyID, nil
+}
+
+// GetPoliciesForParent mengambil semua kebijakan milik parent.
+func (s *autoApprovalServiceImpl) GetPoliciesForParent(ctx context.Context, parentID int) ([]models.AutoApprovalPolicy, error) {
+	return s.policyRepo.GetPoliciesByCreatorID(ctx, parentID)
+}
+
+// UpdatePolicy memperbarui masa tenggang dan/atau status aktif kebijakan.
+func (s *autoApprovalServiceImpl) UpdatePolicy(ctx context.Context, policyID int, parentID int, input *models.UpdateAutoApprovalPolicyInput) error {
+	policy, err := s.getOwnedPolicy(ctx, policyID, parentID)
+	if err != nil {
+		return err
+	}
+	if input.GracePeriodMinutes == nil && input.IsActive == nil {
+		return fmt.Errorf("invalid input: at least one field must be provided")
+	}
+	if input.GracePeriodMinutes != nil {
+		policy.GracePeriodMinutes = *input.GracePeriodMinutes
+	}
+	if input.IsActive != nil {
+		policy.IsActive = *input.IsActive
+	}
+	if err := s.policyRepo.UpdatePolicy(ctx, policy); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, s.auditRepo, parentID, "auto_approval_policy.updated", "auto_approval_policy", policyID, policyAuditDetails(policy))
+	return nil
+}
+
+// DeletePolicy menghapus kebijakan milik parent.
+func (s *autoApprovalServiceImpl) DeletePolicy(ctx context.Context, policyID int, parentID int) error {
+	policy, err := s.getOwnedPolicy(ctx, policyID, parentID)
+	if err != nil {
+		return err
+	}
+	if err := s.policyRepo.DeletePolicy(ctx, policyID); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, s.auditRepo, parentID, "auto_approval_policy.deleted", "auto_approval_policy", policyID, policyAuditDetails(policy))
+	return nil
+}
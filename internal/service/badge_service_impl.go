@@ -0,0 +1,332 @@
+// internal/service/badge_service_impl.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// badgeBatchSize membatasi jumlah anak yang dievaluasi worker badge dalam satu putaran.
+const badgeBatchSize = 100
+
+// badgeForbiddenMessage dipakai saat parent mengelola badge anak yang bukan anaknya.
+const badgeForbiddenMessage = "you are not authorized to manage badges for this child"
+
+type badgeServiceImpl struct {
+	badgeRepo        repository.BadgeRepository
+	streakRepo       repository.StreakRepository           // Hari yang dilindungi streak freeze untuk aturan 'task_streak'
+	pointRepo        repository.PointTransactionRepository // Saldo untuk aturan 'points_balance'
+	taskRepo         repository.TaskRepository             // Validasi kepemilikan tugas pada aturan tugas
+	userRepo         repository.UserRepository             // Zona waktu anak untuk aturan 'task_streak'
+	userRelRepo      repository.UserRelationshipRepository
+	notificationRepo repository.NotificationRepository
+}
+
+// NewBadgeService creates a new instance of BadgeService.
+func NewBadgeService(
+	badgeRepo repository.BadgeRepository,
+	streakRepo repository.StreakRepository,
+	pointRepo repository.PointTransactionRepository,
+	taskRepo repository.TaskRepository,
+	userRepo repository.UserRepository,
+	userRelRepo repository.UserRelationshipRepository,
+	notificationRepo repository.NotificationRepository,
+) BadgeService {
+	return &badgeServiceImpl{
+		badgeRepo:        badgeRepo,
+		streakRepo:       streakRepo,
+		pointRepo:        pointRepo,
+		taskRepo:         taskRepo,
+		userRepo:         userRepo,
+		userRelRepo:      userRelRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+// --- Helper Functions ---
+
+// getOwnedBadge mengambil badge dan memastikan badge custom tersebut milik parentID.
+func (s *badgeServiceImpl) getOwnedBadge(ctx context.Context, parentID int, badgeID int) (*models.Badge, error) {
+	badge, err := s.badgeRepo.GetBadgeByID(ctx, badgeID)
+	if err != nil {
+		return nil, err
+	}
+	if badge.CreatedByUserID != parentID {
+		return nil, fmt.Errorf("forbidden: you can only manage badges you created")
+	}
+	return badge, nil
+}
+
+// newBadgeFromInput memvalidasi aturan badge dan menyusun badge custom milik parentID.
+func (s *badgeServiceImpl) newBadgeFromInput(ctx context.Context, parentID int, input *models.BadgeInput) (*models.Badge, error) {
+	if input.RuleType == models.BadgeRuleManual && input.Threshold != 0 {
+		return nil, fmt.Errorf("invalid threshold: manual badges have no threshold")
+	}
+	if input.RuleType != models.BadgeRuleManual && input.Threshold <= 0 {
+		return nil, fmt.Errorf("invalid threshold: must be greater than 0 for rule '%s'", input.RuleType)
+	}
+	if input.TaskID != 0 {
+		if input.RuleType != models.BadgeRuleTasksCompleted && input.RuleType != models.BadgeRuleTaskStreak {
+			return nil, fmt.Errorf("invalid task_id: only tasks_completed and task_streak rules can target a task")
+		}
+		task, err := s.taskRepo.GetTaskByID(ctx, input.TaskID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("invalid task_id: task %d not found", input.TaskID)
+			}
+			return nil, fmt.Errorf("internal server error: could not retrieve task")
+		}
+		if task.CreatedByUserID != parentID {
+			return nil, fmt.Errorf("forbidden: badge task must be created by you")
+		}
+	}
+	return &models.Badge{
+		CreatedByUserID: parentID,
+		Name:            input.Name,
+		Description:     input.Description,
+		Icon:            input.Icon,
+		RuleType:        input.RuleType,
+		Threshold:       input.Threshold,
+		TaskID:          input.TaskID,
+	}, nil
+}
+
+// badgeMetrics menghitung metrik aturan badge seorang anak sekali per evaluasi.
+type badgeMetrics struct {
+	s        *badgeServiceImpl
+	childID  int
+	loc      *time.Location
+	balance  *int
+	claims   *int
+	approved map[int]int // taskID (0 = semua) -> jumlah tugas disetujui
+}
+
+// reached mengembalikan true jika anak sudah memenuhi aturan badge.
+func (m *badgeMetrics) reached(ctx context.Context, badge *models.Badge) (bool, error) {
+	switch badge.RuleType {
+	case models.BadgeRuleTasksCompleted:
+		count, ok := m.approved[badge.TaskID]
+		if !ok {
+			var err error
+			if count, err = m.s.badgeRepo.CountApprovedTasks(ctx, m.childID, badge.TaskID); err != nil {
+				return false, err
+			}
+			m.approved[badge.TaskID] = count
+		}
+		return count >= badge.Threshold, nil
+	case models.BadgeRuleTaskStreak:
+		today := models.LocalDate(time.Now(), m.loc)
+		// Cukup ambil penyelesaian sejak threshold hari sebelum kemarin
+		since := models.LocalMidnight(today.AddDate(0, 0, -badge.Threshold), m.loc)
+		times, err := m.s.badgeRepo.GetApprovedTaskCompletionTimes(ctx, m.childID, badge.TaskID, since)
+		if err != nil {
+			return false, err
+		}
+		frozen, err := m.s.streakRepo.GetFrozenDays(ctx, m.childID, today.AddDate(0, 0, -badge.Threshold))
+		if err != nil {
+			return false, err
+		}
+		streak, _ := models.StreakRunWithFreezes(localDays(times, m.loc), frozen, today)
+		return streak >= badge.Threshold, nil
+	case models.BadgeRulePointsBalance:
+		if m.balance == nil {
+			balance, err := m.s.pointRepo.CalculateTotalPointsByUserID(ctx, m.childID)
+			if err != nil {
+				return false, err
+			}
+			m.balance = &balance
+		}
+		return *m.balance >= badge.Threshold, nil
+	case models.BadgeRuleRewardsClaimed:
+		if m.claims == nil {
+			claims, err := m.s.badgeRepo.CountRewardClaims(ctx, m.childID)
+			if err != nil {
+				return false, err
+			}
+			m.claims = &claims
+		}
+		return *m.claims >= badge.Threshold, nil
+	default:
+		return false, nil // 'manual' hanya diberikan parent
+	}
+}
+
+// award mencatat badge untuk anak dan mengirim notifikasi. Mengembalikan nil jika badge sudah dimiliki.
+func (s *badgeServiceImpl) award(ctx context.Context, childID int, badge *models.Badge, awardedByID int) (*models.ChildBadge, error) {
+	award := &models.ChildBadge{ChildID: childID, Badge: *badge, AwardedByUserID: awardedByID}
+	awarded, err := s.badgeRepo.AwardBadge(ctx, award)
+	if err != nil || !awarded {
+		return nil, err
+	}
+	err = s.notificationRepo.CreateNotification(ctx, &models.Notification{
+		UserID:     childID,
+		Type:       models.NotificationBadgeAwarded,
+		Title:      "New badge earned",
+		Message:    fmt.Sprintf("You earned the '%s' badge!", badge.Name),
+		EntityType: "badge",
+		EntityID:   badge.ID,
+	})
+	if err != nil {
+		// Badge sudah tersimpan; kegagalan notifikasi hanya di-log
+		zlog.Warn().Err(err).Int("child_id", childID).Int("badge_id", badge.ID).Msg("Service: Failed to send badge notification")
+	}
+	return award, nil
+}
+
+// --- Public Methods ---
+
+// CreateBadge membuat badge custom milik parent.
+func (s *badgeServiceImpl) CreateBadge(ctx context.Context, parentID int, input *models.BadgeInput) (*models.Badge, error) {
+	badge, err := s.newBadgeFromInput(ctx, parentID, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.badgeRepo.CreateBadge(ctx, badge); err != nil {
+		return nil, err
+	}
+	return badge, nil
+}
+
+// GetBadges mengambil badge sistem dan badge custom milik parent.
+func (s *badgeServiceImpl) GetBadges(ctx context.Context, parentID int) ([]models.Badge, error) {
+	return s.badgeRepo.GetBadgesForParent(ctx, parentID)
+}
+
+// UpdateBadge memperbarui badge custom milik parent.
+func (s *badgeServiceImpl) UpdateBadge(ctx context.Context, parentID int, badgeID int, input *models.BadgeInput) (*models.Badge, error) {
+	if _, err := s.getOwnedBadge(ctx, parentID, badgeID); err != nil {
+		return nil, err
+	}
+	badge, err := s.newBadgeFromInput(ctx, parentID, input)
+	if err != nil {
+		return nil, err
+	}
+	badge.ID = badgeID
+	if err := s.badgeRepo.UpdateBadge(ctx, badge); err != nil {
+		return nil, err
+	}
+	// Ambil ulang agar nama tugas (join) ikut terisi
+	return s.badgeRepo.GetBadgeByID(ctx, badgeID)
+}
+
+// DeleteBadge menghapus badge custom milik parent.
+func (s *badgeServiceImpl) DeleteBadge(ctx context.Context, parentID int, badgeID int) error {
+	if _, err := s.getOwnedBadge(ctx, parentID, badgeID); err != nil {
+		return err
+	}
+	return s.badgeRepo.DeleteBadge(ctx, badgeID, parentID)
+}
+
+// AwardBadge memberikan badge manual milik parent kepada anaknya.
+func (s *badgeServiceImpl) AwardBadge(ctx context.Context, parentID int, childID int, badgeID int) (*models.ChildBadge, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, badgeForbiddenMessage); err != nil {
+		return nil, err
+	}
+	badge, err := s.getOwnedBadge(ctx, parentID, badgeID)
+	if err != nil {
+		return nil, err
+	}
+	if badge.RuleType != models.BadgeRuleManual {
+		return nil, fmt.Errorf("cannot award badge: '%s' badges are awarded automatically", badge.RuleType)
+	}
+	award, err := s.award(ctx, childID, badge, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if award == nil {
+		return nil, fmt.Errorf("badge already exists for this child")
+	}
+	return award, nil
+}
+
+// GetChildBadges mengambil badge yang sudah diraih anak (Parent).
+func (s *badgeServiceImpl) GetChildBadges(ctx context.Context, parentID int, childID int) ([]models.ChildBadge, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, badgeForbiddenMessage); err != nil {
+		return nil, err
+	}
+	return s.badgeRepo.GetChildBadges(ctx, childID)
+}
+
+// GetMyBadges mengambil badge yang sudah diraih anak sendiri.
+func (s *badgeServiceImpl) GetMyBadges(ctx context.Context, childID int) ([]models.ChildBadge, error) {
+	return s.badgeRepo.GetChildBadges(ctx, childID)
+}
+
+// EvaluateChild memberikan semua badge berbasis aturan yang sudah terpenuhi oleh anak. Pemberian bersifat
+// idempoten, sehingga aman dipanggil berulang kali (misal dari beberapa hook sekaligus).
+func (s *badgeServiceImpl) EvaluateChild(ctx context.Context, childID int) ([]models.ChildBadge, error) {
+	badges, err := s.badgeRepo.GetUnearnedRuleBadges(ctx, childID)
+	if err != nil || len(badges) == 0 {
+		return []models.ChildBadge{}, err
+	}
+	child, err := s.userRepo.GetUserByID(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+	metrics := &badgeMetrics{s: s, childID: childID, loc: models.LoadTimezone(child.Timezone), approved: map[int]int{}}
+
+	awarded := []models.ChildBadge{}
+	for i := range badges {
+		ok, err := metrics.reached(ctx, &badges[i])
+		if err != nil {
+			return awarded, err
+		}
+		if !ok {
+			continue
+		}
+		award, err := s.award(ctx, childID, &badges[i], 0)
+		if err != nil {
+			return awarded, err
+		}
+		if award != nil {
+			awarded = append(awarded, *award)
+		}
+	}
+	if len(awarded) > 0 {
+		zlog.Info().Int("child_id", childID).Int("awarded", len(awarded)).Msg("Service: Badges awarded")
+	}
+	return awarded, nil
+}
+
+// ProcessBalanceBadges mengevaluasi anak yang saldonya sudah memenuhi badge 'points_balance' (dipanggil oleh worker).
+func (s *badgeServiceImpl) ProcessBalanceBadges(ctx context.Context) (int, error) {
+	processed := 0
+	afterChildID := 0
+	for {
+		childIDs, err := s.badgeRepo.GetChildIDsWithReachableBalanceBadges(ctx, afterChildID, badgeBatchSize)
+		if err != nil {
+			return processed, err
+		}
+		for _, childID := range childIDs {
+			awarded, err := s.EvaluateChild(ctx, childID)
+			if err != nil {
+				zlog.Error().Err(err).Int("child_id", childID).Msg("Service: Failed to evaluate badges")
+				continue
+			}
+			processed += len(awarded)
+		}
+		if len(childIDs) < badgeBatchSize {
+			break
+		}
+		afterChildID = childIDs[len(childIDs)-1]
+	}
+	return processed, nil
+}
+
+// evaluateBadgesAfterCommit menjalankan evaluasi badge setelah operasi utama tersimpan.
+// Kegagalan hanya di-log agar tidak memengaruhi hasil operasi utama.
+func evaluateBadgesAfterCommit(ctx context.Context, badgeService BadgeService, childID int) {
+	if badgeService == nil || childID == 0 {
+		return
+	}
+	if _, err := badgeService.EvaluateChild(ctx, childID); err != nil {
+		zlog.Warn().Err(err).Int("child_id", childID).Msg("Service: Failed to evaluate badges")
+	}
+}
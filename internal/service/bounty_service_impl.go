@@ -0,0 +1,187 @@
+// internal/service/bounty_service_impl.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// ErrBountyUnavailable dikembalikan saat bounty sudah tidak bisa diklaim (dibatalkan, penuh, atau kedaluwarsa).
+var ErrBountyUnavailable = errors.New("bounty is no longer available")
+
+// ErrBountyAlreadyClaimed dikembalikan saat anak mencoba mengklaim bounty yang sama lebih dari sekali.
+var ErrBountyAlreadyClaimed = errors.New("you have already claimed this bounty")
+
+type bountyServiceImpl struct {
+	pool         *pgxpool.Pool // Untuk transaksi klaim
+	bountyRepo   repository.TaskBountyRepository
+	taskRepo     repository.TaskRepository
+	userTaskRepo repository.UserTaskRepository
+	userRelRepo  repository.UserRelationshipRepository
+}
+
+// NewBountyService creates a new instance of BountyService.
+func NewBountyService(
+	pool *pgxpool.Pool,
+	bountyRepo repository.TaskBountyRepository,
+	taskRepo repository.TaskRepository,
+	userTaskRepo repository.UserTaskRepository,
+	userRelRepo repository.UserRelationshipRepository,
+) BountyService {
+	return &bountyServiceImpl{
+		pool:         pool,
+		bountyRepo:   bountyRepo,
+		taskRepo:     taskRepo,
+		userTaskRepo: userTaskRepo,
+		userRelRepo:  userRelRepo,
+	}
+}
+
+// --- Helper Functions ---
+
+// canManageParentResource memeriksa apakah parent boleh mengelola data milik creatorID:
+// pembuatnya sendiri, atau parent lain yang memiliki anak bersama dengan pembuat (satu keluarga).
+func (s *bountyServiceImpl) canManageParentResource(ctx context.Context, parentID int, creatorID int) (bool, error) {
+	if creatorID == parentID {
+		return true, nil
+	}
+	hasSharedChild, err := s.userRelRepo.HasSharedChild(ctx, parentID, creatorID)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", parentID).Int("creator_id", creatorID).Msg("Service: Error checking shared child for bounty access")
+		return false, fmt.Errorf("internal server error: could not verify relationship")
+	}
+	return hasSharedChild, nil
+}
+
+// --- Service Methods ---
+
+// CreateBounty memposting definisi tugas sebagai bounty terbuka.
+func (s *bountyServiceImpl) CreateBounty(ctx context.Context, parentID int, input *models.CreateBountyInput) (int, error) {
+	log := zlog.With().Int("parent_id", parentID).Int("task_id", input.TaskID).Logger()
+
+	// 1. Validasi definisi tugas & hak akses (pembuat atau satu keluarga)
+	task, err := s.taskRepo.GetTaskByID(ctx, input.TaskID)
+	if err != nil {
+		return 0, err
+	}
+	allowed, err := s.canManageParentResource(ctx, parentID, task.CreatedByUserID)
+	if err != nil {
+		return 0, err
+	}
+	if !allowed {
+		return 0, fmt.Errorf("forbidden: you are not authorized to use this task definition")
+	}
+
+	// 2. Validasi batas klaim & kedaluwarsa
+	maxClaims := input.MaxClaims
+	if maxClaims <= 0 {
+		maxClaims = 1
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return 0, fmt.Errorf("invalid expires_at: must be in the future")
+	}
+
+	bounty := &models.TaskBounty{
+		TaskID:          task.ID,
+		CreatedByUserID: parentID,
+		MaxClaims:       maxClaims,
+		ExpiresAt:       input.ExpiresAt,
+	}
+	bountyID, err := s.bountyRepo.CreateBounty(ctx, bounty)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Info().Int("bounty_id", bountyID).Msg("Service: Task bounty created")
+	return bountyID, nil
+}
+
+// GetBountiesForParent mengambil daftar bounty milik parent.
+func (s *bountyServiceImpl) GetBountiesForParent(ctx context.Context, parentID int, page, limit int) ([]models.TaskBounty, int, error) {
+	return s.bountyRepo.GetBountiesByCreatorID(ctx, parentID, page, limit)
+}
+
+// CancelBounty membatalkan bounty jika parent berhak dan bounty masih terbuka.
+func (s *bountyServiceImpl) CancelBounty(ctx context.Context, bountyID int, parentID int) error {
+	bounty, err := s.bountyRepo.GetBountyByID(ctx, bountyID)
+	if err != nil {
+		return err // pgx.ErrNoRows diteruskan agar handler mengembalikan 404
+	}
+	allowed, err := s.canManageParentResource(ctx, parentID, bounty.CreatedByUserID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("forbidden: you are not authorized to cancel this bounty")
+	}
+	return s.bountyRepo.CancelBounty(ctx, bountyID)
+}
+
+// GetOpenBountiesForChild mengambil bounty yang masih bisa diklaim oleh anak.
+func (s *bountyServiceImpl) GetOpenBountiesForChild(ctx context.Context, childID int, page, limit int) ([]models.TaskBounty, int, error) {
+	return s.bountyRepo.GetOpenBountiesForChild(ctx, childID, page, limit)
+}
+
+// ClaimBounty mengklaim bounty untuk anak. Baris bounty dikunci (FOR UPDATE) sehingga saat beberapa anak
+// mengklaim bersamaan, hanya klaim yang masih dalam batas max_claims yang berhasil.
+func (s *bountyServiceImpl) ClaimBounty(ctx context.Context, childID int, bountyID int) (int, error) {
+	log := zlog.With().Int("child_id", childID).Int("bounty_id", bountyID).Logger()
+	var userTaskID int
+
+	err := withTx(ctx, s.pool, "ClaimBounty", func(tx pgx.Tx) error {
+		// 1. Kunci bounty
+		bounty, err := s.bountyRepo.GetBountyForUpdateTx(ctx, tx, bountyID)
+		if err != nil {
+			return err
+		}
+
+		// 2. Bounty hanya terlihat oleh anak dari parent yang memposting
+		isParent, err := s.userRelRepo.IsParentOfTx(ctx, tx, bounty.CreatedByUserID, childID)
+		if err != nil {
+			log.Error().Err(err).Msg("Service: Error checking relationship for bounty claim")
+			return fmt.Errorf("internal server error: could not verify relationship")
+		}
+		if !isParent {
+			return fmt.Errorf("forbidden: this bounty is not available to you")
+		}
+
+		// 3. Validasi status, kedaluwarsa & batas klaim
+		if bounty.Status != models.BountyStatusOpen || bounty.ClaimsCount >= bounty.MaxClaims {
+			return ErrBountyUnavailable
+		}
+		if bounty.ExpiresAt != nil && !bounty.ExpiresAt.After(time.Now()) {
+			return ErrBountyUnavailable
+		}
+		alreadyClaimed, err := s.bountyRepo.HasChildClaimedTx(ctx, tx, bountyID, childID)
+		if err != nil {
+			return err
+		}
+		if alreadyClaimed {
+			return ErrBountyAlreadyClaimed
+		}
+
+		// 4. Tugaskan ke anak (pemberi tugas = parent yang memposting), catat klaim & tambah hitungan
+		userTaskID, err = s.userTaskRepo.AssignTaskTx(ctx, tx, childID, bounty.TaskID, bounty.CreatedByUserID, nil)
+		if err != nil {
+			return err
+		}
+		if _, err := s.bountyRepo.CreateClaimTx(ctx, tx, bountyID, childID, userTaskID); err != nil {
+			return err
+		}
+		return s.bountyRepo.IncrementClaimsTx(ctx, tx, bountyID)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	log.Info().Int("user_task_id", userTaskID).Msg("Service: Task bounty claimed")
+	return userTaskID, nil
+}
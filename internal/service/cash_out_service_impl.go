@@ -0,0 +1,343 @@
+// internal/service/cash_out_service_impl.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// maxCashOutAmountMinor membatasi nilai uang satu permintaan cash-out (satuan terkecil).
+const maxCashOutAmountMinor = 1000000000000
+
+// cashOutForbiddenMessage dipakai saat parent memproses pencairan anak yang bukan anaknya.
+const cashOutForbiddenMessage = "you are not authorized to manage cash-outs for this child"
+
+type cashOutServiceImpl struct {
+	pool             *pgxpool.Pool // Untuk transaksi pengurangan poin & review
+	cashOutRepo      repository.CashOutRepository
+	pointRepo        repository.PointTransactionRepository
+	goalRepo         repository.SavingsGoalRepository // Poin yang disisihkan untuk target tabungan tidak bisa dicairkan
+	userRepo         repository.UserRepository        // Zona waktu anak untuk laporan bulanan
+	userRelRepo      repository.UserRelationshipRepository
+	notificationRepo repository.NotificationRepository
+	badgeService     BadgeService // Evaluasi badge saldo setelah poin cash-out dikembalikan
+}
+
+// NewCashOutService creates a new instance of CashOutService.
+func NewCashOutService(
+	pool *pgxpool.Pool,
+	cashOutRepo repository.CashOutRepository,
+	pointRepo repository.PointTransactionRepository,
+	goalRepo repository.SavingsGoalRepository,
+	userRepo repository.UserRepository,
+	userRelRepo repository.UserRelationshipRepository,
+	notificationRepo repository.NotificationRepository,
+	badgeService BadgeService,
+) CashOutService {
+	return &cashOutServiceImpl{
+		pool:             pool,
+		cashOutRepo:      cashOutRepo,
+		pointRepo:        pointRepo,
+		goalRepo:         goalRepo,
+		userRepo:         userRepo,
+		userRelRepo:      userRelRepo,
+		notificationRepo: notificationRepo,
+		badgeService:     badgeService,
+	}
+}
+
+// --- Helper Functions ---
+
+// formatMinor menampilkan nilai uang satuan terkecil untuk pesan notifikasi (misal "1500 USD minor units").
+func formatMinor(amountMinor int64, currencyCode string) string {
+	return fmt.Sprintf("%d %s minor units", amountMinor, currencyCode)
+}
+
+// --- Public Methods ---
+
+// SetPolicy membuat atau memperbarui kurs pencairan milik parent untuk semua anaknya (childID 0) atau satu anak.
+func (s *cashOutServiceImpl) SetPolicy(ctx context.Context, parentID int, childID int, input *models.SetCashOutPolicyInput) (*models.CashOutPolicy, error) {
+	if childID != 0 {
+		if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, cashOutForbiddenMessage); err != nil {
+			return nil, err
+		}
+	}
+	if input.MinPoints > 0 && input.MinPoints%input.Points != 0 {
+		return nil, fmt.Errorf("invalid min_points: must be a multiple of %d", input.Points)
+	}
+	policy := &models.CashOutPolicy{
+		CreatedByUserID: parentID,
+		ChildID:         childID,
+		Points:          input.Points,
+		MinorUnits:      input.MinorUnits,
+		CurrencyCode:    input.CurrencyCode,
+		MinPoints:       input.MinPoints,
+	}
+	if err := s.cashOutRepo.UpsertPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// GetPolicy mengambil kurs keluarga milik parent (childID 0), atau kurs yang berlaku untuk anak.
+func (s *cashOutServiceImpl) GetPolicy(ctx context.Context, parentID int, childID int) (*models.CashOutPolicy, error) {
+	if childID == 0 {
+		return s.cashOutRepo.GetPolicyByOwner(ctx, parentID, 0)
+	}
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, cashOutForbiddenMessage); err != nil {
+		return nil, err
+	}
+	return s.cashOutRepo.GetPolicyForChild(ctx, childID)
+}
+
+// DeletePolicy menghapus kurs pencairan milik parent untuk semua anak (childID 0) atau satu anak.
+func (s *cashOutServiceImpl) DeletePolicy(ctx context.Context, parentID int, childID int) error {
+	if childID != 0 {
+		if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, cashOutForbiddenMessage); err != nil {
+			return err
+		}
+	}
+	return s.cashOutRepo.DeletePolicy(ctx, parentID, childID)
+}
+
+// RequestCashOut mengajukan pencairan poin. Nilai uang dihitung dan disimpan sesuai kurs saat permintaan,
+// sehingga perubahan kurs setelahnya tidak memengaruhi permintaan yang sudah ada.
+func (s *cashOutServiceImpl) RequestCashOut(ctx context.Context, childID int, input *models.CreateCashOutInput) (*models.CashOutRequest, error) {
+	policy, err := s.cashOutRepo.GetPolicyForChild(ctx, childID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("forbidden: cash-out is not enabled for this child")
+		}
+		return nil, fmt.Errorf("internal server error: could not retrieve cash-out policy")
+	}
+	if input.Points < policy.MinPoints {
+		return nil, fmt.Errorf("cannot request cash-out: minimum is %d points", policy.MinPoints)
+	}
+	if input.Points%policy.Points != 0 {
+		return nil, fmt.Errorf("cannot request cash-out: points must be a multiple of %d", policy.Points)
+	}
+	amountMinor := policy.ConvertPoints(input.Points)
+	if amountMinor > maxCashOutAmountMinor {
+		return nil, fmt.Errorf("cannot request cash-out: amount is too large")
+	}
+
+	request := &models.CashOutRequest{
+		ChildID:      childID,
+		Points:       input.Points,
+		AmountMinor:  amountMinor,
+		CurrencyCode: policy.CurrencyCode,
+		Note:         input.Note,
+		Status:       models.CashOutStatusPending,
+	}
+	err = withTx(ctx, s.pool, "RequestCashOut", func(tx pgx.Tx) error {
+		balance, err := s.pointRepo.CalculateTotalPointsByUserIDTx(ctx, tx, childID)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not retrieve points balance")
+		}
+		earmarked, err := s.goalRepo.SumEarmarkedPointsTx(ctx, tx, childID, 0)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not retrieve earmarked points")
+		}
+		if free := max(balance-earmarked, 0); free < input.Points {
+			return fmt.Errorf("%w: only %d points are available to cash out", ErrInsufficientPoints, free)
+		}
+
+		debit := &models.PointTransaction{
+			UserID:          childID,
+			ChangeAmount:    -input.Points,
+			TransactionType: models.TransactionTypeCashOut,
+			CreatedByUserID: childID,
+			Notes:           fmt.Sprintf("Cash-out request for %s", formatMinor(amountMinor, policy.CurrencyCode)),
+		}
+		if err := s.pointRepo.CreateTransactionTx(ctx, tx, debit); err != nil {
+			if errors.Is(err, repository.ErrNegativeBalance) {
+				return ErrInsufficientPoints
+			}
+			return fmt.Errorf("internal server error: could not record cash-out debit")
+		}
+		request.DebitTransactionID = debit.ID
+		if err := s.cashOutRepo.CreateRequestTx(ctx, tx, request); err != nil {
+			return fmt.Errorf("internal server error: could not create cash-out request")
+		}
+
+		parentIDs, err := s.userRelRepo.GetParentIDsByChildIDTx(ctx, tx, childID)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not retrieve parents")
+		}
+		for _, parentID := range parentIDs {
+			err = s.notificationRepo.CreateNotificationTx(ctx, tx, &models.Notification{
+				UserID:     parentID,
+				Type:       models.NotificationCashOutRequested,
+				Title:      "Cash-out needs approval",
+				Message:    fmt.Sprintf("Your child wants to cash out %d points for %s.", request.Points, formatMinor(amountMinor, policy.CurrencyCode)),
+				EntityType: "cash_out_request",
+				EntityID:   request.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("internal server error: could not send notification")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zlog.Info().Int("cash_out_id", request.ID).Int("child_id", childID).Int("points", request.Points).
+		Int64("amount_minor", amountMinor).Str("currency_code", policy.CurrencyCode).Msg("Service: Cash-out requested")
+	return request, nil
+}
+
+// GetMyCashOuts mengambil permintaan cash-out anak sendiri.
+func (s *cashOutServiceImpl) GetMyCashOuts(ctx context.Context, childID int, status models.CashOutStatus, page, limit int) ([]models.CashOutRequest, int, error) {
+	return s.cashOutRepo.GetRequestsByChildID(ctx, childID, status, page, limit)
+}
+
+// GetCashOutsForParent mengambil permintaan cash-out anak-anak parent.
+func (s *cashOutServiceImpl) GetCashOutsForParent(ctx context.Context, parentID int, status models.CashOutStatus, page, limit int) ([]models.CashOutRequest, int, error) {
+	return s.cashOutRepo.GetRequestsByParentID(ctx, parentID, status, page, limit)
+}
+
+// ReviewCashOut menyetujui atau menolak permintaan cash-out 'pending' dalam satu transaksi.
+// Disetujui: pembayaran dicatat ke ledger uang. Ditolak: poin dikembalikan dengan entri 'cash_out_refund'.
+func (s *cashOutServiceImpl) ReviewCashOut(ctx context.Context, parentID int, requestID int, input *models.ReviewCashOutInput) (*models.CashOutRequest, error) {
+	var request *models.CashOutRequest
+	err := withTx(ctx, s.pool, "ReviewCashOut", func(tx pgx.Tx) error {
+		var err error
+		request, err = s.cashOutRepo.GetRequestForUpdateTx(ctx, tx, requestID)
+		if err != nil {
+			return err
+		}
+		if err := ensureParentOf(ctx, s.userRelRepo, parentID, request.ChildID, cashOutForbiddenMessage); err != nil {
+			return err
+		}
+		if request.Status != models.CashOutStatusPending {
+			return fmt.Errorf("cannot review cash-out: request is already %s", request.Status)
+		}
+
+		request.Status = models.CashOutStatus(input.Status)
+		request.ReviewedByUserID = parentID
+		request.ReviewNote = input.Note
+		notification := &models.Notification{
+			UserID:     request.ChildID,
+			EntityType: "cash_out_request",
+			EntityID:   request.ID,
+		}
+
+		if request.Status == models.CashOutStatusApproved {
+			payout := &models.MoneyTransaction{
+				ChildID:          request.ChildID,
+				CashOutRequestID: request.ID,
+				Points:           request.Points,
+				AmountMinor:      request.AmountMinor,
+				CurrencyCode:     request.CurrencyCode,
+				PayoutMethod:     models.PayoutMethod(input.PayoutMethod),
+				Reference:        input.Reference,
+				PaidByUserID:     parentID,
+			}
+			if err := s.cashOutRepo.CreateMoneyTransactionTx(ctx, tx, payout); err != nil {
+				return fmt.Errorf("internal server error: could not record payout")
+			}
+			request.Payout = payout
+			notification.Type = models.NotificationCashOutApproved
+			notification.Title = "Cash-out approved"
+			notification.Message = fmt.Sprintf("Your cash-out of %d points was paid: %s.", request.Points, formatMinor(request.AmountMinor, request.CurrencyCode))
+		} else {
+			if request.DebitTransactionID == 0 {
+				zlog.Error().Int("cash_out_id", request.ID).Msg("Service: Cash-out request has no debit transaction to refund")
+				return fmt.Errorf("internal server error: could not refund cash-out points")
+			}
+			refund := &models.PointTransaction{
+				UserID:                request.ChildID,
+				ChangeAmount:          request.Points,
+				TransactionType:       models.TransactionTypeCashOutRefund,
+				ReversesTransactionID: request.DebitTransactionID,
+				CreatedByUserID:       parentID,
+				Notes:                 fmt.Sprintf("Points refunded for rejected cash-out ID %d", request.ID),
+			}
+			if err := s.pointRepo.CreateTransactionTx(ctx, tx, refund); err != nil {
+				zlog.Error().Err(err).Int("cash_out_id", request.ID).Msg("Service: Failed to create cash-out refund transaction")
+				return fmt.Errorf("internal server error: could not refund cash-out points")
+			}
+			request.RefundTransactionID = refund.ID
+			notification.Type = models.NotificationCashOutRejected
+			notification.Title = "Cash-out rejected"
+			notification.Message = fmt.Sprintf("Your cash-out of %d points was rejected and the points were returned.", request.Points)
+		}
+
+		if err := s.cashOutRepo.UpdateRequestReviewTx(ctx, tx, request); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("cannot review cash-out: request is no longer pending")
+			}
+			return fmt.Errorf("internal server error: could not update cash-out request")
+		}
+		if err := s.notificationRepo.CreateNotificationTx(ctx, tx, notification); err != nil {
+			return fmt.Errorf("internal server error: could not send notification")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	zlog.Info().Int("cash_out_id", requestID).Int("parent_id", parentID).Str("status", input.Status).Msg("Service: Cash-out reviewed")
+	if request.RefundTransactionID != 0 {
+		evaluateBadgesAfterCommit(ctx, s.badgeService, request.ChildID)
+	}
+	return request, nil
+}
+
+// GetStatement mengambil laporan uang bulanan anak sendiri.
+func (s *cashOutServiceImpl) GetStatement(ctx context.Context, childID int, month string) (*models.MoneyStatement, error) {
+	child, err := s.userRepo.GetUserByID(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+	loc := models.LoadTimezone(child.Timezone)
+	start, end, err := models.StatementPeriod(month, loc, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	payouts, err := s.cashOutRepo.GetMoneyTransactionsByChildID(ctx, childID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &models.MoneyStatement{
+		ChildID:     childID,
+		Month:       start.Format(models.StatementMonthLayout),
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Timezone:    loc.String(),
+		Totals:      []models.MoneyStatementTotal{},
+		Payouts:     payouts,
+	}
+	totals := map[string]int{} // kode mata uang -> indeks di statement.Totals
+	for _, payout := range payouts {
+		idx, ok := totals[payout.CurrencyCode]
+		if !ok {
+			idx = len(statement.Totals)
+			totals[payout.CurrencyCode] = idx
+			statement.Totals = append(statement.Totals, models.MoneyStatementTotal{CurrencyCode: payout.CurrencyCode})
+		}
+		statement.Totals[idx].AmountMinor += payout.AmountMinor
+		statement.Totals[idx].PayoutCount++
+		statement.PointsCashedOut += payout.Points
+	}
+	return statement, nil
+}
+
+// GetChildStatement mengambil laporan uang bulanan anak untuk orang tuanya.
+func (s *cashOutServiceImpl) GetChildStatement(ctx context.Context, parentID int, childID int, month string) (*models.MoneyStatement, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, cashOutForbiddenMessage); err != nil {
+		return nil, err
+	}
+	return s.GetStatement(ctx, childID, month)
+}
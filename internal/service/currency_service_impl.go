@@ -0,0 +1,218 @@
+// internal/service/currency_service_impl.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// maxExchangeCredit membatasi jumlah mata uang tujuan yang dihasilkan satu penukaran.
+const maxExchangeCredit = 1000000
+
+// currencyForbiddenMessage dipakai saat parent melihat saldo mata uang anak yang bukan anaknya.
+const currencyForbiddenMessage = "you are not authorized to view balances for this child"
+
+type currencyServiceImpl struct {
+	pool         *pgxpool.Pool // Untuk transaksi penukaran
+	currencyRepo repository.CurrencyRepository
+	pointRepo    repository.PointTransactionRepository
+	goalRepo     repository.SavingsGoalRepository // Poin yang disisihkan untuk target tabungan tidak bisa ditukar
+	userRelRepo  repository.UserRelationshipRepository
+	badgeService BadgeService // Evaluasi badge saldo setelah penukaran mengkredit poin
+}
+
+// NewCurrencyService creates a new instance of CurrencyService.
+func NewCurrencyService(
+	pool *pgxpool.Pool,
+	currencyRepo repository.CurrencyRepository,
+	pointRepo repository.PointTransactionRepository,
+	goalRepo repository.SavingsGoalRepository,
+	userRelRepo repository.UserRelationshipRepository,
+	badgeService BadgeService,
+) CurrencyService {
+	return &currencyServiceImpl{
+		pool:         pool,
+		currencyRepo: currencyRepo,
+		pointRepo:    pointRepo,
+		goalRepo:     goalRepo,
+		userRelRepo:  userRelRepo,
+		badgeService: badgeService,
+	}
+}
+
+// --- Helper Functions ---
+
+// --- Public Methods ---
+
+// CreateCurrency membuat mata uang baru milik parent.
+func (s *currencyServiceImpl) CreateCurrency(ctx context.Context, parentID int, input *models.CurrencyInput) (*models.Currency, error) {
+	currency := &models.Currency{CreatedByUserID: parentID, Name: input.Name, Symbol: input.Symbol}
+	if err := s.currencyRepo.CreateCurrency(ctx, currency); err != nil {
+		return nil, err
+	}
+	return currency, nil
+}
+
+// GetCurrencies mengambil semua mata uang milik parent.
+func (s *currencyServiceImpl) GetCurrencies(ctx context.Context, parentID int) ([]models.Currency, error) {
+	return s.currencyRepo.GetCurrenciesByOwnerID(ctx, parentID)
+}
+
+// UpdateCurrency mengubah nama/simbol mata uang milik parent.
+func (s *currencyServiceImpl) UpdateCurrency(ctx context.Context, parentID int, currencyID int, input *models.CurrencyInput) (*models.Currency, error) {
+	currency := &models.Currency{ID: currencyID, CreatedByUserID: parentID, Name: input.Name, Symbol: input.Symbol}
+	if err := s.currencyRepo.UpdateCurrency(ctx, currency); err != nil {
+		return nil, err
+	}
+	return currency, nil
+}
+
+// DeleteCurrency menghapus mata uang milik parent.
+func (s *currencyServiceImpl) DeleteCurrency(ctx context.Context, parentID int, currencyID int) error {
+	return s.currencyRepo.DeleteCurrency(ctx, currencyID, parentID)
+}
+
+// CreateExchangeRule membuat aturan tukar milik parent. Kepemilikan kedua mata uang dijaga oleh foreign key.
+func (s *currencyServiceImpl) CreateExchangeRule(ctx context.Context, parentID int, input *models.CreateExchangeRuleInput) (*models.CurrencyExchangeRule, error) {
+	if input.FromCurrencyID == input.ToCurrencyID {
+		return nil, fmt.Errorf("cannot create exchange rule: from and to currencies must differ")
+	}
+	rule := &models.CurrencyExchangeRule{
+		CreatedByUserID: parentID,
+		FromCurrencyID:  input.FromCurrencyID,
+		ToCurrencyID:    input.ToCurrencyID,
+		FromAmount:      input.FromAmount,
+		ToAmount:        input.ToAmount,
+	}
+	if err := s.currencyRepo.CreateExchangeRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// GetExchangeRules mengambil semua aturan tukar milik parent.
+func (s *currencyServiceImpl) GetExchangeRules(ctx context.Context, parentID int) ([]models.CurrencyExchangeRule, error) {
+	return s.currencyRepo.GetExchangeRulesByOwnerID(ctx, parentID)
+}
+
+// DeleteExchangeRule menghapus aturan tukar milik parent.
+func (s *currencyServiceImpl) DeleteExchangeRule(ctx context.Context, parentID int, ruleID int) error {
+	return s.currencyRepo.DeleteExchangeRule(ctx, ruleID, parentID)
+}
+
+// GetChildBalances mengambil saldo anak per mata uang untuk orang tuanya.
+func (s *currencyServiceImpl) GetChildBalances(ctx context.Context, parentID int, childID int) ([]models.CurrencyBalance, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, currencyForbiddenMessage); err != nil {
+		return nil, err
+	}
+	return s.currencyRepo.GetBalancesByUserID(ctx, childID)
+}
+
+// GetBalances mengambil saldo anak sendiri per mata uang.
+func (s *currencyServiceImpl) GetBalances(ctx context.Context, childID int) ([]models.CurrencyBalance, error) {
+	return s.currencyRepo.GetBalancesByUserID(ctx, childID)
+}
+
+// GetExchangeRulesForChild mengambil aturan tukar milik orang tua anak.
+func (s *currencyServiceImpl) GetExchangeRulesForChild(ctx context.Context, childID int) ([]models.CurrencyExchangeRule, error) {
+	return s.currencyRepo.GetExchangeRulesForChild(ctx, childID)
+}
+
+// Exchange menukar input.Amount unit mata uang asal menjadi mata uang tujuan sesuai aturan.
+// Saldo kedua mata uang dikunci (urutan ID mata uang menaik agar tidak deadlock) sebelum entri debit & kredit dicatat.
+func (s *currencyServiceImpl) Exchange(ctx context.Context, childID int, input *models.ExchangeCurrencyInput) (*models.CurrencyExchangeResult, error) {
+	rule, err := s.currencyRepo.GetExchangeRuleByID(ctx, input.RuleID)
+	if err != nil {
+		return nil, err // ErrNoRows diteruskan agar handler mengembalikan 404
+	}
+	isParent, err := s.userRelRepo.IsParentOf(ctx, rule.CreatedByUserID, childID)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Int("rule_id", rule.ID).Msg("Service: Error checking exchange rule owner")
+		return nil, fmt.Errorf("internal server error: could not verify relationship")
+	}
+	if !isParent {
+		return nil, pgx.ErrNoRows // Aturan milik keluarga lain diperlakukan seperti tidak ada
+	}
+	if rule.FromCurrencyID == rule.ToCurrencyID {
+		return nil, fmt.Errorf("cannot exchange: exchange rule is invalid")
+	}
+	if input.Amount%rule.FromAmount != 0 {
+		return nil, fmt.Errorf("cannot exchange: amount must be a multiple of %d", rule.FromAmount)
+	}
+	credited := input.Amount / rule.FromAmount * rule.ToAmount
+	if credited > maxExchangeCredit || input.Amount/rule.FromAmount > maxExchangeCredit {
+		return nil, fmt.Errorf("cannot exchange: amount is too large")
+	}
+
+	result := &models.CurrencyExchangeResult{
+		RuleID:         rule.ID,
+		FromCurrencyID: rule.FromCurrencyID,
+		ToCurrencyID:   rule.ToCurrencyID,
+		Debited:        input.Amount,
+		Credited:       credited,
+	}
+	err = withTx(ctx, s.pool, "ExchangeCurrency", func(tx pgx.Tx) error {
+		balances := map[int]int{}
+		for _, currencyID := range []int{min(rule.FromCurrencyID, rule.ToCurrencyID), max(rule.FromCurrencyID, rule.ToCurrencyID)} {
+			balance, err := s.pointRepo.CalculateBalanceTx(ctx, tx, childID, currencyID)
+			if err != nil {
+				return fmt.Errorf("internal server error: could not retrieve balance")
+			}
+			balances[currencyID] = balance
+		}
+		available := balances[rule.FromCurrencyID]
+		if rule.FromCurrencyID == 0 {
+			earmarked, err := s.goalRepo.SumEarmarkedPointsTx(ctx, tx, childID, 0)
+			if err != nil {
+				return fmt.Errorf("internal server error: could not retrieve earmarked points")
+			}
+			available = max(available-earmarked, 0)
+		}
+		if available < input.Amount {
+			return fmt.Errorf("%w: only %d are available to exchange", ErrInsufficientPoints, available)
+		}
+
+		notes := fmt.Sprintf("Exchange via rule #%d (%d for %d)", rule.ID, rule.FromAmount, rule.ToAmount)
+		debit := &models.PointTransaction{
+			UserID:          childID,
+			ChangeAmount:    -input.Amount,
+			TransactionType: models.TransactionTypeExchange,
+			CurrencyID:      rule.FromCurrencyID,
+			CreatedByUserID: childID,
+			Notes:           notes,
+		}
+		if err := s.pointRepo.CreateTransactionTx(ctx, tx, debit); err != nil {
+			if errors.Is(err, repository.ErrNegativeBalance) {
+				return ErrInsufficientPoints
+			}
+			return fmt.Errorf("internal server error: could not record exchange debit")
+		}
+		credit := &models.PointTransaction{
+			UserID:          childID,
+			ChangeAmount:    credited,
+			TransactionType: models.TransactionTypeExchange,
+			CurrencyID:      rule.ToCurrencyID,
+			CreatedByUserID: childID,
+			Notes:           notes,
+		}
+		if err := s.pointRepo.CreateTransactionTx(ctx, tx, credit); err != nil {
+			return fmt.Errorf("internal server error: could not record exchange credit")
+		}
+		result.DebitTransactionID = debit.ID
+		result.CreditTransactionID = credit.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	zlog.Info().Int("child_id", childID).Int("rule_id", rule.ID).Int("debited", result.Debited).Int("credited", result.Credited).Msg("Service: Currency exchanged")
+	evaluateBadgesAfterCommit(ctx, s.badgeService, childID)
+	return result, nil
+}
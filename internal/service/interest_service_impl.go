@@ -0,0 +1,208 @@
+// internal/service/interest_service_impl.go
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// interestBatchSize membatasi jumlah kebijakan yang dibaca worker per query.
+const interestBatchSize = 100
+
+// interestForbiddenMessage dipakai saat parent mengatur bunga tabungan anak yang bukan anaknya.
+const interestForbiddenMessage = "you are not authorized to manage interest for this child"
+
+type interestServiceImpl struct {
+	pool             *pgxpool.Pool // Untuk transaksi posting bunga
+	interestRepo     repository.InterestRepository
+	pointRepo        repository.PointTransactionRepository
+	userRelRepo      repository.UserRelationshipRepository
+	notificationRepo repository.NotificationRepository
+	badgeService     BadgeService // Evaluasi badge saldo setelah bunga diposting
+}
+
+// NewInterestService creates a new instance of InterestService.
+func NewInterestService(
+	pool *pgxpool.Pool,
+	interestRepo repository.InterestRepository,
+	pointRepo repository.PointTransactionRepository,
+	userRelRepo repository.UserRelationshipRepository,
+	notificationRepo repository.NotificationRepository,
+	badgeService BadgeService,
+) InterestService {
+	return &interestServiceImpl{
+		pool:             pool,
+		interestRepo:     interestRepo,
+		pointRepo:        pointRepo,
+		userRelRepo:      userRelRepo,
+		notificationRepo: notificationRepo,
+		badgeService:     badgeService,
+	}
+}
+
+// --- Helper Functions ---
+
+// postInterest memposting bunga satu periode untuk anak dalam satu transaksi.
+// Saldo dikunci lebih dulu; catatan periode dibuat sebelum transaksi poin sehingga worker yang
+// berjalan berulang atau bersamaan tidak memposting periode yang sama dua kali.
+// Mengembalikan bunga yang diposting (0 jika periode sudah diposting atau bunga dibulatkan ke 0).
+func (s *interestServiceImpl) postInterest(ctx context.Context, policy *models.InterestPolicy, periodStart time.Time) (int, error) {
+	posted := 0
+	err := withTx(ctx, s.pool, "PostInterest", func(tx pgx.Tx) error {
+		balance, err := s.pointRepo.CalculateTotalPointsByUserIDTx(ctx, tx, policy.ChildID)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not retrieve points balance")
+		}
+		interest := policy.ComputeInterest(balance)
+
+		claimed, err := s.interestRepo.ClaimPeriodTx(ctx, tx, policy.ChildID, periodStart, balance, interest)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not record interest posting")
+		}
+		if !claimed || interest == 0 {
+			return nil // Sudah diposting, atau bunga 0 tetap dicatat agar tidak dihitung ulang
+		}
+
+		pointTx := &models.PointTransaction{
+			UserID:          policy.ChildID,
+			ChangeAmount:    interest,
+			TransactionType: models.TransactionTypeInterest,
+			Notes:           fmt.Sprintf("Interest for %s period starting %s", policy.Schedule, periodStart.Format("2006-01-02")),
+			// CreatedByUserID 0 = dicatat oleh sistem
+		}
+		if err := s.pointRepo.CreateTransactionTx(ctx, tx, pointTx); err != nil {
+			return fmt.Errorf("internal server error: could not record interest")
+		}
+		if err := s.interestRepo.SetPostingTransactionTx(ctx, tx, policy.ChildID, periodStart, pointTx.ID); err != nil {
+			return fmt.Errorf("internal server error: could not record interest posting")
+		}
+		err = s.notificationRepo.CreateNotificationTx(ctx, tx, &models.Notification{
+			UserID:  policy.ChildID,
+			Type:    models.NotificationInterestPosted,
+			Title:   "Your savings earned interest",
+			Message: fmt.Sprintf("You earned %d interest points on your balance of %d points.", interest, balance),
+		})
+		if err != nil {
+			return fmt.Errorf("internal server error: could not send notification")
+		}
+		posted = interest
+		return nil
+	})
+	return posted, err
+}
+
+// --- Public Methods ---
+
+// SetPolicy membuat atau memperbarui kebijakan bunga milik parent untuk semua anaknya (childID 0) atau satu anak.
+func (s *interestServiceImpl) SetPolicy(ctx context.Context, parentID int, childID int, input *models.SetInterestPolicyInput) (*models.InterestPolicy, error) {
+	if childID != 0 {
+		if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, interestForbiddenMessage); err != nil {
+			return nil, err
+		}
+	}
+	rounding := input.Rounding
+	if rounding == "" {
+		rounding = models.InterestRoundingFloor
+	}
+	policy := &models.InterestPolicy{
+		CreatedByUserID:    parentID,
+		ChildID:            childID,
+		RateBasisPoints:    input.RateBasisPoints,
+		Schedule:           input.Schedule,
+		Rounding:           rounding,
+		MaxPointsPerPeriod: input.MaxPointsPerPeriod,
+	}
+	if err := s.interestRepo.UpsertPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// GetPolicy mengambil kebijakan keluarga milik parent (childID 0), atau kebijakan yang berlaku untuk anak.
+func (s *interestServiceImpl) GetPolicy(ctx context.Context, parentID int, childID int) (*models.InterestPolicy, error) {
+	if childID == 0 {
+		return s.interestRepo.GetPolicyByOwner(ctx, parentID, 0)
+	}
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, interestForbiddenMessage); err != nil {
+		return nil, err
+	}
+	return s.interestRepo.GetPolicyForChild(ctx, childID)
+}
+
+// DeletePolicy menghapus kebijakan bunga milik parent untuk semua anak (childID 0) atau satu anak.
+func (s *interestServiceImpl) DeletePolicy(ctx context.Context, parentID int, childID int) error {
+	if childID != 0 {
+		if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, interestForbiddenMessage); err != nil {
+			return err
+		}
+	}
+	return s.interestRepo.DeletePolicy(ctx, parentID, childID)
+}
+
+// PreviewForChild memproyeksikan pertumbuhan saldo anak berdasarkan kebijakan bunga yang berlaku untuknya.
+func (s *interestServiceImpl) PreviewForChild(ctx context.Context, childID int, periods int, now time.Time) (*models.InterestProjection, error) {
+	policy, err := s.interestRepo.GetPolicyForChild(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := s.pointRepo.CalculateTotalPointsByUserID(ctx, childID)
+	if err != nil {
+		return nil, fmt.Errorf("internal server error: could not retrieve points balance")
+	}
+	projection := policy.Project(balance, periods, now)
+	projection.Policy = policy
+	return &projection, nil
+}
+
+// PreviewForParent memproyeksikan pertumbuhan saldo anak untuk orang tuanya.
+func (s *interestServiceImpl) PreviewForParent(ctx context.Context, parentID int, childID int, periods int, now time.Time) (*models.InterestProjection, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, interestForbiddenMessage); err != nil {
+		return nil, err
+	}
+	return s.PreviewForChild(ctx, childID, periods, now)
+}
+
+// ProcessInterest memposting bunga periode terakhir yang sudah selesai (dipanggil oleh worker).
+// Periode yang dimulai sebelum kebijakan dibuat tidak berbunga, dan periode yang terlewat saat
+// worker tidak berjalan tidak diposting mundur (saldo historisnya tidak diketahui).
+func (s *interestServiceImpl) ProcessInterest(ctx context.Context, now time.Time) (int, error) {
+	total := 0
+	afterChildID := 0
+	for {
+		policies, err := s.interestRepo.GetPoliciesAfter(ctx, afterChildID, interestBatchSize)
+		if err != nil {
+			return total, err
+		}
+		for i := range policies {
+			policy := &policies[i]
+			periodStart := policy.Schedule.LastCompletedPeriodStart(now)
+			if periodStart.Before(policy.CreatedAt) {
+				continue
+			}
+			posted, err := s.postInterest(ctx, policy, periodStart)
+			if err != nil {
+				zlog.Error().Err(err).Int("child_id", policy.ChildID).Msg("Service: Failed to post interest")
+				continue
+			}
+			total += posted
+			if posted > 0 {
+				evaluateBadgesAfterCommit(ctx, s.badgeService, policy.ChildID)
+			}
+		}
+		if len(policies) < interestBatchSize {
+			break
+		}
+		afterChildID = policies[len(policies)-1].ChildID
+	}
+	if total > 0 {
+		zlog.Info().Int("interest_points", total).Msg("Service: Interest posted")
+	}
+	return total, nil
+}
@@ -0,0 +1,112 @@
+// internal/service/ledger_service_impl.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type ledgerServiceImpl struct {
+	pool         *pgxpool.Pool
+	ledgerRepo   repository.LedgerRepository
+	pointRepo    repository.PointTransactionRepository
+	auditRepo    repository.AuditLogRepository
+	badgeService BadgeService // Evaluasi badge saldo setelah koreksi yang menambah poin
+}
+
+// NewLedgerService creates a new instance of LedgerService.
+func NewLedgerService(
+	pool *pgxpool.Pool,
+	ledgerRepo repository.LedgerRepository,
+	pointRepo repository.PointTransactionRepository,
+	auditRepo repository.AuditLogRepository,
+	badgeService BadgeService,
+) LedgerService {
+	return &ledgerServiceImpl{
+		pool:         pool,
+		ledgerRepo:   ledgerRepo,
+		pointRepo:    pointRepo,
+		auditRepo:    auditRepo,
+		badgeService: badgeService,
+	}
+}
+
+// VerifyLedger memverifikasi rantai hash ledger. Temuan dicatat ke log sebagai peringatan.
+func (s *ledgerServiceImpl) VerifyLedger(ctx context.Context, userID int) (*models.LedgerVerificationReport, error) {
+	report, err := s.ledgerRepo.VerifyChain(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("internal server error: could not verify point ledger")
+	}
+	if !report.Valid {
+		zlog.Warn().Int("user_id", userID).Int("issues", len(report.Issues)).Msg("Service: Point ledger integrity issues found")
+	}
+	return report, nil
+}
+
+// ReverseTransaction mencatat entri 'correction' yang membalik transactionID.
+func (s *ledgerServiceImpl) ReverseTransaction(ctx context.Context, adminID int, transactionID int, input *models.ReversePointTransactionInput) (*models.PointTransaction, error) {
+	var correction *models.PointTransaction
+	err := withTx(ctx, s.pool, "ReverseTransaction", func(tx pgx.Tx) error {
+		original, reversed, err := s.ledgerRepo.GetTransactionTx(ctx, tx, transactionID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return err
+			}
+			return fmt.Errorf("internal server error: could not retrieve point transaction")
+		}
+		if original.TransactionType.IsReversal() {
+			return fmt.Errorf("cannot reverse transaction: it is already a reversal entry")
+		}
+		if reversed {
+			return fmt.Errorf("cannot reverse transaction: it has already been reversed")
+		}
+		if original.ChangeAmount == 0 {
+			return fmt.Errorf("cannot reverse transaction: it did not change the balance")
+		}
+
+		correction = &models.PointTransaction{
+			UserID:                original.UserID,
+			ChangeAmount:          -original.ChangeAmount,
+			TransactionType:       models.TransactionTypeCorrection,
+			ReversesTransactionID: original.ID,
+			CurrencyID:            original.CurrencyID,
+			CreatedByUserID:       adminID,
+			Notes:                 fmt.Sprintf("Correction of transaction #%d: %s", original.ID, input.Reason),
+		}
+		if err := s.pointRepo.CreateTransactionTx(ctx, tx, correction); err != nil {
+			if errors.Is(err, repository.ErrNegativeBalance) {
+				return fmt.Errorf("cannot reverse transaction: child's balance is lower than the %d points to reverse", original.ChangeAmount)
+			}
+			return fmt.Errorf("internal server error: could not record correction")
+		}
+
+		auditDetails := map[string]any{
+			"child_id":                original.UserID,
+			"reverses_transaction_id": original.ID,
+			"change_amount":           correction.ChangeAmount,
+			"currency_id":             original.CurrencyID,
+			"reason":                  input.Reason,
+		}
+		if err := recordAuditTx(ctx, tx, s.auditRepo, adminID, "point_transaction.corrected", "point_transaction", correction.ID, auditDetails); err != nil {
+			return fmt.Errorf("internal server error: could not record audit log")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zlog.Info().Int("transaction_id", transactionID).Int("correction_id", correction.ID).Int("admin_id", adminID).
+		Msg("Service: Point transaction corrected")
+	if correction.ChangeAmount > 0 {
+		evaluateBadgesAfterCommit(ctx, s.badgeService, correction.UserID)
+	}
+	return correction, nil
+}
@@ -0,0 +1,104 @@
+// internal/service/level_service_impl.go
+package service
+
+import (
+	"context"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+)
+
+type levelServiceImpl struct {
+	levelRepo   repository.LevelRepository
+	userRelRepo repository.UserRelationshipRepository
+}
+
+// NewLevelService creates a new instance of LevelService.
+func NewLevelService(levelRepo repository.LevelRepository, userRelRepo repository.UserRelationshipRepository) LevelService {
+	return &levelServiceImpl{
+		levelRepo:   levelRepo,
+		userRelRepo: userRelRepo,
+	}
+}
+
+// GetMyLevel mengambil ringkasan level milik anak yang sedang login.
+func (s *levelServiceImpl) GetMyLevel(ctx context.Context, childID int) (*models.LevelOverview, error) {
+	return s.buildOverview(ctx, childID)
+}
+
+// GetChildLevel mengambil ringkasan level anak untuk orang tuanya.
+func (s *levelServiceImpl) GetChildLevel(ctx context.Context, parentID int, childID int) (*models.LevelOverview, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, "you are not authorized to view this child's level"); err != nil {
+		return nil, err
+	}
+	return s.buildOverview(ctx, childID)
+}
+
+// GetLevelProgress menghitung level dan progres XP anak menuju level berikutnya.
+func (s *levelServiceImpl) GetLevelProgress(ctx context.Context, childID int) (*models.LevelProgress, error) {
+	levels, err := s.levelRepo.GetLevels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	totalXP, err := s.levelRepo.GetTotalXP(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+	progress := models.NewLevelProgress(levels, totalXP)
+	return &progress, nil
+}
+
+// GetLevelProgressForUsers menghitung progres level beberapa anak sekaligus (mis. daftar anak milik parent).
+func (s *levelServiceImpl) GetLevelProgressForUsers(ctx context.Context, childIDs []int) (map[int]models.LevelProgress, error) {
+	progress := make(map[int]models.LevelProgress, len(childIDs))
+	if len(childIDs) == 0 {
+		return progress, nil
+	}
+	levels, err := s.levelRepo.GetLevels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.levelRepo.GetTotalXPByUserIDs(ctx, childIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, childID := range childIDs {
+		progress[childID] = models.NewLevelProgress(levels, totals[childID])
+	}
+	return progress, nil
+}
+
+// buildOverview menyusun level anak, tangga level dengan hadiah yang terbuka di tiap level, dan riwayat level-up.
+func (s *levelServiceImpl) buildOverview(ctx context.Context, childID int) (*models.LevelOverview, error) {
+	levels, err := s.levelRepo.GetLevels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	totalXP, err := s.levelRepo.GetTotalXP(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+	rewards, err := s.levelRepo.GetLevelRewardsForChild(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+	levelUps, err := s.levelRepo.GetLevelUpsByUserID(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+
+	overview := &models.LevelOverview{
+		ChildID:  childID,
+		Progress: models.NewLevelProgress(levels, totalXP),
+		Levels:   levels,
+		LevelUps: levelUps,
+	}
+	for i := range overview.Levels {
+		for _, reward := range rewards {
+			if reward.MinLevel == overview.Levels[i].Level {
+				overview.Levels[i].UnlockedRewards = append(overview.Levels[i].UnlockedRewards, reward)
+			}
+		}
+	}
+	return overview, nil
+}
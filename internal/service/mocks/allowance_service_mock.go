@@ -0,0 +1,71 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAllowanceService struct {
+	mock.Mock
+}
+
+func (m *MockAllowanceService) SetPlan(ctx context.Context, parentID int, childID int, input *models.SetAllowancePlanInput) (*models.AllowancePlan, error) {
+	args := m.Called(ctx, parentID, childID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AllowancePlan), args.Error(1)
+}
+
+func (m *MockAllowanceService) GetPlan(ctx context.Context, parentID int, childID int) (*models.AllowancePlan, error) {
+	args := m.Called(ctx, parentID, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AllowancePlan), args.Error(1)
+}
+
+func (m *MockAllowanceService) DeletePlan(ctx context.Context, parentID int, childID int) error {
+	args := m.Called(ctx, parentID, childID)
+	return args.Error(0)
+}
+
+func (m *MockAllowanceService) PausePlan(ctx context.Context, parentID int, childID int) (*models.AllowancePlan, error) {
+	args := m.Called(ctx, parentID, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AllowancePlan), args.Error(1)
+}
+
+func (m *MockAllowanceService) ResumePlan(ctx context.Context, parentID int, childID int, now time.Time) (*models.AllowancePlan, error) {
+	args := m.Called(ctx, parentID, childID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AllowancePlan), args.Error(1)
+}
+
+func (m *MockAllowanceService) GetPayoutsForParent(ctx context.Context, parentID int, childID int, page, limit int) ([]models.AllowancePayout, int, error) {
+	args := m.Called(ctx, parentID, childID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]models.AllowancePayout), args.Int(1), args.Error(2)
+}
+
+func (m *MockAllowanceService) GetMyPayouts(ctx context.Context, childID int, page, limit int) ([]models.AllowancePayout, int, error) {
+	args := m.Called(ctx, childID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]models.AllowancePayout), args.Int(1), args.Error(2)
+}
+
+func (m *MockAllowanceService) ProcessAllowances(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(ctx, now)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAutoApprovalService struct {
+	mock.Mock
+}
+
+func (m *MockAutoApprovalService) CreatePolicy(ctx context.Context, parentID int, input *models.CreateAutoApprovalPolicyInput) (int, error) {
+	args := m.Called(ctx, parentID, input)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAutoApprovalService) GetPoliciesForParent(ctx context.Context, parentID int) ([]models.AutoApprovalPolicy, error) {
+	args := m.Called(ctx, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AutoApprovalPolicy), args.Error(1)
+}
+
+func (m *MockAutoApprovalService) UpdatePolicy(ctx context.Context, policyID int, parentID int, input *models.UpdateAutoApprovalPolicyInput) error {
+	args := m.Called(ctx, policyID, parentID, input)
+	return args.Error(0)
+}
+
+func (m *MockAutoApprovalService) DeletePolicy(ctx context.Context, policyID int, parentID int) error {
+	args := m.Called(ctx, policyID, parentID)
+	return args.Error(0)
+}
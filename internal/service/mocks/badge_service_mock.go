@@ -0,0 +1,78 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBadgeService struct {
+	mock.Mock
+}
+
+func (m *MockBadgeService) CreateBadge(ctx context.Context, parentID int, input *models.BadgeInput) (*models.Badge, error) {
+	args := m.Called(ctx, parentID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Badge), args.Error(1)
+}
+
+func (m *MockBadgeService) GetBadges(ctx context.Context, parentID int) ([]models.Badge, error) {
+	args := m.Called(ctx, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Badge), args.Error(1)
+}
+
+func (m *MockBadgeService) UpdateBadge(ctx context.Context, parentID int, badgeID int, input *models.BadgeInput) (*models.Badge, error) {
+	args := m.Called(ctx, parentID, badgeID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Badge), args.Error(1)
+}
+
+func (m *MockBadgeService) DeleteBadge(ctx context.Context, parentID int, badgeID int) error {
+	args := m.Called(ctx, parentID, badgeID)
+	return args.Error(0)
+}
+
+func (m *MockBadgeService) AwardBadge(ctx context.Context, parentID int, childID int, badgeID int) (*models.ChildBadge, error) {
+	args := m.Called(ctx, parentID, childID, badgeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ChildBadge), args.Error(1)
+}
+
+func (m *MockBadgeService) GetChildBadges(ctx context.Context, parentID int, childID int) ([]models.ChildBadge, error) {
+	args := m.Called(ctx, parentID, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ChildBadge), args.Error(1)
+}
+
+func (m *MockBadgeService) GetMyBadges(ctx context.Context, childID int) ([]models.ChildBadge, error) {
+	args := m.Called(ctx, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ChildBadge), args.Error(1)
+}
+
+func (m *MockBadgeService) EvaluateChild(ctx context.Context, childID int) ([]models.ChildBadge, error) {
+	args := m.Called(ctx, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ChildBadge), args.Error(1)
+}
+
+func (m *MockBadgeService) ProcessBalanceBadges(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBountyService struct {
+	mock.Mock
+}
+
+func (m *MockBountyService) CreateBounty(ctx context.Context, parentID int, input *models.CreateBountyInput) (int, error) {
+	args := m.Called(ctx, parentID, input)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBountyService) GetBountiesForParent(ctx context.Context, parentID int, page, limit int) ([]models.TaskBounty, int, error) {
+	args := m.Called(ctx, parentID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]models.TaskBounty), args.Int(1), args.Error(2)
+}
+
+func (m *MockBountyService) CancelBounty(ctx context.Context, bountyID int, parentID int) error {
+	args := m.Called(ctx, bountyID, parentID)
+	return args.Error(0)
+}
+
+func (m *MockBountyService) GetOpenBountiesForChild(ctx context.Context, childID int, page, limit int) ([]models.TaskBounty, int, error) {
+	args := m.Called(ctx, childID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]models.TaskBounty), args.Int(1), args.Error(2)
+}
+
+func (m *MockBountyService) ClaimBounty(ctx context.Context, childID int, bountyID int) (int, error) {
+	args := m.Called(ctx, childID, bountyID)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,81 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCashOutService struct {
+	mock.Mock
+}
+
+func (m *MockCashOutService) SetPolicy(ctx context.Context, parentID int, childID int, input *models.SetCashOutPolicyInput) (*models.CashOutPolicy, error) {
+	args := m.Called(ctx, parentID, childID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CashOutPolicy), args.Error(1)
+}
+
+func (m *MockCashOutService) GetPolicy(ctx context.Context, parentID int, childID int) (*models.CashOutPolicy, error) {
+	args := m.Called(ctx, parentID, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CashOutPolicy), args.Error(1)
+}
+
+func (m *MockCashOutService) DeletePolicy(ctx context.Context, parentID int, childID int) error {
+	args := m.Called(ctx, parentID, childID)
+	return args.Error(0)
+}
+
+func (m *MockCashOutService) RequestCashOut(ctx context.Context, childID int, input *models.CreateCashOutInput) (*models.CashOutRequest, error) {
+	args := m.Called(ctx, childID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CashOutRequest), args.Error(1)
+}
+
+func (m *MockCashOutService) GetMyCashOuts(ctx context.Context, childID int, status models.CashOutStatus, page, limit int) ([]models.CashOutRequest, int, error) {
+	args := m.Called(ctx, childID, status, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]models.CashOutRequest), args.Int(1), args.Error(2)
+}
+
+func (m *MockCashOutService) GetCashOutsForParent(ctx context.Context, parentID int, status models.CashOutStatus, page, limit int) ([]models.CashOutRequest, int, error) {
+	args := m.Called(ctx, parentID, status, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]models.CashOutRequest), args.Int(1), args.Error(2)
+}
+
+func (m *MockCashOutService) ReviewCashOut(ctx context.Context, parentID int, requestID int, input *models.ReviewCashOutInput) (*models.CashOutRequest, error) {
+	args := m.Called(ctx, parentID, requestID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CashOutRequest), args.Error(1)
+}
+
+func (m *MockCashOutService) GetStatement(ctx context.Context, childID int, month string) (*models.MoneyStatement, error) {
+	args := m.Called(ctx, childID, month)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MoneyStatement), args.Error(1)
+}
+
+func (m *MockCashOutService) GetChildStatement(ctx context.Context, parentID int, childID int, month string) (*models.MoneyStatement, error) {
+	args := m.Called(ctx, parentID, childID, month)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MoneyStatement), args.Error(1)
+}
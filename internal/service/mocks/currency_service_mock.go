@@ -0,0 +1,94 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCurrencyService struct {
+	mock.Mock
+}
+
+func (m *MockCurrencyService) CreateCurrency(ctx context.Context, parentID int, input *models.CurrencyInput) (*models.Currency, error) {
+	args := m.Called(ctx, parentID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Currency), args.Error(1)
+}
+
+func (m *MockCurrencyService) GetCurrencies(ctx context.Context, parentID int) ([]models.Currency, error) {
+	args := m.Called(ctx, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Currency), args.Error(1)
+}
+
+func (m *MockCurrencyService) UpdateCurrency(ctx context.Context, parentID int, currencyID int, input *models.CurrencyInput) (*models.Currency, error) {
+	args := m.Called(ctx, parentID, currencyID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Currency), args.Error(1)
+}
+
+func (m *MockCurrencyService) DeleteCurrency(ctx context.Context, parentID int, currencyID int) error {
+	args := m.Called(ctx, parentID, currencyID)
+	return args.Error(0)
+}
+
+func (m *MockCurrencyService) CreateExchangeRule(ctx context.Context, parentID int, input *models.CreateExchangeRuleInput) (*models.CurrencyExchangeRule, error) {
+	args := m.Called(ctx, parentID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CurrencyExchangeRule), args.Error(1)
+}
+
+func (m *MockCurrencyService) GetExchangeRules(ctx context.Context, parentID int) ([]models.CurrencyExchangeRule, error) {
+	args := m.Called(ctx, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CurrencyExchangeRule), args.Error(1)
+}
+
+func (m *MockCurrencyService) DeleteExchangeRule(ctx context.Context, parentID int, ruleID int) error {
+	args := m.Called(ctx, parentID, ruleID)
+	return args.Error(0)
+}
+
+func (m *MockCurrencyService) GetChildBalances(ctx context.Context, parentID int, childID int) ([]models.CurrencyBalance, error) {
+	args := m.Called(ctx, parentID, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CurrencyBalance), args.Error(1)
+}
+
+func (m *MockCurrencyService) GetBalances(ctx context.Context, childID int) ([]models.CurrencyBalance, error) {
+	args := m.Called(ctx, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CurrencyBalance), args.Error(1)
+}
+
+func (m *MockCurrencyService) GetExchangeRulesForChild(ctx context.Context, childID int) ([]models.CurrencyExchangeRule, error) {
+	args := m.Called(ctx, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CurrencyExchangeRule), args.Error(1)
+}
+
+func (m *MockCurrencyService) Exchange(ctx context.Context, childID int, input *models.ExchangeCurrencyInput) (*models.CurrencyExchangeResult, error) {
+	args := m.Called(ctx, childID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CurrencyExchangeResult), args.Error(1)
+}
@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInterestService struct {
+	mock.Mock
+}
+
+func (m *MockInterestService) SetPolicy(ctx context.Context, parentID int, childID int, input *models.SetInterestPolicyInput) (*models.InterestPolicy, error) {
+	args := m.Called(ctx, parentID, childID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.InterestPolicy), args.Error(1)
+}
+
+func (m *MockInterestService) GetPolicy(ctx context.Context, parentID int, childID int) (*models.InterestPolicy, error) {
+	args := m.Called(ctx, parentID, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.InterestPolicy), args.Error(1)
+}
+
+func (m *MockInterestService) DeletePolicy(ctx context.Context, parentID int, childID int) error {
+	args := m.Called(ctx, parentID, childID)
+	return args.Error(0)
+}
+
+func (m *MockInterestService) PreviewForChild(ctx context.Context, childID int, periods int, now time.Time) (*models.InterestProjection, error) {
+	args := m.Called(ctx, childID, periods, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.InterestProjection), args.Error(1)
+}
+
+func (m *MockInterestService) PreviewForParent(ctx context.Context, parentID int, childID int, periods int, now time.Time) (*models.InterestProjection, error) {
+	args := m.Called(ctx, parentID, childID, periods, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.InterestProjection), args.Error(1)
+}
+
+func (m *MockInterestService) ProcessInterest(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(ctx, now)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLedgerService struct {
+	mock.Mock
+}
+
+func (m *MockLedgerService) VerifyLedger(ctx context.Context, userID int) (*models.LedgerVerificationReport, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LedgerVerificationReport), args.Error(1)
+}
+
+func (m *MockLedgerService) ReverseTransaction(ctx context.Context, adminID int, transactionID int, input *models.ReversePointTransactionInput) (*models.PointTransaction, error) {
+	args := m.Called(ctx, adminID, transactionID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PointTransaction), args.Error(1)
+}
@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLevelService struct {
+	mock.Mock
+}
+
+func (m *MockLevelService) GetMyLevel(ctx context.Context, childID int) (*models.LevelOverview, error) {
+	args := m.Called(ctx, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LevelOverview), args.Error(1)
+}
+
+func (m *MockLevelService) GetChildLevel(ctx context.Context, parentID int, childID int) (*models.LevelOverview, error) {
+	args := m.Called(ctx, parentID, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LevelOverview), args.Error(1)
+}
+
+func (m *MockLevelService) GetLevelProgress(ctx context.Context, childID int) (*models.LevelProgress, error) {
+	args := m.Called(ctx, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LevelProgress), args.Error(1)
+}
+
+func (m *MockLevelService) GetLevelProgressForUsers(ctx context.Context, childIDs []int) (map[int]models.LevelProgress, error) {
+	args := m.Called(ctx, childIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int]models.LevelProgress), args.Error(1)
+}
@@ -0,0 +1,73 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPenaltyService struct {
+	mock.Mock
+}
+
+func (m *MockPenaltyService) CreateInfractionType(ctx context.Context, parentID int, input *models.InfractionTypeInput) (*models.InfractionType, error) {
+	args := m.Called(ctx, parentID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.InfractionType), args.Error(1)
+}
+
+func (m *MockPenaltyService) GetInfractionTypes(ctx context.Context, parentID int) ([]models.InfractionType, error) {
+	args := m.Called(ctx, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.InfractionType), args.Error(1)
+}
+
+func (m *MockPenaltyService) UpdateInfractionType(ctx context.Context, parentID int, infractionTypeID int, input *models.InfractionTypeInput) (*models.InfractionType, error) {
+	args := m.Called(ctx, parentID, infractionTypeID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.InfractionType), args.Error(1)
+}
+
+func (m *MockPenaltyService) DeleteInfractionType(ctx context.Context, parentID int, infractionTypeID int) error {
+	args := m.Called(ctx, parentID, infractionTypeID)
+	return args.Error(0)
+}
+
+func (m *MockPenaltyService) ApplyPenalty(ctx context.Context, parentID int, childID int, input *models.ApplyPenaltyInput) (*models.Penalty, error) {
+	args := m.Called(ctx, parentID, childID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Penalty), args.Error(1)
+}
+
+func (m *MockPenaltyService) GetChildPenalties(ctx context.Context, parentID int, childID int, page, limit int) ([]models.Penalty, int, error) {
+	args := m.Called(ctx, parentID, childID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]models.Penalty), args.Int(1), args.Error(2)
+}
+
+func (m *MockPenaltyService) GetMyPenalties(ctx context.Context, childID int, page, limit int) ([]models.Penalty, int, error) {
+	args := m.Called(ctx, childID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]models.Penalty), args.Int(1), args.Error(2)
+}
+
+func (m *MockPenaltyService) GetPenaltyReport(ctx context.Context, parentID int, childID int, filter *models.PenaltyReportFilter) (*models.PenaltyReport, error) {
+	args := m.Called(ctx, parentID, childID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PenaltyReport), args.Error(1)
+}
@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPointExpirationService struct {
+	mock.Mock
+}
+
+func (m *MockPointExpirationService) SetPolicy(ctx context.Context, parentID int, childID int, input *models.SetPointExpirationPolicyInput) (*models.PointExpirationPolicy, error) {
+	args := m.Called(ctx, parentID, childID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PointExpirationPolicy), args.Error(1)
+}
+
+func (m *MockPointExpirationService) GetPolicy(ctx context.Context, parentID int, childID int) (*models.PointExpirationPolicy, error) {
+	args := m.Called(ctx, parentID, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PointExpirationPolicy), args.Error(1)
+}
+
+func (m *MockPointExpirationService) DeletePolicy(ctx context.Context, parentID int, childID int) error {
+	args := m.Called(ctx, parentID, childID)
+	return args.Error(0)
+}
+
+func (m *MockPointExpirationService) GetExpiringPoints(ctx context.Context, childID int, now time.Time) (*models.ExpiringPointsSummary, error) {
+	args := m.Called(ctx, childID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ExpiringPointsSummary), args.Error(1)
+}
+
+func (m *MockPointExpirationService) ProcessExpirations(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(ctx, now)
+	return args.Int(0), args.Error(1)
+}
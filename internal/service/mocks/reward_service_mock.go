@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
 	"github.com/stretchr/testify/mock"
@@ -39,7 +40,54 @@ func (m *MockRewardService) ClaimReward(ctx context.Context, childID int, reward
 	return args.Int(0), args.Error(1)
 }
 
-func (m *MockRewardService) ReviewClaim(ctx context.Context, claimID int, parentID int, newStatus models.UserRewardStatus) error {
+func (m *MockRewardService) ReviewClaim(ctx context.Context, claimID int, parentID int, newStatus models.UserRewardStatus) (*models.ClaimReviewResult, error) {
 	args := m.Called(ctx, claimID, parentID, newStatus)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ClaimReviewResult), args.Error(1)
+}
+
+func (m *MockRewardService) ScheduleClaimFulfillment(ctx context.Context, claimID int, parentID int, deliveryDate time.Time, note string) error {
+	args := m.Called(ctx, claimID, parentID, deliveryDate, note)
+	return args.Error(0)
+}
+
+func (m *MockRewardService) FulfillClaim(ctx context.Context, claimID int, parentID int, note string) error {
+	args := m.Called(ctx, claimID, parentID, note)
+	return args.Error(0)
+}
+
+func (m *MockRewardService) ConfirmClaimReceipt(ctx context.Context, claimID int, childID int) error {
+	args := m.Called(ctx, claimID, childID)
+	return args.Error(0)
+}
+
+func (m *MockRewardService) GetClaimHistory(ctx context.Context, claimID int, userID int) ([]models.UserRewardEvent, error) {
+	args := m.Called(ctx, claimID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.UserRewardEvent), args.Error(1)
+}
+
+func (m *MockRewardService) SetApprovalPolicy(ctx context.Context, parentID int, childID int, input *models.SetRewardApprovalPolicyInput) (*models.RewardApprovalPolicy, error) {
+	args := m.Called(ctx, parentID, childID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RewardApprovalPolicy), args.Error(1)
+}
+
+func (m *MockRewardService) GetApprovalPolicy(ctx context.Context, parentID int, childID int) (*models.RewardApprovalPolicy, error) {
+	args := m.Called(ctx, parentID, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RewardApprovalPolicy), args.Error(1)
+}
+
+func (m *MockRewardService) DeleteApprovalPolicy(ctx context.Context, parentID int, childID int) error {
+	args := m.Called(ctx, parentID, childID)
 	return args.Error(0)
 }
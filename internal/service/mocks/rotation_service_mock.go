@@ -0,0 +1,81 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRotationService struct {
+	mock.Mock
+}
+
+func (m *MockRotationService) CreateRotation(ctx context.Context, parentID int, input *models.CreateRotationInput) (int, error) {
+	args := m.Called(ctx, parentID, input)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRotationService) GetRotation(ctx context.Context, rotationID int, parentID int) (*models.TaskRotation, error) {
+	args := m.Called(ctx, rotationID, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskRotation), args.Error(1)
+}
+
+func (m *MockRotationService) GetRotationsForParent(ctx context.Context, parentID int, page, limit int) ([]models.TaskRotation, int, error) {
+	args := m.Called(ctx, parentID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]models.TaskRotation), args.Int(1), args.Error(2)
+}
+
+func (m *MockRotationService) DeleteRotation(ctx context.Context, rotationID int, parentID int) error {
+	args := m.Called(ctx, rotationID, parentID)
+	return args.Error(0)
+}
+
+func (m *MockRotationService) AdvanceRotation(ctx context.Context, rotationID int, actorID int) (*models.TaskRotationTurn, error) {
+	args := m.Called(ctx, rotationID, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskRotationTurn), args.Error(1)
+}
+
+func (m *MockRotationService) SkipTurn(ctx context.Context, rotationID int, parentID int, notes string) (*models.TaskRotationTurn, error) {
+	args := m.Called(ctx, rotationID, parentID, notes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskRotationTurn), args.Error(1)
+}
+
+func (m *MockRotationService) SwapMembers(ctx context.Context, rotationID int, parentID int, firstChildID int, secondChildID int) error {
+	args := m.Called(ctx, rotationID, parentID, firstChildID, secondChildID)
+	return args.Error(0)
+}
+
+func (m *MockRotationService) GetRotationHistory(ctx context.Context, rotationID int, parentID int, page, limit int) ([]models.TaskRotationTurn, int, error) {
+	args := m.Called(ctx, rotationID, parentID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]models.TaskRotationTurn), args.Int(1), args.Error(2)
+}
+
+func (m *MockRotationService) PreviewRotation(ctx context.Context, rotationID int, parentID int, count int) ([]models.RotationPreviewItem, error) {
+	args := m.Called(ctx, rotationID, parentID, count)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.RotationPreviewItem), args.Error(1)
+}
+
+func (m *MockRotationService) ProcessDueRotations(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(ctx, now)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSavingsGoalService struct {
+	mock.Mock
+}
+
+func (m *MockSavingsGoalService) CreateGoal(ctx context.Context, childID int, input *models.CreateSavingsGoalInput) (int, error) {
+	args := m.Called(ctx, childID, input)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSavingsGoalService) GetSavingsOverview(ctx context.Context, childID int) (*models.SavingsOverview, error) {
+	args := m.Called(ctx, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SavingsOverview), args.Error(1)
+}
+
+func (m *MockSavingsGoalService) GetChildSavingsOverview(ctx context.Context, parentID int, childID int) (*models.SavingsOverview, error) {
+	args := m.Called(ctx, parentID, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SavingsOverview), args.Error(1)
+}
+
+func (m *MockSavingsGoalService) EarmarkPoints(ctx context.Context, goalID int, childID int, points int) error {
+	args := m.Called(ctx, goalID, childID, points)
+	return args.Error(0)
+}
+
+func (m *MockSavingsGoalService) CancelGoal(ctx context.Context, goalID int, childID int) error {
+	args := m.Called(ctx, goalID, childID)
+	return args.Error(0)
+}
+
+func (m *MockSavingsGoalService) ContributeToGoal(ctx context.Context, goalID int, parentID int, input *models.ContributeToGoalInput) error {
+	args := m.Called(ctx, goalID, parentID, input)
+	return args.Error(0)
+}
+
+func (m *MockSavingsGoalService) ProcessReachedGoals(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStatementService struct {
+	mock.Mock
+}
+
+func (m *MockStatementService) GetStatement(ctx context.Context, childID int, month string, currencyID int) (*models.PointStatement, error) {
+	args := m.Called(ctx, childID, month, currencyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PointStatement), args.Error(1)
+}
+
+func (m *MockStatementService) GetChildStatement(ctx context.Context, parentID int, childID int, month string, currencyID int) (*models.PointStatement, error) {
+	args := m.Called(ctx, parentID, childID, month, currencyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PointStatement), args.Error(1)
+}
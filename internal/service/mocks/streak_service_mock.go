@@ -0,0 +1,91 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStreakService struct {
+	mock.Mock
+}
+
+func (m *MockStreakService) CreateBonusRule(ctx context.Context, parentID int, input *models.StreakBonusRuleInput) (*models.StreakBonusRule, error) {
+	args := m.Called(ctx, parentID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StreakBonusRule), args.Error(1)
+}
+
+func (m *MockStreakService) GetBonusRules(ctx context.Context, parentID int) ([]models.StreakBonusRule, error) {
+	args := m.Called(ctx, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.StreakBonusRule), args.Error(1)
+}
+
+func (m *MockStreakService) UpdateBonusRule(ctx context.Context, parentID int, ruleID int, input *models.StreakBonusRuleInput) (*models.StreakBonusRule, error) {
+	args := m.Called(ctx, parentID, ruleID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StreakBonusRule), args.Error(1)
+}
+
+func (m *MockStreakService) DeleteBonusRule(ctx context.Context, parentID int, ruleID int) error {
+	args := m.Called(ctx, parentID, ruleID)
+	return args.Error(0)
+}
+
+func (m *MockStreakService) GetChildStreaks(ctx context.Context, parentID int, childID int) (*models.StreakSummary, error) {
+	args := m.Called(ctx, parentID, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StreakSummary), args.Error(1)
+}
+
+func (m *MockStreakService) SetFreezePolicy(ctx context.Context, parentID int, childID int, input *models.SetStreakFreezePolicyInput) (*models.StreakFreezePolicy, error) {
+	args := m.Called(ctx, parentID, childID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StreakFreezePolicy), args.Error(1)
+}
+
+func (m *MockStreakService) GetFreezePolicy(ctx context.Context, parentID int, childID int) (*models.StreakFreezePolicy, error) {
+	args := m.Called(ctx, parentID, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StreakFreezePolicy), args.Error(1)
+}
+
+func (m *MockStreakService) DeleteFreezePolicy(ctx context.Context, parentID int, childID int) error {
+	args := m.Called(ctx, parentID, childID)
+	return args.Error(0)
+}
+
+func (m *MockStreakService) GetMyStreaks(ctx context.Context, childID int) (*models.StreakSummary, error) {
+	args := m.Called(ctx, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StreakSummary), args.Error(1)
+}
+
+func (m *MockStreakService) BuyStreakFreeze(ctx context.Context, childID int) (*models.StreakFreeze, error) {
+	args := m.Called(ctx, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StreakFreeze), args.Error(1)
+}
+
+func (m *MockStreakService) ProcessStreakFreezes(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
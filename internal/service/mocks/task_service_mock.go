@@ -2,6 +2,7 @@ package mocks
 
 import (
     "context"
+    "time"
     "github.com/rakaarfi/digital-parenting-app-be/internal/models"
     "github.com/stretchr/testify/mock"
 )
@@ -41,4 +42,42 @@ func (m *MockTaskService) VerifyTask(ctx context.Context, taskID int, parentID i
 func (m *MockTaskService) SubmitTask(ctx context.Context, taskID int, childID int) error {
     args := m.Called(ctx, taskID, childID)
     return args.Error(0)
-}
\ No newline at end of file
+}
+func (m *MockTaskService) AutoApproveTask(ctx context.Context, userTaskID int) (bool, error) {
+    args := m.Called(ctx, userTaskID)
+    return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTaskService) ProcessDueAutoApprovals(ctx context.Context, now time.Time) (int, error) {
+    args := m.Called(ctx, now)
+    return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskService) UnassignTask(ctx context.Context, userTaskID int, parentID int) error {
+    args := m.Called(ctx, userTaskID, parentID)
+    return args.Error(0)
+}
+
+func (m *MockTaskService) ReassignTask(ctx context.Context, userTaskID int, parentID int, newChildID int) error {
+    args := m.Called(ctx, userTaskID, parentID, newChildID)
+    return args.Error(0)
+}
+
+func (m *MockTaskService) RevertVerification(ctx context.Context, userTaskID int, parentID int, reason string) error {
+    args := m.Called(ctx, userTaskID, parentID, reason)
+    return args.Error(0)
+}
+
+func (m *MockTaskService) ExpireOverdueTasks(ctx context.Context, now time.Time) (int, error) {
+    args := m.Called(ctx, now)
+    return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskService) GetTaskTimeline(ctx context.Context, userTaskID int, userID int) ([]models.UserTaskEvent, error) {
+    args := m.Called(ctx, userTaskID, userID)
+    var r0 []models.UserTaskEvent
+    if args.Get(0) != nil {
+        r0 = args.Get(0).([]models.UserTaskEvent)
+    }
+    return r0, args.Error(1)
+}
@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTemplateService struct {
+	mock.Mock
+}
+
+func (m *MockTemplateService) CreateTemplate(ctx context.Context, adminID int, input *models.TemplateInput) (int, error) {
+	args := m.Called(ctx, adminID, input)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTemplateService) UpdateTemplate(ctx context.Context, templateID int, input *models.TemplateInput) error {
+	args := m.Called(ctx, templateID, input)
+	return args.Error(0)
+}
+
+func (m *MockTemplateService) DeleteTemplate(ctx context.Context, templateID int) error {
+	args := m.Called(ctx, templateID)
+	return args.Error(0)
+}
+
+func (m *MockTemplateService) GetTemplateByID(ctx context.Context, templateID int, locale string) (*models.DefinitionTemplate, error) {
+	args := m.Called(ctx, templateID, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DefinitionTemplate), args.Error(1)
+}
+
+func (m *MockTemplateService) ListTemplates(ctx context.Context, filter *models.TemplateFilter, includeInactive bool, page, limit int) ([]models.DefinitionTemplate, int, error) {
+	args := m.Called(ctx, filter, includeInactive, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]models.DefinitionTemplate), args.Int(1), args.Error(2)
+}
+
+func (m *MockTemplateService) ImportTemplates(ctx context.Context, parentID int, input *models.ImportTemplatesInput) ([]models.ImportedDefinition, error) {
+	args := m.Called(ctx, parentID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ImportedDefinition), args.Error(1)
+}
+
+func (m *MockTemplateService) GetUpdateSuggestions(ctx context.Context, parentID int, locale string) ([]models.TemplateUpdateSuggestion, error) {
+	args := m.Called(ctx, parentID, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TemplateUpdateSuggestion), args.Error(1)
+}
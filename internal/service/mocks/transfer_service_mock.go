@@ -0,0 +1,75 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTransferService struct {
+	mock.Mock
+}
+
+func (m *MockTransferService) SetPolicy(ctx context.Context, parentID int, childID int, input *models.SetTransferPolicyInput) (*models.TransferPolicy, error) {
+	args := m.Called(ctx, parentID, childID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TransferPolicy), args.Error(1)
+}
+
+func (m *MockTransferService) GetPolicy(ctx context.Context, parentID int, childID int) (*models.TransferPolicy, error) {
+	args := m.Called(ctx, parentID, childID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TransferPolicy), args.Error(1)
+}
+
+func (m *MockTransferService) DeletePolicy(ctx context.Context, parentID int, childID int) error {
+	args := m.Called(ctx, parentID, childID)
+	return args.Error(0)
+}
+
+func (m *MockTransferService) CreateTransfer(ctx context.Context, childID int, input *models.CreateTransferInput) (*models.PointTransfer, error) {
+	args := m.Called(ctx, childID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PointTransfer), args.Error(1)
+}
+
+func (m *MockTransferService) CancelTransfer(ctx context.Context, childID int, transferID int) error {
+	args := m.Called(ctx, childID, transferID)
+	return args.Error(0)
+}
+
+func (m *MockTransferService) GetMyTransfers(ctx context.Context, childID int, page, limit int) ([]models.PointTransfer, int, error) {
+	args := m.Called(ctx, childID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]models.PointTransfer), args.Int(1), args.Error(2)
+}
+
+func (m *MockTransferService) GetTransfersForParent(ctx context.Context, parentID int, status models.PointTransferStatus, page, limit int) ([]models.PointTransfer, int, error) {
+	args := m.Called(ctx, parentID, status, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]models.PointTransfer), args.Int(1), args.Error(2)
+}
+
+func (m *MockTransferService) ApproveTransfer(ctx context.Context, parentID int, transferID int) (*models.PointTransfer, error) {
+	args := m.Called(ctx, parentID, transferID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PointTransfer), args.Error(1)
+}
+
+func (m *MockTransferService) RejectTransfer(ctx context.Context, parentID int, transferID int, reason string) error {
+	args := m.Called(ctx, parentID, transferID, reason)
+	return args.Error(0)
+}
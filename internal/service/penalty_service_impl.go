@@ -0,0 +1,365 @@
+// internal/service/penalty_service_impl.go
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// Batas rentang laporan sanksi.
+const (
+	defaultPenaltyReportDays = 30  // Rentang default jika 'from' kosong
+	maxPenaltyReportDays     = 366 // Rentang maksimal agar jumlah periode tetap wajar
+)
+
+// penaltyForbiddenMessage dipakai saat parent menjatuhkan atau melihat sanksi anak yang bukan anaknya.
+const penaltyForbiddenMessage = "you are not authorized to manage penalties for this child"
+
+type penaltyServiceImpl struct {
+	pool             *pgxpool.Pool // Untuk transaksi penerapan sanksi
+	penaltyRepo      repository.PenaltyRepository
+	pointRepo        repository.PointTransactionRepository
+	taskRepo         repository.TaskRepository     // Validasi kepemilikan tugas penebus
+	userTaskRepo     repository.UserTaskRepository // Menugaskan tugas penebus
+	userRepo         repository.UserRepository     // Zona waktu anak untuk batas harian & laporan
+	userRelRepo      repository.UserRelationshipRepository
+	notificationRepo repository.NotificationRepository
+	auditRepo        repository.AuditLogRepository
+}
+
+// NewPenaltyService creates a new instance of PenaltyService.
+func NewPenaltyService(
+	pool *pgxpool.Pool,
+	penaltyRepo repository.PenaltyRepository,
+	pointRepo repository.PointTransactionRepository,
+	taskRepo repository.TaskRepository,
+	userTaskRepo repository.UserTaskRepository,
+	userRepo repository.UserRepository,
+	userRelRepo repository.UserRelationshipRepository,
+	notificationRepo repository.NotificationRepository,
+	auditRepo repository.AuditLogRepository,
+) PenaltyService {
+	return &penaltyServiceImpl{
+		pool:             pool,
+		penaltyRepo:      penaltyRepo,
+		pointRepo:        pointRepo,
+		taskRepo:         taskRepo,
+		userTaskRepo:     userTaskRepo,
+		userRepo:         userRepo,
+		userRelRepo:      userRelRepo,
+		notificationRepo: notificationRepo,
+		auditRepo:        auditRepo,
+	}
+}
+
+// --- Helper Functions ---
+
+// getOwnedInfractionType mengambil jenis pelanggaran dan memastikan dimiliki parentID.
+func (s *penaltyServiceImpl) getOwnedInfractionType(ctx context.Context, parentID int, infractionTypeID int) (*models.InfractionType, error) {
+	infraction, err := s.penaltyRepo.GetInfractionTypeByID(ctx, infractionTypeID)
+	if err != nil {
+		return nil, err
+	}
+	if infraction.CreatedByUserID != parentID {
+		return nil, fmt.Errorf("forbidden: you are not authorized to use this infraction type")
+	}
+	return infraction, nil
+}
+
+// validateEarnBackTask memastikan tugas penebus (jika ada) dibuat oleh parentID.
+func (s *penaltyServiceImpl) validateEarnBackTask(ctx context.Context, parentID int, taskID int) error {
+	if taskID == 0 {
+		return nil
+	}
+	task, err := s.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("invalid earn_back_task_id: task %d not found", taskID)
+		}
+		return fmt.Errorf("internal server error: could not retrieve earn-back task")
+	}
+	if task.CreatedByUserID != parentID {
+		return fmt.Errorf("forbidden: earn-back task must be created by you")
+	}
+	return nil
+}
+
+// --- Public Methods ---
+
+// CreateInfractionType membuat jenis pelanggaran baru milik parent.
+func (s *penaltyServiceImpl) CreateInfractionType(ctx context.Context, parentID int, input *models.InfractionTypeInput) (*models.InfractionType, error) {
+	if err := s.validateEarnBackTask(ctx, parentID, input.EarnBackTaskID); err != nil {
+		return nil, err
+	}
+	infraction := &models.InfractionType{
+		CreatedByUserID: parentID,
+		Name:            input.Name,
+		Description:     input.Description,
+		Points:          input.Points,
+		MaxPerDay:       input.MaxPerDay,
+		EarnBackTaskID:  input.EarnBackTaskID,
+	}
+	if err := s.penaltyRepo.CreateInfractionType(ctx, infraction); err != nil {
+		return nil, err
+	}
+	return infraction, nil
+}
+
+// GetInfractionTypes mengambil katalog pelanggaran milik parent.
+func (s *penaltyServiceImpl) GetInfractionTypes(ctx context.Context, parentID int) ([]models.InfractionType, error) {
+	return s.penaltyRepo.GetInfractionTypesByOwnerID(ctx, parentID)
+}
+
+// UpdateInfractionType memperbarui jenis pelanggaran milik parent.
+func (s *penaltyServiceImpl) UpdateInfractionType(ctx context.Context, parentID int, infractionTypeID int, input *models.InfractionTypeInput) (*models.InfractionType, error) {
+	if _, err := s.getOwnedInfractionType(ctx, parentID, infractionTypeID); err != nil {
+		return nil, err
+	}
+	if err := s.validateEarnBackTask(ctx, parentID, input.EarnBackTaskID); err != nil {
+		return nil, err
+	}
+	infraction := &models.InfractionType{
+		ID:              infractionTypeID,
+		CreatedByUserID: parentID,
+		Name:            input.Name,
+		Description:     input.Description,
+		Points:          input.Points,
+		MaxPerDay:       input.MaxPerDay,
+		EarnBackTaskID:  input.EarnBackTaskID,
+	}
+	if err := s.penaltyRepo.UpdateInfractionType(ctx, infraction); err != nil {
+		return nil, err
+	}
+	// Ambil ulang agar nama tugas penebus (join) ikut terisi
+	return s.penaltyRepo.GetInfractionTypeByID(ctx, infractionTypeID)
+}
+
+// DeleteInfractionType menghapus jenis pelanggaran milik parent.
+func (s *penaltyServiceImpl) DeleteInfractionType(ctx context.Context, parentID int, infractionTypeID int) error {
+	if _, err := s.getOwnedInfractionType(ctx, parentID, infractionTypeID); err != nil {
+		return err
+	}
+	return s.penaltyRepo.DeleteInfractionType(ctx, infractionTypeID, parentID)
+}
+
+// ApplyPenalty menerapkan sanksi ke anak. Potongan poin dibatasi saldo anak agar saldo tidak negatif;
+// sanksi tetap tercatat (dengan points_deducted lebih kecil) sehingga laporan tetap lengkap.
+func (s *penaltyServiceImpl) ApplyPenalty(ctx context.Context, parentID int, childID int, input *models.ApplyPenaltyInput) (*models.Penalty, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, penaltyForbiddenMessage); err != nil {
+		return nil, err
+	}
+	infraction, err := s.getOwnedInfractionType(ctx, parentID, input.InfractionTypeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("invalid infraction_type_id: infraction type %d not found", input.InfractionTypeID)
+		}
+		return nil, err
+	}
+	child, err := s.userRepo.GetUserByID(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+	loc := models.LoadTimezone(child.Timezone)
+	dayStart := models.LocalMidnight(models.LocalDate(time.Now(), loc), loc)
+
+	penalty := &models.Penalty{
+		ChildID:          childID,
+		InfractionTypeID: infraction.ID,
+		InfractionName:   infraction.Name,
+		Points:           infraction.Points,
+		Notes:            input.Notes,
+		AppliedByUserID:  parentID,
+	}
+	err = withTx(ctx, s.pool, "ApplyPenalty", func(tx pgx.Tx) error {
+		// Mengunci baris saldo anak sehingga penerapan sanksi paralel (dan cek batas harian) berurutan
+		balance, err := s.pointRepo.CalculateTotalPointsByUserIDTx(ctx, tx, childID)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not retrieve points balance")
+		}
+		if infraction.MaxPerDay > 0 {
+			count, err := s.penaltyRepo.CountPenaltiesSinceTx(ctx, tx, childID, infraction.ID, dayStart)
+			if err != nil {
+				return fmt.Errorf("internal server error: could not check daily penalty limit")
+			}
+			if count >= infraction.MaxPerDay {
+				return fmt.Errorf("cannot apply penalty: daily limit of %d reached for '%s'", infraction.MaxPerDay, infraction.Name)
+			}
+		}
+
+		penalty.PointsDeducted = min(infraction.Points, max(balance, 0))
+		if penalty.PointsDeducted > 0 {
+			debit := &models.PointTransaction{
+				UserID:          childID,
+				ChangeAmount:    -penalty.PointsDeducted,
+				TransactionType: models.TransactionTypePenalty,
+				CreatedByUserID: parentID,
+				Notes:           fmt.Sprintf("Penalty: %s", infraction.Name),
+			}
+			if err := s.pointRepo.CreateTransactionTx(ctx, tx, debit); err != nil {
+				return fmt.Errorf("internal server error: could not record penalty")
+			}
+			penalty.TransactionID = debit.ID
+		}
+
+		if infraction.EarnBackTaskID != 0 {
+			userTaskID, err := s.userTaskRepo.AssignTaskTx(ctx, tx, childID, infraction.EarnBackTaskID, parentID, nil)
+			if err != nil {
+				return fmt.Errorf("internal server error: could not assign earn-back task")
+			}
+			penalty.EarnBackUserTaskID = userTaskID
+			penalty.EarnBackTaskStatus = models.UserTaskStatusAssigned
+		}
+		if err := s.penaltyRepo.CreatePenaltyTx(ctx, tx, penalty); err != nil {
+			return fmt.Errorf("internal server error: could not create penalty")
+		}
+
+		message := fmt.Sprintf("%d points were deducted for '%s'.", penalty.PointsDeducted, infraction.Name)
+		if penalty.EarnBackUserTaskID != 0 {
+			message += fmt.Sprintf(" Complete '%s' to earn them back.", infraction.EarnBackTaskName)
+		}
+		err = s.notificationRepo.CreateNotificationTx(ctx, tx, &models.Notification{
+			UserID:     childID,
+			Type:       models.NotificationPenaltyApplied,
+			Title:      "Penalty applied",
+			Message:    message,
+			EntityType: "penalty",
+			EntityID:   penalty.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("internal server error: could not send notification")
+		}
+
+		auditDetails := map[string]any{
+			"child_id":           childID,
+			"infraction_type_id": infraction.ID,
+			"points":             penalty.Points,
+			"points_deducted":    penalty.PointsDeducted,
+		}
+		if err := recordAuditTx(ctx, tx, s.auditRepo, parentID, "penalty.applied", "penalty", penalty.ID, auditDetails); err != nil {
+			return fmt.Errorf("internal server error: could not record audit log")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zlog.Info().Int("penalty_id", penalty.ID).Int("child_id", childID).Int("parent_id", parentID).
+		Int("points_deducted", penalty.PointsDeducted).Msg("Service: Penalty applied")
+	return penalty, nil
+}
+
+// GetChildPenalties mengambil riwayat sanksi anak (Parent).
+func (s *penaltyServiceImpl) GetChildPenalties(ctx context.Context, parentID int, childID int, page, limit int) ([]models.Penalty, int, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, penaltyForbiddenMessage); err != nil {
+		return nil, 0, err
+	}
+	return s.penaltyRepo.GetPenaltiesByChildID(ctx, childID, page, limit)
+}
+
+// GetMyPenalties mengambil riwayat sanksi anak sendiri.
+func (s *penaltyServiceImpl) GetMyPenalties(ctx context.Context, childID int, page, limit int) ([]models.Penalty, int, error) {
+	return s.penaltyRepo.GetPenaltiesByChildID(ctx, childID, page, limit)
+}
+
+// GetPenaltyReport menyusun laporan sanksi anak. Periode dihitung di zona waktu anak dan periode tanpa
+// sanksi tetap disertakan agar tren mudah dibaca. Poin yang ditebus dihitung pada periode sanksinya.
+func (s *penaltyServiceImpl) GetPenaltyReport(ctx context.Context, parentID int, childID int, filter *models.PenaltyReportFilter) (*models.PenaltyReport, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, penaltyForbiddenMessage); err != nil {
+		return nil, err
+	}
+	child, err := s.userRepo.GetUserByID(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+	loc := models.LoadTimezone(child.Timezone)
+
+	groupBy := models.PenaltyReportGroupBy(filter.GroupBy)
+	if groupBy == "" {
+		groupBy = models.PenaltyReportByWeek
+	}
+	toDate := models.LocalDate(time.Now(), loc)
+	if filter.To != "" {
+		if toDate, err = time.Parse(models.PenaltyReportDateLayout, filter.To); err != nil {
+			return nil, fmt.Errorf("invalid to: expected YYYY-MM-DD")
+		}
+	}
+	fromDate := toDate.AddDate(0, 0, -(defaultPenaltyReportDays - 1))
+	if filter.From != "" {
+		if fromDate, err = time.Parse(models.PenaltyReportDateLayout, filter.From); err != nil {
+			return nil, fmt.Errorf("invalid from: expected YYYY-MM-DD")
+		}
+	}
+	if fromDate.After(toDate) {
+		return nil, fmt.Errorf("invalid range: from must not be after to")
+	}
+	if toDate.Sub(fromDate) >= maxPenaltyReportDays*24*time.Hour {
+		return nil, fmt.Errorf("invalid range: at most %d days can be reported", maxPenaltyReportDays)
+	}
+
+	start := models.LocalMidnight(fromDate, loc)
+	end := models.LocalMidnight(toDate.AddDate(0, 0, 1), loc)
+	penalties, err := s.penaltyRepo.GetPenaltiesBetween(ctx, childID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.PenaltyReport{
+		ChildID:      childID,
+		From:         fromDate.Format(models.PenaltyReportDateLayout),
+		To:           toDate.Format(models.PenaltyReportDateLayout),
+		Timezone:     loc.String(),
+		GroupBy:      groupBy,
+		Periods:      []models.PenaltyReportPeriod{},
+		ByInfraction: []models.PenaltyInfractionTotal{},
+	}
+	for periodStart := groupBy.PeriodStart(start, loc); periodStart.Before(end); periodStart = groupBy.Next(periodStart) {
+		report.Periods = append(report.Periods, models.PenaltyReportPeriod{PeriodStart: periodStart})
+	}
+
+	// penalties terurut created_at, sehingga indeks periode hanya perlu maju
+	periodIndex := 0
+	for _, penalty := range penalties {
+		for periodIndex+1 < len(report.Periods) && !penalty.CreatedAt.Before(report.Periods[periodIndex+1].PeriodStart) {
+			periodIndex++
+		}
+		earnedBack := 0
+		if penalty.EarnedBackAt != nil {
+			earnedBack = penalty.PointsDeducted
+		}
+		period := &report.Periods[periodIndex]
+		period.Count++
+		period.PointsDeducted += penalty.PointsDeducted
+		period.PointsEarnedBack += earnedBack
+		report.TotalCount++
+		report.TotalPointsDeducted += penalty.PointsDeducted
+		report.TotalPointsEarnedBack += earnedBack
+
+		i := slices.IndexFunc(report.ByInfraction, func(total models.PenaltyInfractionTotal) bool {
+			return total.InfractionTypeID == penalty.InfractionTypeID && total.InfractionName == penalty.InfractionName
+		})
+		if i < 0 {
+			report.ByInfraction = append(report.ByInfraction, models.PenaltyInfractionTotal{
+				InfractionTypeID: penalty.InfractionTypeID,
+				InfractionName:   penalty.InfractionName,
+			})
+			i = len(report.ByInfraction) - 1
+		}
+		report.ByInfraction[i].Count++
+		report.ByInfraction[i].PointsDeducted += penalty.PointsDeducted
+	}
+	slices.SortStableFunc(report.ByInfraction, func(a, b models.PenaltyInfractionTotal) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+	return report, nil
+}
@@ -0,0 +1,261 @@
+// internal/service/point_expiration_service_impl.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// pointExpirationBatchSize membatasi jumlah kebijakan yang dibaca worker per query.
+const pointExpirationBatchSize = 100
+
+// pointExpirationForbiddenMessage dipakai saat parent mengatur kedaluwarsa poin anak yang bukan anaknya.
+const pointExpirationForbiddenMessage = "you are not authorized to manage point expiration for this child"
+
+type pointExpirationServiceImpl struct {
+	pool             *pgxpool.Pool // Untuk transaksi pencatatan kedaluwarsa
+	expirationRepo   repository.PointExpirationRepository
+	pointRepo        repository.PointTransactionRepository
+	goalRepo         repository.SavingsGoalRepository // Poin yang disisihkan untuk target tabungan tidak ikut hangus
+	userRelRepo      repository.UserRelationshipRepository
+	notificationRepo repository.NotificationRepository
+}
+
+// NewPointExpirationService creates a new instance of PointExpirationService.
+func NewPointExpirationService(
+	pool *pgxpool.Pool,
+	expirationRepo repository.PointExpirationRepository,
+	pointRepo repository.PointTransactionRepository,
+	goalRepo repository.SavingsGoalRepository,
+	userRelRepo repository.UserRelationshipRepository,
+	notificationRepo repository.NotificationRepository,
+) PointExpirationService {
+	return &pointExpirationServiceImpl{
+		pool:             pool,
+		expirationRepo:   expirationRepo,
+		pointRepo:        pointRepo,
+		goalRepo:         goalRepo,
+		userRelRepo:      userRelRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+// --- Helper Functions ---
+
+// withExpiry mengisi ExpiresAt setiap lot berdasarkan kebijakan.
+func withExpiry(lots []models.PointLot, policy *models.PointExpirationPolicy) []models.PointLot {
+	for i := range lots {
+		expiresAt := lots[i].EarnedAt.AddDate(0, 0, policy.ExpireAfterDays)
+		lots[i].ExpiresAt = &expiresAt
+	}
+	return lots
+}
+
+// expireChildPoints mencatat satu transaksi 'expiration' untuk seluruh sisa lot yang sudah kedaluwarsa.
+// Saldo anak dikunci lebih dulu sehingga tidak bentrok dengan klaim yang berjalan bersamaan.
+// Mengembalikan jumlah poin yang kedaluwarsa.
+func (s *pointExpirationServiceImpl) expireChildPoints(ctx context.Context, policy *models.PointExpirationPolicy, now time.Time) (int, error) {
+	expired := 0
+	err := withTx(ctx, s.pool, "ExpirePoints", func(tx pgx.Tx) error {
+		balance, err := s.pointRepo.CalculateTotalPointsByUserIDTx(ctx, tx, policy.ChildID)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not retrieve points balance")
+		}
+		lots, err := s.expirationRepo.GetOpenLotsTx(ctx, tx, policy.ChildID)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not retrieve point lots")
+		}
+
+		due := 0
+		for _, lot := range withExpiry(lots, policy) {
+			if !lot.ExpiresAt.After(now) {
+				due += lot.Remaining
+			}
+		}
+		if due == 0 {
+			return nil
+		}
+
+		earmarked, err := s.goalRepo.SumEarmarkedPointsTx(ctx, tx, policy.ChildID, 0)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not retrieve earmarked points")
+		}
+		amount := min(due, balance-earmarked)
+		if amount <= 0 {
+			return nil
+		}
+
+		err = s.pointRepo.CreateTransactionTx(ctx, tx, &models.PointTransaction{
+			UserID:          policy.ChildID,
+			ChangeAmount:    -amount,
+			TransactionType: models.TransactionTypeExpiration,
+			Notes:           fmt.Sprintf("Points expired after %d days", policy.ExpireAfterDays),
+			// CreatedByUserID 0 = dicatat oleh sistem
+		})
+		if err != nil {
+			return fmt.Errorf("internal server error: could not record expired points")
+		}
+		err = s.notificationRepo.CreateNotificationTx(ctx, tx, &models.Notification{
+			UserID:  policy.ChildID,
+			Type:    models.NotificationPointsExpired,
+			Title:   "Points expired",
+			Message: fmt.Sprintf("%d of your points expired because they were not used within %d days.", amount, policy.ExpireAfterDays),
+		})
+		if err != nil {
+			return fmt.Errorf("internal server error: could not send notification")
+		}
+		expired = amount
+		return nil
+	})
+	return expired, err
+}
+
+// warnExpiringPoints memberi tahu anak tentang lot yang akan kedaluwarsa dalam masa peringatan.
+// Setiap lot hanya diperingatkan satu kali.
+func (s *pointExpirationServiceImpl) warnExpiringPoints(ctx context.Context, policy *models.PointExpirationPolicy, now time.Time) error {
+	if policy.WarnBeforeDays == 0 {
+		return nil
+	}
+	lots, err := s.expirationRepo.GetOpenLots(ctx, policy.ChildID)
+	if err != nil {
+		return err
+	}
+	warnUntil := now.AddDate(0, 0, policy.WarnBeforeDays)
+	for _, lot := range withExpiry(lots, policy) {
+		if !lot.ExpiresAt.After(now) || lot.ExpiresAt.After(warnUntil) {
+			continue
+		}
+		warned, err := s.notificationRepo.HasNotification(ctx, policy.ChildID, models.NotificationPointsExpiring, "point_transaction", lot.TransactionID)
+		if err != nil {
+			return err
+		}
+		if warned {
+			continue
+		}
+		err = s.notificationRepo.CreateNotification(ctx, &models.Notification{
+			UserID:     policy.ChildID,
+			Type:       models.NotificationPointsExpiring,
+			Title:      "Points expiring soon",
+			Message:    fmt.Sprintf("%d points will expire on %s. Use them before then!", lot.Remaining, lot.ExpiresAt.Format("2006-01-02")),
+			EntityType: "point_transaction",
+			EntityID:   lot.TransactionID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// --- Public Methods ---
+
+// SetPolicy membuat atau memperbarui kebijakan kedaluwarsa poin milik parent untuk semua anaknya (childID 0) atau satu anak.
+func (s *pointExpirationServiceImpl) SetPolicy(ctx context.Context, parentID int, childID int, input *models.SetPointExpirationPolicyInput) (*models.PointExpirationPolicy, error) {
+	if childID != 0 {
+		if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, pointExpirationForbiddenMessage); err != nil {
+			return nil, err
+		}
+	}
+	if input.WarnBeforeDays >= input.ExpireAfterDays {
+		return nil, fmt.Errorf("invalid policy: warn_before_days must be less than expire_after_days")
+	}
+	policy := &models.PointExpirationPolicy{
+		CreatedByUserID: parentID,
+		ChildID:         childID,
+		ExpireAfterDays: input.ExpireAfterDays,
+		WarnBeforeDays:  input.WarnBeforeDays,
+	}
+	if err := s.expirationRepo.UpsertPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// GetPolicy mengambil kebijakan keluarga milik parent (childID 0), atau kebijakan yang berlaku untuk anak.
+func (s *pointExpirationServiceImpl) GetPolicy(ctx context.Context, parentID int, childID int) (*models.PointExpirationPolicy, error) {
+	if childID == 0 {
+		return s.expirationRepo.GetPolicyByOwner(ctx, parentID, 0)
+	}
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, pointExpirationForbiddenMessage); err != nil {
+		return nil, err
+	}
+	return s.expirationRepo.GetPolicyForChild(ctx, childID)
+}
+
+// DeletePolicy menghapus kebijakan kedaluwarsa poin milik parent untuk semua anak (childID 0) atau satu anak.
+func (s *pointExpirationServiceImpl) DeletePolicy(ctx context.Context, parentID int, childID int) error {
+	if childID != 0 {
+		if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, pointExpirationForbiddenMessage); err != nil {
+			return err
+		}
+	}
+	return s.expirationRepo.DeletePolicy(ctx, parentID, childID)
+}
+
+// GetExpiringPoints mengambil lot poin anak yang kedaluwarsa dalam masa peringatan (termasuk yang sudah
+// lewat namun belum diproses worker).
+func (s *pointExpirationServiceImpl) GetExpiringPoints(ctx context.Context, childID int, now time.Time) (*models.ExpiringPointsSummary, error) {
+	summary := &models.ExpiringPointsSummary{Lots: []models.PointLot{}}
+	policy, err := s.expirationRepo.GetPolicyForChild(ctx, childID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return summary, nil // Tidak ada kebijakan: poin tidak kedaluwarsa
+		}
+		return nil, fmt.Errorf("internal server error: could not retrieve expiration policy")
+	}
+	summary.Policy = policy
+
+	lots, err := s.expirationRepo.GetOpenLots(ctx, childID)
+	if err != nil {
+		return nil, fmt.Errorf("internal server error: could not retrieve point lots")
+	}
+	warnUntil := now.AddDate(0, 0, policy.WarnBeforeDays)
+	for _, lot := range withExpiry(lots, policy) {
+		if lot.ExpiresAt.After(warnUntil) {
+			break // Lot terurut dari yang tertua, sisanya kedaluwarsa lebih lambat
+		}
+		summary.ExpiringPoints += lot.Remaining
+		summary.Lots = append(summary.Lots, lot)
+	}
+	return summary, nil
+}
+
+// ProcessExpirations memproses semua anak yang memiliki kebijakan kedaluwarsa yang berlaku (dipanggil oleh worker).
+func (s *pointExpirationServiceImpl) ProcessExpirations(ctx context.Context, now time.Time) (int, error) {
+	total := 0
+	afterChildID := 0
+	for {
+		policies, err := s.expirationRepo.GetPoliciesAfter(ctx, afterChildID, pointExpirationBatchSize)
+		if err != nil {
+			return total, err
+		}
+		for i := range policies {
+			policy := &policies[i]
+			expired, err := s.expireChildPoints(ctx, policy, now)
+			if err != nil {
+				zlog.Error().Err(err).Int("child_id", policy.ChildID).Msg("Service: Failed to expire points")
+				continue
+			}
+			total += expired
+			if err := s.warnExpiringPoints(ctx, policy, now); err != nil {
+				zlog.Error().Err(err).Int("child_id", policy.ChildID).Msg("Service: Failed to send point expiry warnings")
+			}
+		}
+		if len(policies) < pointExpirationBatchSize {
+			break
+		}
+		afterChildID = policies[len(policies)-1].ChildID
+	}
+	if total > 0 {
+		zlog.Info().Int("points_expired", total).Msg("Service: Expired points processed")
+	}
+	return total, nil
+}
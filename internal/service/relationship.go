@@ -0,0 +1,25 @@
+// internal/service/relationship.go
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// ensureParentOf memastikan parentID adalah orang tua dari childID.
+// forbiddenMessage adalah pesan error (tanpa awalan "forbidden: ") jika bukan orang tua anak tersebut,
+// misal: "you are not authorized to manage badges for this child".
+func ensureParentOf(ctx context.Context, userRelRepo repository.UserRelationshipRepository, parentID int, childID int, forbiddenMessage string) error {
+	isParent, err := userRelRepo.IsParentOf(ctx, parentID, childID)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", parentID).Int("child_id", childID).Msg("Service: Error checking parent-child relationship")
+		return fmt.Errorf("internal server error: could not verify relationship")
+	}
+	if !isParent {
+		return fmt.Errorf("forbidden: %s", forbiddenMessage)
+	}
+	return nil
+}
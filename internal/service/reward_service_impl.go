@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -14,6 +15,9 @@ import (
 	zlog "github.com/rs/zerolog/log"
 )
 
+// approvalPolicyForbiddenMessage dipakai saat parent mengatur kebijakan persetujuan bersama anak yang bukan anaknya.
+const approvalPolicyForbiddenMessage = "you are not authorized to manage approval policies for this child"
+
 // rewardServiceImpl implements the RewardService interface.
 type rewardServiceImpl struct {
 	pool           *pgxpool.Pool
@@ -21,12 +25,39 @@ type rewardServiceImpl struct {
 	userRewardRepo repository.UserRewardRepository
 	pointRepo      repository.PointTransactionRepository
 	userRelRepo    repository.UserRelationshipRepository
+	goalRepo       repository.SavingsGoalRepository
+	approvalRepo   repository.RewardApprovalRepository
+	badgeService   BadgeService // Evaluasi badge setelah klaim & review
 }
 
 // Definisikan error spesifik untuk service layer jika perlu
 var ErrInsufficientPoints = errors.New("insufficient points to claim reward")
 var ErrInvalidReviewStatus = errors.New("invalid status provided for review")
 
+// RewardUnavailableError dikembalikan ClaimReward saat level, stok, kuota per anak, atau cooldown menolak klaim.
+// Availability berisi kode alasan dan kapan hadiah bisa diklaim lagi.
+type RewardUnavailableError struct {
+	Availability models.RewardAvailability
+}
+
+func (e *RewardUnavailableError) Error() string {
+	switch e.Availability.Reason {
+	case models.RewardUnavailableOutOfStock:
+		return "reward is out of stock"
+	case models.RewardUnavailableClaimLimitReached:
+		if e.Availability.AvailableAt != nil {
+			return fmt.Sprintf("claim limit reached for this reward, available again at %s", e.Availability.AvailableAt.Format(time.RFC3339))
+		}
+		return "claim limit reached for this reward"
+	case models.RewardUnavailableCooldown:
+		return fmt.Sprintf("reward is on cooldown, available again at %s", e.Availability.AvailableAt.Format(time.RFC3339))
+	case models.RewardUnavailableLevelLocked:
+		return fmt.Sprintf("reward is locked until level %d", e.Availability.RequiredLevel)
+	default:
+		return "reward is currently unavailable"
+	}
+}
+
 // NewRewardService creates a new instance of RewardService.
 func NewRewardService(
 	pool *pgxpool.Pool,
@@ -34,6 +65,9 @@ func NewRewardService(
 	userRewardRepo repository.UserRewardRepository,
 	pointRepo repository.PointTransactionRepository,
 	userRelRepo repository.UserRelationshipRepository,
+	goalRepo repository.SavingsGoalRepository,
+	approvalRepo repository.RewardApprovalRepository,
+	badgeService BadgeService,
 ) RewardService {
 	return &rewardServiceImpl{
 		pool:           pool,
@@ -41,7 +75,48 @@ func NewRewardService(
 		userRewardRepo: userRewardRepo,
 		pointRepo:      pointRepo,
 		userRelRepo:    userRelRepo,
+		goalRepo:       goalRepo,
+		approvalRepo:   approvalRepo,
+		badgeService:   badgeService,
+	}
+}
+
+// requiredApprovalsTx menentukan jumlah persetujuan orang tua berbeda yang dibutuhkan klaim.
+// Mengembalikan 1 jika tidak ada kebijakan dari orang tua anak yang berlaku atau nilai klaim tidak melewati ambang.
+// Kebutuhan dibatasi jumlah orang tua anak agar klaim tidak tertahan selamanya.
+func (s *rewardServiceImpl) requiredApprovalsTx(ctx context.Context, tx pgx.Tx, claim *repository.ClaimReviewDetails) (int, error) {
+	policy, err := s.approvalRepo.GetPolicyForChildTx(ctx, tx, claim.ChildID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 1, nil
+		}
+		return 0, fmt.Errorf("internal server error: could not retrieve approval policy")
+	}
+	if claim.PointsDeducted <= policy.PointsThreshold {
+		return 1, nil
+	}
+	parentIDs, err := s.userRelRepo.GetParentIDsByChildIDTx(ctx, tx, claim.ChildID)
+	if err != nil {
+		return 0, fmt.Errorf("internal server error: could not retrieve parents")
+	}
+	return min(policy.RequiredApprovals, len(parentIDs)), nil
+}
+
+// newUserRewardEvent membangun event riwayat klaim; actorID == SystemActorID dicatat sebagai aksi sistem.
+func newUserRewardEvent(claimID int, action models.UserRewardAction, from, to models.UserRewardStatus, actorID int, note string) *models.UserRewardEvent {
+	event := &models.UserRewardEvent{
+		UserRewardID: claimID,
+		Action:       action,
+		FromStatus:   from,
+		ToStatus:     to,
+		ActorUserID:  actorID,
+		ActorType:    models.AuditActorUser,
+		Note:         note,
+	}
+	if actorID == SystemActorID {
+		event.ActorType = models.AuditActorSystem
 	}
+	return event
 }
 
 // ClaimReward implements the business logic for claiming a reward, including transaction management.
@@ -53,6 +128,13 @@ func (s *rewardServiceImpl) ClaimReward(ctx context.Context, childID int, reward
 		return 0, fmt.Errorf("internal server error: could not start operation")
 	}
 
+	// Evaluasi badge setelah commit (defer ini dijalankan setelah defer commit di bawah)
+	defer func() {
+		if err == nil {
+			evaluateBadgesAfterCommit(ctx, s.badgeService, childID)
+		}
+	}()
+
 	// --- 2. Defer Rollback/Commit ---
 	defer func() {
 		if p := recover(); p != nil {
@@ -111,45 +193,99 @@ func (s *rewardServiceImpl) ClaimReward(ctx context.Context, childID int, reward
 		return 0, err // Rollback
 	}
 
-	// 3c. Dapatkan Poin Anak Saat Ini
-	currentPoints, err := s.pointRepo.CalculateTotalPointsByUserIDTx(ctx, tx, childID)
+	// 3c. Validasi Level, Stok, Kuota, dan Cooldown (baris reward sudah di-lock sehingga klaim bersamaan diserialisasi)
+	usage, err := s.rewardRepo.GetRewardUsageTx(ctx, tx, childID, rewardID)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Int("reward_id", rewardID).Msg("Service: Error fetching reward usage for claim")
+		err = fmt.Errorf("internal server error: could not check reward availability")
+		return 0, err // Rollback
+	}
+	availability := rewardDetails.Limits.Availability(*usage, time.Now())
+	if !availability.Claimable {
+		zlog.Warn().Int("child_id", childID).Int("reward_id", rewardID).Str("reason", string(availability.Reason)).Msg("Service: Reward not available for claim")
+		err = &RewardUnavailableError{Availability: availability}
+		return 0, err // Rollback
+	}
+
+	// 3d. Dapatkan Poin Anak Saat Ini (baris saldo dikunci sampai commit, mencegah klaim bersamaan membelanjakan poin yang sama)
+	//     Saldo yang dibaca adalah saldo mata uang harga reward (0 = poin)
+	currentPoints, err := s.pointRepo.CalculateBalanceTx(ctx, tx, childID, rewardDetails.CurrencyID)
 	if err != nil {
 		zlog.Error().Err(err).Int("child_id", childID).Msg("Service: Error calculating child points for claim")
 		err = fmt.Errorf("internal server error: could not retrieve points balance")
 		return 0, err // Rollback
 	}
 
-	// 3d. Cek Poin Cukup
+	// 3e. Cek Poin Cukup
 	if currentPoints < rewardDetails.RequiredPoints {
 		zlog.Warn().Int("child_id", childID).Int("reward_id", rewardID).Int("current_points", currentPoints).Int("required_points", rewardDetails.RequiredPoints).Msg("Service: Insufficient points for reward claim")
 		err = ErrInsufficientPoints // Gunakan error spesifik service
 		return 0, err               // Rollback
 	}
 
-	// 3d. Buat Record UserReward (Klaim) dalam Transaksi
-	// Asumsi: userRewardRepo.CreateClaimTx(ctx, tx, childID, rewardID, rewardDetails.RequiredPoints) -> (int, error) // return claimID
-	// Anda perlu membuat metode ini di user_reward_repo.go
-	claimID, err = s.userRewardRepo.CreateClaimTx(ctx, tx, childID, rewardID, rewardDetails.RequiredPoints)
+	// 3e'. Poin yang disisihkan untuk target tabungan lain tidak boleh dipakai.
+	//      Poin yang disisihkan untuk target yang menyematkan reward ini justru dipakai untuk klaim ini.
+	//      Target tabungan hanya menyisihkan poin, bukan mata uang lain.
+	earmarked := 0
+	if rewardDetails.CurrencyID == 0 {
+		earmarked, err = s.goalRepo.SumEarmarkedPointsTx(ctx, tx, childID, rewardID)
+		if err != nil {
+			err = fmt.Errorf("internal server error: could not retrieve earmarked points")
+			return 0, err // Rollback
+		}
+	}
+	if currentPoints-earmarked < rewardDetails.RequiredPoints {
+		zlog.Warn().Int("child_id", childID).Int("reward_id", rewardID).Int("current_points", currentPoints).Int("earmarked_points", earmarked).Msg("Service: Points earmarked for savings goals block reward claim")
+		err = fmt.Errorf("%w: %d points are earmarked for savings goals", ErrInsufficientPoints, earmarked)
+		return 0, err // Rollback
+	}
+
+	// 3f. Buat Record UserReward (Klaim) dalam Transaksi
+	//    pointsDeducted di sini adalah nilai *snapshot* saat klaim, sesuai harga reward.
+	//    stockReserved dicatat agar penolakan hanya mengembalikan stok yang benar-benar dikurangi di 3g.
+	stockReserved := rewardDetails.Limits.Stock != nil
+	claimID, err = s.userRewardRepo.CreateClaimTx(ctx, tx, childID, rewardID, rewardDetails.RequiredPoints, stockReserved)
 	if err != nil {
 		zlog.Error().Err(err).Int("child_id", childID).Int("reward_id", rewardID).Msg("Service: Failed to create user reward claim within transaction")
 		err = fmt.Errorf("internal server error: could not create claim record")
 		return 0, err // Rollback
 	}
+	err = s.userRewardRepo.CreateClaimEventTx(ctx, tx, newUserRewardEvent(claimID, models.UserRewardActionClaim, "", models.UserRewardStatusPending, childID, ""))
+	if err != nil {
+		err = fmt.Errorf("internal server error: could not record claim history")
+		return 0, err // Rollback
+	}
+	if _, err = s.goalRepo.MarkClaimedByRewardTx(ctx, tx, childID, rewardID, claimID); err != nil {
+		err = fmt.Errorf("internal server error: could not update savings goals")
+		return 0, err // Rollback
+	}
 
-	// 3e. Buat Transaksi Pengurangan Poin SEKARANG
+	// 3g. Kurangi Stok (jika hadiah memakai stok)
+	if stockReserved {
+		err = s.rewardRepo.DecrementStockTx(ctx, tx, rewardID)
+		if err != nil {
+			zlog.Error().Err(err).Int("reward_id", rewardID).Msg("Service: Failed to decrement reward stock within transaction")
+			err = fmt.Errorf("internal server error: could not update reward stock")
+			return 0, err // Rollback
+		}
+	}
+
+	// 3h. Buat Transaksi Pengurangan Poin, dikaitkan dengan klaim yang baru dibuat
 	if rewardDetails.RequiredPoints > 0 { // Hanya kurangi jika poin > 0
 		pointTx := &models.PointTransaction{
-			UserID:          childID,
-			ChangeAmount:    -rewardDetails.RequiredPoints, // Poin negatif
-			TransactionType: models.TransactionTypeRedemption,
-			// RelatedUserRewardID diisi NANTI setelah klaim dibuat? Atau bisa NULL?
-			// Jika FK constraint mengizinkan NULL, biarkan 0 di sini. Jika tidak, perlu update lagi nanti.
-			// Mari asumsikan bisa NULL/0 untuk sementara.
-			// RelatedUserRewardID: 0,
-			CreatedByUserID: childID,                                                            // Anak yang menginisiasi klaim
-			Notes:           fmt.Sprintf("Points deducted for claiming reward ID %d", rewardID), // Opsional
+			UserID:              childID,
+			ChangeAmount:        -rewardDetails.RequiredPoints, // Poin negatif
+			TransactionType:     models.TransactionTypeRedemption,
+			RelatedUserRewardID: claimID,
+			CurrencyID:          rewardDetails.CurrencyID,
+			CreatedByUserID:     childID,                                                            // Anak yang menginisiasi klaim
+			Notes:               fmt.Sprintf("Points deducted for claiming reward ID %d", rewardID), // Opsional
 		}
 		err = s.pointRepo.CreateTransactionTx(ctx, tx, pointTx)
+		if errors.Is(err, repository.ErrNegativeBalance) {
+			err = ErrInsufficientPoints // Saldo berubah sejak dicek; CHECK constraint menolak saldo negatif
+			return 0, err               // Rollback
+		}
 		if err != nil {
 			zlog.Error().Err(err).Int("reward_id", rewardID).Int("child_id", childID).Msg("Service: Failed to create point deduction transaction within DB transaction")
 			err = fmt.Errorf("internal server error: could not update points balance")
@@ -158,39 +294,30 @@ func (s *rewardServiceImpl) ClaimReward(ctx context.Context, childID int, reward
 		zlog.Info().Int("reward_id", rewardID).Int("points_deducted", rewardDetails.RequiredPoints).Int("child_id", childID).Msg("Service: Point deduction transaction created within DB transaction")
 	}
 
-	// 3f. Buat Record UserReward (Klaim) dalam Transaksi
-	//    pointsDeducted di sini adalah nilai *snapshot* saat klaim, sesuai harga reward
-	claimID, err = s.userRewardRepo.CreateClaimTx(ctx, tx, childID, rewardID, rewardDetails.RequiredPoints)
-	if err != nil {
-		zlog.Error().Err(err).Int("child_id", childID).Int("reward_id", rewardID).Msg("Service: Failed to create user reward claim within transaction")
-		// Jika pengurangan poin sudah terjadi, apakah perlu di-rollback manual?
-		// Defer akan handle rollback DB, jadi state konsisten.
-		err = fmt.Errorf("internal server error: could not create claim record")
-		return 0, err // Rollback
-	}
-
-	// (Opsional) Update RelatedUserRewardID di PointTransaction jika FK tidak nullable
-	// if !nullableFK && rewardDetails.RequiredPoints > 0 {
-	//     err = s.pointRepo.UpdateRewardIDForTransactionTx(ctx, tx, /* ID transaksi poin */, claimID)
-	//     if err != nil { /* rollback */ }
-	// }
-
 	return claimID, nil // Sukses
 }
 
-func (s *rewardServiceImpl) ReviewClaim(ctx context.Context, claimID int, parentID int, newStatus models.UserRewardStatus) (err error) {
+func (s *rewardServiceImpl) ReviewClaim(ctx context.Context, claimID int, parentID int, newStatus models.UserRewardStatus) (result *models.ClaimReviewResult, err error) {
 	// Validasi input status (opsional tapi baik)
 	if newStatus != models.UserRewardStatusApproved && newStatus != models.UserRewardStatusRejected {
-		return ErrInvalidReviewStatus
+		return nil, ErrInvalidReviewStatus
 	}
 
 	// --- 1. Mulai Transaksi ---
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
 		zlog.Error().Err(err).Msg("Service: Failed to begin transaction for reward review")
-		return fmt.Errorf("internal server error: could not start operation")
+		return nil, fmt.Errorf("internal server error: could not start operation")
 	}
 
+	// Evaluasi badge setelah commit (defer ini dijalankan setelah defer commit di bawah)
+	badgeChildID := 0
+	defer func() {
+		if err == nil {
+			evaluateBadgesAfterCommit(ctx, s.badgeService, badgeChildID)
+		}
+	}()
+
 	// --- 2. Defer Rollback/Commit ---
 	defer func() {
 		if p := recover(); p != nil {
@@ -222,18 +349,19 @@ func (s *rewardServiceImpl) ReviewClaim(ctx context.Context, claimID int, parent
 		if errors.Is(err, pgx.ErrNoRows) {
 			zlog.Warn().Int("claim_id", claimID).Msg("Service: Claim not found for review")
 			err = fmt.Errorf("reward claim not found")
-			return err // Rollback
+			return nil, err // Rollback
 		}
 		zlog.Error().Err(err).Int("claim_id", claimID).Msg("Service: Error fetching claim details for review")
 		err = fmt.Errorf("internal server error: could not retrieve claim details")
-		return err // Rollback
+		return nil, err // Rollback
 	}
+	badgeChildID = claimDetails.ChildID
 
 	// 3b. Validasi Status Saat Ini
 	if claimDetails.CurrentStatus != models.UserRewardStatusPending {
 		zlog.Warn().Int("claim_id", claimID).Str("current_status", string(claimDetails.CurrentStatus)).Msg("Service: Review claim failed: Claim not in 'pending' status")
 		err = fmt.Errorf("cannot review claim: current status is '%s', expected 'pending'", claimDetails.CurrentStatus)
-		return err // Rollback
+		return nil, err // Rollback
 	}
 
 	// 3c. Validasi Relasi Parent-Child
@@ -241,12 +369,12 @@ func (s *rewardServiceImpl) ReviewClaim(ctx context.Context, claimID int, parent
 	if err != nil {
 		zlog.Error().Err(err).Int("parent_id", parentID).Int("child_id", claimDetails.ChildID).Msg("Service: Error checking parent-child relationship during claim review")
 		err = fmt.Errorf("internal server error: could not verify relationship")
-		return err // Rollback
+		return nil, err // Rollback
 	}
 	if !isParentOfClaimant {
 		zlog.Warn().Int("claim_id", claimID).Int("parent_id", parentID).Int("child_id", claimDetails.ChildID).Msg("Service: Review claim failed: Requesting user is not the parent")
 		err = fmt.Errorf("forbidden: you are not authorized to review claims for this child")
-		return err // Rollback
+		return nil, err // Rollback
 	}
 
 	canReview := false
@@ -258,7 +386,7 @@ func (s *rewardServiceImpl) ReviewClaim(ctx context.Context, claimID int, parent
 		if errShared != nil {
 			zlog.Error().Err(errShared).Int("reviewer", parentID).Int("creator", claimDetails.RewardCreatorID).Msg("Service: Error checking shared child for reward review")
 			err = fmt.Errorf("%w: error checking reviewer permissions", ErrInvitationFailed) // atau error lain
-			return err                                                                       // Rollback
+			return nil, err                                                                       // Rollback
 		}
 		if hasShared {
 			canReview = true // Boleh review karena satu "keluarga"
@@ -267,7 +395,42 @@ func (s *rewardServiceImpl) ReviewClaim(ctx context.Context, claimID int, parent
 	if !canReview {
 		zlog.Warn().Int("claim_id", claimID).Int("reviewer", parentID).Int("creator", claimDetails.RewardCreatorID).Msg("Service: Parent attempted to review claim for reward created by unrelated parent")
 		err = fmt.Errorf("forbidden: you cannot review claims for rewards created outside your family scope")
-		return err // Rollback
+		return nil, err // Rollback
+	}
+
+	result = &models.ClaimReviewResult{ClaimID: claimID, Status: newStatus}
+
+	// 3c'. Persetujuan bersama: klaim di atas ambang kebijakan butuh persetujuan N orang tua berbeda.
+	//      Setiap suara disimpan; penolakan dari satu orang tua langsung menolak klaim (poin dikembalikan di 3e).
+	required, err := s.requiredApprovalsTx(ctx, tx, claimDetails)
+	if err != nil {
+		return nil, err // Rollback
+	}
+	if required > 1 {
+		if err = s.approvalRepo.CreateVoteTx(ctx, tx, claimID, parentID, newStatus); err != nil {
+			return nil, err // Rollback
+		}
+		if newStatus == models.UserRewardStatusApproved {
+			approvals, errCount := s.approvalRepo.CountApprovalsTx(ctx, tx, claimID)
+			if errCount != nil {
+				err = fmt.Errorf("internal server error: could not count approvals")
+				return nil, err // Rollback
+			}
+			result.Approvals = approvals
+			result.RequiredApprovals = required
+			if approvals < required {
+				// Kuorum belum tercapai: klaim tetap 'pending', suara dicatat di riwayat klaim.
+				note := fmt.Sprintf("approval %d of %d", approvals, required)
+				err = s.userRewardRepo.CreateClaimEventTx(ctx, tx, newUserRewardEvent(claimID, models.UserRewardActionApprove, claimDetails.CurrentStatus, claimDetails.CurrentStatus, parentID, note))
+				if err != nil {
+					err = fmt.Errorf("internal server error: could not record claim history")
+					return nil, err // Rollback
+				}
+				result.Status = models.UserRewardStatusPending
+				zlog.Info().Int("claim_id", claimID).Int("approvals", approvals).Int("required", required).Msg("Service: Claim approval recorded, waiting for quorum")
+				return result, nil // Commit suara
+			}
+		}
 	}
 
 	// 3d. Update Status Klaim dalam Transaksi
@@ -278,10 +441,23 @@ func (s *rewardServiceImpl) ReviewClaim(ctx context.Context, claimID int, parent
 		// Kembalikan error asli dari repo jika informatif (misal: "current status is already 'approved'")
 		// Jika tidak, bungkus dengan pesan generik
 		if strings.Contains(err.Error(), "current status is already") {
-			return err // Kembalikan error status change
+			return nil, err // Kembalikan error status change
 		}
 		err = fmt.Errorf("internal server error: could not update claim status")
-		return err // Rollback
+		return nil, err // Rollback
+	}
+	reviewAction := models.UserRewardActionApprove
+	if newStatus == models.UserRewardStatusRejected {
+		reviewAction = models.UserRewardActionReject
+	}
+	reviewNote := ""
+	if result.RequiredApprovals > 0 {
+		reviewNote = fmt.Sprintf("approval %d of %d", result.Approvals, result.RequiredApprovals)
+	}
+	err = s.userRewardRepo.CreateClaimEventTx(ctx, tx, newUserRewardEvent(claimID, reviewAction, claimDetails.CurrentStatus, newStatus, parentID, reviewNote))
+	if err != nil {
+		err = fmt.Errorf("internal server error: could not record claim history")
+		return nil, err // Rollback
 	}
 
 	// 3e. Jika Approved, Buat Transaksi Pengurangan Poin
@@ -292,13 +468,22 @@ func (s *rewardServiceImpl) ReviewClaim(ctx context.Context, claimID int, parent
 	if newStatus == models.UserRewardStatusRejected {
 		// Hanya kembalikan poin jika memang ada poin yang tercatat untuk dikurangi
 		if claimDetails.PointsDeducted > 0 {
+			// Refund selalu menunjuk transaksi reward_redemption asli dari klaim ini
+			redemption, lookupErr := s.pointRepo.GetUnreversedTransactionTx(ctx, tx, models.TransactionTypeRedemption, 0, claimID)
+			if lookupErr != nil {
+				zlog.Error().Err(lookupErr).Int("claim_id", claimID).Msg("Service: Failed to find redemption transaction to refund")
+				err = fmt.Errorf("internal server error: claim rejected but failed to refund points")
+				return nil, err // Rollback
+			}
 			refundTx := &models.PointTransaction{
-				UserID:              claimDetails.ChildID,
-				ChangeAmount:        claimDetails.PointsDeducted, // Poin POSITIF
-				TransactionType:     models.TransactionTypeManualAdjustment, // Atau tipe baru: "reward_refund"
-				RelatedUserRewardID: claimID,                   // Kaitkan dengan klaim yang ditolak
-				CreatedByUserID:     parentID,                    // Parent yang reject
-				Notes:               fmt.Sprintf("Points refunded for rejected reward claim ID %d", claimID),
+				UserID:                claimDetails.ChildID,
+				ChangeAmount:          -redemption.ChangeAmount, // Poin POSITIF (kebalikan redemption)
+				TransactionType:       models.TransactionTypeRewardRefund,
+				RelatedUserRewardID:   claimID,       // Kaitkan dengan klaim yang ditolak
+				ReversesTransactionID: redemption.ID, // Transaksi asli yang dibalik
+				CurrencyID:            redemption.CurrencyID,
+				CreatedByUserID:       parentID, // Parent yang reject
+				Notes:                 fmt.Sprintf("Points refunded for rejected reward claim ID %d", claimID),
 			}
 			err = s.pointRepo.CreateTransactionTx(ctx, tx, refundTx)
 			if err != nil {
@@ -307,13 +492,173 @@ func (s *rewardServiceImpl) ReviewClaim(ctx context.Context, claimID int, parent
 				// Mungkin lebih baik biarkan commit status reject tapi log error refund.
 				// Atau kembalikan error internal. Kita pilih rollback untuk konsistensi.
 				err = fmt.Errorf("internal server error: claim rejected but failed to refund points")
-				return err // Rollback
+				return nil, err // Rollback
 			}
 			zlog.Info().Int("claim_id", claimID).Int("points_refunded", claimDetails.PointsDeducted).Int("child_id", claimDetails.ChildID).Msg("Service: Point refund transaction created within DB transaction")
 		} else {
             zlog.Info().Int("claim_id", claimID).Msg("Service: Claim rejected, no points to refund (PointsDeducted was 0)")
         }
+
+		// Kembalikan stok hanya jika klaim ini memang mengurangi stok saat dibuat
+		if claimDetails.StockReserved {
+			err = s.rewardRepo.RestoreStockTx(ctx, tx, claimDetails.RewardID)
+			if err != nil {
+				zlog.Error().Err(err).Int("claim_id", claimID).Int("reward_id", claimDetails.RewardID).Msg("Service: Failed to restore reward stock after claim rejection")
+				err = fmt.Errorf("internal server error: claim rejected but failed to restore reward stock")
+				return nil, err // Rollback
+			}
+		}
+	}
+
+	return result, nil // Sukses
+}
+
+// lockClaimForParentTx mengunci klaim dan memastikan parentID adalah orang tua dari anak yang mengklaim.
+func (s *rewardServiceImpl) lockClaimForParentTx(ctx context.Context, tx pgx.Tx, claimID int, parentID int) (*repository.ClaimReviewDetails, error) {
+	details, err := s.userRewardRepo.GetClaimDetailsForReviewTx(ctx, tx, claimID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err // Diteruskan agar handler mengembalikan 404
+		}
+		return nil, fmt.Errorf("internal server error: could not retrieve claim details")
+	}
+	isParent, err := s.userRelRepo.IsParentOfTx(ctx, tx, parentID, details.ChildID)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", parentID).Int("child_id", details.ChildID).Msg("Service: Error checking parent-child relationship for claim fulfillment")
+		return nil, fmt.Errorf("internal server error: could not verify relationship")
+	}
+	if !isParent {
+		return nil, fmt.Errorf("forbidden: you are not authorized to manage claims for this child")
+	}
+	return details, nil
+}
+
+// ScheduleClaimFulfillment menjadwalkan (atau menjadwal ulang) tanggal penyerahan hadiah yang sudah disetujui.
+func (s *rewardServiceImpl) ScheduleClaimFulfillment(ctx context.Context, claimID int, parentID int, deliveryDate time.Time, note string) error {
+	return withTx(ctx, s.pool, "ScheduleClaimFulfillment", func(tx pgx.Tx) error {
+		details, err := s.lockClaimForParentTx(ctx, tx, claimID, parentID)
+		if err != nil {
+			return err
+		}
+		nextStatus, err := models.NextUserRewardStatus(details.CurrentStatus, models.UserRewardActionSchedule)
+		if err != nil {
+			return err
+		}
+		event := newUserRewardEvent(claimID, models.UserRewardActionSchedule, details.CurrentStatus, nextStatus, parentID, note)
+		event.ScheduledFor = &deliveryDate
+		if err := s.userRewardRepo.ApplyClaimTransitionTx(ctx, tx, event); err != nil {
+			return err
+		}
+		zlog.Info().Int("claim_id", claimID).Time("delivery_date", deliveryDate).Msg("Service: Reward delivery scheduled")
+		return nil
+	})
+}
+
+// FulfillClaim menandai hadiah sudah diserahkan ke anak.
+func (s *rewardServiceImpl) FulfillClaim(ctx context.Context, claimID int, parentID int, note string) error {
+	return withTx(ctx, s.pool, "FulfillClaim", func(tx pgx.Tx) error {
+		details, err := s.lockClaimForParentTx(ctx, tx, claimID, parentID)
+		if err != nil {
+			return err
+		}
+		nextStatus, err := models.NextUserRewardStatus(details.CurrentStatus, models.UserRewardActionFulfill)
+		if err != nil {
+			return err
+		}
+		event := newUserRewardEvent(claimID, models.UserRewardActionFulfill, details.CurrentStatus, nextStatus, parentID, note)
+		if err := s.userRewardRepo.ApplyClaimTransitionTx(ctx, tx, event); err != nil {
+			return err
+		}
+		zlog.Info().Int("claim_id", claimID).Int("parent_id", parentID).Msg("Service: Reward marked as fulfilled")
+		return nil
+	})
+}
+
+// ConfirmClaimReceipt mencatat konfirmasi anak bahwa hadiah sudah diterima.
+func (s *rewardServiceImpl) ConfirmClaimReceipt(ctx context.Context, claimID int, childID int) error {
+	return withTx(ctx, s.pool, "ConfirmClaimReceipt", func(tx pgx.Tx) error {
+		details, err := s.userRewardRepo.GetClaimDetailsForReviewTx(ctx, tx, claimID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return err
+			}
+			return fmt.Errorf("internal server error: could not retrieve claim details")
+		}
+		if details.ChildID != childID {
+			return fmt.Errorf("forbidden: this claim does not belong to you")
+		}
+		if details.ReceivedAt != nil {
+			return fmt.Errorf("cannot confirm_receipt claim: receipt was already confirmed at %s", details.ReceivedAt.Format(time.RFC3339))
+		}
+		nextStatus, err := models.NextUserRewardStatus(details.CurrentStatus, models.UserRewardActionConfirmReceipt)
+		if err != nil {
+			return err
+		}
+		event := newUserRewardEvent(claimID, models.UserRewardActionConfirmReceipt, details.CurrentStatus, nextStatus, childID, "")
+		if err := s.userRewardRepo.ApplyClaimTransitionTx(ctx, tx, event); err != nil {
+			return err
+		}
+		zlog.Info().Int("claim_id", claimID).Int("child_id", childID).Msg("Service: Reward receipt confirmed by child")
+		return nil
+	})
+}
+
+// GetClaimHistory mengambil riwayat transisi klaim. Hanya anak pemilik klaim atau orang tuanya yang boleh melihat.
+func (s *rewardServiceImpl) GetClaimHistory(ctx context.Context, claimID int, userID int) ([]models.UserRewardEvent, error) {
+	claim, err := s.userRewardRepo.GetUserRewardByID(ctx, claimID)
+	if err != nil {
+		return nil, err // ErrNoRows diteruskan agar handler mengembalikan 404
 	}
+	if claim.UserID != userID {
+		isParent, err := s.userRelRepo.IsParentOf(ctx, userID, claim.UserID)
+		if err != nil {
+			zlog.Error().Err(err).Int("user_id", userID).Int("child_id", claim.UserID).Msg("Service: Error checking relationship for claim history")
+			return nil, fmt.Errorf("internal server error: could not verify relationship")
+		}
+		if !isParent {
+			return nil, fmt.Errorf("forbidden: you are not authorized to view this claim")
+		}
+	}
+	return s.userRewardRepo.GetClaimEvents(ctx, claimID)
+}
 
-	return nil // Sukses
+// SetApprovalPolicy membuat atau memperbarui kebijakan persetujuan bersama milik parent,
+// untuk semua anaknya (childID 0) atau satu anak.
+func (s *rewardServiceImpl) SetApprovalPolicy(ctx context.Context, parentID int, childID int, input *models.SetRewardApprovalPolicyInput) (*models.RewardApprovalPolicy, error) {
+	if childID != 0 {
+		if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, approvalPolicyForbiddenMessage); err != nil {
+			return nil, err
+		}
+	}
+	policy := &models.RewardApprovalPolicy{
+		CreatedByUserID:   parentID,
+		ChildID:           childID,
+		PointsThreshold:   input.PointsThreshold,
+		RequiredApprovals: input.RequiredApprovals,
+	}
+	if err := s.approvalRepo.UpsertPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// GetApprovalPolicy mengambil kebijakan keluarga milik parent (childID 0), atau kebijakan yang berlaku untuk anak.
+func (s *rewardServiceImpl) GetApprovalPolicy(ctx context.Context, parentID int, childID int) (*models.RewardApprovalPolicy, error) {
+	if childID == 0 {
+		return s.approvalRepo.GetPolicyByOwner(ctx, parentID, 0)
+	}
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, approvalPolicyForbiddenMessage); err != nil {
+		return nil, err
+	}
+	return s.approvalRepo.GetPolicyForChild(ctx, childID)
+}
+
+// DeleteApprovalPolicy menghapus kebijakan persetujuan bersama milik parent untuk semua anak (childID 0) atau satu anak.
+func (s *rewardServiceImpl) DeleteApprovalPolicy(ctx context.Context, parentID int, childID int) error {
+	if childID != 0 {
+		if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, approvalPolicyForbiddenMessage); err != nil {
+			return err
+		}
+	}
+	return s.approvalRepo.DeletePolicy(ctx, parentID, childID)
 }
@@ -0,0 +1,369 @@
+// internal/service/rotation_service_impl.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+const (
+	defaultRotationPreviewCount = 5  // Jumlah giliran default pada pratinjau
+	maxRotationPreviewCount     = 20 // Batas maksimum giliran pada pratinjau
+)
+
+type rotationServiceImpl struct {
+	pool         *pgxpool.Pool // Untuk transaksi
+	rotationRepo repository.TaskRotationRepository
+	taskRepo     repository.TaskRepository
+	userTaskRepo repository.UserTaskRepository // Penugasan dibuat lewat AssignTask yang sudah ada
+	userRelRepo  repository.UserRelationshipRepository
+}
+
+// NewRotationService creates a new instance of RotationService.
+func NewRotationService(
+	pool *pgxpool.Pool,
+	rotationRepo repository.TaskRotationRepository,
+	taskRepo repository.TaskRepository,
+	userTaskRepo repository.UserTaskRepository,
+	userRelRepo repository.UserRelationshipRepository,
+) RotationService {
+	return &rotationServiceImpl{
+		pool:         pool,
+		rotationRepo: rotationRepo,
+		taskRepo:     taskRepo,
+		userTaskRepo: userTaskRepo,
+		userRelRepo:  userRelRepo,
+	}
+}
+
+// --- Helper Functions ---
+
+// canManageRotation memeriksa apakah parent boleh mengelola rotasi:
+// pembuatnya sendiri, atau parent lain yang memiliki anak bersama dengan pembuat (satu keluarga).
+func (s *rotationServiceImpl) canManageRotation(ctx context.Context, parentID int, rotation *models.TaskRotation) error {
+	if rotation.CreatedByUserID == parentID {
+		return nil
+	}
+	hasSharedChild, err := s.userRelRepo.HasSharedChild(ctx, parentID, rotation.CreatedByUserID)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", parentID).Int("rotation_id", rotation.ID).Msg("Service: Error checking shared child for rotation access")
+		return fmt.Errorf("internal server error: could not verify relationship")
+	}
+	if !hasSharedChild {
+		return fmt.Errorf("forbidden: you are not authorized to manage this rotation")
+	}
+	return nil
+}
+
+// getAuthorizedRotation mengambil rotasi (tanpa lock) dan memastikan parent berhak mengaksesnya.
+func (s *rotationServiceImpl) getAuthorizedRotation(ctx context.Context, rotationID int, parentID int) (*models.TaskRotation, error) {
+	rotation, err := s.rotationRepo.GetRotationByID(ctx, rotationID)
+	if err != nil {
+		return nil, err // pgx.ErrNoRows diteruskan agar handler mengembalikan 404
+	}
+	if err := s.canManageRotation(ctx, parentID, rotation); err != nil {
+		return nil, err
+	}
+	return rotation, nil
+}
+
+// nextScheduledRotation menghitung jadwal giliran berikutnya setelah giliran saat ini diproses.
+// Untuk scheduler, jadwal maju dari jadwal sebelumnya (melewati slot yang terlewat saat server mati);
+// untuk pemicu manual, jadwal dihitung dari waktu sekarang.
+func nextScheduledRotation(rotation *models.TaskRotation, actorID int, now time.Time) time.Time {
+	interval := rotation.Cadence.Interval()
+	if actorID != SystemActorID {
+		return now.Add(interval)
+	}
+	next := rotation.NextRotationAt.Add(interval)
+	for !next.After(now) {
+		next = next.Add(interval)
+	}
+	return next
+}
+
+// --- Service Methods ---
+
+// CreateRotation membuat rotasi baru setelah memvalidasi akses ke tugas dan relasi ke semua anak.
+func (s *rotationServiceImpl) CreateRotation(ctx context.Context, parentID int, input *models.CreateRotationInput) (int, error) {
+	log := zlog.With().Int("parent_id", parentID).Int("task_id", input.TaskID).Logger()
+
+	// 1. Validasi definisi tugas & hak akses (pembuat atau satu keluarga)
+	task, err := s.taskRepo.GetTaskByID(ctx, input.TaskID)
+	if err != nil {
+		return 0, err
+	}
+	if task.CreatedByUserID != parentID {
+		hasSharedChild, errShared := s.userRelRepo.HasSharedChild(ctx, parentID, task.CreatedByUserID)
+		if errShared != nil {
+			log.Error().Err(errShared).Msg("Service: Error checking shared child for rotation task")
+			return 0, fmt.Errorf("internal server error: could not verify relationship")
+		}
+		if !hasSharedChild {
+			return 0, fmt.Errorf("forbidden: you are not authorized to use this task definition")
+		}
+	}
+
+	// 2. Validasi semua anak adalah anak dari parent ini
+	for _, childID := range input.ChildIDs {
+		isParent, errRel := s.userRelRepo.IsParentOf(ctx, parentID, childID)
+		if errRel != nil {
+			log.Error().Err(errRel).Int("child_id", childID).Msg("Service: Error checking relationship for rotation member")
+			return 0, fmt.Errorf("internal server error: could not verify relationship")
+		}
+		if !isParent {
+			return 0, fmt.Errorf("forbidden: you are not the parent of child %d", childID)
+		}
+	}
+
+	// 3. Simpan rotasi & anggota secara atomik
+	startAt := time.Now()
+	if input.StartAt != nil {
+		startAt = *input.StartAt
+	}
+	rotation := &models.TaskRotation{
+		TaskID:          task.ID,
+		CreatedByUserID: parentID,
+		Cadence:         models.RotationCadence(input.Cadence),
+		NextRotationAt:  startAt,
+	}
+
+	var rotationID int
+	err = withTx(ctx, s.pool, "CreateRotation", func(tx pgx.Tx) error {
+		var errCreate error
+		rotationID, errCreate = s.rotationRepo.CreateRotationTx(ctx, tx, rotation, input.ChildIDs)
+		return errCreate
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	log.Info().Int("rotation_id", rotationID).Msg("Service: Task rotation created")
+	return rotationID, nil
+}
+
+// GetRotation mengambil detail rotasi untuk parent yang berhak.
+func (s *rotationServiceImpl) GetRotation(ctx context.Context, rotationID int, parentID int) (*models.TaskRotation, error) {
+	return s.getAuthorizedRotation(ctx, rotationID, parentID)
+}
+
+// GetRotationsForParent mengambil daftar rotasi milik parent.
+func (s *rotationServiceImpl) GetRotationsForParent(ctx context.Context, parentID int, page, limit int) ([]models.TaskRotation, int, error) {
+	return s.rotationRepo.GetRotationsByCreatorID(ctx, parentID, page, limit)
+}
+
+// DeleteRotation menghapus rotasi jika parent berhak.
+func (s *rotationServiceImpl) DeleteRotation(ctx context.Context, rotationID int, parentID int) error {
+	if _, err := s.getAuthorizedRotation(ctx, rotationID, parentID); err != nil {
+		return err
+	}
+	return s.rotationRepo.DeleteRotation(ctx, rotationID)
+}
+
+// AdvanceRotation memberikan giliran ke anak berikutnya dan memajukan rotasi.
+func (s *rotationServiceImpl) AdvanceRotation(ctx context.Context, rotationID int, actorID int) (*models.TaskRotationTurn, error) {
+	log := zlog.With().Int("rotation_id", rotationID).Int("actor_id", actorID).Logger()
+	var turn *models.TaskRotationTurn
+
+	err := withTx(ctx, s.pool, "AdvanceRotation", func(tx pgx.Tx) error {
+		// 1. Kunci rotasi agar scheduler dan parent tidak memproses giliran yang sama bersamaan
+		rotation, err := s.rotationRepo.GetRotationForUpdateTx(ctx, tx, rotationID)
+		if err != nil {
+			return err
+		}
+		if actorID != SystemActorID {
+			if err := s.canManageRotation(ctx, actorID, rotation); err != nil {
+				return err
+			}
+		}
+		if !rotation.IsActive {
+			return fmt.Errorf("cannot rotate: rotation is inactive")
+		}
+		if len(rotation.Members) == 0 {
+			return fmt.Errorf("cannot rotate: rotation has no members")
+		}
+
+		position := rotation.CurrentPosition % len(rotation.Members)
+		member := rotation.Members[position]
+		turn = &models.TaskRotationTurn{
+			RotationID:      rotation.ID,
+			ChildID:         member.ChildID,
+			ChildUsername:   member.Username,
+			CreatedByUserID: actorID,
+		}
+
+		// 2. Jangan menumpuk penugasan jika tugas yang sama masih aktif untuk anak ini
+		activeTaskExists, err := s.userTaskRepo.CheckExistingActiveTaskTx(ctx, tx, member.ChildID, rotation.TaskID)
+		if err != nil {
+			log.Error().Err(err).Int("child_id", member.ChildID).Msg("Service: Error checking existing active task for rotation")
+			return fmt.Errorf("internal server error: could not check existing assignment")
+		}
+		if activeTaskExists {
+			turn.Status = models.RotationTurnStatusSkipped
+			turn.Notes = "previous assignment of this task is still active"
+		} else {
+			// 3. Tugaskan dalam transaksi yang sama agar penugasan dan posisi rotasi selalu konsisten.
+			// Pemberi tugas adalah parent pemicu, atau pembuat rotasi jika dipicu scheduler.
+			assignedBy := actorID
+			if assignedBy == SystemActorID {
+				assignedBy = rotation.CreatedByUserID
+			}
+			userTaskID, err := s.userTaskRepo.AssignTaskTx(ctx, tx, member.ChildID, rotation.TaskID, assignedBy, nil)
+			if err != nil {
+				log.Error().Err(err).Int("child_id", member.ChildID).Msg("Service: Failed to assign rotation task")
+				return fmt.Errorf("internal server error: could not assign rotation task")
+			}
+			turn.Status = models.RotationTurnStatusAssigned
+			turn.UserTaskID = userTaskID
+		}
+
+		// 4. Catat riwayat giliran & majukan posisi
+		turn.ID, err = s.rotationRepo.CreateTurnTx(ctx, tx, turn)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		turn.CreatedAt = now
+		nextPosition := (position + 1) % len(rotation.Members)
+		return s.rotationRepo.UpdateRotationStateTx(ctx, tx, rotation.ID, nextPosition, nextScheduledRotation(rotation, actorID, now))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Int("child_id", turn.ChildID).Str("status", string(turn.Status)).Msg("Service: Rotation advanced")
+	return turn, nil
+}
+
+// SkipTurn melewati giliran anak berikutnya tanpa mengubah jadwal rotasi.
+func (s *rotationServiceImpl) SkipTurn(ctx context.Context, rotationID int, parentID int, notes string) (*models.TaskRotationTurn, error) {
+	var turn *models.TaskRotationTurn
+
+	err := withTx(ctx, s.pool, "SkipTurn", func(tx pgx.Tx) error {
+		rotation, err := s.rotationRepo.GetRotationForUpdateTx(ctx, tx, rotationID)
+		if err != nil {
+			return err
+		}
+		if err := s.canManageRotation(ctx, parentID, rotation); err != nil {
+			return err
+		}
+		if len(rotation.Members) == 0 {
+			return fmt.Errorf("cannot skip turn: rotation has no members")
+		}
+
+		position := rotation.CurrentPosition % len(rotation.Members)
+		member := rotation.Members[position]
+		turn = &models.TaskRotationTurn{
+			RotationID:      rotation.ID,
+			ChildID:         member.ChildID,
+			ChildUsername:   member.Username,
+			Status:          models.RotationTurnStatusSkipped,
+			Notes:           notes,
+			CreatedByUserID: parentID,
+			CreatedAt:       time.Now(),
+		}
+		turn.ID, err = s.rotationRepo.CreateTurnTx(ctx, tx, turn)
+		if err != nil {
+			return err
+		}
+		// Jadwal tetap; giliran pada jadwal tersebut jatuh ke anak berikutnya
+		return s.rotationRepo.UpdateRotationStateTx(ctx, tx, rotation.ID, (position+1)%len(rotation.Members), rotation.NextRotationAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zlog.Info().Int("rotation_id", rotationID).Int("child_id", turn.ChildID).Msg("Service: Rotation turn skipped")
+	return turn, nil
+}
+
+// SwapMembers menukar urutan dua anak dalam rotasi.
+func (s *rotationServiceImpl) SwapMembers(ctx context.Context, rotationID int, parentID int, firstChildID int, secondChildID int) error {
+	return withTx(ctx, s.pool, "SwapMembers", func(tx pgx.Tx) error {
+		rotation, err := s.rotationRepo.GetRotationForUpdateTx(ctx, tx, rotationID)
+		if err != nil {
+			return err
+		}
+		if err := s.canManageRotation(ctx, parentID, rotation); err != nil {
+			return err
+		}
+		err = s.rotationRepo.SwapMemberPositionsTx(ctx, tx, rotationID, firstChildID, secondChildID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("invalid swap: both children must be members of this rotation")
+		}
+		return err
+	})
+}
+
+// GetRotationHistory mengambil riwayat giliran rotasi.
+func (s *rotationServiceImpl) GetRotationHistory(ctx context.Context, rotationID int, parentID int, page, limit int) ([]models.TaskRotationTurn, int, error) {
+	if _, err := s.getAuthorizedRotation(ctx, rotationID, parentID); err != nil {
+		return nil, 0, err
+	}
+	return s.rotationRepo.GetTurnsByRotationID(ctx, rotationID, page, limit)
+}
+
+// PreviewRotation menghitung giliran mendatang berdasarkan posisi dan cadence saat ini.
+func (s *rotationServiceImpl) PreviewRotation(ctx context.Context, rotationID int, parentID int, count int) ([]models.RotationPreviewItem, error) {
+	rotation, err := s.getAuthorizedRotation(ctx, rotationID, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if count <= 0 {
+		count = defaultRotationPreviewCount
+	}
+	if count > maxRotationPreviewCount {
+		count = maxRotationPreviewCount
+	}
+
+	preview := []models.RotationPreviewItem{}
+	if len(rotation.Members) == 0 {
+		return preview, nil
+	}
+
+	// Jika jadwal sudah lewat (menunggu diproses scheduler), giliran pertama dianggap sekarang
+	scheduledAt := rotation.NextRotationAt
+	if now := time.Now(); scheduledAt.Before(now) {
+		scheduledAt = now
+	}
+	interval := rotation.Cadence.Interval()
+	for i := 0; i < count; i++ {
+		member := rotation.Members[(rotation.CurrentPosition+i)%len(rotation.Members)]
+		preview = append(preview, models.RotationPreviewItem{
+			ChildID:       member.ChildID,
+			ChildUsername: member.Username,
+			ScheduledAt:   scheduledAt.Add(time.Duration(i) * interval),
+		})
+	}
+	return preview, nil
+}
+
+// ProcessDueRotations memproses semua rotasi yang jadwalnya sudah tiba.
+// Kegagalan satu rotasi dicatat dan tidak menghentikan rotasi lainnya.
+func (s *rotationServiceImpl) ProcessDueRotations(ctx context.Context, now time.Time) (int, error) {
+	ids, err := s.rotationRepo.GetDueRotationIDs(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, id := range ids {
+		if _, err := s.AdvanceRotation(ctx, id, SystemActorID); err != nil {
+			zlog.Error().Err(err).Int("rotation_id", id).Msg("Service: Failed to process due rotation")
+			continue
+		}
+		processed++
+	}
+	if len(ids) > 0 {
+		zlog.Info().Int("due_count", len(ids)).Int("processed_count", processed).Msg("Service: Due rotations processed")
+	}
+	return processed, nil
+}
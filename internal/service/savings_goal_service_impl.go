@@ -0,0 +1,420 @@
+// internal/service/savings_goal_service_impl.go
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// savingsGoalBatchSize membatasi jumlah anak yang targetnya diproses worker dalam satu putaran.
+const savingsGoalBatchSize = 100
+
+type savingsGoalServiceImpl struct {
+	pool             *pgxpool.Pool // Untuk transaksi earmark/kontribusi
+	goalRepo         repository.SavingsGoalRepository
+	rewardRepo       repository.RewardRepository
+	pointRepo        repository.PointTransactionRepository
+	userRelRepo      repository.UserRelationshipRepository
+	notificationRepo repository.NotificationRepository
+	rewardService    RewardService // Untuk klaim otomatis lewat alur ClaimReward yang sama
+	badgeService     BadgeService  // Evaluasi badge saldo setelah kontribusi orang tua
+}
+
+// NewSavingsGoalService creates a new instance of SavingsGoalService.
+func NewSavingsGoalService(
+	pool *pgxpool.Pool,
+	goalRepo repository.SavingsGoalRepository,
+	rewardRepo repository.RewardRepository,
+	pointRepo repository.PointTransactionRepository,
+	userRelRepo repository.UserRelationshipRepository,
+	notificationRepo repository.NotificationRepository,
+	rewardService RewardService,
+	badgeService BadgeService,
+) SavingsGoalService {
+	return &savingsGoalServiceImpl{
+		pool:             pool,
+		goalRepo:         goalRepo,
+		rewardRepo:       rewardRepo,
+		pointRepo:        pointRepo,
+		userRelRepo:      userRelRepo,
+		notificationRepo: notificationRepo,
+		rewardService:    rewardService,
+		badgeService:     badgeService,
+	}
+}
+
+// --- Helper Functions ---
+
+// sumEarmarked menjumlahkan poin yang disisihkan pada target terbuka.
+func sumEarmarked(goals []models.SavingsGoal) int {
+	total := 0
+	for _, goal := range goals {
+		if goal.IsOpen() {
+			total += goal.EarmarkedPoints
+		}
+	}
+	return total
+}
+
+// findGoal mencari target dengan ID tertentu pada daftar target milik anak.
+func findGoal(goals []models.SavingsGoal, goalID int) *models.SavingsGoal {
+	for i := range goals {
+		if goals[i].ID == goalID {
+			return &goals[i]
+		}
+	}
+	return nil
+}
+
+// buildOverview menyusun ringkasan tabungan dan menghitung progres setiap target terbuka.
+func buildOverview(balance int, goals []models.SavingsGoal) *models.SavingsOverview {
+	earmarked := sumEarmarked(goals)
+	free := max(balance-earmarked, 0)
+	for i := range goals {
+		if goals[i].IsOpen() {
+			progress := goals[i].ComputeProgress(free)
+			goals[i].Progress = &progress
+		}
+	}
+	return &models.SavingsOverview{
+		Balance:         balance,
+		EarmarkedPoints: earmarked,
+		SpendablePoints: free,
+		Goals:           goals,
+	}
+}
+
+// notifyTx mengirim notifikasi terkait target tabungan ke beberapa pengguna dalam transaksi.
+func (s *savingsGoalServiceImpl) notifyTx(ctx context.Context, tx pgx.Tx, userIDs []int, notificationType models.NotificationType, title, message string, goalID int) error {
+	for _, userID := range userIDs {
+		err := s.notificationRepo.CreateNotificationTx(ctx, tx, &models.Notification{
+			UserID:     userID,
+			Type:       notificationType,
+			Title:      title,
+			Message:    message,
+			EntityType: "savings_goal",
+			EntityID:   goalID,
+		})
+		if err != nil {
+			return fmt.Errorf("internal server error: could not send notification")
+		}
+	}
+	return nil
+}
+
+// markReachedGoalsTx mengunci target terbuka anak, menandai target 'active' yang sudah tercapai
+// sebagai 'reached', dan memberi tahu anak serta orang tuanya. Mengembalikan target yang baru tercapai.
+func (s *savingsGoalServiceImpl) markReachedGoalsTx(ctx context.Context, tx pgx.Tx, childID int) ([]models.SavingsGoal, error) {
+	goals, err := s.goalRepo.GetOpenGoalsByChildIDForUpdateTx(ctx, tx, childID)
+	if err != nil {
+		return nil, fmt.Errorf("internal server error: could not retrieve savings goals")
+	}
+	if len(goals) == 0 {
+		return nil, nil
+	}
+	balance, err := s.pointRepo.CalculateTotalPointsByUserIDTx(ctx, tx, childID)
+	if err != nil {
+		return nil, fmt.Errorf("internal server error: could not retrieve points balance")
+	}
+	free := balance - sumEarmarked(goals)
+
+	var reached []models.SavingsGoal
+	var recipients []int
+	for _, goal := range goals {
+		if goal.Status != models.SavingsGoalStatusActive || !goal.ComputeProgress(free).Reached {
+			continue
+		}
+		marked, err := s.goalRepo.MarkReachedTx(ctx, tx, goal.ID)
+		if err != nil {
+			return nil, fmt.Errorf("internal server error: could not update savings goal")
+		}
+		if !marked {
+			continue
+		}
+		if recipients == nil {
+			parentIDs, err := s.userRelRepo.GetParentIDsByChildIDTx(ctx, tx, childID)
+			if err != nil {
+				return nil, fmt.Errorf("internal server error: could not retrieve parents")
+			}
+			recipients = append([]int{childID}, parentIDs...)
+		}
+		message := fmt.Sprintf("Savings goal '%s' reached %d points.", goal.GoalName, goal.TargetPoints)
+		if err := s.notifyTx(ctx, tx, recipients, models.NotificationSavingsGoalReached, "Savings goal reached", message, goal.ID); err != nil {
+			return nil, err
+		}
+		reached = append(reached, goal)
+	}
+	return reached, nil
+}
+
+// evaluateGoals menandai target anak yang sudah tercapai lalu, setelah commit, menjalankan klaim otomatis
+// lewat RewardService.ClaimReward. Klaim otomatis yang gagal tidak membatalkan status 'reached';
+// anak mendapat notifikasi dan tetap bisa mengklaim secara manual.
+func (s *savingsGoalServiceImpl) evaluateGoals(ctx context.Context, childID int) (int, error) {
+	var reached []models.SavingsGoal
+	err := withTx(ctx, s.pool, "EvaluateSavingsGoals", func(tx pgx.Tx) error {
+		var err error
+		reached, err = s.markReachedGoalsTx(ctx, tx, childID)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, goal := range reached {
+		if !goal.AutoClaim || goal.RewardID == 0 {
+			continue
+		}
+		claimID, claimErr := s.rewardService.ClaimReward(ctx, childID, goal.RewardID)
+		if claimErr == nil {
+			zlog.Info().Int("goal_id", goal.ID).Int("claim_id", claimID).Msg("Service: Savings goal auto-claimed")
+			continue
+		}
+		zlog.Warn().Err(claimErr).Int("goal_id", goal.ID).Int("child_id", childID).Msg("Service: Savings goal auto-claim failed")
+		notifyErr := s.notificationRepo.CreateNotification(ctx, &models.Notification{
+			UserID:     childID,
+			Type:       models.NotificationSavingsGoalAutoClaimFailed,
+			Title:      "Auto-claim failed",
+			Message:    fmt.Sprintf("Could not claim '%s' automatically: %s", goal.GoalName, claimErr.Error()),
+			EntityType: "savings_goal",
+			EntityID:   goal.ID,
+		})
+		if notifyErr != nil {
+			zlog.Error().Err(notifyErr).Int("goal_id", goal.ID).Msg("Service: Failed to send auto-claim failure notification")
+		}
+	}
+	return len(reached), nil
+}
+
+// --- Service Methods ---
+
+// CreateGoal membuat target tabungan baru untuk anak.
+func (s *savingsGoalServiceImpl) CreateGoal(ctx context.Context, childID int, input *models.CreateSavingsGoalInput) (int, error) {
+	goal := &models.SavingsGoal{
+		ChildID:      childID,
+		GoalName:     input.GoalName,
+		TargetPoints: input.TargetPoints,
+		AutoClaim:    input.AutoClaim,
+	}
+
+	if input.RewardID > 0 {
+		reward, err := s.rewardRepo.GetRewardByID(ctx, input.RewardID)
+		if err != nil {
+			return 0, err // ErrNoRows diteruskan agar handler mengembalikan 404
+		}
+		isParent, err := s.userRelRepo.IsParentOf(ctx, reward.CreatedByUserID, childID)
+		if err != nil {
+			zlog.Error().Err(err).Int("child_id", childID).Int("reward_id", input.RewardID).Msg("Service: Error checking reward creator for savings goal")
+			return 0, fmt.Errorf("internal server error: could not verify relationship")
+		}
+		if !isParent {
+			return 0, fmt.Errorf("forbidden: you cannot save for this reward")
+		}
+		if reward.CurrencyID != 0 {
+			return 0, fmt.Errorf("cannot pin reward: savings goals only support rewards priced in points")
+		}
+		goal.RewardID = reward.ID
+		goal.GoalName = reward.RewardName
+		goal.TargetPoints = reward.RewardPoint
+	} else if input.AutoClaim {
+		return 0, fmt.Errorf("cannot enable auto_claim for a custom savings goal")
+	}
+
+	goalID, err := s.goalRepo.CreateGoal(ctx, goal)
+	if err != nil {
+		return 0, err
+	}
+
+	// Target bisa langsung tercapai jika saldo bebas sudah mencukupi.
+	if _, err := s.evaluateGoals(ctx, childID); err != nil {
+		zlog.Warn().Err(err).Int("goal_id", goalID).Msg("Service: Failed to evaluate savings goals after creation")
+	}
+	return goalID, nil
+}
+
+// GetSavingsOverview mengambil ringkasan tabungan anak.
+func (s *savingsGoalServiceImpl) GetSavingsOverview(ctx context.Context, childID int) (*models.SavingsOverview, error) {
+	goals, err := s.goalRepo.GetGoalsByChildID(ctx, childID, false)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := s.pointRepo.CalculateTotalPointsByUserID(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+	return buildOverview(balance, goals), nil
+}
+
+// GetChildSavingsOverview mengambil ringkasan tabungan anak untuk orang tuanya.
+func (s *savingsGoalServiceImpl) GetChildSavingsOverview(ctx context.Context, parentID int, childID int) (*models.SavingsOverview, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, "you are not authorized to view this child's savings goals"); err != nil {
+		return nil, err
+	}
+	return s.GetSavingsOverview(ctx, childID)
+}
+
+// EarmarkPoints menyisihkan atau melepas poin untuk target milik anak.
+func (s *savingsGoalServiceImpl) EarmarkPoints(ctx context.Context, goalID int, childID int, points int) error {
+	err := withTx(ctx, s.pool, "EarmarkPoints", func(tx pgx.Tx) error {
+		// Kunci semua target terbuka anak agar saldo bebas tidak disisihkan dua kali secara bersamaan.
+		goals, err := s.goalRepo.GetOpenGoalsByChildIDForUpdateTx(ctx, tx, childID)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not retrieve savings goals")
+		}
+		goal := findGoal(goals, goalID)
+		if goal == nil {
+			// Bukan milik anak atau sudah ditutup; bedakan agar pesan error tetap jelas.
+			existing, err := s.goalRepo.GetGoalForUpdateTx(ctx, tx, goalID)
+			if err != nil {
+				return err
+			}
+			if existing.ChildID != childID {
+				return pgx.ErrNoRows
+			}
+			return fmt.Errorf("cannot earmark points: goal is already %s", existing.Status)
+		}
+
+		if points < 0 {
+			if -points > goal.EarmarkedPoints {
+				return fmt.Errorf("cannot release points: only %d points are earmarked for this goal", goal.EarmarkedPoints)
+			}
+		} else {
+			balance, err := s.pointRepo.CalculateTotalPointsByUserIDTx(ctx, tx, childID)
+			if err != nil {
+				return fmt.Errorf("internal server error: could not retrieve points balance")
+			}
+			free := max(balance-sumEarmarked(goals), 0)
+			if points > free {
+				return fmt.Errorf("%w: only %d points are available to earmark", ErrInsufficientPoints, free)
+			}
+			if needed := goal.TargetPoints - goal.EarmarkedPoints; points > needed {
+				return fmt.Errorf("cannot earmark points: this goal needs only %d more points", needed)
+			}
+		}
+
+		return s.goalRepo.AdjustEarmarkTx(ctx, tx, goalID, points)
+	})
+	if err != nil {
+		return err
+	}
+
+	zlog.Info().Int("goal_id", goalID).Int("child_id", childID).Int("points", points).Msg("Service: Savings goal earmark adjusted")
+	if _, err := s.evaluateGoals(ctx, childID); err != nil {
+		zlog.Warn().Err(err).Int("goal_id", goalID).Msg("Service: Failed to evaluate savings goals after earmark")
+	}
+	return nil
+}
+
+// CancelGoal membatalkan target terbuka milik anak.
+func (s *savingsGoalServiceImpl) CancelGoal(ctx context.Context, goalID int, childID int) error {
+	return withTx(ctx, s.pool, "CancelSavingsGoal", func(tx pgx.Tx) error {
+		goal, err := s.goalRepo.GetGoalForUpdateTx(ctx, tx, goalID)
+		if err != nil {
+			return err
+		}
+		if goal.ChildID != childID {
+			return pgx.ErrNoRows // Sembunyikan keberadaan target milik anak lain
+		}
+		return s.goalRepo.CancelGoalTx(ctx, tx, goalID)
+	})
+}
+
+// ContributeToGoal menambahkan bonus poin dari orang tua untuk target anak.
+func (s *savingsGoalServiceImpl) ContributeToGoal(ctx context.Context, goalID int, parentID int, input *models.ContributeToGoalInput) error {
+	var childID int
+	err := withTx(ctx, s.pool, "ContributeToGoal", func(tx pgx.Tx) error {
+		goal, err := s.goalRepo.GetGoalForUpdateTx(ctx, tx, goalID)
+		if err != nil {
+			return err
+		}
+		childID = goal.ChildID
+
+		isParent, err := s.userRelRepo.IsParentOfTx(ctx, tx, parentID, goal.ChildID)
+		if err != nil {
+			zlog.Error().Err(err).Int("parent_id", parentID).Int("child_id", goal.ChildID).Msg("Service: Error checking relationship for savings goal contribution")
+			return fmt.Errorf("internal server error: could not verify relationship")
+		}
+		if !isParent {
+			return fmt.Errorf("forbidden: you are not authorized to contribute to this goal")
+		}
+		if !goal.IsOpen() {
+			return fmt.Errorf("cannot contribute to savings goal: goal is already %s", goal.Status)
+		}
+
+		// 1. Tambahkan bonus poin ke ledger anak
+		pointTx := &models.PointTransaction{
+			UserID:          goal.ChildID,
+			ChangeAmount:    input.Points,
+			TransactionType: models.TransactionTypeManualAdjustment,
+			CreatedByUserID: parentID,
+			Notes:           fmt.Sprintf("Contribution to savings goal '%s'", goal.GoalName),
+		}
+		if err := s.pointRepo.CreateTransactionTx(ctx, tx, pointTx); err != nil {
+			return fmt.Errorf("internal server error: could not update points balance")
+		}
+
+		// 2. Sisihkan bonus untuk target ini (tidak melebihi target)
+		if earmark := min(input.Points, goal.TargetPoints-goal.EarmarkedPoints); earmark > 0 {
+			if err := s.goalRepo.AdjustEarmarkTx(ctx, tx, goal.ID, earmark); err != nil {
+				return err
+			}
+		}
+
+		// 3. Catat kontribusi & beri tahu anak
+		contribution := &models.SavingsGoalContribution{
+			GoalID:              goal.ID,
+			ContributedByUserID: parentID,
+			Points:              input.Points,
+			Note:                input.Note,
+		}
+		if err := s.goalRepo.CreateContributionTx(ctx, tx, contribution); err != nil {
+			return fmt.Errorf("internal server error: could not record contribution")
+		}
+		message := fmt.Sprintf("A parent added %d bonus points to your savings goal '%s'.", input.Points, goal.GoalName)
+		return s.notifyTx(ctx, tx, []int{goal.ChildID}, models.NotificationSavingsGoalContribution, "New contribution", message, goal.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	zlog.Info().Int("goal_id", goalID).Int("parent_id", parentID).Int("points", input.Points).Msg("Service: Savings goal contribution recorded")
+	if _, err := s.evaluateGoals(ctx, childID); err != nil {
+		zlog.Warn().Err(err).Int("goal_id", goalID).Msg("Service: Failed to evaluate savings goals after contribution")
+	}
+	evaluateBadgesAfterCommit(ctx, s.badgeService, childID)
+	return nil
+}
+
+// ProcessReachedGoals memeriksa target 'active' anak-anak secara bertahap (dipanggil oleh worker).
+func (s *savingsGoalServiceImpl) ProcessReachedGoals(ctx context.Context) (int, error) {
+	processed := 0
+	afterChildID := 0
+	for {
+		childIDs, err := s.goalRepo.GetChildIDsWithReachableGoals(ctx, afterChildID, savingsGoalBatchSize)
+		if err != nil {
+			return processed, err
+		}
+		for _, childID := range childIDs {
+			reached, err := s.evaluateGoals(ctx, childID)
+			if err != nil {
+				zlog.Error().Err(err).Int("child_id", childID).Msg("Service: Failed to evaluate savings goals")
+				continue
+			}
+			processed += reached
+		}
+		if len(childIDs) < savingsGoalBatchSize {
+			break
+		}
+		afterChildID = childIDs[len(childIDs)-1]
+	}
+	if processed > 0 {
+		zlog.Info().Int("processed", processed).Msg("Service: Reached savings goals processed")
+	}
+	return processed, nil
+}
@@ -3,6 +3,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
 )
@@ -72,8 +73,38 @@ type TaskService interface {
 	// dilakukan secara atomik (dalam satu transaksi database).
 	// Memerlukan ID UserTask, ID orang tua (untuk validasi), dan status baru (Approved/Rejected).
 	// Mengembalikan error jika terjadi kesalahan, validasi gagal, atau operasi database gagal.
+	// parentID bernilai SystemActorID untuk auto-approval; hanya diizinkan jika ada kebijakan yang jatuh tempo.
 	VerifyTask(ctx context.Context, userTaskID int, parentID int, newStatus models.UserTaskStatus) error
 
+	// AutoApproveTask menyetujui submission atas nama sistem jika kebijakan auto-approval-nya sudah jatuh tempo
+	// (misal: langsung setelah anak submit untuk kebijakan tanpa masa tenggang).
+	// Mengembalikan true jika tugas disetujui, false jika belum ada kebijakan yang berlaku.
+	AutoApproveTask(ctx context.Context, userTaskID int) (bool, error)
+
+	// ProcessDueAutoApprovals menyetujui semua submission yang masa tenggangnya sudah lewat pada waktu `now`
+	// melalui transaksi VerifyTask yang sama. Dipanggil oleh worker background.
+	// Mengembalikan jumlah tugas yang disetujui.
+	ProcessDueAutoApprovals(ctx context.Context, now time.Time) (int, error)
+
+	// UnassignTask membatalkan penugasan yang belum disetujui (assigned/submitted/rejected).
+	// Tugas yang sudah approved harus di-revert terlebih dahulu.
+	UnassignTask(ctx context.Context, userTaskID int, parentID int) error
+
+	// ReassignTask memindahkan penugasan berstatus 'assigned' ke anak lain milik parent yang sama.
+	ReassignTask(ctx context.Context, userTaskID int, parentID int, newChildID int) error
+
+	// RevertVerification mengembalikan tugas approved/rejected ke status 'submitted' dalam batas waktu revert.
+	// Untuk tugas approved, poin yang sudah diberikan dibalik dengan transaksi kompensasi (bukan dihapus).
+	RevertVerification(ctx context.Context, userTaskID int, parentID int, reason string) error
+
+	// ExpireOverdueTasks mengubah penugasan 'assigned' yang due_at-nya sudah lewat menjadi 'expired'.
+	// Dipanggil oleh worker background. Mengembalikan jumlah tugas yang kedaluwarsa.
+	ExpireOverdueTasks(ctx context.Context, now time.Time) (int, error)
+
+	// GetTaskTimeline mengembalikan riwayat transisi status sebuah penugasan (terlama dulu).
+	// Hanya anak pemilik tugas atau orang tuanya yang boleh melihat.
+	GetTaskTimeline(ctx context.Context, userTaskID int, userID int) ([]models.UserTaskEvent, error)
+
 	// AssignTask menangani logika bisnis untuk menugaskan tugas kepada anak.
 	// Memerlukan ID orang tua (pemberi tugas), ID anak (penerima), dan ID tugas yang akan diberikan.
 	// Mengembalikan ID UserTask yang baru dibuat atau error jika terjadi kesalahan (misal, relasi tidak valid, tugas sudah aktif).
@@ -92,6 +123,8 @@ type RewardService interface {
 	// Memerlukan ID anak yang mengklaim dan ID hadiah yang diklaim.
 	// Mengembalikan ID klaim (UserReward) yang baru dibuat atau error jika terjadi kesalahan
 	// (misal, poin tidak cukup, hadiah tidak valid, operasi database gagal).
+	// Stok, kuota per anak, dan cooldown diperiksa dalam transaksi yang sama; pelanggaran dikembalikan
+	// sebagai *RewardUnavailableError beserta kode alasannya.
 	ClaimReward(ctx context.Context, childID int, rewardID int) (int, error)
 
 	// ReviewClaim menangani logika bisnis saat orang tua meninjau (menyetujui/menolak)
@@ -99,8 +132,37 @@ type RewardService interface {
 	// Memastikan pembaruan status klaim dilakukan secara atomik. Jika klaim ditolak,
 	// mungkin perlu ada logika pengembalian poin (tergantung aturan bisnis).
 	// Memerlukan ID klaim, ID orang tua (untuk validasi), dan status baru (Approved/Rejected).
-	// Mengembalikan error jika terjadi kesalahan, validasi gagal, atau operasi database gagal.
-	ReviewClaim(ctx context.Context, claimID int, parentID int, newStatus models.UserRewardStatus) error
+	// Jika anak memiliki kebijakan persetujuan bersama dan nilai klaim melewati ambangnya, persetujuan
+	// hanya dicatat sebagai suara sampai kuorum tercapai (hasil tetap 'pending'); satu penolakan langsung
+	// menolak klaim. Mengembalikan hasil review atau error jika validasi/operasi database gagal.
+	ReviewClaim(ctx context.Context, claimID int, parentID int, newStatus models.UserRewardStatus) (*models.ClaimReviewResult, error)
+
+	// ScheduleClaimFulfillment menjadwalkan (atau menjadwal ulang) tanggal penyerahan hadiah
+	// untuk klaim yang sudah disetujui. Hanya orang tua dari anak pengklaim yang boleh melakukannya.
+	ScheduleClaimFulfillment(ctx context.Context, claimID int, parentID int, deliveryDate time.Time, note string) error
+
+	// FulfillClaim menandai hadiah dari klaim 'approved'/'scheduled' sudah diserahkan ke anak.
+	FulfillClaim(ctx context.Context, claimID int, parentID int, note string) error
+
+	// ConfirmClaimReceipt mencatat konfirmasi anak bahwa hadiah sudah diterima.
+	// Klaim yang belum ditandai diserahkan oleh orang tua ikut berpindah ke 'fulfilled'.
+	ConfirmClaimReceipt(ctx context.Context, claimID int, childID int) error
+
+	// GetClaimHistory mengambil riwayat transisi klaim (klaim, review, jadwal, penyerahan, konfirmasi).
+	// Hanya anak pemilik klaim atau orang tuanya yang boleh melihat.
+	GetClaimHistory(ctx context.Context, claimID int, userID int) ([]models.UserRewardEvent, error)
+
+	// SetApprovalPolicy membuat atau memperbarui kebijakan persetujuan bersama milik parent (klaim di atas ambang
+	// poin butuh persetujuan beberapa orang tua berbeda). childID 0 = semua anak parent; selain itu hanya untuk
+	// anak tersebut, dan hanya orang tua anak yang boleh mengaturnya.
+	SetApprovalPolicy(ctx context.Context, parentID int, childID int, input *models.SetRewardApprovalPolicyInput) (*models.RewardApprovalPolicy, error)
+
+	// GetApprovalPolicy mengambil kebijakan keluarga milik parent (childID 0) atau kebijakan yang berlaku
+	// untuk anak dari semua orang tuanya (pgx.ErrNoRows jika tidak ada).
+	GetApprovalPolicy(ctx context.Context, parentID int, childID int) (*models.RewardApprovalPolicy, error)
+
+	// DeleteApprovalPolicy menghapus kebijakan persetujuan bersama milik parent untuk cakupan childID (0 = semua anak).
+	DeleteApprovalPolicy(ctx context.Context, parentID int, childID int) error
 }
 
 // ====================================================================================
@@ -126,6 +188,526 @@ type InvitationService interface {
 	AcceptInvitation(ctx context.Context, joiningParentID int, code string) error
 }
 
+// ====================================================================================
+// Rotation Service
+// ====================================================================================
+
+// SystemActorID adalah ID aktor yang digunakan ketika operasi dipicu oleh sistem
+// (misal: scheduler background), bukan oleh pengguna yang login.
+const SystemActorID = 0
+
+// RotationService: Kontrak untuk operasi terkait rotasi tugas antar saudara
+// (misal: giliran cuci piring yang berpindah setiap hari/minggu).
+type RotationService interface {
+	// CreateRotation membuat grup rotasi untuk definisi tugas tertentu dengan urutan anak yang diberikan.
+	// Parent harus memiliki akses ke definisi tugas dan merupakan parent dari semua anak dalam rotasi.
+	// Mengembalikan ID rotasi baru atau error.
+	CreateRotation(ctx context.Context, parentID int, input *models.CreateRotationInput) (int, error)
+
+	// GetRotation mengambil detail rotasi jika parent berhak mengaksesnya.
+	GetRotation(ctx context.Context, rotationID int, parentID int) (*models.TaskRotation, error)
+
+	// GetRotationsForParent mengambil daftar rotasi yang dibuat oleh parent dengan paginasi.
+	GetRotationsForParent(ctx context.Context, parentID int, page, limit int) ([]models.TaskRotation, int, error)
+
+	// DeleteRotation menghapus rotasi beserta riwayatnya. Penugasan yang sudah dibuat tidak ikut dihapus.
+	DeleteRotation(ctx context.Context, rotationID int, parentID int) error
+
+	// AdvanceRotation memberikan giliran berikutnya: menugaskan tugas ke anak yang sedang mendapat giliran
+	// melalui UserTaskRepository.AssignTask, mencatat riwayat, lalu memajukan posisi rotasi.
+	// actorID bernilai SystemActorID jika dipicu oleh scheduler.
+	// Mengembalikan catatan giliran yang dibuat atau error.
+	AdvanceRotation(ctx context.Context, rotationID int, actorID int) (*models.TaskRotationTurn, error)
+
+	// SkipTurn melewati giliran anak berikutnya tanpa membuat penugasan, sehingga giliran jatuh ke anak setelahnya.
+	// Mengembalikan catatan giliran yang dilewati atau error.
+	SkipTurn(ctx context.Context, rotationID int, parentID int, notes string) (*models.TaskRotationTurn, error)
+
+	// SwapMembers menukar urutan dua anak dalam rotasi.
+	SwapMembers(ctx context.Context, rotationID int, parentID int, firstChildID int, secondChildID int) error
+
+	// GetRotationHistory mengambil riwayat giliran sebuah rotasi dengan paginasi.
+	GetRotationHistory(ctx context.Context, rotationID int, parentID int, page, limit int) ([]models.TaskRotationTurn, int, error)
+
+	// PreviewRotation menghitung daftar giliran mendatang (siapa dan kapan) tanpa mengubah data.
+	PreviewRotation(ctx context.Context, rotationID int, parentID int, count int) ([]models.RotationPreviewItem, error)
+
+	// ProcessDueRotations memproses semua rotasi aktif yang jadwalnya sudah tiba (dipanggil oleh scheduler).
+	// Mengembalikan jumlah rotasi yang berhasil diproses.
+	ProcessDueRotations(ctx context.Context, now time.Time) (int, error)
+}
+
+// ====================================================================================
+// Bounty Service
+// ====================================================================================
+
+// BountyService: Kontrak untuk operasi terkait bounty, yaitu tugas terbuka yang bisa diklaim
+// oleh anak mana pun dalam keluarga. Klaim yang berhasil menghasilkan UserTask biasa
+// sehingga tetap mengikuti alur submit/verify yang sudah ada.
+type BountyService interface {
+	// CreateBounty memposting definisi tugas sebagai bounty. Parent harus memiliki akses ke definisi tugas.
+	// Mengembalikan ID bounty baru atau error.
+	CreateBounty(ctx context.Context, parentID int, input *models.CreateBountyInput) (int, error)
+
+	// GetBountiesForParent mengambil daftar bounty yang diposting parent (beserta klaimnya) dengan paginasi.
+	GetBountiesForParent(ctx context.Context, parentID int, page, limit int) ([]models.TaskBounty, int, error)
+
+	// CancelBounty membatalkan bounty yang masih terbuka. Penugasan dari klaim sebelumnya tidak ikut dibatalkan.
+	CancelBounty(ctx context.Context, bountyID int, parentID int) error
+
+	// GetOpenBountiesForChild mengambil bounty yang masih bisa diklaim oleh anak dengan paginasi.
+	GetOpenBountiesForChild(ctx context.Context, childID int, page, limit int) ([]models.TaskBounty, int, error)
+
+	// ClaimBounty mengklaim bounty untuk anak secara atomik (baris bounty dikunci dalam transaksi),
+	// lalu menugaskan tugasnya ke anak tersebut.
+	// Mengembalikan ID UserTask yang dibuat atau error (misal: ErrBountyUnavailable, ErrBountyAlreadyClaimed).
+	ClaimBounty(ctx context.Context, childID int, bountyID int) (int, error)
+}
+
+// ====================================================================================
+// Template Service
+// ====================================================================================
+
+// TemplateService: Kontrak untuk katalog template tugas/hadiah sistem. Admin mengelola katalog,
+// Parent menelusuri dan mengimpor template menjadi definisi Task/Reward miliknya sendiri.
+type TemplateService interface {
+	// CreateTemplate membuat template baru (Admin). Mengembalikan ID template baru atau error.
+	CreateTemplate(ctx context.Context, adminID int, input *models.TemplateInput) (int, error)
+
+	// UpdateTemplate memperbarui template (Admin). Setiap pembaruan menaikkan versi template
+	// sehingga definisi hasil impor bisa diberi saran pembaruan.
+	UpdateTemplate(ctx context.Context, templateID int, input *models.TemplateInput) error
+
+	// DeleteTemplate menghapus template (Admin). Definisi hasil impor tidak ikut terhapus.
+	DeleteTemplate(ctx context.Context, templateID int) error
+
+	// GetTemplateByID mengambil detail template beserta semua terjemahannya.
+	GetTemplateByID(ctx context.Context, templateID int, locale string) (*models.DefinitionTemplate, error)
+
+	// ListTemplates mengambil daftar template dengan filter & paginasi.
+	// includeInactive hanya dipakai untuk tampilan Admin.
+	ListTemplates(ctx context.Context, filter *models.TemplateFilter, includeInactive bool, page, limit int) ([]models.DefinitionTemplate, int, error)
+
+	// ImportTemplates menyalin template aktif menjadi definisi Task/Reward milik parent dalam satu transaksi.
+	// Mengembalikan daftar definisi yang dibuat atau error jika ada template yang tidak valid.
+	ImportTemplates(ctx context.Context, parentID int, input *models.ImportTemplatesInput) ([]models.ImportedDefinition, error)
+
+	// GetUpdateSuggestions mengambil definisi hasil impor milik parent yang template sumbernya sudah diperbarui.
+	GetUpdateSuggestions(ctx context.Context, parentID int, locale string) ([]models.TemplateUpdateSuggestion, error)
+}
+
+// ====================================================================================
+// Auto-Approval Service
+// ====================================================================================
+
+// AutoApprovalService: Kontrak untuk mengelola kebijakan auto-approval submission tugas.
+// Persetujuan otomatisnya sendiri dijalankan oleh TaskService (AutoApproveTask / ProcessDueAutoApprovals).
+type AutoApprovalService interface {
+	// CreatePolicy membuat kebijakan untuk tugas dan/atau anak. Parent harus memiliki akses ke tugas
+	// dan merupakan orang tua dari anak tersebut. Mengembalikan ID kebijakan baru atau error.
+	CreatePolicy(ctx context.Context, parentID int, input *models.CreateAutoApprovalPolicyInput) (int, error)
+
+	// GetPoliciesForParent mengambil semua kebijakan milik parent.
+	GetPoliciesForParent(ctx context.Context, parentID int) ([]models.AutoApprovalPolicy, error)
+
+	// UpdatePolicy memperbarui masa tenggang dan/atau status aktif kebijakan milik parent.
+	UpdatePolicy(ctx context.Context, policyID int, parentID int, input *models.UpdateAutoApprovalPolicyInput) error
+
+	// DeletePolicy menghapus kebijakan milik parent.
+	DeletePolicy(ctx context.Context, policyID int, parentID int) error
+}
+
+// ====================================================================================
+// Savings Goal Service
+// ====================================================================================
+
+// SavingsGoalService: Kontrak untuk target tabungan (wishlist) anak: progres dari ledger poin,
+// penyisihan poin (earmark), bonus dari orang tua, dan notifikasi/klaim otomatis saat target tercapai.
+type SavingsGoalService interface {
+	// CreateGoal membuat target tabungan untuk anak. Jika RewardID diisi, nama dan target diambil
+	// dari Reward (yang harus dibuat oleh orang tua anak). Klaim otomatis hanya untuk target Reward.
+	CreateGoal(ctx context.Context, childID int, input *models.CreateSavingsGoalInput) (int, error)
+
+	// GetSavingsOverview mengambil saldo, poin yang disisihkan, dan target terbuka anak beserta progresnya.
+	GetSavingsOverview(ctx context.Context, childID int) (*models.SavingsOverview, error)
+
+	// GetChildSavingsOverview sama seperti GetSavingsOverview, namun untuk orang tua dari anak tersebut.
+	GetChildSavingsOverview(ctx context.Context, parentID int, childID int) (*models.SavingsOverview, error)
+
+	// EarmarkPoints menyisihkan (points positif) atau melepas (points negatif) poin anak untuk target miliknya.
+	// Poin yang disisihkan tidak bisa dipakai untuk klaim hadiah lain.
+	EarmarkPoints(ctx context.Context, goalID int, childID int, points int) error
+
+	// CancelGoal membatalkan target terbuka milik anak dan melepas poin yang disisihkan.
+	CancelGoal(ctx context.Context, goalID int, childID int) error
+
+	// ContributeToGoal menambahkan bonus poin dari orang tua ke saldo anak dan langsung menyisihkannya
+	// untuk target tersebut (maksimal sampai target terpenuhi).
+	ContributeToGoal(ctx context.Context, goalID int, parentID int, input *models.ContributeToGoalInput) error
+
+	// ProcessReachedGoals memeriksa target 'active', menandai yang sudah tercapai, mengirim notifikasi,
+	// dan menjalankan klaim otomatis. Dipanggil oleh background worker. Mengembalikan jumlah target yang tercapai.
+	ProcessReachedGoals(ctx context.Context) (int, error)
+}
+
+// ====================================================================================
+// Point Expiration Service
+// ====================================================================================
+
+// PointExpirationService: Kontrak untuk kebijakan kedaluwarsa poin anak. Pemakaian poin dihitung FIFO
+// (lot tertua dipakai dulu); lot yang melewati masa berlaku dicatat sebagai transaksi 'expiration'.
+type PointExpirationService interface {
+	// SetPolicy membuat atau memperbarui kebijakan kedaluwarsa poin milik parent untuk semua anaknya (childID 0)
+	// atau satu anak (hanya orang tua anak tersebut).
+	SetPolicy(ctx context.Context, parentID int, childID int, input *models.SetPointExpirationPolicyInput) (*models.PointExpirationPolicy, error)
+
+	// GetPolicy mengambil kebijakan keluarga milik parent (childID 0) atau kebijakan yang berlaku
+	// untuk anak (pgx.ErrNoRows jika belum ada).
+	GetPolicy(ctx context.Context, parentID int, childID int) (*models.PointExpirationPolicy, error)
+
+	// DeletePolicy menghapus kebijakan kedaluwarsa poin milik parent untuk cakupan childID (0 = semua anak);
+	// anak tanpa kebijakan yang berlaku tidak lagi kehilangan poin.
+	DeletePolicy(ctx context.Context, parentID int, childID int) error
+
+	// GetExpiringPoints mengambil lot poin anak yang akan kedaluwarsa dalam masa peringatan kebijakan.
+	GetExpiringPoints(ctx context.Context, childID int, now time.Time) (*models.ExpiringPointsSummary, error)
+
+	// ProcessExpirations mencatat transaksi 'expiration' untuk lot yang sudah kedaluwarsa dan mengirim
+	// peringatan untuk lot yang akan kedaluwarsa. Dipanggil oleh background worker.
+	// Mengembalikan total poin yang kedaluwarsa.
+	ProcessExpirations(ctx context.Context, now time.Time) (int, error)
+}
+
+// ====================================================================================
+// Interest Service
+// ====================================================================================
+
+// InterestService: Kontrak untuk bunga tabungan atas saldo poin anak: kebijakan (tarif, jadwal, pembulatan,
+// batas), proyeksi pertumbuhan, dan posting bunga periodik yang idempoten.
+type InterestService interface {
+	// SetPolicy membuat atau memperbarui kebijakan bunga milik parent untuk semua anaknya (childID 0)
+	// atau satu anak (hanya orang tua anak tersebut).
+	SetPolicy(ctx context.Context, parentID int, childID int, input *models.SetInterestPolicyInput) (*models.InterestPolicy, error)
+
+	// GetPolicy mengambil kebijakan keluarga milik parent (childID 0) atau kebijakan yang berlaku
+	// untuk anak (pgx.ErrNoRows jika belum ada).
+	GetPolicy(ctx context.Context, parentID int, childID int) (*models.InterestPolicy, error)
+
+	// DeletePolicy menghapus kebijakan bunga milik parent untuk cakupan childID (0 = semua anak);
+	// saldo anak tanpa kebijakan yang berlaku tidak lagi berbunga.
+	DeletePolicy(ctx context.Context, parentID int, childID int) error
+
+	// PreviewForChild memproyeksikan pertumbuhan saldo anak selama beberapa periode ke depan.
+	// Mengembalikan pgx.ErrNoRows jika anak tidak memiliki kebijakan bunga.
+	PreviewForChild(ctx context.Context, childID int, periods int, now time.Time) (*models.InterestProjection, error)
+
+	// PreviewForParent sama seperti PreviewForChild, namun untuk orang tua dari anak tersebut.
+	PreviewForParent(ctx context.Context, parentID int, childID int, periods int, now time.Time) (*models.InterestProjection, error)
+
+	// ProcessInterest memposting bunga periode terakhir yang sudah selesai untuk setiap anak yang memiliki
+	// kebijakan. Setiap periode hanya diposting satu kali. Dipanggil oleh background worker.
+	// Mengembalikan total poin bunga yang diposting.
+	ProcessInterest(ctx context.Context, now time.Time) (int, error)
+}
+
+// ====================================================================================
+// Allowance Service
+// ====================================================================================
+
+// AllowanceService: Kontrak untuk uang saku otomatis: rencana per anak (jumlah, frekuensi, tanggal mulai,
+// syarat), jeda/lanjut, riwayat pembayaran, dan pembayaran periodik tepat satu kali per periode.
+type AllowanceService interface {
+	// SetPlan membuat atau memperbarui rencana uang saku anak (hanya orang tua anak tersebut).
+	SetPlan(ctx context.Context, parentID int, childID int, input *models.SetAllowancePlanInput) (*models.AllowancePlan, error)
+
+	// GetPlan mengambil rencana uang saku anak (pgx.ErrNoRows jika belum ada).
+	GetPlan(ctx context.Context, parentID int, childID int) (*models.AllowancePlan, error)
+
+	// DeletePlan menghapus rencana uang saku anak; riwayat pembayaran tetap disimpan.
+	DeletePlan(ctx context.Context, parentID int, childID int) error
+
+	// PausePlan menjeda rencana; periode yang berjalan selama jeda tidak dibayarkan.
+	PausePlan(ctx context.Context, parentID int, childID int) (*models.AllowancePlan, error)
+
+	// ResumePlan melanjutkan rencana yang dijeda mulai dari periode yang sedang berjalan pada waktu now.
+	ResumePlan(ctx context.Context, parentID int, childID int, now time.Time) (*models.AllowancePlan, error)
+
+	// GetPayoutsForParent mengambil riwayat pembayaran uang saku anak untuk orang tuanya.
+	GetPayoutsForParent(ctx context.Context, parentID int, childID int, page, limit int) ([]models.AllowancePayout, int, error)
+
+	// GetMyPayouts mengambil riwayat pembayaran uang saku milik anak.
+	GetMyPayouts(ctx context.Context, childID int, page, limit int) ([]models.AllowancePayout, int, error)
+
+	// ProcessAllowances memproses setiap periode yang sudah selesai untuk semua rencana aktif (termasuk
+	// periode yang terlewat saat worker tidak berjalan). Dipanggil oleh background worker.
+	// Mengembalikan total poin uang saku yang dibayarkan.
+	ProcessAllowances(ctx context.Context, now time.Time) (int, error)
+}
+
+// ====================================================================================
+// Transfer Service
+// ====================================================================================
+
+// TransferService: Kontrak untuk transfer/hadiah poin antar saudara: kebijakan keluarga per anak pengirim,
+// persetujuan orang tua, dan pencatatan debit & kredit yang saling terkait di ledger poin.
+type TransferService interface {
+	// SetPolicy membuat atau memperbarui kebijakan transfer milik parent untuk semua anaknya (childID 0)
+	// atau satu anak (hanya orang tua anak tersebut).
+	SetPolicy(ctx context.Context, parentID int, childID int, input *models.SetTransferPolicyInput) (*models.TransferPolicy, error)
+
+	// GetPolicy mengambil kebijakan keluarga milik parent (childID 0) atau kebijakan yang berlaku
+	// untuk anak (pgx.ErrNoRows jika belum ada).
+	GetPolicy(ctx context.Context, parentID int, childID int) (*models.TransferPolicy, error)
+
+	// DeletePolicy menghapus kebijakan transfer milik parent untuk cakupan childID (0 = semua anak);
+	// anak tanpa kebijakan kembali ke default (boleh, perlu persetujuan).
+	DeletePolicy(ctx context.Context, parentID int, childID int) error
+
+	// CreateTransfer mengirim poin dari anak ke saudaranya. Jika kebijakan tidak memerlukan persetujuan,
+	// poin langsung dipindahkan; jika tidak, transfer menunggu persetujuan orang tua.
+	CreateTransfer(ctx context.Context, childID int, input *models.CreateTransferInput) (*models.PointTransfer, error)
+
+	// CancelTransfer membatalkan transfer 'pending' milik anak pengirim.
+	CancelTransfer(ctx context.Context, childID int, transferID int) error
+
+	// GetMyTransfers mengambil transfer yang dikirim atau diterima anak dengan paginasi.
+	GetMyTransfers(ctx context.Context, childID int, page, limit int) ([]models.PointTransfer, int, error)
+
+	// GetTransfersForParent mengambil transfer yang dikirim anak-anak parent (status kosong = semua).
+	GetTransfersForParent(ctx context.Context, parentID int, status models.PointTransferStatus, page, limit int) ([]models.PointTransfer, int, error)
+
+	// ApproveTransfer menyetujui transfer 'pending' dan memindahkan poinnya.
+	// Mengembalikan ErrInsufficientPoints (transfer tetap 'pending') jika poin pengirim tidak lagi cukup.
+	ApproveTransfer(ctx context.Context, parentID int, transferID int) (*models.PointTransfer, error)
+
+	// RejectTransfer menolak transfer 'pending' tanpa memindahkan poin.
+	RejectTransfer(ctx context.Context, parentID int, transferID int, reason string) error
+}
+
+// ====================================================================================
+// Currency Service
+// ====================================================================================
+
+// CurrencyService: Kontrak untuk mata uang tambahan per keluarga (misal bintang, koin, menit layar):
+// mata uang milik parent, saldo anak per mata uang, dan aturan tukar antar mata uang. CurrencyID 0 = poin.
+type CurrencyService interface {
+	// CreateCurrency membuat mata uang baru milik parent.
+	CreateCurrency(ctx context.Context, parentID int, input *models.CurrencyInput) (*models.Currency, error)
+
+	// GetCurrencies mengambil semua mata uang milik parent.
+	GetCurrencies(ctx context.Context, parentID int) ([]models.Currency, error)
+
+	// UpdateCurrency mengubah nama/simbol mata uang milik parent (pgx.ErrNoRows jika tidak ditemukan).
+	UpdateCurrency(ctx context.Context, parentID int, currencyID int, input *models.CurrencyInput) (*models.Currency, error)
+
+	// DeleteCurrency menghapus mata uang yang belum dipakai tugas, hadiah, maupun ledger.
+	DeleteCurrency(ctx context.Context, parentID int, currencyID int) error
+
+	// CreateExchangeRule membuat aturan tukar antar dua mata uang milik parent (atau poin).
+	CreateExchangeRule(ctx context.Context, parentID int, input *models.CreateExchangeRuleInput) (*models.CurrencyExchangeRule, error)
+
+	// GetExchangeRules mengambil semua aturan tukar milik parent.
+	GetExchangeRules(ctx context.Context, parentID int) ([]models.CurrencyExchangeRule, error)
+
+	// DeleteExchangeRule menghapus aturan tukar milik parent.
+	DeleteExchangeRule(ctx context.Context, parentID int, ruleID int) error
+
+	// GetChildBalances mengambil saldo anak per mata uang (hanya orang tua anak tersebut).
+	GetChildBalances(ctx context.Context, parentID int, childID int) ([]models.CurrencyBalance, error)
+
+	// GetBalances mengambil saldo anak sendiri per mata uang.
+	GetBalances(ctx context.Context, childID int) ([]models.CurrencyBalance, error)
+
+	// GetExchangeRulesForChild mengambil aturan tukar yang bisa dipakai anak (milik orang tuanya).
+	GetExchangeRulesForChild(ctx context.Context, childID int) ([]models.CurrencyExchangeRule, error)
+
+	// Exchange menukar mata uang anak sesuai aturan tukar orang tuanya, dicatat sebagai entri debit & kredit
+	// berjenis 'exchange'. Mengembalikan ErrInsufficientPoints jika saldo mata uang asal tidak cukup.
+	Exchange(ctx context.Context, childID int, input *models.ExchangeCurrencyInput) (*models.CurrencyExchangeResult, error)
+}
+
+// ====================================================================================
+// Cash-Out Service
+// ====================================================================================
+
+// CashOutService: Kontrak untuk pencairan poin menjadi uang saku: kurs keluarga atau per anak, permintaan cash-out oleh anak
+// dengan persetujuan orang tua, ledger uang terpisah untuk pembayaran, dan laporan bulanan.
+// Semua nilai uang disimpan sebagai bilangan bulat satuan terkecil beserta kode mata uang.
+type CashOutService interface {
+	// SetPolicy membuat atau memperbarui kurs pencairan milik parent untuk semua anaknya (childID 0)
+	// atau satu anak (hanya orang tua anak tersebut).
+	SetPolicy(ctx context.Context, parentID int, childID int, input *models.SetCashOutPolicyInput) (*models.CashOutPolicy, error)
+
+	// GetPolicy mengambil kurs keluarga milik parent (childID 0) atau kurs yang berlaku
+	// untuk anak (pgx.ErrNoRows jika belum ada).
+	GetPolicy(ctx context.Context, parentID int, childID int) (*models.CashOutPolicy, error)
+
+	// DeletePolicy menghapus kurs pencairan milik parent untuk cakupan childID (0 = semua anak);
+	// anak tanpa kurs yang berlaku tidak bisa lagi mengajukan cash-out.
+	DeletePolicy(ctx context.Context, parentID int, childID int) error
+
+	// RequestCashOut mengajukan pencairan poin sesuai kurs saat ini. Poin langsung dikurangi (entri 'cash_out')
+	// dan permintaan menunggu persetujuan orang tua. Mengembalikan ErrInsufficientPoints jika poin bebas tidak cukup.
+	RequestCashOut(ctx context.Context, childID int, input *models.CreateCashOutInput) (*models.CashOutRequest, error)
+
+	// GetMyCashOuts mengambil permintaan cash-out anak sendiri (status kosong = semua) dengan paginasi.
+	GetMyCashOuts(ctx context.Context, childID int, status models.CashOutStatus, page, limit int) ([]models.CashOutRequest, int, error)
+
+	// GetCashOutsForParent mengambil permintaan cash-out anak-anak parent (status kosong = semua) dengan paginasi.
+	GetCashOutsForParent(ctx context.Context, parentID int, status models.CashOutStatus, page, limit int) ([]models.CashOutRequest, int, error)
+
+	// ReviewCashOut menyetujui (mencatat pembayaran ke ledger uang) atau menolak (mengembalikan poin lewat
+	// entri 'cash_out_refund') permintaan cash-out 'pending'.
+	ReviewCashOut(ctx context.Context, parentID int, requestID int, input *models.ReviewCashOutInput) (*models.CashOutRequest, error)
+
+	// GetStatement mengambil laporan uang bulanan anak sendiri (month format YYYY-MM, kosong = bulan berjalan).
+	GetStatement(ctx context.Context, childID int, month string) (*models.MoneyStatement, error)
+
+	// GetChildStatement mengambil laporan uang bulanan anak (hanya orang tua anak tersebut).
+	GetChildStatement(ctx context.Context, parentID int, childID int, month string) (*models.MoneyStatement, error)
+}
+
+// ====================================================================================
+// Statement Service
+// ====================================================================================
+
+// StatementService: Kontrak untuk laporan rekening poin bulanan: saldo awal, kredit & debit per jenis transaksi,
+// saldo akhir, dan rincian transaksi yang terkait tugas/hadiah.
+type StatementService interface {
+	// GetStatement menyusun laporan rekening anak sendiri untuk month (format YYYY-MM, kosong = bulan berjalan)
+	// dan currencyID (0 = poin).
+	GetStatement(ctx context.Context, childID int, month string, currencyID int) (*models.PointStatement, error)
+
+	// GetChildStatement menyusun laporan rekening anak (hanya orang tua anak tersebut).
+	GetChildStatement(ctx context.Context, parentID int, childID int, month string, currencyID int) (*models.PointStatement, error)
+}
+
+// ====================================================================================
+// Ledger Service
+// ====================================================================================
+
+// LedgerService: Kontrak untuk integritas ledger poin (Admin). Ledger bersifat append-only,
+// sehingga koreksi hanya dilakukan dengan entri pembalik.
+type LedgerService interface {
+	// VerifyLedger memverifikasi rantai hash ledger untuk userID (0 = semua anak) dan melaporkan
+	// entri yang diubah, celah urutan, atau ujung rantai yang tidak cocok.
+	VerifyLedger(ctx context.Context, userID int) (*models.LedgerVerificationReport, error)
+
+	// ReverseTransaction membalik entri ledger yang salah dengan entri 'correction' sebesar kebalikannya
+	// (dalam mata uang yang sama) dan mencatatnya di jejak audit. Entri pembalik tidak bisa dibalik lagi.
+	ReverseTransaction(ctx context.Context, adminID int, transactionID int, input *models.ReversePointTransactionInput) (*models.PointTransaction, error)
+}
+
+// ====================================================================================
+// Penalty Service
+// ====================================================================================
+
+// PenaltyService: Kontrak untuk katalog pelanggaran milik parent dan sanksi poin ke anak.
+// Sanksi dicatat sebagai entri ledger 'penalty' dan bisa ditebus lewat tugas penebus (lihat TaskService.VerifyTask).
+type PenaltyService interface {
+	// CreateInfractionType membuat jenis pelanggaran baru. Tugas penebus harus dibuat oleh parent yang sama.
+	CreateInfractionType(ctx context.Context, parentID int, input *models.InfractionTypeInput) (*models.InfractionType, error)
+	// GetInfractionTypes mengambil katalog pelanggaran milik parent.
+	GetInfractionTypes(ctx context.Context, parentID int) ([]models.InfractionType, error)
+	// UpdateInfractionType memperbarui jenis pelanggaran milik parent. Sanksi yang sudah diterapkan tidak berubah.
+	UpdateInfractionType(ctx context.Context, parentID int, infractionTypeID int, input *models.InfractionTypeInput) (*models.InfractionType, error)
+	// DeleteInfractionType menghapus jenis pelanggaran milik parent.
+	DeleteInfractionType(ctx context.Context, parentID int, infractionTypeID int) error
+
+	// ApplyPenalty menerapkan sanksi ke anak: memotong poin (maksimal sebesar saldo), menegakkan batas harian
+	// di zona waktu anak, dan menugaskan tugas penebus jika ada.
+	ApplyPenalty(ctx context.Context, parentID int, childID int, input *models.ApplyPenaltyInput) (*models.Penalty, error)
+	// GetChildPenalties mengambil riwayat sanksi anak (Parent) dengan paginasi.
+	GetChildPenalties(ctx context.Context, parentID int, childID int, page, limit int) ([]models.Penalty, int, error)
+	// GetMyPenalties mengambil riwayat sanksi anak sendiri dengan paginasi.
+	GetMyPenalties(ctx context.Context, childID int, page, limit int) ([]models.Penalty, int, error)
+	// GetPenaltyReport menyusun laporan sanksi anak per hari/minggu/bulan dan per jenis pelanggaran.
+	GetPenaltyReport(ctx context.Context, parentID int, childID int, filter *models.PenaltyReportFilter) (*models.PenaltyReport, error)
+}
+
+// ====================================================================================
+// Badge Service
+// ====================================================================================
+
+// BadgeService: Kontrak untuk badge & pencapaian anak. Badge sistem berlaku untuk semua anak,
+// sedangkan badge custom dibuat parent untuk anak-anaknya.
+type BadgeService interface {
+	// CreateBadge membuat badge custom. Aturan tugas hanya boleh memakai tugas yang dibuat parent tersebut.
+	CreateBadge(ctx context.Context, parentID int, input *models.BadgeInput) (*models.Badge, error)
+	// GetBadges mengambil badge sistem dan badge custom milik parent.
+	GetBadges(ctx context.Context, parentID int) ([]models.Badge, error)
+	// UpdateBadge memperbarui badge custom milik parent. Badge yang sudah diraih tidak dicabut.
+	UpdateBadge(ctx context.Context, parentID int, badgeID int, input *models.BadgeInput) (*models.Badge, error)
+	// DeleteBadge menghapus badge custom milik parent.
+	DeleteBadge(ctx context.Context, parentID int, badgeID int) error
+	// AwardBadge memberikan badge custom ber-aturan 'manual' milik parent kepada anaknya.
+	AwardBadge(ctx context.Context, parentID int, childID int, badgeID int) (*models.ChildBadge, error)
+
+	// GetChildBadges mengambil badge yang sudah diraih anak (Parent).
+	GetChildBadges(ctx context.Context, parentID int, childID int) ([]models.ChildBadge, error)
+	// GetMyBadges mengambil badge yang sudah diraih anak sendiri.
+	GetMyBadges(ctx context.Context, childID int) ([]models.ChildBadge, error)
+
+	// EvaluateChild mengevaluasi aturan badge yang belum diraih anak dan memberikan yang sudah terpenuhi.
+	// Dipanggil setelah verifikasi tugas dan klaim/review hadiah selesai.
+	EvaluateChild(ctx context.Context, childID int) ([]models.ChildBadge, error)
+	// ProcessBalanceBadges memberikan badge 'points_balance' yang terpenuhi karena entri ledger apa pun (dipanggil oleh worker).
+	ProcessBalanceBadges(ctx context.Context) (int, error)
+}
+
+// ====================================================================================
+// Streak Service
+// ====================================================================================
+
+// StreakService: Kontrak untuk streak anak (hari berturut-turut dengan tugas disetujui, di zona waktu anak),
+// aturan bonus streak milik parent, dan streak freeze yang dibeli anak dengan poin.
+// Bonus streak sendiri diberikan oleh TaskService saat tugas disetujui (dalam transaksi yang sama).
+type StreakService interface {
+	// CreateBonusRule membuat aturan bonus streak. Aturan per tugas hanya boleh memakai tugas yang dibuat parent tersebut.
+	CreateBonusRule(ctx context.Context, parentID int, input *models.StreakBonusRuleInput) (*models.StreakBonusRule, error)
+	// GetBonusRules mengambil semua aturan bonus streak milik parent.
+	GetBonusRules(ctx context.Context, parentID int) ([]models.StreakBonusRule, error)
+	// UpdateBonusRule memperbarui aturan bonus streak milik parent. Bonus yang sudah diberikan tidak berubah.
+	UpdateBonusRule(ctx context.Context, parentID int, ruleID int, input *models.StreakBonusRuleInput) (*models.StreakBonusRule, error)
+	// DeleteBonusRule menghapus aturan bonus streak milik parent.
+	DeleteBonusRule(ctx context.Context, parentID int, ruleID int) error
+
+	// GetChildStreaks merangkum streak anak (Parent).
+	GetChildStreaks(ctx context.Context, parentID int, childID int) (*models.StreakSummary, error)
+	// SetFreezePolicy mengatur harga streak freeze anak (membuat atau mengganti).
+	SetFreezePolicy(ctx context.Context, parentID int, childID int, input *models.SetStreakFreezePolicyInput) (*models.StreakFreezePolicy, error)
+	// GetFreezePolicy mengambil harga streak freeze anak.
+	GetFreezePolicy(ctx context.Context, parentID int, childID int) (*models.StreakFreezePolicy, error)
+	// DeleteFreezePolicy menghapus harga streak freeze anak; freeze yang sudah dibeli tetap bisa dipakai.
+	DeleteFreezePolicy(ctx context.Context, parentID int, childID int) error
+
+	// GetMyStreaks merangkum streak anak sendiri.
+	GetMyStreaks(ctx context.Context, childID int) (*models.StreakSummary, error)
+	// BuyStreakFreeze membeli satu streak freeze sesuai harga yang diatur parent (entri ledger 'streak_freeze').
+	BuyStreakFreeze(ctx context.Context, childID int) (*models.StreakFreeze, error)
+
+	// ProcessStreakFreezes memakai streak freeze untuk hari kemarin yang terlewat pada streak yang masih berjalan
+	// (dipanggil oleh worker). Mengembalikan jumlah freeze yang dipakai.
+	ProcessStreakFreezes(ctx context.Context) (int, error)
+}
+
+// ====================================================================================
+// Level Service
+// ====================================================================================
+
+// LevelService: Kontrak untuk XP dan level anak. XP terpisah dari poin (tidak bisa dibelanjakan) sehingga progres
+// anak tidak turun saat poin ditukar hadiah. XP sendiri dicatat oleh TaskService saat tugas disetujui.
+type LevelService interface {
+	// GetMyLevel merangkum level, tangga level beserta perknya, dan riwayat level-up anak sendiri.
+	GetMyLevel(ctx context.Context, childID int) (*models.LevelOverview, error)
+	// GetChildLevel merangkum level anak (Parent).
+	GetChildLevel(ctx context.Context, parentID int, childID int) (*models.LevelOverview, error)
+	// GetLevelProgress menghitung level & progres seorang anak.
+	GetLevelProgress(ctx context.Context, childID int) (*models.LevelProgress, error)
+	// GetLevelProgressForUsers menghitung level & progres beberapa anak sekaligus (misal: daftar anak milik parent).
+	GetLevelProgressForUsers(ctx context.Context, childIDs []int) (map[int]models.LevelProgress, error)
+}
+
 // ====================================================================================
 // (Optional) Point Service
 // ====================================================================================
@@ -0,0 +1,143 @@
+// internal/service/statement_service_impl.go
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// pointsCurrencyName adalah nama mata uang bawaan (currency_id 0) pada laporan rekening.
+const pointsCurrencyName = "points"
+
+// statementForbiddenMessage dipakai saat parent meminta laporan poin anak yang bukan anaknya.
+const statementForbiddenMessage = "you are not authorized to view statements for this child"
+
+type statementServiceImpl struct {
+	statementRepo repository.StatementRepository
+	currencyRepo  repository.CurrencyRepository
+	userRepo      repository.UserRepository // Username & zona waktu anak
+	userRelRepo   repository.UserRelationshipRepository
+}
+
+// NewStatementService creates a new instance of StatementService.
+func NewStatementService(
+	statementRepo repository.StatementRepository,
+	currencyRepo repository.CurrencyRepository,
+	userRepo repository.UserRepository,
+	userRelRepo repository.UserRelationshipRepository,
+) StatementService {
+	return &statementServiceImpl{
+		statementRepo: statementRepo,
+		currencyRepo:  currencyRepo,
+		userRepo:      userRepo,
+		userRelRepo:   userRelRepo,
+	}
+}
+
+// --- Helper Functions ---
+
+// currencyName mengembalikan nama mata uang untuk laporan. Mata uang milik keluarga lain
+// diperlakukan seperti tidak ada (pgx.ErrNoRows).
+func (s *statementServiceImpl) currencyName(ctx context.Context, childID int, currencyID int) (string, error) {
+	if currencyID == 0 {
+		return pointsCurrencyName, nil
+	}
+	currency, err := s.currencyRepo.GetCurrencyByID(ctx, currencyID)
+	if err != nil {
+		return "", err
+	}
+	isParent, err := s.userRelRepo.IsParentOf(ctx, currency.CreatedByUserID, childID)
+	if err != nil {
+		zlog.Error().Err(err).Int("child_id", childID).Int("currency_id", currencyID).Msg("Service: Error checking currency owner")
+		return "", fmt.Errorf("internal server error: could not verify relationship")
+	}
+	if !isParent {
+		return "", pgx.ErrNoRows
+	}
+	return currency.Name, nil
+}
+
+// addTypeTotal menambahkan amount ke total jenis transaksi txType, mempertahankan urutan kemunculan.
+func addTypeTotal(totals []models.StatementTypeTotal, txType models.TransactionType, amount int) []models.StatementTypeTotal {
+	for i := range totals {
+		if totals[i].TransactionType == txType {
+			totals[i].Amount += amount
+			totals[i].Count++
+			return totals
+		}
+	}
+	return append(totals, models.StatementTypeTotal{TransactionType: txType, Amount: amount, Count: 1})
+}
+
+// --- Public Methods ---
+
+// GetStatement menyusun laporan rekening anak dari ledger: saldo awal adalah jumlah semua transaksi
+// sebelum awal bulan, lalu setiap baris menambah saldo berjalan hingga saldo akhir.
+func (s *statementServiceImpl) GetStatement(ctx context.Context, childID int, month string, currencyID int) (*models.PointStatement, error) {
+	child, err := s.userRepo.GetUserByID(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+	currencyName, err := s.currencyName(ctx, childID, currencyID)
+	if err != nil {
+		return nil, err
+	}
+	loc := models.LoadTimezone(child.Timezone)
+	start, end, err := models.StatementPeriod(month, loc, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	opening, err := s.statementRepo.GetBalanceBefore(ctx, childID, currencyID, start)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := s.statementRepo.GetStatementLines(ctx, childID, currencyID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &models.PointStatement{
+		ChildID:        childID,
+		ChildUsername:  child.Username,
+		Month:          start.Format(models.StatementMonthLayout),
+		PeriodStart:    start,
+		PeriodEnd:      end,
+		Timezone:       loc.String(),
+		CurrencyID:     currencyID,
+		CurrencyName:   currencyName,
+		OpeningBalance: opening,
+		Credits:        []models.StatementTypeTotal{},
+		Debits:         []models.StatementTypeTotal{},
+		Lines:          lines,
+	}
+	balance := opening
+	for i := range statement.Lines {
+		line := &statement.Lines[i]
+		balance += line.ChangeAmount
+		line.Balance = balance
+		if line.ChangeAmount >= 0 {
+			statement.TotalCredits += line.ChangeAmount
+			statement.Credits = addTypeTotal(statement.Credits, line.TransactionType, line.ChangeAmount)
+		} else {
+			statement.TotalDebits -= line.ChangeAmount
+			statement.Debits = addTypeTotal(statement.Debits, line.TransactionType, -line.ChangeAmount)
+		}
+	}
+	statement.ClosingBalance = balance
+	return statement, nil
+}
+
+// GetChildStatement menyusun laporan rekening anak untuk orang tuanya.
+func (s *statementServiceImpl) GetChildStatement(ctx context.Context, parentID int, childID int, month string, currencyID int) (*models.PointStatement, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, statementForbiddenMessage); err != nil {
+		return nil, err
+	}
+	return s.GetStatement(ctx, childID, month, currencyID)
+}
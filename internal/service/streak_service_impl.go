@@ -0,0 +1,396 @@
+// internal/service/streak_service_impl.go
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// streakFreezeBatchSize membatasi jumlah anak yang diambil worker streak freeze per query.
+const streakFreezeBatchSize = 100
+
+// streakFreezeLookbackDays adalah riwayat yang dibaca worker untuk memastikan streak sebelum hari yang terlewat
+// dimulai dari aktivitas nyata (lebih panjang dari rangkaian freeze terpanjang yang mungkin).
+const streakFreezeLookbackDays = 14
+
+// streakForbiddenMessage dipakai saat parent mengelola streak anak yang bukan anaknya.
+const streakForbiddenMessage = "you are not authorized to manage streaks for this child"
+
+type streakServiceImpl struct {
+	pool             *pgxpool.Pool // Untuk transaksi pembelian streak freeze
+	streakRepo       repository.StreakRepository
+	pointRepo        repository.PointTransactionRepository
+	goalRepo         repository.SavingsGoalRepository // Poin yang disisihkan untuk target tabungan tidak bisa dipakai membeli freeze
+	taskRepo         repository.TaskRepository        // Validasi kepemilikan tugas pada aturan per tugas
+	userRepo         repository.UserRepository        // Zona waktu anak untuk batas hari
+	userRelRepo      repository.UserRelationshipRepository
+	notificationRepo repository.NotificationRepository
+}
+
+// NewStreakService creates a new instance of StreakService.
+func NewStreakService(
+	pool *pgxpool.Pool,
+	streakRepo repository.StreakRepository,
+	pointRepo repository.PointTransactionRepository,
+	goalRepo repository.SavingsGoalRepository,
+	taskRepo repository.TaskRepository,
+	userRepo repository.UserRepository,
+	userRelRepo repository.UserRelationshipRepository,
+	notificationRepo repository.NotificationRepository,
+) StreakService {
+	return &streakServiceImpl{
+		pool:             pool,
+		streakRepo:       streakRepo,
+		pointRepo:        pointRepo,
+		goalRepo:         goalRepo,
+		taskRepo:         taskRepo,
+		userRepo:         userRepo,
+		userRelRepo:      userRelRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+// --- Helper Functions ---
+
+// getOwnedBonusRule mengambil aturan bonus streak dan memastikan aturan tersebut milik parentID.
+func (s *streakServiceImpl) getOwnedBonusRule(ctx context.Context, parentID int, ruleID int) (*models.StreakBonusRule, error) {
+	rule, err := s.streakRepo.GetBonusRuleByID(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if rule.CreatedByUserID != parentID {
+		return nil, fmt.Errorf("forbidden: you can only manage streak bonus rules you created")
+	}
+	return rule, nil
+}
+
+// newBonusRuleFromInput memvalidasi input aturan dan membangun aturan milik parentID.
+func (s *streakServiceImpl) newBonusRuleFromInput(ctx context.Context, parentID int, input *models.StreakBonusRuleInput) (*models.StreakBonusRule, error) {
+	if input.TaskID != 0 {
+		task, err := s.taskRepo.GetTaskByID(ctx, input.TaskID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("invalid task_id: task %d not found", input.TaskID)
+			}
+			return nil, fmt.Errorf("internal server error: could not retrieve task")
+		}
+		if task.CreatedByUserID != parentID {
+			return nil, fmt.Errorf("forbidden: streak task must be created by you")
+		}
+	}
+	return &models.StreakBonusRule{
+		CreatedByUserID: parentID,
+		Name:            input.Name,
+		TaskID:          input.TaskID,
+		StreakDays:      input.StreakDays,
+		BonusPoints:     input.BonusPoints,
+		IsActive:        input.IsActive == nil || *input.IsActive,
+	}, nil
+}
+
+// localDays mengubah waktu penyelesaian menjadi tanggal lokal di zona waktu loc.
+func localDays(times []time.Time, loc *time.Location) []time.Time {
+	days := make([]time.Time, 0, len(times))
+	for _, t := range times {
+		days = append(days, models.LocalDate(t, loc))
+	}
+	return days
+}
+
+// --- Parent: Bonus Rules ---
+
+// CreateBonusRule membuat aturan bonus streak milik parent (opsional untuk satu tugas).
+func (s *streakServiceImpl) CreateBonusRule(ctx context.Context, parentID int, input *models.StreakBonusRuleInput) (*models.StreakBonusRule, error) {
+	rule, err := s.newBonusRuleFromInput(ctx, parentID, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.streakRepo.CreateBonusRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	// Ambil ulang agar nama tugas (join) ikut terisi
+	return s.streakRepo.GetBonusRuleByID(ctx, rule.ID)
+}
+
+// GetBonusRules mengambil semua aturan bonus streak milik parent.
+func (s *streakServiceImpl) GetBonusRules(ctx context.Context, parentID int) ([]models.StreakBonusRule, error) {
+	return s.streakRepo.GetBonusRulesByOwnerID(ctx, parentID)
+}
+
+// UpdateBonusRule memperbarui aturan bonus streak milik parent.
+func (s *streakServiceImpl) UpdateBonusRule(ctx context.Context, parentID int, ruleID int, input *models.StreakBonusRuleInput) (*models.StreakBonusRule, error) {
+	if _, err := s.getOwnedBonusRule(ctx, parentID, ruleID); err != nil {
+		return nil, err
+	}
+	rule, err := s.newBonusRuleFromInput(ctx, parentID, input)
+	if err != nil {
+		return nil, err
+	}
+	rule.ID = ruleID
+	if err := s.streakRepo.UpdateBonusRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	return s.streakRepo.GetBonusRuleByID(ctx, ruleID)
+}
+
+// DeleteBonusRule menghapus aturan bonus streak milik parent.
+func (s *streakServiceImpl) DeleteBonusRule(ctx context.Context, parentID int, ruleID int) error {
+	if _, err := s.getOwnedBonusRule(ctx, parentID, ruleID); err != nil {
+		return err
+	}
+	return s.streakRepo.DeleteBonusRule(ctx, ruleID, parentID)
+}
+
+// --- Streak Summary ---
+
+// GetChildStreaks mengambil ringkasan streak anak untuk orang tuanya.
+func (s *streakServiceImpl) GetChildStreaks(ctx context.Context, parentID int, childID int) (*models.StreakSummary, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, streakForbiddenMessage); err != nil {
+		return nil, err
+	}
+	return s.buildSummary(ctx, childID)
+}
+
+// GetMyStreaks mengambil ringkasan streak milik anak yang sedang login.
+func (s *streakServiceImpl) GetMyStreaks(ctx context.Context, childID int) (*models.StreakSummary, error) {
+	return s.buildSummary(ctx, childID)
+}
+
+// buildSummary menghitung streak keseluruhan dan per tugas anak per hari ini di zona waktu anak.
+func (s *streakServiceImpl) buildSummary(ctx context.Context, childID int) (*models.StreakSummary, error) {
+	child, err := s.userRepo.GetUserByID(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+	loc := models.LoadTimezone(child.Timezone)
+	today := models.LocalDate(time.Now(), loc)
+	since := today.AddDate(0, 0, -models.StreakLookbackDays)
+
+	completions, err := s.streakRepo.GetApprovedCompletions(ctx, childID, models.LocalMidnight(since, loc))
+	if err != nil {
+		return nil, err
+	}
+	frozen, err := s.streakRepo.GetFrozenDays(ctx, childID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	allDays := make([]time.Time, 0, len(completions))
+	taskDays := map[int][]time.Time{}
+	taskNames := map[int]string{}
+	for _, completion := range completions {
+		day := models.LocalDate(completion.CompletedAt, loc)
+		allDays = append(allDays, day)
+		taskDays[completion.TaskID] = append(taskDays[completion.TaskID], day)
+		taskNames[completion.TaskID] = completion.TaskName
+	}
+
+	summary := &models.StreakSummary{
+		ChildID:     childID,
+		Timezone:    loc.String(),
+		Date:        today.Format(time.DateOnly),
+		ActiveToday: slices.Contains(allDays, today) || slices.Contains(frozen, today),
+		TaskStreaks: []models.TaskStreak{},
+	}
+	summary.CurrentStreak, _ = models.StreakRunWithFreezes(allDays, frozen, today)
+	for taskID, days := range taskDays {
+		if current, _ := models.StreakRunWithFreezes(days, frozen, today); current > 0 {
+			summary.TaskStreaks = append(summary.TaskStreaks, models.TaskStreak{TaskID: taskID, TaskName: taskNames[taskID], Current: current})
+		}
+	}
+	slices.SortFunc(summary.TaskStreaks, func(a, b models.TaskStreak) int {
+		return cmp.Or(cmp.Compare(b.Current, a.Current), cmp.Compare(a.TaskName, b.TaskName), cmp.Compare(a.TaskID, b.TaskID))
+	})
+
+	if summary.FreezesAvailable, err = s.streakRepo.CountUnusedFreezes(ctx, childID); err != nil {
+		return nil, err
+	}
+	policy, err := s.streakRepo.GetFreezePolicyByChildID(ctx, childID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	summary.FreezePolicy = policy
+	return summary, nil
+}
+
+// --- Streak Freeze ---
+
+// SetFreezePolicy membuat atau memperbarui harga dan batas kepemilikan streak freeze anak.
+func (s *streakServiceImpl) SetFreezePolicy(ctx context.Context, parentID int, childID int, input *models.SetStreakFreezePolicyInput) (*models.StreakFreezePolicy, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, streakForbiddenMessage); err != nil {
+		return nil, err
+	}
+	policy := &models.StreakFreezePolicy{
+		ChildID:         childID,
+		PricePoints:     input.PricePoints,
+		MaxOwned:        input.MaxOwned,
+		UpdatedByUserID: parentID,
+	}
+	if err := s.streakRepo.UpsertFreezePolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// GetFreezePolicy mengambil kebijakan streak freeze anak.
+func (s *streakServiceImpl) GetFreezePolicy(ctx context.Context, parentID int, childID int) (*models.StreakFreezePolicy, error) {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, streakForbiddenMessage); err != nil {
+		return nil, err
+	}
+	return s.streakRepo.GetFreezePolicyByChildID(ctx, childID)
+}
+
+// DeleteFreezePolicy menghapus kebijakan streak freeze anak; anak tidak bisa membeli freeze lagi.
+func (s *streakServiceImpl) DeleteFreezePolicy(ctx context.Context, parentID int, childID int) error {
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, streakForbiddenMessage); err != nil {
+		return err
+	}
+	return s.streakRepo.DeleteFreezePolicy(ctx, childID)
+}
+
+// BuyStreakFreeze membeli satu streak freeze dengan poin bebas anak (entri 'streak_freeze').
+func (s *streakServiceImpl) BuyStreakFreeze(ctx context.Context, childID int) (*models.StreakFreeze, error) {
+	policy, err := s.streakRepo.GetFreezePolicyByChildID(ctx, childID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("forbidden: streak freezes are not enabled for this child")
+		}
+		return nil, fmt.Errorf("internal server error: could not retrieve streak freeze policy")
+	}
+
+	freeze := &models.StreakFreeze{ChildID: childID, PricePoints: policy.PricePoints}
+	err = withTx(ctx, s.pool, "BuyStreakFreeze", func(tx pgx.Tx) error {
+		// Mengunci baris saldo anak sehingga pembelian paralel (dan cek jumlah freeze) berurutan
+		balance, err := s.pointRepo.CalculateTotalPointsByUserIDTx(ctx, tx, childID)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not retrieve points balance")
+		}
+		owned, err := s.streakRepo.CountUnusedFreezesTx(ctx, tx, childID)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not count streak freezes")
+		}
+		if owned >= policy.MaxOwned {
+			return fmt.Errorf("cannot buy streak freeze: you already have %d unused streak freeze(s)", owned)
+		}
+		earmarked, err := s.goalRepo.SumEarmarkedPointsTx(ctx, tx, childID, 0)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not retrieve earmarked points")
+		}
+		if free := max(balance-earmarked, 0); free < policy.PricePoints {
+			return fmt.Errorf("%w: a streak freeze costs %d points, only %d are available", ErrInsufficientPoints, policy.PricePoints, free)
+		}
+
+		debit := &models.PointTransaction{
+			UserID:          childID,
+			ChangeAmount:    -policy.PricePoints,
+			TransactionType: models.TransactionTypeStreakFreeze,
+			CreatedByUserID: childID,
+			Notes:           "Streak freeze purchase",
+		}
+		if err := s.pointRepo.CreateTransactionTx(ctx, tx, debit); err != nil {
+			if errors.Is(err, repository.ErrNegativeBalance) {
+				return ErrInsufficientPoints
+			}
+			return fmt.Errorf("internal server error: could not record streak freeze purchase")
+		}
+		freeze.TransactionID = debit.ID
+		if err := s.streakRepo.CreateFreezeTx(ctx, tx, freeze); err != nil {
+			return fmt.Errorf("internal server error: could not create streak freeze")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zlog.Info().Int("freeze_id", freeze.ID).Int("child_id", childID).Int("price_points", freeze.PricePoints).Msg("Service: Streak freeze purchased")
+	return freeze, nil
+}
+
+// --- Worker ---
+
+// ProcessStreakFreezes memakai streak freeze anak untuk hari yang terlewat (dipanggil oleh worker).
+func (s *streakServiceImpl) ProcessStreakFreezes(ctx context.Context) (int, error) {
+	used, afterID := 0, 0
+	for {
+		childIDs, err := s.streakRepo.GetChildIDsWithUnusedFreezes(ctx, afterID, streakFreezeBatchSize)
+		if err != nil {
+			return used, err
+		}
+		for _, childID := range childIDs {
+			ok, err := s.protectMissedDay(ctx, childID)
+			if err != nil {
+				zlog.Error().Err(err).Int("child_id", childID).Msg("Service: Failed to apply streak freeze")
+				continue
+			}
+			if ok {
+				used++
+			}
+		}
+		if len(childIDs) < streakFreezeBatchSize {
+			return used, nil
+		}
+		afterID = childIDs[len(childIDs)-1]
+	}
+}
+
+// protectMissedDay memakai satu streak freeze untuk hari kemarin (zona waktu anak) jika kemarin terlewat
+// sementara streak masih berjalan sampai kemarin lusa. Mengembalikan true jika freeze dipakai.
+func (s *streakServiceImpl) protectMissedDay(ctx context.Context, childID int) (bool, error) {
+	child, err := s.userRepo.GetUserByID(ctx, childID)
+	if err != nil {
+		return false, err
+	}
+	loc := models.LoadTimezone(child.Timezone)
+	today := models.LocalDate(time.Now(), loc)
+	yesterday, dayBefore := today.AddDate(0, 0, -1), today.AddDate(0, 0, -2)
+	since := today.AddDate(0, 0, -streakFreezeLookbackDays)
+
+	completions, err := s.streakRepo.GetApprovedCompletions(ctx, childID, models.LocalMidnight(since, loc))
+	if err != nil {
+		return false, err
+	}
+	frozen, err := s.streakRepo.GetFrozenDays(ctx, childID, since)
+	if err != nil {
+		return false, err
+	}
+	days := make([]time.Time, 0, len(completions))
+	for _, completion := range completions {
+		days = append(days, models.LocalDate(completion.CompletedAt, loc))
+	}
+	if slices.Contains(days, yesterday) || slices.Contains(frozen, yesterday) {
+		return false, nil
+	}
+	streak, _ := models.StreakRunWithFreezes(days, frozen, dayBefore)
+	if streak == 0 || !(slices.Contains(days, dayBefore) || slices.Contains(frozen, dayBefore)) {
+		return false, nil // Tidak ada streak yang perlu diselamatkan
+	}
+
+	freezeID, err := s.streakRepo.UseFreeze(ctx, childID, yesterday)
+	if err != nil || freezeID == 0 {
+		return false, err
+	}
+	err = s.notificationRepo.CreateNotification(ctx, &models.Notification{
+		UserID:     childID,
+		Type:       models.NotificationStreakFreezeUsed,
+		Title:      "Streak freeze used",
+		Message:    fmt.Sprintf("You missed %s, so a streak freeze kept your %d-day streak going.", yesterday.Format(time.DateOnly), streak+1),
+		EntityType: "streak_freeze",
+		EntityID:   freezeID,
+	})
+	if err != nil {
+		// Freeze sudah dipakai; notifikasi yang gagal cukup dicatat
+		zlog.Warn().Err(err).Int("child_id", childID).Int("freeze_id", freezeID).Msg("Service: Failed to notify streak freeze usage")
+	}
+	return true, nil
+}
@@ -5,6 +5,9 @@ import (
 	"context"
 	"errors" // Import errors
 	"fmt"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/jackc/pgx/v5" // Import pgx for ErrNoRows etc.
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -13,13 +16,45 @@ import (
 	zlog "github.com/rs/zerolog/log"
 )
 
+// ErrAutoApprovalNotDue dikembalikan saat sistem mencoba menyetujui tugas yang tidak memiliki
+// kebijakan auto-approval aktif, atau masa tenggangnya belum lewat.
+var ErrAutoApprovalNotDue = errors.New("cannot auto-approve task: no auto-approval policy is due")
+
+// autoApprovalBatchSize membatasi jumlah submission yang diproses worker dalam satu putaran.
+const autoApprovalBatchSize = 100
+
+// taskExpiryBatchSize membatasi jumlah penugasan kedaluwarsa yang diproses worker dalam satu putaran.
+const taskExpiryBatchSize = 100
+
+// defaultRevertWindow adalah batas waktu default untuk membatalkan verifikasi tugas.
+const defaultRevertWindow = 24 * time.Hour
+
+// revertWindowFromEnv membaca batas waktu revert verifikasi (dalam jam) dari TASK_REVERT_WINDOW_HOURS.
+// Mengembalikan nilai default jika variabel tidak diset atau tidak valid.
+func revertWindowFromEnv() time.Duration {
+	hours, err := strconv.Atoi(os.Getenv("TASK_REVERT_WINDOW_HOURS"))
+	if err != nil || hours <= 0 {
+		return defaultRevertWindow
+	}
+	return time.Duration(hours) * time.Hour
+}
+
 // taskServiceImpl implements the TaskService interface.
 type taskServiceImpl struct {
 	pool         *pgxpool.Pool // Pool dibutuhkan untuk memulai transaksi
 	userTaskRepo repository.UserTaskRepository
 	// taskRepo     repository.TaskRepository // Mungkin tidak perlu jika userTaskRepo.VerifyTaskTx sudah cukup
-	pointRepo   repository.PointTransactionRepository
-	userRelRepo repository.UserRelationshipRepository // Dibutuhkan untuk cek relasi
+	pointRepo    repository.PointTransactionRepository
+	userRelRepo  repository.UserRelationshipRepository   // Dibutuhkan untuk cek relasi
+	policyRepo   repository.AutoApprovalPolicyRepository // Kebijakan auto-approval (verifikasi oleh sistem)
+	auditRepo    repository.AuditLogRepository
+	penaltyRepo  repository.PenaltyRepository      // Sanksi yang ditebus saat tugas penebus disetujui
+	notifRepo    repository.NotificationRepository // Notifikasi penebusan sanksi & bonus streak
+	streakRepo   repository.StreakRepository       // Aturan & pemberian bonus streak saat tugas disetujui
+	userRepo     repository.UserRepository         // Zona waktu anak untuk perhitungan streak
+	levelRepo    repository.LevelRepository        // XP & level-up saat tugas disetujui
+	badgeService BadgeService                      // Evaluasi badge setelah tugas disetujui
+	revertWindow time.Duration                     // Batas waktu setelah verifikasi di mana parent masih boleh revert
 }
 
 // NewTaskService creates a new instance of TaskService.
@@ -28,13 +63,47 @@ func NewTaskService(
 	userTaskRepo repository.UserTaskRepository,
 	pointRepo repository.PointTransactionRepository,
 	userRelRepo repository.UserRelationshipRepository,
+	policyRepo repository.AutoApprovalPolicyRepository,
+	auditRepo repository.AuditLogRepository,
+	penaltyRepo repository.PenaltyRepository,
+	notifRepo repository.NotificationRepository,
+	streakRepo repository.StreakRepository,
+	userRepo repository.UserRepository,
+	levelRepo repository.LevelRepository,
+	badgeService BadgeService,
 ) TaskService {
 	return &taskServiceImpl{
 		pool:         pool,
 		userTaskRepo: userTaskRepo,
 		pointRepo:    pointRepo,
 		userRelRepo:  userRelRepo,
+		policyRepo:   policyRepo,
+		auditRepo:    auditRepo,
+		penaltyRepo:  penaltyRepo,
+		notifRepo:    notifRepo,
+		streakRepo:   streakRepo,
+		userRepo:     userRepo,
+		levelRepo:    levelRepo,
+		badgeService: badgeService,
+		revertWindow: revertWindowFromEnv(),
+	}
+}
+
+// newUserTaskEvent menyusun event transisi UserTask; actorID SystemActorID dicatat sebagai 'system'.
+func newUserTaskEvent(userTaskID int, action models.UserTaskAction, from, to models.UserTaskStatus, actorID int, reason string) *models.UserTaskEvent {
+	event := &models.UserTaskEvent{
+		UserTaskID:  userTaskID,
+		Action:      action,
+		FromStatus:  from,
+		ToStatus:    to,
+		ActorUserID: actorID,
+		ActorType:   models.AuditActorUser,
+		Reason:      reason,
+	}
+	if actorID == SystemActorID {
+		event.ActorType = models.AuditActorSystem
 	}
+	return event
 }
 
 // VerifyTask implements the business logic for verifying a task, including transaction management.
@@ -46,6 +115,14 @@ func (s *taskServiceImpl) VerifyTask(ctx context.Context, userTaskID int, parent
 		return fmt.Errorf("internal server error: could not start operation") // Error generik ke handler
 	}
 
+	// Evaluasi badge setelah commit (defer ini dijalankan setelah defer commit di bawah)
+	badgeChildID := 0
+	defer func() {
+		if err == nil {
+			evaluateBadgesAfterCommit(ctx, s.badgeService, badgeChildID)
+		}
+	}()
+
 	// --- 2. Defer untuk Rollback atau Commit ---
 	defer func() {
 		if p := recover(); p != nil {
@@ -100,32 +177,56 @@ func (s *taskServiceImpl) VerifyTask(ctx context.Context, userTaskID int, parent
 		return fmt.Errorf("internal server error: could not retrieve task details")
 	}
 
-	// 4b. Validasi Status Saat Ini
-	if taskDetails.CurrentStatus != models.UserTaskStatusSubmitted {
-		zlog.Warn().Int("user_task_id", userTaskID).Str("current_status", string(taskDetails.CurrentStatus)).Msg("Service: Verify task failed: Task not in 'submitted' status")
-		err = fmt.Errorf("cannot verify task: current status is '%s', expected 'submitted'", taskDetails.CurrentStatus)
-		return err // Rollback
-	}
-
-	// 4c. Validasi Relasi Parent-Child (gunakan UserRelationshipRepository)
-	// Asumsi metode repo baru `IsParentOfTx`
-	// Anda perlu membuat metode ini di user_relationship_repo.go
-	isParent, err := s.userRelRepo.IsParentOfTx(ctx, tx, parentID, taskDetails.ChildID)
+	// 4b. Validasi Transisi Status (aturan lifecycle terpusat di models)
+	var action models.UserTaskAction
+	action, err = models.VerificationAction(newStatus)
 	if err != nil {
-		zlog.Error().Err(err).Int("parent_id", parentID).Int("child_id", taskDetails.ChildID).Msg("Service: Error checking parent-child relationship during task verification")
-		err = fmt.Errorf("internal server error: could not verify relationship")
 		return err // Rollback
 	}
-	if !isParent {
-		zlog.Warn().Int("user_task_id", userTaskID).Int("parent_id", parentID).Int("child_id", taskDetails.ChildID).Msg("Service: Verify task failed: Requesting user is not the parent")
-		err = fmt.Errorf("forbidden: you are not authorized to verify tasks for this child")
+	if _, err = models.NextUserTaskStatus(taskDetails.CurrentStatus, action); err != nil {
+		zlog.Warn().Int("user_task_id", userTaskID).Str("current_status", string(taskDetails.CurrentStatus)).Msg("Service: Verify task failed: Task not in 'submitted' status")
 		return err // Rollback
 	}
 
-	// 4d. Update Status UserTask dalam Transaksi
-	// Asumsi metode repo baru `UpdateStatusTx`
-	// Anda perlu membuat metode ini di user_task_repo.go
-	err = s.userTaskRepo.UpdateStatusTx(ctx, tx, userTaskID, newStatus, parentID)
+	// 4c. Validasi Pelaku. Verifikasi oleh sistem (SystemActorID) hanya boleh menyetujui,
+	// dan hanya jika ada kebijakan auto-approval yang berlaku & sudah jatuh tempo.
+	var policy *models.AutoApprovalPolicy
+	if parentID == SystemActorID {
+		if newStatus != models.UserTaskStatusApproved {
+			err = fmt.Errorf("cannot auto-verify task: system can only approve submissions")
+			return err // Rollback
+		}
+		policy, err = s.policyRepo.GetDuePolicyForUserTaskTx(ctx, tx, userTaskID, time.Now())
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				err = ErrAutoApprovalNotDue
+				return err // Rollback
+			}
+			err = fmt.Errorf("internal server error: could not check auto-approval policy")
+			return err // Rollback
+		}
+	} else {
+		// Validasi Relasi Parent-Child (gunakan UserRelationshipRepository)
+		var isParent bool
+		isParent, err = s.userRelRepo.IsParentOfTx(ctx, tx, parentID, taskDetails.ChildID)
+		if err != nil {
+			zlog.Error().Err(err).Int("parent_id", parentID).Int("child_id", taskDetails.ChildID).Msg("Service: Error checking parent-child relationship during task verification")
+			err = fmt.Errorf("internal server error: could not verify relationship")
+			return err // Rollback
+		}
+		if !isParent {
+			zlog.Warn().Int("user_task_id", userTaskID).Int("parent_id", parentID).Int("child_id", taskDetails.ChildID).Msg("Service: Verify task failed: Requesting user is not the parent")
+			err = fmt.Errorf("forbidden: you are not authorized to verify tasks for this child")
+			return err // Rollback
+		}
+	}
+
+	// 4d. Update Status UserTask & catat event timeline dalam Transaksi
+	event := newUserTaskEvent(userTaskID, action, taskDetails.CurrentStatus, newStatus, parentID, "")
+	if policy != nil {
+		event.Reason = fmt.Sprintf("Auto-approved by system (auto-approval policy #%d)", policy.ID)
+	}
+	err = s.userTaskRepo.ApplyTransitionTx(ctx, tx, event)
 	if err != nil {
 		zlog.Error().Err(err).Int("user_task_id", userTaskID).Str("new_status", string(newStatus)).Msg("Service: Failed to update task status within transaction")
 		err = fmt.Errorf("internal server error: could not update task status")
@@ -140,7 +241,11 @@ func (s *taskServiceImpl) VerifyTask(ctx context.Context, userTaskID int, parent
 				ChangeAmount:      taskDetails.TaskPoint,
 				TransactionType:   models.TransactionTypeCompletion,
 				RelatedUserTaskID: userTaskID,
-				CreatedByUserID:   parentID,
+				CreatedByUserID:   parentID, // SystemActorID disimpan sebagai NULL
+				CurrencyID:        taskDetails.CurrencyID,
+			}
+			if policy != nil {
+				pointTx.Notes = fmt.Sprintf("Auto-approved by system (auto-approval policy #%d)", policy.ID)
 			}
 			// Asumsi metode repo baru `CreateTransactionTx`
 			// Anda perlu membuat metode ini di point_transaction_repo.go (buat file ini)
@@ -154,10 +259,527 @@ func (s *taskServiceImpl) VerifyTask(ctx context.Context, userTaskID int, parent
 		} else {
 			zlog.Info().Int("user_task_id", userTaskID).Msg("Service: Task approved, but no points awarded (TaskPoint <= 0)")
 		}
+		if err = s.earnBackPenaltyTx(ctx, tx, userTaskID, taskDetails.ChildID, parentID); err != nil {
+			return err // Rollback
+		}
+		if err = s.awardStreakBonusesTx(ctx, tx, userTaskID, taskDetails.ChildID, taskDetails.TaskID); err != nil {
+			return err // Rollback
+		}
+		if err = s.awardTaskXPTx(ctx, tx, userTaskID, taskDetails.ChildID, taskDetails.TaskPoint); err != nil {
+			return err // Rollback
+		}
+		badgeChildID = taskDetails.ChildID
+	}
+
+	// 4f. Catat jejak audit (ikut di-rollback jika transaksi gagal)
+	details := map[string]any{"child_id": taskDetails.ChildID, "points": taskDetails.TaskPoint, "auto_approved": policy != nil}
+	if policy != nil {
+		details["policy_id"] = policy.ID
+		details["grace_period_minutes"] = policy.GracePeriodMinutes
+	}
+	err = recordAuditTx(ctx, tx, s.auditRepo, parentID, "user_task."+string(newStatus), "user_task", userTaskID, details)
+	if err != nil {
+		err = fmt.Errorf("internal server error: could not record audit log")
+		return err // Rollback
 	}
 
 	// Jika semua berhasil, err akan nil, dan defer akan Commit
 	return nil // Sukses
 }
 
+// earnBackPenaltyTx mengembalikan poin sanksi jika userTaskID adalah tugas penebus sanksi yang belum ditebus.
+// Entri 'penalty_reversal' membalik entri 'penalty' aslinya; sanksi tanpa potongan hanya ditandai sudah ditebus.
+func (s *taskServiceImpl) earnBackPenaltyTx(ctx context.Context, tx pgx.Tx, userTaskID int, childID int, actorID int) error {
+	penalty, err := s.penaltyRepo.GetPenaltyByEarnBackUserTaskIDForUpdateTx(ctx, tx, userTaskID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil // Bukan tugas penebus
+		}
+		return fmt.Errorf("internal server error: could not retrieve penalty")
+	}
+	if penalty.EarnedBackAt != nil {
+		return nil
+	}
+
+	reversalID := 0
+	if penalty.PointsDeducted > 0 && penalty.TransactionID != 0 {
+		reversal := &models.PointTransaction{
+			UserID:                childID,
+			ChangeAmount:          penalty.PointsDeducted,
+			TransactionType:       models.TransactionTypePenaltyReversal,
+			RelatedUserTaskID:     userTaskID,
+			ReversesTransactionID: penalty.TransactionID,
+			CreatedByUserID:       actorID, // SystemActorID disimpan sebagai NULL
+			Notes:                 fmt.Sprintf("Earned back penalty: %s", penalty.InfractionName),
+		}
+		if err := s.pointRepo.CreateTransactionTx(ctx, tx, reversal); err != nil {
+			return fmt.Errorf("internal server error: could not record points")
+		}
+		reversalID = reversal.ID
+	}
+	if err := s.penaltyRepo.MarkEarnedBackTx(ctx, tx, penalty.ID, reversalID); err != nil {
+		return fmt.Errorf("internal server error: could not update penalty")
+	}
+	err = s.notifRepo.CreateNotificationTx(ctx, tx, &models.Notification{
+		UserID:     childID,
+		Type:       models.NotificationPenaltyEarnedBack,
+		Title:      "Penalty earned back",
+		Message:    fmt.Sprintf("You earned back %d points for '%s'.", penalty.PointsDeducted, penalty.InfractionName),
+		EntityType: "penalty",
+		EntityID:   penalty.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("internal server error: could not send notification")
+	}
+	zlog.Info().Int("penalty_id", penalty.ID).Int("user_task_id", userTaskID).Int("points", penalty.PointsDeducted).Msg("Service: Penalty earned back")
+	return nil
+}
+
+// awardStreakBonusesTx memberikan bonus streak (entri 'streak_bonus') untuk aturan milik orang tua anak yang
+// terpenuhi setelah userTaskID disetujui. Setiap aturan memberi bonus sekali per streak; streak dihitung
+// di zona waktu anak dan hari yang dilindungi streak freeze ikut menyambung streak.
+func (s *taskServiceImpl) awardStreakBonusesTx(ctx context.Context, tx pgx.Tx, userTaskID int, childID int, taskID int) error {
+	rules, err := s.streakRepo.GetActiveBonusRulesForChildTx(ctx, tx, childID, taskID)
+	if err != nil {
+		return fmt.Errorf("internal server error: could not retrieve streak bonus rules")
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	child, err := s.userRepo.GetUserByIDTx(ctx, tx, childID)
+	if err != nil {
+		return fmt.Errorf("internal server error: could not retrieve child")
+	}
+	loc := models.LoadTimezone(child.Timezone)
+	today := models.LocalDate(time.Now(), loc)
+	since := today.AddDate(0, 0, -models.StreakLookbackDays)
+	frozen, err := s.streakRepo.GetFrozenDaysTx(ctx, tx, childID, since)
+	if err != nil {
+		return fmt.Errorf("internal server error: could not retrieve streak freezes")
+	}
+
+	type streakRun struct {
+		length int
+		start  time.Time
+	}
+	runs := map[int]streakRun{} // taskID aturan (0 = semua tugas) -> streak saat ini
+	for _, rule := range rules {
+		run, ok := runs[rule.TaskID]
+		if !ok {
+			times, err := s.streakRepo.GetApprovedCompletionTimesTx(ctx, tx, childID, rule.TaskID, models.LocalMidnight(since, loc))
+			if err != nil {
+				return fmt.Errorf("internal server error: could not retrieve task completions")
+			}
+			run.length, run.start = models.StreakRunWithFreezes(localDays(times, loc), frozen, today)
+			runs[rule.TaskID] = run
+		}
+		// Streak sepanjang seluruh riwayat yang dibaca sudah melewati batas streak_days sejak lama
+		if run.length < rule.StreakDays || run.length >= models.StreakLookbackDays {
+			continue
+		}
+		awarded, err := s.streakRepo.HasBonusAwardSinceTx(ctx, tx, rule.ID, childID, run.start)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not check streak bonuses")
+		}
+		if awarded {
+			continue
+		}
+
+		credit := &models.PointTransaction{
+			UserID:            childID,
+			ChangeAmount:      rule.BonusPoints,
+			TransactionType:   models.TransactionTypeStreakBonus,
+			RelatedUserTaskID: userTaskID,
+			CreatedByUserID:   rule.CreatedByUserID,
+			Notes:             fmt.Sprintf("Streak bonus: %s (%d days in a row)", rule.Name, run.length),
+		}
+		if err := s.pointRepo.CreateTransactionTx(ctx, tx, credit); err != nil {
+			return fmt.Errorf("internal server error: could not record streak bonus")
+		}
+		award := &models.StreakBonusAward{
+			RuleID:          rule.ID,
+			ChildID:         childID,
+			StreakStartDate: run.start,
+			StreakDays:      run.length,
+			BonusPoints:     rule.BonusPoints,
+			UserTaskID:      userTaskID,
+			TransactionID:   credit.ID,
+		}
+		if err := s.streakRepo.CreateBonusAwardTx(ctx, tx, award); err != nil {
+			return fmt.Errorf("internal server error: could not record streak bonus")
+		}
+		err = s.notifRepo.CreateNotificationTx(ctx, tx, &models.Notification{
+			UserID:     childID,
+			Type:       models.NotificationStreakBonusAwarded,
+			Title:      "Streak bonus",
+			Message:    fmt.Sprintf("%d days in a row! You earned %d bonus points for '%s'.", run.length, rule.BonusPoints, rule.Name),
+			EntityType: "streak_bonus_award",
+			EntityID:   award.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("internal server error: could not send notification")
+		}
+		zlog.Info().Int("rule_id", rule.ID).Int("child_id", childID).Int("streak_days", run.length).
+			Int("bonus_points", rule.BonusPoints).Msg("Service: Streak bonus awarded")
+	}
+	return nil
+}
+
+// awardTaskXPTx menambah XP anak untuk tugas yang disetujui dan mencatat level yang baru dicapai.
+// Setiap level dicatat sekali; notifikasi level-up dikirim untuk level tertinggi yang baru dicapai.
+func (s *taskServiceImpl) awardTaskXPTx(ctx context.Context, tx pgx.Tx, userTaskID int, childID int, taskPoint int) error {
+	xp := &models.XPTransaction{
+		UserID:            childID,
+		ChangeAmount:      models.TaskXP(taskPoint),
+		Source:            models.XPSourceTaskCompletion,
+		RelatedUserTaskID: userTaskID,
+	}
+	totalXP, err := s.levelRepo.AddXPTx(ctx, tx, xp)
+	if err != nil {
+		return fmt.Errorf("internal server error: could not record XP")
+	}
+	levels, err := s.levelRepo.GetLevelsTx(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("internal server error: could not retrieve levels")
+	}
+	previousLevel := models.NewLevelProgress(levels, totalXP-xp.ChangeAmount).Level
+	current := models.NewLevelProgress(levels, totalXP)
+	if current.Level <= previousLevel {
+		return nil
+	}
+
+	var reached *models.LevelUp
+	for _, level := range levels {
+		if level.Level <= previousLevel || level.Level > current.Level {
+			continue
+		}
+		levelUp := &models.LevelUp{UserID: childID, Level: level.Level, Title: level.Title, TotalXP: totalXP, UserTaskID: userTaskID}
+		created, err := s.levelRepo.CreateLevelUpTx(ctx, tx, levelUp)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not record level-up")
+		}
+		if created {
+			reached = levelUp // Level sudah pernah dicapai sebelum revert tidak dinotifikasi lagi
+		}
+	}
+	if reached == nil {
+		return nil
+	}
+	err = s.notifRepo.CreateNotificationTx(ctx, tx, &models.Notification{
+		UserID:     childID,
+		Type:       models.NotificationLevelUp,
+		Title:      "Level up!",
+		Message:    fmt.Sprintf("You reached level %d (%s)! New rewards may be unlocked.", reached.Level, reached.Title),
+		EntityType: "level_up",
+		EntityID:   reached.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("internal server error: could not send notification")
+	}
+	zlog.Info().Int("child_id", childID).Int("level", reached.Level).Int("total_xp", totalXP).Msg("Service: Child leveled up")
+	return nil
+}
+
+// AutoApproveTask menjalankan VerifyTask sebagai sistem untuk satu submission.
+// Mengembalikan false (tanpa error) jika belum ada kebijakan auto-approval yang jatuh tempo.
+func (s *taskServiceImpl) AutoApproveTask(ctx context.Context, userTaskID int) (bool, error) {
+	err := s.VerifyTask(ctx, userTaskID, SystemActorID, models.UserTaskStatusApproved)
+	if err != nil {
+		if errors.Is(err, ErrAutoApprovalNotDue) {
+			return false, nil
+		}
+		return false, err
+	}
+	zlog.Info().Int("user_task_id", userTaskID).Msg("Service: Task auto-approved by system")
+	return true, nil
+}
+
+// ProcessDueAutoApprovals menyetujui semua submission yang masa tenggang kebijakannya sudah lewat.
+// Dipanggil oleh worker background; kegagalan satu tugas tidak menghentikan yang lain.
+func (s *taskServiceImpl) ProcessDueAutoApprovals(ctx context.Context, now time.Time) (int, error) {
+	ids, err := s.policyRepo.GetDueUserTaskIDs(ctx, now, autoApprovalBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, id := range ids {
+		approved, err := s.AutoApproveTask(ctx, id)
+		if err != nil {
+			zlog.Error().Err(err).Int("user_task_id", id).Msg("Service: Failed to auto-approve task")
+			continue
+		}
+		if approved {
+			processed++
+		}
+	}
+	if processed > 0 {
+		zlog.Info().Int("processed", processed).Msg("Service: Due auto-approvals processed")
+	}
+	return processed, nil
+}
+
+// lockUserTaskForParentTx mengunci penugasan dan memastikan parent berhak mengelolanya.
+func (s *taskServiceImpl) lockUserTaskForParentTx(ctx context.Context, tx pgx.Tx, userTaskID int, parentID int) (*repository.TaskVerificationDetails, error) {
+	details, err := s.userTaskRepo.GetTaskDetailsForVerificationTx(ctx, tx, userTaskID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err // Diteruskan agar handler mengembalikan 404
+		}
+		return nil, fmt.Errorf("internal server error: could not retrieve task details")
+	}
+	isParent, err := s.userRelRepo.IsParentOfTx(ctx, tx, parentID, details.ChildID)
+	if err != nil {
+		zlog.Error().Err(err).Int("parent_id", parentID).Int("child_id", details.ChildID).Msg("Service: Error checking parent-child relationship for task assignment")
+		return nil, fmt.Errorf("internal server error: could not verify relationship")
+	}
+	if !isParent {
+		return nil, fmt.Errorf("forbidden: you are not authorized to manage tasks for this child")
+	}
+	return details, nil
+}
+
+// UnassignTask membatalkan penugasan yang dibuat karena kesalahan.
+func (s *taskServiceImpl) UnassignTask(ctx context.Context, userTaskID int, parentID int) error {
+	return withTx(ctx, s.pool, "UnassignTask", func(tx pgx.Tx) error {
+		details, err := s.lockUserTaskForParentTx(ctx, tx, userTaskID, parentID)
+		if err != nil {
+			return err
+		}
+		// Tugas approved sudah menghasilkan poin; harus di-revert dulu agar ledger tetap konsisten
+		if details.CurrentStatus == models.UserTaskStatusApproved {
+			return fmt.Errorf("cannot unassign task: approved tasks must be reverted first")
+		}
+		// Penugasan tidak dihapus; status menjadi 'cancelled' agar riwayatnya tetap tersimpan
+		if _, err := models.NextUserTaskStatus(details.CurrentStatus, models.UserTaskActionCancel); err != nil {
+			return err
+		}
+		event := newUserTaskEvent(userTaskID, models.UserTaskActionCancel, details.CurrentStatus, models.UserTaskStatusCancelled, parentID, "Unassigned by parent")
+		if err := s.userTaskRepo.ApplyTransitionTx(ctx, tx, event); err != nil {
+			return err
+		}
+
+		auditDetails := map[string]any{"child_id": details.ChildID, "task_id": details.TaskID, "previous_status": details.CurrentStatus}
+		if err := recordAuditTx(ctx, tx, s.auditRepo, parentID, "user_task.unassigned", "user_task", userTaskID, auditDetails); err != nil {
+			return fmt.Errorf("internal server error: could not record audit log")
+		}
+		return nil
+	})
+}
+
+// ReassignTask memindahkan penugasan ke anak lain sebelum dikerjakan.
+func (s *taskServiceImpl) ReassignTask(ctx context.Context, userTaskID int, parentID int, newChildID int) error {
+	return withTx(ctx, s.pool, "ReassignTask", func(tx pgx.Tx) error {
+		details, err := s.lockUserTaskForParentTx(ctx, tx, userTaskID, parentID)
+		if err != nil {
+			return err
+		}
+		if _, err := models.NextUserTaskStatus(details.CurrentStatus, models.UserTaskActionReassign); err != nil {
+			return err
+		}
+		if details.ChildID == newChildID {
+			return fmt.Errorf("invalid input: task is already assigned to this child")
+		}
+
+		isParent, err := s.userRelRepo.IsParentOfTx(ctx, tx, parentID, newChildID)
+		if err != nil {
+			zlog.Error().Err(err).Int("parent_id", parentID).Int("child_id", newChildID).Msg("Service: Error checking relationship for reassignment target")
+			return fmt.Errorf("internal server error: could not verify relationship")
+		}
+		if !isParent {
+			return fmt.Errorf("forbidden: you are not authorized to assign tasks to this child")
+		}
+
+		// Dicek di dalam tx sambil mengunci pasangan anak & tugas agar tidak balapan dengan penugasan lain
+		exists, err := s.userTaskRepo.CheckExistingActiveTaskTx(ctx, tx, newChildID, details.TaskID)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not check existing tasks")
+		}
+		if exists {
+			return fmt.Errorf("cannot reassign task: the child already has this task assigned or submitted")
+		}
+
+		if err := s.userTaskRepo.ReassignTaskTx(ctx, tx, userTaskID, newChildID); err != nil {
+			return err
+		}
+		reason := fmt.Sprintf("Reassigned from child #%d to child #%d", details.ChildID, newChildID)
+		event := newUserTaskEvent(userTaskID, models.UserTaskActionReassign, details.CurrentStatus, models.UserTaskStatusAssigned, parentID, reason)
+		if err := s.userTaskRepo.CreateEventTx(ctx, tx, event); err != nil {
+			return fmt.Errorf("internal server error: could not record task event")
+		}
+
+		auditDetails := map[string]any{"from_child_id": details.ChildID, "to_child_id": newChildID, "task_id": details.TaskID}
+		if err := recordAuditTx(ctx, tx, s.auditRepo, parentID, "user_task.reassigned", "user_task", userTaskID, auditDetails); err != nil {
+			return fmt.Errorf("internal server error: could not record audit log")
+		}
+		return nil
+	})
+}
+
+// RevertVerification membatalkan hasil verifikasi (approved/rejected) dalam batas waktu revert.
+// Poin dari approval dibalik lewat transaksi kompensasi sehingga riwayat ledger tetap utuh; XP-nya juga dibalik.
+func (s *taskServiceImpl) RevertVerification(ctx context.Context, userTaskID int, parentID int, reason string) error {
+	return withTx(ctx, s.pool, "RevertVerification", func(tx pgx.Tx) error {
+		details, err := s.lockUserTaskForParentTx(ctx, tx, userTaskID, parentID)
+		if err != nil {
+			return err
+		}
+		nextStatus, err := models.NextUserTaskStatus(details.CurrentStatus, models.UserTaskActionRevert)
+		if err != nil {
+			return err
+		}
+		if details.VerifiedAt == nil || time.Since(*details.VerifiedAt) > s.revertWindow {
+			return fmt.Errorf("cannot revert verification: verifications can only be reverted within %d hours", int(s.revertWindow.Hours()))
+		}
+
+		// Balik transaksi task_completion yang belum dibalik (bukan task_point saat ini, yang mungkin sudah diubah)
+		pointsReversed, xpReversed := 0, 0
+		if details.CurrentStatus == models.UserTaskStatusApproved {
+			// Tugas penebus yang sudah mengembalikan poin sanksi tidak bisa di-revert (ledger append-only)
+			penalty, err := s.penaltyRepo.GetPenaltyByEarnBackUserTaskIDForUpdateTx(ctx, tx, userTaskID)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("internal server error: could not retrieve penalty")
+			}
+			if penalty != nil && penalty.EarnedBackAt != nil {
+				return fmt.Errorf("cannot revert verification: this task earned back penalty #%d", penalty.ID)
+			}
+			// Begitu juga persetujuan yang memicu bonus streak
+			hasBonus, err := s.streakRepo.HasBonusAwardForUserTaskTx(ctx, tx, userTaskID)
+			if err != nil {
+				return fmt.Errorf("internal server error: could not check streak bonuses")
+			}
+			if hasBonus {
+				return fmt.Errorf("cannot revert verification: this task earned a streak bonus")
+			}
+			original, err := s.pointRepo.GetUnreversedTransactionTx(ctx, tx, models.TransactionTypeCompletion, userTaskID, 0)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("internal server error: could not retrieve task points")
+			}
+			if original != nil && original.ChangeAmount > 0 {
+				netPoints := original.ChangeAmount
+				// Dibalik dalam mata uang transaksi asli
+				balance, err := s.pointRepo.CalculateBalanceTx(ctx, tx, details.ChildID, original.CurrencyID)
+				if err != nil {
+					return fmt.Errorf("internal server error: could not calculate points")
+				}
+				if balance < netPoints {
+					return fmt.Errorf("cannot revert verification: child's balance (%d) is lower than the %d points to reverse", balance, netPoints)
+				}
+				reversal := &models.PointTransaction{
+					UserID:                details.ChildID,
+					ChangeAmount:          -netPoints,
+					TransactionType:       models.TransactionTypeTaskReversal,
+					RelatedUserTaskID:     userTaskID,
+					ReversesTransactionID: original.ID,
+					CreatedByUserID:       parentID,
+					CurrencyID:            original.CurrencyID,
+					Notes:                 fmt.Sprintf("Reversal of task approval: %s", reason),
+				}
+				if err := s.pointRepo.CreateTransactionTx(ctx, tx, reversal); err != nil {
+					return fmt.Errorf("internal server error: could not record points")
+				}
+				pointsReversed = netPoints
+			}
+
+			// XP dibalik; level-up yang sudah tercatat tetap ada di riwayat
+			netXP, err := s.levelRepo.GetNetTaskXPTx(ctx, tx, userTaskID)
+			if err != nil {
+				return fmt.Errorf("internal server error: could not retrieve task XP")
+			}
+			if netXP > 0 {
+				xp := &models.XPTransaction{
+					UserID:            details.ChildID,
+					ChangeAmount:      -netXP,
+					Source:            models.XPSourceTaskReversal,
+					RelatedUserTaskID: userTaskID,
+				}
+				if _, err := s.levelRepo.AddXPTx(ctx, tx, xp); err != nil {
+					return fmt.Errorf("internal server error: could not record XP")
+				}
+				xpReversed = netXP
+			}
+		}
+
+		event := newUserTaskEvent(userTaskID, models.UserTaskActionRevert, details.CurrentStatus, nextStatus, parentID, reason)
+		if err := s.userTaskRepo.ApplyTransitionTx(ctx, tx, event); err != nil {
+			return err
+		}
+
+		auditDetails := map[string]any{
+			"child_id":        details.ChildID,
+			"previous_status": details.CurrentStatus,
+			"points_reversed": pointsReversed,
+			"xp_reversed":     xpReversed,
+			"reason":          reason,
+		}
+		if err := recordAuditTx(ctx, tx, s.auditRepo, parentID, "user_task.verification_reverted", "user_task", userTaskID, auditDetails); err != nil {
+			return fmt.Errorf("internal server error: could not record audit log")
+		}
+		zlog.Info().Int("user_task_id", userTaskID).Int("points_reversed", pointsReversed).Msg("Service: Task verification reverted")
+		return nil
+	})
+}
+
+// ExpireOverdueTasks menandai penugasan yang melewati due_at sebagai 'expired'.
+// Setiap tugas diproses dalam transaksinya sendiri; kegagalan satu tugas tidak menghentikan yang lain.
+func (s *taskServiceImpl) ExpireOverdueTasks(ctx context.Context, now time.Time) (int, error) {
+	ids, err := s.userTaskRepo.GetOverdueUserTaskIDs(ctx, now, taskExpiryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, id := range ids {
+		applied := false
+		err := withTx(ctx, s.pool, "ExpireOverdueTasks", func(tx pgx.Tx) error {
+			details, err := s.userTaskRepo.GetTaskDetailsForVerificationTx(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			// Status bisa berubah (misal: anak baru saja submit) sejak ID diambil
+			nextStatus, err := models.NextUserTaskStatus(details.CurrentStatus, models.UserTaskActionExpire)
+			if err != nil {
+				return nil
+			}
+			event := newUserTaskEvent(id, models.UserTaskActionExpire, details.CurrentStatus, nextStatus, SystemActorID, "Due date passed")
+			if err := s.userTaskRepo.ApplyTransitionTx(ctx, tx, event); err != nil {
+				return err
+			}
+			applied = true
+			auditDetails := map[string]any{"child_id": details.ChildID, "task_id": details.TaskID}
+			return recordAuditTx(ctx, tx, s.auditRepo, SystemActorID, "user_task.expired", "user_task", id, auditDetails)
+		})
+		if err != nil {
+			zlog.Error().Err(err).Int("user_task_id", id).Msg("Service: Failed to expire overdue task")
+			continue
+		}
+		if applied {
+			expired++
+		}
+	}
+	if expired > 0 {
+		zlog.Info().Int("expired", expired).Msg("Service: Overdue tasks expired")
+	}
+	return expired, nil
+}
+
+// GetTaskTimeline mengembalikan riwayat transisi penugasan untuk anak pemilik atau orang tuanya.
+func (s *taskServiceImpl) GetTaskTimeline(ctx context.Context, userTaskID int, userID int) ([]models.UserTaskEvent, error) {
+	userTask, err := s.userTaskRepo.GetUserTaskByID(ctx, userTaskID)
+	if err != nil {
+		return nil, err // ErrNoRows diteruskan agar handler mengembalikan 404
+	}
+	if userTask.UserID != userID {
+		isParent, err := s.userRelRepo.IsParentOf(ctx, userID, userTask.UserID)
+		if err != nil {
+			zlog.Error().Err(err).Int("user_id", userID).Int("child_id", userTask.UserID).Msg("Service: Error checking relationship for task timeline")
+			return nil, fmt.Errorf("internal server error: could not verify relationship")
+		}
+		if !isParent {
+			return nil, fmt.Errorf("forbidden: you are not authorized to view this task")
+		}
+	}
+	return s.userTaskRepo.GetEventsByUserTaskID(ctx, userTaskID)
+}
+
 // Implementasi metode TaskService lainnya...
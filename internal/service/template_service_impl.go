@@ -0,0 +1,192 @@
+// internal/service/template_service_impl.go
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type templateServiceImpl struct {
+	pool         *pgxpool.Pool // Untuk transaksi pembuatan/pembaruan template & impor
+	templateRepo repository.TemplateRepository
+	taskRepo     repository.TaskRepository
+	rewardRepo   repository.RewardRepository
+}
+
+// NewTemplateService creates a new instance of TemplateService.
+func NewTemplateService(
+	pool *pgxpool.Pool,
+	templateRepo repository.TemplateRepository,
+	taskRepo repository.TaskRepository,
+	rewardRepo repository.RewardRepository,
+) TemplateService {
+	return &templateServiceImpl{
+		pool:         pool,
+		templateRepo: templateRepo,
+		taskRepo:     taskRepo,
+		rewardRepo:   rewardRepo,
+	}
+}
+
+// --- Helper Functions ---
+
+// templateFromInput memvalidasi rentang usia dan mengubah input menjadi model template.
+func templateFromInput(input *models.TemplateInput) (*models.DefinitionTemplate, error) {
+	if input.MinAge != nil && input.MaxAge != nil && *input.MinAge > *input.MaxAge {
+		return nil, fmt.Errorf("invalid age range: min_age cannot be greater than max_age")
+	}
+	seenLocales := make(map[string]struct{}, len(input.Translations))
+	for _, translation := range input.Translations {
+		if _, ok := seenLocales[translation.Locale]; ok {
+			return nil, fmt.Errorf("invalid translations: duplicate locale '%s'", translation.Locale)
+		}
+		seenLocales[translation.Locale] = struct{}{}
+	}
+
+	isActive := true
+	if input.IsActive != nil {
+		isActive = *input.IsActive
+	}
+	return &models.DefinitionTemplate{
+		Kind:            models.TemplateKind(input.Kind),
+		Category:        models.DefinitionCategory(input.Category),
+		Tags:            input.Tags,
+		SuggestedPoints: input.SuggestedPoints,
+		MinAge:          input.MinAge,
+		MaxAge:          input.MaxAge,
+		IsActive:        isActive,
+		Translations:    input.Translations,
+	}, nil
+}
+
+// --- Service Methods (Admin) ---
+
+// CreateTemplate membuat template katalog baru.
+func (s *templateServiceImpl) CreateTemplate(ctx context.Context, adminID int, input *models.TemplateInput) (int, error) {
+	template, err := templateFromInput(input)
+	if err != nil {
+		return 0, err
+	}
+	template.CreatedByUserID = adminID
+
+	var templateID int
+	err = withTx(ctx, s.pool, "CreateTemplate", func(tx pgx.Tx) error {
+		var txErr error
+		templateID, txErr = s.templateRepo.CreateTemplateTx(ctx, tx, template)
+		return txErr
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	zlog.Info().Int("admin_id", adminID).Int("template_id", templateID).Msg("Service: Definition template created")
+	return templateID, nil
+}
+
+// UpdateTemplate memperbarui template katalog dan menaikkan versinya.
+func (s *templateServiceImpl) UpdateTemplate(ctx context.Context, templateID int, input *models.TemplateInput) error {
+	template, err := templateFromInput(input)
+	if err != nil {
+		return err
+	}
+	template.ID = templateID
+
+	return withTx(ctx, s.pool, "UpdateTemplate", func(tx pgx.Tx) error {
+		return s.templateRepo.UpdateTemplateTx(ctx, tx, template)
+	})
+}
+
+// DeleteTemplate menghapus template katalog.
+func (s *templateServiceImpl) DeleteTemplate(ctx context.Context, templateID int) error {
+	return s.templateRepo.DeleteTemplate(ctx, templateID)
+}
+
+// --- Service Methods (Shared / Parent) ---
+
+// GetTemplateByID mengambil detail template.
+func (s *templateServiceImpl) GetTemplateByID(ctx context.Context, templateID int, locale string) (*models.DefinitionTemplate, error) {
+	return s.templateRepo.GetTemplateByID(ctx, templateID, locale)
+}
+
+// ListTemplates mengambil daftar template sesuai filter.
+func (s *templateServiceImpl) ListTemplates(ctx context.Context, filter *models.TemplateFilter, includeInactive bool, page, limit int) ([]models.DefinitionTemplate, int, error) {
+	return s.templateRepo.ListTemplates(ctx, filter, includeInactive, page, limit)
+}
+
+// ImportTemplates menyalin template menjadi definisi milik parent. Semua template harus aktif;
+// jika salah satu tidak valid, tidak ada definisi yang dibuat.
+func (s *templateServiceImpl) ImportTemplates(ctx context.Context, parentID int, input *models.ImportTemplatesInput) ([]models.ImportedDefinition, error) {
+	log := zlog.With().Int("parent_id", parentID).Ints("template_ids", input.TemplateIDs).Logger()
+	imported := make([]models.ImportedDefinition, 0, len(input.TemplateIDs))
+
+	err := withTx(ctx, s.pool, "ImportTemplates", func(tx pgx.Tx) error {
+		// 1. Ambil template aktif & pastikan semuanya ditemukan
+		templates, err := s.templateRepo.GetActiveTemplatesByIDsTx(ctx, tx, input.TemplateIDs, input.Locale)
+		if err != nil {
+			return err
+		}
+		found := make(map[int]models.DefinitionTemplate, len(templates))
+		for _, template := range templates {
+			found[template.ID] = template
+		}
+		for _, id := range input.TemplateIDs {
+			if _, ok := found[id]; !ok {
+				return fmt.Errorf("invalid template id %d: template not found or inactive", id)
+			}
+		}
+
+		// 2. Buat definisi sesuai urutan permintaan, simpan tautan ke template & versinya
+		for _, id := range input.TemplateIDs {
+			template := found[id]
+			result := models.ImportedDefinition{TemplateID: template.ID, Kind: template.Kind, Name: template.Name}
+			switch template.Kind {
+			case models.TemplateKindTask:
+				result.DefinitionID, err = s.taskRepo.CreateTaskTx(ctx, tx, &models.Task{
+					TaskName:              template.Name,
+					TaskPoint:             template.SuggestedPoints,
+					TaskDescription:       template.Description,
+					Category:              template.Category,
+					Tags:                  template.Tags,
+					CreatedByUserID:       parentID,
+					SourceTemplateID:      template.ID,
+					SourceTemplateVersion: template.Version,
+				})
+			case models.TemplateKindReward:
+				result.DefinitionID, err = s.rewardRepo.CreateRewardTx(ctx, tx, &models.Reward{
+					RewardName:            template.Name,
+					RewardPoint:           template.SuggestedPoints,
+					RewardDescription:     template.Description,
+					Category:              template.Category,
+					Tags:                  template.Tags,
+					CreatedByUserID:       parentID,
+					SourceTemplateID:      template.ID,
+					SourceTemplateVersion: template.Version,
+				})
+			default:
+				return fmt.Errorf("invalid template id %d: unknown kind '%s'", id, template.Kind)
+			}
+			if err != nil {
+				return err
+			}
+			imported = append(imported, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Int("imported_count", len(imported)).Msg("Service: Templates imported into parent definitions")
+	return imported, nil
+}
+
+// GetUpdateSuggestions mengambil saran pembaruan untuk definisi hasil impor milik parent.
+func (s *templateServiceImpl) GetUpdateSuggestions(ctx context.Context, parentID int, locale string) ([]models.TemplateUpdateSuggestion, error) {
+	return s.templateRepo.GetUpdateSuggestions(ctx, parentID, locale)
+}
@@ -0,0 +1,362 @@
+// internal/service/transfer_service_impl.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/models"
+	"github.com/rakaarfi/digital-parenting-app-be/internal/repository"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// transferForbiddenMessage dipakai saat parent mengelola transfer poin anak yang bukan anaknya.
+const transferForbiddenMessage = "you are not authorized to manage point transfers for this child"
+
+type transferServiceImpl struct {
+	pool             *pgxpool.Pool // Untuk transaksi pemindahan poin
+	transferRepo     repository.PointTransferRepository
+	pointRepo        repository.PointTransactionRepository
+	goalRepo         repository.SavingsGoalRepository // Poin yang disisihkan untuk target tabungan tidak bisa ditransfer
+	userRelRepo      repository.UserRelationshipRepository
+	notificationRepo repository.NotificationRepository
+	badgeService     BadgeService // Evaluasi badge saldo penerima setelah transfer selesai
+}
+
+// NewTransferService creates a new instance of TransferService.
+func NewTransferService(
+	pool *pgxpool.Pool,
+	transferRepo repository.PointTransferRepository,
+	pointRepo repository.PointTransactionRepository,
+	goalRepo repository.SavingsGoalRepository,
+	userRelRepo repository.UserRelationshipRepository,
+	notificationRepo repository.NotificationRepository,
+	badgeService BadgeService,
+) TransferService {
+	return &transferServiceImpl{
+		pool:             pool,
+		transferRepo:     transferRepo,
+		pointRepo:        pointRepo,
+		goalRepo:         goalRepo,
+		userRelRepo:      userRelRepo,
+		notificationRepo: notificationRepo,
+		badgeService:     badgeService,
+	}
+}
+
+// --- Helper Functions ---
+
+// effectivePolicy mengambil kebijakan transfer yang berlaku untuk anak dari kebijakan orang tuanya,
+// atau kebijakan default jika belum diatur.
+func (s *transferServiceImpl) effectivePolicy(ctx context.Context, childID int) (*models.TransferPolicy, error) {
+	policy, err := s.transferRepo.GetPolicyForChild(ctx, childID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &models.TransferPolicy{ChildID: childID, Allowed: true, RequiresApproval: true}, nil
+		}
+		return nil, fmt.Errorf("internal server error: could not retrieve transfer policy")
+	}
+	return policy, nil
+}
+
+// executeTransferTx memindahkan poin transfer dalam transaksi: mengunci saldo kedua anak (urutan ID
+// menaik agar tidak deadlock), memastikan poin bebas pengirim cukup, mencatat entri debit & kredit yang
+// menunjuk transfer, lalu menandai transfer selesai. reviewerID 0 berarti transfer otomatis.
+func (s *transferServiceImpl) executeTransferTx(ctx context.Context, tx pgx.Tx, transfer *models.PointTransfer, reviewerID int) error {
+	first, second := min(transfer.FromChildID, transfer.ToChildID), max(transfer.FromChildID, transfer.ToChildID)
+	balances := map[int]int{}
+	for _, userID := range []int{first, second} {
+		balance, err := s.pointRepo.CalculateTotalPointsByUserIDTx(ctx, tx, userID)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not retrieve points balance")
+		}
+		balances[userID] = balance
+	}
+	earmarked, err := s.goalRepo.SumEarmarkedPointsTx(ctx, tx, transfer.FromChildID, 0)
+	if err != nil {
+		return fmt.Errorf("internal server error: could not retrieve earmarked points")
+	}
+	if free := max(balances[transfer.FromChildID]-earmarked, 0); free < transfer.Amount {
+		return fmt.Errorf("%w: only %d points are available to transfer", ErrInsufficientPoints, free)
+	}
+
+	debit := &models.PointTransaction{
+		UserID:            transfer.FromChildID,
+		ChangeAmount:      -transfer.Amount,
+		TransactionType:   models.TransactionTypeTransfer,
+		RelatedTransferID: transfer.ID,
+		CreatedByUserID:   transfer.FromChildID,
+		Notes:             fmt.Sprintf("Points sent to %s", transfer.ToUsername),
+	}
+	if err := s.pointRepo.CreateTransactionTx(ctx, tx, debit); err != nil {
+		if errors.Is(err, repository.ErrNegativeBalance) {
+			return ErrInsufficientPoints
+		}
+		return fmt.Errorf("internal server error: could not record transfer debit")
+	}
+	credit := &models.PointTransaction{
+		UserID:            transfer.ToChildID,
+		ChangeAmount:      transfer.Amount,
+		TransactionType:   models.TransactionTypeTransfer,
+		RelatedTransferID: transfer.ID,
+		CreatedByUserID:   transfer.FromChildID,
+		Notes:             fmt.Sprintf("Points received from %s", transfer.FromUsername),
+	}
+	if err := s.pointRepo.CreateTransactionTx(ctx, tx, credit); err != nil {
+		return fmt.Errorf("internal server error: could not record transfer credit")
+	}
+
+	transfer.DebitTransactionID = debit.ID
+	transfer.CreditTransactionID = credit.ID
+	if err := s.transferRepo.CompleteTransferTx(ctx, tx, transfer, reviewerID); err != nil {
+		return fmt.Errorf("internal server error: could not complete transfer")
+	}
+
+	message := fmt.Sprintf("%s sent you %d points.", transfer.FromUsername, transfer.Amount)
+	if transfer.Note != "" {
+		message = fmt.Sprintf("%s sent you %d points: %q", transfer.FromUsername, transfer.Amount, transfer.Note)
+	}
+	err = s.notificationRepo.CreateNotificationTx(ctx, tx, &models.Notification{
+		UserID:     transfer.ToChildID,
+		Type:       models.NotificationTransferReceived,
+		Title:      "Points received",
+		Message:    message,
+		EntityType: "point_transfer",
+		EntityID:   transfer.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("internal server error: could not send notification")
+	}
+	return nil
+}
+
+// --- Public Methods ---
+
+// SetPolicy membuat atau memperbarui kebijakan transfer milik parent untuk semua anaknya (childID 0) atau satu anak.
+func (s *transferServiceImpl) SetPolicy(ctx context.Context, parentID int, childID int, input *models.SetTransferPolicyInput) (*models.TransferPolicy, error) {
+	if childID != 0 {
+		if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, transferForbiddenMessage); err != nil {
+			return nil, err
+		}
+	}
+	policy := &models.TransferPolicy{
+		CreatedByUserID:  parentID,
+		ChildID:          childID,
+		Allowed:          *input.Allowed,
+		MaxAmount:        input.MaxAmount,
+		RequiresApproval: *input.RequiresApproval,
+	}
+	if err := s.transferRepo.UpsertPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// GetPolicy mengambil kebijakan keluarga milik parent (childID 0), atau kebijakan yang berlaku untuk anak.
+func (s *transferServiceImpl) GetPolicy(ctx context.Context, parentID int, childID int) (*models.TransferPolicy, error) {
+	if childID == 0 {
+		return s.transferRepo.GetPolicyByOwner(ctx, parentID, 0)
+	}
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, transferForbiddenMessage); err != nil {
+		return nil, err
+	}
+	return s.transferRepo.GetPolicyForChild(ctx, childID)
+}
+
+// DeletePolicy menghapus kebijakan transfer milik parent untuk semua anak (childID 0) atau satu anak.
+func (s *transferServiceImpl) DeletePolicy(ctx context.Context, parentID int, childID int) error {
+	if childID != 0 {
+		if err := ensureParentOf(ctx, s.userRelRepo, parentID, childID, transferForbiddenMessage); err != nil {
+			return err
+		}
+	}
+	return s.transferRepo.DeletePolicy(ctx, parentID, childID)
+}
+
+// CreateTransfer mengirim poin ke saudara sesuai kebijakan transfer pengirim.
+// Transfer yang menunggu persetujuan tidak menahan poin; saldo dicek ulang saat disetujui.
+func (s *transferServiceImpl) CreateTransfer(ctx context.Context, childID int, input *models.CreateTransferInput) (*models.PointTransfer, error) {
+	if input.ToChildID == childID {
+		return nil, fmt.Errorf("cannot transfer points to yourself")
+	}
+	siblings, err := s.transferRepo.AreSiblings(ctx, childID, input.ToChildID)
+	if err != nil {
+		return nil, fmt.Errorf("internal server error: could not verify recipient")
+	}
+	if !siblings {
+		return nil, fmt.Errorf("forbidden: recipient is not your sibling")
+	}
+	policy, err := s.effectivePolicy(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.Allowed {
+		return nil, fmt.Errorf("forbidden: transfers are not allowed for this child")
+	}
+	if policy.MaxAmount > 0 && input.Amount > policy.MaxAmount {
+		return nil, fmt.Errorf("cannot transfer more than %d points at once", policy.MaxAmount)
+	}
+
+	var transfer *models.PointTransfer
+	err = withTx(ctx, s.pool, "CreatePointTransfer", func(tx pgx.Tx) error {
+		created := &models.PointTransfer{
+			FromChildID: childID,
+			ToChildID:   input.ToChildID,
+			Amount:      input.Amount,
+			Note:        input.Note,
+			Status:      models.PointTransferStatusPending,
+		}
+		if err := s.transferRepo.CreateTransferTx(ctx, tx, created); err != nil {
+			return fmt.Errorf("internal server error: could not create transfer")
+		}
+		// Ambil ulang untuk username pengirim & penerima
+		transfer, err = s.transferRepo.GetTransferForUpdateTx(ctx, tx, created.ID)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not retrieve transfer")
+		}
+
+		if !policy.RequiresApproval {
+			return s.executeTransferTx(ctx, tx, transfer, 0)
+		}
+
+		// Tolak lebih awal jika poin bebas saat ini sudah tidak cukup
+		balance, err := s.pointRepo.CalculateTotalPointsByUserIDTx(ctx, tx, childID)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not retrieve points balance")
+		}
+		earmarked, err := s.goalRepo.SumEarmarkedPointsTx(ctx, tx, childID, 0)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not retrieve earmarked points")
+		}
+		if free := max(balance-earmarked, 0); free < transfer.Amount {
+			return fmt.Errorf("%w: only %d points are available to transfer", ErrInsufficientPoints, free)
+		}
+
+		parentIDs, err := s.userRelRepo.GetParentIDsByChildIDTx(ctx, tx, childID)
+		if err != nil {
+			return fmt.Errorf("internal server error: could not retrieve parents")
+		}
+		for _, parentID := range parentIDs {
+			err = s.notificationRepo.CreateNotificationTx(ctx, tx, &models.Notification{
+				UserID:     parentID,
+				Type:       models.NotificationTransferRequested,
+				Title:      "Point transfer needs approval",
+				Message:    fmt.Sprintf("%s wants to send %d points to %s.", transfer.FromUsername, transfer.Amount, transfer.ToUsername),
+				EntityType: "point_transfer",
+				EntityID:   transfer.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("internal server error: could not send notification")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zlog.Info().Int("transfer_id", transfer.ID).Int("from_child_id", childID).Int("to_child_id", input.ToChildID).
+		Int("amount", input.Amount).Str("status", string(transfer.Status)).Msg("Service: Point transfer created")
+	if transfer.Status == models.PointTransferStatusCompleted {
+		evaluateBadgesAfterCommit(ctx, s.badgeService, transfer.ToChildID)
+	}
+	return transfer, nil
+}
+
+// CancelTransfer membatalkan transfer 'pending' milik anak pengirim.
+func (s *transferServiceImpl) CancelTransfer(ctx context.Context, childID int, transferID int) error {
+	transfer, err := s.transferRepo.GetTransferByID(ctx, transferID)
+	if err != nil {
+		return err
+	}
+	if transfer.FromChildID != childID {
+		return pgx.ErrNoRows // Jangan bocorkan transfer milik anak lain
+	}
+	if transfer.Status != models.PointTransferStatusPending {
+		return fmt.Errorf("cannot cancel transfer: transfer is already %s", transfer.Status)
+	}
+	if err := s.transferRepo.UpdateTransferStatus(ctx, transferID, models.PointTransferStatusCancelled, 0, ""); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("cannot cancel transfer: transfer is no longer pending")
+		}
+		return err
+	}
+	zlog.Info().Int("transfer_id", transferID).Int("child_id", childID).Msg("Service: Point transfer cancelled")
+	return nil
+}
+
+// GetMyTransfers mengambil transfer yang dikirim atau diterima anak.
+func (s *transferServiceImpl) GetMyTransfers(ctx context.Context, childID int, page, limit int) ([]models.PointTransfer, int, error) {
+	return s.transferRepo.GetTransfersByChildID(ctx, childID, page, limit)
+}
+
+// GetTransfersForParent mengambil transfer yang dikirim anak-anak parent.
+func (s *transferServiceImpl) GetTransfersForParent(ctx context.Context, parentID int, status models.PointTransferStatus, page, limit int) ([]models.PointTransfer, int, error) {
+	return s.transferRepo.GetTransfersByParentID(ctx, parentID, status, page, limit)
+}
+
+// ApproveTransfer menyetujui transfer 'pending' dan memindahkan poinnya dalam satu transaksi.
+func (s *transferServiceImpl) ApproveTransfer(ctx context.Context, parentID int, transferID int) (*models.PointTransfer, error) {
+	var transfer *models.PointTransfer
+	err := withTx(ctx, s.pool, "ApprovePointTransfer", func(tx pgx.Tx) error {
+		var err error
+		transfer, err = s.transferRepo.GetTransferForUpdateTx(ctx, tx, transferID)
+		if err != nil {
+			return err
+		}
+		if err := ensureParentOf(ctx, s.userRelRepo, parentID, transfer.FromChildID, transferForbiddenMessage); err != nil {
+			return err
+		}
+		if transfer.Status != models.PointTransferStatusPending {
+			return fmt.Errorf("cannot approve transfer: transfer is already %s", transfer.Status)
+		}
+		return s.executeTransferTx(ctx, tx, transfer, parentID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	zlog.Info().Int("transfer_id", transferID).Int("parent_id", parentID).Msg("Service: Point transfer approved")
+	evaluateBadgesAfterCommit(ctx, s.badgeService, transfer.ToChildID)
+	return transfer, nil
+}
+
+// RejectTransfer menolak transfer 'pending' dan memberi tahu anak pengirim.
+func (s *transferServiceImpl) RejectTransfer(ctx context.Context, parentID int, transferID int, reason string) error {
+	transfer, err := s.transferRepo.GetTransferByID(ctx, transferID)
+	if err != nil {
+		return err
+	}
+	if err := ensureParentOf(ctx, s.userRelRepo, parentID, transfer.FromChildID, transferForbiddenMessage); err != nil {
+		return err
+	}
+	if transfer.Status != models.PointTransferStatusPending {
+		return fmt.Errorf("cannot reject transfer: transfer is already %s", transfer.Status)
+	}
+	if err := s.transferRepo.UpdateTransferStatus(ctx, transferID, models.PointTransferStatusRejected, parentID, reason); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("cannot reject transfer: transfer is no longer pending")
+		}
+		return err
+	}
+
+	message := fmt.Sprintf("Your transfer of %d points to %s was rejected.", transfer.Amount, transfer.ToUsername)
+	if reason != "" {
+		message = fmt.Sprintf("%s Reason: %s", message, reason)
+	}
+	err = s.notificationRepo.CreateNotification(ctx, &models.Notification{
+		UserID:     transfer.FromChildID,
+		Type:       models.NotificationTransferRejected,
+		Title:      "Point transfer rejected",
+		Message:    message,
+		EntityType: "point_transfer",
+		EntityID:   transferID,
+	})
+	if err != nil {
+		zlog.Warn().Err(err).Int("transfer_id", transferID).Msg("Service: Failed to notify child about rejected transfer")
+	}
+	zlog.Info().Int("transfer_id", transferID).Int("parent_id", parentID).Msg("Service: Point transfer rejected")
+	return nil
+}
@@ -0,0 +1,43 @@
+// internal/service/tx.go
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// withTx menjalankan fn di dalam satu transaksi database dengan pola yang sama seperti
+// VerifyTask/ClaimReward: rollback jika fn mengembalikan error atau panic, commit jika sukses.
+// operation hanya digunakan sebagai konteks log.
+func withTx(ctx context.Context, pool *pgxpool.Pool, operation string, fn func(tx pgx.Tx) error) (err error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		zlog.Error().Err(err).Str("operation", operation).Msg("Service: Failed to begin transaction")
+		return fmt.Errorf("internal server error: could not start operation")
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			zlog.Error().Str("operation", operation).Msgf("Service: Panic recovered during transaction: %v", p)
+			_ = tx.Rollback(ctx)
+			panic(p)
+		} else if err != nil {
+			zlog.Warn().Err(err).Str("operation", operation).Msg("Service: Rolling back transaction due to error")
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				zlog.Error().Err(rbErr).Str("operation", operation).Msg("Service: Failed to rollback transaction")
+			}
+		} else {
+			err = tx.Commit(ctx)
+			if err != nil {
+				zlog.Error().Err(err).Str("operation", operation).Msg("Service: Failed to commit transaction")
+				err = fmt.Errorf("internal server error: could not finalize operation")
+			}
+		}
+	}()
+
+	return fn(tx)
+}
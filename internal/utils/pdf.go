@@ -0,0 +1,136 @@
+// internal/utils/pdf.go
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// File ini berisi penulis PDF teks sederhana (ukuran A4, font standar Type1) tanpa
+// dependensi eksternal. Cukup untuk dokumen berbasis baris seperti laporan rekening;
+// tidak mendukung gambar, tabel, maupun karakter di luar ASCII (diganti '?').
+
+// ====================================================================================
+// Konstanta & Tipe
+// ====================================================================================
+
+const (
+	pdfPageWidth  = 595 // Lebar A4 dalam point
+	pdfPageHeight = 842 // Tinggi A4 dalam point
+	pdfMargin     = 50  // Margin halaman dalam point
+	pdfLeading    = 1.4 // Jarak antar baris relatif terhadap ukuran font
+)
+
+// PDFFont adalah nama resource font standar yang tersedia di setiap halaman.
+type PDFFont string
+
+const (
+	PDFFontRegular PDFFont = "F1" // Helvetica
+	PDFFontBold    PDFFont = "F2" // Helvetica-Bold
+	PDFFontMono    PDFFont = "F3" // Courier (lebar karakter tetap, cocok untuk kolom)
+)
+
+// PDFMonoCharWidth adalah lebar satu karakter Courier relatif terhadap ukuran font,
+// dipakai pemanggil untuk menghitung jumlah karakter per baris.
+const PDFMonoCharWidth = 0.6
+
+// PDFDocument menyusun halaman-halaman PDF baris demi baris dari atas ke bawah.
+// Halaman baru dibuat otomatis saat baris berikutnya melewati margin bawah.
+type PDFDocument struct {
+	pages []*bytes.Buffer // Content stream setiap halaman
+	y     float64         // Posisi vertikal baris terakhir pada halaman aktif
+}
+
+// ====================================================================================
+// Penyusunan Dokumen
+// ====================================================================================
+
+// NewPDFDocument membuat dokumen PDF kosong dengan satu halaman.
+func NewPDFDocument() *PDFDocument {
+	doc := &PDFDocument{}
+	doc.newPage()
+	return doc
+}
+
+// newPage menambah halaman baru dan memindahkan posisi tulis ke margin atas.
+func (d *PDFDocument) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pdfPageHeight - pdfMargin
+}
+
+// TextWidth mengembalikan lebar area tulis (tanpa margin) dalam point.
+func (d *PDFDocument) TextWidth() float64 {
+	return pdfPageWidth - 2*pdfMargin
+}
+
+// WriteLine menulis satu baris teks dengan font dan ukuran tertentu.
+func (d *PDFDocument) WriteLine(font PDFFont, size float64, text string) {
+	lead := size * pdfLeading
+	if d.y-lead < pdfMargin {
+		d.newPage()
+	}
+	d.y -= lead
+	fmt.Fprintf(d.pages[len(d.pages)-1], "BT /%s %.1f Tf %d %.2f Td (%s) Tj ET\n", font, size, pdfMargin, d.y, pdfEscape(text))
+}
+
+// AddSpace menambah jarak vertikal kosong (dalam point) sebelum baris berikutnya.
+func (d *PDFDocument) AddSpace(height float64) {
+	d.y -= height
+}
+
+// Bytes menghasilkan berkas PDF lengkap (objek, tabel xref, dan trailer).
+func (d *PDFDocument) Bytes() []byte {
+	// Objek 1: katalog, 2: daftar halaman, 3-5: font, lalu pasangan (halaman, content stream) per halaman
+	const firstPageObj = 6
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"", // Diisi setelah nomor objek halaman diketahui
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+	}
+	kids := make([]string, 0, len(d.pages))
+	for i, page := range d.pages {
+		pageObj := firstPageObj + 2*i
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageObj))
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R /F3 5 0 R >> >> /Contents %d 0 R >>",
+				pdfPageWidth, pdfPageHeight, pageObj+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages))
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xrefOffset := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xrefOffset)
+	return out.Bytes()
+}
+
+// pdfEscape meloloskan karakter khusus string PDF dan mengganti karakter non-ASCII dengan '?'.
+func pdfEscape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r < 32 || r > 126:
+			b.WriteRune('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,130 @@
+// internal/worker/jobs.go
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/rakaarfi/digital-parenting-app-be/internal/service"
+)
+
+// NewRotationJob membuat job yang memberikan giliran rotasi tugas yang jadwalnya sudah tiba.
+// Interval dapat diatur lewat ROTATION_WORKER_INTERVAL_SECONDS (default 60 detik).
+func NewRotationJob(rotationService service.RotationService) Job {
+	return Job{
+		Name:     "task-rotation",
+		Interval: IntervalFromEnv("ROTATION_WORKER_INTERVAL_SECONDS", time.Minute),
+		Run: func(ctx context.Context) error {
+			_, err := rotationService.ProcessDueRotations(ctx, time.Now())
+			return err
+		},
+	}
+}
+
+// NewAutoApprovalJob membuat job yang menyetujui submission tugas yang masa tenggang auto-approval-nya sudah lewat.
+// Interval dapat diatur lewat AUTO_APPROVAL_WORKER_INTERVAL_SECONDS (default 60 detik).
+func NewAutoApprovalJob(taskService service.TaskService) Job {
+	return Job{
+		Name:     "task-auto-approval",
+		Interval: IntervalFromEnv("AUTO_APPROVAL_WORKER_INTERVAL_SECONDS", time.Minute),
+		Run: func(ctx context.Context) error {
+			_, err := taskService.ProcessDueAutoApprovals(ctx, time.Now())
+			return err
+		},
+	}
+}
+
+// NewTaskExpiryJob membuat job yang menandai penugasan 'assigned' yang melewati due_at sebagai 'expired'.
+// Interval dapat diatur lewat TASK_EXPIRY_WORKER_INTERVAL_SECONDS (default 60 detik).
+func NewTaskExpiryJob(taskService service.TaskService) Job {
+	return Job{
+		Name:     "task-expiry",
+		Interval: IntervalFromEnv("TASK_EXPIRY_WORKER_INTERVAL_SECONDS", time.Minute),
+		Run: func(ctx context.Context) error {
+			_, err := taskService.ExpireOverdueTasks(ctx, time.Now())
+			return err
+		},
+	}
+}
+
+// NewSavingsGoalJob membuat job yang menandai target tabungan yang sudah tercapai, mengirim notifikasi,
+// dan menjalankan klaim otomatis. Interval dapat diatur lewat SAVINGS_GOAL_WORKER_INTERVAL_SECONDS (default 60 detik).
+func NewSavingsGoalJob(savingsGoalService service.SavingsGoalService) Job {
+	return Job{
+		Name:     "savings-goals",
+		Interval: IntervalFromEnv("SAVINGS_GOAL_WORKER_INTERVAL_SECONDS", time.Minute),
+		Run: func(ctx context.Context) error {
+			_, err := savingsGoalService.ProcessReachedGoals(ctx)
+			return err
+		},
+	}
+}
+
+// NewPointExpirationJob membuat job yang mencatat poin kedaluwarsa dan memperingatkan anak tentang poin yang akan kedaluwarsa.
+// Interval dapat diatur lewat POINT_EXPIRATION_WORKER_INTERVAL_SECONDS (default 3600 detik).
+func NewPointExpirationJob(pointExpirationService service.PointExpirationService) Job {
+	return Job{
+		Name:     "point-expiration",
+		Interval: IntervalFromEnv("POINT_EXPIRATION_WORKER_INTERVAL_SECONDS", time.Hour),
+		Run: func(ctx context.Context) error {
+			_, err := pointExpirationService.ProcessExpirations(ctx, time.Now())
+			return err
+		},
+	}
+}
+
+// NewInterestJob membuat job yang memposting bunga tabungan periode terakhir yang sudah selesai.
+// Interval dapat diatur lewat INTEREST_WORKER_INTERVAL_SECONDS (default 3600 detik).
+func NewInterestJob(interestService service.InterestService) Job {
+	return Job{
+		Name:     "interest",
+		Interval: IntervalFromEnv("INTEREST_WORKER_INTERVAL_SECONDS", time.Hour),
+		Run: func(ctx context.Context) error {
+			_, err := interestService.ProcessInterest(ctx, time.Now())
+			return err
+		},
+	}
+}
+
+// NewAllowanceJob membuat job yang membayar uang saku untuk setiap periode yang sudah selesai.
+// Interval dapat diatur lewat ALLOWANCE_WORKER_INTERVAL_SECONDS (default 300 detik).
+func NewAllowanceJob(allowanceService service.AllowanceService) Job {
+	return Job{
+		Name:     "allowance",
+		Interval: IntervalFromEnv("ALLOWANCE_WORKER_INTERVAL_SECONDS", 5*time.Minute),
+		Run: func(ctx context.Context) error {
+			_, err := allowanceService.ProcessAllowances(ctx, time.Now())
+			return err
+		},
+	}
+}
+
+// NewBadgeJob membuat job cadangan yang memberikan badge saldo poin yang terpenuhi karena entri ledger apa pun.
+// Badge sudah dievaluasi langsung setelah penulisan ledger yang menambah saldo (tugas, klaim, transfer, uang saku,
+// bunga, kontribusi tabungan, penyesuaian manual, penukaran mata uang,
+// refund cash-out, koreksi ledger); job ini menangkap sisanya (misal: evaluasi yang gagal).
+// Interval dapat diatur lewat BADGE_WORKER_INTERVAL_SECONDS (default 300 detik).
+func NewBadgeJob(badgeService service.BadgeService) Job {
+	return Job{
+		Name:     "badges",
+		Interval: IntervalFromEnv("BADGE_WORKER_INTERVAL_SECONDS", 5*time.Minute),
+		Run: func(ctx context.Context) error {
+			_, err := badgeService.ProcessBalanceBadges(ctx)
+			return err
+		},
+	}
+}
+
+// NewStreakFreezeJob membuat job yang memakai streak freeze anak untuk hari kemarin yang terlewat
+// (di zona waktu anak) agar streak yang masih berjalan tidak putus.
+// Interval dapat diatur lewat STREAK_FREEZE_WORKER_INTERVAL_SECONDS (default 300 detik).
+func NewStreakFreezeJob(streakService service.StreakService) Job {
+	return Job{
+		Name:     "streak-freezes",
+		Interval: IntervalFromEnv("STREAK_FREEZE_WORKER_INTERVAL_SECONDS", 5*time.Minute),
+		Run: func(ctx context.Context) error {
+			_, err := streakService.ProcessStreakFreezes(ctx)
+			return err
+		},
+	}
+}
@@ -0,0 +1,85 @@
+// internal/worker/scheduler.go
+package worker
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	zlog "github.com/rs/zerolog/log"
+)
+
+// File ini menyediakan scheduler sederhana untuk menjalankan pekerjaan (job) periodik
+// di background, misalnya memproses rotasi tugas yang jadwalnya sudah tiba.
+// Setiap job berjalan di goroutine sendiri dan berhenti ketika context dibatalkan.
+
+// Job adalah satu pekerjaan periodik yang dijalankan oleh Scheduler.
+type Job struct {
+	Name     string                          // Nama job (untuk log)
+	Interval time.Duration                   // Jeda antar eksekusi
+	Run      func(ctx context.Context) error // Fungsi yang dijalankan setiap interval
+}
+
+// Scheduler menjalankan kumpulan Job secara periodik.
+type Scheduler struct {
+	jobs []Job
+}
+
+// NewScheduler membuat instance Scheduler kosong.
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// Register menambahkan job ke scheduler. Harus dipanggil sebelum Start.
+func (s *Scheduler) Register(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start menjalankan semua job yang terdaftar di goroutine terpisah.
+// Fungsi ini tidak blocking; job berhenti ketika ctx dibatalkan.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		go s.runJob(ctx, job)
+		zlog.Info().Str("job", job.Name).Dur("interval", job.Interval).Msg("Worker: Background job started")
+	}
+}
+
+// runJob menjalankan satu job setiap interval sampai ctx dibatalkan.
+func (s *Scheduler) runJob(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			zlog.Info().Str("job", job.Name).Msg("Worker: Background job stopped")
+			return
+		case <-ticker.C:
+			s.execute(ctx, job)
+		}
+	}
+}
+
+// execute menjalankan job sekali dan memastikan panic tidak mematikan goroutine scheduler.
+func (s *Scheduler) execute(ctx context.Context, job Job) {
+	defer func() {
+		if p := recover(); p != nil {
+			zlog.Error().Str("job", job.Name).Msgf("Worker: Panic recovered in background job: %v", p)
+		}
+	}()
+
+	if err := job.Run(ctx); err != nil {
+		zlog.Error().Err(err).Str("job", job.Name).Msg("Worker: Background job run failed")
+	}
+}
+
+// IntervalFromEnv membaca interval (dalam detik) dari environment variable.
+// Mengembalikan nilai default jika variabel tidak diset atau tidak valid.
+func IntervalFromEnv(key string, defaultInterval time.Duration) time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || seconds <= 0 {
+		return defaultInterval
+	}
+	return time.Duration(seconds) * time.Second
+}
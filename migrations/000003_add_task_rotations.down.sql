@@ -0,0 +1,19 @@
+-- migrations/000003_add_task_rotations.down.sql
+
+-- Hapus Trigger DULU
+DROP TRIGGER IF EXISTS set_timestamp_task_rotations ON task_rotations;
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_task_rotations_created_by;
+DROP INDEX IF EXISTS idx_task_rotations_due;
+DROP INDEX IF EXISTS idx_task_rotation_members_rotation;
+DROP INDEX IF EXISTS idx_task_rotation_turns_rotation;
+
+-- Hapus Tabel (urutan sesuai dependensi)
+DROP TABLE IF EXISTS task_rotation_turns;
+DROP TABLE IF EXISTS task_rotation_members;
+DROP TABLE IF EXISTS task_rotations;
+
+-- Hapus Custom Type (ENUM)
+DROP TYPE IF EXISTS rotation_turn_status;
+DROP TYPE IF EXISTS rotation_cadence;
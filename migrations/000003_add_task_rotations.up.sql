@@ -0,0 +1,95 @@
+-- migrations/000003_add_task_rotations.up.sql
+
+-- Buat tipe ENUM untuk irama (cadence) rotasi dan status giliran
+CREATE TYPE rotation_cadence AS ENUM ('daily', 'weekly');
+CREATE TYPE rotation_turn_status AS ENUM ('assigned', 'skipped');
+
+-- Tabel grup rotasi: satu definisi tugas yang bergiliran di antara beberapa anak
+CREATE TABLE task_rotations (
+    id SERIAL PRIMARY KEY,
+    task_id INT NOT NULL,                                    -- Definisi tugas yang dirotasi
+    created_by_user_id INT NOT NULL,                         -- Parent yang membuat rotasi
+    cadence rotation_cadence NOT NULL DEFAULT 'daily',       -- Seberapa sering giliran berpindah
+    current_position INT NOT NULL DEFAULT 0,                 -- Posisi anak yang mendapat giliran berikutnya
+    next_rotation_at TIMESTAMPTZ NOT NULL,                   -- Waktu giliran berikutnya diberikan
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,                 -- Rotasi nonaktif tidak diproses scheduler
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_rotation_task
+        FOREIGN KEY(task_id)
+        REFERENCES tasks(id)
+        ON DELETE CASCADE, -- Jika definisi tugas dihapus, rotasinya ikut hilang
+
+    CONSTRAINT fk_rotation_creator
+        FOREIGN KEY(created_by_user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+-- Tabel anggota rotasi: urutan anak dalam satu rotasi
+CREATE TABLE task_rotation_members (
+    id SERIAL PRIMARY KEY,
+    rotation_id INT NOT NULL,
+    child_id INT NOT NULL,
+    position INT NOT NULL,                                   -- Urutan giliran (dimulai dari 0)
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_rotation_member_rotation
+        FOREIGN KEY(rotation_id)
+        REFERENCES task_rotations(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_rotation_member_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT unique_rotation_member UNIQUE (rotation_id, child_id),
+    -- DEFERRABLE agar dua posisi bisa ditukar (swap) dalam satu transaksi
+    CONSTRAINT unique_rotation_position UNIQUE (rotation_id, position) DEFERRABLE INITIALLY DEFERRED
+);
+
+-- Tabel riwayat giliran: siapa mendapat giliran apa dan kapan
+CREATE TABLE task_rotation_turns (
+    id SERIAL PRIMARY KEY,
+    rotation_id INT NOT NULL,
+    child_id INT NOT NULL,
+    user_task_id INT,                                        -- Penugasan yang dibuat untuk giliran ini (NULL jika dilewati)
+    status rotation_turn_status NOT NULL,
+    notes TEXT,
+    created_by_user_id INT,                                  -- Parent yang memicu giliran (NULL = scheduler otomatis)
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_rotation_turn_rotation
+        FOREIGN KEY(rotation_id)
+        REFERENCES task_rotations(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_rotation_turn_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_rotation_turn_user_task
+        FOREIGN KEY(user_task_id)
+        REFERENCES user_tasks(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT fk_rotation_turn_creator
+        FOREIGN KEY(created_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+);
+
+-- Index
+CREATE INDEX idx_task_rotations_created_by ON task_rotations (created_by_user_id);
+CREATE INDEX idx_task_rotations_due ON task_rotations (next_rotation_at) WHERE is_active;
+CREATE INDEX idx_task_rotation_members_rotation ON task_rotation_members (rotation_id);
+CREATE INDEX idx_task_rotation_turns_rotation ON task_rotation_turns (rotation_id);
+
+-- Trigger updated_at (menggunakan fungsi yang sudah ada)
+CREATE TRIGGER set_timestamp_task_rotations
+BEFORE UPDATE ON task_rotations
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
@@ -0,0 +1,16 @@
+-- migrations/000004_add_task_bounties.down.sql
+
+-- Hapus Trigger DULU
+DROP TRIGGER IF EXISTS set_timestamp_task_bounties ON task_bounties;
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_task_bounties_created_by;
+DROP INDEX IF EXISTS idx_task_bounties_status;
+DROP INDEX IF EXISTS idx_task_bounty_claims_bounty;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS task_bounty_claims;
+DROP TABLE IF EXISTS task_bounties;
+
+-- Hapus Custom Type (ENUM)
+DROP TYPE IF EXISTS bounty_status;
@@ -0,0 +1,67 @@
+-- migrations/000004_add_task_bounties.up.sql
+
+-- Buat tipe ENUM untuk status bounty
+CREATE TYPE bounty_status AS ENUM ('open', 'filled', 'cancelled');
+
+-- Tabel bounty: tugas terbuka yang bisa diklaim oleh anak mana pun dalam keluarga
+CREATE TABLE task_bounties (
+    id SERIAL PRIMARY KEY,
+    task_id INT NOT NULL,                                    -- Definisi tugas yang ditawarkan
+    created_by_user_id INT NOT NULL,                         -- Parent yang memposting bounty
+    max_claims INT NOT NULL DEFAULT 1,                       -- Jumlah anak maksimum yang boleh mengklaim
+    claims_count INT NOT NULL DEFAULT 0,                     -- Jumlah klaim yang sudah terjadi
+    status bounty_status NOT NULL DEFAULT 'open',
+    expires_at TIMESTAMPTZ,                                  -- Batas waktu klaim (NULL = tidak kedaluwarsa)
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_bounty_task
+        FOREIGN KEY(task_id)
+        REFERENCES tasks(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_bounty_creator
+        FOREIGN KEY(created_by_user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT chk_bounty_max_claims CHECK (max_claims > 0),
+    CONSTRAINT chk_bounty_claims_count CHECK (claims_count >= 0 AND claims_count <= max_claims)
+);
+
+-- Tabel klaim bounty: anak mana yang mengambil bounty dan penugasan yang dihasilkan
+CREATE TABLE task_bounty_claims (
+    id SERIAL PRIMARY KEY,
+    bounty_id INT NOT NULL,
+    child_id INT NOT NULL,
+    user_task_id INT,                                        -- Penugasan hasil klaim (mengikuti alur submit/verify biasa)
+    claimed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_bounty_claim_bounty
+        FOREIGN KEY(bounty_id)
+        REFERENCES task_bounties(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_bounty_claim_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_bounty_claim_user_task
+        FOREIGN KEY(user_task_id)
+        REFERENCES user_tasks(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT unique_bounty_claim_child UNIQUE (bounty_id, child_id) -- Satu anak hanya boleh klaim sekali
+);
+
+-- Index
+CREATE INDEX idx_task_bounties_created_by ON task_bounties (created_by_user_id);
+CREATE INDEX idx_task_bounties_status ON task_bounties (status);
+CREATE INDEX idx_task_bounty_claims_bounty ON task_bounty_claims (bounty_id);
+
+-- Trigger updated_at (menggunakan fungsi yang sudah ada)
+CREATE TRIGGER set_timestamp_task_bounties
+BEFORE UPDATE ON task_bounties
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
@@ -0,0 +1,23 @@
+-- migrations/000005_add_definition_categories.down.sql
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_rewards_search_vector;
+DROP INDEX IF EXISTS idx_rewards_tags;
+DROP INDEX IF EXISTS idx_rewards_created_by_category;
+DROP INDEX IF EXISTS idx_tasks_search_vector;
+DROP INDEX IF EXISTS idx_tasks_tags;
+DROP INDEX IF EXISTS idx_tasks_created_by_category;
+
+-- Hapus Kolom
+ALTER TABLE rewards
+    DROP COLUMN IF EXISTS search_vector,
+    DROP COLUMN IF EXISTS tags,
+    DROP COLUMN IF EXISTS category;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS search_vector,
+    DROP COLUMN IF EXISTS tags,
+    DROP COLUMN IF EXISTS category;
+
+-- Hapus Custom Type (ENUM)
+DROP TYPE IF EXISTS definition_category;
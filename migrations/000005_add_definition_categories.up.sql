@@ -0,0 +1,28 @@
+-- migrations/000005_add_definition_categories.up.sql
+
+-- Buat tipe ENUM untuk kategori definisi tugas & hadiah
+CREATE TYPE definition_category AS ENUM ('chores', 'homework', 'behaviour', 'health');
+
+-- Kategori, tag bebas, dan kolom pencarian full-text untuk definisi tugas
+ALTER TABLE tasks
+    ADD COLUMN category definition_category,                 -- NULL = tanpa kategori
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        to_tsvector('simple', coalesce(task_name, '') || ' ' || coalesce(task_description, ''))
+    ) STORED;
+
+-- Kategori, tag bebas, dan kolom pencarian full-text untuk definisi hadiah
+ALTER TABLE rewards
+    ADD COLUMN category definition_category,
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        to_tsvector('simple', coalesce(reward_name, '') || ' ' || coalesce(reward_description, ''))
+    ) STORED;
+
+-- Index
+CREATE INDEX idx_tasks_created_by_category ON tasks (created_by_user_id, category);
+CREATE INDEX idx_tasks_tags ON tasks USING GIN (tags);
+CREATE INDEX idx_tasks_search_vector ON tasks USING GIN (search_vector);
+CREATE INDEX idx_rewards_created_by_category ON rewards (created_by_user_id, category);
+CREATE INDEX idx_rewards_tags ON rewards USING GIN (tags);
+CREATE INDEX idx_rewards_search_vector ON rewards USING GIN (search_vector);
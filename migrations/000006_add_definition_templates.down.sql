@@ -0,0 +1,25 @@
+-- migrations/000006_add_definition_templates.down.sql
+
+-- Hapus Trigger DULU
+DROP TRIGGER IF EXISTS set_timestamp_definition_templates ON definition_templates;
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_rewards_source_template;
+DROP INDEX IF EXISTS idx_tasks_source_template;
+DROP INDEX IF EXISTS idx_definition_templates_kind_active;
+
+-- Hapus Kolom tautan template
+ALTER TABLE rewards
+    DROP COLUMN IF EXISTS source_template_version,
+    DROP COLUMN IF EXISTS source_template_id;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS source_template_version,
+    DROP COLUMN IF EXISTS source_template_id;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS definition_template_translations;
+DROP TABLE IF EXISTS definition_templates;
+
+-- Hapus Custom Type (ENUM)
+DROP TYPE IF EXISTS template_kind;
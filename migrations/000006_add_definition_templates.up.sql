@@ -0,0 +1,100 @@
+-- migrations/000006_add_definition_templates.up.sql
+
+-- Buat tipe ENUM untuk jenis template (menjadi definisi tugas atau hadiah)
+CREATE TYPE template_kind AS ENUM ('task', 'reward');
+
+-- Tabel katalog template (dikelola Admin, berlaku untuk seluruh sistem)
+CREATE TABLE definition_templates (
+    id SERIAL PRIMARY KEY,
+    kind template_kind NOT NULL,
+    category definition_category,                            -- Kategori (opsional)
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    suggested_points INT NOT NULL,                           -- Poin yang disarankan saat diimpor
+    min_age INT,                                             -- Usia minimum anak yang disarankan (NULL = tanpa batas)
+    max_age INT,                                             -- Usia maksimum anak yang disarankan (NULL = tanpa batas)
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,                 -- Template nonaktif tidak tampil untuk Parent
+    version INT NOT NULL DEFAULT 1,                          -- Naik setiap kali template diperbarui
+    created_by_user_id INT,                                  -- Admin pembuat (NULL untuk template bawaan)
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_template_creator
+        FOREIGN KEY(created_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT chk_template_points CHECK (suggested_points > 0),
+    CONSTRAINT chk_template_age_range CHECK (
+        (min_age IS NULL OR min_age >= 0) AND
+        (max_age IS NULL OR max_age >= 0) AND
+        (min_age IS NULL OR max_age IS NULL OR min_age <= max_age)
+    )
+);
+
+-- Tabel terjemahan template: nama & deskripsi per bahasa
+CREATE TABLE definition_template_translations (
+    template_id INT NOT NULL,
+    locale VARCHAR(10) NOT NULL,                             -- Kode bahasa, misal: 'en', 'id'
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+
+    PRIMARY KEY (template_id, locale),
+
+    CONSTRAINT fk_template_translation_template
+        FOREIGN KEY(template_id)
+        REFERENCES definition_templates(id)
+        ON DELETE CASCADE
+);
+
+-- Tautan definisi milik Parent ke template sumbernya
+ALTER TABLE tasks
+    ADD COLUMN source_template_id INT REFERENCES definition_templates(id) ON DELETE SET NULL,
+    ADD COLUMN source_template_version INT;                  -- Versi template saat diimpor
+
+ALTER TABLE rewards
+    ADD COLUMN source_template_id INT REFERENCES definition_templates(id) ON DELETE SET NULL,
+    ADD COLUMN source_template_version INT;
+
+-- Index
+CREATE INDEX idx_definition_templates_kind_active ON definition_templates (kind, is_active);
+CREATE INDEX idx_tasks_source_template ON tasks (source_template_id);
+CREATE INDEX idx_rewards_source_template ON rewards (source_template_id);
+
+-- Trigger updated_at (menggunakan fungsi yang sudah ada)
+CREATE TRIGGER set_timestamp_definition_templates
+BEFORE UPDATE ON definition_templates
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+-- Template bawaan (Inggris & Indonesia)
+WITH seeded AS (
+    INSERT INTO definition_templates (kind, category, tags, suggested_points, min_age, max_age)
+    VALUES
+        ('task',   'chores',    '{kitchen}',     10, 6,    NULL),
+        ('task',   'chores',    '{bedroom}',      5, 4,    NULL),
+        ('task',   'homework',  '{school}',      15, 6,    NULL),
+        ('task',   'health',    '{hygiene}',      5, 3,    10),
+        ('task',   'behaviour', '{reading}',     10, 5,    NULL),
+        ('reward', NULL,        '{screen-time}', 30, NULL, NULL),
+        ('reward', NULL,        '{outing}',     100, NULL, NULL)
+    RETURNING id, tags
+)
+INSERT INTO definition_template_translations (template_id, locale, name, description)
+SELECT s.id, t.locale, t.name, t.description
+FROM seeded s
+JOIN (VALUES
+    ('{kitchen}',     'en', 'Wash the dishes',            'Wash, dry and put away the dishes after a meal.'),
+    ('{kitchen}',     'id', 'Mencuci piring',             'Mencuci, mengeringkan, dan merapikan piring setelah makan.'),
+    ('{bedroom}',     'en', 'Tidy up your room',          'Make the bed and put toys and clothes back in place.'),
+    ('{bedroom}',     'id', 'Merapikan kamar',            'Merapikan tempat tidur dan mengembalikan mainan serta pakaian ke tempatnya.'),
+    ('{school}',      'en', 'Finish homework',            'Complete today''s homework before playtime.'),
+    ('{school}',      'id', 'Menyelesaikan PR',           'Menyelesaikan pekerjaan rumah hari ini sebelum bermain.'),
+    ('{hygiene}',     'en', 'Brush teeth twice',          'Brush teeth in the morning and before bed.'),
+    ('{hygiene}',     'id', 'Sikat gigi dua kali',        'Menyikat gigi di pagi hari dan sebelum tidur.'),
+    ('{reading}',     'en', 'Read for 20 minutes',        'Read a book of your choice for at least 20 minutes.'),
+    ('{reading}',     'id', 'Membaca 20 menit',           'Membaca buku pilihan sendiri minimal 20 menit.'),
+    ('{screen-time}', 'en', '30 minutes of screen time',  'Extra 30 minutes of games or videos.'),
+    ('{screen-time}', 'id', 'Waktu layar 30 menit',       'Tambahan 30 menit bermain gim atau menonton video.'),
+    ('{outing}',      'en', 'Family outing',              'Choose the destination for the next family outing.'),
+    ('{outing}',      'id', 'Jalan-jalan keluarga',       'Memilih tujuan jalan-jalan keluarga berikutnya.')
+) AS t(tags, locale, name, description) ON s.tags = t.tags::TEXT[];
@@ -0,0 +1,26 @@
+-- migrations/000007_add_auto_approval_and_audit_logs.down.sql
+
+-- Hapus Trigger DULU
+DROP TRIGGER IF EXISTS set_timestamp_auto_approval_policies ON auto_approval_policies;
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_audit_logs_created_at;
+DROP INDEX IF EXISTS idx_audit_logs_actor;
+DROP INDEX IF EXISTS idx_audit_logs_entity;
+DROP INDEX IF EXISTS idx_user_tasks_submitted;
+DROP INDEX IF EXISTS idx_auto_approval_policies_child;
+DROP INDEX IF EXISTS idx_auto_approval_policies_task;
+DROP INDEX IF EXISTS uq_auto_approval_policies_scope;
+
+-- Kembalikan NOT NULL. Transaksi sistem diatribusikan ke pemilik poin agar saldo tidak berubah.
+UPDATE point_transactions SET created_by_user_id = user_id WHERE created_by_user_id IS NULL;
+ALTER TABLE point_transactions
+    ALTER COLUMN created_by_user_id SET NOT NULL;
+
+-- Hapus Kolom
+ALTER TABLE user_tasks
+    DROP COLUMN IF EXISTS auto_approved;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS audit_logs;
+DROP TABLE IF EXISTS auto_approval_policies;
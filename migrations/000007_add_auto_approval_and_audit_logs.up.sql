@@ -0,0 +1,77 @@
+-- migrations/000007_add_auto_approval_and_audit_logs.up.sql
+
+-- Kebijakan auto-approval: submission tugas disetujui otomatis (langsung atau setelah masa tenggang)
+-- Berlaku per tugas, per anak, atau kombinasi keduanya.
+CREATE TABLE auto_approval_policies (
+    id SERIAL PRIMARY KEY,
+    created_by_user_id INT NOT NULL,                         -- Parent pemilik kebijakan
+    task_id INT,                                             -- Definisi tugas (NULL = semua tugas)
+    child_id INT,                                            -- Anak (NULL = semua anak Parent)
+    grace_period_minutes INT NOT NULL DEFAULT 0,             -- 0 = langsung disetujui saat submit
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_auto_approval_scope CHECK (task_id IS NOT NULL OR child_id IS NOT NULL),
+    CONSTRAINT chk_auto_approval_grace CHECK (grace_period_minutes >= 0),
+
+    CONSTRAINT fk_auto_approval_creator
+        FOREIGN KEY(created_by_user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_auto_approval_task
+        FOREIGN KEY(task_id)
+        REFERENCES tasks(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_auto_approval_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+-- Satu kebijakan per kombinasi parent + tugas + anak
+CREATE UNIQUE INDEX uq_auto_approval_policies_scope
+    ON auto_approval_policies (created_by_user_id, COALESCE(task_id, 0), COALESCE(child_id, 0));
+
+-- Tandai penugasan yang disetujui otomatis oleh sistem
+ALTER TABLE user_tasks
+    ADD COLUMN auto_approved BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Transaksi poin dari sistem (misal: auto-approval) tidak memiliki pembuat
+ALTER TABLE point_transactions
+    ALTER COLUMN created_by_user_id DROP NOT NULL;
+
+-- Jejak audit untuk tindakan penting (oleh pengguna maupun sistem)
+CREATE TABLE audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    actor_user_id INT,                                       -- Pengguna pelaku (NULL = sistem)
+    actor_type VARCHAR(20) NOT NULL,                         -- 'user' / 'system'
+    action VARCHAR(100) NOT NULL,                            -- Misal: 'user_task.approved'
+    entity_type VARCHAR(50) NOT NULL,                        -- Misal: 'user_task'
+    entity_id INT NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_audit_actor_type CHECK (actor_type IN ('user', 'system')),
+
+    CONSTRAINT fk_audit_actor
+        FOREIGN KEY(actor_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+);
+
+-- Index
+CREATE INDEX idx_auto_approval_policies_task ON auto_approval_policies (task_id) WHERE is_active;
+CREATE INDEX idx_auto_approval_policies_child ON auto_approval_policies (child_id) WHERE is_active;
+CREATE INDEX idx_user_tasks_submitted ON user_tasks (submitted_at) WHERE status = 'submitted';
+CREATE INDEX idx_audit_logs_entity ON audit_logs (entity_type, entity_id);
+CREATE INDEX idx_audit_logs_actor ON audit_logs (actor_user_id);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs (created_at);
+
+-- Trigger updated_at (menggunakan fungsi yang sudah ada)
+CREATE TRIGGER set_timestamp_auto_approval_policies
+BEFORE UPDATE ON auto_approval_policies
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
@@ -0,0 +1,17 @@
+-- migrations/000008_add_user_task_lifecycle_states.down.sql
+
+-- PostgreSQL tidak mendukung DROP VALUE pada ENUM, sehingga tipe dibuat ulang.
+-- Penugasan yang dibatalkan/kedaluwarsa dikembalikan ke status final terdekat ('rejected').
+UPDATE user_tasks SET status = 'rejected' WHERE status IN ('cancelled', 'expired');
+
+-- Hapus Index (predikatnya bergantung pada tipe lama)
+DROP INDEX IF EXISTS idx_user_tasks_submitted;
+
+-- Buat ulang Custom Type (ENUM)
+ALTER TYPE user_task_status RENAME TO user_task_status_old;
+CREATE TYPE user_task_status AS ENUM ('assigned', 'submitted', 'approved', 'rejected');
+ALTER TABLE user_tasks
+    ALTER COLUMN status TYPE user_task_status USING status::text::user_task_status;
+DROP TYPE user_task_status_old;
+
+CREATE INDEX idx_user_tasks_submitted ON user_tasks (submitted_at) WHERE status = 'submitted';
@@ -0,0 +1,7 @@
+-- migrations/000008_add_user_task_lifecycle_states.up.sql
+
+-- Status lifecycle baru untuk penugasan tugas.
+-- Dipisah dari migrasi tabel event karena nilai ENUM baru tidak boleh dipakai
+-- di transaksi yang sama dengan ALTER TYPE ... ADD VALUE.
+ALTER TYPE user_task_status ADD VALUE IF NOT EXISTS 'cancelled';
+ALTER TYPE user_task_status ADD VALUE IF NOT EXISTS 'expired';
@@ -0,0 +1,12 @@
+-- migrations/000009_add_user_task_events.down.sql
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_user_tasks_due;
+DROP INDEX IF EXISTS idx_user_task_events_user_task;
+
+-- Hapus Kolom
+ALTER TABLE user_tasks
+    DROP COLUMN IF EXISTS due_at;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS user_task_events;
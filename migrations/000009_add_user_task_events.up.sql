@@ -0,0 +1,53 @@
+-- migrations/000009_add_user_task_events.up.sql
+
+-- Batas waktu pengerjaan (opsional). Tugas 'assigned' yang melewati batas ini ditandai 'expired' oleh worker.
+ALTER TABLE user_tasks
+    ADD COLUMN due_at TIMESTAMPTZ;
+
+-- Riwayat transisi status setiap penugasan (timeline)
+CREATE TABLE user_task_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_task_id INT NOT NULL,
+    action VARCHAR(30) NOT NULL,                             -- assign, submit, approve, reject, revert, reassign, cancel, expire
+    from_status user_task_status,                            -- NULL untuk event 'assign'
+    to_status user_task_status NOT NULL,
+    actor_user_id INT,                                       -- NULL jika dilakukan oleh sistem
+    actor_type VARCHAR(10) NOT NULL DEFAULT 'user',
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_user_task_event_actor_type CHECK (actor_type IN ('user', 'system')),
+
+    CONSTRAINT fk_user_task_event_user_task
+        FOREIGN KEY(user_task_id)
+        REFERENCES user_tasks(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_user_task_event_actor
+        FOREIGN KEY(actor_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+);
+
+-- Index
+CREATE INDEX idx_user_task_events_user_task ON user_task_events (user_task_id, created_at);
+CREATE INDEX idx_user_tasks_due ON user_tasks (due_at) WHERE status = 'assigned' AND due_at IS NOT NULL;
+
+-- Backfill timeline dari kolom timestamp yang sudah ada
+INSERT INTO user_task_events (user_task_id, action, from_status, to_status, actor_user_id, actor_type, created_at)
+SELECT id, 'assign', NULL, 'assigned', assigned_by_user_id, 'user', COALESCE(assigned_at, created_at)
+FROM user_tasks;
+
+INSERT INTO user_task_events (user_task_id, action, from_status, to_status, actor_user_id, actor_type, created_at)
+SELECT id, 'submit', 'assigned', 'submitted', user_id, 'user', submitted_at
+FROM user_tasks
+WHERE submitted_at IS NOT NULL;
+
+INSERT INTO user_task_events (user_task_id, action, from_status, to_status, actor_user_id, actor_type, created_at)
+SELECT id,
+       CASE WHEN status = 'approved' THEN 'approve' ELSE 'reject' END,
+       'submitted', status, verified_by_user_id,
+       CASE WHEN verified_by_user_id IS NULL THEN 'system' ELSE 'user' END,
+       verified_at
+FROM user_tasks
+WHERE verified_at IS NOT NULL AND status IN ('approved', 'rejected');
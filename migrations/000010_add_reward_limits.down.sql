@@ -0,0 +1,12 @@
+-- migrations/000010_add_reward_limits.down.sql
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_user_rewards_user_reward_claimed;
+
+-- Hapus Kolom
+ALTER TABLE rewards
+    DROP CONSTRAINT IF EXISTS chk_rewards_claim_limit_period,
+    DROP COLUMN IF EXISTS cooldown_minutes,
+    DROP COLUMN IF EXISTS claim_limit_period,
+    DROP COLUMN IF EXISTS claim_limit,
+    DROP COLUMN IF EXISTS stock;
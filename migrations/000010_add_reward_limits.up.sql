@@ -0,0 +1,12 @@
+-- migrations/000010_add_reward_limits.up.sql
+
+-- Stok, kuota klaim per anak, dan cooldown untuk definisi hadiah
+ALTER TABLE rewards
+    ADD COLUMN stock INT CHECK (stock >= 0),                                                -- NULL = stok tak terbatas
+    ADD COLUMN claim_limit INT CHECK (claim_limit > 0),                                     -- NULL = tanpa kuota
+    ADD COLUMN claim_limit_period VARCHAR(10) CHECK (claim_limit_period IN ('day', 'week', 'month')),
+    ADD COLUMN cooldown_minutes INT NOT NULL DEFAULT 0 CHECK (cooldown_minutes >= 0),       -- 0 = tanpa cooldown
+    ADD CONSTRAINT chk_rewards_claim_limit_period CHECK ((claim_limit IS NULL) = (claim_limit_period IS NULL));
+
+-- Index untuk menghitung kuota & cooldown per anak per hadiah
+CREATE INDEX idx_user_rewards_user_reward_claimed ON user_rewards (user_id, reward_id, claimed_at DESC);
@@ -0,0 +1,12 @@
+-- migrations/000011_add_reward_fulfillment_states.down.sql
+
+-- PostgreSQL tidak mendukung DROP VALUE pada ENUM, sehingga tipe dibuat ulang.
+-- Klaim yang sudah dijadwalkan/diserahkan dikembalikan ke 'approved'.
+UPDATE user_rewards SET status = 'approved' WHERE status IN ('scheduled', 'fulfilled');
+
+-- Buat ulang Custom Type (ENUM)
+ALTER TYPE user_reward_status RENAME TO user_reward_status_old;
+CREATE TYPE user_reward_status AS ENUM ('pending', 'approved', 'rejected');
+ALTER TABLE user_rewards
+    ALTER COLUMN status TYPE user_reward_status USING status::text::user_reward_status;
+DROP TYPE user_reward_status_old;
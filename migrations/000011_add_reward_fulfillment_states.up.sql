@@ -0,0 +1,7 @@
+-- migrations/000011_add_reward_fulfillment_states.up.sql
+
+-- Tahap penyerahan hadiah setelah klaim disetujui (approved -> scheduled -> fulfilled).
+-- Dipisah dari migrasi tabel event karena nilai ENUM baru tidak boleh dipakai
+-- di transaksi yang sama dengan ALTER TYPE ... ADD VALUE.
+ALTER TYPE user_reward_status ADD VALUE IF NOT EXISTS 'scheduled';
+ALTER TYPE user_reward_status ADD VALUE IF NOT EXISTS 'fulfilled';
@@ -0,0 +1,14 @@
+-- migrations/000012_add_user_reward_events.down.sql
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_user_rewards_unfulfilled;
+DROP INDEX IF EXISTS idx_user_reward_events_user_reward;
+
+-- Hapus Kolom
+ALTER TABLE user_rewards
+    DROP COLUMN IF EXISTS received_at,
+    DROP COLUMN IF EXISTS fulfilled_at,
+    DROP COLUMN IF EXISTS scheduled_for;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS user_reward_events;
@@ -0,0 +1,51 @@
+-- migrations/000012_add_user_reward_events.up.sql
+
+-- Data penyerahan hadiah (semua opsional)
+ALTER TABLE user_rewards
+    ADD COLUMN scheduled_for TIMESTAMPTZ,                    -- Rencana tanggal penyerahan dari parent
+    ADD COLUMN fulfilled_at TIMESTAMPTZ,                     -- Waktu hadiah diserahkan
+    ADD COLUMN received_at TIMESTAMPTZ;                      -- Waktu anak mengonfirmasi sudah menerima
+
+-- Riwayat transisi status setiap klaim hadiah
+CREATE TABLE user_reward_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_reward_id INT NOT NULL,
+    action VARCHAR(30) NOT NULL,                             -- claim, approve, reject, schedule, fulfill, confirm_receipt
+    from_status user_reward_status,                          -- NULL untuk event 'claim'
+    to_status user_reward_status NOT NULL,
+    scheduled_for TIMESTAMPTZ,                               -- Tanggal penyerahan untuk event 'schedule'
+    actor_user_id INT,                                       -- NULL jika dilakukan oleh sistem
+    actor_type VARCHAR(10) NOT NULL DEFAULT 'user',
+    note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_user_reward_event_actor_type CHECK (actor_type IN ('user', 'system')),
+
+    CONSTRAINT fk_user_reward_event_user_reward
+        FOREIGN KEY(user_reward_id)
+        REFERENCES user_rewards(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_user_reward_event_actor
+        FOREIGN KEY(actor_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+);
+
+-- Index
+CREATE INDEX idx_user_reward_events_user_reward ON user_reward_events (user_reward_id, created_at);
+CREATE INDEX idx_user_rewards_unfulfilled ON user_rewards (reviewed_at) WHERE status IN ('approved', 'scheduled');
+
+-- Backfill riwayat dari kolom timestamp yang sudah ada
+INSERT INTO user_reward_events (user_reward_id, action, from_status, to_status, actor_user_id, actor_type, created_at)
+SELECT id, 'claim', NULL, 'pending', user_id, 'user', claimed_at
+FROM user_rewards;
+
+INSERT INTO user_reward_events (user_reward_id, action, from_status, to_status, actor_user_id, actor_type, created_at)
+SELECT id,
+       CASE WHEN status = 'approved' THEN 'approve' ELSE 'reject' END,
+       'pending', status, reviewed_by_user_id,
+       CASE WHEN reviewed_by_user_id IS NULL THEN 'system' ELSE 'user' END,
+       reviewed_at
+FROM user_rewards
+WHERE reviewed_at IS NOT NULL AND status IN ('approved', 'rejected');
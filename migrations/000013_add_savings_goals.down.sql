@@ -0,0 +1,20 @@
+-- migrations/000013_add_savings_goals.down.sql
+
+-- Hapus Trigger DULU
+DROP TRIGGER IF EXISTS set_timestamp_savings_goals ON savings_goals;
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_notifications_unread;
+DROP INDEX IF EXISTS idx_notifications_user;
+DROP INDEX IF EXISTS idx_savings_goal_contributions_goal;
+DROP INDEX IF EXISTS idx_savings_goals_open_reward;
+DROP INDEX IF EXISTS idx_savings_goals_open;
+DROP INDEX IF EXISTS idx_savings_goals_child;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS savings_goal_contributions;
+DROP TABLE IF EXISTS savings_goals;
+
+-- Hapus Custom Type (ENUM)
+DROP TYPE IF EXISTS savings_goal_status;
@@ -0,0 +1,93 @@
+-- migrations/000013_add_savings_goals.up.sql
+
+-- Buat tipe ENUM untuk status target tabungan
+CREATE TYPE savings_goal_status AS ENUM ('active', 'reached', 'claimed', 'cancelled');
+
+-- Tabel target tabungan (wishlist) anak: menyematkan Reward atau target kustom
+CREATE TABLE savings_goals (
+    id SERIAL PRIMARY KEY,
+    child_id INT NOT NULL,
+    reward_id INT,                                           -- Reward yang disematkan (NULL = target kustom)
+    goal_name VARCHAR(255) NOT NULL,
+    target_points INT NOT NULL,
+    earmarked_points INT NOT NULL DEFAULT 0,                 -- Poin yang disisihkan dan tidak bisa dipakai untuk klaim lain
+    auto_claim BOOLEAN NOT NULL DEFAULT FALSE,               -- Klaim Reward otomatis saat target tercapai
+    status savings_goal_status NOT NULL DEFAULT 'active',
+    reached_at TIMESTAMPTZ,
+    claimed_user_reward_id INT,                              -- Klaim hadiah yang menutup target ini
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_savings_goal_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_savings_goal_reward
+        FOREIGN KEY(reward_id)
+        REFERENCES rewards(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT fk_savings_goal_user_reward
+        FOREIGN KEY(claimed_user_reward_id)
+        REFERENCES user_rewards(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT chk_savings_goal_target CHECK (target_points > 0),
+    CONSTRAINT chk_savings_goal_earmark CHECK (earmarked_points >= 0 AND earmarked_points <= target_points)
+);
+
+-- Kontribusi bonus poin dari parent untuk sebuah target tabungan
+CREATE TABLE savings_goal_contributions (
+    id SERIAL PRIMARY KEY,
+    goal_id INT NOT NULL,
+    contributed_by_user_id INT,                              -- Parent pemberi kontribusi
+    points INT NOT NULL,
+    note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_savings_goal_contribution_goal
+        FOREIGN KEY(goal_id)
+        REFERENCES savings_goals(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_savings_goal_contribution_user
+        FOREIGN KEY(contributed_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT chk_savings_goal_contribution_points CHECK (points > 0)
+);
+
+-- Notifikasi in-app untuk pengguna (misal: target tabungan tercapai)
+CREATE TABLE notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    type VARCHAR(50) NOT NULL,                               -- Kode jenis notifikasi (savings_goal_reached, ...)
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    entity_type VARCHAR(50),                                 -- Entitas terkait (opsional)
+    entity_id INT,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_notification_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+-- Index
+CREATE INDEX idx_savings_goals_child ON savings_goals (child_id);
+CREATE INDEX idx_savings_goals_open ON savings_goals (child_id) WHERE status IN ('active', 'reached');
+CREATE UNIQUE INDEX idx_savings_goals_open_reward ON savings_goals (child_id, reward_id)
+    WHERE reward_id IS NOT NULL AND status IN ('active', 'reached'); -- Satu target terbuka per Reward
+CREATE INDEX idx_savings_goal_contributions_goal ON savings_goal_contributions (goal_id);
+CREATE INDEX idx_notifications_user ON notifications (user_id, created_at DESC);
+CREATE INDEX idx_notifications_unread ON notifications (user_id) WHERE read_at IS NULL;
+
+-- Trigger updated_at (menggunakan fungsi yang sudah ada)
+CREATE TRIGGER set_timestamp_savings_goals
+BEFORE UPDATE ON savings_goals
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
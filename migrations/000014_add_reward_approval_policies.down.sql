@@ -0,0 +1,8 @@
+-- migrations/000014_add_reward_approval_policies.down.sql
+
+-- Hapus Trigger DULU
+DROP TRIGGER IF EXISTS set_timestamp_reward_approval_policies ON reward_approval_policies;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS user_reward_votes;
+DROP TABLE IF EXISTS reward_approval_policies;
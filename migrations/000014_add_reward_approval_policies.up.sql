@@ -0,0 +1,52 @@
+-- migrations/000014_add_reward_approval_policies.up.sql
+
+-- Kebijakan persetujuan bersama per anak (berlaku untuk semua orang tua anak tersebut)
+CREATE TABLE reward_approval_policies (
+    child_id INT PRIMARY KEY,
+    points_threshold INT NOT NULL,                           -- Klaim di ATAS nilai ini butuh persetujuan bersama
+    required_approvals INT NOT NULL,                         -- Jumlah orang tua berbeda yang harus menyetujui
+    updated_by_user_id INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_reward_approval_policy_threshold CHECK (points_threshold >= 0),
+    CONSTRAINT chk_reward_approval_policy_required CHECK (required_approvals BETWEEN 2 AND 10),
+
+    CONSTRAINT fk_reward_approval_policy_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_reward_approval_policy_updated_by
+        FOREIGN KEY(updated_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+);
+
+-- Suara setiap orang tua untuk klaim yang membutuhkan persetujuan bersama
+CREATE TABLE user_reward_votes (
+    id SERIAL PRIMARY KEY,
+    user_reward_id INT NOT NULL,
+    parent_id INT NOT NULL,
+    vote user_reward_status NOT NULL,                        -- 'approved' atau 'rejected'
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_user_reward_vote CHECK (vote IN ('approved', 'rejected')),
+    CONSTRAINT uq_user_reward_vote_parent UNIQUE (user_reward_id, parent_id),
+
+    CONSTRAINT fk_user_reward_vote_user_reward
+        FOREIGN KEY(user_reward_id)
+        REFERENCES user_rewards(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_user_reward_vote_parent
+        FOREIGN KEY(parent_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+-- Trigger updated_at
+CREATE TRIGGER set_timestamp_reward_approval_policies
+BEFORE UPDATE ON reward_approval_policies
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
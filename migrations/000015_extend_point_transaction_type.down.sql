@@ -0,0 +1,13 @@
+-- migrations/000015_extend_point_transaction_type.down.sql
+
+-- PostgreSQL tidak mendukung DROP VALUE pada ENUM, sehingga tipe dibuat ulang.
+-- Transaksi dengan jenis baru dikembalikan ke 'manual_adjustment'.
+UPDATE point_transactions SET transaction_type = 'manual_adjustment'
+WHERE transaction_type IN ('reward_refund', 'task_reversal', 'penalty', 'allowance', 'transfer');
+
+-- Buat ulang Custom Type (ENUM)
+ALTER TYPE point_transaction_type RENAME TO point_transaction_type_old;
+CREATE TYPE point_transaction_type AS ENUM ('task_completion', 'reward_redemption', 'manual_adjustment');
+ALTER TABLE point_transactions
+    ALTER COLUMN transaction_type TYPE point_transaction_type USING transaction_type::text::point_transaction_type;
+DROP TYPE point_transaction_type_old;
@@ -0,0 +1,10 @@
+-- migrations/000015_extend_point_transaction_type.up.sql
+
+-- Jenis transaksi poin khusus agar pembalikan & mutasi lain tidak lagi menumpang 'manual_adjustment'.
+-- Dipisah dari migrasi kolom pembalikan karena nilai ENUM baru tidak boleh dipakai
+-- di transaksi yang sama dengan ALTER TYPE ... ADD VALUE.
+ALTER TYPE point_transaction_type ADD VALUE IF NOT EXISTS 'reward_refund';
+ALTER TYPE point_transaction_type ADD VALUE IF NOT EXISTS 'task_reversal';
+ALTER TYPE point_transaction_type ADD VALUE IF NOT EXISTS 'penalty';
+ALTER TYPE point_transaction_type ADD VALUE IF NOT EXISTS 'allowance';
+ALTER TYPE point_transaction_type ADD VALUE IF NOT EXISTS 'transfer';
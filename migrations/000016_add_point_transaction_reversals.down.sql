@@ -0,0 +1,11 @@
+-- migrations/000016_add_point_transaction_reversals.down.sql
+
+-- Kembalikan pembalikan ke format lama ('manual_adjustment' tanpa referensi)
+UPDATE point_transactions SET transaction_type = 'manual_adjustment'
+WHERE transaction_type IN ('reward_refund', 'task_reversal');
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_point_transactions_reverses;
+
+-- Hapus Kolom
+ALTER TABLE point_transactions DROP COLUMN IF EXISTS reverses_transaction_id;
@@ -0,0 +1,41 @@
+-- migrations/000016_add_point_transaction_reversals.up.sql
+
+-- Setiap pembalikan (refund klaim / pembatalan verifikasi tugas) menunjuk transaksi asli yang dibaliknya.
+ALTER TABLE point_transactions
+    ADD COLUMN reverses_transaction_id INT REFERENCES point_transactions(id) ON DELETE SET NULL;
+
+-- Satu transaksi hanya boleh dibalik satu kali
+CREATE UNIQUE INDEX idx_point_transactions_reverses ON point_transactions(reverses_transaction_id)
+    WHERE reverses_transaction_id IS NOT NULL;
+
+-- Migrasi data lama: refund klaim yang ditolak dicatat sebagai 'manual_adjustment' positif
+-- dengan related_user_reward_id; pasangkan dengan transaksi 'reward_redemption' klaim tersebut.
+UPDATE point_transactions r
+SET transaction_type = 'reward_refund',
+    reverses_transaction_id = (
+        SELECT o.id FROM point_transactions o
+        WHERE o.related_user_reward_id = r.related_user_reward_id
+          AND o.transaction_type = 'reward_redemption'
+          AND o.id < r.id
+        ORDER BY o.id DESC
+        LIMIT 1
+    )
+WHERE r.transaction_type = 'manual_adjustment'
+  AND r.related_user_reward_id IS NOT NULL
+  AND r.change_amount > 0;
+
+-- Pembatalan verifikasi tugas dicatat sebagai 'manual_adjustment' negatif dengan related_user_task_id;
+-- pasangkan dengan 'task_completion' terakhir sebelumnya untuk tugas yang sama.
+UPDATE point_transactions r
+SET transaction_type = 'task_reversal',
+    reverses_transaction_id = (
+        SELECT o.id FROM point_transactions o
+        WHERE o.related_user_task_id = r.related_user_task_id
+          AND o.transaction_type = 'task_completion'
+          AND o.id < r.id
+        ORDER BY o.id DESC
+        LIMIT 1
+    )
+WHERE r.transaction_type = 'manual_adjustment'
+  AND r.related_user_task_id IS NOT NULL
+  AND r.change_amount < 0;
@@ -0,0 +1,7 @@
+-- migrations/000017_add_point_balances.down.sql
+
+-- Hapus Trigger DULU
+DROP TRIGGER IF EXISTS set_timestamp_point_balances ON point_balances;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS point_balances;
@@ -0,0 +1,30 @@
+-- migrations/000017_add_point_balances.up.sql
+
+-- Saldo poin per anak yang dipelihara di transaksi yang sama dengan setiap insert ke point_transactions.
+-- point_transactions tetap menjadi sumber kebenaran; tabel ini bisa dihitung ulang lewat cmd/reconcile.
+CREATE TABLE point_balances (
+    user_id INT PRIMARY KEY,
+    balance INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_point_balance_non_negative CHECK (balance >= 0),
+
+    CONSTRAINT fk_point_balance_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+-- Isi awal dari ledger. Saldo ledger negatif (data lama) dipotong ke 0
+-- dan akan dilaporkan sebagai selisih oleh perintah rekonsiliasi.
+INSERT INTO point_balances (user_id, balance)
+SELECT user_id, GREATEST(SUM(change_amount), 0)
+FROM point_transactions
+GROUP BY user_id;
+
+-- Trigger untuk tabel point_balances
+CREATE TRIGGER set_timestamp_point_balances
+BEFORE UPDATE ON point_balances
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
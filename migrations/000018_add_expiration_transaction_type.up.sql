@@ -0,0 +1,6 @@
+-- migrations/000018_add_expiration_transaction_type.up.sql
+
+-- Jenis transaksi untuk poin yang kedaluwarsa.
+-- Dipisah dari migrasi tabel kebijakan karena nilai ENUM baru tidak boleh dipakai
+-- di transaksi yang sama dengan ALTER TYPE ... ADD VALUE.
+ALTER TYPE point_transaction_type ADD VALUE IF NOT EXISTS 'expiration';
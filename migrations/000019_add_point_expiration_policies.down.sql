@@ -0,0 +1,10 @@
+-- migrations/000019_add_point_expiration_policies.down.sql
+
+-- Hapus Trigger DULU
+DROP TRIGGER IF EXISTS set_timestamp_point_expiration_policies ON point_expiration_policies;
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_point_transactions_user_created;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS point_expiration_policies;
@@ -0,0 +1,34 @@
+-- migrations/000019_add_point_expiration_policies.up.sql
+
+-- Kebijakan kedaluwarsa poin per anak (berlaku untuk semua orang tua anak tersebut).
+-- Poin yang didapat kedaluwarsa setelah expire_after_days; pemakaian poin dihitung FIFO (lot tertua dulu).
+CREATE TABLE point_expiration_policies (
+    child_id INT PRIMARY KEY,
+    expire_after_days INT NOT NULL,                          -- Umur poin sejak didapat
+    warn_before_days INT NOT NULL DEFAULT 7,                 -- Anak diperingatkan sekian hari sebelum poin kedaluwarsa
+    updated_by_user_id INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_point_expiration_days CHECK (expire_after_days BETWEEN 1 AND 3650),
+    CONSTRAINT chk_point_expiration_warn CHECK (warn_before_days >= 0 AND warn_before_days < expire_after_days),
+
+    CONSTRAINT fk_point_expiration_policy_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_point_expiration_policy_updated_by
+        FOREIGN KEY(updated_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+);
+
+-- Index untuk pencarian lot poin per anak secara berurutan (FIFO)
+CREATE INDEX idx_point_transactions_user_created ON point_transactions(user_id, created_at, id);
+
+-- Trigger updated_at
+CREATE TRIGGER set_timestamp_point_expiration_policies
+BEFORE UPDATE ON point_expiration_policies
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
@@ -0,0 +1,6 @@
+-- migrations/000020_add_interest_transaction_type.up.sql
+
+-- Jenis transaksi untuk bunga tabungan poin.
+-- Dipisah dari migrasi tabel kebijakan karena nilai ENUM baru tidak boleh dipakai
+-- di transaksi yang sama dengan ALTER TYPE ... ADD VALUE.
+ALTER TYPE point_transaction_type ADD VALUE IF NOT EXISTS 'interest';
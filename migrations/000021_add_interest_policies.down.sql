@@ -0,0 +1,12 @@
+-- migrations/000021_add_interest_policies.down.sql
+
+-- Hapus Trigger DULU
+DROP TRIGGER IF EXISTS set_timestamp_interest_policies ON interest_policies;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS interest_postings;
+DROP TABLE IF EXISTS interest_policies;
+
+-- Hapus Custom Type (ENUM)
+DROP TYPE IF EXISTS interest_rounding;
+DROP TYPE IF EXISTS interest_schedule;
@@ -0,0 +1,59 @@
+-- migrations/000021_add_interest_policies.up.sql
+
+-- Buat tipe ENUM untuk jadwal pemajemukan dan aturan pembulatan bunga
+CREATE TYPE interest_schedule AS ENUM ('weekly', 'monthly');
+CREATE TYPE interest_rounding AS ENUM ('floor', 'round', 'ceil');
+
+-- Kebijakan bunga poin per anak (berlaku untuk semua orang tua anak tersebut)
+CREATE TABLE interest_policies (
+    child_id INT PRIMARY KEY,
+    rate_basis_points INT NOT NULL,                          -- Bunga per periode dalam basis poin (100 = 1%)
+    schedule interest_schedule NOT NULL DEFAULT 'monthly',   -- Periode pemajemukan
+    rounding interest_rounding NOT NULL DEFAULT 'floor',     -- Pembulatan bunga ke poin bulat
+    max_points_per_period INT NOT NULL DEFAULT 0,            -- Batas bunga per periode (0 = tanpa batas)
+    updated_by_user_id INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_interest_policy_rate CHECK (rate_basis_points BETWEEN 1 AND 10000),
+    CONSTRAINT chk_interest_policy_cap CHECK (max_points_per_period >= 0),
+
+    CONSTRAINT fk_interest_policy_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_interest_policy_updated_by
+        FOREIGN KEY(updated_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+);
+
+-- Catatan bunga yang sudah diposting per periode; primary key menjamin idempotensi
+-- (satu periode hanya diposting satu kali walau worker berjalan berulang/bersamaan).
+CREATE TABLE interest_postings (
+    child_id INT NOT NULL,
+    period_start TIMESTAMPTZ NOT NULL,                       -- Awal periode yang menghasilkan bunga (UTC)
+    balance INT NOT NULL,                                    -- Saldo yang menjadi dasar perhitungan
+    points INT NOT NULL,                                     -- Bunga yang diposting (bisa 0 setelah pembulatan)
+    point_transaction_id INT,                                -- Transaksi 'interest' terkait (NULL jika bunga 0)
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (child_id, period_start),
+
+    CONSTRAINT fk_interest_posting_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_interest_posting_transaction
+        FOREIGN KEY(point_transaction_id)
+        REFERENCES point_transactions(id)
+        ON DELETE SET NULL
+);
+
+-- Trigger updated_at
+CREATE TRIGGER set_timestamp_interest_policies
+BEFORE UPDATE ON interest_policies
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
@@ -0,0 +1,4 @@
+-- migrations/000022_add_user_timezones.down.sql
+
+-- Hapus Kolom
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,5 @@
+-- migrations/000022_add_user_timezones.up.sql
+
+-- Zona waktu IANA per pengguna (misal 'Asia/Jakarta'); dipakai untuk menentukan batas periode
+-- yang bergantung pada tanggal lokal, seperti jadwal uang saku.
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
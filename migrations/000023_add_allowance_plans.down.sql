@@ -0,0 +1,16 @@
+-- migrations/000023_add_allowance_plans.down.sql
+
+-- Hapus Trigger DULU
+DROP TRIGGER IF EXISTS set_timestamp_allowance_plans ON allowance_plans;
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_user_tasks_user_verified;
+DROP INDEX IF EXISTS idx_allowance_payouts_child;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS allowance_payouts;
+DROP TABLE IF EXISTS allowance_plans;
+
+-- Hapus Custom Type (ENUM)
+DROP TYPE IF EXISTS allowance_payout_status;
+DROP TYPE IF EXISTS allowance_cadence;
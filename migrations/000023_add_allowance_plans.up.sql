@@ -0,0 +1,77 @@
+-- migrations/000023_add_allowance_plans.up.sql
+
+-- Buat tipe ENUM untuk frekuensi uang saku dan status pembayaran per periode
+CREATE TYPE allowance_cadence AS ENUM ('weekly', 'biweekly', 'monthly');
+CREATE TYPE allowance_payout_status AS ENUM ('paid', 'skipped');
+
+-- Rencana uang saku otomatis (satu rencana per anak, dapat dikelola semua orang tua anak tersebut)
+CREATE TABLE allowance_plans (
+    id SERIAL PRIMARY KEY,
+    child_id INT NOT NULL UNIQUE,
+    parent_id INT,                                           -- Orang tua yang terakhir mengatur rencana (pemberi uang saku)
+    amount INT NOT NULL,                                     -- Poin yang dibayarkan per periode
+    cadence allowance_cadence NOT NULL,
+    start_date DATE NOT NULL,                                -- Awal periode pertama (tanggal lokal anak)
+    min_approved_tasks INT NOT NULL DEFAULT 0,               -- Syarat: minimal tugas disetujui dalam periode (0 = tanpa syarat)
+    next_period_start DATE NOT NULL,                         -- Awal periode berikutnya yang belum diproses
+    is_paused BOOLEAN NOT NULL DEFAULT FALSE,
+    paused_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_allowance_plan_amount CHECK (amount > 0),
+    CONSTRAINT chk_allowance_plan_min_tasks CHECK (min_approved_tasks >= 0),
+
+    CONSTRAINT fk_allowance_plan_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_allowance_plan_parent
+        FOREIGN KEY(parent_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+);
+
+-- Riwayat pembayaran uang saku per periode; unique (plan_id, period_start) menjamin setiap
+-- periode hanya diproses satu kali walau worker berjalan berulang/bersamaan.
+CREATE TABLE allowance_payouts (
+    id SERIAL PRIMARY KEY,
+    plan_id INT,
+    child_id INT NOT NULL,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,                                -- Eksklusif
+    amount INT NOT NULL,                                     -- Poin yang dibayarkan (0 jika dilewati)
+    status allowance_payout_status NOT NULL,
+    approved_tasks INT NOT NULL DEFAULT 0,                   -- Jumlah tugas disetujui dalam periode
+    reason TEXT,                                             -- Alasan periode dilewati
+    point_transaction_id INT,                                -- Transaksi 'allowance' terkait
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_allowance_payout_period UNIQUE (plan_id, period_start),
+
+    CONSTRAINT fk_allowance_payout_plan
+        FOREIGN KEY(plan_id)
+        REFERENCES allowance_plans(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT fk_allowance_payout_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_allowance_payout_transaction
+        FOREIGN KEY(point_transaction_id)
+        REFERENCES point_transactions(id)
+        ON DELETE SET NULL
+);
+
+-- Index
+CREATE INDEX idx_allowance_payouts_child ON allowance_payouts (child_id, period_start DESC);
+CREATE INDEX idx_user_tasks_user_verified ON user_tasks (user_id, verified_at) WHERE status = 'approved';
+
+-- Trigger updated_at
+CREATE TRIGGER set_timestamp_allowance_plans
+BEFORE UPDATE ON allowance_plans
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
@@ -0,0 +1,20 @@
+-- migrations/000024_add_point_transfers.down.sql
+
+-- Hapus Trigger DULU
+DROP TRIGGER IF EXISTS set_timestamp_point_transfers ON point_transfers;
+DROP TRIGGER IF EXISTS set_timestamp_transfer_policies ON transfer_policies;
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_point_transfers_pending;
+DROP INDEX IF EXISTS idx_point_transfers_to;
+DROP INDEX IF EXISTS idx_point_transfers_from;
+
+-- Hapus Kolom
+ALTER TABLE point_transactions DROP COLUMN IF EXISTS related_transfer_id;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS point_transfers;
+DROP TABLE IF EXISTS transfer_policies;
+
+-- Hapus Custom Type (ENUM)
+DROP TYPE IF EXISTS point_transfer_status;
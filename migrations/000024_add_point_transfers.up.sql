@@ -0,0 +1,94 @@
+-- migrations/000024_add_point_transfers.up.sql
+
+-- Buat tipe ENUM untuk status transfer poin antar saudara
+CREATE TYPE point_transfer_status AS ENUM ('pending', 'completed', 'rejected', 'cancelled');
+
+-- Kebijakan transfer poin per anak pengirim (berlaku untuk semua orang tua anak tersebut).
+-- Anak tanpa kebijakan boleh mentransfer, namun setiap transfer memerlukan persetujuan orang tua.
+CREATE TABLE transfer_policies (
+    child_id INT PRIMARY KEY,
+    allowed BOOLEAN NOT NULL DEFAULT TRUE,                   -- Anak boleh mengirim poin ke saudaranya
+    max_amount INT NOT NULL DEFAULT 0,                       -- Batas poin per transfer (0 = tanpa batas)
+    requires_approval BOOLEAN NOT NULL DEFAULT TRUE,         -- Transfer menunggu persetujuan orang tua
+    updated_by_user_id INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_transfer_policy_max_amount CHECK (max_amount >= 0),
+
+    CONSTRAINT fk_transfer_policy_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_transfer_policy_updated_by
+        FOREIGN KEY(updated_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+);
+
+-- Transfer poin antar saudara. Transfer yang selesai memiliki dua entri ledger 'transfer'
+-- (debit pengirim & kredit penerima) yang sama-sama menunjuk transfer ini.
+CREATE TABLE point_transfers (
+    id SERIAL PRIMARY KEY,
+    from_child_id INT NOT NULL,
+    to_child_id INT NOT NULL,
+    amount INT NOT NULL,
+    note VARCHAR(255),
+    status point_transfer_status NOT NULL DEFAULT 'pending',
+    reviewed_by_user_id INT,                                 -- Orang tua yang menyetujui/menolak (NULL jika otomatis)
+    reviewed_at TIMESTAMPTZ,
+    review_note VARCHAR(255),                                -- Alasan penolakan (opsional)
+    debit_transaction_id INT,
+    credit_transaction_id INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_point_transfer_amount CHECK (amount > 0),
+    CONSTRAINT chk_point_transfer_distinct CHECK (from_child_id <> to_child_id),
+
+    CONSTRAINT fk_point_transfer_from
+        FOREIGN KEY(from_child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_point_transfer_to
+        FOREIGN KEY(to_child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_point_transfer_reviewed_by
+        FOREIGN KEY(reviewed_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT fk_point_transfer_debit
+        FOREIGN KEY(debit_transaction_id)
+        REFERENCES point_transactions(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT fk_point_transfer_credit
+        FOREIGN KEY(credit_transaction_id)
+        REFERENCES point_transactions(id)
+        ON DELETE SET NULL
+);
+
+-- Tautan dari entri ledger ke transfer asalnya
+ALTER TABLE point_transactions
+    ADD COLUMN related_transfer_id INT REFERENCES point_transfers(id) ON DELETE SET NULL;
+
+-- Index
+CREATE INDEX idx_point_transfers_from ON point_transfers (from_child_id, created_at DESC);
+CREATE INDEX idx_point_transfers_to ON point_transfers (to_child_id, created_at DESC);
+CREATE INDEX idx_point_transfers_pending ON point_transfers (from_child_id) WHERE status = 'pending';
+
+-- Trigger updated_at
+CREATE TRIGGER set_timestamp_transfer_policies
+BEFORE UPDATE ON transfer_policies
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+CREATE TRIGGER set_timestamp_point_transfers
+BEFORE UPDATE ON point_transfers
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
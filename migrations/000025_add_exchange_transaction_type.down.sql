@@ -0,0 +1,15 @@
+-- migrations/000025_add_exchange_transaction_type.down.sql
+
+-- PostgreSQL tidak mendukung DROP VALUE pada ENUM, sehingga tipe dibuat ulang.
+-- Transaksi penukaran dikembalikan ke 'manual_adjustment'.
+UPDATE point_transactions SET transaction_type = 'manual_adjustment' WHERE transaction_type = 'exchange';
+
+-- Buat ulang Custom Type (ENUM)
+ALTER TYPE point_transaction_type RENAME TO point_transaction_type_old;
+CREATE TYPE point_transaction_type AS ENUM (
+    'task_completion', 'reward_redemption', 'manual_adjustment',
+    'reward_refund', 'task_reversal', 'penalty', 'allowance', 'transfer', 'expiration', 'interest'
+);
+ALTER TABLE point_transactions
+    ALTER COLUMN transaction_type TYPE point_transaction_type USING transaction_type::text::point_transaction_type;
+DROP TYPE point_transaction_type_old;
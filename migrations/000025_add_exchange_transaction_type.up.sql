@@ -0,0 +1,6 @@
+-- migrations/000025_add_exchange_transaction_type.up.sql
+
+-- Jenis transaksi untuk penukaran antar mata uang poin.
+-- Dipisah dari migrasi tabel mata uang karena nilai ENUM baru tidak boleh dipakai
+-- di transaksi yang sama dengan ALTER TYPE ... ADD VALUE.
+ALTER TYPE point_transaction_type ADD VALUE IF NOT EXISTS 'exchange';
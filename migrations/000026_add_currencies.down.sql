@@ -0,0 +1,23 @@
+-- migrations/000026_add_currencies.down.sql
+
+-- Hapus Trigger DULU
+DROP TRIGGER IF EXISTS set_timestamp_currency_exchange_rules ON currency_exchange_rules;
+DROP TRIGGER IF EXISTS set_timestamp_currency_balances ON currency_balances;
+DROP TRIGGER IF EXISTS set_timestamp_currencies ON currencies;
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_currency_exchange_rules_pair;
+
+-- Hapus Kolom
+-- Entri ledger mata uang tambahan tidak bisa diwakili sebagai poin, sehingga ikut dihapus.
+DELETE FROM point_transactions WHERE currency_id IS NOT NULL;
+ALTER TABLE point_transactions DROP COLUMN IF EXISTS currency_id;
+ALTER TABLE rewards DROP CONSTRAINT IF EXISTS fk_reward_currency;
+ALTER TABLE rewards DROP COLUMN IF EXISTS currency_id;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS fk_task_currency;
+ALTER TABLE tasks DROP COLUMN IF EXISTS currency_id;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS currency_exchange_rules;
+DROP TABLE IF EXISTS currency_balances;
+DROP TABLE IF EXISTS currencies;
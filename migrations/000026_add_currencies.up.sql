@@ -0,0 +1,114 @@
+-- migrations/000026_add_currencies.up.sql
+
+-- Mata uang tambahan milik parent (misal: bintang perilaku, koin tugas, menit layar).
+-- Seperti definisi tugas & hadiah, mata uang dimiliki parent pembuatnya dan berlaku untuk anak-anaknya.
+-- Mata uang utama ("poin") tidak disimpan di tabel ini: currency_id NULL berarti poin.
+CREATE TABLE currencies (
+    id SERIAL PRIMARY KEY,
+    created_by_user_id INT NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    symbol VARCHAR(16),                                      -- Simbol/emoji tampilan (opsional)
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_currency_name_per_owner UNIQUE (created_by_user_id, name),
+    -- Target foreign key komposit: tugas, hadiah & aturan tukar hanya boleh memakai mata uang milik pembuatnya
+    CONSTRAINT uq_currency_owner UNIQUE (id, created_by_user_id),
+
+    CONSTRAINT fk_currency_created_by
+        FOREIGN KEY(created_by_user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+-- Mata uang yang diberikan tugas / dibutuhkan hadiah (NULL = poin)
+ALTER TABLE tasks
+    ADD COLUMN currency_id INT,
+    ADD CONSTRAINT fk_task_currency
+        FOREIGN KEY (currency_id, created_by_user_id)
+        REFERENCES currencies(id, created_by_user_id)
+        ON DELETE RESTRICT;
+
+ALTER TABLE rewards
+    ADD COLUMN currency_id INT,
+    ADD CONSTRAINT fk_reward_currency
+        FOREIGN KEY (currency_id, created_by_user_id)
+        REFERENCES currencies(id, created_by_user_id)
+        ON DELETE RESTRICT;
+
+-- Mata uang setiap entri ledger (NULL = poin)
+ALTER TABLE point_transactions
+    ADD COLUMN currency_id INT REFERENCES currencies(id) ON DELETE RESTRICT;
+
+-- Saldo materialized per anak per mata uang tambahan (saldo poin tetap di point_balances).
+CREATE TABLE currency_balances (
+    user_id INT NOT NULL,
+    currency_id INT NOT NULL,
+    balance INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (user_id, currency_id),
+    CONSTRAINT chk_currency_balance_non_negative CHECK (balance >= 0),
+
+    CONSTRAINT fk_currency_balance_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_currency_balance_currency
+        FOREIGN KEY(currency_id)
+        REFERENCES currencies(id)
+        ON DELETE CASCADE
+);
+
+-- Aturan tukar milik parent: from_amount unit mata uang asal ditukar menjadi to_amount unit mata uang tujuan.
+CREATE TABLE currency_exchange_rules (
+    id SERIAL PRIMARY KEY,
+    created_by_user_id INT NOT NULL,
+    from_currency_id INT,                                    -- NULL = poin
+    to_currency_id INT,                                      -- NULL = poin
+    from_amount INT NOT NULL,
+    to_amount INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_exchange_rule_amounts CHECK (from_amount > 0 AND to_amount > 0),
+    CONSTRAINT chk_exchange_rule_distinct CHECK (from_currency_id IS DISTINCT FROM to_currency_id),
+
+    CONSTRAINT fk_exchange_rule_created_by
+        FOREIGN KEY(created_by_user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_exchange_rule_from
+        FOREIGN KEY(from_currency_id, created_by_user_id)
+        REFERENCES currencies(id, created_by_user_id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_exchange_rule_to
+        FOREIGN KEY(to_currency_id, created_by_user_id)
+        REFERENCES currencies(id, created_by_user_id)
+        ON DELETE CASCADE
+);
+
+-- Index
+-- Satu aturan per pasangan mata uang per parent (NULL/poin dianggap nilai 0)
+CREATE UNIQUE INDEX idx_currency_exchange_rules_pair
+    ON currency_exchange_rules (created_by_user_id, COALESCE(from_currency_id, 0), COALESCE(to_currency_id, 0));
+
+-- Trigger updated_at
+CREATE TRIGGER set_timestamp_currencies
+BEFORE UPDATE ON currencies
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+CREATE TRIGGER set_timestamp_currency_balances
+BEFORE UPDATE ON currency_balances
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+CREATE TRIGGER set_timestamp_currency_exchange_rules
+BEFORE UPDATE ON currency_exchange_rules
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
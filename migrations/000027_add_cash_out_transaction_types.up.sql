@@ -0,0 +1,7 @@
+-- migrations/000027_add_cash_out_transaction_types.up.sql
+
+-- Jenis transaksi untuk pencairan poin menjadi uang saku (cash-out) dan pengembaliannya saat ditolak.
+-- Dipisah dari migrasi tabel cash-out karena nilai ENUM baru tidak boleh dipakai
+-- di transaksi yang sama dengan ALTER TYPE ... ADD VALUE.
+ALTER TYPE point_transaction_type ADD VALUE IF NOT EXISTS 'cash_out';
+ALTER TYPE point_transaction_type ADD VALUE IF NOT EXISTS 'cash_out_refund';
@@ -0,0 +1,19 @@
+-- migrations/000028_add_cash_outs.down.sql
+
+-- Hapus Trigger DULU
+DROP TRIGGER IF EXISTS set_timestamp_cash_out_requests ON cash_out_requests;
+DROP TRIGGER IF EXISTS set_timestamp_cash_out_policies ON cash_out_policies;
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_money_transactions_child_paid_at;
+DROP INDEX IF EXISTS idx_cash_out_requests_pending;
+DROP INDEX IF EXISTS idx_cash_out_requests_child;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS money_transactions;
+DROP TABLE IF EXISTS cash_out_requests;
+DROP TABLE IF EXISTS cash_out_policies;
+
+-- Hapus Custom Type (ENUM)
+DROP TYPE IF EXISTS payout_method;
+DROP TYPE IF EXISTS cash_out_status;
@@ -0,0 +1,121 @@
+-- migrations/000028_add_cash_outs.up.sql
+
+-- Buat tipe ENUM untuk status permintaan cash-out dan cara pembayaran uang
+CREATE TYPE cash_out_status AS ENUM ('pending', 'approved', 'rejected');
+CREATE TYPE payout_method AS ENUM ('cash', 'transfer');
+
+-- Kurs pencairan poin per anak: setiap `points` poin bernilai `minor_units` satuan terkecil mata uang
+-- (misal 10 poin = 100 sen USD). Anak tanpa kebijakan tidak bisa mencairkan poin.
+CREATE TABLE cash_out_policies (
+    child_id INT PRIMARY KEY,
+    points INT NOT NULL,
+    minor_units INT NOT NULL,
+    currency_code CHAR(3) NOT NULL,                          -- Kode mata uang ISO 4217 (misal IDR, USD)
+    min_points INT NOT NULL DEFAULT 0,                       -- Minimal poin per permintaan (0 = tanpa minimum)
+    updated_by_user_id INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_cash_out_policy_rate CHECK (points > 0 AND minor_units > 0),
+    CONSTRAINT chk_cash_out_policy_currency CHECK (currency_code ~ '^[A-Z]{3}$'),
+    CONSTRAINT chk_cash_out_policy_min_points CHECK (min_points >= 0),
+
+    CONSTRAINT fk_cash_out_policy_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_cash_out_policy_updated_by
+        FOREIGN KEY(updated_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+);
+
+-- Permintaan pencairan poin oleh anak. Poin langsung dikurangi (entri 'cash_out') saat permintaan dibuat
+-- dan dikembalikan (entri 'cash_out_refund') jika ditolak. Nilai uang disimpan sesuai kurs saat permintaan.
+CREATE TABLE cash_out_requests (
+    id SERIAL PRIMARY KEY,
+    child_id INT NOT NULL,
+    points INT NOT NULL,
+    amount_minor BIGINT NOT NULL,                            -- Nilai uang dalam satuan terkecil mata uang
+    currency_code CHAR(3) NOT NULL,
+    note VARCHAR(255),
+    status cash_out_status NOT NULL DEFAULT 'pending',
+    reviewed_by_user_id INT,
+    reviewed_at TIMESTAMPTZ,
+    review_note VARCHAR(255),
+    debit_transaction_id INT,
+    refund_transaction_id INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_cash_out_request_amounts CHECK (points > 0 AND amount_minor > 0),
+
+    CONSTRAINT fk_cash_out_request_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_cash_out_request_reviewed_by
+        FOREIGN KEY(reviewed_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT fk_cash_out_request_debit
+        FOREIGN KEY(debit_transaction_id)
+        REFERENCES point_transactions(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT fk_cash_out_request_refund
+        FOREIGN KEY(refund_transaction_id)
+        REFERENCES point_transactions(id)
+        ON DELETE SET NULL
+);
+
+-- Ledger uang (terpisah dari ledger poin): satu entri per permintaan cash-out yang dibayarkan.
+-- Hanya ditambah, tidak pernah diubah.
+CREATE TABLE money_transactions (
+    id SERIAL PRIMARY KEY,
+    child_id INT NOT NULL,
+    cash_out_request_id INT NOT NULL UNIQUE,
+    amount_minor BIGINT NOT NULL,
+    currency_code CHAR(3) NOT NULL,
+    payout_method payout_method NOT NULL,                    -- 'cash' = dibayar tunai, 'transfer' = ditransfer
+    reference VARCHAR(100),                                  -- Referensi transfer bank/e-wallet (opsional)
+    paid_by_user_id INT,
+    paid_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_money_transaction_amount CHECK (amount_minor > 0),
+
+    CONSTRAINT fk_money_transaction_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_money_transaction_request
+        FOREIGN KEY(cash_out_request_id)
+        REFERENCES cash_out_requests(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT fk_money_transaction_paid_by
+        FOREIGN KEY(paid_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+);
+
+-- Index
+CREATE INDEX idx_cash_out_requests_child ON cash_out_requests (child_id, created_at DESC);
+CREATE INDEX idx_cash_out_requests_pending ON cash_out_requests (child_id) WHERE status = 'pending';
+CREATE INDEX idx_money_transactions_child_paid_at ON money_transactions (child_id, paid_at);
+
+-- Trigger updated_at
+CREATE TRIGGER set_timestamp_cash_out_policies
+BEFORE UPDATE ON cash_out_policies
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+CREATE TRIGGER set_timestamp_cash_out_requests
+BEFORE UPDATE ON cash_out_requests
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
@@ -0,0 +1,16 @@
+-- migrations/000029_add_correction_transaction_type.down.sql
+
+-- PostgreSQL tidak mendukung DROP VALUE pada ENUM, sehingga tipe dibuat ulang.
+-- Entri koreksi dikembalikan ke 'manual_adjustment'.
+UPDATE point_transactions SET transaction_type = 'manual_adjustment' WHERE transaction_type = 'correction';
+
+-- Buat ulang Custom Type (ENUM)
+ALTER TYPE point_transaction_type RENAME TO point_transaction_type_old;
+CREATE TYPE point_transaction_type AS ENUM (
+    'task_completion', 'reward_redemption', 'manual_adjustment',
+    'reward_refund', 'task_reversal', 'penalty', 'allowance', 'transfer', 'expiration', 'interest', 'exchange',
+    'cash_out', 'cash_out_refund'
+);
+ALTER TABLE point_transactions
+    ALTER COLUMN transaction_type TYPE point_transaction_type USING transaction_type::text::point_transaction_type;
+DROP TYPE point_transaction_type_old;
@@ -0,0 +1,7 @@
+-- migrations/000029_add_correction_transaction_type.up.sql
+
+-- Jenis transaksi untuk koreksi ledger oleh Admin. Ledger bersifat append-only,
+-- sehingga kesalahan hanya bisa diperbaiki dengan entri pembalik bertipe 'correction'.
+-- Dipisah dari migrasi integritas ledger karena nilai ENUM baru tidak boleh dipakai
+-- di transaksi yang sama dengan ALTER TYPE ... ADD VALUE.
+ALTER TYPE point_transaction_type ADD VALUE IF NOT EXISTS 'correction';
@@ -0,0 +1,30 @@
+-- migrations/000030_add_point_ledger_integrity.down.sql
+
+-- Hapus Trigger DULU
+DROP TRIGGER IF EXISTS point_ledger_reject_truncate ON point_transactions;
+DROP TRIGGER IF EXISTS point_ledger_reject_mutation ON point_transactions;
+DROP TRIGGER IF EXISTS point_ledger_chain_entry ON point_transactions;
+
+-- Kembalikan trigger updated_at
+CREATE TRIGGER set_timestamp_point_transactions
+BEFORE UPDATE ON point_transactions
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_point_transactions_user_seq;
+
+-- Hapus Kolom
+ALTER TABLE point_transactions
+    DROP COLUMN IF EXISTS entry_hash,
+    DROP COLUMN IF EXISTS prev_hash,
+    DROP COLUMN IF EXISTS seq;
+ALTER TABLE point_transactions ALTER COLUMN created_at DROP NOT NULL;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS point_ledger_heads;
+
+-- Hapus Function
+DROP FUNCTION IF EXISTS point_ledger_reject_mutation();
+DROP FUNCTION IF EXISTS point_ledger_chain_entry();
+DROP FUNCTION IF EXISTS point_ledger_entry_hash(INT, INT, BIGINT, INT, TEXT, INT, TIMESTAMPTZ, TEXT, TEXT);
@@ -0,0 +1,153 @@
+-- migrations/000030_add_point_ledger_integrity.up.sql
+
+-- Ledger poin dibuat append-only: entri yang sudah tercatat tidak boleh diubah atau dihapus,
+-- koreksi hanya lewat entri pembalik. Setiap entri juga dirantai per anak dengan hash SHA-256
+-- (entry_hash mencakup prev_hash entri sebelumnya) agar perubahan langsung di database
+-- maupun entri yang hilang bisa dideteksi lewat cmd/verifyledger atau GET /admin/ledger/verify.
+
+-- updated_at tidak lagi bermakna karena entri tidak pernah diubah
+DROP TRIGGER IF EXISTS set_timestamp_point_transactions ON point_transactions;
+
+-- created_at ikut di-hash, sehingga wajib terisi
+UPDATE point_transactions SET created_at = COALESCE(updated_at, CURRENT_TIMESTAMP) WHERE created_at IS NULL;
+ALTER TABLE point_transactions ALTER COLUMN created_at SET NOT NULL;
+
+ALTER TABLE point_transactions
+    ADD COLUMN seq BIGINT,           -- Nomor urut entri per anak (mulai dari 1, tanpa celah)
+    ADD COLUMN prev_hash CHAR(64),   -- entry_hash entri sebelumnya milik anak yang sama (64 nol untuk entri pertama)
+    ADD COLUMN entry_hash CHAR(64);  -- SHA-256 (hex) atas isi entri dan prev_hash
+
+-- Ujung rantai per anak: seq dan hash entri terakhir. Dipakai trigger untuk menyambung entri baru
+-- (baris dikunci sehingga insert bersamaan untuk anak yang sama berurutan) dan untuk mendeteksi
+-- entri terakhir yang dihapus.
+CREATE TABLE point_ledger_heads (
+    user_id INT PRIMARY KEY,
+    last_seq BIGINT NOT NULL DEFAULT 0,
+    last_hash CHAR(64) NOT NULL DEFAULT repeat('0', 64),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_point_ledger_head_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+-- Hash satu entri ledger. Format harus sama persis dengan ledgerEntryHash di internal/repository/ledger_repo.go.
+-- Kolom relasi (task, reward, transfer, pembuat, reverses_transaction_id) tidak ikut di-hash karena
+-- FK ON DELETE SET NULL boleh mengosongkannya. notes diletakkan terakhir agar pemisah '|' di dalamnya tidak ambigu.
+CREATE OR REPLACE FUNCTION point_ledger_entry_hash(
+    p_id INT, p_user_id INT, p_seq BIGINT, p_change_amount INT, p_transaction_type TEXT,
+    p_currency_id INT, p_created_at TIMESTAMPTZ, p_prev_hash TEXT, p_notes TEXT
+)
+RETURNS CHAR(64) AS $$
+    SELECT encode(sha256(convert_to(concat_ws('|',
+        p_id, p_user_id, p_seq, p_change_amount, p_transaction_type, COALESCE(p_currency_id, 0),
+        to_char(p_created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"'),
+        p_prev_hash, COALESCE(p_notes, '')
+    ), 'UTF8')), 'hex');
+$$ LANGUAGE sql STABLE;
+
+-- Rantai untuk data lama, urut per anak berdasarkan waktu pembuatan
+DO $$
+DECLARE
+    r RECORD;
+    v_user_id INT := NULL;
+    v_seq BIGINT := 0;
+    v_prev_hash CHAR(64);
+    v_entry_hash CHAR(64);
+BEGIN
+    FOR r IN
+        SELECT id, user_id, change_amount, transaction_type::TEXT AS transaction_type, currency_id, created_at, notes
+        FROM point_transactions
+        ORDER BY user_id, created_at, id
+    LOOP
+        IF v_user_id IS DISTINCT FROM r.user_id THEN
+            v_user_id := r.user_id;
+            v_seq := 0;
+            v_prev_hash := repeat('0', 64);
+        END IF;
+        v_seq := v_seq + 1;
+        v_entry_hash := point_ledger_entry_hash(r.id, r.user_id, v_seq, r.change_amount, r.transaction_type,
+                                                r.currency_id, r.created_at, v_prev_hash, r.notes);
+        UPDATE point_transactions SET seq = v_seq, prev_hash = v_prev_hash, entry_hash = v_entry_hash WHERE id = r.id;
+        v_prev_hash := v_entry_hash;
+    END LOOP;
+END $$;
+
+INSERT INTO point_ledger_heads (user_id, last_seq, last_hash)
+SELECT DISTINCT ON (user_id) user_id, seq, entry_hash
+FROM point_transactions
+ORDER BY user_id, seq DESC;
+
+ALTER TABLE point_transactions
+    ALTER COLUMN seq SET NOT NULL,
+    ALTER COLUMN prev_hash SET NOT NULL,
+    ALTER COLUMN entry_hash SET NOT NULL;
+
+CREATE UNIQUE INDEX idx_point_transactions_user_seq ON point_transactions(user_id, seq);
+
+-- Menyambung setiap entri baru ke ujung rantai anaknya. Nilai seq/prev_hash/entry_hash dari aplikasi diabaikan.
+CREATE OR REPLACE FUNCTION point_ledger_chain_entry()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.created_at := COALESCE(NEW.created_at, CURRENT_TIMESTAMP);
+
+    INSERT INTO point_ledger_heads (user_id) VALUES (NEW.user_id) ON CONFLICT (user_id) DO NOTHING;
+    SELECT last_seq + 1, last_hash INTO NEW.seq, NEW.prev_hash
+    FROM point_ledger_heads
+    WHERE user_id = NEW.user_id
+    FOR UPDATE;
+
+    NEW.entry_hash := point_ledger_entry_hash(NEW.id, NEW.user_id, NEW.seq, NEW.change_amount, NEW.transaction_type::TEXT,
+                                              NEW.currency_id, NEW.created_at, NEW.prev_hash, NEW.notes);
+
+    UPDATE point_ledger_heads
+    SET last_seq = NEW.seq, last_hash = NEW.entry_hash, updated_at = CURRENT_TIMESTAMP
+    WHERE user_id = NEW.user_id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Menolak UPDATE/DELETE/TRUNCATE pada ledger. Pengecualian hanya untuk aksi FK dari tabel lain
+-- (pg_trigger_depth() > 1): penghapusan akun anak (ON DELETE CASCADE dari users) dan pengosongan
+-- kolom relasi oleh ON DELETE SET NULL, selama kolom lain tidak berubah.
+CREATE OR REPLACE FUNCTION point_ledger_reject_mutation()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' AND pg_trigger_depth() > 1
+       AND NOT EXISTS (SELECT 1 FROM users WHERE id = OLD.user_id) THEN
+        RETURN OLD;
+    END IF;
+
+    IF TG_OP = 'UPDATE' AND pg_trigger_depth() > 1
+       AND (NEW.id, NEW.user_id, NEW.seq, NEW.change_amount, NEW.transaction_type, NEW.currency_id,
+            NEW.notes, NEW.created_at, NEW.updated_at, NEW.prev_hash, NEW.entry_hash)
+           IS NOT DISTINCT FROM
+           (OLD.id, OLD.user_id, OLD.seq, OLD.change_amount, OLD.transaction_type, OLD.currency_id,
+            OLD.notes, OLD.created_at, OLD.updated_at, OLD.prev_hash, OLD.entry_hash)
+       AND (NEW.related_user_task_id IS NULL OR NEW.related_user_task_id = OLD.related_user_task_id)
+       AND (NEW.related_user_reward_id IS NULL OR NEW.related_user_reward_id = OLD.related_user_reward_id)
+       AND (NEW.created_by_user_id IS NULL OR NEW.created_by_user_id = OLD.created_by_user_id)
+       AND (NEW.reverses_transaction_id IS NULL OR NEW.reverses_transaction_id = OLD.reverses_transaction_id)
+       AND (NEW.related_transfer_id IS NULL OR NEW.related_transfer_id = OLD.related_transfer_id) THEN
+        RETURN NEW;
+    END IF;
+
+    RAISE EXCEPTION 'point_transactions is append-only: % is not allowed, record a reversal entry instead', TG_OP;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER point_ledger_chain_entry
+BEFORE INSERT ON point_transactions
+FOR EACH ROW
+EXECUTE FUNCTION point_ledger_chain_entry();
+
+CREATE TRIGGER point_ledger_reject_mutation
+BEFORE UPDATE OR DELETE ON point_transactions
+FOR EACH ROW
+EXECUTE FUNCTION point_ledger_reject_mutation();
+
+CREATE TRIGGER point_ledger_reject_truncate
+BEFORE TRUNCATE ON point_transactions
+FOR EACH STATEMENT
+EXECUTE FUNCTION point_ledger_reject_mutation();
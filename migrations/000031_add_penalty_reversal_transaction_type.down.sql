@@ -0,0 +1,22 @@
+-- migrations/000031_add_penalty_reversal_transaction_type.down.sql
+
+-- PostgreSQL tidak mendukung DROP VALUE pada ENUM, sehingga tipe dibuat ulang.
+-- Ledger bersifat append-only (migrasi 000030), sehingga entri 'penalty_reversal' yang sudah tercatat
+-- tidak bisa diubah jenisnya: rollback ditolak selama entri tersebut masih ada.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM point_transactions WHERE transaction_type = 'penalty_reversal') THEN
+        RAISE EXCEPTION 'cannot drop point_transaction_type value penalty_reversal: the append-only ledger already contains such entries';
+    END IF;
+END $$;
+
+-- Buat ulang Custom Type (ENUM)
+ALTER TYPE point_transaction_type RENAME TO point_transaction_type_old;
+CREATE TYPE point_transaction_type AS ENUM (
+    'task_completion', 'reward_redemption', 'manual_adjustment',
+    'reward_refund', 'task_reversal', 'penalty', 'allowance', 'transfer', 'expiration', 'interest', 'exchange',
+    'cash_out', 'cash_out_refund', 'correction'
+);
+ALTER TABLE point_transactions
+    ALTER COLUMN transaction_type TYPE point_transaction_type USING transaction_type::text::point_transaction_type;
+DROP TYPE point_transaction_type_old;
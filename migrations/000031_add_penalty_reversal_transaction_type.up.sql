@@ -0,0 +1,6 @@
+-- migrations/000031_add_penalty_reversal_transaction_type.up.sql
+
+-- Jenis transaksi untuk poin sanksi yang dikembalikan karena anak menyelesaikan tugas penebus (earn-back).
+-- Dipisah dari migrasi tabel pelanggaran karena nilai ENUM baru tidak boleh dipakai
+-- di transaksi yang sama dengan ALTER TYPE ... ADD VALUE.
+ALTER TYPE point_transaction_type ADD VALUE IF NOT EXISTS 'penalty_reversal';
@@ -0,0 +1,14 @@
+-- migrations/000032_add_infractions.down.sql
+
+-- Hapus Trigger DULU
+DROP TRIGGER IF EXISTS set_timestamp_penalties ON penalties;
+DROP TRIGGER IF EXISTS set_timestamp_infraction_types ON infraction_types;
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_penalties_earn_back_user_task;
+DROP INDEX IF EXISTS idx_penalties_type_child_created;
+DROP INDEX IF EXISTS idx_penalties_child_created;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS penalties;
+DROP TABLE IF EXISTS infraction_types;
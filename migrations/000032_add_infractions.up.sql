@@ -0,0 +1,99 @@
+-- migrations/000032_add_infractions.up.sql
+
+-- Katalog pelanggaran milik parent (misal "Memukul saudara: -10", "Terlambat tidur: -5").
+-- Seperti definisi tugas & hadiah, jenis pelanggaran dimiliki parent pembuatnya dan berlaku untuk anak-anaknya.
+CREATE TABLE infraction_types (
+    id SERIAL PRIMARY KEY,
+    created_by_user_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    points INT NOT NULL,                                     -- Poin yang dipotong setiap kali diterapkan
+    max_per_day INT,                                         -- Batas penerapan per anak per hari (zona waktu anak); NULL = tanpa batas
+    earn_back_task_id INT,                                   -- Tugas penebus yang otomatis ditugaskan (opsional)
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_infraction_type_name_per_owner UNIQUE (created_by_user_id, name),
+    CONSTRAINT chk_infraction_type_points CHECK (points > 0),
+    CONSTRAINT chk_infraction_type_max_per_day CHECK (max_per_day IS NULL OR max_per_day > 0),
+
+    CONSTRAINT fk_infraction_type_created_by
+        FOREIGN KEY(created_by_user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_infraction_type_earn_back_task
+        FOREIGN KEY(earn_back_task_id)
+        REFERENCES tasks(id)
+        ON DELETE SET NULL
+);
+
+-- Sanksi yang diterapkan ke anak. Poin dipotong dengan entri ledger 'penalty' (dibatasi saldo anak,
+-- sehingga points_deducted bisa lebih kecil dari points). Jika jenis pelanggaran punya tugas penebus,
+-- tugas tersebut ditugaskan ke anak; saat disetujui, poin dikembalikan dengan entri 'penalty_reversal'.
+CREATE TABLE penalties (
+    id SERIAL PRIMARY KEY,
+    child_id INT NOT NULL,
+    infraction_type_id INT,
+    infraction_name VARCHAR(100) NOT NULL,                   -- Salinan nama saat diterapkan (tetap ada jika jenisnya dihapus)
+    points INT NOT NULL,                                     -- Poin sanksi sesuai jenis pelanggaran
+    points_deducted INT NOT NULL,                            -- Poin yang benar-benar dipotong dari saldo
+    notes VARCHAR(255),
+    applied_by_user_id INT,
+    transaction_id INT,                                      -- Entri ledger 'penalty' (NULL jika saldo anak 0)
+    earn_back_user_task_id INT,                              -- Penugasan tugas penebus (opsional)
+    earned_back_at TIMESTAMPTZ,                              -- Waktu tugas penebus disetujui
+    reversal_transaction_id INT,                             -- Entri ledger 'penalty_reversal'
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_penalty_points CHECK (points > 0 AND points_deducted >= 0 AND points_deducted <= points),
+
+    CONSTRAINT fk_penalty_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_penalty_infraction_type
+        FOREIGN KEY(infraction_type_id)
+        REFERENCES infraction_types(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT fk_penalty_applied_by
+        FOREIGN KEY(applied_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT fk_penalty_transaction
+        FOREIGN KEY(transaction_id)
+        REFERENCES point_transactions(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT fk_penalty_earn_back_user_task
+        FOREIGN KEY(earn_back_user_task_id)
+        REFERENCES user_tasks(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT fk_penalty_reversal_transaction
+        FOREIGN KEY(reversal_transaction_id)
+        REFERENCES point_transactions(id)
+        ON DELETE SET NULL
+);
+
+-- Index
+CREATE INDEX idx_penalties_child_created ON penalties(child_id, created_at);
+CREATE INDEX idx_penalties_type_child_created ON penalties(infraction_type_id, child_id, created_at);
+-- Satu penugasan tugas penebus hanya menebus satu sanksi
+CREATE UNIQUE INDEX idx_penalties_earn_back_user_task ON penalties(earn_back_user_task_id)
+    WHERE earn_back_user_task_id IS NOT NULL;
+
+-- Trigger updated_at
+CREATE TRIGGER set_timestamp_infraction_types
+BEFORE UPDATE ON infraction_types
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+CREATE TRIGGER set_timestamp_penalties
+BEFORE UPDATE ON penalties
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
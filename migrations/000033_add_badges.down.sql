@@ -0,0 +1,15 @@
+-- migrations/000033_add_badges.down.sql
+
+-- Hapus Trigger DULU
+DROP TRIGGER IF EXISTS set_timestamp_badges ON badges;
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_child_badges_child_awarded;
+DROP INDEX IF EXISTS idx_badges_created_by;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS child_badges;
+DROP TABLE IF EXISTS badges;
+
+-- Hapus Custom Type (ENUM)
+DROP TYPE IF EXISTS badge_rule_type;
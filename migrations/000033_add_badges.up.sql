@@ -0,0 +1,86 @@
+-- migrations/000033_add_badges.up.sql
+
+-- Aturan pemberian badge:
+--   tasks_completed : jumlah tugas yang disetujui (opsional hanya untuk task_id tertentu) >= threshold
+--   task_streak     : hari berturut-turut (zona waktu anak) dengan minimal satu tugas disetujui >= threshold
+--   points_balance  : saldo poin saat ini >= threshold
+--   rewards_claimed : jumlah klaim hadiah yang tidak ditolak >= threshold
+--   manual          : hanya diberikan langsung oleh parent
+CREATE TYPE badge_rule_type AS ENUM ('tasks_completed', 'task_streak', 'points_balance', 'rewards_claimed', 'manual');
+
+-- Badge sistem (created_by_user_id NULL, punya code) berlaku untuk semua anak;
+-- badge custom milik parent berlaku untuk anak-anaknya.
+CREATE TABLE badges (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) UNIQUE,                                 -- Kode badge sistem (NULL untuk badge custom)
+    created_by_user_id INT,                                  -- Parent pemilik badge custom (NULL = badge sistem)
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    icon VARCHAR(50),                                        -- Kode/emoji ikon untuk klien (opsional)
+    rule_type badge_rule_type NOT NULL,
+    threshold INT,                                           -- Ambang aturan (NULL untuk 'manual')
+    task_id INT,                                             -- Batasi tasks_completed/task_streak ke satu tugas (opsional)
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_badge_name_per_owner UNIQUE (created_by_user_id, name),
+    CONSTRAINT chk_badge_owner CHECK (code IS NULL OR created_by_user_id IS NULL),
+    CONSTRAINT chk_badge_threshold CHECK (
+        (rule_type = 'manual' AND threshold IS NULL) OR (rule_type <> 'manual' AND threshold > 0)
+    ),
+    CONSTRAINT chk_badge_task CHECK (task_id IS NULL OR rule_type IN ('tasks_completed', 'task_streak')),
+
+    CONSTRAINT fk_badge_created_by
+        FOREIGN KEY(created_by_user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_badge_task
+        FOREIGN KEY(task_id)
+        REFERENCES tasks(id)
+        ON DELETE CASCADE
+);
+
+-- Badge yang sudah diraih anak. Badge tidak dicabut lagi meskipun syaratnya kemudian tidak terpenuhi
+-- (misal: saldo turun atau verifikasi tugas dibatalkan).
+CREATE TABLE child_badges (
+    id SERIAL PRIMARY KEY,
+    child_id INT NOT NULL,
+    badge_id INT NOT NULL,
+    awarded_by_user_id INT,                                  -- Parent yang memberikan badge manual (NULL = otomatis)
+    awarded_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_child_badge UNIQUE (child_id, badge_id),
+
+    CONSTRAINT fk_child_badge_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_child_badge_badge
+        FOREIGN KEY(badge_id)
+        REFERENCES badges(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_child_badge_awarded_by
+        FOREIGN KEY(awarded_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+);
+
+-- Index
+CREATE INDEX idx_badges_created_by ON badges(created_by_user_id);
+CREATE INDEX idx_child_badges_child_awarded ON child_badges(child_id, awarded_at);
+
+-- Trigger updated_at
+CREATE TRIGGER set_timestamp_badges
+BEFORE UPDATE ON badges
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+-- Badge sistem
+INSERT INTO badges (code, name, description, icon, rule_type, threshold) VALUES
+    ('first_task', 'First Task', 'Completed your first task.', 'star', 'tasks_completed', 1),
+    ('streak_7', '7-Day Streak', 'Completed a task every day for 7 days in a row.', 'fire', 'task_streak', 7),
+    ('saver_100', 'Super Saver', 'Saved up 100 points.', 'piggy_bank', 'points_balance', 100),
+    ('rewards_10', 'Reward Collector', 'Claimed 10 rewards.', 'gift', 'rewards_claimed', 10);
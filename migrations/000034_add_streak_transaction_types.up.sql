@@ -0,0 +1,8 @@
+-- migrations/000034_add_streak_transaction_types.up.sql
+
+-- Jenis transaksi untuk bonus streak (poin tambahan saat anak mencapai streak tertentu)
+-- dan pembelian streak freeze dengan poin.
+-- Dipisah dari migrasi tabel streak karena nilai ENUM baru tidak boleh dipakai
+-- di transaksi yang sama dengan ALTER TYPE ... ADD VALUE.
+ALTER TYPE point_transaction_type ADD VALUE IF NOT EXISTS 'streak_bonus';
+ALTER TYPE point_transaction_type ADD VALUE IF NOT EXISTS 'streak_freeze';
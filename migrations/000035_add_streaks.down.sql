@@ -0,0 +1,19 @@
+-- migrations/000035_add_streaks.down.sql
+
+-- Hapus Trigger DULU
+DROP TRIGGER IF EXISTS set_timestamp_streak_freezes ON streak_freezes;
+DROP TRIGGER IF EXISTS set_timestamp_streak_freeze_policies ON streak_freeze_policies;
+DROP TRIGGER IF EXISTS set_timestamp_streak_bonus_rules ON streak_bonus_rules;
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_streak_freezes_child_used_on;
+DROP INDEX IF EXISTS idx_streak_freezes_unused;
+DROP INDEX IF EXISTS idx_streak_bonus_awards_user_task;
+DROP INDEX IF EXISTS idx_streak_bonus_awards_child;
+DROP INDEX IF EXISTS idx_streak_bonus_rules_created_by;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS streak_freezes;
+DROP TABLE IF EXISTS streak_freeze_policies;
+DROP TABLE IF EXISTS streak_bonus_awards;
+DROP TABLE IF EXISTS streak_bonus_rules;
@@ -0,0 +1,137 @@
+-- migrations/000035_add_streaks.up.sql
+
+-- Aturan bonus streak milik parent (misal "PR 5 hari berturut-turut: +20 poin").
+-- Streak dihitung dari tanggal penyelesaian user_tasks yang disetujui di zona waktu anak:
+-- task_id NULL = hari dengan minimal satu tugas disetujui, task_id terisi = hari tugas tersebut disetujui.
+CREATE TABLE streak_bonus_rules (
+    id SERIAL PRIMARY KEY,
+    created_by_user_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    task_id INT,                                             -- Tugas yang dihitung (NULL = semua tugas)
+    streak_days INT NOT NULL,                                -- Panjang streak yang memicu bonus
+    bonus_points INT NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_streak_bonus_rule_name_per_owner UNIQUE (created_by_user_id, name),
+    CONSTRAINT chk_streak_bonus_rule_days CHECK (streak_days BETWEEN 2 AND 365),
+    CONSTRAINT chk_streak_bonus_rule_points CHECK (bonus_points > 0),
+
+    CONSTRAINT fk_streak_bonus_rule_created_by
+        FOREIGN KEY(created_by_user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_streak_bonus_rule_task
+        FOREIGN KEY(task_id)
+        REFERENCES tasks(id)
+        ON DELETE CASCADE
+);
+
+-- Bonus streak yang sudah diberikan. Satu aturan memberi bonus sekali per streak (diidentifikasi
+-- dari tanggal mulai streak); setelah streak putus, streak baru bisa mendapat bonus lagi.
+CREATE TABLE streak_bonus_awards (
+    id SERIAL PRIMARY KEY,
+    rule_id INT NOT NULL,
+    child_id INT NOT NULL,
+    streak_start_date DATE NOT NULL,                         -- Hari pertama streak (zona waktu anak)
+    streak_days INT NOT NULL,                                -- Panjang streak saat bonus diberikan
+    bonus_points INT NOT NULL,
+    user_task_id INT,                                        -- Persetujuan tugas yang memicu bonus
+    transaction_id INT,                                      -- Entri ledger 'streak_bonus'
+    awarded_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_streak_bonus_award_per_streak UNIQUE (rule_id, child_id, streak_start_date),
+
+    CONSTRAINT fk_streak_bonus_award_rule
+        FOREIGN KEY(rule_id)
+        REFERENCES streak_bonus_rules(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_streak_bonus_award_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_streak_bonus_award_user_task
+        FOREIGN KEY(user_task_id)
+        REFERENCES user_tasks(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT fk_streak_bonus_award_transaction
+        FOREIGN KEY(transaction_id)
+        REFERENCES point_transactions(id)
+        ON DELETE SET NULL
+);
+
+-- Harga streak freeze per anak. Anak tanpa kebijakan tidak bisa membeli streak freeze.
+CREATE TABLE streak_freeze_policies (
+    child_id INT PRIMARY KEY,
+    price_points INT NOT NULL,
+    max_owned INT NOT NULL DEFAULT 1,                        -- Maksimal freeze belum terpakai yang boleh dimiliki
+    updated_by_user_id INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_streak_freeze_policy_price CHECK (price_points > 0),
+    CONSTRAINT chk_streak_freeze_policy_max_owned CHECK (max_owned BETWEEN 1 AND 10),
+
+    CONSTRAINT fk_streak_freeze_policy_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_streak_freeze_policy_updated_by
+        FOREIGN KEY(updated_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+);
+
+-- Streak freeze yang dibeli anak (entri ledger 'streak_freeze'). Freeze dipakai otomatis untuk hari
+-- yang terlewat (used_on) sehingga hari tersebut tetap dihitung dalam streak.
+CREATE TABLE streak_freezes (
+    id SERIAL PRIMARY KEY,
+    child_id INT NOT NULL,
+    price_points INT NOT NULL,
+    transaction_id INT,                                      -- Entri ledger 'streak_freeze'
+    purchased_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    used_on DATE,                                            -- Hari yang dilindungi (zona waktu anak); NULL = belum dipakai
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_streak_freeze_child
+        FOREIGN KEY(child_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_streak_freeze_transaction
+        FOREIGN KEY(transaction_id)
+        REFERENCES point_transactions(id)
+        ON DELETE SET NULL
+);
+
+-- Index
+CREATE INDEX idx_streak_bonus_rules_created_by ON streak_bonus_rules(created_by_user_id);
+CREATE INDEX idx_streak_bonus_awards_child ON streak_bonus_awards(child_id, awarded_at);
+CREATE INDEX idx_streak_bonus_awards_user_task ON streak_bonus_awards(user_task_id);
+CREATE INDEX idx_streak_freezes_unused ON streak_freezes(child_id) WHERE used_on IS NULL;
+-- Satu hari hanya dilindungi satu freeze
+CREATE UNIQUE INDEX idx_streak_freezes_child_used_on ON streak_freezes(child_id, used_on)
+    WHERE used_on IS NOT NULL;
+
+-- Trigger updated_at
+CREATE TRIGGER set_timestamp_streak_bonus_rules
+BEFORE UPDATE ON streak_bonus_rules
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+CREATE TRIGGER set_timestamp_streak_freeze_policies
+BEFORE UPDATE ON streak_freeze_policies
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+CREATE TRIGGER set_timestamp_streak_freezes
+BEFORE UPDATE ON streak_freezes
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
@@ -0,0 +1,20 @@
+-- migrations/000036_add_levels.down.sql
+
+-- Hapus Trigger DULU
+DROP TRIGGER IF EXISTS set_timestamp_user_xp ON user_xp;
+DROP TRIGGER IF EXISTS set_timestamp_levels ON levels;
+
+-- Hapus Index
+DROP INDEX IF EXISTS idx_level_ups_user;
+DROP INDEX IF EXISTS idx_xp_transactions_user_task;
+DROP INDEX IF EXISTS idx_xp_transactions_user;
+
+-- Hapus Kolom
+ALTER TABLE rewards
+    DROP COLUMN IF EXISTS min_level;
+
+-- Hapus Tabel
+DROP TABLE IF EXISTS level_ups;
+DROP TABLE IF EXISTS user_xp;
+DROP TABLE IF EXISTS xp_transactions;
+DROP TABLE IF EXISTS levels;
@@ -0,0 +1,122 @@
+-- migrations/000036_add_levels.up.sql
+
+-- Tangga level (sistem). Level anak ditentukan dari total XP: level tertinggi dengan xp_required <= total XP.
+CREATE TABLE levels (
+    level INT PRIMARY KEY,
+    xp_required INT NOT NULL,                                -- Total XP minimal untuk mencapai level ini
+    title VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_level_xp_required UNIQUE (xp_required),
+    CONSTRAINT chk_level_number CHECK (level >= 1),
+    CONSTRAINT chk_level_xp_required CHECK (xp_required >= 0)
+);
+
+INSERT INTO levels (level, xp_required, title) VALUES
+    (1, 0, 'Beginner'),
+    (2, 100, 'Helper'),
+    (3, 250, 'Go-Getter'),
+    (4, 500, 'Achiever'),
+    (5, 1000, 'Star'),
+    (6, 2000, 'Champion'),
+    (7, 3500, 'Hero'),
+    (8, 5500, 'Legend'),
+    (9, 8000, 'Master'),
+    (10, 12000, 'Grandmaster');
+
+-- Riwayat XP anak. XP terpisah dari poin: tidak bisa dibelanjakan dan tidak berkurang saat klaim hadiah.
+-- XP hanya bertambah dari tugas yang disetujui dan dibalik jika verifikasinya di-revert.
+CREATE TABLE xp_transactions (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    change_amount INT NOT NULL,
+    source VARCHAR(20) NOT NULL,                             -- 'task_completion' atau 'task_reversal'
+    related_user_task_id INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_xp_transaction_amount CHECK (change_amount <> 0),
+    CONSTRAINT chk_xp_transaction_source CHECK (source IN ('task_completion', 'task_reversal')),
+
+    CONSTRAINT fk_xp_transaction_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_xp_transaction_user_task
+        FOREIGN KEY(related_user_task_id)
+        REFERENCES user_tasks(id)
+        ON DELETE SET NULL
+);
+
+-- Total XP per anak (dijaga bersama xp_transactions dalam transaksi yang sama; baris dikunci saat diubah).
+CREATE TABLE user_xp (
+    user_id INT PRIMARY KEY,
+    total_xp INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_user_xp_total CHECK (total_xp >= 0),
+
+    CONSTRAINT fk_user_xp_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+-- Level-up yang pernah dicapai anak. Setiap level dicatat (dan dinotifikasi) sekali,
+-- walaupun XP sempat turun karena revert lalu naik lagi.
+CREATE TABLE level_ups (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    level INT NOT NULL,
+    total_xp INT NOT NULL,                                   -- Total XP saat level dicapai
+    user_task_id INT,                                        -- Persetujuan tugas yang memicu level-up
+    reached_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_level_up_per_user UNIQUE (user_id, level),
+
+    CONSTRAINT fk_level_up_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_level_up_user_task
+        FOREIGN KEY(user_task_id)
+        REFERENCES user_tasks(id)
+        ON DELETE SET NULL
+);
+
+-- XP awal dari tugas yang sudah disetujui sebelum fitur level ada (level-up lama dicatat tanpa notifikasi)
+INSERT INTO xp_transactions (user_id, change_amount, source, related_user_task_id, created_at)
+SELECT ut.user_id, GREATEST(t.task_point, 1), 'task_completion', ut.id, COALESCE(ut.completed_at, ut.updated_at, CURRENT_TIMESTAMP)
+FROM user_tasks ut
+JOIN tasks t ON t.id = ut.task_id
+WHERE ut.status = 'approved';
+
+INSERT INTO user_xp (user_id, total_xp)
+SELECT user_id, SUM(change_amount) FROM xp_transactions GROUP BY user_id;
+
+INSERT INTO level_ups (user_id, level, total_xp)
+SELECT ux.user_id, lv.level, ux.total_xp
+FROM user_xp ux
+JOIN levels lv ON lv.level > 1 AND lv.xp_required <= ux.total_xp;
+
+-- Perk level: hadiah dengan min_level > 1 baru bisa diklaim setelah anak mencapai level tersebut.
+ALTER TABLE rewards
+    ADD COLUMN min_level INT NOT NULL DEFAULT 1 CHECK (min_level BETWEEN 1 AND 100);         -- 1 = terbuka untuk semua level
+
+-- Index
+CREATE INDEX idx_xp_transactions_user ON xp_transactions(user_id, created_at);
+CREATE INDEX idx_xp_transactions_user_task ON xp_transactions(related_user_task_id);
+CREATE INDEX idx_level_ups_user ON level_ups(user_id, reached_at);
+
+-- Trigger updated_at
+CREATE TRIGGER set_timestamp_levels
+BEFORE UPDATE ON levels
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+CREATE TRIGGER set_timestamp_user_xp
+BEFORE UPDATE ON user_xp
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
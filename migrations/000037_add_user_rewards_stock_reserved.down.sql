@@ -0,0 +1,4 @@
+-- migrations/000037_add_user_rewards_stock_reserved.down.sql
+
+ALTER TABLE user_rewards
+    DROP COLUMN IF EXISTS stock_reserved;
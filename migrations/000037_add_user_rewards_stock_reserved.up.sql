@@ -0,0 +1,8 @@
+-- migrations/000037_add_user_rewards_stock_reserved.up.sql
+
+-- Menandai klaim yang benar-benar mengurangi stok hadiah saat dibuat.
+-- Hanya klaim dengan stock_reserved = TRUE yang mengembalikan stok saat ditolak, sehingga klaim
+-- yang dibuat saat stok masih NULL (atau sebelum stok ada) tidak menambah unit yang tidak pernah ada.
+-- Klaim lama tidak diketahui asal stoknya sehingga dianggap tidak mengurangi stok.
+ALTER TABLE user_rewards
+    ADD COLUMN stock_reserved BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,23 @@
+-- migrations/000038_make_reward_approval_policies_family_scoped.down.sql
+
+DROP INDEX IF EXISTS uq_reward_approval_policies_scope;
+
+-- Kembali ke satu kebijakan per anak: kebijakan untuk semua anak dan duplikat per anak dibuang
+DELETE FROM reward_approval_policies WHERE child_id IS NULL;
+DELETE FROM reward_approval_policies a USING reward_approval_policies b
+WHERE a.child_id = b.child_id AND a.id > b.id;
+
+ALTER TABLE reward_approval_policies ADD COLUMN updated_by_user_id INT;
+UPDATE reward_approval_policies SET updated_by_user_id = created_by_user_id;
+
+ALTER TABLE reward_approval_policies
+    DROP CONSTRAINT fk_reward_approval_policy_creator,
+    DROP COLUMN created_by_user_id,
+    DROP CONSTRAINT reward_approval_policies_pkey,
+    DROP COLUMN id,
+    ALTER COLUMN child_id SET NOT NULL,
+    ADD PRIMARY KEY (child_id),
+    ADD CONSTRAINT fk_reward_approval_policy_updated_by
+        FOREIGN KEY(updated_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL;
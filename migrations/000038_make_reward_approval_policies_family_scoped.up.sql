@@ -0,0 +1,26 @@
+-- migrations/000038_make_reward_approval_policies_family_scoped.up.sql
+
+-- Kebijakan persetujuan bersama menjadi milik parent: berlaku untuk semua anaknya (child_id NULL)
+-- atau satu anak, dan diresolusikan seperti auto_approval_policies (kebijakan khusus anak lebih dulu).
+ALTER TABLE reward_approval_policies DROP CONSTRAINT reward_approval_policies_pkey;
+ALTER TABLE reward_approval_policies ADD COLUMN id SERIAL PRIMARY KEY;
+ALTER TABLE reward_approval_policies ADD COLUMN created_by_user_id INT;
+
+-- Kebijakan lama dimiliki parent yang terakhir mengubahnya, atau parent pertama anak tersebut
+UPDATE reward_approval_policies p
+SET created_by_user_id = COALESCE(p.updated_by_user_id, (SELECT MIN(ur.parent_id) FROM user_relationship ur WHERE ur.child_id = p.child_id));
+DELETE FROM reward_approval_policies WHERE created_by_user_id IS NULL;
+
+ALTER TABLE reward_approval_policies
+    DROP CONSTRAINT fk_reward_approval_policy_updated_by,
+    DROP COLUMN updated_by_user_id,
+    ALTER COLUMN created_by_user_id SET NOT NULL,
+    ALTER COLUMN child_id DROP NOT NULL,
+    ADD CONSTRAINT fk_reward_approval_policy_creator
+        FOREIGN KEY(created_by_user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE;
+
+-- Satu kebijakan per kombinasi parent + anak (NULL = semua anak)
+CREATE UNIQUE INDEX uq_reward_approval_policies_scope
+    ON reward_approval_policies (created_by_user_id, COALESCE(child_id, 0));
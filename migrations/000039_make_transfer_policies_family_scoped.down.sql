@@ -0,0 +1,23 @@
+-- migrations/000039_make_transfer_policies_family_scoped.down.sql
+
+DROP INDEX IF EXISTS uq_transfer_policies_scope;
+
+-- Kembali ke satu kebijakan per anak: kebijakan untuk semua anak dan duplikat per anak dibuang
+DELETE FROM transfer_policies WHERE child_id IS NULL;
+DELETE FROM transfer_policies a USING transfer_policies b
+WHERE a.child_id = b.child_id AND a.id > b.id;
+
+ALTER TABLE transfer_policies ADD COLUMN updated_by_user_id INT;
+UPDATE transfer_policies SET updated_by_user_id = created_by_user_id;
+
+ALTER TABLE transfer_policies
+    DROP CONSTRAINT fk_transfer_policy_creator,
+    DROP COLUMN created_by_user_id,
+    DROP CONSTRAINT transfer_policies_pkey,
+    DROP COLUMN id,
+    ALTER COLUMN child_id SET NOT NULL,
+    ADD PRIMARY KEY (child_id),
+    ADD CONSTRAINT fk_transfer_policy_updated_by
+        FOREIGN KEY(updated_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL;
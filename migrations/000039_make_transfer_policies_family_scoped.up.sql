@@ -0,0 +1,26 @@
+-- migrations/000039_make_transfer_policies_family_scoped.up.sql
+
+-- Kebijakan transfer poin menjadi milik parent: berlaku untuk semua anaknya (child_id NULL)
+-- atau satu anak, dan diresolusikan seperti auto_approval_policies (kebijakan khusus anak lebih dulu).
+ALTER TABLE transfer_policies DROP CONSTRAINT transfer_policies_pkey;
+ALTER TABLE transfer_policies ADD COLUMN id SERIAL PRIMARY KEY;
+ALTER TABLE transfer_policies ADD COLUMN created_by_user_id INT;
+
+-- Kebijakan lama dimiliki parent yang terakhir mengubahnya, atau parent pertama anak tersebut
+UPDATE transfer_policies p
+SET created_by_user_id = COALESCE(p.updated_by_user_id, (SELECT MIN(ur.parent_id) FROM user_relationship ur WHERE ur.child_id = p.child_id));
+DELETE FROM transfer_policies WHERE created_by_user_id IS NULL;
+
+ALTER TABLE transfer_policies
+    DROP CONSTRAINT fk_transfer_policy_updated_by,
+    DROP COLUMN updated_by_user_id,
+    ALTER COLUMN created_by_user_id SET NOT NULL,
+    ALTER COLUMN child_id DROP NOT NULL,
+    ADD CONSTRAINT fk_transfer_policy_creator
+        FOREIGN KEY(created_by_user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE;
+
+-- Satu kebijakan per kombinasi parent + anak (NULL = semua anak)
+CREATE UNIQUE INDEX uq_transfer_policies_scope
+    ON transfer_policies (created_by_user_id, COALESCE(child_id, 0));
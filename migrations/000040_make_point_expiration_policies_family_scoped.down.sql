@@ -0,0 +1,23 @@
+-- migrations/000040_make_point_expiration_policies_family_scoped.down.sql
+
+DROP INDEX IF EXISTS uq_point_expiration_policies_scope;
+
+-- Kembali ke satu kebijakan per anak: kebijakan untuk semua anak dan duplikat per anak dibuang
+DELETE FROM point_expiration_policies WHERE child_id IS NULL;
+DELETE FROM point_expiration_policies a USING point_expiration_policies b
+WHERE a.child_id = b.child_id AND a.id > b.id;
+
+ALTER TABLE point_expiration_policies ADD COLUMN updated_by_user_id INT;
+UPDATE point_expiration_policies SET updated_by_user_id = created_by_user_id;
+
+ALTER TABLE point_expiration_policies
+    DROP CONSTRAINT fk_point_expiration_policy_creator,
+    DROP COLUMN created_by_user_id,
+    DROP CONSTRAINT point_expiration_policies_pkey,
+    DROP COLUMN id,
+    ALTER COLUMN child_id SET NOT NULL,
+    ADD PRIMARY KEY (child_id),
+    ADD CONSTRAINT fk_point_expiration_policy_updated_by
+        FOREIGN KEY(updated_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL;
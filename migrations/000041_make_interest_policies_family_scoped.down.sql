@@ -0,0 +1,23 @@
+-- migrations/000041_make_interest_policies_family_scoped.down.sql
+
+DROP INDEX IF EXISTS uq_interest_policies_scope;
+
+-- Kembali ke satu kebijakan per anak: kebijakan untuk semua anak dan duplikat per anak dibuang
+DELETE FROM interest_policies WHERE child_id IS NULL;
+DELETE FROM interest_policies a USING interest_policies b
+WHERE a.child_id = b.child_id AND a.id > b.id;
+
+ALTER TABLE interest_policies ADD COLUMN updated_by_user_id INT;
+UPDATE interest_policies SET updated_by_user_id = created_by_user_id;
+
+ALTER TABLE interest_policies
+    DROP CONSTRAINT fk_interest_policy_creator,
+    DROP COLUMN created_by_user_id,
+    DROP CONSTRAINT interest_policies_pkey,
+    DROP COLUMN id,
+    ALTER COLUMN child_id SET NOT NULL,
+    ADD PRIMARY KEY (child_id),
+    ADD CONSTRAINT fk_interest_policy_updated_by
+        FOREIGN KEY(updated_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL;
@@ -0,0 +1,26 @@
+-- migrations/000041_make_interest_policies_family_scoped.up.sql
+
+-- Kebijakan bunga poin menjadi milik parent: berlaku untuk semua anaknya (child_id NULL)
+-- atau satu anak, dan diresolusikan seperti auto_approval_policies (kebijakan khusus anak lebih dulu).
+ALTER TABLE interest_policies DROP CONSTRAINT interest_policies_pkey;
+ALTER TABLE interest_policies ADD COLUMN id SERIAL PRIMARY KEY;
+ALTER TABLE interest_policies ADD COLUMN created_by_user_id INT;
+
+-- Kebijakan lama dimiliki parent yang terakhir mengubahnya, atau parent pertama anak tersebut
+UPDATE interest_policies p
+SET created_by_user_id = COALESCE(p.updated_by_user_id, (SELECT MIN(ur.parent_id) FROM user_relationship ur WHERE ur.child_id = p.child_id));
+DELETE FROM interest_policies WHERE created_by_user_id IS NULL;
+
+ALTER TABLE interest_policies
+    DROP CONSTRAINT fk_interest_policy_updated_by,
+    DROP COLUMN updated_by_user_id,
+    ALTER COLUMN created_by_user_id SET NOT NULL,
+    ALTER COLUMN child_id DROP NOT NULL,
+    ADD CONSTRAINT fk_interest_policy_creator
+        FOREIGN KEY(created_by_user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE;
+
+-- Satu kebijakan per kombinasi parent + anak (NULL = semua anak)
+CREATE UNIQUE INDEX uq_interest_policies_scope
+    ON interest_policies (created_by_user_id, COALESCE(child_id, 0));
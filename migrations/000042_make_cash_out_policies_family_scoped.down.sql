@@ -0,0 +1,23 @@
+-- migrations/000042_make_cash_out_policies_family_scoped.down.sql
+
+DROP INDEX IF EXISTS uq_cash_out_policies_scope;
+
+-- Kembali ke satu kebijakan per anak: kebijakan untuk semua anak dan duplikat per anak dibuang
+DELETE FROM cash_out_policies WHERE child_id IS NULL;
+DELETE FROM cash_out_policies a USING cash_out_policies b
+WHERE a.child_id = b.child_id AND a.id > b.id;
+
+ALTER TABLE cash_out_policies ADD COLUMN updated_by_user_id INT;
+UPDATE cash_out_policies SET updated_by_user_id = created_by_user_id;
+
+ALTER TABLE cash_out_policies
+    DROP CONSTRAINT fk_cash_out_policy_creator,
+    DROP COLUMN created_by_user_id,
+    DROP CONSTRAINT cash_out_policies_pkey,
+    DROP COLUMN id,
+    ALTER COLUMN child_id SET NOT NULL,
+    ADD PRIMARY KEY (child_id),
+    ADD CONSTRAINT fk_cash_out_policy_updated_by
+        FOREIGN KEY(updated_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL;